@@ -1,30 +1,108 @@
 package main
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/brunoribeiro127/gobin/internal"
 	"github.com/brunoribeiro127/gobin/internal/gobin"
 	"github.com/brunoribeiro127/gobin/internal/manager"
 	"github.com/brunoribeiro127/gobin/internal/model"
+	"github.com/brunoribeiro127/gobin/internal/registry"
 	"github.com/brunoribeiro127/gobin/internal/system"
 	"github.com/brunoribeiro127/gobin/internal/toolchain"
 )
 
-// exitCodeSignalOffset is the offset for signal exit codes when terminates via
-// signal.
-const exitCodeSignalOffset = 128
+const (
+	// exitCodeSignalOffset is the offset for signal exit codes when terminates
+	// via signal.
+	exitCodeSignalOffset = 128
+	// exitCodeFailure is the exit code when a command fails for a reason not
+	// covered by the exit codes below.
+	exitCodeFailure = 1
+	// exitCodePartialFailure is the exit code when a command run on several
+	// binaries or packages fails for some of them only.
+	exitCodePartialFailure = 2
+	// exitCodeNotFound is the exit code when a binary, module, package or
+	// version is not found.
+	exitCodeNotFound = 3
+	// exitCodeNetwork is the exit code when the module proxies fail to respond.
+	exitCodeNetwork = 4
+	// exitCodePolicy is the exit code when a command is refused by a policy, ex.
+	// the deny list, the maximum download size or a protected binary.
+	exitCodePolicy = 5
+	// exitCodeBuildFailure is the exit code when a package fails to build.
+	exitCodeBuildFailure = 6
+	// exitCodeWarnings is the exit code when a command succeeds with warnings
+	// promoted to failures by the --strict flag.
+	exitCodeWarnings = 7
+	// exitCodeOutdated is the exit code when outdated binaries are found by the
+	// outdated command with the --exit-code flag.
+	exitCodeOutdated = 8
+	// pinSeparatorEnvVar is the environment variable to define the separator
+	// between the binary name and the version of a pin.
+	pinSeparatorEnvVar = "GOBIN_PIN_SEPARATOR"
+	// pinPlacementEnvVar is the environment variable to define the placement
+	// of the version in the name of a pin [suffix (default), prefix].
+	pinPlacementEnvVar = "GOBIN_PIN_PLACEMENT"
+	// goProxyEnvVar is the environment variable to define the module proxy
+	// used by the Go toolchain.
+	goProxyEnvVar = "GOPROXY"
+	// goProxyDirect is the GOPROXY value to fetch modules directly from their
+	// version control repositories.
+	goProxyDirect = "direct"
+	// goProxyFileScheme is the scheme of a GOPROXY value serving the modules
+	// from a directory, laid out as the download directory of a module cache.
+	goProxyFileScheme = "file://"
+	// goModCacheEnvVar is the environment variable to define the module cache
+	// directory used by the Go toolchain.
+	goModCacheEnvVar = "GOMODCACHE"
+	// goFlagsEnvVar is the environment variable to define the default flags of
+	// the go commands.
+	goFlagsEnvVar = "GOFLAGS"
+	// logFileName is the name of the log file in the internal log directory.
+	logFileName = "gobin.log"
+	// tracingShutdownTimeout is the maximum time to wait for the pending spans
+	// to be exported on exit.
+	tracingShutdownTimeout = 5 * time.Second
+	// releaseCheckTimeout is the maximum time to wait for the check for a new
+	// gobin release after a command.
+	releaseCheckTimeout = 5 * time.Second
+	// telemetryFileName is the name of the file in the internal base directory
+	// the usage metrics are appended to when no endpoint is configured.
+	telemetryFileName = "telemetry.jsonl"
+	// telemetryTimeout is the maximum time to wait for the usage metrics to be
+	// recorded after a command.
+	telemetryTimeout = 2 * time.Second
+)
+
+// exitCodeError is the error of a command exiting with the exit code of the
+// binary it runs, ex. gobin run, for gobin to exit with the same exit code.
+type exitCodeError struct {
+	code int
+}
+
+// Error returns the error message with the exit code.
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
 
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -73,44 +151,110 @@ func run(ctx context.Context) int {
 
 	workspace, err := system.NewWorkspace(env, fs, rt)
 	if err != nil {
-		return 1
+		return exitCodeFailure
+	}
+
+	config, err := getConfig(fs, workspace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: invalid config %s: %s\n", workspace.GetInternalConfigPath(), err.Error())
+		return exitCodeFailure
+	}
+
+	if config.StorePath != "" {
+		workspace.SetInternalBinPath(config.StorePath)
 	}
 
 	if err = workspace.Initialize(); err != nil {
-		return 1
+		return exitCodeFailure
+	}
+
+	var logWriter io.Writer
+	logFile, err := internal.OpenRotatingFile(
+		filepath.Join(workspace.GetInternalLogPath(), logFileName),
+		internal.LogFileMaxSize,
+		internal.LogFileBackups,
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: cannot open log file: %s\n", err.Error())
+	} else {
+		defer logFile.Close()
+		logWriter = logFile
+	}
+
+	pinFormat, err := getPinFormat(env, rt)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return exitCodeFailure
+	}
+
+	if _, ok := env.Get(goFlagsEnvVar); !ok && config.Defaults.GoFlags != "" {
+		if err = env.Set(goFlagsEnvVar, config.Defaults.GoFlags); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+			return exitCodeFailure
+		}
 	}
 
+	userPath := system.NewUserPath(env, exec, fs, rt)
+
 	gobin := gobin.NewGobin(
 		manager.NewGoBinaryManager(
+			registry.NewHTTPBinaryCache(),
 			fs,
+			registry.NewOCIRegistry(env),
 			rt,
-			toolchain.NewGoToolchain(
-				system.NewBuildInfo(),
-				exec,
-				toolchain.NewScanExecCombinedOutput,
+			toolchain.NewCachedToolchain(
+				toolchain.NewGoToolchain(
+					system.NewBuildInfo(),
+					exec,
+					toolchain.NewScanExecCombinedOutput,
+					config.Network,
+				),
+				fs,
+				workspace.GetInternalCachePath(),
+				config.Cache.TTL,
 			),
 			workspace,
+			pinFormat,
+			config.PinMode,
+			config.Signing,
 		),
+		config,
 		fs,
 		system.NewResource(exec, rt),
 		os.Stderr,
+		os.Stdin,
 		os.Stdout,
+		userPath,
 		workspace,
 	)
 
+	if execPath, execErr := os.Executable(); execErr == nil {
+		gobin.RemoveStaleExecutable(execPath)
+	}
+
 	var verbose bool
+	var quiet bool
+	var asJSON bool
+	var showAllWarnings bool
+	var strict bool
+	var noProgress bool
+	logFormat := internal.LogFormatText
 	var parallelism int
+	var goProxy string
+	var direct bool
+	var noCache bool
 
 	cmd := &cobra.Command{
 		Use:   "gobin",
 		Short: "gobin - CLI to manage Go binaries",
-		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 			level := slog.LevelError
 			if verbose {
 				level = slog.LevelInfo
 			}
 
-			slog.SetDefault(internal.NewLoggerWithLevel(level))
+			slog.SetDefault(internal.NewLoggerWithLevel(level, logFormat, logWriter))
+			slog.Default().Info("running command", "args", os.Args[1:])
 
 			if parallelism < 1 {
 				parallelismErr := errors.New("parallelism must be greater than 0")
@@ -118,6 +262,39 @@ func run(ctx context.Context) int {
 				return parallelismErr
 			}
 
+			if quiet && verbose {
+				quietErr := errors.New("cannot use --quiet with --verbose")
+				fmt.Fprintf(os.Stderr, "error: %s\n\n", quietErr.Error())
+				return quietErr
+			}
+
+			gobin.SetJSON(asJSON)
+			gobin.SetProgress(!noProgress && !verbose && isTerminal(os.Stderr))
+			gobin.SetQuiet(quiet)
+			gobin.SetShowAllWarnings(showAllWarnings)
+			gobin.SetStrict(strict)
+
+			if goProxy != "" && direct {
+				goProxyErr := errors.New("cannot use --goproxy with --direct")
+				fmt.Fprintf(os.Stderr, "error: %s\n\n", goProxyErr.Error())
+				return goProxyErr
+			}
+
+			if direct {
+				goProxy = goProxyDirect
+			}
+
+			if goProxy != "" {
+				if goProxyErr := env.Set(goProxyEnvVar, goProxy); goProxyErr != nil {
+					fmt.Fprintf(os.Stderr, "error: %s\n", goProxyErr.Error())
+					return goProxyErr
+				}
+			}
+
+			if noCache || goProxy != "" {
+				cmd.SetContext(toolchain.WithNoCache(cmd.Context()))
+			}
+
 			return nil
 		},
 	}
@@ -130,543 +307,3034 @@ func run(ctx context.Context) int {
 		"enable verbose output",
 	)
 
+	cmd.PersistentFlags().BoolVarP(
+		&quiet,
+		"quiet",
+		"q",
+		false,
+		"suppress all non-error output",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&asJSON,
+		"json",
+		config.Defaults.Output == model.OutputJSON,
+		"print the output of list, outdated, search, doctor, info, repo and env as JSON",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&showAllWarnings,
+		"show-all-warnings",
+		false,
+		"show the warnings already shown in the last day, ex. deprecated modules",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&strict,
+		"strict",
+		false,
+		"promote warnings to failures",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&noProgress,
+		"no-progress",
+		false,
+		"disable the progress line of install, upgrade and doctor, ex. in CI",
+	)
+
+	cmd.PersistentFlags().Var(
+		&logFormat,
+		"log-format",
+		"log format [text (default), json]",
+	)
+
 	cmd.PersistentFlags().IntVarP(
 		&parallelism,
 		"parallelism",
 		"p",
-		runtime.NumCPU(),
-		"number of concurrent operations (default: number of CPU cores)",
+		cmp.Or(config.Defaults.Parallelism, runtime.NumCPU()),
+		"number of concurrent operations (default: defaults.parallelism of the config, or number of CPU cores)",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&goProxy,
+		"goproxy",
+		"",
+		"module proxy to use instead of GOPROXY",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&direct,
+		"direct",
+		false,
+		"fetch modules directly from their repositories (GOPROXY=direct)",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&noCache,
+		"no-cache",
+		false,
+		"bypass the cached module lookups, requesting the module proxies",
 	)
 
+	cmd.AddCommand(newBugCmd(gobin, env, workspace))
+	cmd.AddCommand(newBuildMatrixCmd(gobin))
+	cmd.AddCommand(newBundleCmd(gobin))
+	cmd.AddCommand(newCacheCmd(gobin))
+	cmd.AddCommand(newConfigCmd(gobin))
+	cmd.AddCommand(newDebugInfoCmd(gobin, fs, workspace))
+	cmd.AddCommand(newDiffCmd(gobin, fs, workspace))
+	cmd.AddCommand(newDocsCmd(fs))
 	cmd.AddCommand(newDoctorCmd(gobin))
+	cmd.AddCommand(newDuCmd(gobin))
+	cmd.AddCommand(newEnvCmd(gobin, fs, workspace))
+	cmd.AddCommand(newExportCmd(gobin))
+	cmd.AddCommand(newGenerateCmd(gobin))
+	cmd.AddCommand(newHookCmd(gobin, userPath))
 	cmd.AddCommand(newInfoCmd(gobin, fs, workspace))
-	cmd.AddCommand(newInstallCmd(gobin))
+	cmd.AddCommand(newInitCmd(gobin))
+	cmd.AddCommand(newInstallCmd(gobin, env))
+	cmd.AddCommand(newLinkCmd(gobin))
 	cmd.AddCommand(newListCmd(gobin))
 	cmd.AddCommand(newMigrateCmd(gobin, fs, workspace))
 	cmd.AddCommand(newOutdatedCmd(gobin))
 	cmd.AddCommand(newPinCmd(gobin, fs, workspace))
+	cmd.AddCommand(newProtectCmd(gobin, fs, workspace))
 	cmd.AddCommand(newPruneCmd(gobin, fs, workspace))
+	cmd.AddCommand(newPullCmd(gobin))
+	cmd.AddCommand(newPushCmd(gobin, fs, workspace))
+	cmd.AddCommand(newRelinkCmd(gobin, fs, workspace))
 	cmd.AddCommand(newRepoCmd(gobin, fs, workspace))
+	cmd.AddCommand(newReproduceCmd(gobin, fs, workspace))
+	cmd.AddCommand(newRollbackCmd(gobin, fs, workspace))
+	cmd.AddCommand(newRunCmd(gobin, exec))
+	cmd.AddCommand(newSBOMCmd(gobin, fs, workspace))
+	cmd.AddCommand(newSearchCmd(gobin))
+	cmd.AddCommand(newSelfUpdateCmd(gobin))
+	cmd.AddCommand(newServeCacheCmd(gobin))
+	cmd.AddCommand(newSizeCmd(gobin, fs, workspace))
+	cmd.AddCommand(newSyncCmd(gobin))
 	cmd.AddCommand(newUninstallCmd(gobin, fs, workspace))
+	cmd.AddCommand(newUnprotectCmd(gobin, fs, workspace))
 	cmd.AddCommand(newUpgradeCmd(gobin, fs, workspace))
+	cmd.AddCommand(newUseCmd(gobin, fs, workspace))
+	cmd.AddCommand(newVerifyPathCmd(gobin))
 	cmd.AddCommand(newVersionCmd(gobin))
+	cmd.AddCommand(newWatchCmd(gobin))
+	cmd.AddCommand(newWhyCmd(gobin, fs, workspace))
+
+	if config.Defaults.Kind != "" {
+		setKindDefault(cmd, config.Defaults.Kind)
+	}
+
+	cmd.InitDefaultCompletionCmd()
+	for _, subCmd := range cmd.Commands() {
+		if subCmd.Name() == "completion" {
+			subCmd.AddCommand(newCompletionInstallCmd(gobin, userPath))
+		}
+	}
+
+	slog.SetDefault(internal.NewLoggerWithLevel(slog.LevelError, logFormat, logWriter))
+
+	if endpoint, ok := env.Get(internal.TracingEndpointEnvVar); ok && endpoint != "" {
+		shutdown, tracingErr := internal.SetupTracing(ctx)
+		if tracingErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: cannot set up tracing: %s\n", tracingErr.Error())
+		} else {
+			defer shutdownTracing(shutdown)
+		}
+	}
+
+	ctx, span := internal.StartSpan(ctx, "gobin", attribute.StringSlice("gobin.args", os.Args[1:]))
+	defer span.End()
+
+	executedCmd, err := cmd.ExecuteContextC(ctx)
+	if err != nil {
+		_ = internal.RecordSpanError(span, err)
+		slog.Default().Warn("command failed", "args", os.Args[1:], "err", err)
 
-	if err = cmd.ExecuteContext(ctx); err != nil {
-		return 1
+		exitCode := getExitCode(err)
+		recordUsage(ctx, config.Telemetry, workspace, executedCmd, exitCode)
+		return exitCode
 	}
 
+	if !verbose {
+		// errors of the release check are written to the log file only
+		slog.SetDefault(internal.NewFileLogger(logWriter))
+	}
+
+	recordUsage(ctx, config.Telemetry, workspace, executedCmd, 0)
+	notifyNewRelease(ctx, gobin, executedCmd)
+
 	return 0
 }
 
-// newDoctorCmd creates a doctor command to diagnose issues with installed
-// binaries.
-func newDoctorCmd(gobin *gobin.Gobin) *cobra.Command {
-	return &cobra.Command{
-		Use:   "doctor",
-		Short: "Diagnose issues for installed binaries",
-		Long: `Diagnose common issues with installed Go binaries.
+// newBugCmd creates a bug command to generate a diagnostics bundle for issue
+// reports.
+func newBugCmd(gobin *gobin.Gobin, env system.Environment, workspace system.Workspace) *cobra.Command {
+	var output string
 
-Checks for:
-  • Binaries not in PATH
-  • Duplicate binaries in PATH
-  • Binaries not managed by gobin
-  • Pseudo-versions and orphaned binaries
-  • Binaries built without Go modules
-  • Go version mismatches
-  • Platform mismatches (OS/architecture)
-  • Retracted or deprecated modules
-  • Known security vulnerabilities
+	cmd := &cobra.Command{
+		Use:   "bug",
+		Short: "Generate a diagnostics bundle for issue reports",
+		Long: `Generate a zip file to attach to issue reports, collecting the gobin version, the configuration, the
+Go related environment variables, the recent logs and the last operations. Secrets in environment variables
+and URL credentials are redacted, review the bundle before sharing it. If --output flag is specified, the
+bundle is written to the given path instead of gobin-bug-<timestamp>.zip in the current directory.
 
-Run this command regularly to make sure everything is ok with your installed binaries.`,
+Examples:
+  gobin bug                        # Write gobin-bug-<timestamp>.zip
+  gobin bug --output report.zip    # Write report.zip`,
 		Args:          cobra.NoArgs,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			cmd.SilenceUsage = true
 
-			parallelism, _ := cmd.Flags().GetInt("parallelism")
+			path, err := os.Executable()
+			if err != nil {
+				return err
+			}
 
-			return gobin.DiagnoseBinaries(cmd.Context(), parallelism)
+			if output == "" {
+				output = fmt.Sprintf("gobin-bug-%s.zip", time.Now().Format("20060102-150405"))
+			}
+
+			return gobin.CreateBugReport(
+				output,
+				path,
+				filepath.Join(workspace.GetInternalLogPath(), logFileName),
+				env.List(),
+			)
 		},
 	}
+
+	cmd.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"writes the bundle to the given path",
+	)
+
+	return cmd
 }
 
-// newInfoCmd creates a info command to print information about a binary.
-func newInfoCmd(
-	gobin *gobin.Gobin,
-	fs system.FileSystem,
-	workspace system.Workspace,
-) *cobra.Command {
-	return &cobra.Command{
-		Use:   "info [binary]",
-		Short: "Print information about a binary",
-		Args:  cobra.ExactArgs(1),
-		ValidArgsFunction: func(
-			_ *cobra.Command, _ []string, toComplete string,
-		) ([]string, cobra.ShellCompDirective) {
-			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), toComplete)
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveError
-			}
+// newBuildMatrixCmd creates a build-matrix command to build a package for
+// several platforms.
+func newBuildMatrixCmd(gobin *gobin.Gobin) *cobra.Command {
+	var flags model.BuildFlags
+	var platforms []string
+	var output string
 
-			return bins, cobra.ShellCompDirectiveDefault
-		},
+	cmd := &cobra.Command{
+		Use:   "build-matrix [package]",
+		Short: "Build a package for several platforms",
+		Long: `Build a package for each of the given platforms in parallel into an output directory, ex. for
+distribution. The binaries are named <binary>_<version>_<goos>_<goarch>, with the .exe extension for windows. The
+package version is resolved once, so every platform is built at the same version. The binaries are not installed:
+the Go binary path and the managed binaries are left untouched. The --goarm, --goamd64 and --goarm64 flags select
+the architecture variant of the matching platforms.
+
+Examples:
+  gobin build-matrix github.com/go-delve/delve/cmd/dlv@v1.25.1 --platforms linux/amd64,darwin/arm64,windows/amd64
+  gobin build-matrix github.com/go-delve/delve/cmd/dlv --platforms linux/arm64 -o bin/
+
+The package version is optional, defaults to "latest".`,
+		Args:          cobra.ExactArgs(1),
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
-			bin := model.NewBinaryFromString(args[0])
-			if !bin.IsValid() {
-				err := fmt.Errorf("invalid binary argument: %s", args[0])
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+
+			pkg := model.NewPackage(args[0])
+			if !pkg.IsValid() {
+				err := newInvalidArgError("package", args[0], pkg.Version)
 				fmt.Fprintln(os.Stderr, err.Error())
 				return err
 			}
 
-			return gobin.PrintBinaryInfo(bin)
+			if len(platforms) == 0 {
+				err := errors.New("at least one platform is required, ex. --platforms linux/amd64")
+				fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+				return err
+			}
+
+			for _, platform := range platforms {
+				goos, goarch, ok := strings.Cut(platform, "/")
+				if !ok || goos == "" || goarch == "" || strings.Contains(goarch, "/") {
+					err := fmt.Errorf("invalid platform %q, ex. linux/amd64", platform)
+					fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+					return err
+				}
+			}
+
+			slices.Sort(platforms)
+			return gobin.BuildMatrix(cmd.Context(), parallelism, pkg, slices.Compact(platforms), flags, output)
 		},
 	}
-}
 
-// newInstallCmd creates a install command to install packages.
-func newInstallCmd(gobin *gobin.Gobin) *cobra.Command {
-	kind := model.KindLatest
-	var rebuild bool
+	cmd.Flags().StringSliceVar(
+		&platforms,
+		"platforms",
+		nil,
+		"comma separated platforms to build for, ex. linux/amd64,darwin/arm64",
+	)
+
+	cmd.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"dist",
+		"directory the binaries are written to",
+	)
+
+	addVariantFlags(cmd, &flags)
+
+	return cmd
+}
 
+// newBundleCmd creates a bundle command to create a module bundle for offline
+// installs.
+func newBundleCmd(gobin *gobin.Gobin) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "install [packages]",
-		Short: "Install packages",
-		Long: `Install compiles and installs the packages named by the import paths. You can specify the pin kind to create
-[latest (default), major, minor] and whether to rebuild the package and its dependencies.
+		Use:   "bundle [file] [packages]",
+		Short: "Create a module bundle for offline installs",
+		Long: `Create a module bundle, a gzip compressed tar archive with the modules required to install the given
+packages and the checksum database data verifying them, to install the packages on machines without network access
+with gobin install --from-bundle. The package versions are resolved before bundling, so the bundle holds the exact
+versions to install. Without packages, the packages of the managed binaries are bundled at their installed versions,
+skipping the binaries not built from a released module version. The modules are downloaded without building the
+packages, into a temporary module cache removed afterwards.
 
 Examples:
-  gobin install github.com/go-delve/delve/cmd/dlv                      # Install latest version (dlv)
-  gobin install github.com/go-delve/delve/cmd/dlv@latest               # Install latest version (dlv)
-  gobin install github.com/go-delve/delve/cmd/dlv@v1                   # Install latest v1 minor version (dlv)
-  gobin install github.com/go-delve/delve/cmd/dlv@v1.25                # Install latest v1.25 patch version (dlv)
-  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1              # Install specific version (dlv)
-  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1 --rebuild    # Force package and dependencies rebuild (dlv)
-  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1 --kind major # Install and pin latest version (dlv)
-  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1 --kind major # Install and pin major version (dlv-v1)
-  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1 --kind minor # Install and pin minor version (dlv-v1.25)
+  gobin bundle tools.bundle                                                       # Bundle the managed binaries
+  gobin bundle tools.bundle github.com/go-delve/delve/cmd/dlv@v1.25.1             # Bundle specific version (dlv)
+  gobin bundle tools.bundle "golang.org/x/tools/cmd/{goimports,stringer}@v0.35.0" # Bundle several packages
 
-The package version is optional, defaults to "latest".
-The GOFLAGS environment variable can be used to define build flags.`,
+On the offline machine, install from the bundle at the bundled versions, ex.:
+  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1 --from-bundle tools.bundle
+
+The package version is optional, defaults to "latest".`,
 		Args:          cobra.MinimumNArgs(1),
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
-			parallelism, _ := cmd.Flags().GetInt("parallelism")
+			var packages []model.Package
+			for _, arg := range args[1:] {
+				for _, pkg := range model.NewPackages(arg) {
+					if !pkg.IsValid() {
+						err := newInvalidArgError("package", arg, pkg.Version)
+						fmt.Fprintln(os.Stderr, err.Error())
+						return err
+					}
 
-			packages := make([]model.Package, len(args))
-			for i, arg := range args {
-				pkg := model.NewPackage(arg)
-				if !pkg.IsValid() {
-					err := fmt.Errorf("invalid package argument: %s", arg)
-					fmt.Fprintln(os.Stderr, err.Error())
-					return err
+					packages = append(packages, pkg)
 				}
-
-				packages[i] = pkg
 			}
 
-			return gobin.InstallPackages(cmd.Context(), parallelism, kind, rebuild, packages...)
+			return gobin.BundlePackages(cmd.Context(), args[0], packages...)
 		},
 	}
 
-	cmd.Flags().VarP(
-		&kind,
-		"kind",
-		"k",
-		"pin kind [latest (default), major, minor]",
-	)
-
-	cmd.Flags().BoolVarP(
-		&rebuild,
-		"rebuild",
-		"r",
-		false,
-		"forces package and dependencies rebuild",
-	)
-
 	return cmd
 }
 
-// newListCmd creates a list command to list installed binaries.
-func newListCmd(gobin *gobin.Gobin) *cobra.Command {
-	var managed bool
-
+// newCacheCmd creates a cache command to manage the cached module lookups.
+func newCacheCmd(gobin *gobin.Gobin) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List binaries",
-		Long: `List binaries in the Go binary path, or if managed is true, list all managed binaries.
-For installed binaries, the green color indicates that the binary is managed by gobin. For managed binaries,
-the green color indicates that the binary is pinned.
+		Use:   "cache",
+		Short: "Manage the cached module lookups",
+		Long: `Manage the module lookups (latest versions, go.mod files and origins) cached in the cache directory of
+the internal base directory, for an hour or the cache.ttl of the config, so repeated outdated, doctor and upgrade runs
+skip the network calls. Use the --no-cache flag to bypass the cache for a single command.
 
 Examples:
-  gobin list                   # List binaries in the Go binary path
-  gobin list --managed         # List all managed binaries`,
+  gobin cache clear     # Remove the cached module lookups`,
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newCacheClearCmd(gobin))
+
+	return cmd
+}
+
+// newCacheClearCmd creates a cache clear command to remove the cached module
+// lookups.
+func newCacheClearCmd(gobin *gobin.Gobin) *cobra.Command {
+	return &cobra.Command{
+		Use:           "clear",
+		Short:         "Remove the cached module lookups",
 		Args:          cobra.NoArgs,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			cmd.SilenceUsage = true
 
-			return gobin.ListBinaries(managed)
+			return gobin.ClearCache()
 		},
 	}
-
-	cmd.Flags().BoolVarP(
-		&managed,
-		"managed",
-		"m",
-		false,
-		"list all managed binaries",
-	)
-
-	return cmd
 }
 
-// newMigrateCmd creates a migrate command to migrate binaries to be managed
-// internally.
-//
-//nolint:dupl // ignore duplicate code lint check
-func newMigrateCmd(
-	gobin *gobin.Gobin,
-	fs system.FileSystem,
-	workspace system.Workspace,
-) *cobra.Command {
-	var migrateAll bool
-
+// newCompletionInstallCmd creates a completion install command to install the
+// completion script of a shell.
+func newCompletionInstallCmd(gobin *gobin.Gobin, userPath system.UserPath) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "migrate [binaries]",
-		Short: "Migrate specific binaries or all with --all",
-		Long: `Migrate binaries to be managed internally. You can migrate specific binaries or all binaries.
+		Use:   "install [shell]",
+		Short: "Install the autocompletion script for a shell",
+		Long: `Install the autocompletion script for the given shell, or the shell detected from the SHELL environment
+variable (PowerShell on Windows) if none is given, and report where it was written: the bash-completion
+user directory for bash, ~/.zfunc/_gobin for zsh (to be added to fpath), the fish completions directory
+for fish, and the profile for PowerShell. Installing again replaces the script written before.
 
 Examples:
-  gobin migrate dlv                        # Migrate specific binary
-  gobin migrate dlv golangci-lint mockery  # Migrate multiple binaries  
-  gobin migrate --all                 	   # Migrate all binaries`,
-		Args: cobra.ArbitraryArgs,
-		ValidArgsFunction: func(
-			_ *cobra.Command, _ []string, toComplete string,
-		) ([]string, cobra.ShellCompDirective) {
-			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), toComplete)
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveError
-			}
-
-			return bins, cobra.ShellCompDirectiveDefault
-		},
+  gobin completion install         # Install the completion for the current shell
+  gobin completion install fish    # Install the completion for fish`,
+		Args:          cobra.MaximumNArgs(1),
+		ValidArgs:     model.GetShells(),
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
-			bins := make([]model.Binary, len(args))
-			for i, arg := range args {
-				bin := model.NewBinaryFromString(arg)
-				if !bin.IsValid() {
-					err := fmt.Errorf("invalid binary argument: %s", arg)
-					fmt.Fprintln(os.Stderr, err.Error())
-					return err
-				}
-
-				bins[i] = bin
+			shellName := userPath.GetShell()
+			if len(args) > 0 {
+				shellName = args[0]
+			} else if shellName == "" {
+				shellErr := errors.New("cannot detect the shell, pass it as argument")
+				fmt.Fprintln(os.Stderr, shellErr.Error())
+				return shellErr
 			}
 
-			switch {
-			case migrateAll && len(args) > 0:
-				err := errors.New("cannot use --all with specific binaries")
+			shell, err := model.NewShell(shellName)
+			if err != nil {
 				fmt.Fprintln(os.Stderr, err.Error())
 				return err
+			}
 
-			case migrateAll:
-				return gobin.MigrateBinaries()
-
-			case len(args) == 0:
-				err := errors.New("no binaries specified (use --all to migrate all)")
-				fmt.Fprintln(os.Stderr, err.Error())
+			script, err := getCompletionScript(cmd.Root(), shell)
+			if err != nil {
 				return err
-
-			default:
-				return gobin.MigrateBinaries(bins...)
 			}
+
+			return gobin.InstallCompletion(shell, script)
 		},
 	}
 
-	cmd.Flags().BoolVarP(
-		&migrateAll,
-		"all",
-		"a",
-		false,
-		"migrates all binaries",
-	)
-
 	return cmd
 }
 
-// newOutdatedCmd creates a outdated command to list outdated binaries.
-func newOutdatedCmd(gobin *gobin.Gobin) *cobra.Command {
-	var checkMajor bool
-
+// newConfigCmd creates a config command to read and modify the configuration
+// file.
+func newConfigCmd(gobin *gobin.Gobin) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "outdated",
-		Short: "List outdated binaries",
-		Long: `List binaries that have newer versions available. By default, only minor and patch
-updates are shown. Use --major to include potentially breaking major version upgrades. If a binary
-is pinned, it will check the latest version available for the pinned version. 
+		Use:   "config",
+		Short: "Read and modify the configuration",
+		Long: `Read and modify the configuration file (gobin/config.yaml in the user configuration directory), validating
+the updated configuration before writing it and keeping the other settings and the comments. Nested keys are separated
+by dots, and the deny list entries by commas.
+
+Keys:
+  deny                     packages or glob patterns refused to be installed
+  disable_release_check    disable the daily check for a new gobin release
+  network.backoff          wait before the first retry of a module proxy request, ex. 500ms
+  network.concurrency      maximum number of go commands requesting the network at once
+  network.retries          number of retries of a failed module proxy request
+  network.timeout          time limit of each module proxy request, ex. 30s
+  pin_mode                 way binaries are pinned: [symlink (default), copy]
 
 Examples:
-  gobin outdated                       # Show outdated binaries (minor/patch only)
-  gobin outdated --major               # Include major version upgrades`,
-		Args:          cobra.NoArgs,
-		SilenceErrors: true,
-		RunE: func(cmd *cobra.Command, _ []string) error {
+  gobin config get pin_mode                    # Print the pin mode
+  gobin config set network.retries 3           # Retry failed module proxy requests 3 times
+  gobin config set deny "example.com/org/*"    # Deny the packages of example.com/org
+  gobin config unset network.retries           # Restore the default retries`,
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newConfigGetCmd(gobin))
+	cmd.AddCommand(newConfigSetCmd(gobin))
+	cmd.AddCommand(newConfigUnsetCmd(gobin))
+
+	return cmd
+}
+
+// newConfigGetCmd creates a config get command to print the value of a
+// configuration key.
+func newConfigGetCmd(gobin *gobin.Gobin) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print the value of a configuration key",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: getConfigKeysAutoComplete,
+		SilenceErrors:     true,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
-			parallelism, _ := cmd.Flags().GetInt("parallelism")
+			return gobin.PrintConfigValue(args[0])
+		},
+	}
+}
+
+// newConfigSetCmd creates a config set command to set the value of a
+// configuration key.
+func newConfigSetCmd(gobin *gobin.Gobin) *cobra.Command {
+	return &cobra.Command{
+		Use:               "set <key> <value>",
+		Short:             "Set the value of a configuration key",
+		Args:              cobra.ExactArgs(2), //nolint:mnd // key and value
+		ValidArgsFunction: getConfigKeysAutoComplete,
+		SilenceErrors:     true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
 
-			return gobin.ListOutdatedBinaries(cmd.Context(), checkMajor, parallelism)
+			return gobin.SetConfigValue(args[0], args[1])
 		},
 	}
+}
 
-	cmd.Flags().BoolVarP(
-		&checkMajor,
-		"major",
-		"m",
-		false,
-		"checks for major versions",
-	)
+// newConfigUnsetCmd creates a config unset command to remove a configuration
+// key, restoring its default.
+func newConfigUnsetCmd(gobin *gobin.Gobin) *cobra.Command {
+	return &cobra.Command{
+		Use:               "unset <key>",
+		Short:             "Remove a configuration key, restoring its default",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: getConfigKeysAutoComplete,
+		SilenceErrors:     true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
 
-	return cmd
+			return gobin.UnsetConfigValue(args[0])
+		},
+	}
 }
 
-// newPinCmd creates a pin command to pin managed binaries.
-func newPinCmd(
+// newDebugInfoCmd creates a debuginfo command to locate the debug information
+// kept for a stripped binary.
+func newDebugInfoCmd(
 	gobin *gobin.Gobin,
 	fs system.FileSystem,
 	workspace system.Workspace,
 ) *cobra.Command {
-	kind := model.KindLatest
-
-	cmd := &cobra.Command{
-		Use:   "pin [binaries]",
-		Short: "Pin binaries to the Go binary path",
-		Long: `Pin managed binaries to the Go binary path.
+	return &cobra.Command{
+		Use:   "debuginfo [binary]",
+		Short: "Locate the debug info kept for a stripped binary",
+		Long: `Print the path of the debug information kept for a binary installed with --strip --debug-info, keyed by
+the build ID of the binary. The debug information file is the binary built again without stripping, so stack traces
+of the stripped binary can still be symbolized, ex. with go tool addr2line or dlv.
 
 Examples:
-  gobin pin dlv                              # Pin latest version (dlv)
-  gobin pin dlv@v1                           # Pin latest v1 minor version (dlv)
-  gobin pin dlv@v1.25                        # Pin latest v1.25 patch version (dlv)
-  gobin pin dlv@v1.25.1                      # Pin specific version (dlv)
-  gobin pin dlv mockery@3.5                  # Pin multiple binaries to latest version (dlv, mockery)
-  gobin pin dlv@v1 --kind major              # Pin latest v1 minor version (dlv-v1)
-  gobin pin dlv@v1.25 --kind minor           # Pin latest v1.25 patch version (dlv-v1.25)`,
-		Args: cobra.MinimumNArgs(1),
+  gobin debuginfo dlv                                   # Print the debug info path (dlv)
+  go tool addr2line $(gobin debuginfo dlv) < addrs.txt  # Symbolize addresses (dlv)`,
+		Args: cobra.ExactArgs(1),
 		ValidArgsFunction: func(
-			_ *cobra.Command, _ []string, toComplete string,
+			_ *cobra.Command, args []string, toComplete string,
 		) ([]string, cobra.ShellCompDirective) {
-			bins, err := getBinariesAutoComplete(fs, workspace.GetInternalBinPath(), toComplete)
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
 			if err != nil {
 				return nil, cobra.ShellCompDirectiveError
 			}
 
-			return bins, cobra.ShellCompDirectiveDefault
+			return bins, cobra.ShellCompDirectiveNoFileComp
 		},
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
-			bins := make([]model.Binary, len(args))
-			for i, arg := range args {
-				bin := model.NewBinaryFromString(arg)
-				if !bin.IsValid() {
-					err := fmt.Errorf("invalid binary argument: %s", arg)
-					fmt.Fprintln(os.Stderr, err.Error())
-					return err
-				}
-
-				bins[i] = bin
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
 			}
 
-			return gobin.PinBinaries(kind, bins...)
+			return gobin.PrintDebugInfoPath(cmd.Context(), bin)
 		},
 	}
-
-	cmd.Flags().VarP(
-		&kind,
-		"kind",
-		"k",
-		"pin kind [latest (default), major, minor]",
-	)
-
-	return cmd
 }
 
-// newPruneCmd creates a prune command to prune binaries.
-//
-//nolint:dupl // ignore duplicate code lint check
-func newPruneCmd(
+// newDiffCmd creates a diff command to compare two builds of a binary.
+func newDiffCmd(
 	gobin *gobin.Gobin,
 	fs system.FileSystem,
 	workspace system.Workspace,
 ) *cobra.Command {
-	var pruneAll bool
+	var remote bool
 
 	cmd := &cobra.Command{
-		Use:   "prune [binaries]",
-		Short: "Prune specific binaries or all with --all",
-		Long: `Prune binaries from the internal binary directory. You can prune specific binaries or all binaries.
+		Use:   "diff [binary] [from] [to]",
+		Short: "Compare two builds of a binary",
+		Long: `Compare two managed versions of a binary: the Go version, the size, the build settings and the versions
+of the dependencies embedded in the binary.
+
+With --remote, compare the installed binary with the latest version of its module, resolved from its module file
+without building it, so the build settings and the size of the latest version are unknown.
 
 Examples:
-  gobin prune dlv                        # Prune specific binary
-  gobin prune dlv@v1                     # Prune specific binary with major version
-  gobin prune dlv@v1.25                  # Prune specific binary with minor version
-  gobin prune dlv@v1.25.1                # Prune specific binary with patch version
-  gobin prune dlv golangci-lint mockery  # Prune multiple binaries
-  gobin prune --all                 	 # Prune all binaries`,
-		Args: cobra.ArbitraryArgs,
+  gobin diff dlv v1.23.0 v1.24.0  # Compare two managed versions of dlv
+  gobin diff dlv --remote         # Compare the installed dlv with the latest version`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if remote {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+
+			return cobra.ExactArgs(3)(cmd, args) //nolint:mnd // binary, from and to versions
+		},
 		ValidArgsFunction: func(
-			_ *cobra.Command, _ []string, toComplete string,
+			_ *cobra.Command, args []string, toComplete string,
 		) ([]string, cobra.ShellCompDirective) {
-			bins, err := getBinariesAutoComplete(fs, workspace.GetInternalBinPath(), toComplete)
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
 			if err != nil {
 				return nil, cobra.ShellCompDirectiveError
 			}
 
-			return bins, cobra.ShellCompDirectiveDefault
+			return bins, cobra.ShellCompDirectiveNoFileComp
 		},
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
-			bins := make([]model.Binary, len(args))
-			for i, arg := range args {
-				bin := model.NewBinaryFromString(arg)
-				if !bin.IsValid() {
-					err := fmt.Errorf("invalid binary argument: %s", arg)
-					fmt.Fprintln(os.Stderr, err.Error())
-					return err
-				}
-
-				bins[i] = bin
-			}
-
-			switch {
-			case pruneAll && len(args) > 0:
-				err := errors.New("cannot use --all with specific binaries")
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
 				fmt.Fprintln(os.Stderr, err.Error())
 				return err
+			}
 
-			case pruneAll:
-				return gobin.PruneBinaries()
-
-			case len(args) == 0:
-				err := errors.New("no binaries specified (use --all to prune all)")
-				fmt.Fprintln(os.Stderr, err.Error())
-				return err
+			if remote {
+				return gobin.DiffLatestBinary(cmd.Context(), bin)
+			}
 
-			default:
-				return gobin.PruneBinaries(bins...)
+			from, to := model.NewVersion(args[1]), model.NewVersion(args[2])
+			for i, version := range []model.Version{from, to} {
+				if !version.IsValid() {
+					err := newInvalidArgError("version", args[i+1], version)
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
 			}
+
+			return gobin.DiffBinary(bin, from, to)
 		},
 	}
 
-	cmd.Flags().BoolVarP(
-		&pruneAll,
-		"all",
-		"a",
+	cmd.Flags().BoolVar(
+		&remote,
+		"remote",
 		false,
-		"prunes all binaries",
+		"compare the installed binary with the latest version",
 	)
 
 	return cmd
 }
 
-// newRepoCmd creates a repo command to show/open the repository URL for a
-// binary.
-func newRepoCmd(
-	gobin *gobin.Gobin,
-	fs system.FileSystem,
-	workspace system.Workspace,
-) *cobra.Command {
-	var open bool
-
+// newDocsCmd creates a hidden docs command to generate the documentation of
+// the command tree, for distribution packages and releases.
+func newDocsCmd(fs system.FileSystem) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "repo [binary]",
-		Short: "Show binary repository",
-		Long: `Show the repository URL for a Go binary.
+		Use:   "docs",
+		Short: "Generate the documentation of the commands",
+		Long: `Generate the documentation of all commands from the command tree into the given directory, created if it
+does not exist: man pages in section 1 with the man subcommand, or a markdown file per command with the
+markdown subcommand.
 
 Examples:
-  gobin repo dlv                       # Print repository URL
-  gobin repo dlv --open                # Open repository in browser
+  gobin docs man ./man          # Write the man pages to ./man
+  gobin docs markdown ./docs    # Write the markdown files to ./docs`,
+		Args:   cobra.NoArgs,
+		Hidden: true,
+	}
 
-The repository URL is determined from the module's origin information,
-falling back to constructing the URL from the module path if not available.`,
-		Args: cobra.ExactArgs(1),
-		ValidArgsFunction: func(
-			_ *cobra.Command, _ []string, toComplete string,
-		) ([]string, cobra.ShellCompDirective) {
-			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), toComplete)
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveError
-			}
+	cmd.AddCommand(newDocsManCmd(fs))
+	cmd.AddCommand(newDocsMarkdownCmd(fs))
+
+	return cmd
+}
+
+// newDocsManCmd creates a docs man command to generate the man pages of the
+// command tree.
+func newDocsManCmd(fs system.FileSystem) *cobra.Command {
+	return &cobra.Command{
+		Use:           "man <dir>",
+		Short:         "Generate the man pages of the commands",
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
 
-			return bins, cobra.ShellCompDirectiveDefault
+			return genDocs(fs, cmd.Root(), args[0], func(root *cobra.Command, dir string) error {
+				return doc.GenManTree(root, &doc.GenManHeader{Title: "GOBIN", Section: "1"}, dir)
+			})
 		},
+	}
+}
+
+// newDocsMarkdownCmd creates a docs markdown command to generate the markdown
+// documentation of the command tree.
+func newDocsMarkdownCmd(fs system.FileSystem) *cobra.Command {
+	return &cobra.Command{
+		Use:           "markdown <dir>",
+		Short:         "Generate the markdown documentation of the commands",
+		Args:          cobra.ExactArgs(1),
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
-			bin := model.NewBinaryFromString(args[0])
-			if !bin.IsValid() {
-				err := fmt.Errorf("invalid binary argument: %s", args[0])
+			return genDocs(fs, cmd.Root(), args[0], doc.GenMarkdownTree)
+		},
+	}
+}
+
+// newDoctorCmd creates a doctor command to diagnose issues with installed
+// binaries.
+func newDoctorCmd(gobin *gobin.Gobin) *cobra.Command {
+	var dryRun bool
+	var fix bool
+	var network bool
+	var timings bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose issues for installed binaries",
+		Long: `Diagnose common issues with installed Go binaries.
+
+Checks for:
+  • Binaries not in PATH
+  • Duplicate binaries in PATH
+  • Binaries not managed by gobin
+  • Pseudo-versions and orphaned binaries
+  • Binaries built without Go modules
+  • Go version mismatches
+  • Platform mismatches (OS/architecture)
+  • C library mismatches for dynamically linked binaries on Linux (glibc/musl)
+  • Installed binary paths near the Windows path length limit (MAX_PATH)
+  • Retracted or deprecated modules
+  • Known security vulnerabilities
+  • Checksum database verification disabled for all modules (GOSUMDB, GONOSUMDB)
+
+With --network, it also probes each module proxy in GOPROXY, reporting the average latency, the failed probes and the
+module proxies rejecting the .netrc or GOAUTH credentials, and prints the HTTP proxy (HTTP_PROXY, HTTPS_PROXY,
+NO_PROXY) traversed to reach each module proxy, the checksum database and the vulnerability database.
+
+With --fix, it previews and, after confirmation, fixes the issues found, reporting the result per binary:
+  • Binaries not managed by gobin are migrated
+  • Go version and platform mismatches are rebuilt at the installed version
+  • Pins whose managed binary was removed are repinned to the latest matching version
+  • Duplicate additions of the Go binary path to PATH made by gobin are removed
+When the Go binary path is not in PATH, it also previews and, after confirmation, appends the Go binary path to the PATH
+in the shell profile file (~/.zshrc, ~/.bashrc, ~/.bash_profile on macOS, ~/.config/fish/config.fish or ~/.profile,
+based on SHELL) or to the user Path environment variable on Windows. With --dry-run, it only reports the fixes.
+
+With --timings, it reports the wall time spent per phase (version resolution, vulnerability check) for each binary and
+in aggregate at the end.
+
+Run this command regularly to make sure everything is ok with your installed binaries.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+
+			if dryRun && !fix {
+				err := errors.New("cannot use --dry-run without --fix")
 				fmt.Fprintln(os.Stderr, err.Error())
 				return err
 			}
 
-			return gobin.ShowBinaryRepository(cmd.Context(), bin, open)
+			if fix {
+				if err := gobin.FixBinaries(cmd.Context(), parallelism, timings, dryRun); err != nil {
+					return err
+				}
+			} else if err := gobin.DiagnoseBinaries(cmd.Context(), parallelism, timings); err != nil {
+				return err
+			}
+
+			if fix && !dryRun {
+				if err := gobin.FixPath(cmd.Context()); err != nil {
+					return err
+				}
+			}
+
+			if network {
+				return gobin.DiagnoseNetwork(cmd.Context())
+			}
+
+			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(
+		&dryRun,
+		"dry-run",
+		false,
+		"report the fixes without applying them, with --fix",
+	)
+
 	cmd.Flags().BoolVarP(
-		&open,
-		"open",
-		"o",
+		&fix,
+		"fix",
+		"f",
 		false,
-		"opens the repository in the default browser",
+		"fix the issues found and add the Go binary path to PATH when missing",
+	)
+
+	cmd.Flags().BoolVarP(
+		&network,
+		"network",
+		"n",
+		false,
+		"probe the module proxies in GOPROXY",
+	)
+
+	cmd.Flags().BoolVar(
+		&timings,
+		"timings",
+		false,
+		"report the wall time spent per phase",
 	)
 
 	return cmd
 }
 
-// newUninstallCmd creates a uninstall command to uninstall a binary.
-func newUninstallCmd(
-	gobin *gobin.Gobin,
-	fs system.FileSystem,
-	workspace system.Workspace,
-) *cobra.Command {
+// newDuCmd creates a du command to print the disk usage of the internal binary
+// directory.
+func newDuCmd(gobin *gobin.Gobin) *cobra.Command {
 	return &cobra.Command{
-		Use:   "uninstall [binaries]",
-		Short: "Uninstall binaries",
-		Args:  cobra.MinimumNArgs(1),
-		ValidArgsFunction: func(
-			_ *cobra.Command, _ []string, toComplete string,
-		) ([]string, cobra.ShellCompDirective) {
-			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), toComplete)
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveError
-			}
+		Use:   "du",
+		Short: "Show disk usage of the internal binary directory",
+		Long: `Show the disk usage of the internal binary directory: the number of versions and the size of each binary,
+sorted by decreasing size, along with its stale versions, not targeted by any pin, and the space freed by pruning them
+with gobin prune. The binaries cross compiled for other platforms are not included.
 
-			return bins, cobra.ShellCompDirectiveDefault
-		},
+Examples:
+  gobin du   # Print disk usage per binary and in total`,
+		Args:          cobra.NoArgs,
 		SilenceErrors: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			return gobin.PrintStoreUsage()
+		},
+	}
+}
+
+// newEnvCmd creates an env command to print the effective paths and settings,
+// and to manage the environment variables set by the exec shims of binaries.
+func newEnvCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print the effective paths and settings",
+		Long: `Print the effective paths and settings of gobin: the Go binary path, the internal binary, data, receipt,
+shim, temporary and log paths, the config file in use, the pin mode, the release check and its cache, and the build
+cache, module cache and GOPROXY seen by the Go toolchain.
+
+With get, set and unset, manage the environment variables of a binary, recorded in its receipt and set by its exec
+shim before running it. The shims are written to the shim path, which must be in PATH before the Go binary path for
+the environment to apply, as done by gobin init.
+
+Examples:
+  gobin env                                  # Print the paths and settings
+  gobin env --json                           # Print the paths and settings as JSON
+  gobin env get dlv                          # Print the environment of dlv
+  gobin env set dlv GOMEMLIMIT=1GiB          # Run dlv with GOMEMLIMIT=1GiB
+  gobin env unset dlv GOMEMLIMIT             # Stop setting GOMEMLIMIT for dlv
+  gobin env unset dlv                        # Remove the environment and the shim of dlv`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			return gobin.PrintEnv(cmd.Context())
+		},
+	}
+
+	cmd.AddCommand(newEnvGetCmd(gobin, fs, workspace))
+	cmd.AddCommand(newEnvSetCmd(gobin, fs, workspace))
+	cmd.AddCommand(newEnvUnsetCmd(gobin, fs, workspace))
+
+	return cmd
+}
+
+// newEnvGetCmd creates an env get command to print the environment variables
+// of a binary.
+func newEnvGetCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get <binary>",
+		Short:             "Print the environment variables of a binary",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: getEnvBinaryAutoComplete(fs, workspace),
+		SilenceErrors:     true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.PrintBinaryEnv(bin)
+		},
+	}
+}
+
+// newEnvSetCmd creates an env set command to set environment variables of a
+// binary, in the form "name=value".
+func newEnvSetCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:               "set <binary> <name=value>...",
+		Short:             "Set environment variables of a binary",
+		Args:              cobra.MinimumNArgs(2), //nolint:mnd // binary and environment variable
+		ValidArgsFunction: getEnvBinaryAutoComplete(fs, workspace),
+		SilenceErrors:     true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			for _, arg := range args[1:] {
+				if _, _, err := model.ParseEnvVar(arg); err != nil {
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
+			}
+
+			return gobin.SetBinaryEnv(bin, args[1:]...)
+		},
+	}
+}
+
+// newEnvUnsetCmd creates an env unset command to unset environment variables
+// of a binary, or all of them if none is given.
+func newEnvUnsetCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:               "unset <binary> [names]",
+		Short:             "Unset environment variables of a binary",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: getEnvBinaryAutoComplete(fs, workspace),
+		SilenceErrors:     true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			for _, arg := range args[1:] {
+				if !model.IsValidEnvVarName(arg) {
+					err := fmt.Errorf("invalid environment variable name argument: %s", arg)
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
+			}
+
+			return gobin.UnsetBinaryEnv(bin, args[1:]...)
+		},
+	}
+}
+
+// newExportCmd creates an export command to write the lockfile of the managed
+// binaries.
+func newExportCmd(gobin *gobin.Gobin) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export the managed binaries to a lockfile",
+		Long: `Export the managed binaries to a lockfile, gobin.lock in the current directory by default, listing the
+pin name, package, version and pin kind of each binary pinned to the Go binary directory, to reproduce them on another
+machine or in CI with gobin sync. Development builds are skipped, as they cannot be installed from the module proxy.
+
+Examples:
+  gobin export                  # Export to gobin.lock
+  gobin export tools/gobin.lock # Export to a specific file
+  gobin export -                # Print the lockfile to the standard output`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			path := model.DefaultLockfileName
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			return gobin.ExportLockfile(path)
+		},
+	}
+
+	return cmd
+}
+
+// newGenerateCmd creates a generate command to generate the tasks of a task
+// runner installing packages with gobin.
+func newGenerateCmd(gobin *gobin.Gobin) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate [make|task] [packages]",
+		Short: "Generate Makefile or Taskfile targets installing tools",
+		Long: `Generate Makefile or Taskfile targets installing the given packages with gobin, to bootstrap the tools
+of a repository with make tools or task tools. The tools target depends on a target per package, skipped when the
+binary is already installed from the package at the version. The package versions are resolved before generating,
+so the targets install the exact versions. Without packages, the packages of the managed binaries are used at their
+installed versions, skipping the binaries not built from a released module version.
+
+Examples:
+  gobin generate make > tools.mk                                                  # Generate Makefile targets
+  gobin generate task > Taskfile.tools.yml                                        # Generate Taskfile tasks
+  gobin generate make github.com/go-delve/delve/cmd/dlv@v1.25.1 > tools.mk        # Generate for a package (dlv)
+  gobin generate task "golang.org/x/tools/cmd/{goimports,stringer}" > tools.yml   # Generate for several packages
+
+Include the generated Makefile with include tools.mk, or the generated Taskfile with includes in Taskfile.yml.
+
+The package version is optional, defaults to "latest".`,
+		Args:          cobra.MinimumNArgs(1),
+		ValidArgs:     model.GetTaskRunners(),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			runner, err := model.NewTaskRunner(args[0])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			var packages []model.Package
+			for _, arg := range args[1:] {
+				for _, pkg := range model.NewPackages(arg) {
+					if !pkg.IsValid() {
+						err = newInvalidArgError("package", arg, pkg.Version)
+						fmt.Fprintln(os.Stderr, err.Error())
+						return err
+					}
+
+					packages = append(packages, pkg)
+				}
+			}
+
+			return gobin.GenerateTasks(cmd.Context(), runner, packages...)
+		},
+	}
+
+	return cmd
+}
+
+// newHookCmd creates a hook command to integrate gobin with the shell handlers.
+func newHookCmd(gobin *gobin.Gobin, userPath system.UserPath) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hook",
+		Short: "Integrate gobin with the shell handlers",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newHookCommandNotFoundCmd(gobin, userPath))
+
+	return cmd
+}
+
+// newHookCommandNotFoundCmd creates a hook command-not-found command to print
+// the command-not-found hook of a shell, or to handle a command not found.
+func newHookCommandNotFoundCmd(gobin *gobin.Gobin, userPath system.UserPath) *cobra.Command {
+	var shellName string
+
+	cmd := &cobra.Command{
+		Use:   "command-not-found [command]",
+		Short: "Offer to install unknown commands provided by managed binaries",
+		Long: `Print the command-not-found hook of the given shell, or the shell detected from the SHELL environment
+variable if --shell flag is not specified. Supported shells are bash, zsh and fish. When a command is not found
+and a managed binary, installed before with gobin, provides it, the hook offers to install the latest version
+of its package with gobin install and runs the command again. The hook only prompts in interactive sessions.
+
+With a command, it handles the command not found, as called by the hook.
+
+Examples:
+  eval "$(gobin hook command-not-found --shell bash)"          # Set up the current bash session
+  gobin hook command-not-found --shell zsh >> ~/.zshrc          # Set up zsh
+  gobin hook command-not-found --shell fish | source            # Set up the current fish session`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if len(args) > 0 {
+				return gobin.HandleCommandNotFound(cmd.Context(), args[0])
+			}
+
+			if shellName == "" {
+				if shellName = userPath.GetShell(); shellName == "" {
+					shellErr := errors.New("cannot detect the shell, pass it with --shell")
+					fmt.Fprintln(os.Stderr, shellErr.Error())
+					return shellErr
+				}
+			}
+
+			shell, err := model.NewShell(shellName)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.PrintCommandNotFoundHook(shell)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&shellName,
+		"shell",
+		"",
+		"shell of the hook [bash, zsh, fish] (default: detected from SHELL)",
+	)
+
+	return cmd
+}
+
+// newInfoCmd creates a info command to print information about a binary.
+func newInfoCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "info [binary]",
+		Short: "Print information about a binary",
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.PrintBinaryInfo(cmd.Context(), bin)
+		},
+	}
+}
+
+// newInitCmd creates an init command to set up a shell for gobin.
+func newInitCmd(gobin *gobin.Gobin) *cobra.Command {
+	var prompt bool
+	var write bool
+
+	cmd := &cobra.Command{
+		Use:   "init [shell]",
+		Short: "Set up a shell for gobin",
+		Long: `Print the snippet setting up a shell for gobin: the Go binary path is added to PATH and the gobin
+completion is loaded. If --prompt flag is specified, the prompt shows gobin↑ when binaries are outdated,
+checked in the background at most once a day. If --write flag is specified, the snippet is written to the
+shell profile instead, replacing the snippet written before.
+
+Examples:
+  eval "$(gobin init bash)"                  # Set up the current bash session
+  gobin init zsh --write                     # Set up zsh in ~/.zshrc
+  gobin init fish --prompt --write           # Set up fish with the prompt status
+  gobin init powershell | Out-String | iex   # Set up the current PowerShell session`,
+		Args:          cobra.ExactArgs(1),
+		ValidArgs:     model.GetShells(),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			shell, err := model.NewShell(args[0])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.InitShell(shell, prompt, write)
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&prompt,
+		"prompt",
+		false,
+		"adds the outdated binaries status to the prompt",
+	)
+
+	cmd.Flags().BoolVarP(
+		&write,
+		"write",
+		"w",
+		false,
+		"writes the snippet to the shell profile",
+	)
+
+	return cmd
+}
+
+// newInstallCmd creates a install command to install packages.
+func newInstallCmd(gobin *gobin.Gobin, env system.Environment) *cobra.Command {
+	kind := model.KindLatest
+	var flags model.BuildFlags
+	var buildEnv []string
+	var maxDownload model.ByteSize
+	var cacheFrom string
+	var fromBundle string
+	var fromGoMod string
+	var goos string
+	var goarch string
+	var path string
+	var rebuild bool
+	var universal bool
+	var timings bool
+	var upx bool
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "install [packages]",
+		Short: "Install packages",
+		Long: `Install compiles and installs the packages named by the import paths. You can specify the pin kind to create
+[latest (default), major, minor] and whether to rebuild the package and its dependencies. The version "previous"
+installs the version installed before the current one, and "latest-N" installs the N-th release behind the latest one.
+Packages are validated against the module proxy before installing, failing fast with suggestions when the module,
+version or main package is not found. Branch and tag refs are resolved to the version served by the proxy, usually a
+pseudo-version. Packages matching the deny list of the config file are refused before anything is installed. When
+installing several packages, the download size of their modules is estimated first, and with --max-download nothing is
+installed if the estimate exceeds the given size. On macOS, --universal builds the package for amd64 and arm64 and
+merges both into a single universal binary. The --goarm, --goamd64 and --goarm64 flags select the architecture variant,
+ex. v3 for x86-64-v3, recorded in the binary receipt so upgrades keep it, as is --strip, which builds the binary without
+its symbol table and debug information (-ldflags=-s -w). With --debug-info, the debug information of a stripped binary
+is kept apart, keyed by its build ID, and located with gobin debuginfo. With --timings, the wall time spent per phase
+(version resolution, download, compile, link/copy) is reported for each package and in aggregate at the end. With --upx,
+or the upx key of the config file, the binaries are compressed with UPX if available in the PATH, recording the original
+and compressed sizes in the binary receipt. With --provenance, the gobin version, the package spec and the command are
+recorded in the build info of the binary, shown by gobin info even after copying the binary to other machines. With
+--from-bundle, the packages are installed offline from a module bundle, a gzip compressed tar archive of a module cache
+holding the modules of the packages, ex. created with gobin bundle. With --path, the main package of a local directory
+is installed as a development build, resolving the modules of the go.work file and the replace directives of the module
+it belongs to, and marked as such by gobin list and gobin info. With --cache-from, a matching prebuilt binary is
+downloaded from a binary cache server started with gobin serve-cache instead of compiling the package, falling back to
+building it when the server has no binary of the same version, platform and build flags. With --os and --arch, the
+packages are cross compiled for another platform into its directory of the internal binary directory, ex.
+~/.gobin/bin/linux_arm64, without being pinned to the Go binary directory, and listed with gobin list --managed; the
+missing flag defaults to the current operating system or architecture. With --from-gomod, the tool dependencies of a
+module are installed at the versions recorded in its go.sum file, declared with the tool directives of its go.mod file,
+go.mod in the current directory by default, or with blank imports in the tools.go file next to it. With --dry-run, the
+packages are resolved and validated, and the binaries that would be installed and the pins that would be created or
+replaced are printed, without building anything.
+
+Examples:
+  gobin install github.com/go-delve/delve/cmd/dlv                      # Install latest version (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv@latest               # Install latest version (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv@v1                   # Install latest v1 minor version (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv@v1.25                # Install latest v1.25 patch version (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1              # Install specific version (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv@previous             # Install previous version (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv@latest-1             # Install release before latest (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv@master               # Install branch or tag ref (dlv)
+  gobin install "golang.org/x/tools/cmd/{goimports,stringer}@v0.35.0"  # Install several packages (goimports, stringer)
+  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1 --rebuild    # Force package and dependencies rebuild (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --max-download 50MB  # Install if download is at most 50MB (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --universal          # Install universal binary for macOS (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --goamd64 v3         # Install for x86-64-v3 (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --upx                # Install compressed with UPX (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --strip              # Install without symbols (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --tags netgo         # Install with build tags (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --env CGO_ENABLED=1  # Install with cgo enabled (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --provenance         # Install recording gobin provenance (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --from-bundle b.tgz  # Install offline from a module bundle (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --cache-from URL    # Install from a binary cache server (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --arch arm64         # Cross compile for arm64 (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv --dry-run            # Print the install actions (dlv)
+  gobin install --path ./cmd/mytool                                    # Install local package as dev build (mytool)
+  gobin install --from-gomod                                           # Install the tools of go.mod
+  gobin install --from-gomod=tools/go.mod                              # Install the tools of a specific go.mod
+  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1 --kind major # Install and pin latest version (dlv)
+  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1 --kind major # Install and pin major version (dlv-v1)
+  gobin install github.com/go-delve/delve/cmd/dlv@v1.25.1 --kind minor # Install and pin minor version (dlv-v1.25)
+
+The package version is optional, defaults to "latest".
+A brace group in the package path installs one package per comma separated element at the same version.
+The --tags, --ldflags and --env flags set the build tags, linker flags and build environment variables (CGO_ENABLED,
+GOARM, GOAMD64 or GOARM64) of the packages, recorded in the binary receipt so upgrades and rebuilds reuse them. The
+GOFLAGS environment variable can be used to define other build flags, applied to every package.`,
+		Args:          cobra.ArbitraryArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			switch {
+			case path != "" && len(args) > 0:
+				err := errors.New("cannot use --path with specific packages")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case fromGoMod != "" && (path != "" || len(args) > 0):
+				err := errors.New("cannot use --from-gomod with --path or specific packages")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case path != "" && (cmd.Flags().Changed("kind") || rebuild || universal || maxDownload > 0):
+				err := errors.New("cannot use --path with --kind, --rebuild, --universal or --max-download")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case (goos != "" || goarch != "") &&
+				(path != "" || cmd.Flags().Changed("kind") || universal || cacheFrom != "" || upx):
+				err := errors.New("cannot use --os or --arch with --path, --kind, --universal, --cache-from or --upx")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case dryRun && (path != "" || fromBundle != "" || universal || goos != "" || goarch != ""):
+				err := errors.New("cannot use --dry-run with --path, --from-bundle, --universal, --os or --arch")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case path == "" && fromGoMod == "" && len(args) == 0:
+				err := errors.New("no packages specified (use --path to install a local package)")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+
+			var err error
+			if flags, err = flags.WithEnv(buildEnv...); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			var packages []model.Package
+			for _, arg := range args {
+				for _, pkg := range model.NewPackages(arg) {
+					if !pkg.IsValid() {
+						err := newInvalidArgError("package", arg, pkg.Version)
+						fmt.Fprintln(os.Stderr, err.Error())
+						return err
+					}
+
+					packages = append(packages, pkg)
+				}
+			}
+
+			if fromGoMod != "" {
+				tools, err := gobin.GetModuleTools(fromGoMod)
+				if err != nil {
+					return err
+				}
+
+				packages = append(packages, tools...)
+			}
+
+			gobin.SetDryRun(dryRun)
+
+			if cacheFrom != "" {
+				gobin.SetCacheFrom(cacheFrom)
+			}
+
+			if cmd.Flags().Changed("upx") {
+				gobin.SetCompress(upx)
+			}
+
+			if goos != "" || goarch != "" {
+				gobin.SetPlatform(cmp.Or(goos, runtime.GOOS) + "/" + cmp.Or(goarch, runtime.GOARCH))
+			}
+
+			if fromBundle != "" {
+				modCache, cleanup, err := gobin.ExtractBundle(fromBundle)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = cleanup() }()
+
+				if err = setBundleEnv(env, modCache); err != nil {
+					fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+					return err
+				}
+			}
+
+			if path != "" {
+				dir, err := filepath.Abs(path)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+					return err
+				}
+
+				return gobin.InstallLocalPackage(cmd.Context(), dir, flags)
+			}
+
+			return gobin.InstallPackages(
+				cmd.Context(), parallelism, kind, flags, rebuild, universal, timings, maxDownload, packages...,
+			)
+		},
+	}
+
+	cmd.Flags().VarP(
+		&kind,
+		"kind",
+		"k",
+		"pin kind [latest (default), major, minor]",
+	)
+
+	cmd.Flags().BoolVarP(
+		&rebuild,
+		"rebuild",
+		"r",
+		false,
+		"forces package and dependencies rebuild",
+	)
+
+	cmd.Flags().Var(
+		&maxDownload,
+		"max-download",
+		"refuse to install when the estimated download size exceeds this size, ex. 500MB",
+	)
+
+	cmd.Flags().BoolVarP(
+		&universal,
+		"universal",
+		"u",
+		false,
+		"build a macOS universal binary (amd64 and arm64)",
+	)
+
+	cmd.Flags().BoolVar(
+		&timings,
+		"timings",
+		false,
+		"reports the wall time spent per phase",
+	)
+
+	cmd.Flags().BoolVar(
+		&upx,
+		"upx",
+		false,
+		"compresses the binaries with UPX, if available",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.Strip,
+		"strip",
+		false,
+		"strips the symbol table and debug information (-ldflags=-s -w)",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.DebugInfo,
+		"debug-info",
+		false,
+		"keeps the debug information of stripped binaries apart, see gobin debuginfo",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.Provenance,
+		"provenance",
+		false,
+		"records the gobin provenance in the build info of the binaries",
+	)
+
+	cmd.Flags().StringVar(
+		&fromBundle,
+		"from-bundle",
+		"",
+		"installs offline from a module bundle, a tar.gz archive of a module cache",
+	)
+
+	cmd.Flags().StringVar(
+		&fromGoMod,
+		"from-gomod",
+		"",
+		"installs the tool dependencies of a go.mod file at their go.sum versions",
+	)
+	cmd.Flags().Lookup("from-gomod").NoOptDefVal = model.GoModFileName
+
+	cmd.Flags().StringVar(
+		&cacheFrom,
+		"cache-from",
+		"",
+		"downloads matching prebuilt binaries from a binary cache server instead of compiling",
+	)
+
+	cmd.Flags().StringVar(
+		&path,
+		"path",
+		"",
+		"installs the main package of a local directory as a development build, honoring go.work",
+	)
+
+	cmd.Flags().StringVar(
+		&goos,
+		"os",
+		"",
+		"cross compiles for the operating system, ex. linux, into the internal binary directory",
+	)
+
+	cmd.Flags().StringVar(
+		&goarch,
+		"arch",
+		"",
+		"cross compiles for the architecture, ex. arm64, into the internal binary directory",
+	)
+
+	addDryRunFlag(cmd, &dryRun)
+
+	addBuildFlags(cmd, &flags, &buildEnv)
+	addVariantFlags(cmd, &flags)
+
+	return cmd
+}
+
+// newLinkCmd creates a link command to install a local package in develop mode.
+func newLinkCmd(gobin *gobin.Gobin) *cobra.Command {
+	var flags model.BuildFlags
+	var upx bool
+
+	cmd := &cobra.Command{
+		Use:   "link [dir]",
+		Short: "Link a local package in develop mode",
+		Long: `Link builds the main package of a local directory, the current one by default, into the managed binaries
+and pins it, so tool authors can use their own commands through gobin while developing them. The package is built as
+a development build, resolving the modules of the go.work file and the replace directives of the module it belongs
+to. The directory is recorded in the binary receipt, and gobin relink rebuilds the binary from it, on demand or, with
+--watch, whenever its files change.
+
+Examples:
+  gobin link                       # Link the main package of the current directory
+  gobin link ./cmd/mytool          # Link a local package (mytool)
+  gobin link ./cmd/mytool --strip  # Link a local package without symbols (mytool)`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			path := "."
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			dir, err := filepath.Abs(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+				return err
+			}
+
+			if cmd.Flags().Changed("upx") {
+				gobin.SetCompress(upx)
+			}
+
+			return gobin.InstallLocalPackage(cmd.Context(), dir, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&upx,
+		"upx",
+		false,
+		"compresses the binary with UPX, if available",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.Strip,
+		"strip",
+		false,
+		"strips the symbol table and debug information (-ldflags=-s -w)",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.Provenance,
+		"provenance",
+		false,
+		"records the gobin provenance in the build info of the binary",
+	)
+
+	addVariantFlags(cmd, &flags)
+
+	return cmd
+}
+
+// newListCmd creates a list command to list installed binaries.
+func newListCmd(gobin *gobin.Gobin) *cobra.Command {
+	var filter string
+	var goos string
+	var managed bool
+	var null bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List binaries",
+		Long: `List binaries in the Go binary path, or if managed is true, list all managed binaries.
+For installed binaries, the green color indicates that the binary is managed by gobin. For managed binaries,
+the green color indicates that the binary is pinned. When the Go binary path is shared between WSL and
+Windows, the binaries built for the other operating system are labeled with it, and can be filtered out
+with --os flag. If --filter flag is specified, only the binaries whose name matches the glob pattern are
+listed. If --null flag is specified, only the binary names are printed, separated by NUL characters, to be
+piped to the commands reading binaries from stdin (-).
+
+Examples:
+  gobin list                                             # List binaries in the Go binary path
+  gobin list --managed                                   # List all managed binaries
+  gobin list --os linux                                  # List binaries built for Linux
+  gobin list --filter 'golangci-*'                       # List binaries matching a pattern
+  gobin list --filter 'mock*' -0 | gobin uninstall -0 -  # Uninstall binaries matching a pattern`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			if _, err := filepath.Match(filter, ""); err != nil {
+				err = fmt.Errorf("invalid filter %q: %w", filter, err)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.ListBinaries(managed, goos, filter, null)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&filter,
+		"filter",
+		"",
+		"list only binaries whose name matches the glob pattern, ex. golangci-*",
+	)
+
+	cmd.Flags().BoolVarP(
+		&managed,
+		"managed",
+		"m",
+		false,
+		"list all managed binaries",
+	)
+
+	cmd.Flags().StringVar(
+		&goos,
+		"os",
+		"",
+		"list only binaries built for the operating system, ex. linux",
+	)
+
+	cmd.Flags().BoolVarP(
+		&null,
+		"null",
+		"0",
+		false,
+		"prints only the binary names separated by NUL characters, overriding --json",
+	)
+
+	return cmd
+}
+
+// newMigrateCmd creates a migrate command to migrate binaries to be managed
+// internally.
+//
+//nolint:funlen
+func newMigrateCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	var migrateAll bool
+	var dryRun bool
+	var from string
+	var undo bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate [binaries]",
+		Short: "Migrate specific binaries or all with --all",
+		Long: `Migrate binaries to be managed internally. You can migrate specific binaries or all binaries.
+The build flags of the binaries (build tags, linker flags and CGO) are recorded, so that upgrades rebuild
+them with equivalent flags.
+If --from flag is specified, binaries are adopted from the given directory instead of the Go binary path:
+they are moved to the internal binary directory and pinned to the Go binary path.
+If --dry-run flag is specified, it reports which binaries would be migrated, which are already managed,
+and which would fail, without changing anything.
+If --undo flag is specified, managed binaries are restored to plain files in their pre-migration path,
+removing the versioned binary from the internal binary directory.
+
+Examples:
+  gobin migrate dlv                        # Migrate specific binary
+  gobin migrate dlv golangci-lint mockery  # Migrate multiple binaries  
+  gobin migrate --all                 	   # Migrate all binaries
+  gobin migrate --all --dry-run            # Report what would be migrated
+  gobin migrate --all --from /usr/local/bin  # Adopt all binaries from another directory
+  gobin migrate dlv --undo                 # Restore binary to a plain file
+  gobin migrate --all --undo               # Restore all managed binaries to plain files`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bins := make([]model.Binary, len(args))
+			for i, arg := range args {
+				bin := newBinary(arg)
+				if !bin.IsValid() {
+					err := newInvalidArgError("binary", arg, bin.Version)
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
+
+				bins[i] = bin
+			}
+
+			switch {
+			case migrateAll && len(args) > 0:
+				err := errors.New("cannot use --all with specific binaries")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case undo && (dryRun || from != ""):
+				err := errors.New("cannot use --undo with --dry-run or --from")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case undo && (migrateAll || len(args) > 0):
+				return gobin.UnmigrateBinaries(bins...)
+
+			case migrateAll:
+				return gobin.MigrateBinaries(from, dryRun)
+
+			case len(args) == 0:
+				err := errors.New("no binaries specified (use --all to migrate all)")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			default:
+				return gobin.MigrateBinaries(from, dryRun, bins...)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&migrateAll,
+		"all",
+		"a",
+		false,
+		"migrates all binaries",
+	)
+
+	cmd.Flags().BoolVarP(
+		&dryRun,
+		"dry-run",
+		"n",
+		false,
+		"reports what would be migrated without changing anything",
+	)
+
+	cmd.Flags().StringVar(
+		&from,
+		"from",
+		"",
+		"adopts binaries from the given directory (default: Go binary path)",
+	)
+
+	cmd.Flags().BoolVarP(
+		&undo,
+		"undo",
+		"u",
+		false,
+		"restores managed binaries to plain files",
+	)
+
+	return cmd
+}
+
+// newOutdatedCmd creates a outdated command to list outdated binaries.
+func newOutdatedCmd(gobin *gobin.Gobin) *cobra.Command {
+	var checkMajor bool
+	var checkGo bool
+	var exitCode bool
+
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "List outdated binaries",
+		Long: `List binaries that have newer versions available. By default, only minor and patch
+updates are shown. Use --major to include potentially breaking major version upgrades. If a binary
+is pinned, it will check the latest version available for the pinned version. 
+If --go flag is specified, it lists instead the binaries built with a Go version that has a newer
+patch release, carrying security fixes, according to the Go release feed (go.dev/dl), to rebuild
+with gobin upgrade --stale-go.
+If --exit-code flag is specified, the number of outdated binaries is printed to the standard error
+and the command exits with code 8 when any binary is outdated, to fail CI jobs on tooling drift.
+
+Examples:
+  gobin outdated                       # Show outdated binaries (minor/patch only)
+  gobin outdated --major               # Include major version upgrades
+  gobin outdated --go                  # Show binaries built with an outdated Go patch release
+  gobin outdated --exit-code           # Exit with code 8 if any binary is outdated`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			if checkMajor && checkGo {
+				err := errors.New("cannot use --major with --go")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+
+			return gobin.ListOutdatedBinaries(cmd.Context(), checkMajor, checkGo, exitCode, parallelism)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&checkMajor,
+		"major",
+		"m",
+		false,
+		"checks for major versions",
+	)
+
+	cmd.Flags().BoolVar(
+		&checkGo,
+		"go",
+		false,
+		"checks for newer Go patch releases of the Go versions the binaries are built with",
+	)
+
+	cmd.Flags().BoolVar(
+		&exitCode,
+		"exit-code",
+		false,
+		"exits with code 8 if any binary is outdated",
+	)
+
+	return cmd
+}
+
+// newPinCmd creates a pin command to pin managed binaries.
+func newPinCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	kind := model.KindLatest
+
+	cmd := &cobra.Command{
+		Use:   "pin [binaries]",
+		Short: "Pin binaries to the Go binary path",
+		Long: `Pin managed binaries to the Go binary path. The version "previous" pins the version pinned before the
+current one.
+
+Examples:
+  gobin pin dlv                              # Pin latest version (dlv)
+  gobin pin dlv@v1                           # Pin latest v1 minor version (dlv)
+  gobin pin dlv@v1.25                        # Pin latest v1.25 patch version (dlv)
+  gobin pin dlv@v1.25.1                      # Pin specific version (dlv)
+  gobin pin dlv@previous                     # Pin previous version (dlv)
+  gobin pin dlv mockery@3.5                  # Pin multiple binaries to latest version (dlv, mockery)
+  gobin pin dlv@v1 --kind major              # Pin latest v1 minor version (dlv-v1)
+  gobin pin dlv@v1.25 --kind minor           # Pin latest v1.25 patch version (dlv-v1.25)`,
+		Args: cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			bins, err := getBinariesAutoComplete(fs, workspace.GetInternalBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bins := make([]model.Binary, len(args))
+			for i, arg := range args {
+				bin := newBinary(arg)
+				if !bin.IsValid() {
+					err := newInvalidArgError("binary", arg, bin.Version)
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
+
+				bins[i] = bin
+			}
+
+			return gobin.PinBinaries(kind, bins...)
+		},
+	}
+
+	cmd.Flags().VarP(
+		&kind,
+		"kind",
+		"k",
+		"pin kind [latest (default), major, minor]",
+	)
+
+	return cmd
+}
+
+// newProtectCmd creates a protect command to protect pinned binaries from being
+// upgraded, pruned, or uninstalled.
+//
+//nolint:dupl // ignore duplicate code lint check
+func newProtectCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "protect [binaries]",
+		Short: "Protect binaries from upgrade, prune and uninstall",
+		Long: `Protect binaries pinned to the Go binary path. Protected binaries are skipped by bulk operations
+(upgrade --all, prune --all) and refused by explicit upgrade, prune and uninstall unless --force is specified.
+
+Examples:
+  gobin protect dlv                        # Protect specific binary
+  gobin protect dlv-v1 golangci-lint       # Protect multiple binaries`,
+		Args: cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bins := make([]model.Binary, len(args))
+			for i, arg := range args {
+				bin := newBinary(arg)
+				if !bin.IsValid() {
+					err := newInvalidArgError("binary", arg, bin.Version)
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
+
+				bins[i] = bin
+			}
+
+			return gobin.ProtectBinaries(true, bins...)
+		},
+	}
+}
+
+// newPruneCmd creates a prune command to prune binaries.
+//
+//nolint:dupl // ignore duplicate code lint check
+func newPruneCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	var pruneAll bool
+	var policy bool
+	var force bool
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "prune [binaries]",
+		Short: "Prune specific binaries or all with --all",
+		Long: `Prune binaries from the internal binary directory. You can prune specific binaries or all binaries.
+
+With --policy, prunes the versions not kept by the retention policy set in the configuration
+(retention.keep_last and retention.keep_days), keeping the pinned versions.
+
+With --dry-run, prints the versions that would be removed, without removing them.
+
+Examples:
+  gobin prune dlv                        # Prune specific binary
+  gobin prune dlv@v1                     # Prune specific binary with major version
+  gobin prune dlv@v1.25                  # Prune specific binary with minor version
+  gobin prune dlv@v1.25.1                # Prune specific binary with patch version
+  gobin prune dlv golangci-lint mockery  # Prune multiple binaries
+  gobin prune --all                 	 # Prune all binaries (skips protected binaries)
+  gobin prune --policy                   # Prune versions not kept by the retention policy
+  gobin prune --all --dry-run            # Print the versions that would be pruned
+  gobin prune dlv --force                # Prune protected binary`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			bins, err := getBinariesAutoComplete(fs, workspace.GetInternalBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bins := make([]model.Binary, len(args))
+			for i, arg := range args {
+				bin := newBinary(arg)
+				if !bin.IsValid() {
+					err := newInvalidArgError("binary", arg, bin.Version)
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
+
+				bins[i] = bin
+			}
+
+			gobin.SetDryRun(dryRun)
+
+			switch {
+			case pruneAll && len(args) > 0:
+				err := errors.New("cannot use --all with specific binaries")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case policy && (pruneAll || len(args) > 0):
+				err := errors.New("cannot use --policy with --all or specific binaries")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case policy:
+				return gobin.PruneBinariesByPolicy(force)
+
+			case pruneAll:
+				return gobin.PruneBinaries(force)
+
+			case len(args) == 0:
+				err := errors.New("no binaries specified (use --all to prune all)")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			default:
+				return gobin.PruneBinaries(force, bins...)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&pruneAll,
+		"all",
+		"a",
+		false,
+		"prunes all binaries",
+	)
+
+	cmd.Flags().BoolVar(
+		&policy,
+		"policy",
+		false,
+		"prunes the versions not kept by the retention policy",
+	)
+
+	cmd.Flags().BoolVarP(
+		&force,
+		"force",
+		"f",
+		false,
+		"prunes protected binaries",
+	)
+
+	addDryRunFlag(cmd, &dryRun)
+
+	return cmd
+}
+
+// newPullCmd creates a pull command to install a binary from an OCI artifact.
+func newPullCmd(gobin *gobin.Gobin) *cobra.Command {
+	kind := model.KindLatest
+
+	cmd := &cobra.Command{
+		Use:   "pull [reference]",
+		Short: "Pull a binary from an OCI registry",
+		Long: `Pull a binary pushed with gobin push from an OCI registry, and pin it to the Go binary path. The binary
+must be built for the current platform, and its build info must match the module and version annotated in the
+artifact.
+
+Examples:
+  gobin pull oci://ghcr.io/org/tools:dlv               # Pull and pin latest version (dlv)
+  gobin pull oci://ghcr.io/org/tools:dlv --kind major  # Pull and pin latest major version (dlv-v1)
+
+Registries requiring authentication use the credentials from the GOBIN_REGISTRY_USERNAME and
+GOBIN_REGISTRY_PASSWORD environment variables, ex. a personal access token as the password.`,
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			ref, err := model.ParseOCIReference(args[0])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.PullBinary(cmd.Context(), ref, kind)
+		},
+	}
+
+	cmd.Flags().VarP(
+		&kind,
+		"kind",
+		"k",
+		"pin kind [latest (default), major, minor]",
+	)
+
+	return cmd
+}
+
+// newPushCmd creates a push command to publish a managed binary as an OCI
+// artifact.
+func newPushCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "push [binary] [reference]",
+		Short: "Push a binary to an OCI registry",
+		Long: `Push a managed binary to an OCI registry as an artifact, annotated with its package, module, version,
+checksum, Go version, platform and build flags, to share prebuilt tools with gobin pull.
+
+Examples:
+  gobin push dlv oci://ghcr.io/org/tools:dlv             # Push binary (dlv)
+  gobin push dlv-v1 oci://ghcr.io/org/tools:dlv-v1       # Push pinned major version (dlv-v1)
+  gobin push dlv oci://localhost:5000/tools:dlv          # Push binary to a local registry over HTTP
+
+Registries requiring authentication use the credentials from the GOBIN_REGISTRY_USERNAME and
+GOBIN_REGISTRY_PASSWORD environment variables, ex. a personal access token as the password.`,
+		Args: cobra.ExactArgs(2), //nolint:mnd // binary and reference arguments
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			ref, err := model.ParseOCIReference(args[1])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.PushBinary(cmd.Context(), bin, ref)
+		},
+	}
+}
+
+// newRelinkCmd creates a relink command to rebuild linked binaries from their
+// local directories.
+func newRelinkCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	var interval time.Duration
+	var watch bool
+
+	cmd := &cobra.Command{
+		Use:   "relink [binaries]",
+		Short: "Rebuild linked binaries from their local directories",
+		Long: `Relink rebuilds binaries linked with gobin link, or installed with gobin install --path, from the local
+directories recorded in their receipts, with the same build flags. Without binaries, all the linked binaries are
+rebuilt. With --watch, the directories are then checked for changes at every interval, and the binaries whose files
+changed are rebuilt, until interrupted. Build failures while watching are reported without stopping.
+
+Examples:
+  gobin relink                          # Rebuild all linked binaries
+  gobin relink mytool                   # Rebuild a linked binary
+  gobin relink mytool --watch           # Rebuild a linked binary whenever its files change
+  gobin relink --watch --interval 5s    # Check all linked binaries for changes every 5 seconds`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if interval <= 0 {
+				intervalErr := errors.New("interval must be greater than 0")
+				fmt.Fprintln(os.Stderr, intervalErr.Error())
+				return intervalErr
+			}
+
+			bins := make([]model.Binary, len(args))
+			for i, arg := range args {
+				bin := newBinary(arg)
+				if !bin.IsValid() {
+					err := newInvalidArgError("binary", arg, bin.Version)
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
+
+				bins[i] = bin
+			}
+
+			return gobin.RelinkBinaries(cmd.Context(), watch, interval, bins...)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&watch,
+		"watch",
+		"w",
+		false,
+		"rebuilds the binaries whenever their files change, until interrupted",
+	)
+
+	cmd.Flags().DurationVar(
+		&interval,
+		"interval",
+		time.Second,
+		"interval between two checks for changes with --watch, ex. 5s",
+	)
+
+	return cmd
+}
+
+// newRepoCmd creates a repo command to show/open the repository URL for a
+// binary.
+func newRepoCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	var open bool
+
+	cmd := &cobra.Command{
+		Use:   "repo [binary]",
+		Short: "Show binary repository",
+		Long: `Show the repository URL for a Go binary.
+
+Examples:
+  gobin repo dlv                       # Print repository URL
+  gobin repo dlv --open                # Open repository in browser
+
+The repository URL is determined from the module's origin information,
+falling back to constructing the URL from the module path if not available.`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.ShowBinaryRepository(cmd.Context(), bin, open)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&open,
+		"open",
+		"o",
+		false,
+		"opens the repository in the default browser",
+	)
+
+	return cmd
+}
+
+// newReproduceCmd creates a reproduce command to rebuild a binary and compare it
+// with the installed one.
+func newReproduceCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reproduce [binary]",
+		Short: "Rebuild a binary and compare it with the installed one",
+		Long: `Rebuild a managed binary in a temporary directory with the same version, build flags, Go toolchain and
+platform, and compare the SHA-256 hashes of the installed and rebuilt binaries. The installed binary is left
+untouched. A binary not reproducible exits with a failure, listing the build settings that differ, if any.
+
+Examples:
+  gobin reproduce dlv                  # Rebuild and compare (dlv)
+  gobin reproduce dlv-v1               # Rebuild and compare pinned major version (dlv-v1)
+
+The Go toolchain the binary was built with is selected with GOTOOLCHAIN, downloading it if it is not the local
+one.`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.ReproduceBinary(cmd.Context(), bin)
+		},
+	}
+}
+
+// newRollbackCmd creates a rollback command to pin binaries to the version
+// preceding the pinned one.
+func newRollbackCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	var list bool
+
+	cmd := &cobra.Command{
+		Use:   "rollback [binaries]",
+		Short: "Roll back binaries to the previous version",
+		Long: `Roll back binaries to the version preceding the pinned one that is still in the internal binary path,
+matching the pin kind, ex. the previous v1 version for dlv-v1, without rebuilding it. The version rolled back from is
+recorded as the previous version, so the rollback can be undone with gobin pin <binary>@previous. With --list, the
+versions a binary can be rolled back to are listed instead, from the newest to the oldest.
+
+Examples:
+  gobin rollback dlv                  # Roll back to the previous version (dlv)
+  gobin rollback dlv-v1 mockery       # Roll back multiple binaries (dlv-v1, mockery)
+  gobin rollback dlv --list           # List the versions to roll back to (dlv)
+
+Versions removed with gobin prune cannot be rolled back to, install them again with gobin install.`,
+		Args: cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if list && len(args) > 1 {
+				err := errors.New("cannot use --list with multiple binaries")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			bins := make([]model.Binary, len(args))
+			for i, arg := range args {
+				bin := newBinary(arg)
+				if !bin.IsValid() {
+					err := newInvalidArgError("binary", arg, bin.Version)
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
+
+				bins[i] = bin
+			}
+
+			if list {
+				return gobin.ListRollbackVersions(bins[0])
+			}
+
+			return gobin.RollbackBinaries(bins...)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&list,
+		"list",
+		"l",
+		false,
+		"lists the versions to roll back to",
+	)
+
+	return cmd
+}
+
+// newRunCmd creates a run command to run a package without installing it.
+func newRunCmd(gobin *gobin.Gobin, exec system.Exec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run [package] [-- args]",
+		Short: "Run a package without installing it",
+		Long: `Run the binary of a package with the given arguments, without installing it in the Go binary
+directory. The binary is built into the internal binary directory the first time and reused by the next runs of the
+same version, so only resolving the version hits the network, or nothing at all for an exact version already built.
+The binary runs attached to the terminal, and gobin exits with its exit code. The binaries kept for gobin run are
+removed by gobin prune --all, as they are not pinned to the Go binary directory. Packages matching the deny list of
+the config file are refused.
+
+Examples:
+  gobin run golang.org/x/vuln/cmd/govulncheck ./...                          # Run latest version (govulncheck)
+  gobin run golang.org/x/tools/cmd/stringer@v0.35.0 -- -type=Kind             # Run specific version (stringer)
+  gobin run github.com/golangci/golangci-lint/v2/cmd/golangci-lint@v2 -- run  # Run latest v2 version
+
+The package version is optional, defaults to "latest".
+The arguments after the package are passed to the binary, -- is only needed before flags meant for the binary.`,
+		Args:          cobra.MinimumNArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			pkg := model.NewPackage(args[0])
+			if !pkg.IsValid() {
+				err := newInvalidArgError("package", args[0], pkg.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			binArgs := args[1:]
+			if len(binArgs) > 0 && binArgs[0] == "--" {
+				binArgs = binArgs[1:]
+			}
+
+			path, err := gobin.GetRunBinary(cmd.Context(), pkg)
+			if err != nil {
+				return err
+			}
+
+			code, err := exec.Attach(cmd.Context(), path, binArgs...).Run()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+				return err
+			}
+
+			if code != 0 {
+				return &exitCodeError{code: code}
+			}
+
+			return nil
+		},
+	}
+
+	// flags after the package are passed to the binary
+	cmd.Flags().SetInterspersed(false)
+
+	return cmd
+}
+
+// newSBOMCmd creates an sbom command to generate the software bill of
+// materials of a binary.
+func newSBOMCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	var format string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "sbom [binary]",
+		Short: "Generate the SBOM of a binary",
+		Long: `Generate the software bill of materials (SBOM) of a binary in the Go binary path, from the module
+dependencies embedded in its build info, as a CycloneDX 1.5 or SPDX 2.3 JSON document. The document lists the main
+module of the binary and each module compiled into it, with its version and package URL, ex. for compliance
+pipelines keeping an inventory of the tools. A replaced dependency is listed as its replacement. If --output flag is
+specified, the document is written to the given path instead of the standard output.
+
+Examples:
+  gobin sbom dlv                            # Print the CycloneDX SBOM of dlv
+  gobin sbom dlv --format spdx              # Print the SPDX SBOM of dlv
+  gobin sbom dlv --output dlv.cdx.json      # Write the CycloneDX SBOM of dlv to dlv.cdx.json`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			sbomFormat, err := model.NewSBOMFormat(format)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.ExportSBOM(bin, sbomFormat, output)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&format,
+		"format",
+		model.SBOMFormatCycloneDX.String(),
+		"format of the SBOM [cyclonedx, spdx]",
+	)
+
+	cmd.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"-",
+		"writes the SBOM to the given path",
+	)
+
+	return cmd
+}
+
+// newSearchCmd creates a search command to search the package index for
+// packages.
+func newSearchCmd(gobin *gobin.Gobin) *cobra.Command {
+	var limit int
+	var install bool
+
+	cmd := &cobra.Command{
+		Use:   "search [terms]",
+		Short: "Search the package index for packages",
+		Long: `Search the package index of pkg.go.dev for packages matching the given terms, printing their import paths
+and synopses. Commands, ie. main packages installable as binaries, are highlighted. With --install, the first command
+found is installed at its latest version, as with gobin install.
+
+Examples:
+  gobin search stringer             # Search for packages matching "stringer"
+  gobin search --limit 5 protobuf   # Print up to 5 packages matching "protobuf"
+  gobin search --install stringer   # Install the first command matching "stringer"`,
+		Args:          cobra.MinimumNArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if limit < 1 {
+				err := errors.New("limit must be greater than 0")
+				fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+				return err
+			}
+
+			query := strings.Join(args, " ")
+			pkgs, err := gobin.SearchPackages(cmd.Context(), query, limit)
+			if err != nil || !install {
+				return err
+			}
+
+			if len(pkgs) == 0 {
+				err = fmt.Errorf("no command found matching %q", query)
+				fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+				return err
+			}
+
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+			return gobin.InstallPackages(
+				cmd.Context(), parallelism, model.KindLatest, model.BuildFlags{}, false, false, false, 0, pkgs[0],
+			)
+		},
+	}
+
+	cmd.Flags().IntVarP(
+		&limit,
+		"limit",
+		"n",
+		10, //nolint:mnd // default number of search results
+		"maximum number of packages printed",
+	)
+
+	cmd.Flags().BoolVar(
+		&install,
+		"install",
+		false,
+		"installs the first command found",
+	)
+
+	return cmd
+}
+
+// newSelfUpdateCmd creates a self-update command to update gobin to its latest
+// release.
+func newSelfUpdateCmd(gobin *gobin.Gobin) *cobra.Command {
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update gobin to the latest release",
+		Long: `Update gobin to the latest release of its module in the module proxy, within the same major version. The
+module is read from the build info of the running executable. When gobin is managed by itself, its pin in the Go binary
+path is upgraded like any other binary; otherwise, the executable is rebuilt and replaced in place. On Windows, the
+running executable is renamed aside and removed on the next run. With --check, the latest release is only reported.
+
+Examples:
+  gobin self-update          # Update gobin to the latest release
+  gobin self-update --check  # Check for a newer release only
+
+Development builds cannot be updated, install a release with go install first.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			path, err := os.Executable()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+				return err
+			}
+
+			return gobin.SelfUpdate(cmd.Context(), path, check)
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&check,
+		"check",
+		false,
+		"only reports whether a newer release is available",
+	)
+
+	return cmd
+}
+
+// newServeCacheCmd creates a serve-cache command to serve the managed binaries
+// as a binary cache.
+func newServeCacheCmd(gobin *gobin.Gobin) *cobra.Command {
+	var listen string
+
+	cmd := &cobra.Command{
+		Use:   "serve-cache",
+		Short: "Serve the managed binaries as a binary cache",
+		Long: `Serve the managed binaries over HTTP as a binary cache, so other machines of a team can download prebuilt
+binaries with gobin install --cache-from or gobin upgrade --cache-from instead of compiling them. Each binary is keyed
+by its module version and platform, and served with the build flags of its receipt and its sha256 digest, verified by
+the clients along with its build info before installing it. Binaries compressed with UPX and development builds are
+not served. The binaries are listed on each request, so the ones installed or upgraded while serving are served too.
+
+It runs in the foreground until interrupted. The server has no authentication, serve it on a trusted network only.
+
+Examples:
+  gobin serve-cache                  # Serve on localhost:8080
+  gobin serve-cache --listen :8080   # Serve on port 8080 of all interfaces`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			return gobin.ServeCache(cmd.Context(), listen)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&listen,
+		"listen",
+		"localhost:8080",
+		"address to listen on, ex. :8080",
+	)
+
+	return cmd
+}
+
+// newSizeCmd creates a size command to show the size of a binary and its
+// history across versions.
+func newSizeCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	var history bool
+
+	cmd := &cobra.Command{
+		Use:   "size [binary]",
+		Short: "Show binary size and its history",
+		Long: `Show the size of a binary in the Go binary path. The size of each version installed to a binary is
+recorded in its receipt on install and upgrade, and --history shows it across versions, with a sparkline and the change
+from the previous version, in red when growing, to notice when a tool balloons after an upgrade. Rebuilding a version
+replaces its recorded size.
+
+Examples:
+  gobin size dlv              # Print size of the installed version
+  gobin size dlv --history    # Print size of each version installed`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.PrintBinarySize(bin, history)
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&history,
+		"history",
+		false,
+		"shows the size of each version installed",
+	)
+
+	return cmd
+}
+
+// newSyncCmd creates a sync command to install and uninstall the managed
+// binaries to match a lockfile.
+func newSyncCmd(gobin *gobin.Gobin) *cobra.Command {
+	var assumeYes bool
+	var maxDownload model.ByteSize
+
+	cmd := &cobra.Command{
+		Use:   "sync [file]",
+		Short: "Sync the managed binaries with a lockfile",
+		Long: `Install and uninstall the managed binaries so the Go binary directory matches a lockfile, gobin.lock in
+the current directory by default, created with gobin export. The binaries of the lockfile not installed, or installed
+from another package or version, are installed at the locked version with the locked pin kind, and the managed binaries
+missing from the lockfile are uninstalled, skipping protected binaries. The download size of the modules to install is
+estimated first, and with --max-download nothing is changed if the estimate exceeds the given size. The changes are
+listed and confirmed before being applied, use --yes to skip the confirmation, ex. in CI.
+
+Examples:
+  gobin sync                        # Sync with gobin.lock
+  gobin sync tools/gobin.lock       # Sync with a specific file
+  gobin sync --yes                  # Sync without confirmation
+  gobin sync --max-download 200MB   # Sync if download is at most 200MB`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+
+			path := model.DefaultLockfileName
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			return gobin.SyncLockfile(cmd.Context(), path, parallelism, maxDownload, assumeYes)
+		},
+	}
+
+	cmd.Flags().Var(
+		&maxDownload,
+		"max-download",
+		"refuse to sync when the estimated download size exceeds this size, ex. 500MB",
+	)
+
+	cmd.Flags().BoolVarP(
+		&assumeYes,
+		"yes",
+		"y",
+		false,
+		"skips confirmation prompts",
+	)
+
+	return cmd
+}
+
+// newUninstallCmd creates a uninstall command to uninstall a binary.
+//
+//nolint:funlen
+func newUninstallCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	var uninstallAll bool
+	var force bool
+	var purge bool
+	var prune bool
+	var assumeYes bool
+	var null bool
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "uninstall [binaries]",
+		Short: "Uninstall specific binaries or all managed with --all",
+		Long: `Uninstall binaries from the Go binary path. You can uninstall specific binaries or all binaries managed by gobin.
+When uninstalling all binaries, the list of binaries is shown and a confirmation is requested, unless --yes
+flag is specified. If --purge flag is specified, every version of the binaries in the internal binary directory,
+their receipts, exec shims and data (debug information and detached signatures) are removed too. If --prune
+flag is specified, the versions in the internal binary directory are pruned too. When uninstalling specific
+binaries, other pins still referencing the same binary are reported and a confirmation is requested to
+uninstall them too, unless --yes flag is specified. If the only binary is -, the binaries are read from stdin,
+separated by new lines or, if --null flag is specified, by NUL characters, and the confirmations are declined
+unless --yes flag is specified. If --dry-run flag is specified, the files that would be removed are printed,
+without asking for confirmation.
+
+Examples:
+  gobin uninstall dlv                        # Uninstall specific binary
+  gobin uninstall dlv golangci-lint mockery  # Uninstall multiple binaries
+  gobin uninstall dlv --force                # Uninstall protected binary
+  gobin uninstall dlv --purge                # Uninstall binary removing all versions, receipts and data
+  gobin uninstall dlv --purge --dry-run      # Print the files the purge would remove
+  gobin uninstall --yes - < binaries.txt     # Uninstall binaries read from stdin
+  gobin uninstall --all                      # Uninstall all managed binaries
+  gobin uninstall --all --prune --yes        # Uninstall and prune all managed binaries without confirmation`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			gobin.SetStdinArgs(slices.Contains(args, "-"))
+
+			args, err := getStdinArgs(args, os.Stdin, null)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			bins := make([]model.Binary, len(args))
+			for i, arg := range args {
+				bin := newBinary(arg)
+				if !bin.IsValid() {
+					err := newInvalidArgError("binary", arg, bin.Version)
+					fmt.Fprintln(os.Stderr, err.Error())
+					return err
+				}
+
+				bins[i] = bin
+			}
+
+			gobin.SetDryRun(dryRun)
+
+			switch {
+			case uninstallAll && len(args) > 0:
+				err := errors.New("cannot use --all with specific binaries")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case uninstallAll:
+				return gobin.UninstallAllBinaries(force, purge, prune, assumeYes)
+
+			case len(args) == 0:
+				err := errors.New("no binaries specified (use --all to uninstall all)")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case prune:
+				err := errors.New("cannot use --prune with specific binaries")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			default:
+				return gobin.UninstallBinaries(force, purge, assumeYes, bins...)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&uninstallAll,
+		"all",
+		"a",
+		false,
+		"uninstalls all managed binaries",
+	)
+
+	cmd.Flags().BoolVarP(
+		&force,
+		"force",
+		"f",
+		false,
+		"uninstalls protected binaries",
+	)
+
+	cmd.Flags().BoolVar(
+		&purge,
+		"purge",
+		false,
+		"removes every version, receipt and data of the binaries",
+	)
+
+	cmd.Flags().BoolVar(
+		&prune,
+		"prune",
+		false,
+		"prunes all binaries from the internal binary directory (requires --all)",
+	)
+
+	cmd.Flags().BoolVarP(
+		&assumeYes,
+		"yes",
+		"y",
+		false,
+		"skips confirmation prompts",
+	)
+
+	cmd.Flags().BoolVarP(
+		&null,
+		"null",
+		"0",
+		false,
+		"separates binaries read from stdin by NUL characters",
+	)
+
+	addDryRunFlag(cmd, &dryRun)
+
+	return cmd
+}
+
+// newUnprotectCmd creates a unprotect command to remove the protection from
+// pinned binaries.
+//
+//nolint:dupl // ignore duplicate code lint check
+func newUnprotectCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unprotect [binaries]",
+		Short: "Remove protection from binaries",
+		Long: `Remove protection from binaries pinned to the Go binary path.
+
+Examples:
+  gobin unprotect dlv                      # Unprotect specific binary
+  gobin unprotect dlv-v1 golangci-lint     # Unprotect multiple binaries`,
+		Args: cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
 			bins := make([]model.Binary, len(args))
 			for i, arg := range args {
-				bin := model.NewBinaryFromString(arg)
+				bin := newBinary(arg)
 				if !bin.IsValid() {
-					err := fmt.Errorf("invalid binary argument: %s", arg)
+					err := newInvalidArgError("binary", arg, bin.Version)
 					fmt.Fprintln(os.Stderr, err.Error())
 					return err
 				}
@@ -674,7 +3342,7 @@ func newUninstallCmd(
 				bins[i] = bin
 			}
 
-			return gobin.UninstallBinaries(bins...)
+			return gobin.ProtectBinaries(false, bins...)
 		},
 	}
 }
@@ -688,34 +3356,81 @@ func newUpgradeCmd(
 	workspace system.Workspace,
 ) *cobra.Command {
 	var upgradeAll bool
+	var adaptive bool
+	var cacheFrom string
+	var interactive bool
 	var majorUpgrade bool
 	var rebuild bool
+	var staleGo bool
+	var force bool
+	var null bool
+	var timings bool
+	var upx bool
+	var dryRun bool
+	var flags model.BuildFlags
+	var buildEnv []string
 
 	cmd := &cobra.Command{
 		Use:   "upgrade [binaries]",
 		Short: "Upgrade specific binaries or all with --all",
 		Long: `Upgrade binaries to their latest versions. You can upgrade specific binaries or all outdated ones.
 If a binary is pinned, it will be upgraded to the latest pinned version available.
+If --interactive flag is specified, the outdated binaries are listed with their current and latest versions, and the
+binaries to upgrade are selected by their numbers or ranges, ex. "1 3-5", or all.
 If --major flag is specified, the binary will be upgraded to the latest major version available.
 If --rebuild flag is specified, the binary will be rebuilt even if it is up-to-date.
+If --stale-go flag is specified, only the binaries built with a Go version that has a newer patch release are upgraded,
+rebuilt with the latest patch release (see gobin outdated --go), downloaded through GOTOOLCHAIN if not the local one.
+Protected binaries are skipped with --all and refused otherwise, unless --force flag is specified.
+The --goarm, --goamd64 and --goarm64 flags override the architecture variant recorded in the binary receipt, use
+--rebuild to switch the variant of an up-to-date binary.
+The --tags, --ldflags and --env flags override the build tags, linker flags and build environment variables recorded in
+the binary receipt at install, and are recorded in turn for the next upgrades.
+If --strip flag is specified, the binary is built without its symbol table and debug information (-ldflags=-s -w), and
+the setting is recorded in the binary receipt for the next upgrades, as is --debug-info, keeping the debug information
+of the stripped binary apart, and --provenance, recording the gobin version, package spec and command in the build info.
+If --timings flag is specified, the wall time spent per phase (version resolution, download, compile, link/copy) is
+reported for each binary and in aggregate at the end.
+If --upx flag is specified, or the upx key of the config file is set, the upgraded binaries are compressed with UPX.
+If --cache-from flag is specified, matching prebuilt binaries are downloaded from a binary cache server started with
+gobin serve-cache instead of compiling, falling back to building when the server has none.
+The parallel upgrades are throttled under memory pressure or high load, starting no build while the system is under
+pressure unless none is running, to prevent the builds from exhausting the memory (Linux and macOS), unless
+--adaptive=false is specified.
+If --dry-run flag is specified, the binaries that would be built and the pins that would be replaced are printed,
+without building anything.
+If the only binary is -, the binaries are read from stdin, separated by new lines or, if --null flag is
+specified, by NUL characters.
 
 Examples:
   gobin upgrade dlv                        # Upgrade specific binary
   gobin upgrade dlv golangci-lint mockery  # Upgrade multiple binaries  
   gobin upgrade --all                 	   # Upgrade all outdated binaries
   gobin upgrade --all --major              # Include major version upgrades
+  gobin upgrade --all --dry-run            # Print the upgrade actions
+  gobin upgrade --interactive              # Select the outdated binaries to upgrade
+  gobin upgrade --all -p 8 --adaptive=false # Upgrade 8 binaries at a time regardless of the system pressure
   gobin upgrade dlv-v1 --rebuild           # Force rebuild even if up-to-date
-  gobin upgrade --all --rebuild            # Rebuild all binaries with current Go version`,
+  gobin upgrade --all --rebuild            # Rebuild all binaries with current Go version
+  gobin upgrade --all --stale-go           # Rebuild binaries with the latest Go patch releases
+  gobin upgrade dlv --force                # Upgrade protected binary
+  gobin upgrade dlv --rebuild --goamd64 v3 # Rebuild for x86-64-v3
+  gobin upgrade dlv --rebuild --upx        # Rebuild compressed with UPX
+  gobin upgrade dlv --rebuild --strip      # Rebuild without symbols
+  gobin upgrade dlv --rebuild --tags netgo # Rebuild with other build tags
+  gobin upgrade dlv --rebuild --provenance # Rebuild recording gobin provenance
+  gobin upgrade --all --cache-from URL     # Upgrade from a binary cache server
+  gobin upgrade -0 - < binaries.txt        # Upgrade NUL separated binaries read from stdin`,
 		Args: cobra.ArbitraryArgs,
 		ValidArgsFunction: func(
-			_ *cobra.Command, _ []string, toComplete string,
+			_ *cobra.Command, args []string, toComplete string,
 		) ([]string, cobra.ShellCompDirective) {
-			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), toComplete)
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
 			if err != nil {
 				return nil, cobra.ShellCompDirectiveError
 			}
 
-			return bins, cobra.ShellCompDirectiveDefault
+			return bins, cobra.ShellCompDirectiveNoFileComp
 		},
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -723,11 +3438,24 @@ Examples:
 
 			parallelism, _ := cmd.Flags().GetInt("parallelism")
 
+			gobin.SetStdinArgs(slices.Contains(args, "-"))
+
+			args, err := getStdinArgs(args, os.Stdin, null)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			if flags, err = flags.WithEnv(buildEnv...); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
 			bins := make([]model.Binary, len(args))
 			for i, arg := range args {
-				bin := model.NewBinaryFromString(arg)
+				bin := newBinary(arg)
 				if !bin.IsValid() {
-					err := fmt.Errorf("invalid binary argument: %s", arg)
+					err := newInvalidArgError("binary", arg, bin.Version)
 					fmt.Fprintln(os.Stderr, err.Error())
 					return err
 				}
@@ -735,17 +3463,56 @@ Examples:
 				bins[i] = bin
 			}
 
+			gobin.SetDryRun(dryRun)
+
+			if cacheFrom != "" {
+				gobin.SetCacheFrom(cacheFrom)
+			}
+
+			if cmd.Flags().Changed("upx") {
+				gobin.SetCompress(upx)
+			}
+
 			switch {
 			case upgradeAll && len(args) > 0:
 				err := errors.New("cannot use --all with specific binaries")
 				fmt.Fprintln(os.Stderr, err.Error())
 				return err
 
+			case interactive && (upgradeAll || staleGo || len(args) > 0):
+				err := errors.New("cannot use --interactive with --all, --stale-go or specific binaries")
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+
+			case interactive:
+				selected, err := gobin.SelectOutdatedBinaries(cmd.Context(), majorUpgrade, parallelism)
+				if err != nil || len(selected) == 0 {
+					return err
+				}
+
+				return gobin.UpgradeBinaries(
+					cmd.Context(),
+					flags,
+					majorUpgrade,
+					rebuild,
+					staleGo,
+					force,
+					timings,
+					adaptive,
+					parallelism,
+					selected...,
+				)
+
 			case upgradeAll:
 				return gobin.UpgradeBinaries(
 					cmd.Context(),
+					flags,
 					majorUpgrade,
 					rebuild,
+					staleGo,
+					force,
+					timings,
+					adaptive,
 					parallelism,
 				)
 
@@ -757,8 +3524,13 @@ Examples:
 			default:
 				return gobin.UpgradeBinaries(
 					cmd.Context(),
+					flags,
 					majorUpgrade,
 					rebuild,
+					staleGo,
+					force,
+					timings,
+					adaptive,
 					parallelism,
 					bins...,
 				)
@@ -767,11 +3539,33 @@ Examples:
 	}
 
 	cmd.Flags().BoolVarP(
-		&upgradeAll,
-		"all",
-		"a",
+		&upgradeAll,
+		"all",
+		"a",
+		false,
+		"upgrades all binaries",
+	)
+
+	cmd.Flags().BoolVar(
+		&adaptive,
+		"adaptive",
+		true,
+		"throttles the parallel upgrades under memory pressure or high load",
+	)
+
+	cmd.Flags().StringVar(
+		&cacheFrom,
+		"cache-from",
+		"",
+		"downloads matching prebuilt binaries from a binary cache server instead of compiling",
+	)
+
+	cmd.Flags().BoolVarP(
+		&interactive,
+		"interactive",
+		"i",
 		false,
-		"upgrades all binaries",
+		"selects the outdated binaries to upgrade",
 	)
 
 	cmd.Flags().BoolVarP(
@@ -790,9 +3584,150 @@ Examples:
 		"forces binary rebuild",
 	)
 
+	cmd.Flags().BoolVar(
+		&staleGo,
+		"stale-go",
+		false,
+		"upgrades only binaries built with an outdated Go patch release, rebuilt with the latest one",
+	)
+
+	cmd.Flags().BoolVarP(
+		&force,
+		"force",
+		"f",
+		false,
+		"upgrades protected binaries",
+	)
+
+	cmd.Flags().BoolVarP(
+		&null,
+		"null",
+		"0",
+		false,
+		"separates binaries read from stdin by NUL characters",
+	)
+
+	cmd.Flags().BoolVar(
+		&timings,
+		"timings",
+		false,
+		"reports the wall time spent per phase",
+	)
+
+	cmd.Flags().BoolVar(
+		&upx,
+		"upx",
+		false,
+		"compresses the upgraded binaries with UPX, if available",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.Strip,
+		"strip",
+		false,
+		"strips the symbol table and debug information (-ldflags=-s -w)",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.DebugInfo,
+		"debug-info",
+		false,
+		"keeps the debug information of stripped binaries apart, see gobin debuginfo",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.Provenance,
+		"provenance",
+		false,
+		"records the gobin provenance in the build info of the binaries",
+	)
+
+	addDryRunFlag(cmd, &dryRun)
+
+	addBuildFlags(cmd, &flags, &buildEnv)
+	addVariantFlags(cmd, &flags)
+
 	return cmd
 }
 
+// newUseCmd creates a use command to switch a pinned binary between the
+// versions in the internal binary path.
+func newUseCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use [binary]@[version]",
+		Short: "Switch a binary between installed versions",
+		Long: `Switch a binary pinned to the Go binary path to another version in the internal binary path, matching the
+pin kind, ex. a v1 version for dlv-v1, without rebuilding it. The pin is replaced atomically and the version switched
+from is recorded as the previous version, so the switch can be undone with gobin pin <binary>@previous. Without a
+version, the installed versions are listed, from the newest to the oldest, to select the one to switch to.
+
+Examples:
+  gobin use dlv@v1.25.1               # Switch to a specific version (dlv)
+  gobin use dlv-v1@v1.24.4            # Switch a major version pin (dlv-v1)
+  gobin use dlv                       # Select the version to switch to (dlv)
+
+Versions not in the internal binary path cannot be switched to, install them with gobin install first.`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() || (!bin.Version.IsLatest() && !bin.Version.IsExact()) {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			pin := model.NewBinary(bin.Name, model.NewLatestVersion(), bin.Extension)
+			return gobin.UseBinary(pin, bin.Version)
+		},
+	}
+}
+
+// newVerifyPathCmd creates a verify-path command to detect managed binaries
+// shadowed by other executables in PATH.
+func newVerifyPathCmd(gobin *gobin.Gobin) *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify-path",
+		Short: "Detect binaries shadowed in PATH",
+		Long: `Verify that the managed binaries in the Go binary path are the ones run from PATH. A binary is shadowed
+when another executable of the same name is found in a directory earlier in PATH, which runs instead. For each shadowed
+binary, it reports the executable that runs and how to fix it: move the Go binary path before its directory in PATH,
+or rename or remove the shadowing executable if not needed. Symlinks to the managed binaries are not reported.
+
+This complements the duplicate check of gobin doctor, and fails when any binary is shadowed.
+
+Examples:
+  gobin verify-path                    # Report binaries shadowed in PATH`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			return gobin.VerifyPath()
+		},
+	}
+}
+
 // newVersionCmd creates a version command to print the version of the package.
 func newVersionCmd(gobin *gobin.Gobin) *cobra.Command {
 	var short bool
@@ -829,11 +3764,107 @@ func newVersionCmd(gobin *gobin.Gobin) *cobra.Command {
 	return cmd
 }
 
+// newWatchCmd creates a watch command to periodically check binaries for
+// upgrades and known vulnerabilities.
+func newWatchCmd(gobin *gobin.Gobin) *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Periodically check binaries and notify when action is needed",
+		Long: `Check the binaries in the Go binary path for upgrades and known vulnerabilities right away and then at
+every interval, until interrupted. When binaries are outdated or vulnerable, they are printed and a desktop
+notification is raised with notify-send on Linux, osascript on macOS or a toast on Windows.
+
+It runs in the foreground, and can be run in the background by a user service, ex. a systemd user unit,
+a launchd agent or a scheduled task running gobin watch at login.
+
+Examples:
+  gobin watch                  # Check every 24 hours
+  gobin watch --interval 6h    # Check every 6 hours`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			if interval <= 0 {
+				intervalErr := errors.New("interval must be greater than 0")
+				fmt.Fprintln(os.Stderr, intervalErr.Error())
+				return intervalErr
+			}
+
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+
+			return gobin.Watch(cmd.Context(), interval, parallelism)
+		},
+	}
+
+	cmd.Flags().DurationVar(
+		&interval,
+		"interval",
+		24*time.Hour, //nolint:mnd // one day
+		"interval between two checks, ex. 6h",
+	)
+
+	return cmd
+}
+
+// newWhyCmd creates a why command to explain where a binary came from.
+func newWhyCmd(
+	gobin *gobin.Gobin,
+	fs system.FileSystem,
+	workspace system.Workspace,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "why [binary]",
+		Short: "Explain where a binary came from",
+		Long: `Explain where a binary in the Go binary path came from, aggregating its build info and the metadata
+recorded by gobin: the package and module it is built from, how its version is resolved on upgrades (the latest
+release, or the latest release of a major or minor version when pinned), whether it is linked by gobin or installed
+by another tool, when it was installed, and the Go toolchain it was built with.
+
+Examples:
+  gobin why dlv       # Explain where dlv came from
+  gobin why dlv-v1    # Explain where the major version pin of dlv came from`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(
+			_ *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return bins, cobra.ShellCompDirectiveNoFileComp
+		},
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			bin := newBinary(args[0])
+			if !bin.IsValid() {
+				err := newInvalidArgError("binary", args[0], bin.Version)
+				fmt.Fprintln(os.Stderr, err.Error())
+				return err
+			}
+
+			return gobin.PrintBinaryOrigin(bin)
+		},
+	}
+}
+
 // getBinariesAutoComplete returns a list of binaries from the given path that
-// match the given prefix to complete.
+// match the given prefix to complete, skipping the binaries already given as
+// arguments. The binaries are listed from the directory only, without reading
+// their build info, so completion stays fast with many binaries.
 func getBinariesAutoComplete(
 	fs system.FileSystem,
 	path string,
+	args []string,
 	toComplete string,
 ) ([]string, error) {
 	bins, err := fs.ListBinaries(path)
@@ -844,10 +3875,424 @@ func getBinariesAutoComplete(
 	var matches []string
 	for _, bin := range bins {
 		b := filepath.Base(bin)
-		if strings.HasPrefix(b, toComplete) {
+		if strings.HasPrefix(b, toComplete) && !slices.Contains(args, b) {
 			matches = append(matches, b)
 		}
 	}
 
 	return matches, nil
 }
+
+// setKindDefault sets the given pin kind as the default of the --kind flags of
+// the subcommands of the command, so the flags given in the command line
+// override it.
+func setKindDefault(cmd *cobra.Command, kind model.Kind) {
+	for _, subCmd := range cmd.Commands() {
+		if flag := subCmd.Flags().Lookup("kind"); flag != nil {
+			_ = flag.Value.Set(kind.String())
+			flag.DefValue = kind.String()
+		}
+
+		setKindDefault(subCmd, kind)
+	}
+}
+
+// getConfigKeysAutoComplete returns the configuration keys for the first
+// argument of the config subcommands.
+func getConfigKeysAutoComplete(
+	_ *cobra.Command, args []string, _ string,
+) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return model.ConfigKeys, cobra.ShellCompDirectiveNoFileComp
+}
+
+// getEnvBinaryAutoComplete returns a completion function of the binaries in
+// the Go binary path for the first argument of the env subcommands.
+func getEnvBinaryAutoComplete(
+	fs system.FileSystem,
+	workspace system.Workspace,
+) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		bins, err := getBinariesAutoComplete(fs, workspace.GetGoBinPath(), args, toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		return bins, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// addBuildFlags adds the flags setting the build tags, the linker flags and the
+// build environment variables of the binaries, recorded in their receipts so
+// upgrades and rebuilds reuse them.
+func addBuildFlags(cmd *cobra.Command, flags *model.BuildFlags, buildEnv *[]string) {
+	cmd.Flags().StringVar(
+		&flags.Tags,
+		"tags",
+		"",
+		"comma separated build tags, ex. netgo,osusergo",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.LDFlags,
+		"ldflags",
+		"",
+		"linker flags, ex. \"-X main.version=v1.0.0\"",
+	)
+
+	cmd.Flags().StringArrayVar(
+		buildEnv,
+		"env",
+		nil,
+		"build environment variable in the form KEY=VALUE, ex. CGO_ENABLED=1 (repeatable)",
+	)
+}
+
+// addDryRunFlag adds the flag printing the actions of the install, upgrade,
+// uninstall and prune commands without applying them. It is registered per
+// command rather than as a persistent flag, since the other commands either
+// change nothing or, as doctor and migrate, report their changes on their own.
+func addDryRunFlag(cmd *cobra.Command, dryRun *bool) {
+	cmd.Flags().BoolVar(
+		dryRun,
+		"dry-run",
+		false,
+		"prints the actions without applying them",
+	)
+}
+
+// addVariantFlags adds the flags selecting the architecture variant of the
+// binaries, mapped to the GOARM, GOAMD64 and GOARM64 environment variables.
+func addVariantFlags(cmd *cobra.Command, flags *model.BuildFlags) {
+	cmd.Flags().StringVar(
+		&flags.GOARM,
+		"goarm",
+		"",
+		"ARM variant (GOARM), ex. 6",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.GOAMD64,
+		"goamd64",
+		"",
+		"x86-64 variant (GOAMD64), ex. v3",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.GOARM64,
+		"goarm64",
+		"",
+		"ARM64 variant (GOARM64), ex. v8.2",
+	)
+}
+
+// newBinary creates a binary from a command argument, with the extension of the
+// binaries on the current operating system if none is given, so "dlv" and
+// "dlv.exe" name the same binary on Windows.
+func newBinary(arg string) model.Binary {
+	return model.NewBinaryFromString(arg).WithOSExtension(runtime.GOOS)
+}
+
+// genDocs generates the documentation of the command tree of the given root
+// command into the given directory with the given generator, creating the
+// directory if it does not exist. The generation date is omitted so that the
+// output is reproducible. It returns an error if the directory cannot be
+// created or the documentation cannot be generated.
+func genDocs(
+	fs system.FileSystem,
+	root *cobra.Command,
+	dir string,
+	gen func(root *cobra.Command, dir string) error,
+) error {
+	//nolint:mnd // directory permissions
+	if err := fs.CreateDir(dir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return err
+	}
+
+	root.DisableAutoGenTag = true
+
+	if err := gen(root, dir); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// getCompletionScript generates the completion script of the given shell for
+// the given root command, with the descriptions of the commands and flags.
+func getCompletionScript(root *cobra.Command, shell model.Shell) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	switch shell {
+	case model.ShellBash:
+		err = root.GenBashCompletionV2(&buf, true)
+	case model.ShellFish:
+		err = root.GenFishCompletion(&buf, true)
+	case model.ShellPowerShell:
+		err = root.GenPowerShellCompletionWithDesc(&buf)
+	case model.ShellZsh:
+		err = root.GenZshCompletion(&buf)
+	}
+
+	return buf.Bytes(), err
+}
+
+// getConfig gets the configuration from the configuration file of the
+// workspace. It returns an empty configuration if the file does not exist,
+// or an error if the file cannot be read or parsed.
+func getConfig(fs system.FileSystem, workspace system.Workspace) (model.Config, error) {
+	data, err := fs.ReadFile(workspace.GetInternalConfigPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return model.NewConfig(), nil
+	} else if err != nil {
+		return model.Config{}, err
+	}
+
+	return model.ParseConfig(data)
+}
+
+// getExitCode returns the exit code of a command failing with the given error.
+// A partial failure takes precedence over the reason of the failure.
+func getExitCode(err error) int {
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) && codeErr.code > 0 {
+		return codeErr.code
+	}
+
+	switch {
+	case errors.Is(err, gobin.ErrPartialFailure):
+		return exitCodePartialFailure
+	case errors.Is(err, gobin.ErrCommandNotFound),
+		errors.Is(err, toolchain.ErrBinaryNotFound),
+		errors.Is(err, toolchain.ErrModuleNotFound),
+		errors.Is(err, manager.ErrDebugInfoNotFound),
+		errors.Is(err, manager.ErrPackageNotFound),
+		errors.Is(err, manager.ErrPreviousVersionNotFound),
+		errors.Is(err, manager.ErrRefNotFound),
+		errors.Is(err, registry.ErrArtifactNotFound),
+		errors.Is(err, manager.ErrVersionNotAvailable):
+		return exitCodeNotFound
+	case errors.Is(err, toolchain.ErrNetworkUnavailable):
+		return exitCodeNetwork
+	case errors.Is(err, gobin.ErrDownloadTooLarge),
+		errors.Is(err, gobin.ErrPackageDenied),
+		errors.Is(err, manager.ErrBinaryProtected):
+		return exitCodePolicy
+	case errors.Is(err, toolchain.ErrBuildFailed):
+		return exitCodeBuildFailure
+	case errors.Is(err, gobin.ErrWarnings):
+		return exitCodeWarnings
+	case errors.Is(err, gobin.ErrOutdatedBinaries):
+		return exitCodeOutdated
+	default:
+		return exitCodeFailure
+	}
+}
+
+// getOutcome returns the category of the outcome of a command exiting with the
+// given exit code, recorded by the usage metrics.
+func getOutcome(exitCode int) string {
+	switch exitCode {
+	case 0:
+		return "success"
+	case exitCodePartialFailure:
+		return "partial_failure"
+	case exitCodeNotFound:
+		return "not_found"
+	case exitCodeNetwork:
+		return "network"
+	case exitCodePolicy:
+		return "policy"
+	case exitCodeBuildFailure:
+		return "build_failure"
+	case exitCodeWarnings:
+		return "warnings"
+	case exitCodeOutdated:
+		return "outdated"
+	default:
+		return "failure"
+	}
+}
+
+// getPinFormat gets the pin format from the environment, defaulting to the
+// version placed after the binary name and separated by "-". It returns an
+// error if the pin format is not valid for the operating system.
+func getPinFormat(env system.Environment, rt system.Runtime) (model.PinFormat, error) {
+	pinFormat := model.NewDefaultPinFormat()
+
+	separator, ok := env.Get(pinSeparatorEnvVar)
+	if !ok {
+		separator = pinFormat.Separator
+	}
+
+	placement, ok := env.Get(pinPlacementEnvVar)
+	if !ok {
+		placement = string(pinFormat.Placement)
+	}
+
+	pinFormat = model.NewPinFormat(separator, model.PinPlacement(placement))
+	if err := pinFormat.Validate(rt.OS()); err != nil {
+		return model.PinFormat{}, err
+	}
+
+	return pinFormat, nil
+}
+
+// getStdinArgs returns the given arguments, or the arguments read from the
+// given reader if the only argument is "-". Arguments read are separated by
+// new lines, or by NUL characters if null is set.
+func getStdinArgs(args []string, stdIn io.Reader, null bool) ([]string, error) {
+	if !slices.Contains(args, "-") {
+		if null {
+			return nil, errors.New("cannot use --null without reading binaries from stdin (-)")
+		}
+
+		return args, nil
+	}
+
+	if len(args) > 1 {
+		return nil, errors.New("cannot use - with specific binaries")
+	}
+
+	data, err := io.ReadAll(stdIn)
+	if err != nil {
+		return nil, err
+	}
+
+	sep := "\n"
+	if null {
+		sep = "\x00"
+	}
+
+	var stdinArgs []string
+	for arg := range strings.SplitSeq(string(data), sep) {
+		if arg = strings.TrimSpace(arg); arg != "" {
+			stdinArgs = append(stdinArgs, arg)
+		}
+	}
+
+	return stdinArgs, nil
+}
+
+// isTerminal checks if the given file is a terminal, to render output rewritten
+// in place, such as progress lines.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// newInvalidArgError creates an error for an invalid argument of the given
+// kind, suggesting the version most likely meant when the version is a
+// near-miss.
+func newInvalidArgError(kind string, arg string, version model.Version) error {
+	if suggestion, ok := version.Suggest(); ok {
+		return fmt.Errorf("invalid %s argument: %s, did you mean @%s?", kind, arg, suggestion)
+	}
+
+	return fmt.Errorf("invalid %s argument: %s", kind, arg)
+}
+
+// notifyNewRelease notifies when a new gobin release is available after the
+// given command, except for the shell completion commands, whose output is
+// sourced by shells. Errors are logged without failing the command.
+func notifyNewRelease(ctx context.Context, gobin *gobin.Gobin, cmd *cobra.Command) {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Hidden || c.Name() == "completion" {
+			return
+		}
+	}
+
+	path, err := os.Executable()
+	if err != nil {
+		slog.Default().Warn("error checking for a new release", "err", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, releaseCheckTimeout)
+	defer cancel()
+
+	if err = gobin.NotifyNewRelease(ctx, path); err != nil {
+		slog.Default().Warn("error checking for a new release", "err", err)
+	}
+}
+
+// recordUsage records the given command and the outcome of its exit code in
+// the usage metrics when enabled, except for the hidden commands, ex. the
+// shell completion requests. Errors are logged without failing the command.
+func recordUsage(
+	ctx context.Context,
+	config model.TelemetryConfig,
+	workspace system.Workspace,
+	cmd *cobra.Command,
+	exitCode int,
+) {
+	if !config.Enabled || cmd == nil {
+		return
+	}
+
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Hidden {
+			return
+		}
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = filepath.Join(workspace.GetInternalBasePath(), telemetryFileName)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, telemetryTimeout)
+	defer cancel()
+
+	event := internal.NewUsageEvent(cmd.CommandPath(), getOutcome(exitCode))
+	if err := internal.RecordUsage(ctx, endpoint, event); err != nil {
+		slog.Default().Warn("error recording usage", "err", err)
+	}
+}
+
+// setBundleEnv sets the environment of the go commands to install offline from
+// the module cache extracted from a module bundle: the module cache is the
+// given directory, modules are resolved from its download directory instead of
+// the network (GOPROXY=file://...), and the extracted modules are left writable
+// (-modcacherw) so the directory can be removed.
+func setBundleEnv(env system.Environment, modCache string) error {
+	goFlags, _ := env.Get(goFlagsEnvVar)
+
+	downloadDir := filepath.ToSlash(filepath.Join(modCache, "cache", "download"))
+	if !strings.HasPrefix(downloadDir, "/") {
+		downloadDir = "/" + downloadDir
+	}
+
+	for key, value := range map[string]string{
+		goModCacheEnvVar: modCache,
+		goProxyEnvVar:    goProxyFileScheme + downloadDir,
+		goFlagsEnvVar:    strings.TrimSpace(goFlags + " -modcacherw"),
+	} {
+		if err := env.Set(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// shutdownTracing flushes the pending spans and shuts down tracing, waiting up
+// to the tracing shutdown timeout for the spans to be exported.
+func shutdownTracing(shutdown func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: cannot export traces: %s\n", err.Error())
+	}
+}
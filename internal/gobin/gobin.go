@@ -1,23 +1,33 @@
 package gobin
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
+	"time"
+	"unicode"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/brunoribeiro127/gobin/internal"
 	"github.com/brunoribeiro127/gobin/internal/manager"
 	"github.com/brunoribeiro127/gobin/internal/model"
+	"github.com/brunoribeiro127/gobin/internal/registry"
 	"github.com/brunoribeiro127/gobin/internal/system"
 	"github.com/brunoribeiro127/gobin/internal/toolchain"
 )
@@ -39,7 +49,7 @@ const (
     ❗ not managed by gobin
     {{- end }}
     {{- if .IsPseudoVersion }}
-    ❗ pseudo-version
+    ⚠️  pseudo-version
     {{- end }}
     {{- if .NotBuiltWithGoModules }}
     ❗ built without Go modules (GO111MODULE=off)
@@ -53,11 +63,29 @@ const (
     {{- if ne .Platform.Actual .Platform.Expected }}
     ❗ platform mismatch: expected {{ .Platform.Expected }}, actual {{ .Platform.Actual }}
     {{- end }}
+    {{- if .MissingInterpreter }}
+    ❗ libc mismatch: dynamically linked {{ with .GetMissingLibc }}against {{ . }} {{ end }}with {{ .MissingInterpreter }}, not found in the system, rebuild with gobin upgrade --rebuild
+    {{- end }}
+    {{- if .LongPath }}
+    ❗ path near the Windows limit of 260 characters ({{ len .LongPath }}): {{ .LongPath }}, enable Win32 long paths
+    {{- end }}
     {{- if .Retracted }}
     ❗ retracted module version: {{ .Retracted }}
     {{- end }}
     {{- if .Deprecated }}
-    ❗ deprecated module: {{ .Deprecated }}
+    ⚠️  deprecated module: {{ .Deprecated }}
+    {{- end }}
+    {{- if .CompressedNotExecutable }}
+    ⚠️  compressed with UPX, fails to execute: {{ .CompressedNotExecutable }}, reinstall with gobin install --upx=false
+    {{- end }}
+    {{- if .InvalidSignature }}
+    ❗ invalid signature: {{ .InvalidSignature }}, rebuild with gobin upgrade --rebuild
+    {{- end }}
+    {{- if .SumMismatch }}
+    ❗ {{ .SumMismatch }}
+    {{- end }}
+    {{- if .SumNotVerifiable }}
+    ⚠️  {{ .SumNotVerifiable }}
     {{- end }}
     {{- if .Vulnerabilities }}
     ❗ found {{ len .Vulnerabilities }} {{if gt (len .Vulnerabilities) 1}}vulnerabilities{{else}}vulnerability{{end}}:
@@ -78,21 +106,119 @@ Location      {{if eq .FullPath .InstallPath}}<unmanaged>{{else}}{{.InstallPath}
 Package       {{.PackagePath}}
 Module        {{.Module.String}}
 Module Sum    {{if .ModuleSum}}{{.ModuleSum}}{{else}}<none>{{end}}
+Verified      {{if .Verified}}yes{{else}}no ({{.NotVerified}}){{end}}
 {{- if .CommitRevision}}
 Commit        {{.CommitRevision}}{{if .CommitTime}} ({{.CommitTime}}){{end}}
 {{- end}}
 Go Version    {{.GoVersion}}
 Platform      {{.OS}}/{{.Arch}}/{{.Feature}}
+Stripped      {{if .BuildFlags.IsStripped}}yes{{else}}no{{end}}
+{{- if .IsDevBuild}}
+Dev Build     yes
+{{- end}}
+{{- with .BuildFlags.GetProvenance}}{{if .Builder}}
+Built By      {{.Builder}} ({{.Source}} {{.Spec}})
+{{- end}}{{end}}
 Env Vars      {{range $index, $env := .EnvVars}}{{if eq $index 0}}{{$env}}{{else}}
               {{$env}}{{end}}{{end}}
 `
 
+	// whyTemplate is the template for the why command.
+	whyTemplate = `{{.Binary.String}} {{.Module.Version}}
+Package       {{.PackagePath}}
+Module        {{.Module.Path}}
+Resolution    {{.GetResolution}}
+Linked By     {{.GetLinkedBy}}
+{{- if .MigratedFrom}}
+Migrated From {{.MigratedFrom}}
+{{- end}}
+{{- if .PreviousVersion}}
+Previous      {{.PreviousVersion}}
+{{- end}}
+Installed     {{.InstalledAt.Format "2006-01-02 15:04:05"}}
+Go Version    {{.GoVersion}}
+Platform      {{.OS}}/{{.Arch}}
+{{- with .BuildFlags.GetProvenance}}{{if .Builder}}
+Built By      {{.Builder}} ({{.Source}} {{.Spec}})
+{{- end}}{{end}}
+{{- if .Protected}}
+Protected     yes
+{{- end}}
+`
+
+	// diffTemplate is the template for the diff command.
+	diffTemplate = `{{.Name}} {{.From.Module.Version}} → {{.To.Module.Version}}
+Go Version    {{.From.GoVersion}}{{if ne .From.GoVersion .To.GoVersion}} → {{.To.GoVersion}}{{end}}
+Size          {{.FromSize}} → {{.ToSize}}
+Settings      {{if not .To.Settings}}<unknown until built>{{else if not .Settings}}<unchanged>{{else}}{{len .Settings}} changed{{end}}
+{{- range .Settings}}
+              {{if not .From}}{{color (printf "+ %s=%s" .Key .To) "green"}}{{else if not .To}}{{color (printf "- %s=%s" .Key .From) "red"}}{{else}}~ {{.Key}}={{.From}} → {{.To}}{{end}}
+{{- end}}
+Dependencies  {{if not .Deps}}<unchanged>{{else}}{{len .Deps}} changed{{end}}
+{{- range .Deps}}
+              {{if not .From}}{{color (printf "+ %s %s" .Key .To) "green"}}{{else if not .To}}{{color (printf "- %s %s" .Key .From) "red"}}{{else}}~ {{.Key}} {{.From}} → {{.To}}{{end}}
+{{- end}}
+`
+
+	// reproduceTemplate is the template for the reproduce command.
+	reproduceTemplate = `{{.Name}} {{if .IsReproducible}}{{color "reproducible" "green"}}{{else}}{{color "not reproducible" "red"}}{{end}}
+Package       {{.Package}}
+Go Version    {{.GoVersion}}
+Installed     sha256:{{.InstalledHash}}
+Rebuilt       sha256:{{.RebuiltHash}}
+{{- if not .IsReproducible}}
+Settings      {{if not .Settings}}<unchanged>{{else}}{{len .Settings}} changed{{end}}
+{{- range .Settings}}
+              {{if not .From}}{{color (printf "+ %s=%s" .Key .To) "green"}}{{else if not .To}}{{color (printf "- %s=%s" .Key .From) "red"}}{{else}}~ {{.Key}}={{.From}} → {{.To}}{{end}}
+{{- end}}
+{{- end}}
+`
+
+	// sizeHistoryTemplate is the template for the size command with the size
+	// history.
+	sizeHistoryTemplate = `{{.Name}} {{.Sparkline}}
+{{printf "%-*s" $.VersionWidth "Version"}}  {{printf "%*s" $.SizeWidth "Size"}}  {{printf "%*s" $.ChangeWidth "Change"}}
+{{repeat "-" (add $.VersionWidth $.SizeWidth $.ChangeWidth 4)}}
+{{range .Rows -}}
+{{printf "%-*s" $.VersionWidth .Version}}  {{printf "%*s" $.SizeWidth .Size}}{{if .Change}}  {{if .Color}}{{color (printf "%*s" $.ChangeWidth .Change) .Color}}{{else}}{{printf "%*s" $.ChangeWidth .Change}}{{end}}{{end}}
+{{end -}}
+`
+
+	// storeUsageTemplate is the template for the du command.
+	storeUsageTemplate = `{{printf "%-*s" $.NameWidth "Name"}}  {{printf "%*s" $.VersionsWidth "Versions"}}  {{printf "%*s" $.SizeWidth "Size"}}  {{printf "%*s" $.StaleWidth "Stale"}}  {{printf "%*s" $.PrunableWidth "Prunable"}}
+{{repeat "-" (add $.NameWidth $.VersionsWidth $.SizeWidth $.StaleWidth $.PrunableWidth 8)}}
+{{range .Rows -}}
+{{printf "%-*s" $.NameWidth .Name}}  {{printf "%*s" $.VersionsWidth .Versions}}  {{printf "%*s" $.SizeWidth .Size}}  {{printf "%*s" $.StaleWidth .Stale}}  {{printf "%*s" $.PrunableWidth .Prunable}}
+{{end -}}
+{{repeat "-" (add $.NameWidth $.VersionsWidth $.SizeWidth $.StaleWidth $.PrunableWidth 8)}}
+{{with .Total}}{{printf "%-*s" $.NameWidth .Name}}  {{printf "%*s" $.VersionsWidth .Versions}}  {{printf "%*s" $.SizeWidth .Size}}  {{printf "%*s" $.StaleWidth .Stale}}  {{printf "%*s" $.PrunableWidth .Prunable}}{{end}}
+`
+
+	// envTemplate is the template for the env command.
+	envTemplate = `Go Bin Path          {{.GoBinPath}}
+Base Path            {{.BasePath}}
+Bin Path             {{.BinPath}}
+Data Path            {{.DataPath}}
+Receipt Path         {{.ReceiptPath}}
+Shim Path            {{.ShimPath}}
+Temp Path            {{.TempPath}}
+Log Path             {{.LogPath}}
+Config File          {{.ConfigFile}}{{if not .ConfigFileExists}} <not found>{{end}}
+Pin Mode             {{.PinMode}}
+Release Check        {{if .ReleaseCheck}}enabled{{else}}disabled{{end}}
+Release Check Cache  {{.ReleaseCheckCache}}
+Prompt Status Cache  {{.PromptStatusCache}}
+Go Build Cache       {{.GoCache}}
+Go Module Cache      {{.GoModCache}}
+GOPROXY              {{.GoProxy}}
+`
+
 	// listInstalledTemplate is the template for the list command for installed
 	// binaries.
 	listInstalledTemplate = `{{printf "%-*s" $.NameWidth "Name"}} → {{printf "%-*s" $.ModulePathWidth "Module"}} @ {{printf "%-*s" $.ModuleVersionWidth "Version"}}
 {{repeat "-" (add $.NameWidth $.ModulePathWidth $.ModuleVersionWidth 6)}}
 {{range .Binaries -}}
-{{if .IsManaged}}{{color (printf "%-*s" $.NameWidth .Binary.Name) "green"}}{{else}}{{printf "%-*s" $.NameWidth .Binary.Name}}{{end}} → {{printf "%-*s" $.ModulePathWidth .Module.Path}} @ {{printf "%-*s" $.ModuleVersionWidth .Module.Version.String}}
+{{if .IsManaged}}{{color (printf "%-*s" $.NameWidth .Binary.Name) "green"}}{{else}}{{printf "%-*s" $.NameWidth .Binary.Name}}{{end}} → {{printf "%-*s" $.ModulePathWidth .Module.Path}} @ {{printf "%-*s" $.ModuleVersionWidth .Module.Version.String}}{{if .IsDevBuild}} [dev]{{end}}{{if and $.CrossOS (eq .OS $.CrossOS)}} [{{.OS}}]{{end}}
 {{end -}}
 `
 
@@ -101,7 +227,319 @@ Env Vars      {{range $index, $env := .EnvVars}}{{if eq $index 0}}{{$env}}{{else
 	listManagedTemplate = `{{printf "%-*s" $.NameWidth "Name"}} → {{printf "%-*s" $.ModulePathWidth "Module"}} @ {{printf "%-*s" $.ModuleVersionWidth "Version"}}
 {{repeat "-" (add $.NameWidth $.ModulePathWidth $.ModuleVersionWidth 6)}}
 {{range .Binaries -}}
-{{if .IsPinned}}{{color (printf "%-*s" $.NameWidth .Binary.Name) "green"}}{{else}}{{printf "%-*s" $.NameWidth .Binary.Name}}{{end}} → {{printf "%-*s" $.ModulePathWidth .Module.Path}} @ {{printf "%-*s" $.ModuleVersionWidth .Module.Version.String}}
+{{if .IsPinned}}{{color (printf "%-*s" $.NameWidth .Binary.Name) "green"}}{{else}}{{printf "%-*s" $.NameWidth .Binary.Name}}{{end}} → {{printf "%-*s" $.ModulePathWidth .Module.Path}} @ {{printf "%-*s" $.ModuleVersionWidth .Module.Version.String}}{{if .IsDevBuild}} [dev]{{end}}{{if .IsCrossBuild}} [{{.OS}}/{{.Arch}}]{{end}}
+{{end -}}
+`
+
+	// migrateDryRunTemplate is the template for the migrate command in dry-run
+	// mode.
+	migrateDryRunTemplate = `{{- if .Migrate -}}
+🚚 would migrate:
+{{- range .Migrate }}
+    • {{ .Name }} → {{ .Target }}
+{{- end }}
+{{ end -}}
+{{- if .Managed -}}
+✅ already managed:
+{{- range .Managed }}
+    • {{ .Name }}
+{{- end }}
+{{ end -}}
+{{- if .Failed -}}
+❌ would fail:
+{{- range .Failed }}
+    • {{ .Name }}: {{ .Reason }}
+{{- end }}
+{{ end -}}
+{{ .Total }} binaries checked, {{ len .Migrate }} to migrate, {{ len .Managed }} already managed, {{ len .Failed }} failing
+`
+
+	// planTemplate is the template for the actions of an operation in dry-run
+	// mode.
+	planTemplate = `{{- if .Actions -}}
+🔎 would {{ .Operation }} {{ .Name }}:
+{{- range .Actions }}
+    • {{ . }}
+{{- end }}
+{{ else -}}
+🔎 nothing to {{ .Operation }} for {{ .Name }}
+{{ end -}}
+`
+
+	// httpProxiesTemplate is the template for the HTTP proxies section of the
+	// doctor command.
+	httpProxiesTemplate = `
+🔀 http proxies
+{{- range . }}
+    • {{ .Endpoint }} {{ .URL }}: {{ if .IsDirect }}direct{{ else }}via {{ .Proxy }}{{ end }}
+{{- end }}
+`
+
+	// networkTemplate is the template for the network section of the doctor
+	// command.
+	networkTemplate = `
+🌐 module proxies
+{{- range . }}
+    {{ if .IsDown }}❗{{ else }}•{{ end }} {{ .Proxy }}: {{ if not .IsDown }}{{ .Latency }} average latency, {{ end }}{{ .Errors }}/{{ .Probes }} probes failed
+{{- if .Unauthorized }}, .netrc or GOAUTH credentials rejected{{ end }}
+{{- else }}
+    ❗ no module proxies configured (GOPROXY=off)
+{{- end }}
+`
+
+	// fixesTemplate is the template for the fixes section of the doctor
+	// command.
+	fixesTemplate = `
+🔧 {{ if .DryRun }}would fix{{ else }}fixes to apply{{ end }}:
+{{- range .Fixes }}
+    • {{ .Name }}: {{ .Action }} ({{ .Reason }})
+{{- end }}
+`
+
+	// initBeginMarker is the line starting the init snippet in a shell profile.
+	initBeginMarker = "# >>> gobin init >>>"
+	// initEndMarker is the line ending the init snippet in a shell profile.
+	initEndMarker = "# <<< gobin init <<<"
+	// completionBeginMarker is the line starting the completion script in a
+	// shell profile.
+	completionBeginMarker = "# >>> gobin completion >>>"
+	// completionEndMarker is the line ending the completion script in a shell
+	// profile.
+	completionEndMarker = "# <<< gobin completion <<<"
+	// initPromptStatusFile is the file, in the internal base directory, caching
+	// the outdated binaries shown in the prompt status.
+	initPromptStatusFile = "prompt-status"
+
+	// initTemplate is the template for the init command.
+	initTemplate = initBeginMarker + `
+{{- if eq .Shell "fish" }}
+fish_add_path --append "{{ .GoBinPath }}"
+fish_add_path --prepend "{{ .ShimPath }}"
+gobin completion fish | source
+{{- if .Prompt }}
+
+function __gobin_prompt_status
+    set -l status_file "{{ .StatusFile }}"
+    if not test -f $status_file; or count (find $status_file -mmin +1440 2>/dev/null) >/dev/null
+        touch $status_file
+        command gobin outdated >$status_file 2>/dev/null &
+        disown 2>/dev/null
+    end
+    if test -s $status_file; and not grep -q "up to date" $status_file
+        printf 'gobin↑ '
+    end
+end
+
+if not functions -q __gobin_fish_prompt
+    functions -c fish_prompt __gobin_fish_prompt
+    function fish_prompt
+        __gobin_prompt_status
+        __gobin_fish_prompt
+    end
+end
+{{- end }}
+{{- else if eq .Shell "powershell" }}
+if (-not (($env:Path -split [IO.Path]::PathSeparator) -contains '{{ psquote .GoBinPath }}')) {
+    $env:Path += [IO.Path]::PathSeparator + '{{ psquote .GoBinPath }}'
+}
+if (-not (($env:Path -split [IO.Path]::PathSeparator) -contains '{{ psquote .ShimPath }}')) {
+    $env:Path = '{{ psquote .ShimPath }}' + [IO.Path]::PathSeparator + $env:Path
+}
+gobin completion powershell | Out-String | Invoke-Expression
+{{- if .Prompt }}
+
+function global:__GobinPromptStatus {
+    $statusFile = '{{ psquote .StatusFile }}'
+    if (-not (Test-Path $statusFile) -or (Get-Item $statusFile).LastWriteTime -lt (Get-Date).AddDays(-1)) {
+        New-Item -ItemType File -Force $statusFile | Out-Null
+        Start-Job { param($f) gobin outdated 2>$null | Set-Content $f } -ArgumentList $statusFile | Out-Null
+    }
+    if ((Get-Item $statusFile).Length -gt 0 -and -not (Select-String -Quiet 'up to date' $statusFile)) {
+        'gobin↑ '
+    }
+}
+
+if (-not $global:__GobinPrompt) {
+    $global:__GobinPrompt = $function:prompt
+    function global:prompt { (__GobinPromptStatus) + (& $global:__GobinPrompt) }
+}
+{{- end }}
+{{- else }}
+case ":$PATH:" in
+    *":{{ .GoBinPath }}:"*) ;;
+    *) export PATH="$PATH:{{ .GoBinPath }}" ;;
+esac
+case ":$PATH:" in
+    *":{{ .ShimPath }}:"*) ;;
+    *) export PATH="{{ .ShimPath }}:$PATH" ;;
+esac
+{{- if eq .Shell "zsh" }}
+(( $+functions[compdef] )) || { autoload -Uz compinit && compinit; }
+source <(gobin completion zsh)
+{{- else }}
+source <(gobin completion bash)
+{{- end }}
+{{- if .Prompt }}
+
+__gobin_prompt_status() {
+    local status_file="{{ .StatusFile }}"
+    if [ ! -f "$status_file" ] || [ -n "$(find "$status_file" -mmin +1440 2>/dev/null)" ]; then
+        touch "$status_file"
+        (gobin outdated >"$status_file" 2>/dev/null &)
+    fi
+    if [ -s "$status_file" ] && ! grep -q "up to date" "$status_file"; then
+        printf 'gobin↑ '
+    fi
+}
+{{- if eq .Shell "zsh" }}
+
+setopt PROMPT_SUBST
+[[ $PROMPT == *__gobin_prompt_status* ]] || PROMPT='$(__gobin_prompt_status)'"$PROMPT"
+{{- else }}
+
+[[ $PS1 == *__gobin_prompt_status* ]] || PS1='$(__gobin_prompt_status)'"$PS1"
+{{- end }}
+{{- end }}
+{{- end }}
+` + initEndMarker + "\n"
+
+	// commandNotFoundTemplate is the template for the command-not-found hook.
+	commandNotFoundTemplate = `{{- if eq .Shell "fish" -}}
+function fish_command_not_found
+    if not isatty stdin; or not type -q gobin; or not gobin hook command-not-found $argv[1]
+        __fish_default_command_not_found_handler $argv
+        return 127
+    end
+    $argv
+end
+{{- else if eq .Shell "zsh" -}}
+command_not_found_handler() {
+    if [[ ! -t 0 ]] || ! whence -p gobin >/dev/null || ! gobin hook command-not-found "$1"; then
+        print -u2 "zsh: command not found: $1"
+        return 127
+    fi
+    rehash
+    "$@"
+}
+{{- else -}}
+command_not_found_handle() {
+    if [ ! -t 0 ] || ! type -P gobin >/dev/null || ! gobin hook command-not-found "$1"; then
+        printf 'bash: %s: command not found\n' "$1" >&2
+        return 127
+    fi
+    hash -r
+    "$@"
+}
+{{- end }}
+`
+
+	// bugReportLogTailSize is the size of the tail of the log file collected in
+	// a bug report.
+	bugReportLogTailSize = 256 << 10
+	// bugReportMaxOperations is the maximum number of operation records
+	// collected in a bug report.
+	bugReportMaxOperations = 50
+
+	// releaseCheckFileName is the name of the file caching the last check for a
+	// new gobin release, in the internal base directory.
+	releaseCheckFileName = "release-check.json"
+	// releaseCheckInterval is the interval between two checks for a new gobin
+	// release.
+	releaseCheckInterval = 24 * time.Hour
+
+	// deprecationNoticesFileName is the name of the file recording when the
+	// deprecation warning of each module was last shown, in the internal base
+	// directory.
+	deprecationNoticesFileName = "deprecation-notices.json"
+	// deprecationNoticeInterval is the interval during which the deprecation
+	// warning of a module is shown once.
+	deprecationNoticeInterval = 24 * time.Hour
+
+	// pressureCheckInterval is the interval between two checks of the system
+	// pressure while an operation is throttled.
+	pressureCheckInterval = time.Second
+
+	// serveCacheReadHeaderTimeout is the time limit to read the headers of a
+	// request to the binary cache server.
+	serveCacheReadHeaderTimeout = 10 * time.Second
+	// serveCacheShutdownTimeout is the time limit to finish the requests in
+	// flight when the binary cache server is stopped.
+	serveCacheShutdownTimeout = 5 * time.Second
+
+	// generateTemplate is the template for the generate command.
+	generateTemplate = `{{- if eq .Runner "make" -}}
+# Code generated by gobin generate make; DO NOT EDIT.
+
+GOBIN_PATH ?= $(or $(shell go env GOBIN),$(shell go env GOPATH)/bin)
+
+# gobin_installed checks if the binary $(1) is installed from the package $(2) at the version $(3).
+gobin_installed = go version -m "$(GOBIN_PATH)/$(1)" 2>/dev/null | awk '$$1 == "path" && $$2 == "$(2)" { p = 1 } $$1 == "mod" && $$3 == "$(3)" { v = 1 } END { exit !(p && v) }'
+
+.PHONY: tools{{ range .Tools }} tools/{{ .Name }}{{ end }}
+
+tools:{{ range .Tools }} tools/{{ .Name }}{{ end }}
+{{ range .Tools }}
+tools/{{ .Name }}:
+	@$(call gobin_installed,{{ .Name }},{{ .Path }},{{ .Version }}) || gobin install {{ .Path }}@{{ .Version }}
+{{ end -}}
+{{- else -}}
+# Code generated by gobin generate task; DO NOT EDIT.
+
+version: '3'
+
+vars:
+  GOBIN_PATH:
+    sh: go env GOBIN | grep . || echo "$(go env GOPATH)/bin"
+
+tasks:
+  tools:
+    desc: Install the tools at their locked versions
+    deps:
+{{- range .Tools }}
+      - tools:{{ .Name }}
+{{- end }}
+{{- range .Tools }}
+
+  tools:{{ .Name }}:
+    desc: Install {{ .Name }} at {{ .Version }}
+    cmds:
+      - gobin install {{ .Path }}@{{ .Version }}
+    status:
+      - >-
+        go version -m "{{ "{{.GOBIN_PATH}}" }}/{{ .Name }}{{ "{{exeExt}}" }}" 2>/dev/null |
+        awk '$1 == "path" && $2 == "{{ .Path }}" { p = 1 } $1 == "mod" && $3 == "{{ .Version }}" { v = 1 } END { exit !(p && v) }'
+{{- end }}
+{{ end -}}
+`
+
+	// failuresTemplate is the template for the summary of the failed
+	// operations.
+	failuresTemplate = `
+❌ failures ({{ len . }}):
+{{- range . }}
+    • {{ .Name }} — {{ .Reason }}
+{{- end }}
+`
+
+	// timingsTemplate is the template for the timings summary.
+	timingsTemplate = `{{printf "%-*s" $.NameWidth "Name"}}{{range $.Phases}}  {{printf "%*s" $.PhaseWidth .}}{{end}}  {{printf "%*s" $.PhaseWidth "total"}}
+{{repeat "-" $.LineWidth}}
+{{range .Rows -}}
+{{printf "%-*s" $.NameWidth .Name}}{{range .Durations}}  {{printf "%*s" $.PhaseWidth .}}{{end}}  {{printf "%*s" $.PhaseWidth .Total}}
+{{end -}}
+{{repeat "-" $.LineWidth}}
+{{printf "%-*s" $.NameWidth "Total"}}{{range .Total.Durations}}  {{printf "%*s" $.PhaseWidth .}}{{end}}  {{printf "%*s" $.PhaseWidth .Total.Total}}
+`
+	// timingsPhaseWidth is the width of the phase columns of the timings
+	// summary.
+	timingsPhaseWidth = 9
+
+	// outdatedGoTemplate is the template for the outdated command with the Go
+	// versions the binaries are built with.
+	outdatedGoTemplate = `{{printf "%-*s" $.NameWidth "Name"}} → {{printf "%-*s" $.GoVersionWidth "Go"}} ↑ Latest
+{{repeat "-" (add $.NameWidth $.GoVersionWidth $.LatestGoVersionWidth 6)}}
+{{range .Binaries -}}
+{{printf "%-*s" $.NameWidth .Binary.Name}} → {{color (printf "%-*s" $.GoVersionWidth .GoVersion) "red"}} ↑ {{color .LatestGoVersion "green"}}{{if not .IsSupported}} (unsupported){{end}}
 {{end -}}
 `
 
@@ -112,240 +550,733 @@ Env Vars      {{range $index, $env := .EnvVars}}{{if eq $index 0}}{{$env}}{{else
 {{printf "%-*s" $.NameWidth .Binary.Name}} → {{printf "%-*s" $.ModulePathWidth .Module.Path}} @ {{color (printf "%-*s" $.ModuleVersionWidth .Module.Version.String) "red"}} ↑ {{color (printf "%-*s" $.LatestVersionWidth .LatestModule.Version.String) "green"}}
 {{end -}}
 `
+
+	// searchTemplate is the template for the search command.
+	searchTemplate = `{{printf "%-*s" $.PathWidth "Package"}} → Description
+{{repeat "-" (add $.PathWidth $.SynopsisWidth 3)}}
+{{range .Results -}}
+{{if .IsCommand}}{{color (printf "%-*s" $.PathWidth .Path) "green"}}{{else}}{{printf "%-*s" $.PathWidth .Path}}{{end}} → {{.Synopsis}}{{if .IsCommand}} [command]{{end}}
+{{end -}}
+`
+
+	// verifyPathTemplate is the template for the verify-path command.
+	verifyPathTemplate = `{{- range .Shadowed -}}
+🛠️  {{ .Name }}
+    ❗ shadowed by {{ .ShadowedBy }}, which runs instead of {{ .Path }}
+    💡 move {{ .Dir }} before {{ .ShadowedByDir }} in PATH, or rename or remove {{ .ShadowedBy }} if not needed
+{{end -}}
+{{- if .Shadowed }}
+{{""}}
+{{- end -}}
+{{ .Total }} binaries checked, {{ len .Shadowed }} shadowed
+`
+)
+
+var (
+	// ErrCommandNotFound is returned when a command is not provided by any
+	// managed binary, or its installation is declined.
+	ErrCommandNotFound = errors.New("command not found")
+
+	// ErrDevelopmentBuild is returned when gobin is asked to update itself but
+	// is a development build, not built from a released module version.
+	ErrDevelopmentBuild = errors.New("development build")
+
+	// ErrDownloadTooLarge is returned when the estimated download size of the
+	// packages exceeds the maximum download size.
+	ErrDownloadTooLarge = errors.New("download too large")
+
+	// ErrPackageDenied is returned when a package is denied by the deny list of
+	// the configuration.
+	ErrPackageDenied = errors.New("package denied by policy")
+
+	// ErrInvalidSelection is returned when the answer to an interactive
+	// selection is not one of the listed choices.
+	ErrInvalidSelection = errors.New("invalid selection")
+
+	// ErrNoModuleTools is returned when a module declares no tool dependencies
+	// to install.
+	ErrNoModuleTools = errors.New("no module tools")
+
+	// ErrNotReproducible is returned when a rebuilt binary differs from the
+	// installed one.
+	ErrNotReproducible = errors.New("binary not reproducible")
+
+	// ErrRetentionPolicyNotSet is returned when pruning by policy without a
+	// retention policy in the configuration.
+	ErrRetentionPolicyNotSet = errors.New("retention policy not set")
+
+	// ErrOutdatedBinaries is returned when listing the outdated binaries with
+	// the exit code set and some binaries are outdated.
+	ErrOutdatedBinaries = errors.New("outdated binaries found")
+
+	// ErrPartialFailure is returned when an operation run on several binaries
+	// or packages fails for some of them only.
+	ErrPartialFailure = errors.New("partial failure")
+
+	// ErrBinaryShadowed is returned when a managed binary is shadowed by another
+	// executable of the same name earlier in PATH.
+	ErrBinaryShadowed = errors.New("binary shadowed in PATH")
+
+	// ErrWarnings is returned in strict mode when an operation succeeds with
+	// warnings.
+	ErrWarnings = errors.New("warnings treated as failures in strict mode")
 )
 
+// bugReportEnvVarPrefixes are the prefixes of the environment variables
+// collected in a bug report.
+//
+//nolint:gochecknoglobals // global variable to define bug report env vars
+var bugReportEnvVarPrefixes = []string{
+	"CGO_", "GO", "HTTPS_PROXY", "HTTP_PROXY", "NETRC", "NO_PROXY", "PATH", "SHELL", "WSL_DISTRO_NAME",
+}
+
+// releaseCheck is the cached result of the last check for a new gobin release.
+type releaseCheck struct {
+	CheckedAt     time.Time     `json:"checked_at"`
+	LatestVersion model.Version `json:"latest_version"`
+}
+
+// envInfo is the effective paths and settings printed by the env command.
+type envInfo struct {
+	GoBinPath         string        `json:"go_bin_path"`
+	BasePath          string        `json:"base_path"`
+	BinPath           string        `json:"bin_path"`
+	DataPath          string        `json:"data_path"`
+	ReceiptPath       string        `json:"receipt_path"`
+	ShimPath          string        `json:"shim_path"`
+	TempPath          string        `json:"temp_path"`
+	LogPath           string        `json:"log_path"`
+	ConfigFile        string        `json:"config_file"`
+	ConfigFileExists  bool          `json:"config_file_exists"`
+	PinMode           model.PinMode `json:"pin_mode"`
+	ReleaseCheck      bool          `json:"release_check"`
+	ReleaseCheckCache string        `json:"release_check_cache"`
+	PromptStatusCache string        `json:"prompt_status_cache"`
+	GoCache           string        `json:"go_cache"`
+	GoModCache        string        `json:"go_mod_cache"`
+	GoProxy           string        `json:"go_proxy"`
+}
+
+// binaryJSON is a binary printed as JSON by the list, outdated and info
+// commands. The latest versions are only set by the outdated command, and the
+// module sum verification only by the info command.
+type binaryJSON struct {
+	Name            string           `json:"name"`
+	Path            string           `json:"path"`
+	InstallPath     string           `json:"install_path"`
+	Package         string           `json:"package"`
+	Module          string           `json:"module"`
+	Version         model.Version    `json:"version"`
+	ModuleSum       string           `json:"module_sum,omitempty"`
+	GoVersion       string           `json:"go_version"`
+	CommitRevision  string           `json:"commit_revision,omitempty"`
+	CommitTime      string           `json:"commit_time,omitempty"`
+	OS              string           `json:"os"`
+	Arch            string           `json:"arch"`
+	Feature         string           `json:"feature,omitempty"`
+	EnvVars         []string         `json:"env_vars,omitempty"`
+	BuildFlags      model.BuildFlags `json:"build_flags,omitzero"`
+	Managed         bool             `json:"managed"`
+	Pinned          bool             `json:"pinned"`
+	DevBuild        bool             `json:"dev_build"`
+	CrossBuild      bool             `json:"cross_build,omitempty"`
+	Verified        *bool            `json:"verified,omitempty"`
+	NotVerified     string           `json:"not_verified,omitempty"`
+	LatestVersion   model.Version    `json:"latest_version,omitempty"`
+	LatestGoVersion string           `json:"latest_go_version,omitempty"`
+}
+
+// newBinaryJSON creates the JSON output of the given binary info.
+func newBinaryJSON(info model.BinaryInfo) binaryJSON {
+	return binaryJSON{
+		Name:           info.Binary.Name,
+		Path:           info.FullPath,
+		InstallPath:    info.InstallPath,
+		Package:        info.PackagePath,
+		Module:         info.Module.Path,
+		Version:        info.Module.Version,
+		ModuleSum:      info.ModuleSum,
+		GoVersion:      info.GoVersion,
+		CommitRevision: info.CommitRevision,
+		CommitTime:     info.CommitTime,
+		OS:             info.OS,
+		Arch:           info.Arch,
+		Feature:        info.Feature,
+		EnvVars:        info.EnvVars,
+		BuildFlags:     info.BuildFlags,
+		Managed:        info.IsManaged,
+		Pinned:         info.IsPinned,
+		DevBuild:       info.IsDevBuild,
+		CrossBuild:     info.IsCrossBuild,
+	}
+}
+
+// diagnosticsJSON is the diagnostics printed as JSON by the doctor command,
+// listing the binaries with issues.
+type diagnosticsJSON struct {
+	Total      int              `json:"total"`
+	WithIssues int              `json:"with_issues"`
+	Binaries   []diagnosticJSON `json:"binaries"`
+}
+
+// diagnosticJSON is the diagnostic of a binary printed as JSON by the doctor
+// command, only holding its issues.
+type diagnosticJSON struct {
+	Name                    string              `json:"name"`
+	Module                  string              `json:"module,omitempty"`
+	NotInPath               bool                `json:"not_in_path,omitempty"`
+	DuplicatesInPath        []string            `json:"duplicates_in_path,omitempty"`
+	NotManaged              bool                `json:"not_managed,omitempty"`
+	PseudoVersion           bool                `json:"pseudo_version,omitempty"`
+	NotBuiltWithGoModules   bool                `json:"not_built_with_go_modules,omitempty"`
+	Orphaned                bool                `json:"orphaned,omitempty"`
+	GoVersionMismatch       *mismatchJSON       `json:"go_version_mismatch,omitempty"`
+	PlatformMismatch        *mismatchJSON       `json:"platform_mismatch,omitempty"`
+	MissingInterpreter      string              `json:"missing_interpreter,omitempty"`
+	LongPath                string              `json:"long_path,omitempty"`
+	Retracted               string              `json:"retracted,omitempty"`
+	Deprecated              string              `json:"deprecated,omitempty"`
+	CompressedNotExecutable string              `json:"compressed_not_executable,omitempty"`
+	InvalidSignature        string              `json:"invalid_signature,omitempty"`
+	SumMismatch             string              `json:"sum_mismatch,omitempty"`
+	SumNotVerifiable        string              `json:"sum_not_verifiable,omitempty"`
+	Vulnerabilities         []vulnerabilityJSON `json:"vulnerabilities,omitempty"`
+}
+
+// mismatchJSON is the expected and actual values of a mismatch found by the
+// doctor command.
+type mismatchJSON struct {
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// vulnerabilityJSON is a vulnerability found by the doctor command.
+type vulnerabilityJSON struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// newDiagnosticJSON creates the JSON output of the given binary diagnostic.
+func newDiagnosticJSON(diag model.BinaryDiagnostic) diagnosticJSON {
+	diagJSON := diagnosticJSON{
+		Name:                    diag.Name,
+		Module:                  diag.Module,
+		NotInPath:               diag.NotInPath,
+		DuplicatesInPath:        diag.DuplicatesInPath,
+		NotManaged:              diag.IsNotManaged,
+		PseudoVersion:           diag.IsPseudoVersion,
+		NotBuiltWithGoModules:   diag.NotBuiltWithGoModules,
+		Orphaned:                diag.IsOrphaned,
+		MissingInterpreter:      diag.MissingInterpreter,
+		LongPath:                diag.LongPath,
+		Retracted:               diag.Retracted,
+		Deprecated:              diag.Deprecated,
+		CompressedNotExecutable: diag.CompressedNotExecutable,
+		InvalidSignature:        diag.InvalidSignature,
+		SumMismatch:             diag.SumMismatch,
+		SumNotVerifiable:        diag.SumNotVerifiable,
+	}
+
+	if diag.GoVersion.Actual != diag.GoVersion.Expected {
+		diagJSON.GoVersionMismatch = &mismatchJSON{Expected: diag.GoVersion.Expected, Actual: diag.GoVersion.Actual}
+	}
+
+	if diag.Platform.Actual != diag.Platform.Expected {
+		diagJSON.PlatformMismatch = &mismatchJSON{Expected: diag.Platform.Expected, Actual: diag.Platform.Actual}
+	}
+
+	for _, vuln := range diag.Vulnerabilities {
+		diagJSON.Vulnerabilities = append(diagJSON.Vulnerabilities, vulnerabilityJSON{ID: vuln.ID, URL: vuln.URL})
+	}
+
+	return diagJSON
+}
+
+// originJSON is the origin of a binary printed as JSON by the why command.
+type originJSON struct {
+	binaryJSON
+
+	Resolution      string        `json:"resolution"`
+	LinkedBy        string        `json:"linked_by"`
+	PinMode         model.PinMode `json:"pin_mode,omitempty"`
+	InstalledAt     time.Time     `json:"installed_at"`
+	PreviousVersion model.Version `json:"previous_version,omitempty"`
+	MigratedFrom    string        `json:"migrated_from,omitempty"`
+	Source          string        `json:"source,omitempty"`
+	Protected       bool          `json:"protected,omitempty"`
+	BuiltBy         string        `json:"built_by,omitempty"`
+	Spec            string        `json:"spec,omitempty"`
+}
+
+// repositoryJSON is the repository of a binary printed as JSON by the repo
+// command.
+type repositoryJSON struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// binaryFix is the fix of an issue diagnosed in a binary, or in the Go binary
+// path, applied by the doctor command.
+type binaryFix struct {
+	Name   string
+	Action string
+	Reason string
+	apply  func(ctx context.Context) error
+}
+
+// linkedBinary is a binary linked to the local directory it is built from. Root
+// is the directory watched for changes, with the latest modification time of
+// its files when the binary was last built.
+type linkedBinary struct {
+	Binary  model.Binary
+	Source  string
+	Root    string
+	ModTime time.Time
+}
+
+// shadowedBinary is a managed binary shadowed by another executable of the
+// same name in a directory earlier in PATH, which runs instead.
+type shadowedBinary struct {
+	Name          string
+	Path          string
+	Dir           string
+	ShadowedBy    string
+	ShadowedByDir string
+}
+
+// operationFailure is the failure of an operation on a binary or package.
+type operationFailure struct {
+	Name   string
+	Reason string
+}
+
+// operationFailures collects the failures of the operations run in parallel,
+// one per binary or package, to print a summary at the end.
+type operationFailures struct {
+	mutex    sync.Mutex
+	failures []operationFailure
+}
+
+// add records the failure of the operation on the given binary or package.
+func (o *operationFailures) add(name string, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.failures = append(o.failures, operationFailure{Name: name, Reason: err.Error()})
+}
+
+// len returns the number of failed operations.
+func (o *operationFailures) len() int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return len(o.failures)
+}
+
+// operationTimings collects the timings of the operations run in parallel, one
+// per binary or package, to print a summary at the end.
+type operationTimings struct {
+	mutex   sync.Mutex
+	timings map[string]*internal.Timings
+}
+
+// newOperationTimings creates a new empty operationTimings.
+func newOperationTimings() *operationTimings {
+	return &operationTimings{
+		timings: make(map[string]*internal.Timings),
+	}
+}
+
+// track returns a copy of the context recording the timings of the operation
+// on the given binary or package.
+func (o *operationTimings) track(ctx context.Context, name string) context.Context {
+	timings := internal.NewTimings()
+
+	o.mutex.Lock()
+	o.timings[name] = timings
+	o.mutex.Unlock()
+
+	return internal.WithTimings(ctx, timings)
+}
+
+// pressureThrottle throttles the operations run in parallel under system
+// pressure: an operation is only started if no other operation is running or
+// the system is not under pressure, otherwise it waits for the pressure to drop
+// or a running operation to end. It is disabled if the pressure of the system
+// cannot be determined.
+type pressureThrottle struct {
+	mutex     sync.Mutex
+	resource  system.Resource
+	notify    func(pressure model.SystemPressure)
+	released  chan struct{}
+	running   int
+	disabled  bool
+	throttled bool
+}
+
+// newPressureThrottle creates a new pressureThrottle getting the pressure of
+// the system with the given resource, and calling notify with the pressure the
+// first time an operation is throttled.
+func newPressureThrottle(
+	resource system.Resource,
+	notify func(pressure model.SystemPressure),
+) *pressureThrottle {
+	return &pressureThrottle{
+		resource: resource,
+		notify:   notify,
+		released: make(chan struct{}, 1),
+	}
+}
+
+// acquire waits until an operation can be started, and records it as running.
+// It returns an error if the context is canceled while waiting.
+func (p *pressureThrottle) acquire(ctx context.Context) error {
+	for {
+		if p.tryAcquire(ctx) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.released:
+		case <-time.After(pressureCheckInterval):
+		}
+	}
+}
+
+// release records the end of a running operation, waking up an operation
+// waiting to start.
+func (p *pressureThrottle) release() {
+	p.mutex.Lock()
+	p.running--
+	p.mutex.Unlock()
+
+	select {
+	case p.released <- struct{}{}:
+	default:
+	}
+}
+
+// tryAcquire records an operation as running and returns true if it can be
+// started, or returns false if the system is under pressure.
+func (p *pressureThrottle) tryAcquire(ctx context.Context) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.running > 0 && !p.disabled {
+		pressure, err := p.resource.GetPressure(ctx)
+		if err != nil {
+			p.disabled = true
+		} else if pressure.IsHigh() {
+			if !p.throttled {
+				p.throttled = true
+				p.notify(pressure)
+			}
+
+			return false
+		}
+	}
+
+	p.running++
+
+	return true
+}
+
 // Gobin is an application that manages Go binaries.
 type Gobin struct {
-	binaryManager manager.BinaryManager
-	fs            system.FileSystem
-	resource      system.Resource
-	stdErr        io.Writer
-	stdOut        io.Writer
-	workspace     system.Workspace
+	binaryManager   manager.BinaryManager
+	cacheFrom       string
+	compress        bool
+	config          model.Config
+	dryRun          bool
+	fs              system.FileSystem
+	asJSON          bool
+	platform        string
+	progress        bool
+	quiet           bool
+	resource        system.Resource
+	showAllWarnings bool
+	strict          bool
+	stdErr          io.Writer
+	stdIn           *bufio.Reader
+	stdinArgs       bool
+	stdOut          io.Writer
+	userPath        system.UserPath
+	workspace       system.Workspace
 }
 
 // NewGobin creates a new Gobin application.
 func NewGobin(
 	binaryManager manager.BinaryManager,
+	config model.Config,
 	fs system.FileSystem,
 	resource system.Resource,
 	stdErr io.Writer,
+	stdIn io.Reader,
 	stdOut io.Writer,
+	userPath system.UserPath,
 	workspace system.Workspace,
 ) *Gobin {
 	return &Gobin{
 		binaryManager: binaryManager,
+		compress:      config.UPX,
+		config:        config,
 		fs:            fs,
 		resource:      resource,
 		stdErr:        stdErr,
+		stdIn:         bufio.NewReader(stdIn),
 		stdOut:        stdOut,
+		userPath:      userPath,
 		workspace:     workspace,
 	}
 }
 
-// DiagnoseBinaries diagnoses issues in all binaries in the Go binary directory.
-// It prints a template with the diagnostic results to the standard output (or
-// another defined io.Writer), or an error if the binary directory cannot be
-// determined or listed. The command runs in parallel, launching go routines to
-// diagnose binaries up to the given parallelism.
-func (g *Gobin) DiagnoseBinaries(ctx context.Context, parallelism int) error {
-	bins, err := g.fs.ListBinaries(g.workspace.GetGoBinPath())
+// BuildMatrix builds the given package for each of the given platforms, ex.
+// linux/amd64, into the given directory, naming the binaries
+// <binary>_<version>_<goos>_<goarch>. The package version is resolved once, so
+// every platform is built at the same version, and the builds run in parallel
+// up to the given parallelism with the given build flags. The Go binary
+// directory and the managed binaries are left untouched. It prints the built
+// binaries to the standard output (or another defined io.Writer), and returns
+// an error if the package is denied by the configuration or cannot be
+// resolved, or if any of the builds fails.
+func (g *Gobin) BuildMatrix(
+	ctx context.Context,
+	parallelism int,
+	pkg model.Package,
+	platforms []string,
+	flags model.BuildFlags,
+	dir string,
+) error {
+	if rule, ok := g.config.GetDenyRule(pkg); ok {
+		fmt.Fprintf(g.stdErr, "❌ package %q denied by policy rule %q\n", pkg.Path, rule)
+		return ErrPackageDenied
+	}
+
+	resolvedPkg, err := g.binaryManager.ResolvePackage(ctx, pkg)
 	if err != nil {
+		switch {
+		case errors.Is(err, toolchain.ErrModuleNotFound):
+			fmt.Fprintf(g.stdErr, "❌ module for package %q not found\n", pkg.String())
+		case errors.Is(err, manager.ErrVersionNotAvailable),
+			errors.Is(err, manager.ErrRefNotFound),
+			errors.Is(err, manager.ErrPackageNotFound),
+			errors.Is(err, manager.ErrPackageNotMain):
+			fmt.Fprintf(g.stdErr, "❌ invalid package %q: %s\n", pkg.String(), err)
+		default:
+			fmt.Fprintf(g.stdErr, "❌ error resolving package %q\n", pkg.String())
+		}
+
 		return err
 	}
 
-	var (
-		mutex sync.Mutex
-		diags = make([]model.BinaryDiagnostic, 0, len(bins))
-		grp   = new(errgroup.Group)
-	)
-
+	grp := new(errgroup.Group)
 	grp.SetLimit(parallelism)
+	failures := new(operationFailures)
+	paths := make([]string, len(platforms))
 
-	for _, bin := range bins {
+	for i, platform := range platforms {
 		grp.Go(func() error {
-			diag, diagErr := g.binaryManager.DiagnoseBinary(ctx, bin)
-			if diagErr != nil {
-				fmt.Fprintf(g.stdErr, "❌ error diagnosing binary %q\n", filepath.Base(bin))
-				return diagErr
-			}
+			ctx := internal.WithLogAttrs(
+				ctx,
+				slog.String("operation", "build"),
+				slog.String("package", resolvedPkg.Path),
+				slog.String("version", resolvedPkg.Version.String()),
+				slog.String("platform", platform),
+			)
+			start := time.Now()
 
-			mutex.Lock()
-			diags = append(diags, diag)
-			mutex.Unlock()
+			path, buildErr := g.binaryManager.BuildPackage(ctx, resolvedPkg, platform, flags, dir)
+			logOperation(ctx, start, buildErr)
+			if buildErr != nil {
+				fmt.Fprintf(g.stdErr, "❌ cannot build package %q for %s\n", resolvedPkg.String(), platform)
+				failures.add(platform, buildErr)
+				return buildErr
+			}
 
+			paths[i] = path
 			return nil
 		})
 	}
 
-	waitErr := grp.Wait()
+	waitErr := getPartialFailure(grp.Wait(), failures.len(), len(platforms))
 
-	if err = g.printBinaryDiagnostics(diags); err != nil {
+	for i, path := range paths {
+		if path != "" {
+			fmt.Fprintf(g.output(), "✅ %s built for %s: %s\n", resolvedPkg.String(), platforms[i], path)
+		}
+	}
+
+	if err = g.printFailures(failures); err != nil {
 		return err
 	}
 
 	return waitErr
 }
 
-// InstallPackages installs the given packages. It returns an error if any of
-// the packages cannot be installed. The command runs in parallel, launching go
-// routines to install the packages up to the given parallelism.
-func (g *Gobin) InstallPackages(
-	ctx context.Context,
-	parallelism int,
-	kind model.Kind,
-	rebuild bool,
-	packages ...model.Package,
-) error {
-	grp := new(errgroup.Group)
-	grp.SetLimit(parallelism)
+// BundlePackages creates a module bundle at the given path with the modules
+// required to install the given packages, to install them offline on machines
+// without network access with install --from-bundle. The package versions are
+// resolved first, so the bundle holds the exact versions to install. Without
+// packages, the packages of the managed binaries are bundled at their installed
+// versions, skipping the binaries not built from a released module version. It
+// prints the bundled packages to the standard output (or another defined
+// io.Writer), and returns an error if a package is denied by the configuration
+// or cannot be resolved, or if the bundle cannot be created.
+func (g *Gobin) BundlePackages(ctx context.Context, path string, pkgs ...model.Package) error {
+	resolvedPkgs, err := g.resolvePackages(ctx, pkgs...)
+	if err != nil {
+		return err
+	}
 
-	for _, pkg := range packages {
-		grp.Go(func() error {
-			return g.binaryManager.InstallPackage(ctx, pkg, kind, rebuild)
-		})
+	if err = g.binaryManager.BundlePackages(ctx, path, resolvedPkgs...); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error creating bundle %s: %s\n", path, err)
+		return err
+	}
+
+	for _, pkg := range resolvedPkgs {
+		fmt.Fprintf(g.output(), "✅ %s bundled in %s\n", pkg.String(), path)
 	}
 
-	return grp.Wait()
+	return nil
 }
 
-// ListBinaries lists all binaries in the Go binary directory, or if managed is
-// true, it lists all binaries in the internal binary directory. It prints a
-// template with the binaries to the standard output (or another defined
-// io.Writer), or an error if the binary directory cannot be determined or
-// listed.
-func (g *Gobin) ListBinaries(managed bool) error {
-	binInfos, err := g.binaryManager.GetAllBinaryInfos(managed)
-	if err != nil {
+// ClearCache removes the cache directory, holding the cached module lookups,
+// so the following commands request the module proxies again. It prints a
+// success message to the standard output (or another defined io.Writer), or
+// returns an error if the directory cannot be removed.
+func (g *Gobin) ClearCache() error {
+	path := g.workspace.GetInternalCachePath()
+	if err := g.fs.RemoveAll(path); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error clearing cache %s\n", path)
 		return err
 	}
 
-	return g.printBinaries(binInfos, managed)
+	fmt.Fprintf(g.output(), "✅ cache %s cleared\n", path)
+
+	return nil
 }
 
-// ListOutdatedBinaries lists all outdated binaries in the Go binary directory.
-// It prints a template with the outdated binaries to the standard output (or
-// another defined io.Writer), or an error if the binary directory cannot be
-// determined or listed. The command runs in parallel, launching go routines to
-// check the upgrade information of the binaries up to the given parallelism.
-func (g *Gobin) ListOutdatedBinaries(
-	ctx context.Context,
-	checkMajor bool,
-	parallelism int,
-) error {
-	binInfos, err := g.binaryManager.GetAllBinaryInfos(false)
-	if err != nil {
-		return err
+// CreateBugReport creates a bug report zip file at the given path. It collects
+// the version of the given executable, the configuration, the relevant
+// environment variables from the given list, the tail of the log file at the
+// given path and its last operation records, with secrets redacted. It prints
+// a success message to the standard output (or another defined io.Writer), or
+// an error if the zip file cannot be written.
+func (g *Gobin) CreateBugReport(path, execPath, logPath string, envVars []string) error {
+	logs, err := g.fs.ReadFile(logPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Default().Warn("error reading log file", "path", logPath, "err", err)
 	}
 
-	var (
-		mutex    sync.Mutex
-		outdated = make([]model.BinaryUpgradeInfo, 0, len(binInfos))
-		grp      = new(errgroup.Group)
-	)
-
-	grp.SetLimit(parallelism)
-
-	for _, info := range binInfos {
-		grp.Go(func() error {
-			binUpInfo, infoErr := g.binaryManager.GetBinaryUpgradeInfo(
-				ctx, info, checkMajor,
-			)
-			if errors.Is(infoErr, toolchain.ErrBinaryBuiltWithoutGoModules) {
-				return nil
-			} else if infoErr != nil {
-				return infoErr
-			}
+	logs = []byte(model.RedactURLCredentials(string(logs)))
 
-			if binUpInfo.IsUpgradeAvailable {
-				mutex.Lock()
-				outdated = append(outdated, binUpInfo)
-				mutex.Unlock()
-			}
+	files := map[string][]byte{
+		"config.yaml":     g.getBugReportConfig(),
+		"environment.txt": getBugReportEnv(envVars),
+		"gobin.log":       getLogTail(logs, bugReportLogTailSize),
+		"operations.log":  getOperationRecords(logs, bugReportMaxOperations),
+		"version.txt":     g.getBugReportVersion(execPath),
+	}
 
-			return nil
-		})
+	if err = g.fs.WriteZip(path, files); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error writing bug report to %s\n", path)
+		return err
 	}
 
-	waitErr := grp.Wait()
+	fmt.Fprintf(g.output(), "✅ bug report written to %s, review it before attaching it to an issue\n", path)
 
-	if len(outdated) == 0 {
-		if waitErr == nil {
-			fmt.Fprintln(g.stdOut, "✅ All binaries are up to date")
-			return nil
-		}
+	return nil
+}
 
-		return waitErr
-	}
+// DiagnoseBinaries diagnoses issues in all binaries in the Go binary directory,
+// and in the binaries cross compiled for another platform. It prints a template
+// with the diagnostic results to the standard output (or another defined
+// io.Writer), or an error if the binary directory cannot be determined or
+// listed. The command runs in parallel, launching go routines to diagnose
+// binaries up to the given parallelism. It also warns when the Go binary
+// directory is shared through WSL and when checksum database verification is
+// disabled for all modules, and summarizes the binaries that failed to be
+// diagnosed when there are several.
+func (g *Gobin) DiagnoseBinaries(ctx context.Context, parallelism int, timings bool) error {
+	_, err := g.diagnoseBinaries(ctx, parallelism, timings)
+	return err
+}
 
-	if err = g.printOutdatedBinaries(outdated); err != nil {
+// DiagnoseNetwork diagnoses the module proxies configured in GOPROXY. It prints
+// a template with the latency and the failed probes of each proxy, followed by
+// a template with the HTTP proxy traversed to reach each network endpoint, to
+// the standard output (or another defined io.Writer), or an error if the
+// module proxies or the HTTP proxies cannot be determined.
+func (g *Gobin) DiagnoseNetwork(ctx context.Context) error {
+	healths, err := g.binaryManager.DiagnoseNetwork(ctx)
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error diagnosing module proxies")
 		return err
 	}
 
-	return waitErr
-}
-
-// MigrateBinaries migrates the given binaries to be managed internally. It
-// returns an error if any of the binaries cannot be migrated due to the binary
-// being not found or the binary being already managed or any other error.
-func (g *Gobin) MigrateBinaries(bins ...model.Binary) error {
-	var err error
-	var binPaths []string
+	tmplParsed := template.Must(template.New("network").Parse(networkTemplate))
+	if err = tmplParsed.Execute(g.output(), healths); err != nil {
+		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+		return err
+	}
 
-	goBinPath := g.workspace.GetGoBinPath()
+	routes, err := g.binaryManager.DiagnoseHTTPProxies(ctx)
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error diagnosing http proxies")
+		return err
+	}
 
-	if len(bins) == 0 {
-		binPaths, err = g.fs.ListBinaries(goBinPath)
-		if err != nil {
-			return err
-		}
-	} else {
-		for _, bin := range bins {
-			binPaths = append(binPaths, filepath.Join(goBinPath, bin.String()))
-		}
+	tmplParsed = template.Must(template.New("http-proxies").Parse(httpProxiesTemplate))
+	if err = tmplParsed.Execute(g.output(), routes); err != nil {
+		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+		return err
 	}
 
-	for _, path := range binPaths {
-		if migrateErr := g.binaryManager.MigrateBinary(path); migrateErr != nil {
-			switch {
-			case errors.Is(migrateErr, manager.ErrBinaryAlreadyManaged):
-				fmt.Fprintf(g.stdErr, "❌ binary %q already managed\n", filepath.Base(path))
-			case errors.Is(migrateErr, toolchain.ErrBinaryNotFound):
-				fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", filepath.Base(path))
-			default:
-				fmt.Fprintf(g.stdErr, "❌ error migrating binary %q\n", filepath.Base(path))
-			}
+	return nil
+}
 
-			err = migrateErr
-			continue
-		}
+// DiffBinary prints the differences between two managed versions of a binary
+// to the standard output (or another defined io.Writer): the Go version, the
+// size, the build settings and the versions of the embedded dependencies. It
+// returns an error if a version is not managed or its build cannot be read.
+func (g *Gobin) DiffBinary(bin model.Binary, from, to model.Version) error {
+	binPaths, err := g.fs.ListBinaries(g.workspace.GetInternalBinPath())
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error listing managed binaries")
+		return err
 	}
 
-	return err
-}
+	builds := make([]model.BinaryBuild, 0, 2) //nolint:mnd // from and to builds
+	for _, version := range []model.Version{from, to} {
+		idx := slices.IndexFunc(binPaths, func(path string) bool {
+			intBin := model.NewBinaryFromString(filepath.Base(path))
+			return intBin.Name == bin.Name && intBin.Version.Compare(version) == 0
+		})
+		if idx < 0 {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.Name+"@"+version.String())
+			return toolchain.ErrBinaryNotFound
+		}
 
-// PinBinaries pins the given binaries to the Go binary directory. It returns an
-// error if any of the binaries cannot be pinned.
-func (g *Gobin) PinBinaries(kind model.Kind, bins ...model.Binary) error {
-	var err error
-	for _, bin := range bins {
-		pinErr := g.binaryManager.PinBinary(bin, kind)
-		if errors.Is(pinErr, toolchain.ErrBinaryNotFound) {
-			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
-		} else if pinErr != nil {
-			fmt.Fprintf(g.stdErr, "❌ error pinning binary %q\n", bin.String())
+		build, buildErr := g.binaryManager.GetBinaryBuild(binPaths[idx])
+		if buildErr != nil {
+			fmt.Fprintf(g.stdErr, "❌ error getting build of binary %q\n", filepath.Base(binPaths[idx]))
+			return buildErr
 		}
 
-		err = pinErr
+		builds = append(builds, build)
 	}
 
-	return err
+	return g.printBinaryDiff(bin.Name, builds[0], builds[1])
 }
 
-// PrintBinaryInfo prints the binary info for a given binary. It prints a
-// template with the binary info to the standard output (or another defined
-// io.Writer), or an error if the binary cannot be found.
-func (g *Gobin) PrintBinaryInfo(bin model.Binary) error {
+// DiffLatestBinary prints the differences between an installed binary and the
+// latest version of its module to the standard output (or another defined
+// io.Writer). The latest version is resolved from its module file, so its
+// build settings and size are unknown. It returns an error if the binary is
+// not installed, or the latest version cannot be resolved.
+func (g *Gobin) DiffLatestBinary(ctx context.Context, bin model.Binary) error {
 	binInfo, err := g.binaryManager.GetBinaryInfo(
 		filepath.Join(g.workspace.GetGoBinPath(), bin.String()),
 	)
@@ -359,212 +1290,3366 @@ func (g *Gobin) PrintBinaryInfo(bin model.Binary) error {
 		return err
 	}
 
-	tmplParsed := template.Must(template.New("info").Parse(infoTemplate))
-	if err = tmplParsed.Execute(g.stdOut, binInfo); err != nil {
-		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+	from, err := g.binaryManager.GetBinaryBuild(binInfo.InstallPath)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error getting build of binary %q\n", bin.String())
 		return err
 	}
 
-	return nil
-}
-
-// PrintShortVersion prints the short version of a given binary. It prints the
-// module version to the standard output (or another defined io.Writer), or an
-// error if the binary cannot be found.
-func (g *Gobin) PrintShortVersion(path string) error {
-	binInfo, err := g.binaryManager.GetBinaryInfo(path)
+	binUpInfo, err := g.binaryManager.GetBinaryUpgradeInfo(ctx, binInfo, false)
 	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error checking latest version of binary %q\n", bin.String())
 		return err
 	}
 
-	fmt.Fprintln(g.stdOut, binInfo.Module.Version.String())
-
-	return nil
-}
-
-// PrintVersion prints the version of a given binary. It prints the module
-// version, Go version, OS, and architecture to the standard output (or another
-// defined io.Writer), or an error if the binary cannot be found.
-func (g *Gobin) PrintVersion(path string) error {
-	binInfo, err := g.binaryManager.GetBinaryInfo(path)
+	to, err := g.binaryManager.GetModuleBuild(ctx, binUpInfo.LatestModule)
 	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error getting module file of %q\n", binUpInfo.LatestModule.String())
 		return err
 	}
 
-	fmt.Fprintf(
-		g.stdOut,
-		"%s (%s %s/%s)\n",
-		binInfo.Module.Version.String(),
-		binInfo.GoVersion,
-		binInfo.OS,
-		binInfo.Arch,
-	)
-
-	return nil
+	return g.printBinaryDiff(bin.String(), from, to)
 }
 
-// PruneBinaries prunes the given binaries or the whole internal binary
-// directory. It returns an error if the binary directory cannot be determined
-// or listed, or if the binaries failed to be pruned.
-func (g *Gobin) PruneBinaries(bins ...model.Binary) error {
-	if len(bins) == 0 {
-		binPaths, err := g.fs.ListBinaries(g.workspace.GetInternalBinPath())
-		if err != nil {
-			return err
-		}
+// ExportLockfile writes the lockfile of the managed binaries to the given path,
+// or to the standard output (or another defined io.Writer) if the path is "-",
+// listing the package, version and pin kind of each pin so gobin sync can
+// reproduce them on another machine. Development builds are skipped. It prints
+// a success message to the standard output (or another defined io.Writer), and
+// returns an error if the managed binaries cannot be listed or the lockfile
+// cannot be written.
+func (g *Gobin) ExportLockfile(path string) error {
+	bins, err := g.binaryManager.GetLockedBinaries()
+	if err != nil {
+		return err
+	}
 
-		for _, path := range binPaths {
-			bin := model.NewBinaryFromString(filepath.Base(path))
-			bins = append(bins, model.NewBinaryFromString(bin.Name+bin.Extension))
-		}
+	data, err := model.NewLockfile(bins...).Marshal()
+	if err != nil {
+		slog.Default().Error("error encoding lockfile", "err", err)
+		return err
+	}
 
-		bins = slices.Compact(bins)
+	if path == "-" {
+		_, err = g.output().Write(data)
+		return err
 	}
 
-	var err error
-	for _, bin := range bins {
-		if pruneErr := g.binaryManager.PruneBinary(bin); pruneErr != nil {
-			err = pruneErr
-			continue
-		}
+	//nolint:mnd // file permissions
+	if err = g.fs.WriteFile(path, data, 0644); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error writing lockfile %s\n", path)
+		return err
 	}
 
-	return err
+	fmt.Fprintf(g.output(), "✅ %d binaries exported to %s\n", len(bins), path)
+
+	return nil
 }
 
-// ShowBinaryRepository shows the repository URL for a given binary. It prints
-// the repository URL to the standard output (or another defined io.Writer), or
-// an error if the binary cannot be found. If the open flag is set, it opens the
-// repository URL in the default system browser.
-func (g *Gobin) ShowBinaryRepository(ctx context.Context, bin model.Binary, open bool) error {
-	repoURL, err := g.binaryManager.GetBinaryRepository(ctx, bin)
+// ExportSBOM writes the software bill of materials of the given binary in the
+// given format to the given path, or to the standard output (or another defined
+// io.Writer) if the path is "-", listing the main module and the modules
+// embedded in the build info of the binary. It prints a success message to the
+// standard output (or another defined io.Writer) when written to a file, or an
+// error if the binary cannot be found or the document cannot be written.
+func (g *Gobin) ExportSBOM(bin model.Binary, format model.SBOMFormat, path string) error {
+	sbom, err := g.binaryManager.GetBinarySBOM(filepath.Join(g.workspace.GetGoBinPath(), bin.String()))
 	if err != nil {
 		if errors.Is(err, toolchain.ErrBinaryNotFound) {
 			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
 		} else {
-			fmt.Fprintf(g.stdErr, "❌ error getting repository for binary %q\n", bin.String())
+			fmt.Fprintf(g.stdErr, "❌ error getting SBOM for binary %q\n", bin.String())
 		}
 
 		return err
 	}
 
-	if open {
-		return g.resource.Open(ctx, repoURL)
+	data, err := sbom.Marshal(format, time.Now())
+	if err != nil {
+		slog.Default().Error("error encoding SBOM", "format", format, "err", err)
+		return err
 	}
 
-	fmt.Fprintln(g.stdOut, repoURL)
-	return nil
-}
-
-// UninstallBinaries uninstalls the given binaries by removing the binary files.
-// It returns an error if the binary cannot be found or removed.
-func (g *Gobin) UninstallBinaries(bins ...model.Binary) error {
-	var err error
-	for _, bin := range bins {
-		removeErr := g.binaryManager.UninstallBinary(bin)
-		if errors.Is(removeErr, os.ErrNotExist) {
-			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin)
-		} else if removeErr != nil {
-			fmt.Fprintf(g.stdErr, "❌ error uninstalling binary %q\n", bin)
-		}
+	if path == "-" {
+		_, err = g.output().Write(data)
+		return err
+	}
 
-		err = removeErr
+	//nolint:mnd // file permissions
+	if err = g.fs.WriteFile(path, data, 0644); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error writing SBOM %s\n", path)
+		return err
 	}
 
-	return err
-}
+	fmt.Fprintf(g.output(), "✅ %s SBOM of %s written to %s\n", format, bin.String(), path)
 
-// UpgradeBinaries upgrades the given binaries or all binaries in the Go binary
-// directory. If majorUpgrade is set, it upgrades the major version of the
-// binaries. If rebuild is set, it rebuilds the binaries. It returns an error if
-// the binary directory cannot be determined or listed. The command runs in
-// parallel, launching go routines to upgrade the binaries up to the given
-// parallelism.
-func (g *Gobin) UpgradeBinaries(
-	ctx context.Context,
-	majorUpgrade bool,
-	rebuild bool,
-	parallelism int,
-	bins ...model.Binary,
-) error {
-	binFullPath := g.workspace.GetGoBinPath()
+	return nil
+}
 
-	var binPaths []string
-	if len(bins) == 0 {
-		var err error
-		binPaths, err = g.fs.ListBinaries(binFullPath)
-		if err != nil {
-			return err
-		}
-	} else {
-		for _, bin := range bins {
-			binPaths = append(binPaths, filepath.Join(binFullPath, bin.String()))
-		}
+// ExtractBundle extracts the module bundle at the given path, a gzip compressed
+// tar archive of a module cache, into a temporary directory in the internal
+// temp directory, to be used as the module cache of offline installs. It
+// returns the directory and the function removing it, or prints an error to
+// the standard error (or another defined io.Writer) if the bundle cannot be
+// extracted.
+func (g *Gobin) ExtractBundle(path string) (string, system.CleanupFunc, error) {
+	dir, cleanup, err := g.fs.CreateTempDir(g.workspace.GetInternalTempPath(), "bundle-*")
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error extracting bundle %s\n", path)
+		return "", nil, err
 	}
 
-	grp := new(errgroup.Group)
-	grp.SetLimit(parallelism)
+	if err = g.fs.ExtractArchive(path, dir); err != nil {
+		_ = cleanup()
 
-	for _, bin := range binPaths {
-		grp.Go(func() error {
-			upErr := g.binaryManager.UpgradeBinary(ctx, bin, majorUpgrade, rebuild)
-			if errors.Is(upErr, toolchain.ErrBinaryNotFound) {
-				fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", filepath.Base(bin))
-			} else if upErr != nil {
-				fmt.Fprintf(g.stdErr, "❌ error upgrading binary %q\n", filepath.Base(bin))
-			}
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(g.stdErr, "❌ bundle %s not found\n", path)
+		} else {
+			fmt.Fprintf(g.stdErr, "❌ error extracting bundle %s: %s\n", path, err)
+		}
 
-			return upErr
-		})
+		return "", nil, err
 	}
 
-	return grp.Wait()
+	return dir, cleanup, nil
 }
 
-// printBinaryDiagnostics prints the binary diagnostics to the standard output
-// (or another defined io.Writer).
-func (g *Gobin) printBinaryDiagnostics(diags []model.BinaryDiagnostic) error {
-	var diagWithIssues = make([]model.BinaryDiagnostic, 0, len(diags))
-	for _, d := range diags {
-		if d.HasIssues() {
-			diagWithIssues = append(diagWithIssues, d)
-		}
+// FixBinaries diagnoses issues in all binaries, as DiagnoseBinaries does, and
+// fixes the issues that can be fixed: it migrates the binaries not managed by
+// gobin, rebuilds the managed binaries with a Go version or platform mismatch,
+// repins the pins whose internal binary is missing, and removes the duplicate
+// additions of the Go binary path to PATH made by gobin. It prints the fixes
+// per binary to the standard output (or another defined io.Writer) and, unless
+// dryRun is set, applies them after an explicit confirmation, reporting the
+// result of each fix. It returns an error if the binaries cannot be diagnosed
+// or any fix fails.
+func (g *Gobin) FixBinaries(ctx context.Context, parallelism int, timings, dryRun bool) error {
+	diags, diagErr := g.diagnoseBinaries(ctx, parallelism, timings)
+	if diags == nil && diagErr != nil {
+		return diagErr
 	}
 
-	sort.Slice(diagWithIssues, func(i, j int) bool {
-		return diagWithIssues[i].Name < diagWithIssues[j].Name
-	})
+	fixes, err := g.getBinaryFixes(diags)
+	if err != nil {
+		return err
+	}
+
+	if len(fixes) == 0 {
+		fmt.Fprintln(g.output(), "\n✅ no issues to fix")
+		return diagErr
+	}
 
 	data := struct {
-		Total           int
-		WithIssues      int
-		DiagsWithIssues []model.BinaryDiagnostic
+		DryRun bool
+		Fixes  []binaryFix
 	}{
-		Total:           len(diags),
-		WithIssues:      len(diagWithIssues),
-		DiagsWithIssues: diagWithIssues,
+		DryRun: dryRun,
+		Fixes:  fixes,
 	}
 
-	tmplParsed := template.Must(template.New("doctor").Parse(doctorTemplate))
-	if err := tmplParsed.Execute(g.stdOut, data); err != nil {
+	tmplParsed := template.Must(template.New("fixes").Parse(fixesTemplate))
+	if err = tmplParsed.Execute(g.stdOut, data); err != nil {
 		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
 		return err
 	}
 
-	return nil
-}
+	if dryRun {
+		return diagErr
+	}
 
-// printBinaries prints the binaries to the standard output (or another defined
-// io.Writer). If managed is false, it prints the installed binaries, highlighting
-// the managed binaries in green. If managed is true, it prints the managed
-// binaries, highlighting the pinned binaries in green.
-func (g *Gobin) printBinaries(binInfos []model.BinaryInfo, managed bool) error {
-	sort.Slice(binInfos, func(i, j int) bool {
-		if binInfos[i].Binary.Name != binInfos[j].Binary.Name {
-			return binInfos[i].Binary.Name < binInfos[j].Binary.Name
-		}
+	confirmed, err := g.confirm("Apply fixes?")
+	if err != nil {
+		return err
+	}
 
-		return binInfos[i].Module.Version.Compare(binInfos[j].Module.Version) > 0
+	if !confirmed {
+		return diagErr
+	}
+
+	failed := make(map[string]bool, len(fixes))
+	for _, fix := range fixes {
+		if failed[fix.Name] {
+			continue
+		}
+
+		if fixErr := fix.apply(ctx); fixErr != nil {
+			if errors.Is(fixErr, manager.ErrBinaryProtected) {
+				fmt.Fprintf(g.stdErr, "❌ binary %q is protected, unprotect it to %s it\n", fix.Name, fix.Action)
+			} else {
+				fmt.Fprintf(g.stdErr, "❌ error fixing %q (%s)\n", fix.Name, fix.Action)
+			}
+
+			failed[fix.Name] = true
+			err = fixErr
+			continue
+		}
+
+		fmt.Fprintf(g.output(), "✅ fixed %q (%s)\n", fix.Name, fix.Action)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return diagErr
+}
+
+// FixPath adds the Go binary path to the user PATH when it is not in PATH. It
+// prints a preview of the change to the shell profile file (or the Windows user
+// PATH) to the standard output (or another defined io.Writer) and applies it
+// only after an explicit confirmation. It returns an error if the change cannot
+// be determined or applied.
+func (g *Gobin) FixPath(ctx context.Context) error {
+	goBinPath := g.workspace.GetGoBinPath()
+	if g.userPath.Contains(goBinPath) {
+		fmt.Fprintf(g.output(), "✅ %s is already in PATH\n", goBinPath)
+		return nil
+	}
+
+	addition, err := g.userPath.GetAddition(goBinPath)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error determining how to add %s to PATH\n", goBinPath)
+		return err
+	}
+
+	fmt.Fprintf(g.stdOut, "🔧 %s is not in PATH, the following will be appended to %s:\n", goBinPath, addition.Target)
+	fmt.Fprintf(g.stdOut, "    %s\n", addition.Change)
+
+	confirmed, err := g.confirm("Apply fix?")
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return nil
+	}
+
+	if err = g.userPath.Add(ctx, addition); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error adding %s to PATH\n", goBinPath)
+		return err
+	}
+
+	fmt.Fprintf(g.output(), "✅ %s added to PATH, restart your shell to apply it\n", goBinPath)
+
+	return nil
+}
+
+// GenerateTasks prints the tasks of the given task runner installing the given
+// packages with gobin to the standard output (or another defined io.Writer): a
+// Makefile or a Taskfile with a tools task depending on a task per package,
+// skipped when the binary is already installed from the package at the version.
+// The package versions are resolved first, so the tasks install the exact
+// versions. Without packages, the packages of the managed binaries are used at
+// their installed versions, skipping the binaries not built from a released
+// module version. It returns an error if a package is denied by the
+// configuration or cannot be resolved.
+func (g *Gobin) GenerateTasks(ctx context.Context, runner model.TaskRunner, pkgs ...model.Package) error {
+	resolvedPkgs, err := g.resolvePackages(ctx, pkgs...)
+	if err != nil {
+		return err
+	}
+
+	type tool struct {
+		Name    string
+		Path    string
+		Version string
+	}
+
+	tools := make([]tool, 0, len(resolvedPkgs))
+	for _, pkg := range resolvedPkgs {
+		tools = append(tools, tool{
+			Name:    pkg.GetBinaryName(),
+			Path:    pkg.Path,
+			Version: pkg.Version.String(),
+		})
+	}
+
+	data := struct {
+		Runner model.TaskRunner
+		Tools  []tool
+	}{
+		Runner: runner,
+		Tools:  tools,
+	}
+
+	tmplParsed := template.Must(template.New("generate").Parse(generateTemplate))
+	if err = tmplParsed.Execute(g.output(), data); err != nil {
+		slog.Default().Error("error executing template", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetModuleTools gets the tool dependencies of the module of the go.mod file at
+// the given path, declared with tool directives or blank imports in the
+// tools.go file next to it, as packages at the versions of the go.sum file. It
+// prints an error to the standard error (or another defined io.Writer) and
+// returns ErrNoModuleTools if the module has no tool dependencies, or an error
+// if the files cannot be read or the tool dependencies are not valid.
+func (g *Gobin) GetModuleTools(path string) ([]model.Package, error) {
+	goMod, err := g.fs.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(g.stdErr, "❌ %s not found\n", path)
+		return nil, err
+	} else if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error reading %s\n", path)
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+
+	goSum, err := g.fs.ReadFile(filepath.Join(dir, model.GoSumFileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(g.stdErr, "❌ error reading %s\n", filepath.Join(dir, model.GoSumFileName))
+		return nil, err
+	}
+
+	toolsGo, err := g.fs.ReadFile(filepath.Join(dir, model.ToolsFileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(g.stdErr, "❌ error reading %s\n", filepath.Join(dir, model.ToolsFileName))
+		return nil, err
+	}
+
+	packages, err := model.ParseModuleTools(goMod, goSum, toolsGo)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ invalid tool dependencies in %s: %s\n", path, err)
+		return nil, err
+	}
+
+	if len(packages) == 0 {
+		fmt.Fprintf(g.stdErr, "❌ no tool dependencies in %s (add them with go get -tool)\n", path)
+		return nil, ErrNoModuleTools
+	}
+
+	return packages, nil
+}
+
+// GetRunBinary gets the path of the binary of the given package to run it
+// without installing it, storing the binary in the internal binary directory
+// without pinning it to the Go binary directory. The binary already stored for
+// the version is reused, so the package is only built the first time. It
+// returns an error if the package is denied by the configuration, the version
+// cannot be resolved, or the package is not valid or cannot be built.
+func (g *Gobin) GetRunBinary(ctx context.Context, pkg model.Package) (string, error) {
+	if rule, ok := g.config.GetDenyRule(pkg); ok {
+		fmt.Fprintf(g.stdErr, "❌ package %q denied by policy rule %q\n", pkg.Path, rule)
+		return "", ErrPackageDenied
+	}
+
+	path, err := g.binaryManager.StorePackage(ctx, pkg)
+	switch {
+	case errors.Is(err, manager.ErrBinaryNameCollision):
+		fmt.Fprintf(g.stdErr, "❌ cannot run package %q: %s\n", pkg.String(), err)
+	case errors.Is(err, toolchain.ErrModuleNotFound):
+		fmt.Fprintf(g.stdErr, "❌ module for package %q not found\n", pkg.String())
+	case errors.Is(err, manager.ErrVersionNotAvailable),
+		errors.Is(err, manager.ErrRefNotFound),
+		errors.Is(err, manager.ErrPackageNotFound),
+		errors.Is(err, manager.ErrPackageNotMain):
+		fmt.Fprintf(g.stdErr, "❌ invalid package %q: %s\n", pkg.String(), err)
+	case err != nil:
+		fmt.Fprintf(g.stdErr, "❌ error building package %q\n", pkg.String())
+	}
+
+	return path, err
+}
+
+// HandleCommandNotFound handles a command not found by the shell. When the
+// command is provided by a managed binary, installed before with gobin, it
+// offers to install the latest version of its package, for the shell hook to
+// run the command again. It returns ErrCommandNotFound if the command is not
+// provided by any managed binary or the installation is declined, or an error
+// if the managed binaries cannot be listed or the package fails to install.
+func (g *Gobin) HandleCommandNotFound(ctx context.Context, name string) error {
+	binInfos, err := g.binaryManager.GetAllBinaryInfos(true)
+	if err != nil {
+		return err
+	}
+
+	var match *model.BinaryInfo
+	for i, binInfo := range binInfos {
+		if binInfo.Binary.Name != name || binInfo.PackagePath == "" {
+			continue
+		}
+
+		if match == nil || binInfo.Module.Version.Compare(match.Module.Version) > 0 {
+			match = &binInfos[i]
+		}
+	}
+
+	if match == nil {
+		return ErrCommandNotFound
+	}
+
+	install, err := g.confirm(fmt.Sprintf(
+		"📦 %s is provided by %s, installed before with gobin, install it?", name, match.PackagePath,
+	))
+	if err != nil {
+		return err
+	}
+
+	if !install {
+		return ErrCommandNotFound
+	}
+
+	return g.InstallPackages(
+		ctx, 1, model.KindLatest, model.BuildFlags{}, false, false, false, 0, model.NewPackage(match.PackagePath),
+	)
+}
+
+// InitShell prints the setup snippet of the given shell to the standard output
+// (or another defined io.Writer): the Go binary path added to PATH, the exec
+// shim directory added before the other directories of PATH, the loading
+// of the gobin completion and, if prompt is set, a prompt status showing when
+// binaries are outdated, checked in the background at most once a day. If
+// write is set, the snippet is written to the shell profile instead, replacing
+// the snippet written before. It returns an error if the snippet cannot be
+// written.
+func (g *Gobin) InitShell(shell model.Shell, prompt, write bool) error {
+	data := struct {
+		Shell      model.Shell
+		GoBinPath  string
+		ShimPath   string
+		StatusFile string
+		Prompt     bool
+	}{
+		Shell:      shell,
+		GoBinPath:  g.workspace.GetGoBinPath(),
+		ShimPath:   g.workspace.GetInternalShimPath(),
+		StatusFile: filepath.Join(g.workspace.GetInternalBasePath(), initPromptStatusFile),
+		Prompt:     prompt,
+	}
+
+	tmplParsed := template.Must(template.New("init").Funcs(template.FuncMap{
+		"psquote": func(s string) string { return strings.ReplaceAll(s, "'", "''") },
+	}).Parse(initTemplate))
+
+	var snippet strings.Builder
+	if err := tmplParsed.Execute(&snippet, data); err != nil {
+		slog.Default().Error("error executing template", "err", err)
+		return err
+	}
+
+	if !write {
+		fmt.Fprint(g.output(), snippet.String())
+		return nil
+	}
+
+	profile, err := g.userPath.GetShellProfile(shell.String())
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error determining the %s profile\n", shell)
+		return err
+	}
+
+	if err = g.writeProfileBlock(profile, snippet.String(), initBeginMarker, initEndMarker); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error writing gobin init to %s\n", profile)
+		return err
+	}
+
+	fmt.Fprintf(g.output(), "✅ gobin init written to %s, restart your shell to apply it\n", profile)
+
+	return nil
+}
+
+// InstallCompletion writes the given completion script of the given shell to
+// the completion directory of the shell, or to the shell profile for
+// PowerShell, replacing the script written before, and reports where it was
+// written to the standard output (or another defined io.Writer). It returns an
+// error if the completion path cannot be determined or written.
+func (g *Gobin) InstallCompletion(shell model.Shell, script []byte) error {
+	path, err := g.userPath.GetCompletionPath(shell.String())
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error determining the %s completion path\n", shell)
+		return err
+	}
+
+	if shell == model.ShellPowerShell {
+		block := completionBeginMarker + "\n" + string(script) + completionEndMarker + "\n"
+		err = g.writeProfileBlock(path, block, completionBeginMarker, completionEndMarker)
+	} else if err = g.fs.CreateDir(filepath.Dir(path), 0755); err == nil { //nolint:mnd // directory permissions
+		//nolint:mnd // file permissions
+		err = g.fs.WriteFile(path, script, 0644)
+	}
+
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error writing the %s completion to %s\n", shell, path)
+		return err
+	}
+
+	fmt.Fprintf(g.output(), "✅ %s completion written to %s, restart your shell to apply it\n", shell, path)
+
+	if shell == model.ShellZsh {
+		fmt.Fprintf(
+			g.notice(),
+			"💡 add fpath=(%s $fpath) to ~/.zshrc before compinit, unless already done\n",
+			filepath.Dir(path),
+		)
+	}
+
+	return nil
+}
+
+// InstallPackages installs the given packages. It returns an error if any of
+// the packages cannot be installed. The command runs in parallel, launching go
+// routines to install the packages up to the given parallelism. Packages that
+// fail the pre-flight validation are reported with suggestions when available.
+// If any of the packages is denied by the configuration, no package is
+// installed and ErrPackageDenied is returned. When installing several packages
+// or a maximum download size is given, the download size of the modules is
+// estimated first, and if it exceeds the maximum download size, no package is
+// installed and ErrDownloadTooLarge is returned. The packages are built with
+// the given build flags, ex. the GOAMD64 variant. If universal is true, macOS
+// universal binaries are built for amd64 and arm64. The binaries are
+// compressed with UPX when enabled with SetCompress or the upx configuration
+// key, and signed with the identity of the signing configuration section. The
+// binaries are downloaded from the binary cache server set with SetCacheFrom,
+// if any, when it serves a matching binary. When a platform is set with
+// SetPlatform, the packages are cross compiled for it and stored in its
+// platform directory, without pinning them with the given kind. When several
+// packages fail to install, a summary of the failures is printed at the end.
+func (g *Gobin) InstallPackages(
+	ctx context.Context,
+	parallelism int,
+	kind model.Kind,
+	flags model.BuildFlags,
+	rebuild bool,
+	universal bool,
+	timings bool,
+	maxDownload model.ByteSize,
+	packages ...model.Package,
+) error {
+	if err := g.checkDeniedPackages(packages...); err != nil {
+		return err
+	}
+
+	if err := g.checkDownloadSize(ctx, maxDownload, packages...); err != nil {
+		return err
+	}
+
+	return g.installPackages(ctx, parallelism, kind, flags, rebuild, universal, timings, packages...)
+}
+
+// InstallLocalPackage installs the main package of the given local directory
+// as a development build. The package is built from the directory, honoring the
+// go.work file and replace directives of the module it belongs to. The binary
+// is built with the given build flags, compressed with UPX when enabled with
+// SetCompress or the upx configuration key, and signed with the identity of the
+// signing configuration section. It returns an error if the package cannot be
+// installed.
+func (g *Gobin) InstallLocalPackage(
+	ctx context.Context,
+	dir string,
+	flags model.BuildFlags,
+) error {
+	flags.Compress = flags.Compress || g.compress
+	warnings := internal.NewWarnings()
+
+	ctx = internal.WithLogAttrs(
+		ctx,
+		slog.String("operation", "install"),
+		slog.String("dir", dir),
+	)
+	ctx = internal.WithWarnings(ctx, warnings)
+	ctx = toolchain.WithProvenance(ctx, model.NewProvenance("install"))
+	start := time.Now()
+
+	err := g.binaryManager.InstallLocalPackage(ctx, dir, flags)
+	logOperation(ctx, start, err)
+	switch {
+	case errors.Is(err, manager.ErrBinaryNameCollision):
+		fmt.Fprintf(g.stdErr, "❌ cannot install package in %s: %s\n", dir, err)
+	case errors.Is(err, manager.ErrPackageNotMain):
+		fmt.Fprintf(g.stdErr, "❌ invalid package in %s: %s\n", dir, err)
+	}
+
+	g.printWarnings(warnings)
+
+	return g.getStrictErr(err, len(warnings.Get()) > 0)
+}
+
+// ListBinaries lists all binaries in the Go binary directory, or if managed is
+// true, it lists all binaries in the internal binary directory. It prints a
+// template with the binaries to the standard output (or another defined
+// io.Writer), or an error if the binary directory cannot be determined or
+// listed. The managed binaries include the binaries cross compiled for another
+// platform, labeled with their platform. If goos is set, only the binaries
+// built for that operating system are listed, and if filter is set, only the
+// binaries whose name matches the glob pattern, ex. "golangci-*". If null is
+// set, only the binary names are printed, each followed by a NUL character,
+// to be piped to the commands reading binaries from stdin. When the Go binary
+// directory is shared through WSL, it warns that binaries built for the other
+// operating system are listed too, labeled with their operating system.
+func (g *Gobin) ListBinaries(managed bool, goos, filter string, null bool) error {
+	binInfos, err := g.binaryManager.GetAllBinaryInfos(managed)
+	if err != nil {
+		return err
+	}
+
+	if managed {
+		crossInfos, crossErr := g.binaryManager.GetCrossBinaryInfos()
+		if crossErr != nil {
+			return crossErr
+		}
+
+		binInfos = append(binInfos, crossInfos...)
+	}
+
+	if goos != "" {
+		binInfos = slices.DeleteFunc(binInfos, func(info model.BinaryInfo) bool {
+			return info.OS != goos
+		})
+	}
+
+	if filter != "" {
+		binInfos = slices.DeleteFunc(binInfos, func(info model.BinaryInfo) bool {
+			ok, err := filepath.Match(filter, info.Binary.Name)
+			return err != nil || !ok
+		})
+	}
+
+	if null {
+		for _, info := range binInfos {
+			fmt.Fprintf(g.stdOut, "%s\x00", info.Binary.String())
+		}
+
+		return nil
+	}
+
+	var crossOS string
+	if !managed {
+		crossOS = g.binaryManager.GetCrossOS()
+	}
+
+	if crossOS != "" && goos == "" {
+		fmt.Fprintf(
+			g.notice(),
+			"⚠️  the Go binary path %s is shared with %s through WSL, %s binaries are labeled, filter them with --os\n",
+			g.workspace.GetGoBinPath(), crossOS, crossOS,
+		)
+	}
+
+	return g.printBinaries(binInfos, managed, crossOS)
+}
+
+// ListOutdatedBinaries lists all outdated binaries in the Go binary directory.
+// It prints a template with the outdated binaries to the standard output (or
+// another defined io.Writer), or an error if the binary directory cannot be
+// determined or listed. The command runs in parallel, launching go routines to
+// check the upgrade information of the binaries up to the given parallelism.
+// If checkGo is set, it lists instead the binaries built with a Go version with
+// a newer patch release, according to the Go release feed. The binaries
+// matching the exclude list of the configuration defaults are skipped. If
+// exitCode is set, the number of outdated binaries is printed to the standard
+// error, unless in quiet mode, and ErrOutdatedBinaries is returned when some
+// binaries are outdated.
+func (g *Gobin) ListOutdatedBinaries(
+	ctx context.Context,
+	checkMajor bool,
+	checkGo bool,
+	exitCode bool,
+	parallelism int,
+) error {
+	binInfos, err := g.getUpgradableBinaryInfos()
+	if err != nil {
+		return err
+	}
+
+	if checkGo {
+		return g.listStaleGoBinaries(ctx, binInfos, exitCode)
+	}
+
+	outdated, waitErr := g.getOutdatedBinaries(ctx, binInfos, checkMajor, parallelism)
+
+	if waitErr == nil && exitCode {
+		fmt.Fprintf(g.notice(), "%d of %d binaries outdated\n", len(outdated), len(binInfos))
+	}
+
+	if len(outdated) == 0 {
+		if waitErr == nil {
+			return g.render([]binaryJSON{}, func(w io.Writer) error {
+				fmt.Fprintln(w, "✅ All binaries are up to date")
+				return nil
+			})
+		}
+
+		return waitErr
+	}
+
+	if err = g.printOutdatedBinaries(outdated); err != nil {
+		return err
+	}
+
+	if waitErr == nil && exitCode {
+		return ErrOutdatedBinaries
+	}
+
+	return waitErr
+}
+
+// ListRollbackVersions prints the versions of the given binary in the internal
+// binary directory it can be rolled back to, older than the pinned version and
+// matching the pin kind, from the newest to the oldest, to the standard output
+// (or another defined io.Writer). It returns an error if the binary cannot be
+// found, is not managed, or its versions cannot be listed.
+func (g *Gobin) ListRollbackVersions(bin model.Binary) error {
+	versions, err := g.binaryManager.GetPinVersions(bin)
+	if err != nil {
+		g.printPinVersionsErr(bin, err)
+		return err
+	}
+
+	idx := slices.IndexFunc(versions, func(version model.PinVersion) bool {
+		return version.Pinned
+	})
+
+	if idx < 0 || idx == len(versions)-1 {
+		fmt.Fprintf(g.output(), "no versions to roll back binary %q to\n", bin.String())
+		return nil
+	}
+
+	for _, version := range versions[idx+1:] {
+		fmt.Fprintln(g.output(), version.Version.String())
+	}
+
+	return nil
+}
+
+// MigrateBinaries migrates the given binaries to be managed internally. The
+// binaries are looked up in the given directory, or in the Go binary directory
+// if empty, and binaries from other directories are adopted by pinning them
+// to the Go binary directory. If dryRun is set, it prints which binaries would
+// be migrated, which are already managed, and which would fail, without
+// changing anything. It returns an error if any of the binaries cannot be
+// migrated due to the binary being not found or the binary being already
+// managed or any other error.
+func (g *Gobin) MigrateBinaries(dir string, dryRun bool, bins ...model.Binary) error {
+	var err error
+	var binPaths []string
+
+	if dir == "" {
+		dir = g.workspace.GetGoBinPath()
+	}
+
+	if len(bins) == 0 {
+		binPaths, err = g.fs.ListBinaries(dir)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, bin := range bins {
+			binPaths = append(binPaths, filepath.Join(dir, bin.String()))
+		}
+	}
+
+	if dryRun {
+		return g.printMigrateDryRun(binPaths)
+	}
+
+	for _, path := range binPaths {
+		if migrateErr := g.binaryManager.MigrateBinary(path); migrateErr != nil {
+			switch {
+			case errors.Is(migrateErr, manager.ErrBinaryAlreadyManaged):
+				fmt.Fprintf(g.stdErr, "❌ binary %q already managed\n", filepath.Base(path))
+			case errors.Is(migrateErr, manager.ErrBinaryAlreadyExists):
+				fmt.Fprintf(g.stdErr, "❌ binary %q already exists in the Go binary path\n", filepath.Base(path))
+			case errors.Is(migrateErr, toolchain.ErrBinaryNotFound):
+				fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", filepath.Base(path))
+			default:
+				fmt.Fprintf(g.stdErr, "❌ error migrating binary %q\n", filepath.Base(path))
+			}
+
+			err = migrateErr
+			continue
+		}
+	}
+
+	return err
+}
+
+// NotifyNewRelease prints a notice to the standard error (or another defined
+// io.Writer) when a newer release of the gobin binary at the given path is
+// available. The latest release is checked at most once a day, caching the
+// result in the internal base directory. Nothing is checked if the release
+// check is disabled in the configuration or the binary is a development build.
+// It returns an error if the binary info or the latest release cannot be
+// determined.
+func (g *Gobin) NotifyNewRelease(ctx context.Context, path string) error {
+	if g.config.DisableReleaseCheck {
+		return nil
+	}
+
+	binInfo, err := g.binaryManager.GetBinaryInfo(path)
+	if err != nil {
+		return err
+	}
+
+	if !binInfo.Module.Version.IsValid() {
+		return nil
+	}
+
+	latest, err := g.getLatestRelease(ctx, binInfo)
+	if err != nil {
+		return err
+	}
+
+	if binInfo.Module.Version.Compare(latest) < 0 {
+		fmt.Fprintf(
+			g.notice(),
+			"🔔 gobin %s is available (current %s), update with gobin self-update\n",
+			latest.String(),
+			binInfo.Module.Version.String(),
+		)
+	}
+
+	return nil
+}
+
+// PinBinaries pins the given binaries to the Go binary directory. It returns an
+// error if any of the binaries cannot be pinned.
+func (g *Gobin) PinBinaries(kind model.Kind, bins ...model.Binary) error {
+	var err error
+	for _, bin := range bins {
+		pinErr := g.binaryManager.PinBinary(bin, kind)
+		if errors.Is(pinErr, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else if errors.Is(pinErr, manager.ErrPreviousVersionNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ no previous version recorded for binary %q\n", bin.Name)
+		} else if errors.Is(pinErr, manager.ErrBinaryNameCollision) {
+			fmt.Fprintf(g.stdErr, "❌ cannot pin binary %q: %s\n", bin.String(), pinErr)
+		} else if pinErr != nil {
+			fmt.Fprintf(g.stdErr, "❌ error pinning binary %q\n", bin.String())
+		}
+
+		err = pinErr
+	}
+
+	return err
+}
+
+// ProtectBinaries sets the protection of the given binaries in the Go binary
+// directory. It returns an error if any of the binaries cannot be protected or
+// unprotected.
+func (g *Gobin) ProtectBinaries(protected bool, bins ...model.Binary) error {
+	var err error
+	for _, bin := range bins {
+		protectErr := g.binaryManager.ProtectBinary(bin, protected)
+		if errors.Is(protectErr, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else if protectErr != nil {
+			fmt.Fprintf(g.stdErr, "❌ error updating protection for binary %q\n", bin.String())
+		}
+
+		err = protectErr
+	}
+
+	return err
+}
+
+// PrintBinaryEnv prints the environment variables, in the form "name=value",
+// set by the exec shim of the given binary to the standard output (or another
+// defined io.Writer), one per line. It returns an error if the binary cannot be
+// found or its receipt cannot be read.
+func (g *Gobin) PrintBinaryEnv(bin model.Binary) error {
+	env, err := g.binaryManager.GetBinaryEnv(bin)
+	if err != nil {
+		if errors.Is(err, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else {
+			fmt.Fprintf(g.stdErr, "❌ error getting environment for binary %q\n", bin.String())
+		}
+
+		return err
+	}
+
+	for _, envVar := range env {
+		fmt.Fprintln(g.output(), envVar)
+	}
+
+	return nil
+}
+
+// PrintBinaryInfo prints the binary info for a given binary. It prints a
+// template with the binary info to the standard output (or another defined
+// io.Writer), or an error if the binary cannot be found. The module sum of the
+// binary is verified against the checksum database, printing the reason it is
+// not verified, if any.
+func (g *Gobin) PrintBinaryInfo(ctx context.Context, bin model.Binary) error {
+	binInfo, err := g.binaryManager.GetBinaryInfo(
+		filepath.Join(g.workspace.GetGoBinPath(), bin.String()),
+	)
+	if err != nil {
+		if errors.Is(err, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else {
+			fmt.Fprintf(g.stdErr, "❌ error getting info for binary %q\n", bin.String())
+		}
+
+		return err
+	}
+
+	data := struct {
+		model.BinaryInfo
+
+		Verified    bool
+		NotVerified string
+	}{
+		BinaryInfo: binInfo,
+		Verified:   true,
+	}
+
+	if err = g.binaryManager.VerifyModuleSum(ctx, binInfo.Module, binInfo.ModuleSum); err != nil {
+		if !errors.Is(err, manager.ErrModuleSumMismatch) && !errors.Is(err, manager.ErrModuleSumNotVerifiable) {
+			slog.Default().Warn("error verifying module sum", "binary", bin.String(), "err", err)
+		}
+
+		data.Verified = false
+		data.NotVerified = err.Error()
+	}
+
+	binJSON := newBinaryJSON(binInfo)
+	binJSON.Verified = &data.Verified
+	binJSON.NotVerified = data.NotVerified
+
+	tmplParsed := template.Must(template.New("info").Parse(infoTemplate))
+	return g.render(binJSON, func(w io.Writer) error {
+		if err = tmplParsed.Execute(w, data); err != nil {
+			slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// PrintBinaryOrigin prints where the given binary came from to the standard
+// output (or another defined io.Writer): its package and module, how its
+// version is resolved on upgrades, who links it to the Go binary directory, when
+// it was installed and the Go toolchain it was built with. It returns an error
+// if the binary cannot be found or its receipt cannot be read.
+func (g *Gobin) PrintBinaryOrigin(bin model.Binary) error {
+	origin, err := g.binaryManager.GetBinaryOrigin(bin)
+	if err != nil {
+		if errors.Is(err, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else {
+			fmt.Fprintf(g.stdErr, "❌ error getting origin of binary %q\n", bin.String())
+		}
+
+		return err
+	}
+
+	provenance := origin.BuildFlags.GetProvenance()
+	originJSON := originJSON{
+		binaryJSON:      newBinaryJSON(origin.BinaryInfo),
+		Resolution:      origin.GetResolution(),
+		LinkedBy:        origin.GetLinkedBy(),
+		PinMode:         origin.PinMode,
+		InstalledAt:     origin.InstalledAt,
+		PreviousVersion: origin.PreviousVersion,
+		MigratedFrom:    origin.MigratedFrom,
+		Source:          origin.Source,
+		Protected:       origin.Protected,
+		BuiltBy:         provenance.Builder,
+		Spec:            provenance.Spec,
+	}
+
+	tmplParsed := template.Must(template.New("why").Parse(whyTemplate))
+	return g.render(originJSON, func(w io.Writer) error {
+		if err = tmplParsed.Execute(w, origin); err != nil {
+			slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// PrintBinarySize prints the size of the given binary to the standard output
+// (or another defined io.Writer). With history, it prints the size of each
+// version installed to the binary instead, with a sparkline and the change from
+// the previous version, in red when growing. It returns an error if the binary
+// cannot be found or its size or size history cannot be read.
+func (g *Gobin) PrintBinarySize(bin model.Binary, history bool) error {
+	if history {
+		return g.printSizeHistory(bin)
+	}
+
+	build, err := g.binaryManager.GetBinaryBuild(filepath.Join(g.workspace.GetGoBinPath(), bin.String()))
+	if err != nil {
+		if errors.Is(err, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else {
+			fmt.Fprintf(g.stdErr, "❌ error getting size for binary %q\n", bin.String())
+		}
+
+		return err
+	}
+
+	fmt.Fprintf(g.output(), "%s %s %s\n", bin.String(), build.Module.Version, build.Size.String())
+
+	return nil
+}
+
+// PrintCommandNotFoundHook prints the command-not-found hook of the given
+// shell to the standard output (or another defined io.Writer). The hook offers
+// to install the unknown commands provided by managed binaries, and runs them
+// again once installed. It returns an error if the shell has no
+// command-not-found handler, ex. PowerShell.
+func (g *Gobin) PrintCommandNotFoundHook(shell model.Shell) error {
+	if shell == model.ShellPowerShell {
+		err := fmt.Errorf("command-not-found hook not supported for %s", shell)
+		fmt.Fprintf(g.stdErr, "❌ %s\n", err.Error())
+		return err
+	}
+
+	tmplParsed := template.Must(template.New("command-not-found").Parse(commandNotFoundTemplate))
+	if err := tmplParsed.Execute(g.output(), struct{ Shell model.Shell }{Shell: shell}); err != nil {
+		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// PrintConfigValue prints the value of the given key of the configuration to
+// the standard output (or another defined io.Writer), with the deny list
+// entries separated by commas. It returns an error if the key is unknown.
+func (g *Gobin) PrintConfigValue(key string) error {
+	value, err := g.config.GetValue(key)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ %s\n", err.Error())
+		return err
+	}
+
+	fmt.Fprintln(g.output(), value)
+
+	return nil
+}
+
+// PrintDebugInfoPath prints the path of the debug information kept for a given
+// stripped binary to the standard output (or another defined io.Writer), to
+// symbolize its stack traces. It returns an error if the binary cannot be
+// found or no debug information is kept for it.
+func (g *Gobin) PrintDebugInfoPath(ctx context.Context, bin model.Binary) error {
+	path, err := g.binaryManager.GetDebugInfoPath(ctx, filepath.Join(g.workspace.GetGoBinPath(), bin.String()))
+	if err != nil {
+		switch {
+		case errors.Is(err, toolchain.ErrBinaryNotFound):
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		case errors.Is(err, manager.ErrDebugInfoNotFound):
+			fmt.Fprintf(
+				g.stdErr, "❌ no debug info kept for binary %q, reinstall it with --strip --debug-info\n", bin.String(),
+			)
+		default:
+			fmt.Fprintf(g.stdErr, "❌ error getting debug info for binary %q\n", bin.String())
+		}
+
+		return err
+	}
+
+	fmt.Fprintln(g.output(), path)
+	return nil
+}
+
+// PrintEnv prints the effective paths and settings of gobin, along with the
+// caches and the GOPROXY setting of the Go toolchain, to the standard output
+// (or another defined io.Writer), as a template or as JSON in JSON mode.
+// It returns an error if the Go toolchain settings cannot be determined.
+func (g *Gobin) PrintEnv(ctx context.Context) error {
+	goEnv, err := g.binaryManager.GetGoEnv(ctx)
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error getting go env")
+		return err
+	}
+
+	pinMode := g.config.PinMode
+	if pinMode == "" {
+		pinMode = model.PinModeSymlink
+	}
+
+	basePath := g.workspace.GetInternalBasePath()
+	env := envInfo{
+		GoBinPath:         g.workspace.GetGoBinPath(),
+		BasePath:          basePath,
+		BinPath:           g.workspace.GetInternalBinPath(),
+		DataPath:          g.workspace.GetInternalDataPath(),
+		ReceiptPath:       g.workspace.GetInternalReceiptPath(),
+		ShimPath:          g.workspace.GetInternalShimPath(),
+		TempPath:          g.workspace.GetInternalTempPath(),
+		LogPath:           g.workspace.GetInternalLogPath(),
+		ConfigFile:        g.workspace.GetInternalConfigPath(),
+		ConfigFileExists:  g.fs.Exists(g.workspace.GetInternalConfigPath()),
+		PinMode:           pinMode,
+		ReleaseCheck:      !g.config.DisableReleaseCheck,
+		ReleaseCheckCache: filepath.Join(basePath, releaseCheckFileName),
+		PromptStatusCache: filepath.Join(basePath, initPromptStatusFile),
+		GoCache:           goEnv.GoCache,
+		GoModCache:        goEnv.GoModCache,
+		GoProxy:           goEnv.GoProxy,
+	}
+
+	tmplParsed := template.Must(template.New("env").Parse(envTemplate))
+	return g.render(env, func(w io.Writer) error {
+		if err = tmplParsed.Execute(w, env); err != nil {
+			slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// PrintShortVersion prints the short version of a given binary. It prints the
+// module version to the standard output (or another defined io.Writer), or an
+// error if the binary cannot be found.
+func (g *Gobin) PrintShortVersion(path string) error {
+	binInfo, err := g.binaryManager.GetBinaryInfo(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(g.output(), binInfo.Module.Version.String())
+
+	return nil
+}
+
+// PrintStoreUsage prints the disk usage of the internal binary directory: a
+// template with the number of versions and size of each binary, sorted by
+// decreasing size, along with its stale versions, not targeted by any pin, and
+// the space freed by pruning them, and the totals, to the standard output (or
+// another defined io.Writer). It returns an error if the usage cannot be read.
+func (g *Gobin) PrintStoreUsage() error {
+	usage, err := g.binaryManager.GetStoreUsage()
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error getting disk usage of the internal binary directory")
+		return err
+	}
+
+	if len(usage) == 0 {
+		fmt.Fprintln(g.notice(), "💡 no binaries in the internal binary directory")
+		return nil
+	}
+
+	type usageRow struct {
+		Name     string
+		Versions string
+		Size     string
+		Stale    string
+		Prunable string
+	}
+
+	newRow := func(name string, versions int, size model.ByteSize, stale int, prunable model.ByteSize) usageRow {
+		return usageRow{
+			Name:     name,
+			Versions: strconv.Itoa(versions),
+			Size:     size.String(),
+			Stale:    strconv.Itoa(stale),
+			Prunable: prunable.String(),
+		}
+	}
+
+	rows := make([]usageRow, len(usage))
+	for i, binUsage := range usage {
+		rows[i] = newRow(binUsage.Name, binUsage.Versions, binUsage.Size, binUsage.StaleVersions, binUsage.StaleSize)
+	}
+
+	total := newRow("Total", usage.GetVersions(), usage.GetSize(), usage.GetStaleVersions(), usage.GetStaleSize())
+	allRows := append(slices.Clone(rows), total)
+
+	data := struct {
+		Rows          []usageRow
+		Total         usageRow
+		NameWidth     int
+		VersionsWidth int
+		SizeWidth     int
+		StaleWidth    int
+		PrunableWidth int
+	}{
+		Rows:          rows,
+		Total:         total,
+		NameWidth:     getColumnMaxWidth("Name", allRows, func(row usageRow) string { return row.Name }),
+		VersionsWidth: getColumnMaxWidth("Versions", allRows, func(row usageRow) string { return row.Versions }),
+		SizeWidth:     getColumnMaxWidth("Size", allRows, func(row usageRow) string { return row.Size }),
+		StaleWidth:    getColumnMaxWidth("Stale", allRows, func(row usageRow) string { return row.Stale }),
+		PrunableWidth: getColumnMaxWidth("Prunable", allRows, func(row usageRow) string { return row.Prunable }),
+	}
+
+	tmplParsed := template.Must(template.New("du").Funcs(template.FuncMap{
+		"add":    add,
+		"repeat": strings.Repeat,
+	}).Parse(storeUsageTemplate))
+
+	if err = tmplParsed.Execute(g.output(), data); err != nil {
+		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+		return err
+	}
+
+	if usage.GetStaleVersions() > 0 {
+		fmt.Fprintf(g.notice(), "💡 run gobin prune --all to free %s\n", total.Prunable)
+	}
+
+	return nil
+}
+
+// PrintVersion prints the version of a given binary. It prints the module
+// version, Go version, OS, and architecture to the standard output (or another
+// defined io.Writer), or an error if the binary cannot be found.
+func (g *Gobin) PrintVersion(path string) error {
+	binInfo, err := g.binaryManager.GetBinaryInfo(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(
+		g.output(),
+		"%s (%s %s/%s)\n",
+		binInfo.Module.Version.String(),
+		binInfo.GoVersion,
+		binInfo.OS,
+		binInfo.Arch,
+	)
+
+	return nil
+}
+
+// PruneBinaries prunes the given binaries or the whole internal binary
+// directory. Protected binaries are skipped when pruning the whole directory,
+// and refused when given explicitly, unless force is set. It returns an error
+// if the binary directory cannot be determined or listed, or if the binaries
+// failed to be pruned.
+func (g *Gobin) PruneBinaries(force bool, bins ...model.Binary) error {
+	pruneAll := len(bins) == 0
+	if pruneAll {
+		binPaths, err := g.fs.ListBinaries(g.workspace.GetInternalBinPath())
+		if err != nil {
+			return err
+		}
+
+		for _, path := range binPaths {
+			bin := model.NewBinaryFromString(filepath.Base(path))
+			bins = append(bins, model.NewBinaryFromString(bin.GetBaseName()))
+		}
+
+		bins = slices.Compact(bins)
+	}
+
+	var err error
+	for _, bin := range bins {
+		pruneErr := g.pruneBinary(bin, force)
+		if errors.Is(pruneErr, manager.ErrBinaryProtected) {
+			if pruneAll {
+				fmt.Fprintf(g.notice(), "🔒 skipping protected binary %q\n", bin.String())
+				continue
+			}
+
+			fmt.Fprintf(g.stdErr, "❌ binary %q is protected (use --force to override)\n", bin.String())
+		}
+
+		if pruneErr != nil {
+			err = pruneErr
+			continue
+		}
+	}
+
+	return err
+}
+
+// PruneBinariesByPolicy prunes the versions of the binaries in the internal
+// binary directory not kept by the retention policy of the configuration,
+// printing each version pruned to the standard output (or another defined
+// io.Writer). Protected binaries are skipped unless force is set. It returns
+// ErrRetentionPolicyNotSet if the configuration has no retention policy, or an
+// error if the versions to prune cannot be determined or any of them fails to
+// be pruned.
+func (g *Gobin) PruneBinariesByPolicy(force bool) error {
+	if !g.config.Retention.IsSet() {
+		fmt.Fprintln(g.stdErr, "❌ no retention policy set, set retention.keep_last or retention.keep_days")
+		return ErrRetentionPolicyNotSet
+	}
+
+	bins, err := g.binaryManager.GetExpiredBinaries(g.config.Retention)
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error applying retention policy")
+		return err
+	}
+
+	if len(bins) == 0 {
+		fmt.Fprintln(g.output(), "no binaries to prune")
+		return nil
+	}
+
+	for _, bin := range bins {
+		pruneErr := g.pruneBinary(bin, force)
+		switch {
+		case errors.Is(pruneErr, manager.ErrBinaryProtected):
+			fmt.Fprintf(g.notice(), "🔒 skipping protected binary %q\n", bin.String())
+			continue
+		case pruneErr != nil:
+			fmt.Fprintf(g.stdErr, "❌ error pruning binary %q\n", bin.String())
+			err = pruneErr
+		case g.dryRun:
+		default:
+			fmt.Fprintf(g.output(), "✅ binary %q pruned\n", bin.String())
+		}
+	}
+
+	return err
+}
+
+// PullBinary pulls a binary from the OCI artifact with the given reference and
+// pins it to the Go binary directory with the given kind. It returns an error if
+// the artifact cannot be found, pulled or pinned, or was built for another
+// platform.
+func (g *Gobin) PullBinary(ctx context.Context, ref model.OCIReference, kind model.Kind) error {
+	bin, err := g.binaryManager.PullBinary(ctx, ref, kind)
+	if errors.Is(err, registry.ErrArtifactNotFound) {
+		fmt.Fprintf(g.stdErr, "❌ artifact %q not found\n", ref.String())
+		return err
+	} else if errors.Is(err, registry.ErrArtifactInvalid) || errors.Is(err, manager.ErrPlatformMismatch) ||
+		errors.Is(err, registry.ErrUnauthorized) {
+		fmt.Fprintf(g.stdErr, "❌ cannot pull artifact %q: %s\n", ref.String(), err)
+		return err
+	} else if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error pulling artifact %q\n", ref.String())
+		return err
+	}
+
+	fmt.Fprintf(g.output(), "✅ %s pulled from %s\n", bin.String(), ref.String())
+	return nil
+}
+
+// PushBinary pushes the given managed binary to the OCI artifact with the given
+// reference, annotated with the metadata of its build. It returns an error if
+// the binary cannot be found, is not managed or cannot be pushed.
+func (g *Gobin) PushBinary(ctx context.Context, bin model.Binary, ref model.OCIReference) error {
+	err := g.binaryManager.PushBinary(ctx, filepath.Join(g.workspace.GetGoBinPath(), bin.String()), ref)
+	if errors.Is(err, toolchain.ErrBinaryNotFound) {
+		fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		return err
+	} else if errors.Is(err, manager.ErrBinaryNotManaged) {
+		fmt.Fprintf(g.stdErr, "❌ binary %q not managed\n", bin.String())
+		return err
+	} else if errors.Is(err, registry.ErrUnauthorized) {
+		fmt.Fprintf(g.stdErr, "❌ cannot push artifact %q: %s\n", ref.String(), err)
+		return err
+	} else if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error pushing binary %q to %q\n", bin.String(), ref.String())
+		return err
+	}
+
+	fmt.Fprintf(g.output(), "✅ %s pushed to %s\n", bin.String(), ref.String())
+	return nil
+}
+
+// RelinkBinaries rebuilds the given binaries in the Go binary directory from the
+// local directories they are linked to, or all the linked binaries if none is
+// given. If watch is true, it then checks the enclosing workspace or module of
+// the directories for changes at every interval and relinks the binaries whose
+// files changed, until the context is canceled. Build failures while watching
+// are reported without stopping, so the binaries are relinked once the sources
+// are fixed. It returns an error if
+// any of the binaries is not found, not linked or cannot be relinked.
+func (g *Gobin) RelinkBinaries(
+	ctx context.Context,
+	watch bool,
+	interval time.Duration,
+	bins ...model.Binary,
+) error {
+	links, err := g.getLinkedBinaries(bins...)
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		fmt.Fprintln(g.notice(), "⚠️  no linked binaries, link a local package with gobin link")
+		return nil
+	}
+
+	for i := range links {
+		if watch {
+			links[i].Root = g.getLinkRoot(links[i].Source)
+			links[i].ModTime, _ = g.fs.GetLatestModTime(links[i].Root)
+		}
+
+		if relinkErr := g.relinkBinary(ctx, links[i]); relinkErr != nil {
+			err = relinkErr
+		}
+	}
+
+	if !watch {
+		return err
+	}
+
+	for _, link := range links {
+		fmt.Fprintf(g.notice(), "👀 watching %s for changes to %s\n", link.Root, link.Binary.String())
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		for i := range links {
+			modTime, modErr := g.fs.GetLatestModTime(links[i].Root)
+			if modErr != nil || !modTime.After(links[i].ModTime) {
+				continue
+			}
+
+			links[i].ModTime = modTime
+			_ = g.relinkBinary(ctx, links[i])
+		}
+	}
+}
+
+// RemoveStaleExecutable removes the gobin executable at the given path renamed
+// aside when replaced while running by SelfUpdate, on Windows. Errors are
+// logged only, as they do not prevent gobin from running.
+func (g *Gobin) RemoveStaleExecutable(path string) {
+	if err := g.binaryManager.RemoveStaleExecutable(path); err != nil {
+		slog.Default().Warn("error removing stale executable", "path", path, "err", err)
+	}
+}
+
+// ReproduceBinary rebuilds the given managed binary with the same package
+// version, build flags and Go toolchain, and prints whether the rebuilt binary
+// is identical to the installed one, with the SHA-256 hashes of both and, if
+// not identical, the build settings that differ. It returns an error if the
+// binary cannot be found or rebuilt, or is not reproducible.
+func (g *Gobin) ReproduceBinary(ctx context.Context, bin model.Binary) error {
+	reproduction, err := g.binaryManager.ReproduceBinary(ctx, filepath.Join(g.workspace.GetGoBinPath(), bin.String()))
+	if errors.Is(err, toolchain.ErrBinaryNotFound) {
+		fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		return err
+	} else if errors.Is(err, manager.ErrBinaryNotManaged) {
+		fmt.Fprintf(g.stdErr, "❌ binary %q not managed\n", bin.String())
+		return err
+	} else if errors.Is(err, toolchain.ErrBuildFailed) {
+		fmt.Fprintf(g.stdErr, "❌ cannot rebuild binary %q\n", bin.String())
+		return err
+	} else if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error reproducing binary %q\n", bin.String())
+		return err
+	}
+
+	tmplParsed := template.Must(template.New("reproduce").Funcs(template.FuncMap{
+		"color": colorize,
+	}).Parse(reproduceTemplate))
+
+	if err = tmplParsed.Execute(g.output(), reproduction); err != nil {
+		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+		return err
+	}
+
+	if !reproduction.IsReproducible() {
+		return ErrNotReproducible
+	}
+
+	return nil
+}
+
+// RollbackBinaries rolls back the given binaries to the version preceding the
+// pinned one still in the internal binary directory, matching the pin kind. It
+// prints the version each binary is rolled back to, to the standard output (or
+// another defined io.Writer), and returns an error if any of the binaries
+// cannot be rolled back.
+func (g *Gobin) RollbackBinaries(bins ...model.Binary) error {
+	var err error
+	for _, bin := range bins {
+		version, rollbackErr := g.binaryManager.RollbackBinary(bin)
+		switch {
+		case errors.Is(rollbackErr, manager.ErrRollbackVersionNotFound):
+			fmt.Fprintf(g.stdErr, "❌ no version to roll back binary %q to\n", bin.String())
+		case errors.Is(rollbackErr, toolchain.ErrBinaryNotFound),
+			errors.Is(rollbackErr, manager.ErrBinaryNotManaged):
+			g.printPinVersionsErr(bin, rollbackErr)
+		case rollbackErr != nil:
+			fmt.Fprintf(g.stdErr, "❌ error rolling back binary %q\n", bin.String())
+		default:
+			fmt.Fprintf(g.output(), "✅ binary %q rolled back to %s\n", bin.String(), version.String())
+		}
+
+		if rollbackErr != nil {
+			err = rollbackErr
+		}
+	}
+
+	return err
+}
+
+// SearchPackages searches the package index for packages matching the given
+// query, up to the given number of results. It prints a template with the
+// import path and synopsis of the packages found, highlighting the commands, to
+// the standard output (or another defined io.Writer), and returns the command
+// packages at their latest version, so they can be installed. It returns an
+// error if the package index cannot be searched.
+func (g *Gobin) SearchPackages(ctx context.Context, query string, limit int) ([]model.Package, error) {
+	results, err := g.binaryManager.SearchPackages(ctx, query, limit)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error searching packages matching %q\n", query)
+		return nil, err
+	}
+
+	var pkgs []model.Package
+	for _, result := range results {
+		if result.IsCommand {
+			pkgs = append(pkgs, model.NewPackage(result.Path))
+		}
+	}
+
+	data := struct {
+		Results       []model.PackageSearchResult
+		PathWidth     int
+		SynopsisWidth int
+	}{
+		Results: results,
+		PathWidth: getColumnMaxWidth(
+			"Package",
+			results,
+			func(result model.PackageSearchResult) string { return result.Path },
+		),
+		SynopsisWidth: getColumnMaxWidth(
+			"Description",
+			results,
+			func(result model.PackageSearchResult) string { return result.Synopsis },
+		),
+	}
+
+	tmplParsed := template.Must(template.New("search").Funcs(template.FuncMap{
+		"add":    add,
+		"color":  colorize,
+		"repeat": strings.Repeat,
+	}).Parse(searchTemplate))
+
+	err = g.render(results, func(w io.Writer) error {
+		if len(results) == 0 {
+			fmt.Fprintf(w, "no packages found matching %q\n", query)
+			return nil
+		}
+
+		if err = tmplParsed.Execute(w, data); err != nil {
+			slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgs, nil
+}
+
+// SelectOutdatedBinaries checks the binaries in the Go binary directory for
+// upgrades, as described in ListOutdatedBinaries, and prints the outdated ones
+// as a numbered list with their current and latest versions to the standard
+// output (or another defined io.Writer). It reads the binaries to select from
+// the standard input (or another defined io.Reader): their numbers or ranges,
+// ex. "1 3-5", separated by spaces or commas, or "all". It returns the selected
+// binaries, none if no binary is outdated or the answer is empty, or
+// ErrInvalidSelection if the answer is not valid. Binaries failing to be
+// checked are printed to the standard error and not listed.
+func (g *Gobin) SelectOutdatedBinaries(
+	ctx context.Context,
+	checkMajor bool,
+	parallelism int,
+) ([]model.Binary, error) {
+	binInfos, err := g.getUpgradableBinaryInfos()
+	if err != nil {
+		return nil, err
+	}
+
+	outdated, err := g.getOutdatedBinaries(ctx, binInfos, checkMajor, parallelism)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error checking binaries for upgrades: %s\n", err.Error())
+	}
+
+	if len(outdated) == 0 {
+		if err == nil {
+			fmt.Fprintln(g.output(), "✅ All binaries are up to date")
+		}
+
+		return nil, err
+	}
+
+	slices.SortFunc(outdated, func(a, b model.BinaryUpgradeInfo) int {
+		return strings.Compare(a.Binary.Name, b.Binary.Name)
+	})
+
+	nameWidth := getColumnMaxWidth("", outdated, func(info model.BinaryUpgradeInfo) string {
+		return info.Binary.Name
+	})
+	versionWidth := getColumnMaxWidth("", outdated, func(info model.BinaryUpgradeInfo) string {
+		return info.Module.Version.String()
+	})
+
+	for i, info := range outdated {
+		fmt.Fprintf(
+			g.stdOut, "%3d) %-*s %s → %s\n", i+1, nameWidth, info.Binary.Name,
+			colorize(fmt.Sprintf("%-*s", versionWidth, info.Module.Version.String()), "red"),
+			colorize(info.LatestModule.Version.String(), "green"),
+		)
+	}
+
+	fmt.Fprintf(g.stdOut, "Select binaries to upgrade [1-%d, ranges (ex. 1-3) or all]: ", len(outdated))
+
+	answer, readErr := g.stdIn.ReadString('\n')
+	if readErr != nil && !errors.Is(readErr, io.EOF) {
+		return nil, readErr
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		fmt.Fprintln(g.stdOut, "no binaries selected")
+		return nil, nil
+	}
+
+	indexes, ok := parseSelection(answer, len(outdated))
+	if !ok {
+		fmt.Fprintf(g.stdErr, "❌ invalid selection %q\n", answer)
+		return nil, ErrInvalidSelection
+	}
+
+	bins := make([]model.Binary, len(indexes))
+	for i, idx := range indexes {
+		bins[i] = outdated[idx].Binary
+	}
+
+	return bins, nil
+}
+
+// SelfUpdate updates the gobin executable at the given path, usually the
+// running one, to the latest release of its module in the module proxy, within
+// the same major version. A gobin binary managed by itself is upgraded as any
+// other managed binary, through its pin in the Go binary directory, while
+// another executable is rebuilt and replaced in place. If check is set, the
+// latest release is only reported. It prints the outcome to the standard
+// output (or another defined io.Writer), and returns ErrDevelopmentBuild if
+// gobin is not built from a released version, manager.ErrBinaryProtected if it
+// is managed and protected, or an error if the latest release cannot be
+// determined or gobin cannot be updated.
+func (g *Gobin) SelfUpdate(ctx context.Context, path string, check bool) error {
+	binInfo, err := g.binaryManager.GetBinaryInfo(path)
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error reading gobin build info")
+		return err
+	}
+
+	if binInfo.IsDevBuild || !binInfo.Module.Version.IsValid() {
+		fmt.Fprintf(
+			g.stdErr,
+			"❌ gobin is a development build, install a release with go install %s@latest\n",
+			binInfo.PackagePath,
+		)
+		return ErrDevelopmentBuild
+	}
+
+	binUpInfo, err := g.binaryManager.GetBinaryUpgradeInfo(ctx, binInfo, false)
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error checking the latest gobin release")
+		return err
+	}
+
+	current, latest := binInfo.Module.Version.String(), binUpInfo.LatestModule.Version.String()
+	switch {
+	case !binUpInfo.IsUpgradeAvailable:
+		fmt.Fprintf(g.output(), "✅ gobin %s is up to date\n", current)
+		return nil
+	case check:
+		fmt.Fprintf(g.output(), "🔔 gobin %s is available (current %s)\n", latest, current)
+		return nil
+	}
+
+	if binInfo.IsManaged {
+		pin := model.NewBinary(binInfo.Binary.Name, model.NewLatestVersion(), binInfo.Binary.Extension)
+		err = g.binaryManager.UpgradeBinary(
+			ctx, filepath.Join(g.workspace.GetGoBinPath(), pin.String()), model.BuildFlags{}, false, false, false, "",
+		)
+	} else {
+		err = g.binaryManager.UpdateExecutable(ctx, path, binUpInfo.GetUpgradePackage())
+	}
+
+	switch {
+	case errors.Is(err, manager.ErrBinaryProtected):
+		fmt.Fprintln(g.stdErr, "❌ gobin is protected (use gobin unprotect gobin to update it)")
+		return err
+	case err != nil:
+		fmt.Fprintf(g.stdErr, "❌ error updating gobin to %s\n", latest)
+		return err
+	}
+
+	fmt.Fprintf(g.output(), "✅ gobin updated from %s to %s\n", current, latest)
+
+	return nil
+}
+
+// ServeCache serves the managed binaries pinned to the Go binary directory as a
+// binary cache on the given address, ex. :8080, until the context is done, for
+// other gobin installations to download them instead of building them, with
+// --cache-from. It prints the address served to the standard output (or another
+// defined io.Writer). It returns an error if the address cannot be listened on
+// or the server fails.
+func (g *Gobin) ServeCache(ctx context.Context, addr string) error {
+	listener, err := new(net.ListenConfig).Listen(ctx, "tcp", addr)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ cannot listen on %s: %s\n", addr, err)
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           registry.NewBinaryCacheHandler(g.fs, g.binaryManager.GetCacheArtifacts),
+		ReadHeaderTimeout: serveCacheReadHeaderTimeout,
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serveCacheShutdownTimeout)
+		defer cancel()
+
+		_ = server.Shutdown(shutdownCtx)
+	})
+	defer stop()
+
+	fmt.Fprintf(g.output(), "✅ serving binary cache on http://%s, press Ctrl+C to stop\n", listener.Addr())
+
+	if err = server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintln(g.stdErr, "❌ error serving binary cache")
+		return err
+	}
+
+	return nil
+}
+
+// SetBinaryEnv sets the given environment variables, in the form "name=value",
+// in the exec shim of the given binary, setting them before running it. It
+// prints a success message per variable to the standard output (or another
+// defined io.Writer), and a notice if the shim directory is not in PATH. It
+// returns an error if the binary cannot be found or the shim cannot be written.
+func (g *Gobin) SetBinaryEnv(bin model.Binary, envVars ...string) error {
+	if err := g.binaryManager.SetBinaryEnv(bin, envVars...); err != nil {
+		if errors.Is(err, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else {
+			fmt.Fprintf(g.stdErr, "❌ error setting environment for binary %q\n", bin.String())
+		}
+
+		return err
+	}
+
+	for _, envVar := range envVars {
+		name, _, _ := strings.Cut(envVar, "=")
+		fmt.Fprintf(g.output(), "✅ %s set for %s\n", name, bin.String())
+	}
+
+	if shimPath := g.workspace.GetInternalShimPath(); !g.userPath.Contains(shimPath) {
+		fmt.Fprintf(
+			g.notice(),
+			"💡 add %s to PATH before %s to apply the environment, ex. with gobin init\n",
+			shimPath, g.workspace.GetGoBinPath(),
+		)
+	}
+
+	return nil
+}
+
+// SetConfigValue sets the given key to the value in the configuration file,
+// created if it does not exist, keeping the other settings and the comments.
+// It returns an error if the key is unknown, the updated configuration is
+// invalid, or the configuration file cannot be read or written.
+func (g *Gobin) SetConfigValue(key, value string) error {
+	path, err := g.updateConfig(func(data []byte) ([]byte, error) {
+		return model.SetConfigValue(data, key, value)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(g.output(), "✅ %s set to %q in %s\n", key, value, path)
+
+	return nil
+}
+
+// SetCacheFrom sets the URL of the binary cache server, started with gobin
+// serve-cache, the installed and upgraded binaries are downloaded from instead
+// of being built, when it serves a matching binary.
+func (g *Gobin) SetCacheFrom(url string) {
+	g.cacheFrom = url
+}
+
+// SetCompress sets whether the installed and upgraded binaries are compressed
+// with UPX, overriding the upx configuration key.
+func (g *Gobin) SetCompress(compress bool) {
+	g.compress = compress
+}
+
+// SetDryRun sets whether the install, upgrade, uninstall and prune operations
+// only print the actions they would take, ex. the binaries installed, the pins
+// replaced and the files removed, without building the packages or changing
+// the filesystem.
+func (g *Gobin) SetDryRun(dryRun bool) {
+	g.dryRun = dryRun
+}
+
+// SetJSON sets whether the output of the list, outdated, doctor, info, repo and
+// env commands is written as JSON instead of tables and messages, for scripts
+// and dashboards. The notices, warnings and errors are still written to the
+// standard error as messages.
+func (g *Gobin) SetJSON(asJSON bool) {
+	g.asJSON = asJSON
+}
+
+// SetPlatform sets the platform, ex. linux/arm64, the installed packages are
+// cross compiled for, stored in the platform directory of the internal binary
+// directory instead of being installed in the Go binary directory.
+func (g *Gobin) SetPlatform(platform string) {
+	g.platform = platform
+}
+
+// SetProgress sets whether the progress of the installs, upgrades and
+// diagnostics of several binaries is rendered to the standard error (or another
+// defined io.Writer), as a line rewritten in place, usually when it is a
+// terminal. The progress is never rendered in quiet mode.
+func (g *Gobin) SetProgress(progress bool) {
+	g.progress = progress
+}
+
+// SetQuiet sets whether the non-error output is suppressed. When quiet, the
+// tables and success messages written to the standard output and the notices
+// and warnings written to the standard error are discarded, while the errors
+// and the confirmation prompts are still written.
+func (g *Gobin) SetQuiet(quiet bool) {
+	g.quiet = quiet
+}
+
+// SetShowAllWarnings sets whether the warnings already shown recently are shown
+// again. By default, the deprecation warning of a module is shown once a day.
+func (g *Gobin) SetShowAllWarnings(showAllWarnings bool) {
+	g.showAllWarnings = showAllWarnings
+}
+
+// SetStdinArgs sets whether the arguments of the command are read from the
+// standard input, which then cannot answer the confirmation prompts: they are
+// declined with a notice to confirm them with --yes instead.
+func (g *Gobin) SetStdinArgs(stdinArgs bool) {
+	g.stdinArgs = stdinArgs
+}
+
+// SetStrict sets whether the warnings are promoted to failures. When strict,
+// the warnings are written to the standard error even in quiet mode, and the
+// operations raising them fail with ErrWarnings.
+func (g *Gobin) SetStrict(strict bool) {
+	g.strict = strict
+}
+
+// ShowBinaryRepository shows the repository URL for a given binary. It prints
+// the repository URL to the standard output (or another defined io.Writer), or
+// an error if the binary cannot be found. If the open flag is set, it opens the
+// repository URL in the default system browser.
+func (g *Gobin) ShowBinaryRepository(ctx context.Context, bin model.Binary, open bool) error {
+	repoURL, err := g.binaryManager.GetBinaryRepository(ctx, bin)
+	if err != nil {
+		if errors.Is(err, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else {
+			fmt.Fprintf(g.stdErr, "❌ error getting repository for binary %q\n", bin.String())
+		}
+
+		return err
+	}
+
+	if open {
+		return g.resource.Open(ctx, repoURL)
+	}
+
+	return g.render(repositoryJSON{Name: bin.String(), URL: repoURL}, func(w io.Writer) error {
+		fmt.Fprintln(w, repoURL)
+		return nil
+	})
+}
+
+// SyncLockfile installs and uninstalls the managed binaries so the Go binary
+// directory matches the lockfile at the given path. The binaries of the
+// lockfile missing or built from another package or version are installed at
+// their locked version with their pin kind, launching go routines up to the
+// given parallelism, and the managed binaries missing from the lockfile are
+// uninstalled, skipping protected binaries. Before prompting for confirmation
+// with the list of changes, unless assumeYes is set, the packages to install
+// are checked against the deny list and their download size is estimated, and
+// nothing is changed if it exceeds the given maximum download size. It returns
+// an error if the lockfile cannot be read or is invalid, if the managed
+// binaries cannot be listed, if a package is denied or too large to download,
+// or if any of the binaries cannot be installed or uninstalled.
+func (g *Gobin) SyncLockfile(
+	ctx context.Context,
+	path string,
+	parallelism int,
+	maxDownload model.ByteSize,
+	assumeYes bool,
+) error {
+	data, err := g.fs.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(g.stdErr, "❌ lockfile %s not found (use gobin export to create it)\n", path)
+		return err
+	} else if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error reading lockfile %s\n", path)
+		return err
+	}
+
+	lockfile, err := model.ParseLockfile(data)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ invalid lockfile %s: %s\n", path, err)
+		return err
+	}
+
+	current, err := g.binaryManager.GetLockedBinaries()
+	if err != nil {
+		return err
+	}
+
+	installed := make(map[string]model.LockedBinary, len(current))
+	for _, bin := range current {
+		installed[bin.Name] = bin
+	}
+
+	var sb strings.Builder
+	locked := make(map[string]bool, len(lockfile.Binaries))
+	installs := make(map[model.Kind][]model.Package)
+	for _, bin := range lockfile.Binaries {
+		locked[bin.Name] = true
+
+		cur, ok := installed[bin.Name]
+		switch {
+		case !ok:
+			fmt.Fprintf(&sb, "  • install %s\n", bin)
+		case cur.Package != bin.Package || cur.Version != bin.Version:
+			fmt.Fprintf(&sb, "  • replace %s with %s\n", cur, bin)
+		default:
+			continue
+		}
+
+		installs[bin.Kind] = append(installs[bin.Kind], bin.GetPackage())
+	}
+
+	var uninstalls []model.Binary
+	for _, bin := range current {
+		if !locked[bin.Name] {
+			fmt.Fprintf(&sb, "  • uninstall %s\n", bin)
+			uninstalls = append(uninstalls, bin.GetBinary())
+		}
+	}
+
+	if sb.Len() == 0 {
+		fmt.Fprintf(g.output(), "✅ binaries already in sync with %s\n", path)
+		return nil
+	}
+
+	kinds := []model.Kind{model.KindLatest, model.KindMajor, model.KindMinor}
+
+	var packages []model.Package
+	for _, kind := range kinds {
+		packages = append(packages, installs[kind]...)
+	}
+
+	if err = g.checkDeniedPackages(packages...); err != nil {
+		return err
+	}
+
+	if err = g.checkDownloadSize(ctx, maxDownload, packages...); err != nil {
+		return err
+	}
+
+	if !assumeYes {
+		confirmed, confirmErr := g.confirm(fmt.Sprintf(
+			"The following changes will be applied to sync with %s:\n%sDo you want to continue?", path, sb.String(),
+		))
+		if confirmErr != nil {
+			return confirmErr
+		}
+
+		if !confirmed {
+			fmt.Fprintln(g.output(), "sync aborted")
+			return nil
+		}
+	}
+
+	for _, kind := range kinds {
+		if len(installs[kind]) == 0 {
+			continue
+		}
+
+		if installErr := g.installPackages(
+			ctx, parallelism, kind, model.BuildFlags{}, false, false, false, installs[kind]...,
+		); installErr != nil {
+			err = installErr
+		}
+	}
+
+	for _, bin := range uninstalls {
+		removeErr := g.uninstallBinary(bin, false, false)
+		if errors.Is(removeErr, manager.ErrBinaryProtected) {
+			fmt.Fprintf(g.notice(), "🔒 skipping protected binary %q\n", bin)
+		} else if removeErr != nil {
+			fmt.Fprintf(g.stdErr, "❌ error uninstalling binary %q\n", bin)
+			err = removeErr
+		}
+	}
+
+	return err
+}
+
+// UninstallAllBinaries uninstalls all binaries managed by gobin by removing
+// their pins from the Go binary directory. It prompts for confirmation with the
+// list of binaries to uninstall unless assumeYes is set. Protected binaries are
+// skipped unless force is set. If purge is set, it also removes every version
+// and receipt of the binaries, skipping pins already removed while purging
+// another pin of the same binary. If prune is set, it also prunes the binaries
+// from the internal binary directory. It returns an error if the binaries
+// cannot be listed, or if any of the binaries cannot be uninstalled or pruned.
+func (g *Gobin) UninstallAllBinaries(force, purge, prune, assumeYes bool) error {
+	binInfos, err := g.binaryManager.GetAllBinaryInfos(false)
+	if err != nil {
+		return err
+	}
+
+	var bins []model.Binary
+	for _, info := range binInfos {
+		if info.IsManaged {
+			bins = append(bins, info.Binary)
+		}
+	}
+
+	if len(bins) == 0 && !prune {
+		fmt.Fprintln(g.output(), "no managed binaries to uninstall")
+		return nil
+	}
+
+	if !assumeYes && !g.dryRun {
+		var sb strings.Builder
+		sb.WriteString("The following binaries will be uninstalled:\n")
+		for _, bin := range bins {
+			fmt.Fprintf(&sb, "  • %s\n", bin)
+		}
+
+		if prune {
+			sb.WriteString("All unpinned versions will be pruned from the internal binary directory.\n")
+		}
+
+		confirmed, confirmErr := g.confirm(sb.String() + "Do you want to continue?")
+		if confirmErr != nil {
+			return confirmErr
+		}
+
+		if !confirmed {
+			fmt.Fprintln(g.output(), "uninstall aborted")
+			return nil
+		}
+	}
+
+	for _, bin := range bins {
+		removeErr := g.uninstallBinary(bin, force, purge)
+		if errors.Is(removeErr, manager.ErrBinaryProtected) {
+			fmt.Fprintf(g.notice(), "🔒 skipping protected binary %q\n", bin)
+			continue
+		} else if purge && errors.Is(removeErr, os.ErrNotExist) {
+			continue
+		} else if removeErr != nil {
+			fmt.Fprintf(g.stdErr, "❌ error uninstalling binary %q\n", bin)
+			err = removeErr
+		}
+	}
+
+	if prune {
+		if pruneErr := g.PruneBinaries(force); pruneErr != nil {
+			err = pruneErr
+		}
+	}
+
+	return err
+}
+
+// UninstallBinaries uninstalls the given binaries by removing the binary files.
+// If purge is set, it also removes every version and receipt of the binaries.
+// Protected binaries are refused unless force is set. Otherwise, it warns about
+// other pins still referencing the same binary and prompts to uninstall them
+// too, unless assumeYes is set, in which case they are uninstalled without
+// prompting. It returns an error if the binary cannot be found or removed.
+func (g *Gobin) UninstallBinaries(force, purge, assumeYes bool, bins ...model.Binary) error {
+	var err error
+	for _, bin := range bins {
+		var pins []model.Binary
+		if !purge && !g.dryRun {
+			pins, _ = g.binaryManager.GetRelatedPins(bin)
+		}
+
+		removeErr := g.uninstallBinary(bin, force, purge)
+		if errors.Is(removeErr, os.ErrNotExist) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin)
+		} else if errors.Is(removeErr, manager.ErrBinaryProtected) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q is protected (use --force to override)\n", bin)
+		} else if removeErr != nil {
+			fmt.Fprintf(g.stdErr, "❌ error uninstalling binary %q\n", bin)
+		} else if len(pins) > 0 {
+			removeErr = g.uninstallRelatedPins(bin, pins, force, assumeYes)
+		}
+
+		err = removeErr
+	}
+
+	return err
+}
+
+// UnsetBinaryEnv unsets the given environment variables, or all of them if
+// none is given, in the exec shim of the given binary, removing the shim if no
+// variable is left. It prints a success message to the standard output (or
+// another defined io.Writer). It returns an error if the binary cannot be found
+// or the shim cannot be updated.
+func (g *Gobin) UnsetBinaryEnv(bin model.Binary, names ...string) error {
+	if err := g.binaryManager.UnsetBinaryEnv(bin, names...); err != nil {
+		if errors.Is(err, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else {
+			fmt.Fprintf(g.stdErr, "❌ error unsetting environment for binary %q\n", bin.String())
+		}
+
+		return err
+	}
+
+	if len(names) == 0 {
+		fmt.Fprintf(g.output(), "✅ environment unset for %s\n", bin.String())
+	}
+
+	for _, name := range names {
+		fmt.Fprintf(g.output(), "✅ %s unset for %s\n", name, bin.String())
+	}
+
+	return nil
+}
+
+// UnsetConfigValue removes the given key from the configuration file, keeping
+// the other settings and the comments, to restore its default. It returns an
+// error if the key is unknown, or the configuration file cannot be read or
+// written.
+func (g *Gobin) UnsetConfigValue(key string) error {
+	path, err := g.updateConfig(func(data []byte) ([]byte, error) {
+		return model.UnsetConfigValue(data, key)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(g.output(), "✅ %s unset in %s\n", key, path)
+
+	return nil
+}
+
+// UnmigrateBinaries restores the given binaries, or all managed binaries in the
+// Go binary directory, to plain files, undoing their migration. It returns an
+// error if the binaries cannot be listed or any of the binaries cannot be
+// restored.
+func (g *Gobin) UnmigrateBinaries(bins ...model.Binary) error {
+	if len(bins) == 0 {
+		binInfos, err := g.binaryManager.GetAllBinaryInfos(false)
+		if err != nil {
+			return err
+		}
+
+		for _, info := range binInfos {
+			if info.IsManaged {
+				bins = append(bins, info.Binary)
+			}
+		}
+	}
+
+	var err error
+	for _, bin := range bins {
+		restoreErr := g.binaryManager.UnmigrateBinary(bin)
+		switch {
+		case restoreErr == nil:
+			continue
+		case errors.Is(restoreErr, toolchain.ErrBinaryNotFound):
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin)
+		case errors.Is(restoreErr, manager.ErrBinaryNotManaged):
+			fmt.Fprintf(g.stdErr, "❌ binary %q not managed\n", bin)
+		default:
+			fmt.Fprintf(g.stdErr, "❌ error restoring binary %q\n", bin)
+		}
+
+		err = restoreErr
+	}
+
+	return err
+}
+
+// UpgradeBinaries upgrades the given binaries or all binaries in the Go binary
+// directory. The non-empty build flags override the ones recorded in the
+// binary receipts. If majorUpgrade is set, it upgrades the major version of
+// the binaries. If rebuild is set, it rebuilds the binaries. Protected
+// binaries are skipped when upgrading all binaries, and refused when given
+// explicitly, unless force is set. If staleGo is set, only the binaries built
+// with a Go version with a newer patch release are upgraded, rebuilt with the
+// latest patch release. It returns an error if the binary directory cannot be
+// determined or listed. The command runs in parallel, launching go routines to
+// upgrade the binaries up to the given parallelism, and a summary of the
+// failures is printed at the end when several binaries fail to upgrade. The
+// binaries matching the exclude list of the configuration defaults are skipped
+// when upgrading all binaries. If adaptive is set, the parallelism is throttled
+// under memory pressure or high load, starting no upgrade while the system is
+// under pressure unless none is running, to prevent the builds from exhausting
+// the memory. The binaries are downloaded from the binary cache server set with
+// SetCacheFrom, if any, when it serves a matching binary. When the retention
+// policy of the configuration enables auto-prune, the versions it does not keep
+// are pruned once all the binaries are upgraded successfully.
+func (g *Gobin) UpgradeBinaries(
+	ctx context.Context,
+	flags model.BuildFlags,
+	majorUpgrade bool,
+	rebuild bool,
+	staleGo bool,
+	force bool,
+	timings bool,
+	adaptive bool,
+	parallelism int,
+	bins ...model.Binary,
+) error {
+	flags.Compress = flags.Compress || g.compress
+	binFullPath := g.workspace.GetGoBinPath()
+	upgradeAll := len(bins) == 0
+
+	var releases model.GoReleases
+	if staleGo {
+		var err error
+		releases, err = g.binaryManager.GetGoReleases(ctx)
+		if err != nil {
+			fmt.Fprintln(g.stdErr, "❌ error getting Go releases")
+			return err
+		}
+	}
+
+	var binPaths []string
+	if upgradeAll {
+		var err error
+		binPaths, err = g.fs.ListBinaries(binFullPath)
+		if err != nil {
+			return err
+		}
+
+		binPaths = slices.DeleteFunc(binPaths, func(binPath string) bool {
+			return g.config.Defaults.IsExcluded(filepath.Base(binPath))
+		})
+	} else {
+		for _, bin := range bins {
+			binPaths = append(binPaths, filepath.Join(binFullPath, bin.String()))
+		}
+	}
+
+	grp := new(errgroup.Group)
+	grp.SetLimit(parallelism)
+	opTimings := newOperationTimings()
+	failures := new(operationFailures)
+	warnings := internal.NewWarnings()
+
+	var throttle *pressureThrottle
+	if adaptive && parallelism > 1 {
+		throttle = newPressureThrottle(g.resource, func(pressure model.SystemPressure) {
+			fmt.Fprintf(
+				g.notice(),
+				"🐢 system under pressure (%s of %s memory available, load %.1f on %d CPUs), throttling upgrades\n",
+				pressure.MemoryAvailable.String(),
+				pressure.MemoryTotal.String(),
+				pressure.Load,
+				pressure.CPUs,
+			)
+		})
+	}
+
+	progress, stopProgress := g.startProgress("upgrading", len(binPaths))
+
+	for _, bin := range binPaths {
+		grp.Go(func() error {
+			if throttle != nil {
+				if err := throttle.acquire(ctx); err != nil {
+					return err
+				}
+				defer throttle.release()
+			}
+
+			progress.Begin(filepath.Base(bin))
+			defer progress.End(filepath.Base(bin))
+
+			ctx := internal.WithLogAttrs(ctx, slog.String("operation", "upgrade"), slog.String("binary", filepath.Base(bin)))
+			ctx = internal.WithWarnings(ctx, warnings)
+			ctx = opTimings.track(ctx, filepath.Base(bin))
+			ctx = toolchain.WithProvenance(ctx, model.NewProvenance("upgrade"))
+			start := time.Now()
+
+			rebuild := rebuild
+			var upErr error
+			if staleGo {
+				var info model.BinaryInfo
+				if info, upErr = g.binaryManager.GetBinaryInfo(bin); upErr == nil {
+					updateInfo, stale := releases.GetBinaryGoUpdateInfo(info)
+					if !stale {
+						if !upgradeAll {
+							fmt.Fprintf(
+								g.output(), "✅ %s already built with the latest Go patch release\n", filepath.Base(bin),
+							)
+						}
+						return nil
+					}
+
+					ctx = toolchain.WithGoVersion(ctx, updateInfo.LatestGoVersion)
+					rebuild = true
+				}
+			}
+
+			if upErr == nil && g.dryRun {
+				var actions []model.Action
+				actions, upErr = g.binaryManager.PlanUpgradeBinary(ctx, bin, flags, majorUpgrade, rebuild, force)
+				if upErr == nil {
+					upErr = g.printPlan("upgrade", filepath.Base(bin), actions)
+				}
+			} else if upErr == nil {
+				upErr = g.binaryManager.UpgradeBinary(ctx, bin, flags, majorUpgrade, rebuild, force, g.cacheFrom)
+			}
+			logOperation(ctx, start, upErr)
+			if errors.Is(upErr, toolchain.ErrBinaryNotFound) {
+				fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", filepath.Base(bin))
+			} else if errors.Is(upErr, manager.ErrBinaryProtected) {
+				if upgradeAll {
+					fmt.Fprintf(g.notice(), "🔒 skipping protected binary %q\n", filepath.Base(bin))
+					return nil
+				}
+
+				fmt.Fprintf(g.stdErr, "❌ binary %q is protected (use --force to override)\n", filepath.Base(bin))
+			} else if upErr != nil {
+				fmt.Fprintf(g.stdErr, "❌ error upgrading binary %q\n", filepath.Base(bin))
+			}
+
+			if upErr != nil {
+				failures.add(filepath.Base(bin), upErr)
+			}
+
+			return upErr
+		})
+	}
+
+	waitErr := getPartialFailure(grp.Wait(), failures.len(), len(binPaths))
+	stopProgress()
+
+	if timings {
+		if err := g.printTimings(opTimings); err != nil {
+			return err
+		}
+	}
+
+	g.printWarnings(warnings)
+
+	if err := g.printFailures(failures); err != nil {
+		return err
+	}
+
+	if waitErr == nil && !g.dryRun && g.config.Retention.AutoPrune && g.config.Retention.IsSet() {
+		if err := g.PruneBinariesByPolicy(false); err != nil {
+			return err
+		}
+	}
+
+	return g.getStrictErr(waitErr, len(warnings.Get()) > 0)
+}
+
+// UseBinary switches the given pin to the given version of the binary in the
+// internal binary directory, without building it. If the version is latest, it
+// lists the versions of the binary in the internal binary directory matching
+// the pin kind, from the newest to the oldest, and reads the version to switch
+// to from the standard input (or another defined io.Reader). It prints the
+// version the binary is switched to, to the standard output (or another defined
+// io.Writer). It returns ErrInvalidSelection if the selected version is not one
+// of the listed ones, or an error if the binary cannot be found, is not
+// managed, the version is not installed, or the pin cannot be switched.
+func (g *Gobin) UseBinary(bin model.Binary, version model.Version) error {
+	if version.IsLatest() {
+		versions, err := g.binaryManager.GetPinVersions(bin)
+		if err != nil {
+			g.printPinVersionsErr(bin, err)
+			return err
+		}
+
+		var ok bool
+		if version, ok, err = g.selectPinVersion(bin, versions); err != nil || !ok {
+			return err
+		}
+	}
+
+	err := g.binaryManager.SwitchBinary(bin, version)
+	switch {
+	case errors.Is(err, manager.ErrVersionNotInstalled):
+		fmt.Fprintf(g.stdErr, "❌ version %s of binary %q not installed\n", version.String(), bin.String())
+	case errors.Is(err, toolchain.ErrBinaryNotFound),
+		errors.Is(err, manager.ErrBinaryNotManaged):
+		g.printPinVersionsErr(bin, err)
+	case err != nil:
+		fmt.Fprintf(g.stdErr, "❌ error switching binary %q to %s\n", bin.String(), version.String())
+	default:
+		fmt.Fprintf(g.output(), "✅ binary %q switched to %s\n", bin.String(), version.String())
+	}
+
+	return err
+}
+
+// VerifyPath verifies that the managed binaries in the Go binary directory are
+// the ones run from PATH, and not shadowed by other executables of the same name
+// in directories earlier in PATH. It prints the shadowed binaries, with the
+// executable that runs instead and how to fix it, to the standard output (or
+// another defined io.Writer). It returns ErrBinaryShadowed if any binary is
+// shadowed, or an error if the binaries cannot be listed.
+func (g *Gobin) VerifyPath() error {
+	goBinPath := g.workspace.GetGoBinPath()
+	if !g.userPath.Contains(goBinPath) {
+		fmt.Fprintf(g.notice(), "⚠️  %s is not in PATH, add it with gobin doctor --fix\n", goBinPath)
+	}
+
+	binInfos, err := g.binaryManager.GetAllBinaryInfos(false)
+	if err != nil {
+		return err
+	}
+
+	var (
+		total    int
+		shadowed = make([]shadowedBinary, 0, len(binInfos))
+	)
+
+	for _, info := range binInfos {
+		if !info.IsManaged {
+			continue
+		}
+
+		total++
+
+		locations := g.fs.LocateBinaryInPath(filepath.Base(info.FullPath))
+		if len(locations) > 0 && filepath.Dir(locations[0]) == g.workspace.GetInternalShimPath() {
+			locations = locations[1:]
+		}
+
+		if len(locations) == 0 || g.isSameBinary(locations[0], info) {
+			continue
+		}
+
+		shadowed = append(shadowed, shadowedBinary{
+			Name:          info.Binary.String(),
+			Path:          info.FullPath,
+			Dir:           filepath.Dir(info.FullPath),
+			ShadowedBy:    locations[0],
+			ShadowedByDir: filepath.Dir(locations[0]),
+		})
+	}
+
+	sort.Slice(shadowed, func(i, j int) bool {
+		return shadowed[i].Name < shadowed[j].Name
+	})
+
+	data := struct {
+		Total    int
+		Shadowed []shadowedBinary
+	}{
+		Total:    total,
+		Shadowed: shadowed,
+	}
+
+	tmplParsed := template.Must(template.New("verify-path").Parse(verifyPathTemplate))
+	if err = tmplParsed.Execute(g.output(), data); err != nil {
+		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+		return err
+	}
+
+	if len(shadowed) > 0 {
+		return ErrBinaryShadowed
+	}
+
+	return nil
+}
+
+// Watch checks the binaries in the Go binary directory for upgrades and known
+// vulnerabilities right away and then at every interval, until the context is
+// canceled. When action is needed, it prints the outdated and vulnerable
+// binaries to the standard output (or another defined io.Writer) and raises a
+// desktop notification. It returns an error if the binaries cannot be listed.
+func (g *Gobin) Watch(ctx context.Context, interval time.Duration, parallelism int) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := g.watchBinaries(ctx, parallelism); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkDeniedPackages checks the given packages against the deny list of the
+// configuration, reporting every denied package. It returns ErrPackageDenied if
+// any of the packages is denied.
+func (g *Gobin) checkDeniedPackages(packages ...model.Package) error {
+	var denied bool
+	for _, pkg := range packages {
+		if rule, ok := g.config.GetDenyRule(pkg); ok {
+			fmt.Fprintf(g.stdErr, "❌ package %q denied by policy rule %q\n", pkg.Path, rule)
+			denied = true
+		}
+	}
+
+	if denied {
+		return ErrPackageDenied
+	}
+
+	return nil
+}
+
+// checkDownloadSize estimates the download size of the modules of the given
+// packages, when there are several packages or a maximum download size is
+// given. It returns ErrDownloadTooLarge if the estimate exceeds the maximum
+// download size.
+func (g *Gobin) checkDownloadSize(ctx context.Context, maxDownload model.ByteSize, packages ...model.Package) error {
+	if len(packages) <= 1 && maxDownload <= 0 {
+		return nil
+	}
+
+	estimate := g.binaryManager.EstimateDownloadSize(ctx, packages...)
+	fmt.Fprintf(
+		g.notice(),
+		"📦 estimated download size: %s for %d modules, dependencies excluded\n",
+		estimate.Size.String(),
+		estimate.Modules,
+	)
+
+	if estimate.Unknown > 0 {
+		fmt.Fprintf(g.notice(), "⚠️  download size unknown for %d modules\n", estimate.Unknown)
+	}
+
+	if maxDownload > 0 && estimate.Size > maxDownload {
+		fmt.Fprintf(
+			g.stdErr,
+			"❌ estimated download size %s exceeds the maximum download size %s\n",
+			estimate.Size.String(),
+			maxDownload.String(),
+		)
+		return ErrDownloadTooLarge
+	}
+
+	return nil
+}
+
+// confirm prints the given prompt to the standard output (or another defined
+// io.Writer) and reads the answer from the standard input (or another defined
+// io.Reader). It returns true if the answer is yes, or an error if the answer
+// cannot be read. If the arguments are read from the standard input, it
+// declines without prompting.
+func (g *Gobin) confirm(prompt string) (bool, error) {
+	if g.stdinArgs {
+		fmt.Fprintln(g.notice(), "💡 confirmation declined, the binaries are read from stdin (use --yes to confirm)")
+		return false, nil
+	}
+
+	fmt.Fprintf(g.stdOut, "%s [y/N] ", prompt)
+
+	answer, err := g.stdIn.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// diagnoseBinaries diagnoses and prints the issues in all binaries, as
+// described in DiagnoseBinaries, and returns the diagnostic results.
+func (g *Gobin) diagnoseBinaries(
+	ctx context.Context,
+	parallelism int,
+	timings bool,
+) ([]model.BinaryDiagnostic, error) {
+	bins, err := g.fs.ListBinaries(g.workspace.GetGoBinPath())
+	if err != nil {
+		return nil, err
+	}
+
+	crossInfos, err := g.binaryManager.GetCrossBinaryInfos()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range crossInfos {
+		bins = append(bins, info.FullPath)
+	}
+
+	var (
+		mutex     sync.Mutex
+		diags     = make([]model.BinaryDiagnostic, 0, len(bins))
+		grp       = new(errgroup.Group)
+		opTimings = newOperationTimings()
+		failures  = new(operationFailures)
+		warnings  = internal.NewWarnings()
+	)
+
+	grp.SetLimit(parallelism)
+	progress, stopProgress := g.startProgress("diagnosing", len(bins))
+
+	for _, bin := range bins {
+		grp.Go(func() error {
+			progress.Begin(filepath.Base(bin))
+			defer progress.End(filepath.Base(bin))
+
+			ctx := internal.WithLogAttrs(ctx, slog.String("operation", "doctor"), slog.String("binary", filepath.Base(bin)))
+			ctx = internal.WithWarnings(ctx, warnings)
+			ctx = opTimings.track(ctx, filepath.Base(bin))
+			start := time.Now()
+
+			diag, diagErr := g.binaryManager.DiagnoseBinary(ctx, bin)
+			logOperation(ctx, start, diagErr)
+			if diagErr != nil {
+				failures.add(filepath.Base(bin), diagErr)
+				fmt.Fprintf(g.stdErr, "❌ error diagnosing binary %q\n", filepath.Base(bin))
+				return diagErr
+			}
+
+			mutex.Lock()
+			diags = append(diags, diag)
+			mutex.Unlock()
+
+			return nil
+		})
+	}
+
+	waitErr := getPartialFailure(grp.Wait(), failures.len(), len(bins))
+	stopProgress()
+
+	shownDiags, hidden := g.filterDeprecations(diags)
+	if err = g.printBinaryDiagnostics(shownDiags); err != nil {
+		return nil, err
+	}
+
+	if timings {
+		if err = g.printTimings(opTimings); err != nil {
+			return nil, err
+		}
+	}
+
+	if crossOS := g.binaryManager.GetCrossOS(); crossOS != "" {
+		fmt.Fprintf(
+			g.notice(),
+			"⚠️  the Go binary path %s is shared with %s through WSL, %s binaries are diagnosed with a platform "+
+				"mismatch\n",
+			g.workspace.GetGoBinPath(), crossOS, crossOS,
+		)
+	}
+
+	sumDBConfig, err := g.binaryManager.GetSumDBConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason, disabled := sumDBConfig.GetDisabledReason(); disabled {
+		fmt.Fprintf(
+			g.notice(),
+			"⚠️  checksum database verification is disabled for all modules (%s), verification results are weaker\n",
+			reason,
+		)
+	}
+
+	if hidden > 0 {
+		fmt.Fprintf(
+			g.notice(),
+			"⚠️  %d deprecated modules already reported in the last day, use --show-all-warnings to show them\n",
+			hidden,
+		)
+	}
+
+	g.printWarnings(warnings)
+
+	if err = g.printFailures(failures); err != nil {
+		return nil, err
+	}
+
+	warned := len(warnings.Get()) > 0 || slices.ContainsFunc(diags, model.BinaryDiagnostic.HasWarnings)
+	return diags, g.getStrictErr(waitErr, warned)
+}
+
+// filterDeprecations returns the given diagnostics without the deprecation
+// warnings of the modules already shown in the last day, and the number of
+// warnings hidden, recording when the remaining ones are shown. All warnings
+// are shown in strict mode or when showing all warnings, and none is recorded
+// in quiet mode. A record that cannot be read or written is logged and ignored.
+func (g *Gobin) filterDeprecations(diags []model.BinaryDiagnostic) ([]model.BinaryDiagnostic, int) {
+	if (g.quiet && !g.strict) || !slices.ContainsFunc(diags, func(d model.BinaryDiagnostic) bool {
+		return d.Module != "" && d.Deprecated != ""
+	}) {
+		return diags, 0
+	}
+
+	path := filepath.Join(g.workspace.GetInternalBasePath(), deprecationNoticesFileName)
+
+	shownAt := map[string]time.Time{}
+	if data, err := g.fs.ReadFile(path); err == nil {
+		if err = json.Unmarshal(data, &shownAt); err != nil {
+			shownAt = map[string]time.Time{}
+		}
+	}
+
+	var (
+		now      = time.Now()
+		shown    = make([]model.BinaryDiagnostic, 0, len(diags))
+		recorded = maps.Clone(shownAt)
+		hidden   int
+	)
+
+	for _, d := range diags {
+		if d.Module != "" && d.Deprecated != "" {
+			if !g.showAllWarnings && !g.strict && now.Sub(shownAt[d.Module]) < deprecationNoticeInterval {
+				d.Deprecated = ""
+				hidden++
+			} else {
+				recorded[d.Module] = now
+			}
+		}
+
+		shown = append(shown, d)
+	}
+
+	data, err := json.Marshal(recorded)
+	if err == nil {
+		//nolint:mnd // owner only permissions
+		err = g.fs.WriteFile(path, data, 0600)
+	}
+
+	if err != nil {
+		slog.Default().Warn("error recording deprecation notices", "path", path, "err", err)
+	}
+
+	return shown, hidden
+}
+
+// getBinaryFixes gets the fixes of the issues in the given diagnostics, in the
+// order they are applied: a binary is migrated before it is rebuilt. Binaries
+// cross compiled for another platform, built without Go modules, or not built
+// from a released module version are not fixed, and neither are the binaries
+// of another OS sharing the Go binary directory through WSL. Duplicate entries
+// of the Go binary path in PATH not added by gobin cannot be fixed, and are
+// reported as a notice. It returns an error if the broken pins or the
+// additions of the Go binary path to PATH cannot be determined.
+func (g *Gobin) getBinaryFixes(diags []model.BinaryDiagnostic) ([]binaryFix, error) {
+	goBinPath := g.workspace.GetGoBinPath()
+	crossOS := g.binaryManager.GetCrossOS()
+
+	var fixes []binaryFix
+	var duplicated bool
+
+	for _, diag := range diags {
+		if strings.Contains(diag.Name, "/") || diag.NotBuiltWithGoModules {
+			continue
+		}
+
+		path := filepath.Join(goBinPath, diag.Name)
+
+		if diag.IsNotManaged {
+			fixes = append(fixes, binaryFix{
+				Name:   diag.Name,
+				Action: "migrate",
+				Reason: "not managed by gobin",
+				apply: func(context.Context) error {
+					return g.binaryManager.MigrateBinary(path)
+				},
+			})
+		}
+
+		var reason string
+		switch {
+		case diag.IsOrphaned || (crossOS != "" && strings.HasPrefix(diag.Platform.Actual, crossOS+"/")):
+		case diag.GoVersion.Actual != diag.GoVersion.Expected:
+			reason = fmt.Sprintf("built with %s, expected %s", diag.GoVersion.Actual, diag.GoVersion.Expected)
+		case diag.Platform.Actual != diag.Platform.Expected:
+			reason = fmt.Sprintf("built for %s, expected %s", diag.Platform.Actual, diag.Platform.Expected)
+		}
+
+		if reason != "" {
+			fixes = append(fixes, binaryFix{
+				Name:   diag.Name,
+				Action: "rebuild",
+				Reason: reason,
+				apply: func(ctx context.Context) error {
+					return g.binaryManager.RebuildBinary(ctx, path)
+				},
+			})
+		}
+
+		inGoBinPath := 0
+		for _, location := range diag.DuplicatesInPath {
+			if filepath.Dir(location) == filepath.Clean(goBinPath) {
+				inGoBinPath++
+			}
+		}
+
+		duplicated = duplicated || inGoBinPath > 1
+	}
+
+	pins, err := g.binaryManager.GetBrokenPins()
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error listing broken pins")
+		return nil, err
+	}
+
+	for _, pin := range pins {
+		fixes = append(fixes, binaryFix{
+			Name:   filepath.Base(pin),
+			Action: "repin",
+			Reason: "internal binary missing",
+			apply: func(context.Context) error {
+				return g.binaryManager.RepinBinary(pin)
+			},
+		})
+	}
+
+	if !duplicated {
+		return fixes, nil
+	}
+
+	addition, err := g.userPath.GetAddition(goBinPath)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error determining how %s was added to PATH\n", goBinPath)
+		return nil, err
+	}
+
+	count, err := g.userPath.CountAdditions(addition)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error reading %s\n", addition.Target)
+		return nil, err
+	}
+
+	if count < 2 {
+		fmt.Fprintf(
+			g.notice(),
+			"⚠️  %s is in PATH more than once, remove the duplicate entries from your shell configuration\n",
+			goBinPath,
+		)
+		return fixes, nil
+	}
+
+	return append(fixes, binaryFix{
+		Name:   goBinPath,
+		Action: "remove duplicate PATH entries",
+		Reason: fmt.Sprintf("added to PATH %d times in %s", count, addition.Target),
+		apply: func(context.Context) error {
+			return g.userPath.RemoveDuplicateAdditions(addition)
+		},
+	}), nil
+}
+
+// getBugReportConfig returns the configuration file for a bug report, with the
+// credentials of URLs redacted.
+func (g *Gobin) getBugReportConfig() []byte {
+	config, err := g.fs.ReadFile(g.workspace.GetInternalConfigPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []byte("# no configuration file\n")
+		}
+
+		return fmt.Appendf(nil, "# error reading configuration file: %s\n", err)
+	}
+
+	return []byte(model.RedactURLCredentials(string(config)))
+}
+
+// getBugReportVersion returns the version of the given executable for a bug
+// report, or the error getting it.
+func (g *Gobin) getBugReportVersion(execPath string) []byte {
+	binInfo, err := g.binaryManager.GetBinaryInfo(execPath)
+	if err != nil {
+		return fmt.Appendf(nil, "error getting version: %s\n", err)
+	}
+
+	return fmt.Appendf(
+		nil,
+		"%s (%s %s/%s)\n",
+		binInfo.Module.Version.String(),
+		binInfo.GoVersion,
+		binInfo.OS,
+		binInfo.Arch,
+	)
+}
+
+// getLatestRelease returns the latest release of the given gobin binary from
+// the cached release check if done in the last day, or from the module proxy
+// otherwise, caching the result. A failed check is cached too, keeping the
+// latest release known, so that it is not retried on every command while
+// offline. It returns an error if the latest release cannot be determined or
+// the result cannot be cached.
+func (g *Gobin) getLatestRelease(ctx context.Context, binInfo model.BinaryInfo) (model.Version, error) {
+	path := filepath.Join(g.workspace.GetInternalBasePath(), releaseCheckFileName)
+
+	var check releaseCheck
+	if data, err := g.fs.ReadFile(path); err == nil && json.Unmarshal(data, &check) == nil &&
+		time.Since(check.CheckedAt) < releaseCheckInterval {
+		return check.LatestVersion, nil
+	}
+
+	check.CheckedAt = time.Now()
+
+	binUpInfo, checkErr := g.binaryManager.GetBinaryUpgradeInfo(ctx, binInfo, false)
+	if checkErr == nil {
+		check.LatestVersion = binUpInfo.LatestModule.Version
+	}
+
+	data, err := json.Marshal(check)
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:mnd // owner only permissions
+	if err = g.fs.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+
+	if checkErr != nil {
+		return "", checkErr
+	}
+
+	return check.LatestVersion, nil
+}
+
+// getLinkRoot returns the directory watched for changes to the binary linked to
+// the given directory: the enclosing workspace with a go.work file, or else the
+// enclosing module with a go.mod file, so changes to the packages imported by
+// the linked package are picked up too. It falls back to the given directory.
+func (g *Gobin) getLinkRoot(source string) string {
+	root := ""
+	for dir := source; ; dir = filepath.Dir(dir) {
+		if g.fs.Exists(filepath.Join(dir, "go.work")) {
+			return dir
+		}
+
+		if root == "" && g.fs.Exists(filepath.Join(dir, "go.mod")) {
+			root = dir
+		}
+
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	if root == "" {
+		return source
+	}
+
+	return root
+}
+
+// getLinkedBinaries gets the local directories the given binaries are linked
+// to, or of all the linked binaries in the Go binary directory if none is given.
+// It returns an error if any of the given binaries is not found or not linked.
+func (g *Gobin) getLinkedBinaries(bins ...model.Binary) ([]linkedBinary, error) {
+	if len(bins) == 0 {
+		binInfos, err := g.binaryManager.GetAllBinaryInfos(false)
+		if err != nil {
+			return nil, err
+		}
+
+		links := make([]linkedBinary, 0, len(binInfos))
+		for _, info := range binInfos {
+			if !info.IsManaged {
+				continue
+			}
+
+			source, err := g.binaryManager.GetLinkSource(info.Binary)
+			if errors.Is(err, manager.ErrBinaryNotLinked) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			links = append(links, linkedBinary{Binary: info.Binary, Source: source})
+		}
+
+		return links, nil
+	}
+
+	links := make([]linkedBinary, 0, len(bins))
+	for _, bin := range bins {
+		source, err := g.binaryManager.GetLinkSource(bin)
+		switch {
+		case errors.Is(err, toolchain.ErrBinaryNotFound):
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+			return nil, err
+		case errors.Is(err, manager.ErrBinaryNotLinked):
+			fmt.Fprintf(g.stdErr, "❌ binary %q not linked, link a local package with gobin link\n", bin.String())
+			return nil, err
+		case err != nil:
+			fmt.Fprintf(g.stdErr, "❌ error getting link for binary %q\n", bin.String())
+			return nil, err
+		}
+
+		links = append(links, linkedBinary{Binary: bin, Source: source})
+	}
+
+	return links, nil
+}
+
+// getOutdatedBinaries checks the given binaries for upgrades, launching go
+// routines up to the given parallelism, and returns the outdated ones. Binaries
+// built without Go modules are skipped. It returns the outdated binaries found
+// along with the first error checking a binary, if any.
+func (g *Gobin) getOutdatedBinaries(
+	ctx context.Context,
+	binInfos []model.BinaryInfo,
+	checkMajor bool,
+	parallelism int,
+) ([]model.BinaryUpgradeInfo, error) {
+	var (
+		mutex    sync.Mutex
+		outdated = make([]model.BinaryUpgradeInfo, 0, len(binInfos))
+		grp      = new(errgroup.Group)
+	)
+
+	grp.SetLimit(parallelism)
+
+	for _, info := range binInfos {
+		grp.Go(func() error {
+			binUpInfo, infoErr := g.binaryManager.GetBinaryUpgradeInfo(
+				ctx, info, checkMajor,
+			)
+			if errors.Is(infoErr, toolchain.ErrBinaryBuiltWithoutGoModules) {
+				return nil
+			} else if infoErr != nil {
+				return infoErr
+			}
+
+			if binUpInfo.IsUpgradeAvailable {
+				mutex.Lock()
+				outdated = append(outdated, binUpInfo)
+				mutex.Unlock()
+			}
+
+			return nil
+		})
+	}
+
+	return outdated, grp.Wait()
+}
+
+// getStrictErr returns ErrWarnings in strict mode if the operation succeeded
+// with warnings, or the error of the operation otherwise.
+func (g *Gobin) getStrictErr(err error, warned bool) error {
+	if err == nil && warned && g.strict {
+		return ErrWarnings
+	}
+
+	return err
+}
+
+// getUpgradableBinaryInfos returns the information of the binaries in the Go
+// binary directory checked for upgrades, skipping the binaries matching the
+// exclude list of the configuration defaults.
+func (g *Gobin) getUpgradableBinaryInfos() ([]model.BinaryInfo, error) {
+	binInfos, err := g.binaryManager.GetAllBinaryInfos(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(binInfos, func(info model.BinaryInfo) bool {
+		return g.config.Defaults.IsExcluded(info.Binary.String())
+	}), nil
+}
+
+// installPackages installs the given packages, launching go routines up to the
+// given parallelism, once they passed the deny list and download size checks.
+// It returns an error if any of the packages cannot be installed.
+func (g *Gobin) installPackages(
+	ctx context.Context,
+	parallelism int,
+	kind model.Kind,
+	flags model.BuildFlags,
+	rebuild bool,
+	universal bool,
+	timings bool,
+	packages ...model.Package,
+) error {
+	flags.Compress = flags.Compress || g.compress
+	grp := new(errgroup.Group)
+	grp.SetLimit(parallelism)
+	opTimings := newOperationTimings()
+	failures := new(operationFailures)
+	warnings := internal.NewWarnings()
+	progress, stopProgress := g.startProgress("installing", len(packages))
+
+	for _, pkg := range packages {
+		grp.Go(func() error {
+			progress.Begin(pkg.GetBinaryName())
+			defer progress.End(pkg.GetBinaryName())
+
+			ctx := internal.WithLogAttrs(
+				ctx,
+				slog.String("operation", "install"),
+				slog.String("package", pkg.Path),
+				slog.String("version", pkg.Version.String()),
+			)
+			ctx = internal.WithWarnings(ctx, warnings)
+			ctx = opTimings.track(ctx, pkg.GetBinaryName())
+			ctx = toolchain.WithProvenance(ctx, model.NewProvenance("install"))
+			start := time.Now()
+
+			var installErr error
+			if g.dryRun {
+				var actions []model.Action
+				if actions, installErr = g.binaryManager.PlanInstallPackage(ctx, pkg, kind, flags); installErr == nil {
+					installErr = g.printPlan("install", pkg.String(), actions)
+				}
+			} else if g.platform != "" {
+				var path string
+				path, installErr = g.binaryManager.InstallPackagePlatform(ctx, pkg, g.platform, flags, rebuild)
+				if installErr == nil {
+					fmt.Fprintf(g.output(), "✅ %s built for %s: %s\n", pkg.String(), g.platform, path)
+				}
+			} else {
+				installErr = g.binaryManager.InstallPackage(ctx, pkg, kind, flags, rebuild, universal, g.cacheFrom)
+			}
+			logOperation(ctx, start, installErr)
+			switch {
+			case errors.Is(installErr, manager.ErrBinaryNameCollision),
+				errors.Is(installErr, manager.ErrUniversalNotSupported):
+				fmt.Fprintf(g.stdErr, "❌ cannot install package %q: %s\n", pkg.String(), installErr)
+			case errors.Is(installErr, toolchain.ErrModuleNotFound):
+				fmt.Fprintf(g.stdErr, "❌ module for package %q not found\n", pkg.String())
+			case errors.Is(installErr, manager.ErrVersionNotAvailable),
+				errors.Is(installErr, manager.ErrRefNotFound),
+				errors.Is(installErr, manager.ErrPackageNotFound),
+				errors.Is(installErr, manager.ErrPackageNotMain):
+				fmt.Fprintf(g.stdErr, "❌ invalid package %q: %s\n", pkg.String(), installErr)
+			}
+
+			if installErr != nil {
+				failures.add(pkg.GetBinaryName(), installErr)
+			}
+
+			return installErr
+		})
+	}
+
+	waitErr := getPartialFailure(grp.Wait(), failures.len(), len(packages))
+	stopProgress()
+
+	if timings {
+		if err := g.printTimings(opTimings); err != nil {
+			return err
+		}
+	}
+
+	g.printWarnings(warnings)
+
+	if err := g.printFailures(failures); err != nil {
+		return err
+	}
+
+	return g.getStrictErr(waitErr, len(warnings.Get()) > 0)
+}
+
+// isSameBinary returns whether the executable at the given path in PATH is the
+// given managed binary: the binary itself, reached through another PATH entry
+// of the same directory, or a symlink to the binary or to its internal binary.
+func (g *Gobin) isSameBinary(path string, info model.BinaryInfo) bool {
+	if filepath.Clean(path) == filepath.Clean(info.FullPath) {
+		return true
+	}
+
+	target, err := g.fs.GetSymlinkTarget(path)
+	if err != nil {
+		return false
+	}
+
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+
+	return target == filepath.Clean(info.FullPath) || target == filepath.Clean(info.InstallPath)
+}
+
+// listStaleGoBinaries lists the given binaries built with a Go version with a
+// newer patch release, carrying security fixes, according to the Go release
+// feed. It prints a template with the stale binaries and the Go version to
+// rebuild them with to the standard output (or another defined io.Writer), or
+// an error if the Go release feed cannot be read. If exitCode is set, the
+// number of stale binaries is printed to the standard error and
+// ErrOutdatedBinaries is returned when some binaries are stale.
+func (g *Gobin) listStaleGoBinaries(ctx context.Context, binInfos []model.BinaryInfo, exitCode bool) error {
+	releases, err := g.binaryManager.GetGoReleases(ctx)
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error getting Go releases")
+		return err
+	}
+
+	stale := make([]model.BinaryGoUpdateInfo, 0, len(binInfos))
+	for _, info := range binInfos {
+		if updateInfo, ok := releases.GetBinaryGoUpdateInfo(info); ok {
+			stale = append(stale, updateInfo)
+		}
+	}
+
+	if exitCode {
+		fmt.Fprintf(g.notice(), "%d of %d binaries built with an outdated Go patch release\n", len(stale), len(binInfos))
+	}
+
+	if len(stale) == 0 {
+		return g.render([]binaryJSON{}, func(w io.Writer) error {
+			fmt.Fprintln(w, "✅ All binaries are built with the latest Go patch releases")
+			return nil
+		})
+	}
+
+	if err = g.printStaleGoBinaries(stale); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(g.notice(), "💡 rebuild them with the latest Go patch releases with gobin upgrade --all --stale-go")
+
+	if exitCode {
+		return ErrOutdatedBinaries
+	}
+
+	return nil
+}
+
+// notice returns the writer for the non-error output written to the standard
+// error, discarding it in quiet mode.
+func (g *Gobin) notice() io.Writer {
+	if g.quiet {
+		return io.Discard
+	}
+
+	return g.stdErr
+}
+
+// output returns the writer for the output written to the standard output,
+// discarding it in quiet mode.
+func (g *Gobin) output() io.Writer {
+	if g.quiet {
+		return io.Discard
+	}
+
+	return g.stdOut
+}
+
+// printBinaryDiagnostics prints the binary diagnostics to the standard output
+// (or another defined io.Writer).
+func (g *Gobin) printBinaryDiagnostics(diags []model.BinaryDiagnostic) error {
+	var diagWithIssues = make([]model.BinaryDiagnostic, 0, len(diags))
+	for _, d := range diags {
+		if d.HasIssues() {
+			diagWithIssues = append(diagWithIssues, d)
+		}
+	}
+
+	sort.Slice(diagWithIssues, func(i, j int) bool {
+		return diagWithIssues[i].Name < diagWithIssues[j].Name
+	})
+
+	data := struct {
+		Total           int
+		WithIssues      int
+		DiagsWithIssues []model.BinaryDiagnostic
+	}{
+		Total:           len(diags),
+		WithIssues:      len(diagWithIssues),
+		DiagsWithIssues: diagWithIssues,
+	}
+
+	diagsJSON := diagnosticsJSON{
+		Total:      len(diags),
+		WithIssues: len(diagWithIssues),
+		Binaries:   make([]diagnosticJSON, len(diagWithIssues)),
+	}
+	for i, diag := range diagWithIssues {
+		diagsJSON.Binaries[i] = newDiagnosticJSON(diag)
+	}
+
+	tmplParsed := template.Must(template.New("doctor").Parse(doctorTemplate))
+	return g.render(diagsJSON, func(w io.Writer) error {
+		if err := tmplParsed.Execute(w, data); err != nil {
+			slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// printBinaryDiff prints the differences between two builds of a binary to the
+// standard output (or another defined io.Writer), highlighting the added build
+// settings and dependencies in green and the removed ones in red.
+func (g *Gobin) printBinaryDiff(name string, from, to model.BinaryBuild) error {
+	formatSize := func(size model.ByteSize) string {
+		if size == 0 {
+			return "<unknown>"
+		}
+		return size.String()
+	}
+
+	var settings []model.BuildChange
+	if to.Settings != nil {
+		settings = from.DiffSettings(to)
+	}
+
+	data := struct {
+		Name     string
+		From     model.BinaryBuild
+		To       model.BinaryBuild
+		FromSize string
+		ToSize   string
+		Settings []model.BuildChange
+		Deps     []model.BuildChange
+	}{
+		Name:     name,
+		From:     from,
+		To:       to,
+		FromSize: formatSize(from.Size),
+		ToSize:   formatSize(to.Size),
+		Settings: settings,
+		Deps:     from.DiffDeps(to),
+	}
+
+	tmplParsed := template.Must(template.New("diff").Funcs(template.FuncMap{
+		"color": colorize,
+	}).Parse(diffTemplate))
+
+	if err := tmplParsed.Execute(g.output(), data); err != nil {
+		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// printBinaries prints the binaries to the standard output (or another defined
+// io.Writer). If managed is false, it prints the installed binaries, highlighting
+// the managed binaries in green and labeling the binaries built for the given
+// cross operating system. If managed is true, it prints the managed binaries,
+// highlighting the pinned binaries in green.
+func (g *Gobin) printBinaries(binInfos []model.BinaryInfo, managed bool, crossOS string) error {
+	sort.Slice(binInfos, func(i, j int) bool {
+		if binInfos[i].Binary.Name != binInfos[j].Binary.Name {
+			return binInfos[i].Binary.Name < binInfos[j].Binary.Name
+		}
+
+		return binInfos[i].Module.Version.Compare(binInfos[j].Module.Version) > 0
 	})
 
 	maxNameWidth := getColumnMaxWidth(
@@ -583,93 +4668,849 @@ func (g *Gobin) printBinaries(binInfos []model.BinaryInfo, managed bool) error {
 		func(bin model.BinaryInfo) string { return bin.Module.Version.String() },
 	)
 
-	data := struct {
-		Binaries           []model.BinaryInfo
-		NameWidth          int
-		ModulePathWidth    int
-		ModuleVersionWidth int
-	}{
-		Binaries:           binInfos,
-		NameWidth:          maxNameWidth,
-		ModulePathWidth:    maxModulePathWidth,
-		ModuleVersionWidth: maxModuleVersionWidth,
+	data := struct {
+		Binaries           []model.BinaryInfo
+		CrossOS            string
+		NameWidth          int
+		ModulePathWidth    int
+		ModuleVersionWidth int
+	}{
+		Binaries:           binInfos,
+		CrossOS:            crossOS,
+		NameWidth:          maxNameWidth,
+		ModulePathWidth:    maxModulePathWidth,
+		ModuleVersionWidth: maxModuleVersionWidth,
+	}
+
+	tmpl := listInstalledTemplate
+	if managed {
+		tmpl = listManagedTemplate
+	}
+
+	tmplParsed := template.Must(template.New("list").Funcs(template.FuncMap{
+		"add":    add,
+		"color":  colorize,
+		"repeat": strings.Repeat,
+	}).Parse(tmpl))
+
+	binsJSON := make([]binaryJSON, len(binInfos))
+	for i, info := range binInfos {
+		binsJSON[i] = newBinaryJSON(info)
+	}
+
+	return g.render(binsJSON, func(w io.Writer) error {
+		if err := tmplParsed.Execute(w, data); err != nil {
+			slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// printFailures prints the summary of the given operation failures to the
+// standard error (or another defined io.Writer), sorted by binary or package,
+// when several operations failed, so the failures are not lost in the
+// interleaved output of the operations run in parallel.
+func (g *Gobin) printFailures(failures *operationFailures) error {
+	if failures.len() < 2 {
+		return nil
+	}
+
+	slices.SortFunc(failures.failures, func(a, b operationFailure) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	tmplParsed := template.Must(template.New("failures").Parse(failuresTemplate))
+	if err := tmplParsed.Execute(g.stdErr, failures.failures); err != nil {
+		slog.Default().Error("error executing template", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// printMigrateDryRun prints which of the given binaries would be migrated,
+// which are already managed, and which would fail to the standard output (or
+// another defined io.Writer). It returns an error if the template cannot be
+// executed.
+func (g *Gobin) printMigrateDryRun(binPaths []string) error {
+	type entry struct {
+		Name   string
+		Target string
+		Reason string
+	}
+
+	var migrate, managed, failed []entry
+	for _, path := range binPaths {
+		name := filepath.Base(path)
+
+		info, err := g.binaryManager.GetBinaryInfo(path)
+		switch {
+		case errors.Is(err, toolchain.ErrBinaryNotFound):
+			failed = append(failed, entry{Name: name, Reason: "not found"})
+		case errors.Is(err, toolchain.ErrBinaryBuiltWithoutGoModules):
+			failed = append(failed, entry{Name: name, Reason: "built without Go modules"})
+		case err != nil:
+			failed = append(failed, entry{Name: name, Reason: "no build info available"})
+		case info.IsManaged:
+			managed = append(managed, entry{Name: name})
+		default:
+			bin := model.NewBinary(info.Binary.Name, info.Module.Version, filepath.Ext(path))
+			migrate = append(migrate, entry{Name: name, Target: bin.String()})
+		}
+	}
+
+	data := struct {
+		Total   int
+		Migrate []entry
+		Managed []entry
+		Failed  []entry
+	}{
+		Total:   len(binPaths),
+		Migrate: migrate,
+		Managed: managed,
+		Failed:  failed,
+	}
+
+	tmplParsed := template.Must(template.New("migrate").Parse(migrateDryRunTemplate))
+	if err := tmplParsed.Execute(g.output(), data); err != nil {
+		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// printOutdatedBinaries prints the outdated binaries to the standard output
+// (or another defined io.Writer).
+func (g *Gobin) printOutdatedBinaries(binInfos []model.BinaryUpgradeInfo) error {
+	sort.Slice(binInfos, func(i, j int) bool {
+		return binInfos[i].Binary.Name < binInfos[j].Binary.Name
+	})
+
+	maxNameWidth := getColumnMaxWidth(
+		"Name",
+		binInfos,
+		func(bin model.BinaryUpgradeInfo) string { return bin.Binary.Name },
+	)
+	maxModulePathWidth := getColumnMaxWidth(
+		"Module",
+		binInfos,
+		func(bin model.BinaryUpgradeInfo) string { return bin.Module.Path },
+	)
+	maxModuleVersionWidth := getColumnMaxWidth(
+		"Current",
+		binInfos,
+		func(bin model.BinaryUpgradeInfo) string { return bin.Module.Version.String() },
+	)
+	maxLatestVersionWidth := getColumnMaxWidth(
+		"Latest",
+		binInfos,
+		func(bin model.BinaryUpgradeInfo) string { return bin.LatestModule.Version.String() },
+	)
+
+	data := struct {
+		Binaries           []model.BinaryUpgradeInfo
+		NameWidth          int
+		ModulePathWidth    int
+		ModuleVersionWidth int
+		LatestVersionWidth int
+	}{
+		Binaries:           binInfos,
+		NameWidth:          maxNameWidth,
+		ModulePathWidth:    maxModulePathWidth,
+		ModuleVersionWidth: maxModuleVersionWidth,
+		LatestVersionWidth: maxLatestVersionWidth,
+	}
+
+	tmplParsed := template.Must(template.New("outdated").Funcs(template.FuncMap{
+		"add":    add,
+		"color":  colorize,
+		"repeat": strings.Repeat,
+	}).Parse(outdatedTemplate))
+
+	binsJSON := make([]binaryJSON, len(binInfos))
+	for i, info := range binInfos {
+		binsJSON[i] = newBinaryJSON(info.BinaryInfo)
+		binsJSON[i].LatestVersion = info.LatestModule.Version
+	}
+
+	return g.render(binsJSON, func(w io.Writer) error {
+		if err := tmplParsed.Execute(w, data); err != nil {
+			slog.Default().Error("error executing template", "err", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// printPinVersionsErr prints to the standard error (or another defined
+// io.Writer) the error of listing the versions of the given pin.
+func (g *Gobin) printPinVersionsErr(bin model.Binary, err error) {
+	switch {
+	case errors.Is(err, toolchain.ErrBinaryNotFound):
+		fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+	case errors.Is(err, manager.ErrBinaryNotManaged):
+		fmt.Fprintf(g.stdErr, "❌ binary %q not managed\n", bin.String())
+	default:
+		fmt.Fprintf(g.stdErr, "❌ error listing versions of binary %q\n", bin.String())
+	}
+}
+
+// printPlan prints the actions the given operation on a binary or package
+// would take in dry-run mode to the standard output (or another defined
+// io.Writer), in a single write so the plans of the operations run in parallel
+// do not interleave.
+func (g *Gobin) printPlan(operation, name string, actions []model.Action) error {
+	data := struct {
+		Operation string
+		Name      string
+		Actions   []model.Action
+	}{
+		Operation: operation,
+		Name:      name,
+		Actions:   actions,
+	}
+
+	var sb strings.Builder
+	tmplParsed := template.Must(template.New("plan").Parse(planTemplate))
+	if err := tmplParsed.Execute(&sb, data); err != nil {
+		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+		return err
+	}
+
+	_, err := io.WriteString(g.output(), sb.String())
+	return err
+}
+
+// printSizeHistory prints the size history of the given binary to the standard
+// output (or another defined io.Writer), with a sparkline of the sizes and the
+// change of each size from the previous one.
+func (g *Gobin) printSizeHistory(bin model.Binary) error {
+	history, err := g.binaryManager.GetBinarySizeHistory(bin)
+	if err != nil {
+		if errors.Is(err, toolchain.ErrBinaryNotFound) {
+			fmt.Fprintf(g.stdErr, "❌ binary %q not found\n", bin.String())
+		} else {
+			fmt.Fprintf(g.stdErr, "❌ error getting size history for binary %q\n", bin.String())
+		}
+
+		return err
+	}
+
+	if len(history) == 0 {
+		fmt.Fprintf(g.notice(), "💡 no size history recorded for %q, recorded from its next install or upgrade\n",
+			bin.String())
+		return nil
+	}
+
+	type sizeRow struct {
+		Version string
+		Size    string
+		Change  string
+		Color   string
+	}
+
+	rows := make([]sizeRow, len(history))
+	for i, record := range history {
+		rows[i] = sizeRow{Version: record.Version.String(), Size: record.Size.String()}
+		if i == 0 || history[i-1].Size == 0 {
+			continue
+		}
+
+		previous := history[i-1].Size
+		rows[i].Change = fmt.Sprintf("%+.1f%%", float64(record.Size-previous)*100/float64(previous))
+
+		switch {
+		case record.Size > previous:
+			rows[i].Color = "red"
+		case record.Size < previous:
+			rows[i].Color = "green"
+		}
+	}
+
+	data := struct {
+		Name         string
+		Sparkline    string
+		Rows         []sizeRow
+		VersionWidth int
+		SizeWidth    int
+		ChangeWidth  int
+	}{
+		Name:         bin.String(),
+		Sparkline:    history.Sparkline(),
+		Rows:         rows,
+		VersionWidth: getColumnMaxWidth("Version", rows, func(row sizeRow) string { return row.Version }),
+		SizeWidth:    getColumnMaxWidth("Size", rows, func(row sizeRow) string { return row.Size }),
+		ChangeWidth:  getColumnMaxWidth("Change", rows, func(row sizeRow) string { return row.Change }),
+	}
+
+	tmplParsed := template.Must(template.New("size-history").Funcs(template.FuncMap{
+		"add":    add,
+		"color":  colorize,
+		"repeat": strings.Repeat,
+	}).Parse(sizeHistoryTemplate))
+
+	if err = tmplParsed.Execute(g.output(), data); err != nil {
+		slog.Default().Error("error executing template", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// printStaleGoBinaries prints the binaries built with a Go version with a newer
+// patch release to the standard output (or another defined io.Writer), with the
+// Go version they are built with in red and the one to rebuild them with in
+// green.
+func (g *Gobin) printStaleGoBinaries(binInfos []model.BinaryGoUpdateInfo) error {
+	sort.Slice(binInfos, func(i, j int) bool {
+		return binInfos[i].Binary.Name < binInfos[j].Binary.Name
+	})
+
+	data := struct {
+		Binaries             []model.BinaryGoUpdateInfo
+		NameWidth            int
+		GoVersionWidth       int
+		LatestGoVersionWidth int
+	}{
+		Binaries: binInfos,
+		NameWidth: getColumnMaxWidth(
+			"Name", binInfos, func(bin model.BinaryGoUpdateInfo) string { return bin.Binary.Name },
+		),
+		GoVersionWidth: getColumnMaxWidth(
+			"Go", binInfos, func(bin model.BinaryGoUpdateInfo) string { return bin.GoVersion },
+		),
+		LatestGoVersionWidth: getColumnMaxWidth(
+			"Latest", binInfos, func(bin model.BinaryGoUpdateInfo) string { return bin.LatestGoVersion },
+		),
+	}
+
+	tmplParsed := template.Must(template.New("outdated-go").Funcs(template.FuncMap{
+		"add":    add,
+		"color":  colorize,
+		"repeat": strings.Repeat,
+	}).Parse(outdatedGoTemplate))
+
+	binsJSON := make([]binaryJSON, len(binInfos))
+	for i, info := range binInfos {
+		binsJSON[i] = newBinaryJSON(info.BinaryInfo)
+		binsJSON[i].LatestGoVersion = info.LatestGoVersion
+	}
+
+	return g.render(binsJSON, func(w io.Writer) error {
+		if err := tmplParsed.Execute(w, data); err != nil {
+			slog.Default().Error("error executing template", "err", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// printTimings prints the summary of the given operation timings to the
+// standard error (or another defined io.Writer), with the wall time spent per
+// phase for each binary or package and in aggregate.
+func (g *Gobin) printTimings(opTimings *operationTimings) error {
+	type timingsRow struct {
+		Name      string
+		Durations []string
+		Total     string
+	}
+
+	phases := internal.GetPhases()
+	names := slices.Sorted(maps.Keys(opTimings.timings))
+	total := internal.NewTimings()
+
+	rows := make([]timingsRow, 0, len(names))
+	for _, name := range names {
+		timings := opTimings.timings[name]
+
+		row := timingsRow{Name: name, Total: formatDuration(timings.Total())}
+		for _, phase := range phases {
+			row.Durations = append(row.Durations, formatDuration(timings.Get(phase)))
+			total.Add(phase, timings.Get(phase))
+		}
+
+		rows = append(rows, row)
+	}
+
+	totalRow := timingsRow{Name: "Total", Total: formatDuration(total.Total())}
+	for _, phase := range phases {
+		totalRow.Durations = append(totalRow.Durations, formatDuration(total.Get(phase)))
+	}
+
+	maxNameWidth := getColumnMaxWidth("Total", names, func(name string) string { return name })
+
+	data := struct {
+		Rows       []timingsRow
+		Total      timingsRow
+		Phases     []internal.Phase
+		NameWidth  int
+		PhaseWidth int
+		LineWidth  int
+	}{
+		Rows:       rows,
+		Total:      totalRow,
+		Phases:     phases,
+		NameWidth:  maxNameWidth,
+		PhaseWidth: timingsPhaseWidth,
+		LineWidth:  maxNameWidth + (len(phases)+1)*(timingsPhaseWidth+2),
+	}
+
+	tmplParsed := template.Must(template.New("timings").Funcs(template.FuncMap{
+		"repeat": strings.Repeat,
+	}).Parse(timingsTemplate))
+
+	if err := tmplParsed.Execute(g.notice(), data); err != nil {
+		slog.Default().Error("error executing template", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// printWarnings prints the given warnings to the standard error (or another
+// defined io.Writer). They are discarded in quiet mode, unless strict mode
+// promotes them to failures.
+func (g *Gobin) printWarnings(warnings *internal.Warnings) {
+	w := g.notice()
+	if g.strict {
+		w = g.stdErr
+	}
+
+	for _, warning := range warnings.Get() {
+		fmt.Fprintf(w, "⚠️  %s\n", warning)
+	}
+}
+
+// pruneBinary prunes the given binary from the internal binary directory, or
+// prints the actions pruning it would take in dry-run mode.
+func (g *Gobin) pruneBinary(bin model.Binary, force bool) error {
+	if !g.dryRun {
+		return g.binaryManager.PruneBinary(bin, force)
+	}
+
+	actions, err := g.binaryManager.PlanPruneBinary(bin, force)
+	if err != nil {
+		return err
+	}
+
+	return g.printPlan("prune", bin.String(), actions)
+}
+
+// relinkBinary rebuilds the given linked binary from its local directory,
+// printing the result to the standard output (or another defined io.Writer), or
+// the error to the standard error if the binary cannot be rebuilt.
+func (g *Gobin) relinkBinary(ctx context.Context, link linkedBinary) error {
+	warnings := internal.NewWarnings()
+
+	ctx = internal.WithLogAttrs(
+		ctx,
+		slog.String("operation", "relink"),
+		slog.String("binary", link.Binary.String()),
+		slog.String("dir", link.Source),
+	)
+	ctx = internal.WithWarnings(ctx, warnings)
+	ctx = toolchain.WithProvenance(ctx, model.NewProvenance("relink"))
+	start := time.Now()
+
+	err := g.binaryManager.RelinkBinary(ctx, link.Binary, model.BuildFlags{Compress: g.compress})
+	logOperation(ctx, start, err)
+	g.printWarnings(warnings)
+	if err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error relinking binary %q from %s: %s\n", link.Binary.String(), link.Source, err)
+		return err
 	}
 
-	tmpl := listInstalledTemplate
-	if managed {
-		tmpl = listManagedTemplate
-	}
+	fmt.Fprintf(g.output(), "✅ %s relinked from %s\n", link.Binary.String(), link.Source)
 
-	tmplParsed := template.Must(template.New("list").Funcs(template.FuncMap{
-		"add":    add,
-		"color":  colorize,
-		"repeat": strings.Repeat,
-	}).Parse(tmpl))
+	return nil
+}
 
-	if err := tmplParsed.Execute(g.stdOut, data); err != nil {
-		slog.Default().Error("error executing template", "template", tmplParsed.Name(), "err", err)
+// render writes the output of a command to the standard output (or another
+// defined io.Writer): the given value as indented JSON in JSON mode, or the
+// output written by the given function, ex. a template, otherwise.
+func (g *Gobin) render(value any, human func(w io.Writer) error) error {
+	if !g.asJSON {
+		return human(g.output())
+	}
+
+	encoder := json.NewEncoder(g.output())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		slog.Default().Error("error encoding json", "err", err)
 		return err
 	}
 
 	return nil
 }
 
-// printOutdatedBinaries prints the outdated binaries to the standard output
-// (or another defined io.Writer).
-func (g *Gobin) printOutdatedBinaries(binInfos []model.BinaryUpgradeInfo) error {
-	sort.Slice(binInfos, func(i, j int) bool {
-		return binInfos[i].Binary.Name < binInfos[j].Binary.Name
-	})
+// resolvePackages resolves the versions of the given packages to the module
+// versions they would be installed at. Without packages, it resolves the
+// packages of the managed binaries at their installed versions, skipping the
+// binaries not built from a released module version. It prints an error to the
+// standard error (or another defined io.Writer) and returns it if a package is
+// denied by the configuration or cannot be resolved.
+func (g *Gobin) resolvePackages(ctx context.Context, pkgs ...model.Package) ([]model.Package, error) {
+	if len(pkgs) == 0 {
+		binInfos, err := g.binaryManager.GetAllBinaryInfos(true)
+		if err != nil {
+			fmt.Fprintln(g.stdErr, "❌ error listing binaries")
+			return nil, err
+		}
 
-	maxNameWidth := getColumnMaxWidth(
-		"Name",
-		binInfos,
-		func(bin model.BinaryUpgradeInfo) string { return bin.Binary.Name },
-	)
-	maxModulePathWidth := getColumnMaxWidth(
-		"Module",
-		binInfos,
-		func(bin model.BinaryUpgradeInfo) string { return bin.Module.Path },
-	)
-	maxModuleVersionWidth := getColumnMaxWidth(
-		"Current",
-		binInfos,
-		func(bin model.BinaryUpgradeInfo) string { return bin.Module.Version.String() },
+		for _, binInfo := range binInfos {
+			if binInfo.PackagePath == "" || !binInfo.Module.Version.IsValid() {
+				continue
+			}
+
+			pkg := model.NewPackageWithVersion(binInfo.PackagePath, binInfo.Module.Version)
+			if !slices.Contains(pkgs, pkg) {
+				pkgs = append(pkgs, pkg)
+			}
+		}
+	}
+
+	resolvedPkgs := make([]model.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if rule, ok := g.config.GetDenyRule(pkg); ok {
+			fmt.Fprintf(g.stdErr, "❌ package %q denied by policy rule %q\n", pkg.Path, rule)
+			return nil, ErrPackageDenied
+		}
+
+		resolvedPkg, err := g.binaryManager.ResolvePackage(ctx, pkg)
+		if err != nil {
+			switch {
+			case errors.Is(err, toolchain.ErrModuleNotFound):
+				fmt.Fprintf(g.stdErr, "❌ module for package %q not found\n", pkg.String())
+			case errors.Is(err, manager.ErrVersionNotAvailable),
+				errors.Is(err, manager.ErrRefNotFound),
+				errors.Is(err, manager.ErrPackageNotFound),
+				errors.Is(err, manager.ErrPackageNotMain):
+				fmt.Fprintf(g.stdErr, "❌ invalid package %q: %s\n", pkg.String(), err)
+			default:
+				fmt.Fprintf(g.stdErr, "❌ error resolving package %q\n", pkg.String())
+			}
+
+			return nil, err
+		}
+
+		resolvedPkgs = append(resolvedPkgs, resolvedPkg)
+	}
+
+	return resolvedPkgs, nil
+}
+
+// selectPinVersion prints the given versions of a pin as a numbered list to
+// the standard output (or another defined io.Writer) and reads the number of
+// the version to select from the standard input (or another defined
+// io.Reader). It returns false if no version is selected, or
+// ErrInvalidSelection if the answer is not a listed number.
+func (g *Gobin) selectPinVersion(bin model.Binary, versions []model.PinVersion) (model.Version, bool, error) {
+	if len(versions) == 0 {
+		fmt.Fprintf(g.stdOut, "no versions of binary %q installed\n", bin.String())
+		return "", false, nil
+	}
+
+	for i, version := range versions {
+		if version.Pinned {
+			fmt.Fprintf(g.stdOut, "%3d) %s (pinned)\n", i+1, version.Version.String())
+		} else {
+			fmt.Fprintf(g.stdOut, "%3d) %s\n", i+1, version.Version.String())
+		}
+	}
+
+	fmt.Fprintf(g.stdOut, "Select a version of binary %q [1-%d]: ", bin.String(), len(versions))
+
+	answer, err := g.stdIn.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", false, err
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		fmt.Fprintln(g.stdOut, "no version selected")
+		return "", false, nil
+	}
+
+	idx, err := strconv.Atoi(answer)
+	if err != nil || idx < 1 || idx > len(versions) {
+		fmt.Fprintf(g.stdErr, "❌ invalid selection %q\n", answer)
+		return "", false, ErrInvalidSelection
+	}
+
+	return versions[idx-1].Version, true, nil
+}
+
+// startProgress starts rendering the progress of the given action on the given
+// total of items, when enabled with SetProgress, not in quiet mode, and for
+// several items. The standard output and error are written above the progress
+// line until the returned function is called, stopping the progress. The
+// returned progress is nil, rendering nothing, when not started.
+func (g *Gobin) startProgress(action string, total int) (*internal.Progress, func()) {
+	if !g.progress || g.quiet || total < 2 {
+		return nil, func() {}
+	}
+
+	stdErr, stdOut := g.stdErr, g.stdOut
+
+	progress := internal.NewProgress(stdErr, action, total)
+	g.stdErr, g.stdOut = progress.Writer(stdErr), progress.Writer(stdOut)
+	progress.Start(internal.ProgressInterval)
+
+	return progress, func() {
+		progress.Stop()
+		g.stdErr, g.stdOut = stdErr, stdOut
+	}
+}
+
+// uninstallBinary uninstalls the given binary, or prints the actions
+// uninstalling it would take in dry-run mode.
+func (g *Gobin) uninstallBinary(bin model.Binary, force, purge bool) error {
+	if !g.dryRun {
+		return g.binaryManager.UninstallBinary(bin, force, purge)
+	}
+
+	actions, err := g.binaryManager.PlanUninstallBinary(bin, force, purge)
+	if err != nil {
+		return err
+	}
+
+	return g.printPlan("uninstall", bin.String(), actions)
+}
+
+// uninstallRelatedPins warns about the pins still referencing the same binary
+// as the given uninstalled binary and, once confirmed (or if assumeYes is set),
+// uninstalls them. It returns an error if the confirmation cannot be read or
+// any of the pins cannot be uninstalled.
+func (g *Gobin) uninstallRelatedPins(
+	bin model.Binary,
+	pins []model.Binary,
+	force, assumeYes bool,
+) error {
+	names := make([]string, 0, len(pins))
+	for _, pin := range pins {
+		names = append(names, pin.String())
+	}
+
+	fmt.Fprintf(
+		g.stdErr, "⚠️  binary %q is still referenced by pins: %s\n",
+		bin, strings.Join(names, ", "),
 	)
-	maxLatestVersionWidth := getColumnMaxWidth(
-		"Latest",
-		binInfos,
-		func(bin model.BinaryUpgradeInfo) string { return bin.LatestModule.Version.String() },
+
+	if !assumeYes {
+		confirmed, err := g.confirm("Do you want to uninstall them too?")
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+
+	var err error
+	for _, pin := range pins {
+		removeErr := g.binaryManager.UninstallBinary(pin, force, false)
+		if errors.Is(removeErr, manager.ErrBinaryProtected) {
+			fmt.Fprintf(g.notice(), "🔒 skipping protected binary %q\n", pin)
+		} else if removeErr != nil {
+			fmt.Fprintf(g.stdErr, "❌ error uninstalling binary %q\n", pin)
+			err = removeErr
+		}
+	}
+
+	return err
+}
+
+// updateConfig applies the update to the data of the configuration file, an
+// empty file if it does not exist, and writes the updated data back, creating
+// its directory if needed. It returns the configuration file path, or an error
+// if the file cannot be read, updated or written.
+func (g *Gobin) updateConfig(update func(data []byte) ([]byte, error)) (string, error) {
+	path := g.workspace.GetInternalConfigPath()
+
+	data, err := g.fs.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(g.stdErr, "❌ error reading config %s\n", path)
+		return "", err
+	}
+
+	if data, err = update(data); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ invalid config: %s\n", err.Error())
+		return "", err
+	}
+
+	//nolint:mnd // owner only permissions
+	if err = g.fs.CreateDir(filepath.Dir(path), 0700); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error writing config %s\n", path)
+		return "", err
+	}
+
+	//nolint:mnd // file permissions
+	if err = g.fs.WriteFile(path, data, 0644); err != nil {
+		fmt.Fprintf(g.stdErr, "❌ error writing config %s\n", path)
+		return "", err
+	}
+
+	return path, nil
+}
+
+// watchBinaries checks the binaries in the Go binary directory for upgrades
+// and known vulnerabilities, and reports the binaries needing action with a
+// desktop notification. The binaries failing to be checked are skipped, and
+// nothing is reported if the context is canceled during the check. It returns
+// an error if the binaries cannot be listed.
+func (g *Gobin) watchBinaries(ctx context.Context, parallelism int) error {
+	binInfos, err := g.binaryManager.GetAllBinaryInfos(false)
+	if err != nil {
+		fmt.Fprintln(g.stdErr, "❌ error listing binaries")
+		return err
+	}
+
+	var (
+		mutex      sync.Mutex
+		outdated   []string
+		vulnerable []string
+		failures   = new(operationFailures)
+		grp        = new(errgroup.Group)
 	)
 
-	data := struct {
-		Binaries           []model.BinaryUpgradeInfo
-		NameWidth          int
-		ModulePathWidth    int
-		ModuleVersionWidth int
-		LatestVersionWidth int
-	}{
-		Binaries:           binInfos,
-		NameWidth:          maxNameWidth,
-		ModulePathWidth:    maxModulePathWidth,
-		ModuleVersionWidth: maxModuleVersionWidth,
-		LatestVersionWidth: maxLatestVersionWidth,
+	grp.SetLimit(parallelism)
+
+	for _, info := range binInfos {
+		grp.Go(func() error {
+			ctx := internal.WithLogAttrs(
+				ctx, slog.String("operation", "watch"), slog.String("binary", info.Binary.Name),
+			)
+
+			binUpInfo, upErr := g.binaryManager.GetBinaryUpgradeInfo(ctx, info, false)
+			if errors.Is(upErr, toolchain.ErrBinaryBuiltWithoutGoModules) {
+				return nil
+			}
+
+			diag, diagErr := g.binaryManager.DiagnoseBinary(ctx, info.FullPath)
+			if checkErr := errors.Join(upErr, diagErr); checkErr != nil {
+				failures.add(info.Binary.Name, checkErr)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if upErr == nil && binUpInfo.IsUpgradeAvailable {
+				outdated = append(outdated, info.Binary.Name)
+			}
+
+			if diagErr == nil && len(diag.Vulnerabilities) > 0 {
+				vulnerable = append(vulnerable, info.Binary.Name)
+			}
+
+			return nil
+		})
 	}
 
-	tmplParsed := template.Must(template.New("outdated").Funcs(template.FuncMap{
-		"add":    add,
-		"color":  colorize,
-		"repeat": strings.Repeat,
-	}).Parse(outdatedTemplate))
+	_ = grp.Wait()
 
-	if err := tmplParsed.Execute(g.stdOut, data); err != nil {
-		slog.Default().Error("error executing template", "err", err)
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	failed := failures.len()
+	if failed > 0 {
+		fmt.Fprintf(g.notice(), "⚠️  %d of %d binaries failed to be checked, see the log file\n", failed, len(binInfos))
+	}
+
+	if len(outdated) == 0 && len(vulnerable) == 0 {
+		if failed == 0 {
+			fmt.Fprintln(g.output(), "✅ All binaries are up to date and free of known vulnerabilities")
+		}
+
+		return nil
+	}
+
+	var lines []string
+	if len(outdated) > 0 {
+		slices.Sort(outdated)
+		lines = append(lines, fmt.Sprintf("outdated binaries (%d): %s", len(outdated), strings.Join(outdated, ", ")))
+	}
+
+	if len(vulnerable) > 0 {
+		slices.Sort(vulnerable)
+		lines = append(lines, fmt.Sprintf(
+			"binaries with known vulnerabilities (%d): %s", len(vulnerable), strings.Join(vulnerable, ", "),
+		))
+	}
+
+	for _, line := range lines {
+		fmt.Fprintf(g.output(), "🔔 %s\n", line)
+	}
+
+	if err = g.resource.Notify(ctx, "gobin: action needed", strings.Join(lines, "\n")); err != nil {
+		fmt.Fprintf(g.notice(), "⚠️  cannot raise a desktop notification: %s\n", err.Error())
+	}
+
+	return nil
+}
+
+// writeProfileBlock writes the given block, starting with the begin marker and
+// ending with the end marker, to the given shell profile. The block between
+// the markers is replaced if present, otherwise the block is appended to the
+// profile, created if it does not exist. It returns an error if the profile
+// cannot be read or written.
+func (g *Gobin) writeProfileBlock(profile, block, beginMarker, endMarker string) error {
+	logger := slog.Default().With("profile", profile)
+
+	content, err := g.fs.ReadFile(profile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Error("error reading shell profile", "err", err)
+		return err
+	}
+
+	text := string(content)
+	begin := strings.Index(text, beginMarker)
+	end := strings.Index(text, endMarker)
+
+	if begin >= 0 && end > begin {
+		text = text[:begin] + block + strings.TrimPrefix(text[end+len(endMarker):], "\n")
+	} else {
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+
+		if text != "" {
+			text += "\n"
+		}
+
+		text += block
+	}
+
+	//nolint:mnd // directory permissions
+	if err = g.fs.CreateDir(filepath.Dir(profile), 0755); err != nil {
+		logger.Error("error creating shell profile dir", "err", err)
+		return err
+	}
+
+	//nolint:mnd // file permissions
+	if err = g.fs.WriteFile(profile, []byte(text), 0644); err != nil {
+		logger.Error("error writing shell profile", "err", err)
 		return err
 	}
 
 	return nil
 }
 
+// logOperation logs the end of the operation started at the given time, with
+// its duration and error if any. The operation is identified by the attributes
+// carried by the given context. Failures are logged at the info level, as they
+// are already reported to the user.
+func logOperation(ctx context.Context, start time.Time, err error) {
+	if err != nil {
+		slog.Default().InfoContext(ctx, "operation failed", "duration", time.Since(start), "err", err)
+		return
+	}
+
+	slog.Default().InfoContext(ctx, "operation completed", "duration", time.Since(start))
+}
+
 // add adds the given integers.
 func add(args ...int) int {
 	sum := 0
@@ -689,6 +5530,28 @@ func colorize(s, color string) string {
 	return colors[color] + s + colors["reset"]
 }
 
+// formatDuration formats the given duration in seconds, rounded to tenths.
+func formatDuration(duration time.Duration) string {
+	return fmt.Sprintf("%.1fs", duration.Seconds())
+}
+
+// getBugReportEnv returns the environment variables for a bug report, keeping
+// the relevant ones from the given list, sorted and redacted.
+func getBugReportEnv(envVars []string) []byte {
+	var b strings.Builder
+	for _, envVar := range slices.Sorted(slices.Values(envVars)) {
+		name, _, _ := strings.Cut(envVar, "=")
+		if slices.ContainsFunc(bugReportEnvVarPrefixes, func(prefix string) bool {
+			return strings.HasPrefix(strings.ToUpper(name), prefix)
+		}) {
+			b.WriteString(model.RedactEnvVar(envVar))
+			b.WriteString("\n")
+		}
+	}
+
+	return []byte(b.String())
+}
+
 // getColumnMaxWidth gets the maximum width of a column for a given header and
 // items.
 func getColumnMaxWidth[T any](header string, items []T, accessor func(T) string) int {
@@ -700,3 +5563,89 @@ func getColumnMaxWidth[T any](header string, items []T, accessor func(T) string)
 	}
 	return maxWidth
 }
+
+// getLogTail returns the last lines of the given logs, up to the given size.
+func getLogTail(logs []byte, size int) []byte {
+	if len(logs) <= size {
+		return logs
+	}
+
+	tail := logs[len(logs)-size:]
+	if i := strings.IndexByte(string(tail), '\n'); i >= 0 {
+		tail = tail[i+1:]
+	}
+
+	return tail
+}
+
+// getOperationRecords returns the last operation records of the given logs, up
+// to the given maximum.
+func getOperationRecords(logs []byte, maxRecords int) []byte {
+	var records []string
+	for line := range strings.Lines(string(logs)) {
+		if strings.Contains(line, `msg="operation completed"`) ||
+			strings.Contains(line, `msg="operation failed"`) {
+			records = append(records, line)
+		}
+	}
+
+	if len(records) > maxRecords {
+		records = records[len(records)-maxRecords:]
+	}
+
+	return []byte(strings.Join(records, ""))
+}
+
+// getPartialFailure returns the given error of an operation run on the given
+// total of binaries or packages, wrapped in ErrPartialFailure if only some of
+// them failed.
+func getPartialFailure(err error, failed, total int) error {
+	if err != nil && failed < total {
+		return fmt.Errorf("%w: %w", ErrPartialFailure, err)
+	}
+
+	return err
+}
+
+// parseSelection parses the answer to a selection among the given total of
+// items: "all", or item numbers and ranges, ex. "1 3-5", separated by spaces or
+// commas. It returns the zero-based indexes of the selected items, in
+// ascending order and without duplicates, or false if the answer is not
+// valid.
+func parseSelection(answer string, total int) ([]int, bool) {
+	if strings.EqualFold(answer, "all") {
+		indexes := make([]int, total)
+		for i := range indexes {
+			indexes[i] = i
+		}
+
+		return indexes, true
+	}
+
+	var indexes []int
+	for _, field := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		from, to, isRange := strings.Cut(field, "-")
+		if !isRange {
+			to = from
+		}
+
+		first, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, false
+		}
+
+		last, err := strconv.Atoi(to)
+		if err != nil || first < 1 || last < first || last > total {
+			return nil, false
+		}
+
+		for idx := first - 1; idx < last; idx++ {
+			if !slices.Contains(indexes, idx) {
+				indexes = append(indexes, idx)
+			}
+		}
+	}
+
+	slices.Sort(indexes)
+	return indexes, len(indexes) > 0
+}
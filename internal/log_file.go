@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const (
+	// LogFileBackups is the number of rotated log files kept.
+	LogFileBackups = 3
+	// LogFileMaxSize is the size in bytes from which the log file is rotated.
+	LogFileMaxSize = 5 << 20
+)
+
+// RotatingFile is a log file rotated when it reaches a maximum size, keeping a
+// number of rotated files suffixed from .1 (the most recent) to .N. It is safe
+// for concurrent use.
+type RotatingFile struct {
+	mutex   sync.Mutex
+	path    string
+	maxSize int64
+	backups int
+	file    *os.File
+	size    int64
+}
+
+// OpenRotatingFile opens the log file at the given path for appending, created
+// if it does not exist. The file is rotated when a write would exceed the given
+// maximum size, keeping the given number of rotated files. It returns an error
+// if the file cannot be opened.
+func OpenRotatingFile(path string, maxSize int64, backups int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		path:    path,
+		maxSize: maxSize,
+		backups: backups,
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Close closes the log file.
+func (f *RotatingFile) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.file.Close()
+}
+
+// Write writes the given bytes to the log file, rotating it first if the write
+// would exceed the maximum size. It returns an error if the file cannot be
+// rotated or written.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// open opens the log file for appending, creating it if it does not exist, and
+// gets its current size.
+func (f *RotatingFile) open() error {
+	//nolint:mnd // owner only permissions
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+// rotate closes the log file, shifts the rotated files, dropping the oldest one,
+// renames the log file to the most recent rotated file and opens a new log file.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	if f.backups < 1 {
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		return f.open()
+	}
+
+	for i := f.backups - 1; i > 0; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return err
+	}
+
+	return f.open()
+}
@@ -0,0 +1,90 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brunoribeiro127/gobin/internal"
+)
+
+func TestRotatingFile(t *testing.T) {
+	cases := map[string]struct {
+		existing        string
+		writes          []string
+		backups         int
+		expectedContent string
+		expectedBackups []string
+	}{
+		"no-rotation": {
+			writes:          []string{"aaaa\n", "bbbb\n"},
+			backups:         2,
+			expectedContent: "aaaa\nbbbb\n",
+		},
+		"rotation-on-write": {
+			writes:          []string{"aaaa\n", "bbbb\n", "cccc\n"},
+			backups:         2,
+			expectedContent: "cccc\n",
+			expectedBackups: []string{"aaaa\nbbbb\n"},
+		},
+		"rotation-of-existing-file": {
+			existing:        "0000\n1111\n",
+			writes:          []string{"aaaa\n"},
+			backups:         2,
+			expectedContent: "aaaa\n",
+			expectedBackups: []string{"0000\n1111\n"},
+		},
+		"rotation-drops-oldest-backup": {
+			writes:          []string{"aaaa\naaaa\n", "bbbb\nbbbb\n", "cccc\ncccc\n", "dddd\n"},
+			backups:         2,
+			expectedContent: "dddd\n",
+			expectedBackups: []string{"cccc\ncccc\n", "bbbb\nbbbb\n"},
+		},
+		"rotation-without-backups": {
+			writes:          []string{"aaaa\naaaa\n", "bbbb\n"},
+			expectedContent: "bbbb\n",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "gobin.log")
+			if tc.existing != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tc.existing), 0600))
+			}
+
+			file, err := internal.OpenRotatingFile(path, 10, tc.backups)
+			require.NoError(t, err)
+
+			for _, write := range tc.writes {
+				n, writeErr := file.Write([]byte(write))
+				require.NoError(t, writeErr)
+				assert.Equal(t, len(write), n)
+			}
+
+			require.NoError(t, file.Close())
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedContent, string(content))
+
+			for i, expected := range tc.expectedBackups {
+				backup, readErr := os.ReadFile(path + "." + strconv.Itoa(i+1))
+				require.NoError(t, readErr)
+				assert.Equal(t, expected, string(backup))
+			}
+
+			_, err = os.Stat(path + "." + strconv.Itoa(len(tc.expectedBackups)+1))
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestOpenRotatingFile_Error(t *testing.T) {
+	_, err := internal.OpenRotatingFile(filepath.Join(t.TempDir(), "missing", "gobin.log"), 10, 1)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -1,18 +1,192 @@
 package internal
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"slices"
+	"strings"
 )
 
-// NewLoggerWithLevel creates a new logger with the specified log level. It uses
-// the slog package and adds the source file and line number to the log messages.
-func NewLoggerWithLevel(level slog.Level) *slog.Logger {
-	return slog.New(slog.NewTextHandler(
-		os.Stderr,
-		&slog.HandlerOptions{
-			AddSource: true,
-			Level:     level,
-		},
-	))
+// LogFormat is the format of the log records. It implements the [flag.Value]
+// interface.
+type LogFormat string
+
+const (
+	// LogFormatJSON is the format of log records as JSON objects.
+	LogFormatJSON LogFormat = "json"
+	// LogFormatText is the format of log records as key=value pairs.
+	LogFormatText LogFormat = "text"
+)
+
+// allowedLogFormats is a list of allowed log formats.
+//
+//nolint:gochecknoglobals // global variable to define allowed log formats
+var allowedLogFormats = []LogFormat{
+	LogFormatText,
+	LogFormatJSON,
+}
+
+// IsValid checks if the log format is valid.
+func (f *LogFormat) IsValid() bool {
+	return slices.Contains(allowedLogFormats, *f)
+}
+
+// String returns the string representation of the log format.
+func (f *LogFormat) String() string {
+	return string(*f)
+}
+
+// Set sets the log format from a string.
+func (f *LogFormat) Set(value string) error {
+	candidate := LogFormat(strings.ToLower(value))
+	if !candidate.IsValid() {
+		return fmt.Errorf("invalid log format %q, allowed values are: %v", value, allowedLogFormats)
+	}
+	*f = candidate
+	return nil
+}
+
+// Type returns the type of the log format.
+func (f *LogFormat) Type() string {
+	return "format"
+}
+
+// logAttrsKey is the context key of the attributes added to the log records.
+type logAttrsKey struct{}
+
+// WithLogAttrs returns a copy of the context carrying the given attributes,
+// added to the log records logged with the context. It allows correlating the
+// log records of the operations running in parallel.
+func WithLogAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(logAttrsKey{}).([]slog.Attr)
+	return context.WithValue(ctx, logAttrsKey{}, append(slices.Clip(existing), attrs...))
+}
+
+// NewLoggerWithLevel creates a new logger with the specified log level and
+// format. It uses the slog package and adds the source file and line number,
+// and the attributes carried by the context, to the log messages. If logFile
+// is not nil, the log messages are also written to it in text format from the
+// info level, independently of the specified log level.
+func NewLoggerWithLevel(level slog.Level, format LogFormat, logFile io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		AddSource: true,
+		Level:     level,
+	}
+
+	var handler slog.Handler = slog.NewTextHandler(os.Stderr, opts)
+	if format == LogFormatJSON {
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	}
+
+	if logFile != nil {
+		handler = &multiHandler{handlers: []slog.Handler{handler, newFileHandler(logFile)}}
+	}
+
+	return slog.New(&contextHandler{handler: handler})
+}
+
+// NewFileLogger creates a new logger writing the log messages to the given log
+// file only, in text format from the info level, with the source file and line
+// number and the attributes carried by the context. The log messages are
+// discarded if logFile is nil.
+func NewFileLogger(logFile io.Writer) *slog.Logger {
+	if logFile == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+
+	return slog.New(&contextHandler{handler: newFileHandler(logFile)})
+}
+
+// newFileHandler creates a slog handler writing the log records to the given
+// log file in text format from the info level.
+func newFileHandler(logFile io.Writer) slog.Handler {
+	return slog.NewTextHandler(logFile, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.LevelInfo,
+	})
+}
+
+// contextHandler is a slog handler adding the attributes carried by the
+// context to the log records.
+type contextHandler struct {
+	handler slog.Handler
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle handles the record, adding the attributes carried by the context.
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs, ok := ctx.Value(logAttrsKey{}).([]slog.Attr); ok {
+		record.AddAttrs(attrs...)
+	}
+
+	return h.handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a new handler with the given attributes.
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{handler: h.handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group.
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{handler: h.handler.WithGroup(name)}
+}
+
+// multiHandler is a slog handler dispatching the log records to several
+// handlers, each with its own level.
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+// Enabled reports whether any of the handlers handles records at the given
+// level.
+func (h *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Handle handles the record with the handlers enabled for its level. It returns
+// the errors of the handlers failing to handle it.
+func (h *multiHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, record.Level) {
+			errs = append(errs, handler.Handle(ctx, record.Clone()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a new handler with the given attributes.
+func (h *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+
+	return &multiHandler{handlers: handlers}
+}
+
+// WithGroup returns a new handler with the given group.
+func (h *multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+
+	return &multiHandler{handlers: handlers}
 }
@@ -0,0 +1,45 @@
+package internal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal"
+)
+
+func TestLogFormat_Set(t *testing.T) {
+	cases := map[string]struct {
+		format   string
+		expected internal.LogFormat
+		err      error
+	}{
+		"text": {
+			format:   "text",
+			expected: internal.LogFormatText,
+		},
+		"json-uppercase": {
+			format:   "JSON",
+			expected: internal.LogFormatJSON,
+		},
+		"invalid": {
+			format: "xml",
+			err:    errors.New(`invalid log format "xml", allowed values are: [text json]`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			format := internal.LogFormat("")
+			err := format.Set(tc.format)
+			assert.Equal(t, tc.expected, format)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestLogFormat_Type(t *testing.T) {
+	format := internal.LogFormat("")
+	assert.Equal(t, "format", format.Type())
+}
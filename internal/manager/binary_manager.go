@@ -1,17 +1,30 @@
 package manager
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
 	"debug/buildinfo"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	goversion "go/version"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/mod/module"
 
+	"github.com/brunoribeiro127/gobin/internal"
 	"github.com/brunoribeiro127/gobin/internal/model"
+	"github.com/brunoribeiro127/gobin/internal/registry"
 	"github.com/brunoribeiro127/gobin/internal/system"
 	"github.com/brunoribeiro127/gobin/internal/toolchain"
 )
@@ -22,46 +35,328 @@ const (
 	// GOOSEnvVar is the environment variable for the Go runtime operating
 	// system.
 	GOOSEnvVar = "GOOS"
+
+	// debugInfoDir is the directory, in the data directory of a binary,
+	// keeping the debug information of its stripped versions by build ID.
+	debugInfoDir = "debug"
+	// debugInfoExtension is the extension of the debug information files.
+	debugInfoExtension = ".debug"
+	// signatureDir is the directory, in the data directory of a binary,
+	// keeping the detached signatures of its signed versions.
+	signatureDir = "signatures"
+	// signatureExtension is the extension of the detached signature files.
+	signatureExtension = ".sig"
+
+	// annotationBuildFlags is the annotation of the build flags, as JSON, of a
+	// binary pushed to an OCI registry.
+	annotationBuildFlags = "dev.gobin.build-flags"
+	// annotationGoVersion is the annotation of the Go version a binary pushed
+	// to an OCI registry was built with.
+	annotationGoVersion = "dev.gobin.go-version"
+	// annotationModule is the annotation of the module path of a binary pushed
+	// to an OCI registry.
+	annotationModule = "dev.gobin.module"
+	// annotationModuleSum is the annotation of the module checksum of a binary
+	// pushed to an OCI registry.
+	annotationModuleSum = "dev.gobin.module-sum"
+	// annotationPackage is the annotation of the package path of a binary
+	// pushed to an OCI registry.
+	annotationPackage = "dev.gobin.package"
+	// annotationPlatform is the annotation of the platform, ex. linux/amd64, of
+	// a binary pushed to an OCI registry.
+	annotationPlatform = "dev.gobin.platform"
+	// annotationTitle is the annotation of the name of a binary pushed to an
+	// OCI registry.
+	annotationTitle = "org.opencontainers.image.title"
+	// annotationVersion is the annotation of the module version of a binary
+	// pushed to an OCI registry.
+	annotationVersion = "org.opencontainers.image.version"
+	// goReleasesURL is the URL of the Go release feed, listing the latest patch
+	// release of each supported Go minor version.
+	goReleasesURL = "https://go.dev/dl/?mode=json"
+	// longPathMargin is the number of characters below the Windows MAX_PATH
+	// limit from which a binary path is diagnosed as near the limit.
+	longPathMargin = 20
+	// moduleStatusCacheFileName is the name of the file caching the deprecation
+	// and retractions of the modules, in the internal cache directory.
+	moduleStatusCacheFileName = "module-status.json"
+	// moduleStatusCacheInterval is the interval after which the cached status
+	// of a module is looked up again.
+	moduleStatusCacheInterval = 24 * time.Hour
+	// maxVersionSuggestions is the maximum number of versions suggested when
+	// the requested version of a package is not available.
+	maxVersionSuggestions = 3
+	// newExecutableSuffix is the suffix of the new executable staged next to
+	// the executable it replaces.
+	newExecutableSuffix = ".new"
+	// oldExecutableSuffix is the suffix of the executable renamed aside when
+	// replaced while running on Windows, removed on the next run.
+	oldExecutableSuffix = ".old"
+	// networkProbes is the number of probes to each module proxy when
+	// diagnosing the network.
+	networkProbes = 3
+	// packageSearchURL is the URL of the package index search page.
+	packageSearchURL = "https://pkg.go.dev/search"
+	// vulnDBURL is the URL of the vulnerability database used by govulncheck.
+	vulnDBURL = "https://vuln.go.dev"
 )
 
+// moduleStatus is the cached deprecation and retractions of a module, as
+// declared in the go.mod file of its latest version.
+type moduleStatus struct {
+	CheckedAt   time.Time          `json:"checked_at"`
+	Deprecated  string             `json:"deprecated,omitempty"`
+	Retractions []moduleRetraction `json:"retractions,omitempty"`
+}
+
+// moduleRetraction is a range of retracted versions of a module.
+type moduleRetraction struct {
+	Low       string `json:"low"`
+	High      string `json:"high"`
+	Rationale string `json:"rationale,omitempty"`
+}
+
+// wslDriveMountRegexp matches the paths in the Windows drives mounted by WSL.
+var wslDriveMountRegexp = regexp.MustCompile(`^/mnt/[a-zA-Z](/|$)`)
+
+// universalPlatforms are the platforms merged in a macOS universal binary.
+//
+//nolint:gochecknoglobals // global variable to define universal platforms
+var universalPlatforms = []string{"darwin/arm64", "darwin/amd64"}
+
 var (
 	// ErrBinaryAlreadyManaged is returned when a binary is already managed.
 	ErrBinaryAlreadyManaged = errors.New("binary already managed")
+
+	// ErrBinaryNotManaged is returned when a binary is not managed.
+	ErrBinaryNotManaged = errors.New("binary not managed")
+
+	// ErrBinaryNotLinked is returned when a binary was not installed from a
+	// local package.
+	ErrBinaryNotLinked = errors.New("binary not linked to a local directory")
+
+	// ErrBinaryAlreadyExists is returned when a binary with the same name
+	// already exists in the Go binary directory.
+	ErrBinaryAlreadyExists = errors.New("binary already exists")
+
+	// ErrBinaryNameCollision is returned when the name of a binary differs only
+	// in letter case from an existing binary, colliding with it on the
+	// case-insensitive file systems of macOS and Windows.
+	ErrBinaryNameCollision = errors.New("binary name collides with an existing binary")
+
+	// ErrBinaryProtected is returned when a binary is protected and the
+	// operation is not forced.
+	ErrBinaryProtected = errors.New("binary protected")
+
+	// ErrDebugInfoNotFound is returned when no debug information is kept for
+	// a binary.
+	ErrDebugInfoNotFound = errors.New("debug info not found")
+
+	// ErrModuleSumMismatch is returned when the module sum of a binary differs
+	// from the one recorded in the checksum database.
+	ErrModuleSumMismatch = errors.New("module sum mismatch")
+
+	// ErrModuleSumNotVerifiable is returned when the module sum of a binary
+	// cannot be verified against the checksum database.
+	ErrModuleSumNotVerifiable = errors.New("module sum not verifiable")
+
+	// ErrPackageNotFound is returned when a package does not exist in the
+	// module providing it.
+	ErrPackageNotFound = errors.New("package not found")
+
+	// ErrPackageNotMain is returned when a package is not a main package and
+	// cannot be installed.
+	ErrPackageNotMain = errors.New("package is not a main package")
+
+	// ErrPlatformMismatch is returned when a binary pulled from an OCI
+	// registry was built for another platform.
+	ErrPlatformMismatch = errors.New("binary built for another platform")
+
+	// ErrPreviousVersionNotFound is returned when no version installed before
+	// the current one is recorded for a binary.
+	ErrPreviousVersionNotFound = errors.New("previous version not found")
+
+	// ErrRollbackVersionNotFound is returned when no version older than the
+	// pinned one is available in the internal binary directory.
+	ErrRollbackVersionNotFound = errors.New("no version to roll back to")
+
+	// ErrRefNotFound is returned when a branch or tag ref cannot be resolved
+	// for a module.
+	ErrRefNotFound = errors.New("ref not found")
+
+	// ErrUniversalNotSupported is returned when a universal binary is requested
+	// on a platform other than macOS.
+	ErrUniversalNotSupported = errors.New("universal binaries are only supported on macOS")
+
+	// ErrVersionNotAvailable is returned when the requested version is not
+	// available for a module.
+	ErrVersionNotAvailable = errors.New("version not available")
+
+	// ErrVersionNotInstalled is returned when a version of a binary is not in
+	// the internal binary directory.
+	ErrVersionNotInstalled = errors.New("version not installed")
 )
 
 // BinaryManager is an interface for a binary manager.
 type BinaryManager interface {
+	// BuildPackage builds a package for a platform in a directory.
+	BuildPackage(
+		ctx context.Context,
+		pkg model.Package,
+		platform string,
+		flags model.BuildFlags,
+		dir string,
+	) (string, error)
+	// BundlePackages creates a module bundle with the modules required to
+	// install the given packages.
+	BundlePackages(
+		ctx context.Context,
+		path string,
+		pkgs ...model.Package,
+	) error
 	// DiagnoseBinary diagnoses issues in a binary.
 	DiagnoseBinary(
 		ctx context.Context,
 		path string,
 	) (model.BinaryDiagnostic, error)
+	// DiagnoseHTTPProxies diagnoses the HTTP proxies traversed to reach the
+	// network endpoints.
+	DiagnoseHTTPProxies(
+		ctx context.Context,
+	) ([]model.HTTPRoute, error)
+	// DiagnoseNetwork diagnoses the module proxies.
+	DiagnoseNetwork(
+		ctx context.Context,
+	) ([]model.ProxyHealth, error)
+	// EstimateDownloadSize estimates the download size of the given packages.
+	EstimateDownloadSize(
+		ctx context.Context,
+		packages ...model.Package,
+	) model.DownloadEstimate
 	// GetAllBinaryInfos gets all binary infos.
 	GetAllBinaryInfos(
 		managed bool,
 	) ([]model.BinaryInfo, error)
+	// GetBinaryBuild gets the build of the binary for a given path.
+	GetBinaryBuild(
+		path string,
+	) (model.BinaryBuild, error)
+	// GetBinaryEnv gets the environment variables set by the exec shim of a
+	// binary.
+	GetBinaryEnv(
+		bin model.Binary,
+	) ([]string, error)
 	// GetBinaryInfo gets the binary info for a given path.
 	GetBinaryInfo(
 		path string,
 	) (model.BinaryInfo, error)
+	// GetBinaryOrigin gets the origin of a given binary.
+	GetBinaryOrigin(
+		bin model.Binary,
+	) (model.BinaryOrigin, error)
 	// GetBinaryRepository gets the repository URL for a given binary.
 	GetBinaryRepository(
 		ctx context.Context,
 		bin model.Binary,
 	) (string, error)
+	// GetBinarySBOM gets the software bill of materials of the binary for a
+	// given path.
+	GetBinarySBOM(
+		path string,
+	) (model.SBOM, error)
+	// GetBinarySizeHistory gets the size history of a given binary.
+	GetBinarySizeHistory(
+		bin model.Binary,
+	) (model.SizeHistory, error)
 	// GetBinaryUpgradeInfo gets the upgrade information for a given binary.
 	GetBinaryUpgradeInfo(
 		ctx context.Context,
 		info model.BinaryInfo,
 		checkMajor bool,
 	) (model.BinaryUpgradeInfo, error)
+	// GetBrokenPins gets the pins in the Go binary directory whose internal
+	// binary is missing.
+	GetBrokenPins() ([]string, error)
+	// GetCacheArtifacts gets the managed binaries served by a binary cache
+	// server.
+	GetCacheArtifacts() ([]model.CacheArtifact, error)
+	// GetCrossBinaryInfos gets the binary infos of the binaries cross
+	// compiled for another platform.
+	GetCrossBinaryInfos() ([]model.BinaryInfo, error)
+	// GetCrossOS gets the operating system sharing the Go binary directory
+	// through WSL.
+	GetCrossOS() string
+	// GetDebugInfoPath gets the path of the debug information kept for a
+	// stripped binary.
+	GetDebugInfoPath(
+		ctx context.Context,
+		path string,
+	) (string, error)
+	// GetExpiredBinaries gets the binaries in the internal binary directory not
+	// kept by a retention policy.
+	GetExpiredBinaries(
+		policy model.RetentionConfig,
+	) ([]model.Binary, error)
+	// GetGoEnv gets the environment settings of the Go toolchain.
+	GetGoEnv(
+		ctx context.Context,
+	) (model.GoEnv, error)
+	// GetGoReleases gets the supported Go releases.
+	GetGoReleases(
+		ctx context.Context,
+	) (model.GoReleases, error)
+	// GetLinkSource gets the local directory a binary is linked to.
+	GetLinkSource(
+		bin model.Binary,
+	) (string, error)
+	// GetLockedBinaries gets the managed binaries pinned to the Go binary
+	// directory, as listed in a lockfile.
+	GetLockedBinaries() ([]model.LockedBinary, error)
+	// GetModuleBuild gets the build a module would produce.
+	GetModuleBuild(
+		ctx context.Context,
+		module model.Module,
+	) (model.BinaryBuild, error)
+	// GetPinVersions gets the versions of the internal binaries a pin can
+	// target.
+	GetPinVersions(
+		bin model.Binary,
+	) ([]model.PinVersion, error)
+	// GetRelatedPins gets the other pins referencing the same binary.
+	GetRelatedPins(
+		bin model.Binary,
+	) ([]model.Binary, error)
+	// GetStoreUsage gets the disk usage of the internal binary directory.
+	GetStoreUsage() (model.StoreUsage, error)
+	// GetSumDBConfig gets the checksum database configuration.
+	GetSumDBConfig(
+		ctx context.Context,
+	) (model.SumDBConfig, error)
 	// InstallPackage installs a package.
 	InstallPackage(
 		ctx context.Context,
 		pkg model.Package,
 		kind model.Kind,
+		flags model.BuildFlags,
 		rebuild bool,
+		universal bool,
+		cacheFrom string,
+	) error
+	// InstallLocalPackage installs the main package of a local directory and
+	// links the binary to it.
+	InstallLocalPackage(
+		ctx context.Context,
+		dir string,
+		flags model.BuildFlags,
 	) error
+	// InstallPackagePlatform installs a package cross compiled for a platform.
+	InstallPackagePlatform(
+		ctx context.Context,
+		pkg model.Package,
+		platform string,
+		flags model.BuildFlags,
+		rebuild bool,
+	) (string, error)
 	// MigrateBinary migrates a binary to be managed internally.
 	MigrateBinary(
 		path string,
@@ -71,59 +366,318 @@ type BinaryManager interface {
 		bin model.Binary,
 		kind model.Kind,
 	) error
+	// PlanInstallPackage plans the install of a package without building it.
+	PlanInstallPackage(
+		ctx context.Context,
+		pkg model.Package,
+		kind model.Kind,
+		flags model.BuildFlags,
+	) ([]model.Action, error)
+	// PlanPruneBinary plans the prune of binaries from the internal binary
+	// directory without removing them.
+	PlanPruneBinary(
+		bin model.Binary,
+		force bool,
+	) ([]model.Action, error)
+	// PlanUninstallBinary plans the uninstall of a binary without removing it.
+	PlanUninstallBinary(
+		bin model.Binary,
+		force bool,
+		purge bool,
+	) ([]model.Action, error)
+	// PlanUpgradeBinary plans the upgrade of a binary without building it.
+	PlanUpgradeBinary(
+		ctx context.Context,
+		binFullPath string,
+		flags model.BuildFlags,
+		majorUpgrade bool,
+		rebuild bool,
+		force bool,
+	) ([]model.Action, error)
+	// ProtectBinary sets the protection of a binary in the Go binary directory.
+	ProtectBinary(
+		bin model.Binary,
+		protected bool,
+	) error
 	// PruneBinary prunes binaries from the internal binary directory.
 	PruneBinary(
 		bin model.Binary,
+		force bool,
+	) error
+	// PullBinary pulls a binary from an OCI registry and pins it.
+	PullBinary(
+		ctx context.Context,
+		ref model.OCIReference,
+		kind model.Kind,
+	) (model.Binary, error)
+	// PushBinary pushes a managed binary to an OCI registry.
+	PushBinary(
+		ctx context.Context,
+		path string,
+		ref model.OCIReference,
+	) error
+	// RebuildBinary rebuilds a managed binary at its installed version.
+	RebuildBinary(
+		ctx context.Context,
+		path string,
+	) error
+	// RelinkBinary rebuilds a binary from the local directory it is linked to.
+	RelinkBinary(
+		ctx context.Context,
+		bin model.Binary,
+		flags model.BuildFlags,
+	) error
+	// RemoveStaleExecutable removes the executable renamed aside by
+	// UpdateExecutable.
+	RemoveStaleExecutable(
+		path string,
+	) error
+	// RepinBinary pins a broken pin to the latest internal binary matching it.
+	RepinBinary(
+		path string,
+	) error
+	// ReproduceBinary rebuilds a managed binary and compares it with the
+	// installed one.
+	ReproduceBinary(
+		ctx context.Context,
+		path string,
+	) (model.BinaryReproduction, error)
+	// ResolvePackage resolves the version of a package.
+	ResolvePackage(
+		ctx context.Context,
+		pkg model.Package,
+	) (model.Package, error)
+	// RollbackBinary pins the version of a binary preceding the pinned one.
+	RollbackBinary(
+		bin model.Binary,
+	) (model.Version, error)
+	// SearchPackages searches the package index for packages matching a query.
+	SearchPackages(
+		ctx context.Context,
+		query string,
+		limit int,
+	) ([]model.PackageSearchResult, error)
+	// SetBinaryEnv sets environment variables in the exec shim of a binary.
+	SetBinaryEnv(
+		bin model.Binary,
+		envVars ...string,
+	) error
+	// StorePackage stores the binary of a package in the internal binary
+	// directory without pinning it.
+	StorePackage(
+		ctx context.Context,
+		pkg model.Package,
+	) (string, error)
+	// SwitchBinary pins a version of a binary in the internal binary directory.
+	SwitchBinary(
+		bin model.Binary,
+		version model.Version,
 	) error
 	// UninstallBinary uninstalls a binary.
 	UninstallBinary(
 		bin model.Binary,
+		force bool,
+		purge bool,
+	) error
+	// UnmigrateBinary restores a managed binary to a plain file.
+	UnmigrateBinary(
+		bin model.Binary,
+	) error
+	// UnsetBinaryEnv unsets environment variables in the exec shim of a binary.
+	UnsetBinaryEnv(
+		bin model.Binary,
+		names ...string,
+	) error
+	// UpdateExecutable builds a package and replaces an executable with it.
+	UpdateExecutable(
+		ctx context.Context,
+		path string,
+		pkg model.Package,
 	) error
 	// UpgradeBinary upgrades a binary.
 	UpgradeBinary(
 		ctx context.Context,
 		binFullPath string,
+		flags model.BuildFlags,
 		majorUpgrade bool,
 		rebuild bool,
+		force bool,
+		cacheFrom string,
+	) error
+	// VerifyModuleSum verifies a module sum against the checksum database.
+	VerifyModuleSum(
+		ctx context.Context,
+		module model.Module,
+		sum string,
 	) error
 }
 
 // GoBinaryManager is a manager for Go binaries.
 type GoBinaryManager struct {
-	fs        system.FileSystem
-	runtime   system.Runtime
-	toolchain toolchain.Toolchain
-	workspace system.Workspace
+	binaryCache registry.BinaryCache
+	cacheMu     sync.Mutex
+	fs          system.FileSystem
+	registry    registry.Registry
+	runtime     system.Runtime
+	toolchain   toolchain.Toolchain
+	workspace   system.Workspace
+	pinFormat   model.PinFormat
+	pinMode     model.PinMode
+	signing     model.SigningConfig
 }
 
-// NewGoBinaryManager creates a new GoBinaryManager.
+// NewGoBinaryManager creates a new GoBinaryManager. The pin format defines the
+// names of the pins with a version in the Go binary directory, the pin mode
+// whether pins are symlinks or copies of the internal binaries, and the signing
+// configuration the identity the installed binaries are signed with, per
+// operating system.
 func NewGoBinaryManager(
+	binaryCache registry.BinaryCache,
 	fs system.FileSystem,
+	registry registry.Registry,
 	runtime system.Runtime,
 	toolchain toolchain.Toolchain,
 	workspace system.Workspace,
+	pinFormat model.PinFormat,
+	pinMode model.PinMode,
+	signing model.SigningConfig,
 ) *GoBinaryManager {
 	return &GoBinaryManager{
-		fs:        fs,
-		runtime:   runtime,
-		toolchain: toolchain,
-		workspace: workspace,
+		binaryCache: binaryCache,
+		fs:          fs,
+		registry:    registry,
+		runtime:     runtime,
+		toolchain:   toolchain,
+		workspace:   workspace,
+		pinFormat:   pinFormat,
+		pinMode:     pinMode,
+		signing:     signing,
+	}
+}
+
+// BuildPackage builds a package for the given platform, ex. linux/amd64,
+// leveraging the toolchain, and moves the binary to the given directory,
+// created if it does not exist, named <binary>_<version>_<goos>_<goarch>, with
+// the .exe extension for windows. The package version is expected to be
+// resolved. The Go binary directory and the internal binaries are left
+// untouched. It returns the path of the binary, or an error if the package
+// cannot be built or moved.
+func (m *GoBinaryManager) BuildPackage(
+	ctx context.Context,
+	pkg model.Package,
+	platform string,
+	flags model.BuildFlags,
+	dir string,
+) (string, error) {
+	ctx, span := internal.StartSpan(
+		ctx,
+		"BuildPackage",
+		attribute.String("gobin.package", pkg.String()),
+		attribute.String("gobin.platform", platform),
+	)
+	defer span.End()
+
+	logger := slog.Default().With("pkg", pkg.String(), "platform", platform)
+
+	binName := pkg.GetBinaryName()
+	binTempDir, cleanup, err := m.fs.CreateTempDir(m.workspace.GetInternalTempPath(), binName+"-*")
+	if err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+	defer func() { _ = cleanup() }()
+
+	if err = m.toolchain.InstallPlatform(ctx, binTempDir, pkg, platform, flags, false); err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	goos, goarch, _ := strings.Cut(platform, "/")
+	extension := model.GetBinaryExtension(goos)
+
+	source := filepath.Join(binTempDir, "bin", binName+extension)
+	if platform != m.runtime.Platform() {
+		source = filepath.Join(binTempDir, "bin", goos+"_"+goarch, binName+extension)
+	}
+
+	target := filepath.Join(dir, fmt.Sprintf("%s_%s_%s_%s%s", binName, pkg.Version, goos, goarch, extension))
+
+	defer internal.TrackPhase(ctx, internal.PhaseLink)()
+
+	//nolint:mnd // directory permissions
+	if err = m.fs.CreateDir(dir, 0755); err != nil {
+		logger.ErrorContext(ctx, "error while creating output directory", "err", err, "dir", dir)
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	if err = m.fs.Move(source, target); err != nil {
+		logger.ErrorContext(
+			ctx, "error while moving binary to output directory",
+			"err", err, "src", source, "dst", target,
+		)
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	return target, nil
+}
+
+// BundlePackages creates a module bundle at the given path, a gzip compressed
+// tar archive of a module cache with the modules required to install the given
+// packages and the checksum database data verifying them, to be installed
+// offline with install --from-bundle. The modules are downloaded into a
+// temporary module cache in the internal temp directory, removed afterwards,
+// and only its download directory is archived. It returns an error if any
+// package cannot be downloaded or the archive cannot be created.
+func (m *GoBinaryManager) BundlePackages(ctx context.Context, path string, pkgs ...model.Package) error {
+	ctx, span := internal.StartSpan(ctx, "BundlePackages", attribute.String("gobin.path", path))
+	defer span.End()
+
+	logger := slog.Default().With("path", path)
+
+	modCache, cleanup, err := m.fs.CreateTempDir(m.workspace.GetInternalTempPath(), "bundle-*")
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+	defer func() { _ = cleanup() }()
+
+	for _, pkg := range pkgs {
+		if err = m.toolchain.DownloadPackage(ctx, modCache, pkg); err != nil {
+			return internal.RecordSpanError(span, err)
+		}
+	}
+
+	if err = m.fs.CreateArchive(path, modCache, filepath.Join("cache", "download")); err != nil {
+		logger.ErrorContext(ctx, "error while creating module bundle", "err", err)
+		return internal.RecordSpanError(span, err)
 	}
+
+	return nil
 }
 
 // DiagnoseBinary diagnoses a binary leveraging the toolchain. It returns the
 // diagnostic results, or an error if the binary cannot be diagnosed (e.g. the
 // binary is not a Go binary, the build info cannot be read, or the binary was
-// built without Go modules). It also checks for vulnerabilities in the binary.
+// built without Go modules). It also checks for vulnerabilities in the binary
+// and, on Linux, for a dynamic linker (glibc or musl) missing in the system
+// or, on Windows, for an installed binary path near the MAX_PATH limit.
+// A binary cross compiled in a platform directory of the internal binary
+// directory is expected to be built for the platform of the directory, and is
+// not checked against the PATH.
 func (m *GoBinaryManager) DiagnoseBinary(
 	ctx context.Context,
 	path string,
 ) (model.BinaryDiagnostic, error) {
+	ctx, span := internal.StartSpan(ctx, "DiagnoseBinary", attribute.String("gobin.binary", filepath.Base(path)))
+	defer span.End()
+
 	binaryName := filepath.Base(path)
 	diagnostic := model.BinaryDiagnostic{
 		Name: binaryName,
 	}
 
+	crossPlatform, isCrossBuild := m.getCrossPlatform(path)
+	if isCrossBuild {
+		diagnostic.Name = model.GetPlatformDir(crossPlatform) + "/" + binaryName
+	}
+
 	buildInfo, err := m.toolchain.GetBuildInfo(path)
 	if err != nil {
 		if errors.Is(err, toolchain.ErrBinaryBuiltWithoutGoModules) {
@@ -131,7 +685,7 @@ func (m *GoBinaryManager) DiagnoseBinary(
 			return diagnostic, nil
 		}
 
-		return model.BinaryDiagnostic{}, err
+		return model.BinaryDiagnostic{}, internal.RecordSpanError(span, err)
 	}
 
 	binPlatform := getBinaryPlatform(buildInfo)
@@ -142,36 +696,196 @@ func (m *GoBinaryManager) DiagnoseBinary(
 	diagnostic.GoVersion.Expected = m.runtime.Version()
 	diagnostic.Platform.Actual = binPlatform
 	diagnostic.Platform.Expected = runtimePlatform
+	if isCrossBuild {
+		diagnostic.Platform.Expected = crossPlatform
+	}
+
+	if binPlatform == runtimePlatform && strings.HasPrefix(runtimePlatform, "linux/") {
+		interpreter, _ := m.fs.GetELFInterpreter(path)
+		if interpreter != "" && !m.fs.Exists(interpreter) {
+			diagnostic.MissingInterpreter = interpreter
+		}
+	}
+
+	if strings.HasPrefix(runtimePlatform, "windows/") {
+		installPath := path
+		if target, targetErr := m.getPinTarget(path); targetErr == nil {
+			installPath = target
+		}
+
+		if len(installPath) >= system.WindowsMaxPath-longPathMargin {
+			diagnostic.LongPath = installPath
+		}
+	}
+
+	// cross compiled binaries are neither in the PATH nor pinned
+	if !isCrossBuild {
+		locations := m.fs.LocateBinaryInPath(binaryName)
+		if len(locations) > 1 {
+			diagnostic.DuplicatesInPath = locations
+		}
+		diagnostic.NotInPath = len(locations) == 0
 
-	locations := m.fs.LocateBinaryInPath(binaryName)
-	if len(locations) > 1 {
-		diagnostic.DuplicatesInPath = locations
+		diagnostic.IsNotManaged = !m.isManagedPin(path)
 	}
-	diagnostic.NotInPath = len(locations) == 0
 
-	isSymlinkToDir, _ := m.fs.IsSymlinkToDir(path, m.workspace.GetInternalBinPath())
-	diagnostic.IsNotManaged = !isSymlinkToDir
+	if !isCrossBuild && !diagnostic.IsNotManaged {
+		receipt, receiptErr := m.readReceipt(binaryName)
+		if receiptErr == nil && receipt.Compression != (model.Compression{}) {
+			if probeErr := m.toolchain.ProbeBinary(ctx, path); probeErr != nil {
+				diagnostic.CompressedNotExecutable = probeErr.Error()
+			}
+		}
+
+		if receiptErr == nil && receipt.Signature != (model.Signature{}) {
+			verifyErr := m.toolchain.VerifySignature(ctx, m.runtime.OS(), path, receipt.Signature.File)
+			if verifyErr != nil {
+				diagnostic.InvalidSignature = verifyErr.Error()
+			}
+		}
+	}
 
 	if buildInfo.Main.Sum != "" {
 		retracted, deprecated, modErr := m.diagnoseGoModFile(
 			ctx, model.NewModule(buildInfo.Main.Path, model.NewVersion(buildInfo.Main.Version)),
 		)
 		if modErr != nil {
-			return model.BinaryDiagnostic{}, modErr
+			return model.BinaryDiagnostic{}, internal.RecordSpanError(span, modErr)
 		}
 
+		diagnostic.Module = buildInfo.Main.Path
 		diagnostic.Retracted = retracted
 		diagnostic.Deprecated = deprecated
+
+		if diagnostic.SumMismatch, diagnostic.SumNotVerifiable, err = m.diagnoseModuleSum(
+			ctx, model.NewModule(buildInfo.Main.Path, model.NewVersion(buildInfo.Main.Version)), buildInfo.Main.Sum,
+		); err != nil {
+			return model.BinaryDiagnostic{}, internal.RecordSpanError(span, err)
+		}
 	}
 
 	diagnostic.Vulnerabilities, err = m.toolchain.VulnCheck(ctx, path)
 	if err != nil {
-		return model.BinaryDiagnostic{}, err
+		return model.BinaryDiagnostic{}, internal.RecordSpanError(span, err)
 	}
 
 	return diagnostic, nil
 }
 
+// DiagnoseHTTPProxies diagnoses the HTTP proxies traversed to reach the module
+// proxies, the checksum database and the vulnerability database leveraging the
+// toolchain. It returns an error if the endpoints cannot be determined or an
+// HTTP proxy is misconfigured.
+func (m *GoBinaryManager) DiagnoseHTTPProxies(ctx context.Context) ([]model.HTTPRoute, error) {
+	proxies, err := m.toolchain.GetProxies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sumDBConfig, err := m.toolchain.GetSumDBConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var routes []model.HTTPRoute
+	for _, proxy := range proxies {
+		if proxy != "direct" {
+			routes = append(routes, model.HTTPRoute{Endpoint: "module proxy", URL: proxy})
+		}
+	}
+
+	if url, ok := sumDBConfig.GetURL(); ok {
+		routes = append(routes, model.HTTPRoute{Endpoint: "checksum database", URL: url})
+	}
+
+	routes = append(routes, model.HTTPRoute{Endpoint: "vulnerability database", URL: vulnDBURL})
+
+	for i := range routes {
+		if routes[i].Proxy, err = m.toolchain.GetHTTPProxy(routes[i].URL); err != nil {
+			return nil, err
+		}
+	}
+
+	return routes, nil
+}
+
+// DiagnoseNetwork diagnoses the module proxies leveraging the toolchain. Each
+// proxy is probed a fixed number of times, reporting the average latency of the
+// successful probes and the number of failed ones, and whether the proxy
+// rejected the credentials. It returns an error if the module proxies cannot be
+// determined.
+func (m *GoBinaryManager) DiagnoseNetwork(ctx context.Context) ([]model.ProxyHealth, error) {
+	proxies, err := m.toolchain.GetProxies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	healths := make([]model.ProxyHealth, 0, len(proxies))
+	for _, proxy := range proxies {
+		health := model.ProxyHealth{
+			Proxy:  proxy,
+			Probes: networkProbes,
+		}
+
+		var total time.Duration
+		for range networkProbes {
+			start := time.Now()
+			if err = m.toolchain.ProbeProxy(ctx, proxy); err != nil {
+				health.Errors++
+				health.Unauthorized = health.Unauthorized || errors.Is(err, toolchain.ErrProxyAuthentication)
+				continue
+			}
+
+			total += time.Since(start)
+		}
+
+		if successes := health.Probes - health.Errors; successes > 0 {
+			health.Latency = (total / time.Duration(successes)).Round(time.Millisecond)
+		}
+
+		healths = append(healths, health)
+	}
+
+	return healths, nil
+}
+
+// EstimateDownloadSize estimates the download size of the given packages
+// leveraging the toolchain, summing the zip file sizes of the distinct modules
+// providing them. Dependencies are not included. Packages whose module or
+// download size cannot be determined are counted as unknown.
+func (m *GoBinaryManager) EstimateDownloadSize(
+	ctx context.Context,
+	packages ...model.Package,
+) model.DownloadEstimate {
+	var estimate model.DownloadEstimate
+	seen := make(map[string]struct{}, len(packages))
+
+	for _, pkg := range packages {
+		mod, size, err := m.toolchain.GetPackageDownloadSize(ctx, pkg)
+
+		key := pkg.String()
+		if mod.Path != "" {
+			key = mod.String()
+		}
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		estimate.Modules++
+		if err != nil {
+			slog.Default().WarnContext(ctx, "error estimating download size", "package", pkg.String(), "err", err)
+			estimate.Unknown++
+			continue
+		}
+
+		estimate.Size += size
+	}
+
+	return estimate
+}
+
 // GetAllBinaryInfos gets all binary infos in the Go binary directory or managed
 // binaries only if managed is true. It returns a list of binary infos, or an
 // error if the binary directory cannot be determined or listed. It skips
@@ -198,6 +912,64 @@ func (m *GoBinaryManager) GetAllBinaryInfos(managed bool) ([]model.BinaryInfo, e
 	return binInfos, nil
 }
 
+// GetBinaryBuild gets the build of the binary for a given path leveraging the
+// toolchain. The version of a replaced dependency is the version of its
+// replacement, or the replacement path for a local replacement. It fails if
+// the binary does not exist or is not a Go binary.
+func (m *GoBinaryManager) GetBinaryBuild(path string) (model.BinaryBuild, error) {
+	info, err := m.toolchain.GetBuildInfo(path)
+	if err != nil {
+		return model.BinaryBuild{}, err
+	}
+
+	size, err := m.fs.GetFileSize(path)
+	if err != nil {
+		return model.BinaryBuild{}, err
+	}
+
+	build := model.BinaryBuild{
+		Module:    model.NewModule(info.Main.Path, model.NewVersion(info.Main.Version)),
+		GoVersion: info.GoVersion,
+		Settings:  make(map[string]string, len(info.Settings)),
+		Deps:      make(map[string]string, len(info.Deps)),
+		Size:      model.ByteSize(size),
+	}
+
+	for _, s := range info.Settings {
+		build.Settings[s.Key] = s.Value
+	}
+
+	for _, dep := range info.Deps {
+		switch {
+		case dep.Replace == nil:
+			build.Deps[dep.Path] = dep.Version
+		case dep.Replace.Version == "":
+			build.Deps[dep.Path] = dep.Replace.Path
+		default:
+			build.Deps[dep.Path] = dep.Replace.Version
+		}
+	}
+
+	return build, nil
+}
+
+// GetBinaryEnv gets the environment variables, in the form "name=value", set by
+// the exec shim of a binary in the Go binary directory, recorded in its receipt.
+// It returns an error if the binary cannot be found or the receipt cannot be
+// read.
+func (m *GoBinaryManager) GetBinaryEnv(bin model.Binary) ([]string, error) {
+	if _, err := m.GetBinaryInfo(filepath.Join(m.workspace.GetGoBinPath(), bin.String())); err != nil {
+		return nil, err
+	}
+
+	receipt, err := m.readReceipt(bin.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt.Env, nil
+}
+
 // GetBinaryInfo gets the binary info for a given path leveraging the toolchain.
 // It constructs the binary info from the binary's build info. It fails if the
 // binary does not exist, is not a Go binary, or the binary was built without
@@ -211,7 +983,7 @@ func (m *GoBinaryManager) GetBinaryInfo(path string) (model.BinaryInfo, error) {
 	}
 
 	installPath := path
-	target, err := m.fs.GetSymlinkTarget(path)
+	target, err := m.getPinTarget(path)
 	if err == nil {
 		installPath = target
 	}
@@ -227,8 +999,11 @@ func (m *GoBinaryManager) GetBinaryInfo(path string) (model.BinaryInfo, error) {
 		ModuleSum:   info.Main.Sum,
 		GoVersion:   info.GoVersion,
 		IsManaged:   strings.HasPrefix(installPath, internalBinPath),
+		IsDevBuild:  info.Main.Sum == "",
 	}
 
+	_, binInfo.IsCrossBuild = m.getCrossPlatform(path)
+
 	if strings.HasPrefix(path, internalBinPath) {
 		binPaths, listErr := m.fs.ListBinaries(m.workspace.GetGoBinPath())
 		if listErr != nil {
@@ -236,7 +1011,7 @@ func (m *GoBinaryManager) GetBinaryInfo(path string) (model.BinaryInfo, error) {
 		}
 
 		for _, bin := range binPaths {
-			if target, err = m.fs.GetSymlinkTarget(bin); err == nil {
+			if target, err = m.getPinTarget(bin); err == nil {
 				if target == path {
 					binInfo.IsPinned = true
 					break
@@ -258,6 +1033,7 @@ func (m *GoBinaryManager) GetBinaryInfo(path string) (model.BinaryInfo, error) {
 		default:
 			if strings.HasPrefix(s.Key, "GO") {
 				binInfo.Feature = s.Value
+				binInfo.EnvVars = append(binInfo.EnvVars, s.Key+"="+s.Value)
 			}
 			if strings.HasPrefix(s.Key, "CGO_") && s.Value != "" {
 				binInfo.EnvVars = append(binInfo.EnvVars, s.Key+"="+s.Value)
@@ -265,15 +1041,58 @@ func (m *GoBinaryManager) GetBinaryInfo(path string) (model.BinaryInfo, error) {
 		}
 	}
 
+	binInfo.BuildFlags = model.NewBuildFlags(info.Settings)
+
 	return binInfo, nil
 }
 
-// GetBinaryRepository gets the repository URL for a binary leveraging the
-// toolchain. It returns the repository URL from the module origin, falling back
-// to the default repository URL if the module origin is not available.
-func (m *GoBinaryManager) GetBinaryRepository(
-	ctx context.Context,
-	bin model.Binary,
+// GetBinaryOrigin gets the origin of the given binary in the Go binary
+// directory. It aggregates the binary info with the pin kind of the binary, the
+// metadata recorded in its receipt, and the modification time of its install
+// path as the time it was installed. The receipt is only read for the binaries
+// managed by gobin. It returns an error if the binary cannot be found or its
+// receipt cannot be read.
+func (m *GoBinaryManager) GetBinaryOrigin(bin model.Binary) (model.BinaryOrigin, error) {
+	info, err := m.GetBinaryInfo(filepath.Join(m.workspace.GetGoBinPath(), bin.String()))
+	if err != nil {
+		return model.BinaryOrigin{}, err
+	}
+
+	installedAt, err := m.fs.GetModTime(info.InstallPath)
+	if err != nil {
+		return model.BinaryOrigin{}, err
+	}
+
+	origin := model.BinaryOrigin{
+		BinaryInfo:  info,
+		Kind:        bin.GetPinKind(m.pinFormat),
+		InstalledAt: installedAt,
+	}
+
+	if !info.IsManaged {
+		return origin, nil
+	}
+
+	receipt, err := m.readReceipt(bin.String())
+	if err != nil {
+		return model.BinaryOrigin{}, err
+	}
+
+	origin.PinMode = m.pinMode
+	origin.PreviousVersion = receipt.PreviousVersion
+	origin.MigratedFrom = receipt.MigratedFrom
+	origin.Source = receipt.Source
+	origin.Protected = receipt.Protected
+
+	return origin, nil
+}
+
+// GetBinaryRepository gets the repository URL for a binary leveraging the
+// toolchain. It returns the repository URL from the module origin, falling back
+// to the default repository URL if the module origin is not available.
+func (m *GoBinaryManager) GetBinaryRepository(
+	ctx context.Context,
+	bin model.Binary,
 ) (string, error) {
 	binInfo, err := m.GetBinaryInfo(filepath.Join(m.workspace.GetGoBinPath(), bin.String()))
 	if err != nil {
@@ -295,6 +1114,64 @@ func (m *GoBinaryManager) GetBinaryRepository(
 	return repoURL, nil
 }
 
+// GetBinarySBOM gets the software bill of materials of the binary for a given
+// path from its build info leveraging the toolchain: the main module and the
+// modules it depends on, sorted by path. A replaced dependency is listed as its
+// replacement, the module actually compiled into the binary, or with its own
+// path and no version for a local replacement. It fails if the binary does not
+// exist or is not a Go binary.
+func (m *GoBinaryManager) GetBinarySBOM(path string) (model.SBOM, error) {
+	info, err := m.toolchain.GetBuildInfo(path)
+	if err != nil {
+		return model.SBOM{}, err
+	}
+
+	sbom := model.SBOM{
+		Name:       filepath.Base(path),
+		GoVersion:  info.GoVersion,
+		Main:       model.SBOMComponent{Path: info.Main.Path, Version: info.Main.Version, Sum: info.Main.Sum},
+		Components: make([]model.SBOMComponent, 0, len(info.Deps)),
+	}
+
+	for _, dep := range info.Deps {
+		switch {
+		case dep.Replace == nil:
+			sbom.Components = append(sbom.Components, model.SBOMComponent{
+				Path: dep.Path, Version: dep.Version, Sum: dep.Sum,
+			})
+		case dep.Replace.Version == "":
+			sbom.Components = append(sbom.Components, model.SBOMComponent{Path: dep.Path})
+		default:
+			sbom.Components = append(sbom.Components, model.SBOMComponent{
+				Path: dep.Replace.Path, Version: dep.Replace.Version, Sum: dep.Replace.Sum,
+			})
+		}
+	}
+
+	slices.SortFunc(sbom.Components, func(a, b model.SBOMComponent) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return sbom, nil
+}
+
+// GetBinarySizeHistory gets the size of each version installed to a binary in
+// the Go binary directory, recorded in its receipt in the order the versions
+// were installed. It returns an error if the binary cannot be found or the
+// receipt cannot be read.
+func (m *GoBinaryManager) GetBinarySizeHistory(bin model.Binary) (model.SizeHistory, error) {
+	if _, err := m.GetBinaryInfo(filepath.Join(m.workspace.GetGoBinPath(), bin.String())); err != nil {
+		return nil, err
+	}
+
+	receipt, err := m.readReceipt(bin.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt.SizeHistory, nil
+}
+
 // GetBinaryUpgradeInfo gets the upgrade information for a binary leveraging the
 // toolchain. It first checks if the binary has a minor version upgrade
 // available. Then, if the checkMajor flag is set, it checks if the binary has
@@ -310,8 +1187,15 @@ func (m *GoBinaryManager) GetBinaryUpgradeInfo(
 		BinaryInfo: info,
 	}
 
-	version := info.Binary.GetPinnedVersion()
-	mod, err := m.toolchain.GetLatestModuleVersion(ctx, model.NewModule(binUpInfo.Module.Path, version))
+	version := info.Binary.GetPinnedVersion(m.pinFormat)
+
+	module := model.NewModule(binUpInfo.Module.Path, version)
+	if binUpInfo.Module.Version.IsIncompatible() {
+		// +incompatible modules have no major version suffix in their path
+		module = model.Module{Path: binUpInfo.Module.Path, Version: version}
+	}
+
+	mod, err := m.toolchain.GetLatestModuleVersion(ctx, module)
 	if err != nil {
 		return model.BinaryUpgradeInfo{}, err
 	}
@@ -336,256 +1220,2962 @@ func (m *GoBinaryManager) GetBinaryUpgradeInfo(
 	return binUpInfo, nil
 }
 
-// InstallPackage installs a package leveraging the toolchain. If kind is major
-// or minor, it pins the binary to the Go binary directory with the given kind.
-// If rebuild is true, it rebuilds the binary.
-func (m *GoBinaryManager) InstallPackage(
-	ctx context.Context,
-	pkg model.Package,
-	kind model.Kind,
-	rebuild bool,
-) error {
-	logger := slog.Default().With("pkg", pkg.String())
+// GetBrokenPins gets the pins in the Go binary directory whose internal binary
+// is missing, ex. removed by hand from the internal binary directory. Only the
+// symlink pins are checked, as pin copies cannot break. It returns an error if
+// the Go binary directory cannot be listed.
+func (m *GoBinaryManager) GetBrokenPins() ([]string, error) {
+	if m.pinMode == model.PinModeCopy {
+		return nil, nil
+	}
 
-	tempDir := m.workspace.GetInternalTempPath()
-	binName := pkg.GetBinaryName()
+	symlinks, err := m.fs.ListSymlinks(m.workspace.GetGoBinPath())
+	if err != nil {
+		return nil, err
+	}
 
-	logger.InfoContext(ctx, "creating internal binary temp directory")
+	var pins []string
+	for _, symlink := range symlinks {
+		target, targetErr := m.fs.GetSymlinkTarget(symlink)
+		if targetErr != nil || filepath.Dir(target) != m.workspace.GetInternalBinPath() {
+			continue
+		}
 
-	binTempDir, cleanup, err := m.fs.CreateTempDir(tempDir, binName+"-*")
+		if !m.fs.Exists(target) {
+			pins = append(pins, symlink)
+		}
+	}
+
+	return pins, nil
+}
+
+// GetCacheArtifacts gets the managed binaries pinned to the Go binary directory
+// to serve from a binary cache server, keyed by module version and platform,
+// with the build flags recorded in their receipt. Development builds and
+// binaries compressed with UPX are skipped. It returns an error if the Go binary
+// directory cannot be listed.
+func (m *GoBinaryManager) GetCacheArtifacts() ([]model.CacheArtifact, error) {
+	binInfos, err := m.GetAllBinaryInfos(false)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer func() { _ = cleanup() }()
 
-	if err = m.toolchain.Install(ctx, binTempDir, pkg, rebuild); err != nil {
-		return err
+	seen := make(map[string]bool, len(binInfos))
+	artifacts := make([]model.CacheArtifact, 0, len(binInfos))
+	for _, info := range binInfos {
+		if !info.IsManaged || info.IsDevBuild || seen[info.InstallPath] {
+			continue
+		}
+
+		receipt, receiptErr := m.readReceipt(filepath.Base(info.FullPath))
+		if receiptErr != nil || receipt.Compression != (model.Compression{}) {
+			continue
+		}
+
+		seen[info.InstallPath] = true
+		artifacts = append(artifacts, model.CacheArtifact{
+			Name:       filepath.Base(info.InstallPath),
+			Package:    info.PackagePath,
+			Module:     info.Module.Path,
+			Version:    info.Module.Version.String(),
+			ModuleSum:  info.ModuleSum,
+			GoVersion:  info.GoVersion,
+			Platform:   info.OS + "/" + info.Arch,
+			BuildFlags: receipt.BuildFlags,
+			Path:       info.InstallPath,
+		})
 	}
 
-	var extension string
-	if m.runtime.OS() == "windows" {
-		extension = ".exe"
+	return artifacts, nil
+}
+
+// GetCrossBinaryInfos gets the binary infos of the binaries cross compiled for
+// another platform, stored in the platform directories of the internal binary
+// directory, ex. ~/.gobin/bin/linux_arm64. It returns no binary infos if the
+// internal binary directory does not exist, or an error if it cannot be listed.
+// It skips silently failures to get the binary info.
+func (m *GoBinaryManager) GetCrossBinaryInfos() ([]model.BinaryInfo, error) {
+	internalBinPath := m.workspace.GetInternalBinPath()
+	if !m.fs.Exists(internalBinPath) {
+		return nil, nil
 	}
-	tempBinPath := filepath.Join(binTempDir, binName+extension)
 
-	buildInfo, err := m.toolchain.GetBuildInfo(tempBinPath)
+	dirs, err := m.fs.ListDirs(internalBinPath)
 	if err != nil {
-		logger.ErrorContext(
-			ctx, "error while getting build info for internal binary",
-			"err", err, "temp_bin_path", tempBinPath,
-		)
-		return err
+		return nil, err
 	}
 
-	bin := model.NewBinary(binName, model.NewVersion(buildInfo.Main.Version), extension)
-	binPath := filepath.Join(m.workspace.GetInternalBinPath(), bin.String())
+	var binInfos []model.BinaryInfo
+	for _, dir := range dirs {
+		if _, ok := model.ParsePlatformDir(filepath.Base(dir)); !ok {
+			continue
+		}
 
-	logger.InfoContext(
-		ctx, "moving binary from temp path to bin path",
-		"temp_path", tempBinPath, "bin_path", binPath,
-	)
+		bins, listErr := m.fs.ListBinaries(dir)
+		if listErr != nil {
+			return nil, listErr
+		}
 
-	if err = m.fs.Move(tempBinPath, binPath); err != nil {
-		logger.ErrorContext(
-			ctx, "error while moving binary from temp path to bin path",
-			"err", err, "src", tempBinPath, "dst", binPath,
-		)
-		return err
+		for _, bin := range bins {
+			info, infoErr := m.GetBinaryInfo(bin)
+			if infoErr == nil {
+				binInfos = append(binInfos, info)
+			}
+		}
 	}
 
-	goBinPath := filepath.Join(m.workspace.GetGoBinPath(), bin.GetTargetBinName(kind))
+	return binInfos, nil
+}
 
-	logger.InfoContext(
-		ctx, "replacing existing symlink for binary",
-		"go_bin_path", goBinPath,
-	)
+// GetCrossOS gets the operating system sharing the Go binary directory through
+// WSL: windows when running under WSL with the Go binary directory in a
+// Windows drive mounted in /mnt, or linux when running on Windows with the Go
+// binary directory in a WSL distribution. It returns an empty string if the Go
+// binary directory is not shared.
+func (m *GoBinaryManager) GetCrossOS() string {
+	goBinPath := m.workspace.GetGoBinPath()
 
-	if err = m.fs.ReplaceSymlink(binPath, goBinPath); err != nil {
-		return err
+	switch m.runtime.OS() {
+	case "linux":
+		if wslDriveMountRegexp.MatchString(goBinPath) && m.runtime.IsWSL() {
+			return "windows"
+		}
+	case "windows":
+		lowerPath := strings.ToLower(goBinPath)
+		if strings.HasPrefix(lowerPath, `\\wsl$\`) || strings.HasPrefix(lowerPath, `\\wsl.localhost\`) {
+			return "linux"
+		}
 	}
 
-	return nil
+	return ""
 }
 
-// MigrateBinary migrates a binary to be managed internally. It gets the binary
-// info, moves the binary from the go bin path to the internal bin path, and
-// creates a symlink to the go bin path.
-func (m *GoBinaryManager) MigrateBinary(path string) error {
-	logger := slog.Default().With("path", path)
-
-	info, err := m.GetBinaryInfo(path)
+// GetDebugInfoPath gets the path of the debug information kept for the
+// stripped binary at the given path, keyed by its build ID. It returns
+// ErrDebugInfoNotFound if no debug information is kept for the build ID, or an
+// error if the build ID cannot be determined.
+func (m *GoBinaryManager) GetDebugInfoPath(ctx context.Context, path string) (string, error) {
+	buildID, err := m.toolchain.GetBuildID(ctx, path)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	if info.IsManaged {
-		return ErrBinaryAlreadyManaged
+	debugPath := m.getDebugInfoPath(model.NewBinaryFromString(filepath.Base(path)).Name, buildID)
+	if !m.fs.Exists(debugPath) {
+		slog.Default().WarnContext(ctx, "debug info not found", "path", path, "build_id", buildID)
+		return "", ErrDebugInfoNotFound
 	}
 
-	bin := model.NewBinary(info.Binary.Name, info.Module.Version, filepath.Ext(path))
-	internalBinPath := filepath.Join(m.workspace.GetInternalBinPath(), bin.String())
-
-	logger.Info(
-		"moving binary from go bin path to internal bin path",
-		"go_bin_path", path, "internal_bin_path", internalBinPath,
-	)
+	return debugPath, nil
+}
 
-	if err = m.fs.MoveWithSymlink(path, internalBinPath); err != nil {
-		return err
+// GetExpiredBinaries gets the versions of the binaries in the internal binary
+// directory not kept by the given retention policy: neither one of the latest
+// versions of their binary nor installed recently, and not targeted by any pin
+// in the Go binary directory. The install time of a version is the modification
+// time of its binary. It returns no binaries if the policy is not set or the
+// internal binary directory does not exist, or an error if the binaries cannot
+// be listed or their modification times cannot be read.
+func (m *GoBinaryManager) GetExpiredBinaries(policy model.RetentionConfig) ([]model.Binary, error) {
+	internalBinPath := m.workspace.GetInternalBinPath()
+	if !policy.IsSet() || !m.fs.Exists(internalBinPath) {
+		return nil, nil
 	}
 
-	return nil
-}
-
-// PinBinary pins a binary to the Go binary directory with the given kind. It
-// creates a symlink to the binary in the Go binary directory with names binary,
-// binary-major, or binary-major.minor if kind is latest, major, or minor
-// respectively.
-func (m *GoBinaryManager) PinBinary(bin model.Binary, kind model.Kind) error {
-	logger := slog.Default().With("bin", bin.String(), "kind", kind.String())
+	binPaths, err := m.fs.ListBinaries(internalBinPath)
+	if err != nil {
+		return nil, err
+	}
 
-	binPaths, err := m.fs.ListBinaries(m.workspace.GetInternalBinPath())
+	pinned, err := m.getPinTargets()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var matchPath string
-	var matchBin model.Binary
+	var names []string
+	versions := make(map[string][]model.Binary, len(binPaths))
 	for _, binPath := range binPaths {
-		intBin := model.NewBinaryFromString(filepath.Base(binPath))
-
-		if !intBin.IsPartOf(bin) {
-			continue
+		bin := model.NewBinaryFromString(filepath.Base(binPath))
+		if _, ok := versions[bin.GetBaseName()]; !ok {
+			names = append(names, bin.GetBaseName())
 		}
 
-		if matchPath == "" || intBin.Version.Compare(matchBin.Version) > 0 {
-			matchPath = binPath
-			matchBin = intBin
-		}
+		versions[bin.GetBaseName()] = append(versions[bin.GetBaseName()], bin)
 	}
 
-	if matchPath == "" {
-		logger.Warn("binary not found")
-		return toolchain.ErrBinaryNotFound
-	}
+	now := time.Now()
 
-	logger.Info("found binary to pin", "path", matchPath)
+	var expired []model.Binary
+	for _, name := range names {
+		bins := versions[name]
+		slices.SortFunc(bins, func(a, b model.Binary) int {
+			return b.Version.Compare(a.Version)
+		})
 
-	targetPath := filepath.Join(m.workspace.GetGoBinPath(), matchBin.GetTargetBinName(kind))
+		for rank, bin := range bins {
+			binPath := filepath.Join(internalBinPath, bin.String())
+			if pinned[binPath] {
+				continue
+			}
 
-	logger.Info("removing existing symlink for binary", "path", targetPath)
+			modTime, modErr := m.fs.GetModTime(binPath)
+			if modErr != nil {
+				return nil, modErr
+			}
 
-	if err = m.fs.ReplaceSymlink(matchPath, targetPath); err != nil {
-		return err
+			if !policy.IsRetained(rank, modTime, now) {
+				expired = append(expired, bin)
+			}
+		}
 	}
 
-	return nil
+	return expired, nil
 }
 
-// PruneBinary prunes binaries from the internal binary directory identified by
-// the given binary when not pinned. It returns an error if binaries cannot be
-// listed, retrieved, or removed.
-func (m *GoBinaryManager) PruneBinary(bin model.Binary) error {
-	logger := slog.Default().With("bin", bin.String())
+// GetGoEnv gets the environment settings of the Go toolchain leveraging the
+// toolchain. It returns an error if the settings cannot be determined.
+func (m *GoBinaryManager) GetGoEnv(ctx context.Context) (model.GoEnv, error) {
+	return m.toolchain.GetGoEnv(ctx)
+}
 
-	binPaths, err := m.fs.ListBinaries(m.workspace.GetInternalBinPath())
+// GetGoReleases gets the supported Go releases from the Go release feed
+// leveraging the toolchain. It returns an error if the feed cannot be read.
+func (m *GoBinaryManager) GetGoReleases(ctx context.Context) (model.GoReleases, error) {
+	return m.toolchain.GetGoReleases(ctx, goReleasesURL)
+}
+
+// GetLinkSource gets the local directory a binary in the Go binary directory is
+// linked to, recorded in its receipt when installed from a local package. It
+// returns ErrBinaryNotLinked if the binary was not installed from a local
+// package, or an error if the binary cannot be found or its receipt cannot be
+// read.
+func (m *GoBinaryManager) GetLinkSource(bin model.Binary) (string, error) {
+	receipt, err := m.readLinkReceipt(bin)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	for _, binPath := range binPaths {
-		intBin := model.NewBinaryFromString(filepath.Base(binPath))
-		if intBin.IsPartOf(bin) {
-			info, infoErr := m.GetBinaryInfo(binPath)
-			if infoErr != nil {
-				return infoErr
-			}
+	return receipt.Source, nil
+}
 
-			if info.IsPinned {
-				logger.Info("skipping prune for pinned binary", "internal_bin", intBin.String())
-				continue
-			}
+// GetLockedBinaries gets the managed binaries pinned to the Go binary
+// directory, with the package and version they are built from, their pin kind
+// and the extension of the pins on the current operating system, to write or
+// sync a lockfile. Development builds are skipped, as they cannot be
+// installed from the module proxy. It returns an error if the Go binary
+// directory cannot be listed.
+func (m *GoBinaryManager) GetLockedBinaries() ([]model.LockedBinary, error) {
+	binInfos, err := m.GetAllBinaryInfos(false)
+	if err != nil {
+		return nil, err
+	}
 
-			if err = m.fs.Remove(info.InstallPath); err != nil {
-				logger.Error("failed to remove binary", "err", err, "path", info.InstallPath)
-				return err
-			}
+	extension := model.GetBinaryExtension(m.runtime.OS())
+
+	bins := make([]model.LockedBinary, 0, len(binInfos))
+	for _, info := range binInfos {
+		if !info.IsManaged || info.IsDevBuild || info.PackagePath == "" {
+			continue
 		}
+
+		bins = append(bins, model.LockedBinary{
+			Name:      info.Binary.Name,
+			Package:   info.PackagePath,
+			Version:   info.Module.Version,
+			Kind:      info.Binary.GetPinKind(m.pinFormat),
+			Extension: extension,
+		})
 	}
 
-	return nil
+	return bins, nil
 }
 
-// UninstallBinary uninstalls a binary by removing the binary file. It removes
-// the binary from the go bin path for unmanaged binaries, or removes the
-// symlink for managed binaries. It returns an error if the binary cannot be
-// found or removed.
-func (m *GoBinaryManager) UninstallBinary(bin model.Binary) error {
-	logger := slog.Default().With("bin", bin.String())
+// GetModuleBuild gets the build a module would produce when installed,
+// leveraging the toolchain. The dependencies are the requirements of the module
+// file, and the Go version is the version of the local toolchain unless the
+// module requires a newer one. The build settings and the size are unknown
+// until the module is built. It fails if the module file cannot be retrieved.
+func (m *GoBinaryManager) GetModuleBuild(
+	ctx context.Context,
+	module model.Module,
+) (model.BinaryBuild, error) {
+	modFile, err := m.toolchain.GetModuleFile(ctx, module)
+	if err != nil {
+		return model.BinaryBuild{}, err
+	}
 
-	err := m.fs.Remove(filepath.Join(m.workspace.GetGoBinPath(), bin.String()))
-	if errors.Is(err, os.ErrNotExist) {
-		logger.Warn("binary not found")
-	} else if err != nil {
-		logger.Error("failed to remove binary", "err", err)
+	build := model.BinaryBuild{
+		Module:    module,
+		GoVersion: m.runtime.Version(),
+		Deps:      make(map[string]string, len(modFile.Require)),
 	}
 
-	return err
+	if modFile.Go != nil && goversion.Compare("go"+modFile.Go.Version, build.GoVersion) > 0 {
+		build.GoVersion = "go" + modFile.Go.Version
+	}
+	if modFile.Toolchain != nil && goversion.Compare(modFile.Toolchain.Name, build.GoVersion) > 0 {
+		build.GoVersion = modFile.Toolchain.Name
+	}
+
+	for _, req := range modFile.Require {
+		build.Deps[req.Mod.Path] = req.Mod.Version
+	}
+
+	return build, nil
 }
 
-// UpgradeBinary upgrades a binary leveraging the toolchain. It gets the binary
-// info and upgrade info, and installs the binary if an upgrade is available or
-// if the rebuild flag is set.
-func (m *GoBinaryManager) UpgradeBinary(
-	ctx context.Context,
-	binFullPath string,
-	majorUpgrade bool,
-	rebuild bool,
-) error {
-	info, err := m.GetBinaryInfo(binFullPath)
-	if err != nil {
-		return err
+// GetPinVersions gets the versions of the internal binaries the given pin can
+// target, from the newest to the oldest, marking the one it targets: all the
+// versions of the binary for a latest pin, ex. dlv, or the versions of the
+// pinned major or minor version, ex. the v1 versions for dlv-v1. It returns
+// toolchain.ErrBinaryNotFound if the pin is not found, ErrBinaryNotManaged if
+// it does not target an internal binary, or an error if the internal binary
+// directory cannot be listed.
+func (m *GoBinaryManager) GetPinVersions(bin model.Binary) ([]model.PinVersion, error) {
+	_, versions, err := m.listPinVersions(bin)
+	return versions, err
+}
+
+// GetRelatedPins gets the other pins in the Go binary directory targeting a
+// version of the same binary as the given pin. It returns no pins if the given
+// pin is not managed, or an error if the Go binary directory cannot be listed.
+func (m *GoBinaryManager) GetRelatedPins(bin model.Binary) ([]model.Binary, error) {
+	path := filepath.Join(m.workspace.GetGoBinPath(), bin.String())
+
+	pinnedBin, ok := m.getPinnedBinary(path)
+	if !ok {
+		return nil, nil
 	}
 
-	binUpInfo, err := m.GetBinaryUpgradeInfo(ctx, info, majorUpgrade)
+	pinPaths, err := m.getRelatedPinPaths(path, pinnedBin)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if binUpInfo.IsUpgradeAvailable || rebuild {
-		kind := binUpInfo.Binary.GetPinKind()
-		return m.InstallPackage(ctx, binUpInfo.GetUpgradePackage(), kind, rebuild)
+	pins := make([]model.Binary, 0, len(pinPaths))
+	for _, pinPath := range pinPaths {
+		pins = append(pins, model.NewBinaryFromString(filepath.Base(pinPath)))
 	}
 
-	return nil
+	return pins, nil
 }
 
-// diagnoseGoModFile diagnoses the Go module file for a given module and
-// version leveraging the toolchain. It returns the retracted and deprecated
-// information if available.
-func (m *GoBinaryManager) diagnoseGoModFile(
-	ctx context.Context,
-	module model.Module,
-) (string, string, error) {
-	modFile, err := m.toolchain.GetModuleFile(ctx, model.NewLatestModule(module.Path))
+// GetStoreUsage gets the disk usage of the internal binary directory per
+// binary, sorted by decreasing size: the number and size of its versions, and
+// of its stale versions, not targeted by any pin in the Go binary directory.
+// The binaries cross compiled in the platform directories are not included. It
+// returns no usage if the internal binary directory does not exist, or an error
+// if the binaries cannot be listed or their sizes cannot be read.
+func (m *GoBinaryManager) GetStoreUsage() (model.StoreUsage, error) {
+	internalBinPath := m.workspace.GetInternalBinPath()
+	if !m.fs.Exists(internalBinPath) {
+		return nil, nil
+	}
+
+	binPaths, err := m.fs.ListBinaries(internalBinPath)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
-	var retracted string
-	for _, r := range modFile.Retract {
-		if model.NewVersion(r.Low).Compare(module.Version) <= 0 &&
-			model.NewVersion(r.High).Compare(module.Version) >= 0 {
-			retracted = r.Rationale
+	pinned, err := m.getPinTargets()
+	if err != nil {
+		return nil, err
+	}
+
+	var usage model.StoreUsage
+	indexes := make(map[string]int, len(binPaths))
+	for _, binPath := range binPaths {
+		size, sizeErr := m.fs.GetFileSize(binPath)
+		if sizeErr != nil {
+			return nil, sizeErr
+		}
+
+		name := model.NewBinaryFromString(filepath.Base(binPath)).Name
+		idx, ok := indexes[name]
+		if !ok {
+			idx = len(usage)
+			indexes[name] = idx
+			usage = append(usage, model.BinaryUsage{Name: name})
+		}
+
+		usage[idx].Versions++
+		usage[idx].Size += model.ByteSize(size)
+		if !pinned[binPath] {
+			usage[idx].StaleVersions++
+			usage[idx].StaleSize += model.ByteSize(size)
 		}
 	}
 
-	var deprecated string
-	if modFile.Module != nil && modFile.Module.Deprecated != "" {
-		deprecated = modFile.Module.Deprecated
+	slices.SortFunc(usage, func(a, b model.BinaryUsage) int {
+		if a.Size != b.Size {
+			return cmp.Compare(b.Size, a.Size)
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return usage, nil
+}
+
+// GetSumDBConfig gets the checksum database configuration leveraging the
+// toolchain. It returns an error if the configuration cannot be determined.
+func (m *GoBinaryManager) GetSumDBConfig(ctx context.Context) (model.SumDBConfig, error) {
+	return m.toolchain.GetSumDBConfig(ctx)
+}
+
+// InstallPackage installs a package leveraging the toolchain. If kind is major
+// or minor, it pins the binary to the Go binary directory with the given kind.
+// If rebuild is true, it rebuilds the binary. The "previous" version resolves
+// to the version installed before the current one, and the "latest-N" version
+// resolves to the N-th release behind the latest one. The package is validated
+// against the module proxy before being installed, and branch or tag refs are
+// resolved to the version served by the proxy, usually a pseudo-version. If
+// universal is true, it builds a macOS universal binary for amd64 and arm64,
+// only supported on macOS. The given build flags, ex. the GOAMD64 variant, are
+// recorded in the pin receipt to rebuild the binary with them on upgrade. If
+// cacheFrom is not empty, the binary is downloaded from the binary cache server,
+// started with gobin serve-cache, at that URL when it serves a binary of the
+// package version built for the runtime platform with the same build flags.
+func (m *GoBinaryManager) InstallPackage(
+	ctx context.Context,
+	pkg model.Package,
+	kind model.Kind,
+	flags model.BuildFlags,
+	rebuild bool,
+	universal bool,
+	cacheFrom string,
+) error {
+	ctx, span := internal.StartSpan(ctx, "InstallPackage", attribute.String("gobin.package", pkg.String()))
+	defer span.End()
+
+	if universal && m.runtime.OS() != "darwin" {
+		return internal.RecordSpanError(span, ErrUniversalNotSupported)
+	}
+
+	pkg, err := m.resolvePackageVersion(ctx, pkg)
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	info, err := m.validatePackage(ctx, pkg)
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	if pkg.IsRef() {
+		slog.Default().InfoContext(ctx, "resolved ref", "pkg", pkg.String(), "version", info.Module.Version.String())
+		pkg = model.NewPackageWithVersion(pkg.Path, info.Module.Version)
+	}
+
+	return internal.RecordSpanError(
+		span, m.installPackage(ctx, pkg, info.Module.Version, kind, flags, rebuild, universal, cacheFrom),
+	)
+}
+
+// InstallLocalPackage installs the main package of the given local directory,
+// ex. a tool under development, as a development build. The package is built
+// within the directory, honoring the go.work file of an enclosing workspace and
+// the replace directives of its module, stored in the internal binary directory
+// under the version stamped by the toolchain, and pinned with the latest kind,
+// replacing the pin of a released version. The directory is recorded in the pin
+// receipt, linking the binary to it for RelinkBinary. It returns
+// ErrPackageNotMain if the directory holds no main package, or an error if the
+// package cannot be built or the binary cannot be stored and pinned.
+func (m *GoBinaryManager) InstallLocalPackage(ctx context.Context, dir string, flags model.BuildFlags) error {
+	ctx, span := internal.StartSpan(ctx, "InstallLocalPackage", attribute.String("gobin.dir", dir))
+	defer span.End()
+
+	logger := slog.Default().With("dir", dir)
+
+	binTempDir, cleanup, err := m.fs.CreateTempDir(m.workspace.GetInternalTempPath(), "local-*")
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+	defer func() { _ = cleanup() }()
+
+	if err = m.toolchain.InstallLocal(ctx, binTempDir, dir, flags); err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	tempBinPaths, err := m.fs.ListBinaries(binTempDir)
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	if len(tempBinPaths) == 0 {
+		logger.WarnContext(ctx, "no binary installed from local package")
+		return internal.RecordSpanError(span, ErrPackageNotMain)
+	}
+
+	tempBinPath := tempBinPaths[0]
+
+	buildInfo, err := m.toolchain.GetBuildInfo(tempBinPath)
+	if err != nil {
+		logger.ErrorContext(
+			ctx, "error while getting build info for internal binary",
+			"err", err, "temp_bin_path", tempBinPath,
+		)
+		return internal.RecordSpanError(span, err)
+	}
+
+	goos := m.runtime.OS()
+	extension := model.GetBinaryExtension(goos)
+	binName := strings.TrimSuffix(filepath.Base(tempBinPath), extension)
+
+	bin := model.NewBinary(binName, model.NewVersion(buildInfo.Main.Version), extension)
+	binPath := filepath.Join(m.workspace.GetInternalBinPath(), bin.String())
+	goBinPath := filepath.Join(m.workspace.GetGoBinPath(), bin.GetTargetBinName(model.KindLatest, m.pinFormat))
+
+	if err = m.checkNameCollision(goos, binPath); err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	if err = m.checkNameCollision(goos, goBinPath); err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	if err = m.storeBinary(ctx, goos, tempBinPath, binPath, goBinPath, flags); err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	return internal.RecordSpanError(span, m.recordSource(goBinPath, dir))
+}
+
+// InstallPackagePlatform installs a package cross compiled for the given
+// platform, ex. linux/arm64, leveraging the toolchain, in the platform directory
+// of the internal binary directory, ex. ~/.gobin/bin/linux_arm64, named
+// <binary>@<version>, with the .exe extension for windows. The package version
+// is resolved and validated as by InstallPackage, and the given build flags,
+// ex. the GOARM64 variant, are applied. The binary is not pinned to the Go
+// binary directory, as it is not meant to run on the current platform. It
+// returns the path of the binary, or an error if the package cannot be
+// resolved, built or moved.
+func (m *GoBinaryManager) InstallPackagePlatform(
+	ctx context.Context,
+	pkg model.Package,
+	platform string,
+	flags model.BuildFlags,
+	rebuild bool,
+) (string, error) {
+	ctx, span := internal.StartSpan(
+		ctx,
+		"InstallPackagePlatform",
+		attribute.String("gobin.package", pkg.String()),
+		attribute.String("gobin.platform", platform),
+	)
+	defer span.End()
+
+	logger := slog.Default().With("pkg", pkg.String(), "platform", platform)
+
+	pkg, err := m.resolvePackageVersion(ctx, pkg)
+	if err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	info, err := m.validatePackage(ctx, pkg)
+	if err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	// install the resolved version, also the one the binary is named after
+	pkg = model.NewPackageWithVersion(pkg.Path, info.Module.Version)
+
+	binName := pkg.GetBinaryName()
+	binTempDir, cleanup, err := m.fs.CreateTempDir(m.workspace.GetInternalTempPath(), binName+"-*")
+	if err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+	defer func() { _ = cleanup() }()
+
+	if err = m.toolchain.InstallPlatform(ctx, binTempDir, pkg, platform, flags, rebuild); err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	goos, goarch, _ := strings.Cut(platform, "/")
+	extension := model.GetBinaryExtension(goos)
+
+	source := filepath.Join(binTempDir, "bin", binName+extension)
+	if platform != m.runtime.Platform() {
+		source = filepath.Join(binTempDir, "bin", goos+"_"+goarch, binName+extension)
+	}
+
+	dir := filepath.Join(m.workspace.GetInternalBinPath(), model.GetPlatformDir(platform))
+	target := filepath.Join(dir, model.NewBinary(binName, pkg.Version, extension).String())
+
+	defer internal.TrackPhase(ctx, internal.PhaseLink)()
+
+	//nolint:mnd // directory permissions
+	if err = m.fs.CreateDir(dir, 0755); err != nil {
+		logger.ErrorContext(ctx, "error while creating platform directory", "err", err, "dir", dir)
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	if err = m.fs.Move(source, target); err != nil {
+		logger.ErrorContext(
+			ctx, "error while moving binary to platform directory",
+			"err", err, "src", source, "dst", target,
+		)
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	return target, nil
+}
+
+// MigrateBinary migrates a binary to be managed internally. It gets the binary
+// info, moves the binary from its path to the internal bin path, and creates a
+// symlink in the go bin path. Binaries outside the go bin path are adopted
+// only if no binary with the same name exists in the go bin path. The
+// pre-migration path is recorded in the binary receipt.
+func (m *GoBinaryManager) MigrateBinary(path string) error {
+	logger := slog.Default().With("path", path)
+
+	info, err := m.GetBinaryInfo(path)
+	if err != nil {
+		return err
+	}
+
+	if info.IsManaged {
+		return ErrBinaryAlreadyManaged
+	}
+
+	bin := model.NewBinary(info.Binary.Name, info.Module.Version, filepath.Ext(path))
+	internalBinPath := filepath.Join(m.workspace.GetInternalBinPath(), bin.String())
+	goBinPath := filepath.Join(m.workspace.GetGoBinPath(), filepath.Base(path))
+
+	if goBinPath == path {
+		logger.Info(
+			"moving binary from go bin path to internal bin path",
+			"go_bin_path", path, "internal_bin_path", internalBinPath,
+		)
+
+		if m.pinMode == model.PinModeCopy {
+			err = m.fs.Move(path, internalBinPath)
+			if err == nil {
+				err = m.linkPin(internalBinPath, path)
+			}
+		} else {
+			err = m.fs.MoveWithSymlink(path, internalBinPath)
+		}
+		if err != nil {
+			return err
+		}
+
+		return m.recordMigration(path, info.BuildFlags)
+	}
+
+	if _, err = m.toolchain.GetBuildInfo(goBinPath); !errors.Is(err, toolchain.ErrBinaryNotFound) {
+		logger.Warn("binary already exists in go bin path", "go_bin_path", goBinPath)
+		return ErrBinaryAlreadyExists
+	}
+
+	logger.Info(
+		"adopting binary into internal bin path",
+		"internal_bin_path", internalBinPath, "go_bin_path", goBinPath,
+	)
+
+	if err = m.fs.Move(path, internalBinPath); err != nil {
+		return err
+	}
+
+	if err = m.linkPin(internalBinPath, goBinPath); err != nil {
+		return err
+	}
+
+	return m.recordMigration(path, info.BuildFlags)
+}
+
+// PinBinary pins a binary to the Go binary directory with the given kind. It
+// creates a symlink to the binary (or a copy, in copy pin mode) in the Go
+// binary directory with names binary, binary-major, or binary-major.minor if
+// kind is latest, major, or minor respectively. The "previous" version resolves to the version pinned before
+// the current one. On macOS and Windows, it refuses pins whose name differs
+// only in letter case from an existing binary.
+func (m *GoBinaryManager) PinBinary(bin model.Binary, kind model.Kind) error {
+	logger := slog.Default().With("bin", bin.String(), "kind", kind.String())
+
+	if bin.Version.IsPrevious() {
+		version, err := m.getPreviousVersion(bin.GetBaseName())
+		if err != nil {
+			return err
+		}
+
+		bin = model.NewBinary(bin.Name, version, bin.Extension)
+	}
+
+	binPaths, err := m.fs.ListBinaries(m.workspace.GetInternalBinPath())
+	if err != nil {
+		return err
+	}
+
+	var matchPath string
+	var matchBin model.Binary
+	for _, binPath := range binPaths {
+		intBin := model.NewBinaryFromString(filepath.Base(binPath))
+
+		if !intBin.IsPartOf(bin) {
+			continue
+		}
+
+		if matchPath == "" || intBin.Version.Compare(matchBin.Version) > 0 {
+			matchPath = binPath
+			matchBin = intBin
+		}
+	}
+
+	if matchPath == "" {
+		logger.Warn("binary not found")
+		return toolchain.ErrBinaryNotFound
+	}
+
+	logger.Info("found binary to pin", "path", matchPath)
+
+	targetPath := filepath.Join(m.workspace.GetGoBinPath(), matchBin.GetTargetBinName(kind, m.pinFormat))
+	if err = m.checkNameCollision(m.runtime.OS(), targetPath); err != nil {
+		return err
+	}
+
+	logger.Info("removing existing symlink for binary", "path", targetPath)
+
+	return m.replacePin(matchPath, targetPath)
+}
+
+// PlanInstallPackage plans the install of a package without building it,
+// resolving its version and validating it against the module proxy like
+// InstallPackage. It returns the actions the install would take: building the
+// binary into the internal binary directory with the given build flags and
+// pinning it to the Go binary directory with the given kind, replacing the
+// binary of the same name if any.
+func (m *GoBinaryManager) PlanInstallPackage(
+	ctx context.Context,
+	pkg model.Package,
+	kind model.Kind,
+	flags model.BuildFlags,
+) ([]model.Action, error) {
+	pkg, err := m.resolvePackageVersion(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := m.validatePackage(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.planInstall(model.NewPackageWithVersion(pkg.Path, info.Module.Version), kind, flags), nil
+}
+
+// PlanPruneBinary plans the prune of the binaries from the internal binary
+// directory identified by the given binary, without removing them. It returns
+// the actions removing the binaries not pinned. If the binary is protected, it
+// refuses to prune unless force is set. It returns an error if binaries cannot
+// be listed or retrieved.
+func (m *GoBinaryManager) PlanPruneBinary(bin model.Binary, force bool) ([]model.Action, error) {
+	logger := slog.Default().With("bin", bin.String())
+
+	if err := m.checkProtection(bin.GetBaseName(), force); err != nil {
+		return nil, err
+	}
+
+	binPaths, err := m.fs.ListBinaries(m.workspace.GetInternalBinPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []model.Action
+	for _, binPath := range binPaths {
+		intBin := model.NewBinaryFromString(filepath.Base(binPath))
+		if !intBin.IsPartOf(bin) {
+			continue
+		}
+
+		info, infoErr := m.GetBinaryInfo(binPath)
+		if infoErr != nil {
+			return nil, infoErr
+		}
+
+		if info.IsPinned {
+			logger.Info("skipping prune for pinned binary", "internal_bin", intBin.String())
+			continue
+		}
+
+		actions = append(actions, model.Action{Type: model.ActionRemove, Path: info.InstallPath})
+	}
+
+	return actions, nil
+}
+
+// PlanUninstallBinary plans the uninstall of a binary without removing it. It
+// returns the actions removing the binary, or its pin for managed binaries,
+// from the Go binary directory. If purge is set, they also remove the other
+// pins referencing the binary, every version of the binary in the internal
+// binary directory and the data generated by gobin for the binary. If the
+// binary, or any of the other pins when purging, is protected, it refuses to
+// uninstall unless force is set. It returns os.ErrNotExist if the binary is not
+// found.
+func (m *GoBinaryManager) PlanUninstallBinary(bin model.Binary, force, purge bool) ([]model.Action, error) {
+	if err := m.checkProtection(bin.String(), force); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(m.workspace.GetGoBinPath(), bin.String())
+	if !m.fs.Exists(path) {
+		return nil, os.ErrNotExist
+	}
+
+	if !purge {
+		return []model.Action{{Type: model.ActionRemove, Path: path}}, nil
+	}
+
+	var actions []model.Action
+	name := model.NewBinaryFromString(filepath.Base(path)).Name
+	if intBin, ok := m.getPinnedBinary(path); ok {
+		name = intBin.Name
+
+		pins, err := m.getRelatedPinPaths(path, intBin)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pin := range pins {
+			if err = m.checkProtection(filepath.Base(pin), force); err != nil {
+				return nil, err
+			}
+
+			actions = append(actions, model.Action{Type: model.ActionRemove, Path: pin})
+		}
+
+		binPaths, err := m.fs.ListBinaries(m.workspace.GetInternalBinPath())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, binPath := range binPaths {
+			if model.NewBinaryFromString(filepath.Base(binPath)).IsPartOf(intBin) {
+				actions = append(actions, model.Action{Type: model.ActionRemove, Path: binPath})
+			}
+		}
+	}
+
+	actions = append(actions, model.Action{Type: model.ActionRemove, Path: path})
+
+	if dataPath := filepath.Join(m.workspace.GetInternalDataPath(), name); m.fs.Exists(dataPath) {
+		actions = append(actions, model.Action{Type: model.ActionRemove, Path: dataPath})
+	}
+
+	return actions, nil
+}
+
+// PlanUpgradeBinary plans the upgrade of a binary without building it. It gets
+// the binary info and upgrade info like UpgradeBinary, and returns the actions
+// installing the binary if an upgrade is available or if the rebuild flag is
+// set, or none otherwise, with the build flags recorded in the receipt of the
+// binary overridden by the given build flags. If the binary is protected, it
+// refuses to upgrade unless force is set.
+func (m *GoBinaryManager) PlanUpgradeBinary(
+	ctx context.Context,
+	binFullPath string,
+	flags model.BuildFlags,
+	majorUpgrade bool,
+	rebuild bool,
+	force bool,
+) ([]model.Action, error) {
+	info, err := m.GetBinaryInfo(binFullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	_, binUpInfo, err := m.getUpgradeInfo(ctx, info, majorUpgrade)
+	if err != nil {
+		return nil, err
+	}
+
+	if !binUpInfo.IsUpgradeAvailable && !rebuild {
+		return nil, nil
+	}
+
+	receipt, err := m.readReceipt(filepath.Base(binFullPath))
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.Protected && !force {
+		return nil, ErrBinaryProtected
+	}
+
+	return m.planInstall(
+		binUpInfo.GetUpgradePackage(), binUpInfo.Binary.GetPinKind(m.pinFormat), receipt.BuildFlags.Merge(flags),
+	), nil
+}
+
+// ProtectBinary sets the protection of a binary in the Go binary directory by
+// updating its receipt. Protected binaries cannot be upgraded, pruned or
+// uninstalled unless the operation is forced. It returns an error if the binary
+// cannot be found or the receipt cannot be read or written.
+func (m *GoBinaryManager) ProtectBinary(bin model.Binary, protected bool) error {
+	logger := slog.Default().With("bin", bin.String(), "protected", protected)
+
+	if _, err := m.GetBinaryInfo(filepath.Join(m.workspace.GetGoBinPath(), bin.String())); err != nil {
+		return err
+	}
+
+	receipt, err := m.readReceipt(bin.String())
+	if err != nil {
+		return err
+	}
+
+	logger.Info("updating binary protection")
+
+	receipt.Protected = protected
+
+	return m.writeReceipt(receipt)
+}
+
+// PruneBinary prunes binaries from the internal binary directory identified by
+// the given binary when not pinned. If the binary is protected, it refuses to
+// prune unless force is set. It returns an error if binaries cannot be listed,
+// retrieved, or removed.
+func (m *GoBinaryManager) PruneBinary(bin model.Binary, force bool) error {
+	logger := slog.Default().With("bin", bin.String())
+
+	actions, err := m.PlanPruneBinary(bin, force)
+	if err != nil {
+		return err
+	}
+
+	for _, action := range actions {
+		if err = m.fs.Remove(action.Path); err != nil {
+			logger.Error("failed to remove binary", "err", err, "path", action.Path)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PullBinary pulls the binary artifact with the given reference from an OCI
+// registry, verifies it was built for the runtime platform and matches the
+// module and version of its annotations, moves it to the internal bin path and
+// pins it with the given kind. The build flags annotated are recorded in the
+// pin receipt. It returns the binary, or an error if the artifact cannot be
+// pulled, is invalid or was built for another platform.
+func (m *GoBinaryManager) PullBinary(
+	ctx context.Context,
+	ref model.OCIReference,
+	kind model.Kind,
+) (model.Binary, error) {
+	ctx, span := internal.StartSpan(ctx, "PullBinary", attribute.String("gobin.ref", ref.String()))
+	defer span.End()
+
+	logger := slog.Default().With("ref", ref.String())
+
+	artifact, err := m.registry.Pull(ctx, ref)
+	if err != nil {
+		return model.Binary{}, internal.RecordSpanError(span, err)
+	}
+
+	annotations := artifact.Annotations
+	name, version := annotations[annotationTitle], model.NewVersion(annotations[annotationVersion])
+	if name == "" || name != filepath.Base(name) || version.String() == "" {
+		logger.Error("error pulling binary", "err", registry.ErrArtifactInvalid)
+		return model.Binary{}, internal.RecordSpanError(span, registry.ErrArtifactInvalid)
+	}
+
+	if platform := annotations[annotationPlatform]; platform != m.runtime.Platform() {
+		logger.Error("binary built for another platform", "platform", platform)
+		return model.Binary{}, internal.RecordSpanError(span, ErrPlatformMismatch)
+	}
+
+	bin := model.NewBinary(name, version, model.GetBinaryExtension(m.runtime.OS()))
+
+	tempDir, cleanup, err := m.fs.CreateTempDir(m.workspace.GetInternalTempPath(), name+"-*")
+	if err != nil {
+		return model.Binary{}, internal.RecordSpanError(span, err)
+	}
+	defer func() { _ = cleanup() }()
+
+	tempPath := filepath.Join(tempDir, bin.String())
+	//nolint:mnd // executable permissions
+	if err = m.fs.WriteFile(tempPath, artifact.Data, 0755); err != nil {
+		logger.Error("error writing binary", "err", err, "path", tempPath)
+		return model.Binary{}, internal.RecordSpanError(span, err)
+	}
+
+	info, err := m.toolchain.GetBuildInfo(tempPath)
+	if err != nil {
+		return model.Binary{}, internal.RecordSpanError(span, err)
+	}
+
+	if info.Main.Path != annotations[annotationModule] || info.Main.Version != version.String() {
+		logger.Error("binary does not match its annotations", "module", info.Main.Path, "version", info.Main.Version)
+		return model.Binary{}, internal.RecordSpanError(span, registry.ErrArtifactInvalid)
+	}
+
+	internalBinPath := filepath.Join(m.workspace.GetInternalBinPath(), bin.String())
+	if err = m.fs.Move(tempPath, internalBinPath); err != nil {
+		logger.Error("error moving binary to internal bin path", "err", err, "path", internalBinPath)
+		return model.Binary{}, internal.RecordSpanError(span, err)
+	}
+
+	if err = m.PinBinary(bin, kind); err != nil {
+		return model.Binary{}, internal.RecordSpanError(span, err)
+	}
+
+	if data, ok := annotations[annotationBuildFlags]; ok {
+		var flags model.BuildFlags
+		if err = json.Unmarshal([]byte(data), &flags); err != nil {
+			logger.Warn("ignoring invalid build flags annotation", "err", err)
+		} else if flags != (model.BuildFlags{}) {
+			pinPath := filepath.Join(m.workspace.GetGoBinPath(), bin.GetTargetBinName(kind, m.pinFormat))
+			if err = m.recordBuildFlags(pinPath, flags); err != nil {
+				return model.Binary{}, internal.RecordSpanError(span, err)
+			}
+		}
+	}
+
+	return bin, nil
+}
+
+// PushBinary pushes the managed binary at the given path to an OCI registry
+// with the given reference, annotated with its name, package, module, version,
+// checksum, Go version, platform and build flags. It returns an error if the
+// binary is not managed or cannot be read or pushed.
+func (m *GoBinaryManager) PushBinary(ctx context.Context, path string, ref model.OCIReference) error {
+	ctx, span := internal.StartSpan(
+		ctx,
+		"PushBinary",
+		attribute.String("gobin.binary", filepath.Base(path)),
+		attribute.String("gobin.ref", ref.String()),
+	)
+	defer span.End()
+
+	info, err := m.GetBinaryInfo(path)
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	if !info.IsManaged {
+		return internal.RecordSpanError(span, ErrBinaryNotManaged)
+	}
+
+	data, err := m.fs.ReadFile(info.InstallPath)
+	if err != nil {
+		slog.Default().Error("error reading binary", "err", err, "path", info.InstallPath)
+		return internal.RecordSpanError(span, err)
+	}
+
+	flags, err := json.Marshal(info.BuildFlags)
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	return internal.RecordSpanError(span, m.registry.Push(ctx, ref, model.OCIArtifact{
+		Data: data,
+		Annotations: map[string]string{
+			annotationBuildFlags: string(flags),
+			annotationGoVersion:  info.GoVersion,
+			annotationModule:     info.Module.Path,
+			annotationModuleSum:  info.ModuleSum,
+			annotationPackage:    info.PackagePath,
+			annotationPlatform:   info.OS + "/" + info.Arch,
+			annotationTitle:      model.NewBinaryFromString(filepath.Base(info.InstallPath)).Name,
+			annotationVersion:    info.Module.Version.String(),
+		},
+	}))
+}
+
+// RebuildBinary rebuilds the managed binary at the given path at its installed
+// version leveraging the toolchain, with the current Go toolchain and for the
+// current platform, keeping its pin kind and the build flags recorded in its
+// receipt. It returns ErrBinaryProtected if the binary is protected, or an
+// error if the binary info or receipt cannot be read or the binary cannot be
+// rebuilt.
+func (m *GoBinaryManager) RebuildBinary(ctx context.Context, path string) error {
+	ctx, span := internal.StartSpan(ctx, "RebuildBinary", attribute.String("gobin.binary", filepath.Base(path)))
+	defer span.End()
+
+	info, err := m.GetBinaryInfo(path)
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	receipt, err := m.readReceipt(filepath.Base(path))
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	if receipt.Protected {
+		slog.Default().WarnContext(ctx, "binary is protected", "bin", receipt.Name)
+		return internal.RecordSpanError(span, ErrBinaryProtected)
+	}
+
+	pkg := model.NewPackageWithVersion(info.PackagePath, info.Module.Version)
+	kind := info.Binary.GetPinKind(m.pinFormat)
+
+	return internal.RecordSpanError(
+		span, m.installPackage(ctx, pkg, info.Module.Version, kind, receipt.BuildFlags, true, false, ""),
+	)
+}
+
+// RelinkBinary rebuilds a binary in the Go binary directory from the local
+// directory it is linked to, with the build flags recorded in its receipt
+// overridden by the given build flags, ex. the compress flag. It returns
+// ErrBinaryNotLinked if the binary was not installed from a local package, or
+// an error if the binary cannot be rebuilt.
+func (m *GoBinaryManager) RelinkBinary(ctx context.Context, bin model.Binary, flags model.BuildFlags) error {
+	receipt, err := m.readLinkReceipt(bin)
+	if err != nil {
+		return err
+	}
+
+	return m.InstallLocalPackage(ctx, receipt.Source, receipt.BuildFlags.Merge(flags))
+}
+
+// RemoveStaleExecutable removes the executable renamed aside when the
+// executable at the given path was replaced while running by UpdateExecutable,
+// if any. It returns an error if the executable exists but cannot be removed.
+func (m *GoBinaryManager) RemoveStaleExecutable(path string) error {
+	oldPath := path + oldExecutableSuffix
+	if !m.fs.Exists(oldPath) {
+		return nil
+	}
+
+	slog.Default().Info("removing stale executable", "path", oldPath)
+
+	return m.fs.Remove(oldPath)
+}
+
+// RepinBinary pins the broken pin at the given path, whose internal binary is
+// missing, to the latest internal binary matching the pin name and kind, ex.
+// dlv-v1 to the latest dlv@v1.x.y binary. It returns ErrBinaryNotFound if no
+// internal binary matches the pin, or an error if the pin cannot be replaced.
+func (m *GoBinaryManager) RepinBinary(path string) error {
+	pin := model.NewBinaryFromString(filepath.Base(path))
+	name, version := m.pinFormat.Parse(pin.Name)
+
+	return m.PinBinary(model.NewBinary(name, version, pin.Extension), pin.GetPinKind(m.pinFormat))
+}
+
+// ReproduceBinary rebuilds the managed binary at the given path in a temporary
+// directory with the same package version, build flags, recorded in its build
+// info and receipt, Go toolchain and platform, and compares the SHA-256 hashes
+// of the installed and rebuilt binaries, along with their build settings. The
+// installed binary is left untouched. It returns the reproduction, or an error
+// if the binary is not managed or cannot be rebuilt or read.
+func (m *GoBinaryManager) ReproduceBinary(ctx context.Context, path string) (model.BinaryReproduction, error) {
+	ctx, span := internal.StartSpan(ctx, "ReproduceBinary", attribute.String("gobin.binary", filepath.Base(path)))
+	defer span.End()
+
+	info, err := m.GetBinaryInfo(path)
+	if err != nil {
+		return model.BinaryReproduction{}, internal.RecordSpanError(span, err)
+	}
+
+	if !info.IsManaged {
+		return model.BinaryReproduction{}, internal.RecordSpanError(span, ErrBinaryNotManaged)
+	}
+
+	receipt, err := m.readReceipt(filepath.Base(path))
+	if err != nil {
+		return model.BinaryReproduction{}, internal.RecordSpanError(span, err)
+	}
+
+	pkg := model.NewPackageWithVersion(info.PackagePath, info.Module.Version)
+	if goversion.IsValid(info.GoVersion) {
+		ctx = toolchain.WithGoVersion(ctx, info.GoVersion)
+	}
+
+	dir, cleanup, err := m.fs.CreateTempDir(m.workspace.GetInternalTempPath(), "reproduce-*")
+	if err != nil {
+		return model.BinaryReproduction{}, internal.RecordSpanError(span, err)
+	}
+	defer func() { _ = cleanup() }()
+
+	flags := info.BuildFlags.Merge(receipt.BuildFlags)
+	rebuiltPath, err := m.BuildPackage(ctx, pkg, info.OS+"/"+info.Arch, flags, dir)
+	if err != nil {
+		return model.BinaryReproduction{}, internal.RecordSpanError(span, err)
+	}
+
+	reproduction := model.BinaryReproduction{
+		Name:      filepath.Base(info.InstallPath),
+		Package:   pkg,
+		GoVersion: info.GoVersion,
+	}
+
+	if reproduction.InstalledHash, err = m.getFileHash(info.InstallPath); err != nil {
+		return model.BinaryReproduction{}, internal.RecordSpanError(span, err)
+	}
+
+	if reproduction.RebuiltHash, err = m.getFileHash(rebuiltPath); err != nil {
+		return model.BinaryReproduction{}, internal.RecordSpanError(span, err)
+	}
+
+	if !reproduction.IsReproducible() {
+		installed, buildErr := m.GetBinaryBuild(info.InstallPath)
+		if buildErr != nil {
+			return model.BinaryReproduction{}, internal.RecordSpanError(span, buildErr)
+		}
+
+		rebuilt, buildErr := m.GetBinaryBuild(rebuiltPath)
+		if buildErr != nil {
+			return model.BinaryReproduction{}, internal.RecordSpanError(span, buildErr)
+		}
+
+		reproduction.Settings = installed.DiffSettings(rebuilt)
+	}
+
+	return reproduction, nil
+}
+
+// ResolvePackage resolves the version of a package to the module version it
+// would be installed at, so several builds of the package use the same version.
+// The "previous" and "latest-N" versions are resolved as when installing, and
+// the package is validated against the module proxy. It returns an error if
+// the version cannot be resolved or the package is not valid.
+func (m *GoBinaryManager) ResolvePackage(
+	ctx context.Context,
+	pkg model.Package,
+) (model.Package, error) {
+	pkg, err := m.resolvePackageVersion(ctx, pkg)
+	if err != nil {
+		return model.Package{}, err
+	}
+
+	info, err := m.validatePackage(ctx, pkg)
+	if err != nil {
+		return model.Package{}, err
+	}
+
+	return model.NewPackageWithVersion(pkg.Path, info.Module.Version), nil
+}
+
+// RollbackBinary pins the given pin to the version of the internal binary
+// preceding the one it targets, the newest older version still in the internal
+// binary directory matching the pin kind, as listed by GetPinVersions. The
+// version replaced is recorded as the previous version, so the rollback can be
+// undone with the "previous" version. It returns the version pinned, or
+// ErrRollbackVersionNotFound if no older version is available, or an error if
+// the versions cannot be listed or the pin cannot be replaced.
+func (m *GoBinaryManager) RollbackBinary(bin model.Binary) (model.Version, error) {
+	logger := slog.Default().With("bin", bin.String())
+
+	intBin, versions, err := m.listPinVersions(bin)
+	if err != nil {
+		return "", err
+	}
+
+	idx := slices.IndexFunc(versions, func(version model.PinVersion) bool {
+		return version.Pinned
+	})
+
+	if idx < 0 || idx == len(versions)-1 {
+		logger.Warn("no version to roll back to")
+		return "", ErrRollbackVersionNotFound
+	}
+
+	version := versions[idx+1].Version
+	if err = m.switchPin(bin, intBin, version); err != nil {
+		return "", err
+	}
+
+	return version, nil
+}
+
+// SearchPackages searches the package index for packages matching the given
+// query leveraging the toolchain, returning up to the given number of results.
+// It returns an error if the package index cannot be searched.
+func (m *GoBinaryManager) SearchPackages(
+	ctx context.Context,
+	query string,
+	limit int,
+) ([]model.PackageSearchResult, error) {
+	return m.toolchain.SearchPackages(ctx, packageSearchURL, query, limit)
+}
+
+// SetBinaryEnv sets the given environment variables, in the form "name=value",
+// in the receipt of a binary in the Go binary directory, replacing the values of
+// the variables already set, and writes its exec shim to the internal shim
+// directory, setting the variables before running the binary. It returns an
+// error if the binary cannot be found, or the receipt or the shim cannot be
+// written.
+func (m *GoBinaryManager) SetBinaryEnv(bin model.Binary, envVars ...string) error {
+	return m.updateBinaryEnv(bin, func(env []string) []string {
+		for _, envVar := range envVars {
+			name, _, _ := strings.Cut(envVar, "=")
+			env = slices.DeleteFunc(env, func(e string) bool {
+				return strings.HasPrefix(e, name+"=")
+			})
+			env = append(env, envVar)
+		}
+
+		return env
+	})
+}
+
+// StorePackage stores the binary of a package in the internal binary directory
+// without pinning it to the Go binary directory, ex. to run it once. The package
+// version is resolved as when installing, unless exact, and the binary already
+// stored for the version is reused, so it is only built the first time. It
+// returns the path of the binary, or an error if the version cannot be resolved,
+// the package is not valid or cannot be built, or the binary cannot be stored.
+func (m *GoBinaryManager) StorePackage(ctx context.Context, pkg model.Package) (string, error) {
+	ctx, span := internal.StartSpan(ctx, "StorePackage", attribute.String("gobin.package", pkg.String()))
+	defer span.End()
+
+	logger := slog.Default().With("pkg", pkg.String())
+
+	goos := m.runtime.OS()
+	extension := model.GetBinaryExtension(goos)
+	getBinPath := func(pkg model.Package) string {
+		bin := model.NewBinary(pkg.GetBinaryName(), pkg.Version, extension)
+		return filepath.Join(m.workspace.GetInternalBinPath(), bin.String())
+	}
+
+	// exact versions stored are reused without resolving them, ex. offline
+	if binPath := getBinPath(pkg); pkg.Version.IsExact() && m.fs.Exists(binPath) {
+		logger.InfoContext(ctx, "reusing stored binary", "bin_path", binPath)
+		return binPath, nil
+	}
+
+	pkg, err := m.ResolvePackage(ctx, pkg)
+	if err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	binPath := getBinPath(pkg)
+	if m.fs.Exists(binPath) {
+		logger.InfoContext(ctx, "reusing stored binary", "bin_path", binPath)
+		return binPath, nil
+	}
+
+	if err = m.checkNameCollision(goos, binPath); err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	binName := pkg.GetBinaryName()
+	binTempDir, cleanup, err := m.fs.CreateTempDir(m.workspace.GetInternalTempPath(), binName+"-*")
+	if err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+	defer func() { _ = cleanup() }()
+
+	if err = m.toolchain.Install(ctx, binTempDir, pkg, model.BuildFlags{}, false); err != nil {
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	tempBinPath := filepath.Join(binTempDir, binName+extension)
+
+	logger.InfoContext(ctx, "moving binary from temp path to bin path", "temp_path", tempBinPath, "bin_path", binPath)
+
+	if err = m.fs.Move(tempBinPath, binPath); err != nil {
+		logger.ErrorContext(
+			ctx, "error while moving binary from temp path to bin path",
+			"err", err, "src", tempBinPath, "dst", binPath,
+		)
+		return "", internal.RecordSpanError(span, err)
+	}
+
+	return binPath, nil
+}
+
+// SwitchBinary pins the given pin to the given version of the internal binary
+// it targets, among the versions listed by GetPinVersions, without building it.
+// The version replaced is recorded as the previous version. It returns
+// ErrVersionNotInstalled if the version is not in the internal binary directory
+// or does not match the pin kind, or an error if the versions cannot be listed
+// or the pin cannot be replaced.
+func (m *GoBinaryManager) SwitchBinary(bin model.Binary, version model.Version) error {
+	intBin, versions, err := m.listPinVersions(bin)
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(versions, func(pinVersion model.PinVersion) bool {
+		return pinVersion.Version == version
+	}) {
+		slog.Default().Warn("version not installed", "bin", bin.String(), "version", version.String())
+		return ErrVersionNotInstalled
+	}
+
+	return m.switchPin(bin, intBin, version)
+}
+
+// UninstallBinary uninstalls a binary by removing the binary file. It removes
+// the binary from the go bin path for unmanaged binaries, or removes the
+// symlink for managed binaries. If purge is set, it also removes every version
+// of the binary from the internal binary directory, the pins referencing them,
+// their receipts and exec shims, and the data generated by gobin for the
+// binary. If the binary is protected, it refuses to uninstall unless force is
+// set. It returns an error if the binary cannot be found or removed.
+func (m *GoBinaryManager) UninstallBinary(bin model.Binary, force, purge bool) error {
+	logger := slog.Default().With("bin", bin.String())
+
+	if err := m.checkProtection(bin.String(), force); err != nil {
+		return err
+	}
+
+	path := filepath.Join(m.workspace.GetGoBinPath(), bin.String())
+
+	if purge {
+		if err := m.purgeBinary(path, force); err != nil {
+			return err
+		}
+	}
+
+	err := m.fs.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		logger.Warn("binary not found")
+		return err
+	} else if err != nil {
+		logger.Error("failed to remove binary", "err", err)
+		return err
+	}
+
+	if purge {
+		return m.removeReceipt(bin.String())
+	}
+
+	return nil
+}
+
+// UnmigrateBinary restores a managed binary in the Go binary directory to a
+// plain file, undoing its migration. It removes the symlink and moves the
+// versioned binary from the internal binary directory back to the recorded
+// pre-migration path, or to the Go binary directory if none was recorded. It
+// returns an error if the binary is not found, not managed, or cannot be
+// restored.
+func (m *GoBinaryManager) UnmigrateBinary(bin model.Binary) error {
+	logger := slog.Default().With("bin", bin.String())
+
+	path := filepath.Join(m.workspace.GetGoBinPath(), bin.String())
+
+	info, err := m.GetBinaryInfo(path)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsManaged {
+		return ErrBinaryNotManaged
+	}
+
+	receipt, err := m.readReceipt(bin.String())
+	if err != nil {
+		return err
+	}
+
+	target := path
+	if receipt.MigratedFrom != "" {
+		target = receipt.MigratedFrom
+	}
+
+	logger.Info("restoring binary to plain file", "internal_bin_path", info.InstallPath, "target", target)
+
+	if err = m.fs.Remove(path); err != nil {
+		logger.Error("failed to remove symlink", "err", err)
+		return err
+	}
+
+	if err = m.fs.Move(info.InstallPath, target); err != nil {
+		logger.Error("failed to move binary", "err", err)
+		return err
+	}
+
+	return m.removeReceipt(bin.String())
+}
+
+// UnsetBinaryEnv unsets the given environment variables, or all of them if
+// none is given, in the receipt of a binary in the Go binary directory, and
+// rewrites its exec shim, or removes it if no variable is left. It returns an
+// error if the binary cannot be found, or the receipt or the shim cannot be
+// written.
+func (m *GoBinaryManager) UnsetBinaryEnv(bin model.Binary, names ...string) error {
+	return m.updateBinaryEnv(bin, func(env []string) []string {
+		if len(names) == 0 {
+			return nil
+		}
+
+		return slices.DeleteFunc(env, func(e string) bool {
+			name, _, _ := strings.Cut(e, "=")
+			return slices.Contains(names, name)
+		})
+	})
+}
+
+// UpdateExecutable builds the given package in a temporary directory and
+// replaces the executable at the given path with it, ex. the running gobin
+// binary. The new executable is staged next to the executable, with the .new
+// suffix, and renamed over it, so the executable is never left partially
+// written. On Windows, where a running executable cannot be replaced but can
+// be renamed, the executable is first renamed aside with the .old suffix, to be
+// removed on the next run by RemoveStaleExecutable. It returns an error if the
+// package cannot be built or the executable cannot be replaced.
+func (m *GoBinaryManager) UpdateExecutable(ctx context.Context, path string, pkg model.Package) error {
+	ctx, span := internal.StartSpan(ctx, "UpdateExecutable", attribute.String("gobin.package", pkg.String()))
+	defer span.End()
+
+	logger := slog.Default().With("path", path, "pkg", pkg.String())
+
+	binName := pkg.GetBinaryName()
+	binTempDir, cleanup, err := m.fs.CreateTempDir(m.workspace.GetInternalTempPath(), binName+"-*")
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+	defer func() { _ = cleanup() }()
+
+	if err = m.toolchain.Install(ctx, binTempDir, pkg, model.BuildFlags{}, false); err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	tempBinPath := filepath.Join(binTempDir, binName+model.GetBinaryExtension(m.runtime.OS()))
+	newPath := path + newExecutableSuffix
+
+	logger.InfoContext(ctx, "staging new executable", "temp_path", tempBinPath, "new_path", newPath)
+
+	if err = m.fs.Move(tempBinPath, newPath); err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	if m.runtime.OS() == "windows" {
+		oldPath := path + oldExecutableSuffix
+		if err = m.RemoveStaleExecutable(path); err != nil {
+			return internal.RecordSpanError(span, err)
+		}
+
+		logger.InfoContext(ctx, "renaming running executable aside", "old_path", oldPath)
+
+		if err = m.fs.Move(path, oldPath); err != nil {
+			_ = m.fs.Remove(newPath)
+			return internal.RecordSpanError(span, err)
+		}
+	}
+
+	logger.InfoContext(ctx, "replacing executable", "new_path", newPath)
+
+	if err = m.fs.Move(newPath, path); err != nil {
+		logger.ErrorContext(ctx, "error while replacing executable", "err", err)
+		_ = m.fs.Remove(newPath)
+		if m.runtime.OS() == "windows" {
+			_ = m.fs.Move(path+oldExecutableSuffix, path)
+		}
+		return internal.RecordSpanError(span, err)
+	}
+
+	return nil
+}
+
+// UpgradeBinary upgrades a binary leveraging the toolchain. It gets the binary
+// info and upgrade info, and installs the binary if an upgrade is available or
+// if the rebuild flag is set, with the build flags recorded in the receipt of
+// the binary overridden by the given build flags. If the binary is protected,
+// it refuses to upgrade unless force is set. If the module is not found in the
+// module proxy, it is resolved directly, raising a warning. If cacheFrom is not
+// empty, the binary is downloaded from the binary cache server at that URL as
+// by InstallPackage.
+func (m *GoBinaryManager) UpgradeBinary(
+	ctx context.Context,
+	binFullPath string,
+	flags model.BuildFlags,
+	majorUpgrade bool,
+	rebuild bool,
+	force bool,
+	cacheFrom string,
+) error {
+	ctx, span := internal.StartSpan(ctx, "UpgradeBinary", attribute.String("gobin.binary", filepath.Base(binFullPath)))
+	defer span.End()
+
+	info, err := m.GetBinaryInfo(binFullPath)
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	ctx = internal.WithLogAttrs(
+		ctx,
+		slog.String("module", info.Module.Path),
+		slog.String("version", info.Module.Version.String()),
+	)
+
+	span.SetAttributes(
+		attribute.String("gobin.module", info.Module.Path),
+		attribute.String("gobin.version", info.Module.Version.String()),
+	)
+
+	ctx, binUpInfo, err := m.getUpgradeInfo(ctx, info, majorUpgrade)
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+
+	if binUpInfo.IsUpgradeAvailable || rebuild {
+		receipt, receiptErr := m.readReceipt(filepath.Base(binFullPath))
+		if receiptErr != nil {
+			return internal.RecordSpanError(span, receiptErr)
+		}
+
+		if receipt.Protected && !force {
+			slog.Default().WarnContext(ctx, "binary is protected", "bin", receipt.Name)
+			return internal.RecordSpanError(span, ErrBinaryProtected)
+		}
+
+		kind := binUpInfo.Binary.GetPinKind(m.pinFormat)
+		upgradePkg := binUpInfo.GetUpgradePackage()
+		return internal.RecordSpanError(span, m.installPackage(
+			ctx, upgradePkg, upgradePkg.Version, kind, receipt.BuildFlags.Merge(flags), rebuild, false, cacheFrom,
+		))
+	}
+
+	return nil
+}
+
+// VerifyModuleSum verifies the given sum of a module version, as recorded in the
+// build info of a binary, against the checksum database leveraging the
+// toolchain. It returns ErrModuleSumMismatch if the checksum database records
+// another sum, or ErrModuleSumNotVerifiable if the module has no sum, ex. a
+// binary built locally, the checksum database is disabled for the module or
+// does not record its version. It fails if the checksum database configuration
+// cannot be determined or the checksum database cannot be reached.
+func (m *GoBinaryManager) VerifyModuleSum(ctx context.Context, module model.Module, sum string) error {
+	sumDBConfig, err := m.toolchain.GetSumDBConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	return m.verifyModuleSum(ctx, sumDBConfig, module, sum)
+}
+
+// checkNameCollision checks if a binary in the directory of the given path has
+// the same name except for letter case, only on macOS and Windows, whose
+// default file systems are case-insensitive and would silently replace it. It
+// returns ErrBinaryNameCollision naming the colliding binary.
+func (m *GoBinaryManager) checkNameCollision(goos, path string) error {
+	if goos != "darwin" && goos != "windows" {
+		return nil
+	}
+
+	binPaths, err := m.fs.ListBinaries(filepath.Dir(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	name := filepath.Base(path)
+	for _, binPath := range binPaths {
+		existing := filepath.Base(binPath)
+		if existing != name && strings.EqualFold(existing, name) {
+			slog.Default().Warn("binary name collision", "name", name, "existing", existing)
+			return fmt.Errorf("%w: %q collides with %q", ErrBinaryNameCollision, name, existing)
+		}
+	}
+
+	return nil
+}
+
+// checkProtection checks if the binary with the given pin name is protected. It
+// returns ErrBinaryProtected if the binary is protected and force is not set,
+// or an error if the receipt cannot be read.
+func (m *GoBinaryManager) checkProtection(name string, force bool) error {
+	if force {
+		return nil
+	}
+
+	receipt, err := m.readReceipt(name)
+	if err != nil {
+		return err
+	}
+
+	if receipt.Protected {
+		slog.Default().Warn("binary is protected", "bin", name)
+		return ErrBinaryProtected
+	}
+
+	return nil
+}
+
+// compressBinary compresses the binary at the given path, built for the given
+// operating system, with UPX, if the upx command is available in the PATH. It
+// returns the original and compressed sizes of the binary, or zero sizes if not
+// compressed. As the compression is optional, a missing upx command or a
+// failed compression is reported as a warning and the binary is kept
+// uncompressed.
+func (m *GoBinaryManager) compressBinary(ctx context.Context, goos, path string) model.Compression {
+	binName := filepath.Base(path)
+
+	if len(m.fs.LocateBinaryInPath("upx"+model.GetBinaryExtension(goos))) == 0 {
+		internal.AddWarning(ctx, fmt.Sprintf("upx not found in PATH, %s installed uncompressed", binName))
+		return model.Compression{}
+	}
+
+	originalSize, err := m.fs.GetFileSize(path)
+	if err != nil {
+		internal.AddWarning(ctx, fmt.Sprintf("cannot compress %s: %s", binName, err))
+		return model.Compression{}
+	}
+
+	if err = m.toolchain.Compress(ctx, path); err != nil {
+		internal.AddWarning(ctx, fmt.Sprintf("cannot compress %s: %s", binName, err))
+		return model.Compression{}
+	}
+
+	compressedSize, err := m.fs.GetFileSize(path)
+	if err != nil {
+		internal.AddWarning(ctx, fmt.Sprintf("cannot compress %s: %s", binName, err))
+		return model.Compression{}
+	}
+
+	return model.Compression{
+		OriginalSize:   model.ByteSize(originalSize),
+		CompressedSize: model.ByteSize(compressedSize),
+	}
+}
+
+// diagnoseGoModFile diagnoses the Go module file for a given module and
+// version leveraging the toolchain. It returns the retracted and deprecated
+// information if available. The status of the module is cached for a day.
+func (m *GoBinaryManager) diagnoseGoModFile(
+	ctx context.Context,
+	module model.Module,
+) (string, string, error) {
+	status, err := m.getModuleStatus(ctx, module.Path)
+	if err != nil {
+		return "", "", err
+	}
+
+	var retracted string
+	for _, r := range status.Retractions {
+		if model.NewVersion(r.Low).Compare(module.Version) <= 0 &&
+			model.NewVersion(r.High).Compare(module.Version) >= 0 {
+			retracted = r.Rationale
+		}
+	}
+
+	return retracted, status.Deprecated, nil
+}
+
+// diagnoseModuleSum diagnoses the given sum of a module version against the
+// checksum database, returning the sum mismatch or the reason the sum is not
+// verifiable, if any. The sum is not diagnosed if the checksum database is
+// disabled for all modules, as reported once by the doctor command.
+func (m *GoBinaryManager) diagnoseModuleSum(
+	ctx context.Context,
+	module model.Module,
+	sum string,
+) (string, string, error) {
+	sumDBConfig, err := m.toolchain.GetSumDBConfig(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	if _, disabled := sumDBConfig.GetDisabledReason(); disabled {
+		return "", "", nil
+	}
+
+	err = m.verifyModuleSum(ctx, sumDBConfig, module, sum)
+	switch {
+	case errors.Is(err, ErrModuleSumMismatch):
+		return err.Error(), "", nil
+	case errors.Is(err, ErrModuleSumNotVerifiable):
+		return "", err.Error(), nil
+	default:
+		return "", "", err
+	}
+}
+
+// fetchCachedBinary downloads the binary of the given package, resolved to an
+// exact version, built for the runtime platform with the given build flags from
+// the binary cache server at the given URL, if any, to the given directory,
+// named after the package as by go install. The binary must match the digest
+// of its index entry, and the build info of the binary must match the package
+// and version, with a module sum matching the checksum database unless the
+// module is not verified by it, ex. GONOSUMDB. It returns false if the URL is
+// empty, the server serves no matching binary or the binary cannot be
+// downloaded or verified, adding a warning, so that the package is built
+// instead.
+func (m *GoBinaryManager) fetchCachedBinary(
+	ctx context.Context,
+	url string,
+	dir string,
+	pkg model.Package,
+	flags model.BuildFlags,
+) bool {
+	if url == "" {
+		return false
+	}
+
+	path := filepath.Join(dir, pkg.GetBinaryName()+model.GetBinaryExtension(m.runtime.OS()))
+
+	logger := slog.Default().With("url", url, "pkg", pkg.String())
+	warning := fmt.Sprintf("cannot download %s from binary cache, building it: %%s", pkg.GetBinaryName())
+
+	defer internal.TrackPhase(ctx, internal.PhaseDownload)()
+
+	artifacts, err := m.binaryCache.GetIndex(ctx, url)
+	if err != nil {
+		internal.AddWarning(ctx, fmt.Sprintf(warning, err))
+		return false
+	}
+
+	platform := m.runtime.Platform()
+	idx := slices.IndexFunc(artifacts, func(artifact model.CacheArtifact) bool {
+		return artifact.Matches(pkg, platform, flags)
+	})
+	if idx == -1 {
+		logger.InfoContext(ctx, "no matching binary in binary cache")
+		return false
+	}
+
+	artifact := artifacts[idx]
+	data, err := m.binaryCache.GetArtifact(ctx, url, artifact)
+	if err != nil {
+		internal.AddWarning(ctx, fmt.Sprintf(warning, err))
+		return false
+	}
+
+	//nolint:mnd // executable permissions
+	if err = m.fs.WriteFile(path, data, 0755); err != nil {
+		logger.ErrorContext(ctx, "error writing binary", "err", err, "path", path)
+		internal.AddWarning(ctx, fmt.Sprintf(warning, err))
+		return false
+	}
+
+	info, err := m.toolchain.GetBuildInfo(path)
+	if err != nil || info.Path != pkg.Path || info.Main.Version != pkg.Version.String() {
+		logger.ErrorContext(ctx, "binary does not match its artifact", "artifact", artifact.String())
+		internal.AddWarning(ctx, fmt.Sprintf(warning, registry.ErrArtifactInvalid))
+		_ = m.fs.Remove(path)
+		return false
+	}
+
+	if err = m.verifyCachedModuleSum(ctx, info); err != nil {
+		logger.ErrorContext(ctx, "binary module sum not verified", "artifact", artifact.String(), "err", err)
+		internal.AddWarning(ctx, fmt.Sprintf(warning, err))
+		_ = m.fs.Remove(path)
+		return false
+	}
+
+	logger.InfoContext(ctx, "downloaded binary from binary cache", "artifact", artifact.String())
+
+	return true
+}
+
+// getModuleStatus returns the deprecation and retractions of a given module
+// from the module status cache if looked up in the last day, or from the Go
+// module file of its latest version leveraging the toolchain otherwise,
+// caching the result. The module status cache is bypassed when the context is
+// marked with toolchain.WithNoCache, only caching the result. A cache that
+// cannot be read or written is logged and ignored. It returns an error if the
+// Go module file cannot be retrieved.
+func (m *GoBinaryManager) getModuleStatus(ctx context.Context, modulePath string) (moduleStatus, error) {
+	cacheDir := m.workspace.GetInternalCachePath()
+	path := filepath.Join(cacheDir, moduleStatusCacheFileName)
+
+	if !toolchain.IsNoCache(ctx) {
+		m.cacheMu.Lock()
+		cache := m.readModuleStatusCache(path)
+		m.cacheMu.Unlock()
+
+		if status, ok := cache[modulePath]; ok && time.Since(status.CheckedAt) < moduleStatusCacheInterval {
+			return status, nil
+		}
+	}
+
+	modFile, err := m.toolchain.GetModuleFile(ctx, model.NewLatestModule(modulePath))
+	if err != nil {
+		return moduleStatus{}, err
+	}
+
+	status := moduleStatus{CheckedAt: time.Now()}
+	if modFile.Module != nil {
+		status.Deprecated = modFile.Module.Deprecated
+	}
+
+	for _, r := range modFile.Retract {
+		status.Retractions = append(status.Retractions, moduleRetraction{
+			Low:       r.Low,
+			High:      r.High,
+			Rationale: r.Rationale,
+		})
+	}
+
+	m.cacheMu.Lock()
+	defer m.cacheMu.Unlock()
+
+	cache := m.readModuleStatusCache(path)
+	cache[modulePath] = status
+
+	data, err := json.Marshal(cache)
+	if err == nil {
+		//nolint:mnd // owner only permissions
+		err = m.fs.CreateDir(cacheDir, 0700)
+	}
+
+	if err == nil {
+		//nolint:mnd // owner only permissions
+		err = m.fs.WriteFile(path, data, 0600)
+	}
+
+	if err != nil {
+		slog.Default().WarnContext(ctx, "error caching module status", "module", modulePath, "err", err)
+	}
+
+	return status, nil
+}
+
+// installPackage installs a package leveraging the toolchain with the given
+// build flags, or downloads it from the binary cache server at the cacheFrom
+// URL, if any, when it serves the binary of the package at the given resolved
+// version. If kind is major or minor, it pins the binary to the Go
+// binary directory with the given kind. If rebuild is true, it rebuilds the
+// binary.
+// On macOS and Windows, it refuses binaries whose internal or pin name differs
+// only in letter case from an existing binary.
+func (m *GoBinaryManager) installPackage(
+	ctx context.Context,
+	pkg model.Package,
+	version model.Version,
+	kind model.Kind,
+	flags model.BuildFlags,
+	rebuild bool,
+	universal bool,
+	cacheFrom string,
+) error {
+	logger := slog.Default().With("pkg", pkg.String())
+
+	tempDir := m.workspace.GetInternalTempPath()
+	binName := pkg.GetBinaryName()
+
+	logger.InfoContext(ctx, "creating internal binary temp directory")
+
+	binTempDir, cleanup, err := m.fs.CreateTempDir(tempDir, binName+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cleanup() }()
+
+	if universal {
+		err = m.installUniversalBinary(ctx, binTempDir, pkg, flags, rebuild)
+	} else if rebuild || flags.KeepsDebugInfo() ||
+		!m.fetchCachedBinary(ctx, cacheFrom, binTempDir, model.NewPackageWithVersion(pkg.Path, version), flags) {
+		err = m.toolchain.Install(ctx, binTempDir, pkg, flags, rebuild)
+	}
+	if err != nil {
+		return err
+	}
+
+	goos := m.runtime.OS()
+	extension := model.GetBinaryExtension(goos)
+	tempBinPath := filepath.Join(binTempDir, binName+extension)
+
+	buildInfo, err := m.toolchain.GetBuildInfo(tempBinPath)
+	if err != nil {
+		logger.ErrorContext(
+			ctx, "error while getting build info for internal binary",
+			"err", err, "temp_bin_path", tempBinPath,
+		)
+		return err
+	}
+
+	bin := model.NewBinary(binName, model.NewVersion(buildInfo.Main.Version), extension)
+	binPath := filepath.Join(m.workspace.GetInternalBinPath(), bin.String())
+	goBinPath := filepath.Join(m.workspace.GetGoBinPath(), bin.GetTargetBinName(kind, m.pinFormat))
+
+	if err = m.checkNameCollision(goos, binPath); err != nil {
+		return err
+	}
+
+	if err = m.checkNameCollision(goos, goBinPath); err != nil {
+		return err
+	}
+
+	if flags.KeepsDebugInfo() && !universal {
+		builtPkg := model.Package{Path: pkg.Path, Version: bin.Version}
+		m.keepDebugInfo(ctx, binTempDir, tempBinPath, builtPkg, flags, rebuild)
+	}
+
+	return m.storeBinary(ctx, goos, tempBinPath, binPath, goBinPath, flags)
+}
+
+// installUniversalBinary installs a package for each universal platform in
+// the given temp directory and merges the binaries into a macOS universal
+// binary named after the package in the temp directory. The binary of the
+// runtime platform comes first, so its build info reports the runtime
+// platform.
+func (m *GoBinaryManager) installUniversalBinary(
+	ctx context.Context,
+	tempDir string,
+	pkg model.Package,
+	flags model.BuildFlags,
+	rebuild bool,
+) error {
+	binName := pkg.GetBinaryName()
+	runtimePlatform := m.runtime.Platform()
+
+	platforms := make([]string, 0, len(universalPlatforms))
+	for _, platform := range universalPlatforms {
+		if platform == runtimePlatform {
+			platforms = append([]string{platform}, platforms...)
+		} else {
+			platforms = append(platforms, platform)
+		}
+	}
+
+	sources := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		if err := m.toolchain.InstallPlatform(ctx, tempDir, pkg, platform, flags, rebuild); err != nil {
+			return err
+		}
+
+		source := filepath.Join(tempDir, "bin", binName)
+		if platform != runtimePlatform {
+			source = filepath.Join(tempDir, "bin", strings.ReplaceAll(platform, "/", "_"), binName)
+		}
+
+		sources = append(sources, source)
+	}
+
+	slog.Default().InfoContext(ctx, "creating universal binary", "pkg", pkg.String(), "sources", sources)
+
+	defer internal.TrackPhase(ctx, internal.PhaseLink)()
+
+	return m.fs.CreateUniversalBinary(filepath.Join(tempDir, binName), sources...)
+}
+
+// isManagedPin checks if the given path is a pin targeting a binary in the
+// internal binary directory.
+func (m *GoBinaryManager) isManagedPin(path string) bool {
+	if m.pinMode != model.PinModeCopy {
+		isSymlinkToDir, _ := m.fs.IsSymlinkToDir(path, m.workspace.GetInternalBinPath())
+		return isSymlinkToDir
+	}
+
+	target, err := m.getPinTarget(path)
+	return err == nil && strings.HasPrefix(target, m.workspace.GetInternalBinPath()+string(os.PathSeparator))
+}
+
+// keepDebugInfo keeps the debug information of the stripped binary at the
+// given path, built from the given package, in the debug information directory
+// keyed by its build ID. As the Go linker cannot split the debug information,
+// the package is built again in the given temp directory without stripping,
+// reusing the build cache, and the unstripped binary is kept as the debug
+// information file. As the debug information is optional, a failure is reported
+// as a warning and the binary is installed without it.
+func (m *GoBinaryManager) keepDebugInfo(
+	ctx context.Context,
+	tempDir string,
+	path string,
+	pkg model.Package,
+	flags model.BuildFlags,
+	rebuild bool,
+) {
+	logger := slog.Default().With("pkg", pkg.String())
+	binName := filepath.Base(path)
+	warning := "cannot keep debug info of " + binName + ": %s"
+
+	buildID, err := m.toolchain.GetBuildID(ctx, path)
+	if err != nil {
+		internal.AddWarning(ctx, fmt.Sprintf(warning, err))
+		return
+	}
+
+	debugTempDir := filepath.Join(tempDir, debugInfoDir)
+	if err = m.toolchain.Install(ctx, debugTempDir, pkg, flags.Unstripped(), rebuild); err != nil {
+		internal.AddWarning(ctx, fmt.Sprintf(warning, err))
+		return
+	}
+
+	debugPath := m.getDebugInfoPath(model.NewBinaryFromString(binName).Name, buildID)
+
+	//nolint:mnd // owner only permissions
+	if err = m.fs.CreateDir(filepath.Dir(debugPath), 0700); err != nil {
+		internal.AddWarning(ctx, fmt.Sprintf(warning, err))
+		return
+	}
+
+	logger.InfoContext(ctx, "keeping debug info", "build_id", buildID, "debug_path", debugPath)
+
+	if err = m.fs.Move(filepath.Join(debugTempDir, binName), debugPath); err != nil {
+		internal.AddWarning(ctx, fmt.Sprintf(warning, err))
+	}
+}
+
+// linkPin pins the given internal binary at the target path with a symlink or,
+// when pinning with copies, with a copy of the binary, recording the internal
+// binary in the pin receipt.
+func (m *GoBinaryManager) linkPin(source, target string) error {
+	if m.pinMode != model.PinModeCopy {
+		return m.fs.ReplaceSymlink(source, target)
+	}
+
+	if err := m.fs.Copy(source, target); err != nil {
+		return err
+	}
+
+	receipt, err := m.readReceipt(filepath.Base(target))
+	if err != nil {
+		return err
+	}
+
+	receipt.Target = source
+
+	return m.writeReceipt(receipt)
+}
+
+// listPinVersions lists the versions of the internal binaries the given pin can
+// target, as described in GetPinVersions, along with the internal binary it
+// targets, without version.
+func (m *GoBinaryManager) listPinVersions(bin model.Binary) (model.Binary, []model.PinVersion, error) {
+	logger := slog.Default().With("bin", bin.String())
+
+	path := filepath.Join(m.workspace.GetGoBinPath(), bin.String())
+	if !m.fs.Exists(path) {
+		logger.Warn("binary not found")
+		return model.Binary{}, nil, toolchain.ErrBinaryNotFound
+	}
+
+	target, err := m.getPinTarget(path)
+	if err != nil || filepath.Dir(target) != m.workspace.GetInternalBinPath() {
+		logger.Warn("binary not managed")
+		return model.Binary{}, nil, ErrBinaryNotManaged
+	}
+
+	pinnedBin := model.NewBinaryFromString(filepath.Base(target))
+	intBin := model.NewBinary(pinnedBin.Name, bin.GetPinnedVersion(m.pinFormat), pinnedBin.Extension)
+
+	binPaths, err := m.fs.ListBinaries(m.workspace.GetInternalBinPath())
+	if err != nil {
+		return model.Binary{}, nil, err
+	}
+
+	var versions []model.PinVersion
+	for _, binPath := range binPaths {
+		if candidate := model.NewBinaryFromString(filepath.Base(binPath)); candidate.IsPartOf(intBin) {
+			versions = append(versions, model.PinVersion{
+				Version: candidate.Version,
+				Pinned:  candidate.Version == pinnedBin.Version,
+			})
+		}
+	}
+
+	slices.SortFunc(versions, func(a, b model.PinVersion) int {
+		return b.Version.Compare(a.Version)
+	})
+
+	return model.NewBinary(intBin.Name, model.NewLatestVersion(), intBin.Extension), versions, nil
+}
+
+// planInstall plans the install of a package at its resolved version: building
+// the binary into the internal binary directory with the given build flags, and
+// linking it to the Go binary directory with the given kind, or replacing the
+// binary of the same name there.
+func (m *GoBinaryManager) planInstall(pkg model.Package, kind model.Kind, flags model.BuildFlags) []model.Action {
+	bin := model.NewBinary(pkg.GetBinaryName(), pkg.Version, model.GetBinaryExtension(m.runtime.OS()))
+	binPath := filepath.Join(m.workspace.GetInternalBinPath(), bin.String())
+	goBinPath := filepath.Join(m.workspace.GetGoBinPath(), bin.GetTargetBinName(kind, m.pinFormat))
+
+	pinAction := model.ActionLink
+	if m.fs.Exists(goBinPath) {
+		pinAction = model.ActionReplace
+	}
+
+	return []model.Action{
+		{Type: model.ActionInstall, Path: binPath, Source: pkg.String(), Flags: flags},
+		{Type: pinAction, Path: goBinPath, Source: binPath},
+	}
+}
+
+// purgeBinary removes every version of the binary pinned at the given path
+// from the internal binary directory, along with the other pins referencing
+// them in the Go binary directory, their receipts and the binary data. If the
+// binary is not managed, it only removes the binary data. If any of the other
+// pins is protected, it refuses to purge unless force is set.
+func (m *GoBinaryManager) purgeBinary(path string, force bool) error {
+	logger := slog.Default().With("path", path)
+
+	bin, ok := m.getPinnedBinary(path)
+	if !ok {
+		return m.removeBinaryData(model.NewBinaryFromString(filepath.Base(path)).Name)
+	}
+
+	pins, err := m.getRelatedPinPaths(path, bin)
+	if err != nil {
+		return err
+	}
+
+	for _, pin := range pins {
+		if err = m.checkProtection(filepath.Base(pin), force); err != nil {
+			return err
+		}
+	}
+
+	for _, pin := range pins {
+		logger.Info("removing pin referencing purged binary", "pin", pin)
+
+		if err = m.fs.Remove(pin); err != nil {
+			logger.Error("failed to remove pin", "err", err, "pin", pin)
+			return err
+		}
+
+		if err = m.removeReceipt(filepath.Base(pin)); err != nil {
+			return err
+		}
+	}
+
+	binPaths, err := m.fs.ListBinaries(m.workspace.GetInternalBinPath())
+	if err != nil {
+		return err
+	}
+
+	for _, binPath := range binPaths {
+		if !model.NewBinaryFromString(filepath.Base(binPath)).IsPartOf(bin) {
+			continue
+		}
+
+		logger.Info("removing purged binary", "internal_bin", binPath)
+
+		if err = m.fs.Remove(binPath); err != nil {
+			logger.Error("failed to remove binary", "err", err, "internal_bin", binPath)
+			return err
+		}
+	}
+
+	return m.removeBinaryData(bin.Name)
+}
+
+// readModuleStatusCache reads the module status cache at the given path,
+// keyed by module path. It returns an empty cache if the file does not exist
+// or cannot be parsed.
+func (m *GoBinaryManager) readModuleStatusCache(path string) map[string]moduleStatus {
+	cache := map[string]moduleStatus{}
+	if data, err := m.fs.ReadFile(path); err == nil {
+		if json.Unmarshal(data, &cache) != nil {
+			return map[string]moduleStatus{}
+		}
+	}
+
+	return cache
+}
+
+// readReceipt reads the receipt for the binary with the given pin name. It
+// returns an empty receipt if the receipt does not exist, or an error if the
+// receipt cannot be read or parsed.
+func (m *GoBinaryManager) readReceipt(name string) (model.Receipt, error) {
+	path := m.getReceiptPath(name)
+	logger := slog.Default().With("path", path)
+
+	data, err := m.fs.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return model.NewReceipt(name), nil
+	} else if err != nil {
+		logger.Error("error reading receipt", "err", err)
+		return model.Receipt{}, err
+	}
+
+	var receipt model.Receipt
+	if err = json.Unmarshal(data, &receipt); err != nil {
+		logger.Error("error parsing receipt", "err", err)
+		return model.Receipt{}, err
+	}
+
+	return receipt, nil
+}
+
+// readLinkReceipt reads the receipt of the given binary in the Go binary
+// directory. It returns ErrBinaryNotLinked if no local directory is recorded in
+// the receipt, or an error if the binary cannot be found or the receipt cannot
+// be read.
+func (m *GoBinaryManager) readLinkReceipt(bin model.Binary) (model.Receipt, error) {
+	if _, err := m.GetBinaryInfo(filepath.Join(m.workspace.GetGoBinPath(), bin.String())); err != nil {
+		return model.Receipt{}, err
+	}
+
+	receipt, err := m.readReceipt(bin.String())
+	if err != nil {
+		return model.Receipt{}, err
+	}
+
+	if receipt.Source == "" {
+		return model.Receipt{}, ErrBinaryNotLinked
+	}
+
+	return receipt, nil
+}
+
+// recordMigration records the pre-migration path and the build flags of the
+// binary in the receipt of its pin in the Go binary directory.
+func (m *GoBinaryManager) recordMigration(path string, flags model.BuildFlags) error {
+	receipt, err := m.readReceipt(filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	receipt.MigratedFrom = path
+	receipt.BuildFlags = flags
+
+	return m.writeReceipt(receipt)
+}
+
+// recordBuildFlags records the build flags of the binary in the receipt of its
+// pin in the Go binary directory.
+func (m *GoBinaryManager) recordBuildFlags(path string, flags model.BuildFlags) error {
+	receipt, err := m.readReceipt(filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	receipt.BuildFlags = flags
+
+	return m.writeReceipt(receipt)
+}
+
+// recordCompression records the original and compressed sizes of the binary in
+// the receipt of its pin in the Go binary directory.
+func (m *GoBinaryManager) recordCompression(path string, compression model.Compression) error {
+	receipt, err := m.readReceipt(filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	receipt.Compression = compression
+
+	return m.writeReceipt(receipt)
+}
+
+// recordSize records the size of the given internal binary in the size
+// history of the receipt of the pin at the given path.
+func (m *GoBinaryManager) recordSize(path, binPath string) error {
+	size, err := m.fs.GetFileSize(binPath)
+	if err != nil {
+		slog.Default().Error("error getting binary size", "err", err, "bin_path", binPath)
+		return err
+	}
+
+	receipt, err := m.readReceipt(filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	version := model.NewBinaryFromString(filepath.Base(binPath)).Version
+	receipt.SizeHistory = receipt.SizeHistory.Record(version, model.ByteSize(size))
+
+	return m.writeReceipt(receipt)
+}
+
+// recordSource records the local directory the binary is built from in the
+// receipt of its pin in the Go binary directory.
+func (m *GoBinaryManager) recordSource(path string, dir string) error {
+	receipt, err := m.readReceipt(filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	receipt.Source = dir
+
+	return m.writeReceipt(receipt)
+}
+
+// recordSignature records the signature of the binary in the receipt of its pin
+// in the Go binary directory.
+func (m *GoBinaryManager) recordSignature(path string, signature model.Signature) error {
+	receipt, err := m.readReceipt(filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	receipt.Signature = signature
+
+	return m.writeReceipt(receipt)
+}
+
+// replacePin replaces the pin at the given target path with the given internal
+// binary. If the pin was targeting another internal binary, its version is
+// recorded in the pin receipt as the previous version.
+func (m *GoBinaryManager) replacePin(source, target string) error {
+	current, err := m.getPinTarget(target)
+	if err != nil || current == source || filepath.Dir(current) != m.workspace.GetInternalBinPath() {
+		return m.linkPin(source, target)
+	}
+
+	receipt, err := m.readReceipt(filepath.Base(target))
+	if err != nil {
+		return err
+	}
+
+	receipt.PreviousVersion = model.NewBinaryFromString(filepath.Base(current)).Version
+	if err = m.writeReceipt(receipt); err != nil {
+		return err
+	}
+
+	return m.linkPin(source, target)
+}
+
+// resolvePackageVersion resolves the "previous" and "latest-N" versions of the
+// given package to the version installed before the current one, or to the
+// N-th release behind the latest one, respectively. Other versions are kept.
+func (m *GoBinaryManager) resolvePackageVersion(
+	ctx context.Context,
+	pkg model.Package,
+) (model.Package, error) {
+	if pkg.Version.IsPrevious() {
+		version, err := m.getPreviousVersion(pkg.GetBinaryName() + model.GetBinaryExtension(m.runtime.OS()))
+		if err != nil {
+			return model.Package{}, err
+		}
+
+		return model.NewPackageWithVersion(pkg.Path, version), nil
+	}
+
+	offset, ok := pkg.Version.LatestOffset()
+	if !ok {
+		return pkg, nil
+	}
+
+	versions, err := m.toolchain.GetModuleVersions(ctx, pkg.Path)
+	if err != nil {
+		return model.Package{}, err
+	}
+
+	var releases []model.Version
+	for _, version := range versions {
+		if !version.IsPrerelease() {
+			releases = append(releases, version)
+		}
+	}
+
+	if offset >= len(releases) {
+		slog.Default().WarnContext(ctx, "version not available", "pkg", pkg.String())
+		return model.Package{}, ErrVersionNotAvailable
+	}
+
+	return model.NewPackageWithVersion(pkg.Path, releases[len(releases)-1-offset]), nil
+}
+
+// removeBinaryData removes the data directory of the binary with the given
+// name, holding the debug information and the detached signatures of its
+// versions. It does nothing if the binary has no data.
+func (m *GoBinaryManager) removeBinaryData(name string) error {
+	path := filepath.Join(m.workspace.GetInternalDataPath(), name)
+
+	if err := m.fs.RemoveAll(path); err != nil {
+		slog.Default().Error("error removing binary data", "err", err, "path", path)
+		return err
+	}
+
+	return nil
+}
+
+// removeReceipt removes the receipt for the given pin name, along with the exec
+// shim written from it. It does nothing if the receipt does not exist.
+func (m *GoBinaryManager) removeReceipt(name string) error {
+	if err := m.removeShim(name); err != nil {
+		return err
+	}
+
+	path := m.getReceiptPath(name)
+
+	if err := m.fs.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Default().Error("error removing receipt", "err", err, "path", path)
+		return err
+	}
+
+	return nil
+}
+
+// removeShim removes the exec shim for the given pin name. It does nothing if
+// the shim does not exist.
+func (m *GoBinaryManager) removeShim(name string) error {
+	path := m.getShimPath(name)
+
+	if err := m.fs.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Default().Error("error removing shim", "err", err, "path", path)
+		return err
+	}
+
+	return nil
+}
+
+// signBinary signs the binary at the given path with the identity of the given
+// operating system in the signing configuration, if any. On Linux, the detached
+// signature is written to the signature directory. It returns the signature, or
+// a zero signature if not signed, or an error if the binary cannot be signed.
+func (m *GoBinaryManager) signBinary(ctx context.Context, goos, path string) (model.Signature, error) {
+	identity := m.signing.GetIdentity(goos)
+	if identity == "" {
+		return model.Signature{}, nil
+	}
+
+	signature := model.Signature{Identity: identity}
+	if goos == "linux" {
+		signature.File = m.getSignaturePath(filepath.Base(path))
+
+		//nolint:mnd // owner only permissions
+		if err := m.fs.CreateDir(filepath.Dir(signature.File), 0700); err != nil {
+			return model.Signature{}, err
+		}
+	}
+
+	if err := m.toolchain.Sign(ctx, goos, identity, path, signature.File); err != nil {
+		return model.Signature{}, err
+	}
+
+	return signature, nil
+}
+
+// storeBinary stores the binary built at the given temp path in the internal
+// binary directory at the given bin path and pins it at the given Go binary
+// path. The binary is compressed with UPX when the compress build flag is set,
+// and signed when the signing configuration has an identity for the operating
+// system, once moved to the internal binary directory and before being pinned.
+// Its size, compression, signature and build flags are recorded in the pin
+// receipt.
+func (m *GoBinaryManager) storeBinary(
+	ctx context.Context,
+	goos string,
+	tempBinPath string,
+	binPath string,
+	goBinPath string,
+	flags model.BuildFlags,
+) error {
+	logger := slog.Default().With("bin_path", binPath)
+
+	var compression model.Compression
+	if flags.Compress {
+		compression = m.compressBinary(ctx, goos, tempBinPath)
+	}
+	flags = flags.Uncompressed()
+
+	defer internal.TrackPhase(ctx, internal.PhaseLink)()
+
+	logger.InfoContext(
+		ctx, "moving binary from temp path to bin path",
+		"temp_path", tempBinPath, "bin_path", binPath,
+	)
+
+	if err := m.fs.Move(tempBinPath, binPath); err != nil {
+		logger.ErrorContext(
+			ctx, "error while moving binary from temp path to bin path",
+			"err", err, "src", tempBinPath, "dst", binPath,
+		)
+		return err
+	}
+
+	signature, err := m.signBinary(ctx, goos, binPath)
+	if err != nil {
+		return err
+	}
+
+	logger.InfoContext(
+		ctx, "replacing existing symlink for binary",
+		"go_bin_path", goBinPath,
+	)
+
+	if err = m.replacePin(binPath, goBinPath); err != nil {
+		return err
+	}
+
+	if err = m.recordSize(goBinPath, binPath); err != nil {
+		return err
+	}
+
+	if compression != (model.Compression{}) {
+		if err = m.recordCompression(goBinPath, compression); err != nil {
+			return err
+		}
+	}
+
+	if signature != (model.Signature{}) {
+		if err = m.recordSignature(goBinPath, signature); err != nil {
+			return err
+		}
+	}
+
+	if flags == (model.BuildFlags{}) {
+		return nil
+	}
+
+	return m.recordBuildFlags(goBinPath, flags)
+}
+
+// switchPin pins the given pin to the given version of the given internal
+// binary, without version, recording the version replaced as the previous
+// version.
+func (m *GoBinaryManager) switchPin(bin, intBin model.Binary, version model.Version) error {
+	source := filepath.Join(
+		m.workspace.GetInternalBinPath(),
+		model.NewBinary(intBin.Name, version, intBin.Extension).String(),
+	)
+
+	slog.Default().Info("switching binary version", "bin", bin.String(), "version", version.String(), "path", source)
+
+	return m.replacePin(source, filepath.Join(m.workspace.GetGoBinPath(), bin.String()))
+}
+
+// updateBinaryEnv updates the environment variables in the receipt of a binary
+// in the Go binary directory with the given function, kept sorted by name, and
+// writes its exec shim running the pin of the binary with them, or removes the
+// shim if no variable is left.
+func (m *GoBinaryManager) updateBinaryEnv(bin model.Binary, update func(env []string) []string) error {
+	path := filepath.Join(m.workspace.GetGoBinPath(), bin.String())
+	logger := slog.Default().With("bin", bin.String())
+
+	if _, err := m.GetBinaryInfo(path); err != nil {
+		return err
+	}
+
+	receipt, err := m.readReceipt(bin.String())
+	if err != nil {
+		return err
+	}
+
+	receipt.Env = update(receipt.Env)
+	slices.Sort(receipt.Env)
+
+	if err = m.writeReceipt(receipt); err != nil {
+		return err
+	}
+
+	if len(receipt.Env) == 0 {
+		logger.Info("removing exec shim")
+		return m.removeShim(bin.String())
+	}
+
+	shimPath := m.getShimPath(bin.String())
+	logger.Info("writing exec shim", "path", shimPath)
+
+	//nolint:mnd // owner only permissions
+	if err = m.fs.CreateDir(filepath.Dir(shimPath), 0700); err != nil {
+		logger.Error("error creating shim directory", "err", err)
+		return err
+	}
+
+	//nolint:mnd // owner only executable permissions
+	if err = m.fs.WriteFile(shimPath, model.GetShimScript(m.runtime.OS(), path, receipt.Env), 0700); err != nil {
+		logger.Error("error writing shim", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// validatePackage validates that the given package can be installed, checking
+// that the module providing it exists, the requested version or ref is
+// available, and the package exists and is a main package. It returns the
+// package info on success. When the version or the package is not valid, the
+// returned error suggests the latest versions available or the main packages
+// of the module, respectively.
+func (m *GoBinaryManager) validatePackage(
+	ctx context.Context,
+	pkg model.Package,
+) (model.PackageInfo, error) {
+	logger := slog.Default().With("pkg", pkg.String())
+
+	info, err := m.toolchain.GetPackageInfo(ctx, pkg)
+	if errors.Is(err, toolchain.ErrModuleNotFound) {
+		versions, versionsErr := m.toolchain.GetModuleVersions(ctx, pkg.Path)
+		if versionsErr != nil {
+			return model.PackageInfo{}, err
+		}
+
+		if pkg.IsRef() {
+			logger.WarnContext(ctx, "ref not found")
+			return model.PackageInfo{}, fmt.Errorf(
+				"%w: branch or tag not served by the proxy, set GOPRIVATE or use --direct",
+				ErrRefNotFound,
+			)
+		}
+
+		if len(versions) == 0 {
+			return model.PackageInfo{}, err
+		}
+
+		logger.WarnContext(ctx, "version not available")
+
+		if closest, ok := pkg.Version.Closest(versions); ok {
+			return model.PackageInfo{}, fmt.Errorf("%w: did you mean @%s?", ErrVersionNotAvailable, closest)
+		}
+
+		suggestions := make([]string, 0, maxVersionSuggestions)
+		for i := len(versions) - 1; i >= 0 && len(suggestions) < maxVersionSuggestions; i-- {
+			suggestions = append(suggestions, versions[i].String())
+		}
+
+		return model.PackageInfo{}, fmt.Errorf(
+			"%w: latest versions are %s",
+			ErrVersionNotAvailable,
+			strings.Join(suggestions, ", "),
+		)
+	} else if err != nil {
+		return model.PackageInfo{}, err
+	}
+
+	switch {
+	case !info.Exists:
+		err = ErrPackageNotFound
+	case !info.IsMain:
+		err = ErrPackageNotMain
+	default:
+		return info, nil
+	}
+
+	logger.WarnContext(ctx, err.Error())
+
+	if len(info.MainPackages) > 0 {
+		return model.PackageInfo{}, fmt.Errorf("%w: did you mean %s?", err, strings.Join(info.MainPackages, ", "))
+	}
+
+	return model.PackageInfo{}, err
+}
+
+// verifyCachedModuleSum verifies the module sum recorded in the build info of a
+// binary downloaded from a binary cache server against the checksum database.
+// A module not verified by the checksum database, as it is disabled or the
+// module is excluded from it, is accepted like the go command does, as long as
+// the build info records a module sum.
+func (m *GoBinaryManager) verifyCachedModuleSum(ctx context.Context, info *buildinfo.BuildInfo) error {
+	if info.Main.Sum == "" {
+		return fmt.Errorf("%w: no module sum", ErrModuleSumNotVerifiable)
+	}
+
+	sumDBConfig, err := m.toolchain.GetSumDBConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	module := model.NewModule(info.Main.Path, model.NewVersion(info.Main.Version))
+	if err = m.verifyModuleSum(ctx, sumDBConfig, module, info.Main.Sum); errors.Is(err, ErrModuleSumNotVerifiable) {
+		return nil
+	}
+
+	return err
+}
+
+// verifyModuleSum verifies the given sum of a module version against the
+// checksum database of the given configuration, as described in
+// VerifyModuleSum.
+func (m *GoBinaryManager) verifyModuleSum(
+	ctx context.Context,
+	sumDBConfig model.SumDBConfig,
+	module model.Module,
+	sum string,
+) error {
+	if sum == "" {
+		return fmt.Errorf("%w: no module sum, likely built locally", ErrModuleSumNotVerifiable)
+	}
+
+	if reason, disabled := sumDBConfig.GetDisabledReason(); disabled {
+		return fmt.Errorf("%w: checksum database disabled (%s)", ErrModuleSumNotVerifiable, reason)
+	}
+
+	if sumDBConfig.IsExcluded(module.Path) {
+		return fmt.Errorf(
+			"%w: module excluded from the checksum database (GONOSUMDB=%s)", ErrModuleSumNotVerifiable, sumDBConfig.NoSumDB,
+		)
+	}
+
+	url, _ := sumDBConfig.GetURL()
+
+	expectedSum, err := m.toolchain.LookupModuleSum(ctx, sumDBConfig, module)
+	if err != nil {
+		if errors.Is(err, toolchain.ErrModuleSumNotFound) {
+			return fmt.Errorf("%w: not found in the checksum database %s", ErrModuleSumNotVerifiable, url)
+		}
+
+		return err
+	}
+
+	if sum != expectedSum {
+		return fmt.Errorf("%w: recorded %s, checksum database %s", ErrModuleSumMismatch, sum, expectedSum)
+	}
+
+	return nil
+}
+
+// writeReceipt writes the given receipt to the internal receipt directory. It
+// returns an error if the receipt cannot be serialized or written.
+func (m *GoBinaryManager) writeReceipt(receipt model.Receipt) error {
+	path := m.getReceiptPath(receipt.Name)
+	logger := slog.Default().With("path", path)
+
+	data, err := json.MarshalIndent(receipt, "", "  ")
+	if err != nil {
+		logger.Error("error serializing receipt", "err", err)
+		return err
+	}
+
+	//nolint:mnd // owner only permissions
+	if err = m.fs.WriteFile(path, data, 0600); err != nil {
+		logger.Error("error writing receipt", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// getFileHash returns the hex encoded SHA-256 hash of the file at the given
+// path.
+func (m *GoBinaryManager) getFileHash(path string) (string, error) {
+	data, err := m.fs.ReadFile(path)
+	if err != nil {
+		slog.Default().Error("error reading file", "err", err, "path", path)
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// getDebugInfoPath gets the path of the debug information file of the binary
+// with the given name and build ID, in the data directory of the binary,
+// replacing the slashes separating the parts of the build ID.
+func (m *GoBinaryManager) getDebugInfoPath(name, buildID string) string {
+	key := strings.ReplaceAll(buildID, "/", ".")
+	return filepath.Join(m.workspace.GetInternalDataPath(), name, debugInfoDir, key+debugInfoExtension)
+}
+
+// getUpgradeInfo gets the upgrade info of a binary. If the module is not found
+// in the module proxy, it is resolved directly, raising a warning, and the
+// returned context is marked with WithDirect to upgrade the binary the same
+// way.
+func (m *GoBinaryManager) getUpgradeInfo(
+	ctx context.Context,
+	info model.BinaryInfo,
+	majorUpgrade bool,
+) (context.Context, model.BinaryUpgradeInfo, error) {
+	binUpInfo, err := m.GetBinaryUpgradeInfo(ctx, info, majorUpgrade)
+	if errors.Is(err, toolchain.ErrModuleNotFound) {
+		slog.Default().WarnContext(ctx, "module not found in the module proxy, falling back to direct resolution",
+			"module", info.Module.Path)
+		internal.AddWarning(
+			ctx,
+			fmt.Sprintf("module %s not found in the module proxy, resolved directly", info.Module.Path),
+		)
+		ctx = toolchain.WithDirect(ctx)
+		binUpInfo, err = m.GetBinaryUpgradeInfo(ctx, info, majorUpgrade)
+	}
+
+	return ctx, binUpInfo, err
+}
+
+// getPinnedBinary gets the binary, without version, of the internal binary
+// targeted by the pin at the given path. It returns false if the path is not a
+// pin to the internal binary directory.
+func (m *GoBinaryManager) getPinnedBinary(path string) (model.Binary, bool) {
+	target, err := m.getPinTarget(path)
+	if err != nil || !strings.HasPrefix(target, m.workspace.GetInternalBinPath()) {
+		return model.Binary{}, false
+	}
+
+	intBin := model.NewBinaryFromString(filepath.Base(target))
+
+	return model.NewBinary(intBin.Name, model.NewLatestVersion(), intBin.Extension), true
+}
+
+// getPinTarget gets the internal binary targeted by the pin at the given path,
+// from the symlink or, when pinning with copies, from the pin receipt. It
+// returns an error if the path is not a pin.
+func (m *GoBinaryManager) getPinTarget(path string) (string, error) {
+	if m.pinMode != model.PinModeCopy {
+		return m.fs.GetSymlinkTarget(path)
+	}
+
+	receipt, err := m.readReceipt(filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+
+	if receipt.Target == "" {
+		return "", ErrBinaryNotManaged
+	}
+
+	return receipt.Target, nil
+}
+
+// getPinTargets gets the internal binaries targeted by the pins in the Go
+// binary directory. It returns an error if the Go binary directory cannot be
+// listed.
+func (m *GoBinaryManager) getPinTargets() (map[string]bool, error) {
+	pinPaths, err := m.fs.ListBinaries(m.workspace.GetGoBinPath())
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]bool, len(pinPaths))
+	for _, pinPath := range pinPaths {
+		if target, targetErr := m.getPinTarget(pinPath); targetErr == nil {
+			targets[target] = true
+		}
+	}
+
+	return targets, nil
+}
+
+// getRelatedPinPaths gets the paths of the pins in the Go binary directory,
+// other than the given path, targeting a version of the given binary in the
+// internal binary directory.
+func (m *GoBinaryManager) getRelatedPinPaths(path string, bin model.Binary) ([]string, error) {
+	pinPaths, err := m.fs.ListBinaries(m.workspace.GetGoBinPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var pins []string
+	for _, pinPath := range pinPaths {
+		if pinPath == path {
+			continue
+		}
+
+		if pinBin, ok := m.getPinnedBinary(pinPath); ok && pinBin.IsPartOf(bin) {
+			pins = append(pins, pinPath)
+		}
+	}
+
+	return pins, nil
+}
+
+// getPreviousVersion gets the version pinned before the current one for the
+// pin with the given name from its receipt. It returns an error if the receipt
+// cannot be read or has no previous version.
+func (m *GoBinaryManager) getPreviousVersion(name string) (model.Version, error) {
+	receipt, err := m.readReceipt(name)
+	if err != nil {
+		return "", err
+	}
+
+	if receipt.PreviousVersion == "" {
+		slog.Default().Warn("previous version not found", "bin", name)
+		return "", ErrPreviousVersionNotFound
+	}
+
+	return receipt.PreviousVersion, nil
+}
+
+// getSignaturePath gets the path of the detached signature file of the internal
+// binary with the given file name, ex. dlv@v1.25.0, in the data directory of
+// the binary.
+func (m *GoBinaryManager) getSignaturePath(fileName string) string {
+	name := model.NewBinaryFromString(fileName).Name
+	return filepath.Join(m.workspace.GetInternalDataPath(), name, signatureDir, fileName+signatureExtension)
+}
+
+// getReceiptPath returns the receipt path for the binary with the given pin
+// name.
+func (m *GoBinaryManager) getReceiptPath(name string) string {
+	return filepath.Join(m.workspace.GetInternalReceiptPath(), name+".json")
+}
+
+// getShimPath returns the exec shim path for the binary with the given pin
+// name.
+func (m *GoBinaryManager) getShimPath(name string) string {
+	return filepath.Join(m.workspace.GetInternalShimPath(), model.GetShimName(m.runtime.OS(), name))
+}
+
+// getCrossPlatform returns the platform of a binary cross compiled in a
+// platform directory of the internal binary directory, ex. linux/arm64 for
+// ~/.gobin/bin/linux_arm64/dlv@v1.25.1, or false for any other binary.
+func (m *GoBinaryManager) getCrossPlatform(path string) (string, bool) {
+	dir := filepath.Dir(path)
+	if filepath.Dir(dir) != m.workspace.GetInternalBinPath() {
+		return "", false
 	}
 
-	return retracted, deprecated, nil
+	return model.ParsePlatformDir(filepath.Base(dir))
 }
 
 // getBinaryPlatform returns the platform of a binary based on the build info.
@@ -3,19 +3,29 @@ package manager_test
 import (
 	"context"
 	"debug/buildinfo"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime/debug"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/semver"
 
+	"github.com/brunoribeiro127/gobin/internal"
 	"github.com/brunoribeiro127/gobin/internal/manager"
 	"github.com/brunoribeiro127/gobin/internal/model"
+	"github.com/brunoribeiro127/gobin/internal/registry"
+	registrymocks "github.com/brunoribeiro127/gobin/internal/registry/mocks"
 	"github.com/brunoribeiro127/gobin/internal/system"
 	systemmocks "github.com/brunoribeiro127/gobin/internal/system/mocks"
 	"github.com/brunoribeiro127/gobin/internal/toolchain"
@@ -32,6 +42,7 @@ type mockGetLatestModuleVersionCall struct {
 	module       model.Module
 	latestModule model.Module
 	err          error
+	direct       bool
 }
 
 type mockGetSymlinkTargetCall struct {
@@ -46,43 +57,265 @@ type mockListBinariesCall struct {
 	err      error
 }
 
+type mockReadFileCall struct {
+	path string
+	data []byte
+	err  error
+}
+
 type mockRemoveCall struct {
 	bin string
 	err error
 }
 
+func TestGoBinaryManager_BuildPackage(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	tempPath := workspace.GetInternalTempPath()
+	binTempDir := filepath.Join(tempPath, "mockproj-0123456789")
+	pkg := model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0")
+
+	cases := map[string]struct {
+		platform             string
+		mockCreateTempDirErr error
+		callInstallPlatform  bool
+		mockInstallErr       error
+		callCreateDir        bool
+		mockCreateDirErr     error
+		mockMoveSrc          string
+		mockMoveDst          string
+		mockMoveErr          error
+		expectedPath         string
+		expectedErr          error
+	}{
+		"success-runtime-platform": {
+			platform:            "linux/amd64",
+			callInstallPlatform: true,
+			callCreateDir:       true,
+			mockMoveSrc:         filepath.Join(binTempDir, "bin", "mockproj"),
+			mockMoveDst:         filepath.Join("dist", "mockproj_v1.0.0_linux_amd64"),
+			expectedPath:        filepath.Join("dist", "mockproj_v1.0.0_linux_amd64"),
+		},
+		"success-cross-platform": {
+			platform:            "windows/arm64",
+			callInstallPlatform: true,
+			callCreateDir:       true,
+			mockMoveSrc:         filepath.Join(binTempDir, "bin", "windows_arm64", "mockproj.exe"),
+			mockMoveDst:         filepath.Join("dist", "mockproj_v1.0.0_windows_arm64.exe"),
+			expectedPath:        filepath.Join("dist", "mockproj_v1.0.0_windows_arm64.exe"),
+		},
+		"error-create-temp-dir": {
+			platform:             "linux/amd64",
+			mockCreateTempDirErr: errors.New("unexpected error"),
+			expectedErr:          errors.New("unexpected error"),
+		},
+		"error-install-platform": {
+			platform:            "linux/amd64",
+			callInstallPlatform: true,
+			mockInstallErr:      toolchain.ErrBuildFailed,
+			expectedErr:         toolchain.ErrBuildFailed,
+		},
+		"error-create-dir": {
+			platform:            "linux/amd64",
+			callInstallPlatform: true,
+			callCreateDir:       true,
+			mockCreateDirErr:    errors.New("unexpected error"),
+			expectedErr:         errors.New("unexpected error"),
+		},
+		"error-move": {
+			platform:            "linux/amd64",
+			callInstallPlatform: true,
+			callCreateDir:       true,
+			mockMoveSrc:         filepath.Join(binTempDir, "bin", "mockproj"),
+			mockMoveDst:         filepath.Join("dist", "mockproj_v1.0.0_linux_amd64"),
+			mockMoveErr:         errors.New("unexpected error"),
+			expectedErr:         errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			runtime := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			fs.EXPECT().CreateTempDir(tempPath, "mockproj-*").
+				Return(binTempDir, func() error { return nil }, tc.mockCreateTempDirErr).
+				Once()
+
+			if tc.callInstallPlatform {
+				toolchain.EXPECT().InstallPlatform(
+					mock.Anything, binTempDir, pkg, tc.platform, model.BuildFlags{}, false,
+				).
+					Return(tc.mockInstallErr).
+					Once()
+			}
+
+			if tc.callInstallPlatform && tc.mockInstallErr == nil {
+				runtime.EXPECT().Platform().Return("linux/amd64").Once()
+			}
+
+			if tc.callCreateDir {
+				fs.EXPECT().CreateDir("dist", os.FileMode(0755)).
+					Return(tc.mockCreateDirErr).
+					Once()
+			}
+
+			if tc.mockMoveSrc != "" {
+				fs.EXPECT().Move(tc.mockMoveSrc, tc.mockMoveDst).
+					Return(tc.mockMoveErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, runtime, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			path, err := binaryManager.BuildPackage(context.Background(), pkg, tc.platform, model.BuildFlags{}, "dist")
+			assert.Equal(t, tc.expectedPath, path)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_BundlePackages(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	tempPath := workspace.GetInternalTempPath()
+	modCache := filepath.Join(tempPath, "bundle-0123456789")
+	pkgs := []model.Package{
+		model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+		model.NewPackage("example.com/mockorg/othermockproj/cmd/othermockproj@v2.0.0"),
+	}
+
+	cases := map[string]struct {
+		mockCreateTempDirErr   error
+		callDownloadPackage    int
+		mockDownloadPackageErr error
+		callCreateArchive      bool
+		mockCreateArchiveErr   error
+		expectedErr            error
+	}{
+		"success": {
+			callDownloadPackage: 2,
+			callCreateArchive:   true,
+		},
+		"error-create-temp-dir": {
+			mockCreateTempDirErr: errors.New("unexpected error"),
+			expectedErr:          errors.New("unexpected error"),
+		},
+		"error-download-package": {
+			callDownloadPackage:    1,
+			mockDownloadPackageErr: errors.New("unexpected error"),
+			expectedErr:            errors.New("unexpected error"),
+		},
+		"error-create-archive": {
+			callDownloadPackage:  2,
+			callCreateArchive:    true,
+			mockCreateArchiveErr: errors.New("unexpected error"),
+			expectedErr:          errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			var cleanups int
+			fs.EXPECT().CreateTempDir(tempPath, "bundle-*").
+				Return(modCache, func() error { cleanups++; return nil }, tc.mockCreateTempDirErr).
+				Once()
+
+			for _, pkg := range pkgs[:tc.callDownloadPackage] {
+				toolchain.EXPECT().DownloadPackage(mock.Anything, modCache, pkg).
+					Return(tc.mockDownloadPackageErr).
+					Once()
+			}
+
+			if tc.callCreateArchive {
+				fs.EXPECT().CreateArchive("tools.bundle", modCache, filepath.Join("cache", "download")).
+					Return(tc.mockCreateArchiveErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err := binaryManager.BundlePackages(context.Background(), "tools.bundle", pkgs...)
+			assert.Equal(t, tc.expectedErr, err)
+
+			if tc.mockCreateTempDirErr == nil {
+				assert.Equal(t, 1, cleanups)
+			}
+		})
+	}
+}
+
 func TestGoBinaryManager_DiagnoseBinary(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
 
 	intBinPath := workspace.GetInternalBinPath()
+	longPath := filepath.Join(intBinPath, strings.Repeat("a", system.WindowsMaxPath)+"@v0.1.0.exe")
 
 	cases := map[string]struct {
-		path                   string
-		mockGetBuildInfo       *buildinfo.BuildInfo
-		mockGetBuildInfoErr    error
-		callRuntimePlatform    bool
-		mockRuntimePlatform    string
-		callRuntimeVersion     bool
-		mockRuntimeVersion     string
-		callLocateBinaryInPath bool
-		mockLocateBinaryInPath []string
-		callIsSymlinkToDir     bool
-		mockIsSymlinkToDir     bool
-		mockIsSymlinkToDirErr  error
-		callGetModuleFile      bool
-		mockGetModuleFile      *modfile.File
-		mockGetModuleFileErr   error
-		callVulnCheck          bool
-		mockVulnCheckVulns     []model.Vulnerability
-		mockVulnCheckErr       error
-		expectedDiagnostic     model.BinaryDiagnostic
-		expectedHasIssues      bool
-		expectedErr            error
+		path                    string
+		pinMode                 model.PinMode
+		mockGetBuildInfo        *buildinfo.BuildInfo
+		mockGetBuildInfoErr     error
+		callRuntimePlatform     bool
+		mockRuntimePlatform     string
+		callRuntimeVersion      bool
+		mockRuntimeVersion      string
+		callLocateBinaryInPath  bool
+		mockLocateBinaryInPath  []string
+		callReadReceipt         int
+		mockReadReceipt         []byte
+		mockReadReceiptErr      error
+		callProbeBinary         bool
+		mockProbeBinaryErr      error
+		callVerifySignature     bool
+		mockRuntimeOS           string
+		mockVerifySignatureErr  error
+		callIsSymlinkToDir      bool
+		mockIsSymlinkToDir      bool
+		mockIsSymlinkToDirErr   error
+		callGetELFInterpreter   bool
+		mockGetELFInterpreter   string
+		callExists              bool
+		mockExists              bool
+		callGetSymlinkTarget    bool
+		mockGetSymlinkTarget    string
+		mockGetSymlinkTargetErr error
+		mockModuleStatusCache   []byte
+		callGetModuleFile       bool
+		mockGetModuleFile       *modfile.File
+		mockGetModuleFileErr    error
+		mockSumDBConfig         *model.SumDBConfig
+		mockLookupModuleSum     string
+		mockLookupModuleSumErr  error
+		callVulnCheck           bool
+		mockVulnCheckVulns      []model.Vulnerability
+		mockVulnCheckErr        error
+		expectedDiagnostic      model.BinaryDiagnostic
+		expectedHasIssues       bool
+		expectedErr             error
 	}{
 		"success-has-issues": {
 			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
@@ -119,6 +352,7 @@ func TestGoBinaryManager_DiagnoseBinary(t *testing.T) {
 			},
 			expectedDiagnostic: model.BinaryDiagnostic{
 				Name:      "mockproj",
+				Module:    "example.com/mockorg/mockproj",
 				NotInPath: false,
 				DuplicatesInPath: []string{
 					filepath.Join(workspace.GetGoBinPath(), "mockproj"),
@@ -245,6 +479,8 @@ func TestGoBinaryManager_DiagnoseBinary(t *testing.T) {
 			mockLocateBinaryInPath: []string{
 				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
 			},
+			callReadReceipt:    1,
+			mockReadReceiptErr: os.ErrNotExist,
 			callIsSymlinkToDir: true,
 			mockIsSymlinkToDir: true,
 			callRuntimeVersion: true,
@@ -257,6 +493,7 @@ func TestGoBinaryManager_DiagnoseBinary(t *testing.T) {
 			mockVulnCheckVulns: []model.Vulnerability{},
 			expectedDiagnostic: model.BinaryDiagnostic{
 				Name:             "mockproj",
+				Module:           "example.com/mockorg/mockproj",
 				NotInPath:        false,
 				DuplicatesInPath: nil,
 				GoVersion: struct {
@@ -282,242 +519,7117 @@ func TestGoBinaryManager_DiagnoseBinary(t *testing.T) {
 				Vulnerabilities:       []model.Vulnerability{},
 			},
 		},
-		"error-get-build-info": {
-			path:                filepath.Join(workspace.GetGoBinPath(), "mockproj"),
-			mockGetBuildInfoErr: errors.New("unexpected error"),
-			expectedErr:         errors.New("unexpected error"),
+		"success-cross-build": {
+			path:                filepath.Join(intBinPath, "darwin_arm64", "mockproj@v0.1.0"),
+			mockGetBuildInfo:    getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform: true,
+			mockRuntimePlatform: "linux/amd64",
+			callRuntimeVersion:  true,
+			mockRuntimeVersion:  "go1.24.5",
+			callGetModuleFile:   true,
+			mockGetModuleFile: &modfile.File{
+				Module: &modfile.Module{},
+			},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "darwin_arm64/mockproj@v0.1.0",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "darwin/arm64",
+					Expected: "darwin/arm64",
+				},
+				Vulnerabilities: []model.Vulnerability{},
+			},
 		},
-		"error-get-module-file": {
+		"success-cached-module-status": {
 			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
 			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
 			callRuntimePlatform:    true,
-			mockRuntimePlatform:    "linux/amd64",
+			mockRuntimePlatform:    "darwin/arm64",
 			callLocateBinaryInPath: true,
 			mockLocateBinaryInPath: []string{
 				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
-				"/usr/local/bin/mockproj",
 			},
-			callIsSymlinkToDir:   true,
-			mockIsSymlinkToDir:   false,
-			callRuntimeVersion:   true,
-			mockRuntimeVersion:   "go1.23.11",
-			callGetModuleFile:    true,
-			mockGetModuleFileErr: errors.New("unexpected error"),
-			expectedErr:          errors.New("unexpected error"),
+			callReadReceipt:    1,
+			mockReadReceiptErr: os.ErrNotExist,
+			callIsSymlinkToDir: true,
+			mockIsSymlinkToDir: true,
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.24.5",
+			mockModuleStatusCache: []byte(`{"example.com/mockorg/mockproj":{"checked_at":"` +
+				time.Now().Format(time.RFC3339) + `","deprecated":"cached deprecated",` +
+				`"retractions":[{"low":"v0.1.0","high":"v0.1.0","rationale":"cached rationale"}]}}`),
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "mockproj",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "darwin/arm64",
+					Expected: "darwin/arm64",
+				},
+				Retracted:       "cached rationale",
+				Deprecated:      "cached deprecated",
+				Vulnerabilities: []model.Vulnerability{},
+			},
+			expectedHasIssues: true,
 		},
-		"error-vuln-check": {
+		"success-expired-module-status": {
 			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
 			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
 			callRuntimePlatform:    true,
-			mockRuntimePlatform:    "linux/amd64",
+			mockRuntimePlatform:    "darwin/arm64",
 			callLocateBinaryInPath: true,
 			mockLocateBinaryInPath: []string{
 				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
-				"/usr/local/bin/mockproj",
 			},
+			callReadReceipt:    1,
+			mockReadReceiptErr: os.ErrNotExist,
 			callIsSymlinkToDir: true,
-			mockIsSymlinkToDir: false,
+			mockIsSymlinkToDir: true,
 			callRuntimeVersion: true,
-			mockRuntimeVersion: "go1.23.11",
-			callGetModuleFile:  true,
+			mockRuntimeVersion: "go1.24.5",
+			mockModuleStatusCache: []byte(`{"example.com/mockorg/mockproj":{"checked_at":"` +
+				time.Now().Add(-25*time.Hour).Format(time.RFC3339) + `","deprecated":"cached deprecated",` +
+				`"retractions":[{"low":"v0.1.0","high":"v0.1.0","rationale":"cached rationale"}]}}`),
+			callGetModuleFile: true,
 			mockGetModuleFile: &modfile.File{
 				Module: &modfile.Module{
 					Deprecated: "mock deprecated",
 				},
-				Retract: []*modfile.Retract{
-					{
-						VersionInterval: modfile.VersionInterval{
-							Low:  "v0.1.0",
-							High: "v0.1.0",
-						},
-						Rationale: "mock rationale",
-					},
+			},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "mockproj",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "darwin/arm64",
+					Expected: "darwin/arm64",
 				},
+				Retracted:       "",
+				Deprecated:      "mock deprecated",
+				Vulnerabilities: []model.Vulnerability{},
 			},
-			callVulnCheck:    true,
-			mockVulnCheckErr: errors.New("unexpected error"),
-			expectedErr:      errors.New("unexpected error"),
+			expectedHasIssues: true,
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			fs := systemmocks.NewFileSystem(t)
-			runtime := systemmocks.NewRuntime(t)
-			toolchain := toolchainmocks.NewToolchain(t)
-
-			toolchain.EXPECT().GetBuildInfo(tc.path).
-				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
-				Once()
-
-			if tc.callRuntimePlatform {
-				runtime.EXPECT().Platform().
-					Return(tc.mockRuntimePlatform).
-					Once()
-			}
-
-			if tc.callRuntimeVersion {
-				runtime.EXPECT().Version().
-					Return(tc.mockRuntimeVersion).
-					Once()
-			}
-
-			if tc.callLocateBinaryInPath {
-				fs.EXPECT().LocateBinaryInPath(filepath.Base(tc.path)).
-					Return(tc.mockLocateBinaryInPath).
-					Once()
-			}
-
-			if tc.callIsSymlinkToDir {
-				fs.EXPECT().IsSymlinkToDir(tc.path, intBinPath).
-					Return(tc.mockIsSymlinkToDir, tc.mockIsSymlinkToDirErr).
-					Once()
-			}
-
-			if tc.callGetModuleFile {
-				toolchain.EXPECT().GetModuleFile(
-					context.Background(),
-					model.NewLatestModule(tc.mockGetBuildInfo.Main.Path),
-				).Return(tc.mockGetModuleFile, tc.mockGetModuleFileErr).Once()
-			}
-
-			if tc.callVulnCheck {
-				toolchain.EXPECT().VulnCheck(context.Background(), tc.path).
-					Return(tc.mockVulnCheckVulns, tc.mockVulnCheckErr).
-					Once()
-			}
-
-			binaryManager := manager.NewGoBinaryManager(fs, runtime, toolchain, workspace)
-			diagnostic, diagErr := binaryManager.DiagnoseBinary(context.Background(), tc.path)
-			assert.Equal(t, tc.expectedDiagnostic, diagnostic)
-			assert.Equal(t, tc.expectedHasIssues, diagnostic.HasIssues())
-			assert.Equal(t, tc.expectedErr, diagErr)
-		})
-	}
+		"success-missing-interpreter": {
+			path: filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo: func() *buildinfo.BuildInfo {
+				info := getBuildInfo("mockproj", "v0.1.0")
+				info.Settings[0].Value = "linux"
+				info.Settings[1].Value = "amd64"
+				return info
+			}(),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "linux/amd64",
+			callGetELFInterpreter:  true,
+			mockGetELFInterpreter:  "/lib/ld-musl-x86_64.so.1",
+			callExists:             true,
+			mockExists:             false,
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			},
+			callReadReceipt:    1,
+			mockReadReceiptErr: os.ErrNotExist,
+			callIsSymlinkToDir: true,
+			mockIsSymlinkToDir: true,
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.24.5",
+			callGetModuleFile:  true,
+			mockGetModuleFile: &modfile.File{
+				Module: &modfile.Module{},
+			},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "mockproj",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "linux/amd64",
+					Expected: "linux/amd64",
+				},
+				MissingInterpreter: "/lib/ld-musl-x86_64.so.1",
+				Vulnerabilities:    []model.Vulnerability{},
+			},
+			expectedHasIssues: true,
+		},
+		"success-interpreter-found": {
+			path: filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo: func() *buildinfo.BuildInfo {
+				info := getBuildInfo("mockproj", "v0.1.0")
+				info.Settings[0].Value = "linux"
+				info.Settings[1].Value = "amd64"
+				return info
+			}(),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "linux/amd64",
+			callGetELFInterpreter:  true,
+			mockGetELFInterpreter:  "/lib64/ld-linux-x86-64.so.2",
+			callExists:             true,
+			mockExists:             true,
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			},
+			callReadReceipt:    1,
+			mockReadReceiptErr: os.ErrNotExist,
+			callIsSymlinkToDir: true,
+			mockIsSymlinkToDir: true,
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.24.5",
+			callGetModuleFile:  true,
+			mockGetModuleFile: &modfile.File{
+				Module: &modfile.Module{},
+			},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "mockproj",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "linux/amd64",
+					Expected: "linux/amd64",
+				},
+				Vulnerabilities: []model.Vulnerability{},
+			},
+		},
+		"success-windows-long-path": {
+			path: filepath.Join(workspace.GetGoBinPath(), "mockproj.exe"),
+			mockGetBuildInfo: func() *buildinfo.BuildInfo {
+				info := getBuildInfo("mockproj.exe", "v0.1.0")
+				info.Settings[0].Value = "windows"
+				info.Settings[1].Value = "amd64"
+				return info
+			}(),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "windows/amd64",
+			callGetSymlinkTarget:   true,
+			mockGetSymlinkTarget:   longPath,
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj.exe"),
+			},
+			callReadReceipt:    1,
+			mockReadReceiptErr: os.ErrNotExist,
+			callIsSymlinkToDir: true,
+			mockIsSymlinkToDir: true,
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.24.5",
+			callGetModuleFile:  true,
+			mockGetModuleFile: &modfile.File{
+				Module: &modfile.Module{},
+			},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "mockproj.exe",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "windows/amd64",
+					Expected: "windows/amd64",
+				},
+				LongPath:        longPath,
+				Vulnerabilities: []model.Vulnerability{},
+			},
+			expectedHasIssues: true,
+		},
+		"success-windows-short-path": {
+			path: filepath.Join(workspace.GetGoBinPath(), "mockproj.exe"),
+			mockGetBuildInfo: func() *buildinfo.BuildInfo {
+				info := getBuildInfo("mockproj.exe", "v0.1.0")
+				info.Settings[0].Value = "windows"
+				info.Settings[1].Value = "amd64"
+				return info
+			}(),
+			callRuntimePlatform:     true,
+			mockRuntimePlatform:     "windows/amd64",
+			callGetSymlinkTarget:    true,
+			mockGetSymlinkTargetErr: os.ErrInvalid,
+			callLocateBinaryInPath:  true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj.exe"),
+			},
+			callReadReceipt:    1,
+			mockReadReceiptErr: os.ErrNotExist,
+			callIsSymlinkToDir: true,
+			mockIsSymlinkToDir: true,
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.24.5",
+			callGetModuleFile:  true,
+			mockGetModuleFile: &modfile.File{
+				Module: &modfile.Module{},
+			},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "mockproj.exe",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "windows/amd64",
+					Expected: "windows/amd64",
+				},
+				Vulnerabilities: []model.Vulnerability{},
+			},
+		},
+		"success-copy-mode-managed": {
+			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			pinMode:                model.PinModeCopy,
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "darwin/arm64",
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			},
+			callReadReceipt: 2,
+			mockReadReceipt: []byte(`{"name":"mockproj","target":` +
+				strconv.Quote(filepath.Join(intBinPath, "mockproj@v0.1.0")) + `}`),
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.24.5",
+			callGetModuleFile:  true,
+			mockGetModuleFile: &modfile.File{
+				Module: &modfile.Module{},
+			},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "mockproj",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "darwin/arm64",
+					Expected: "darwin/arm64",
+				},
+				Vulnerabilities: []model.Vulnerability{},
+			},
+		},
+		"success-compressed-not-executable": {
+			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "darwin/arm64",
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			},
+			callReadReceipt: 1,
+			mockReadReceipt: []byte(
+				`{"name":"mockproj","compression":{"original_size":10485760,"compressed_size":3145728}}`,
+			),
+			callProbeBinary:    true,
+			mockProbeBinaryErr: errors.New("signal: killed"),
+			callIsSymlinkToDir: true,
+			mockIsSymlinkToDir: true,
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.24.5",
+			callGetModuleFile:  true,
+			mockGetModuleFile: &modfile.File{
+				Module: &modfile.Module{},
+			},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "mockproj",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "darwin/arm64",
+					Expected: "darwin/arm64",
+				},
+				CompressedNotExecutable: "signal: killed",
+				Vulnerabilities:         []model.Vulnerability{},
+			},
+			expectedHasIssues: true,
+		},
+		"success-invalid-signature": {
+			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "darwin/arm64",
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			},
+			callReadReceipt: 1,
+			mockReadReceipt: []byte(
+				`{"name":"mockproj","signature":{"identity":"Developer ID Application: Mock Org (MOCKTEAMID)"}}`,
+			),
+			callVerifySignature:    true,
+			mockRuntimeOS:          "darwin",
+			mockVerifySignatureErr: errors.New("exit status 1: mockproj: invalid signature"),
+			callIsSymlinkToDir:     true,
+			mockIsSymlinkToDir:     true,
+			callRuntimeVersion:     true,
+			mockRuntimeVersion:     "go1.24.5",
+			callGetModuleFile:      true,
+			mockGetModuleFile: &modfile.File{
+				Module: &modfile.Module{},
+			},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:   "mockproj",
+				Module: "example.com/mockorg/mockproj",
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "darwin/arm64",
+					Expected: "darwin/arm64",
+				},
+				InvalidSignature: "exit status 1: mockproj: invalid signature",
+				Vulnerabilities:  []model.Vulnerability{},
+			},
+			expectedHasIssues: true,
+		},
+		"success-sum-mismatch": {
+			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "darwin/arm64",
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			},
+			callIsSymlinkToDir:  true,
+			mockIsSymlinkToDir:  false,
+			callRuntimeVersion:  true,
+			mockRuntimeVersion:  "go1.24.5",
+			callGetModuleFile:   true,
+			mockGetModuleFile:   &modfile.File{Module: &modfile.Module{}},
+			mockLookupModuleSum: "h1:mocksum=",
+			callVulnCheck:       true,
+			mockVulnCheckVulns:  []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:         "mockproj",
+				Module:       "example.com/mockorg/mockproj",
+				IsNotManaged: true,
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "darwin/arm64",
+					Expected: "darwin/arm64",
+				},
+				SumMismatch:     "module sum mismatch: recorded " + getBuildInfo("mockproj", "v0.1.0").Main.Sum + ", checksum database h1:mocksum=",
+				Vulnerabilities: []model.Vulnerability{},
+			},
+			expectedHasIssues: true,
+		},
+		"success-sum-not-verifiable": {
+			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "darwin/arm64",
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			},
+			callIsSymlinkToDir: true,
+			mockIsSymlinkToDir: false,
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.24.5",
+			callGetModuleFile:  true,
+			mockGetModuleFile:  &modfile.File{Module: &modfile.Module{}},
+			mockSumDBConfig:    &model.SumDBConfig{SumDB: "sum.golang.org", NoSumDB: "example.com/mockorg"},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:         "mockproj",
+				Module:       "example.com/mockorg/mockproj",
+				IsNotManaged: true,
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "darwin/arm64",
+					Expected: "darwin/arm64",
+				},
+				SumNotVerifiable: "module sum not verifiable: module excluded from the checksum database " +
+					"(GONOSUMDB=example.com/mockorg)",
+				Vulnerabilities: []model.Vulnerability{},
+			},
+			expectedHasIssues: true,
+		},
+		"success-sumdb-disabled": {
+			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "darwin/arm64",
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			},
+			callIsSymlinkToDir: true,
+			mockIsSymlinkToDir: false,
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.24.5",
+			callGetModuleFile:  true,
+			mockGetModuleFile:  &modfile.File{Module: &modfile.Module{}},
+			mockSumDBConfig:    &model.SumDBConfig{SumDB: "off"},
+			callVulnCheck:      true,
+			mockVulnCheckVulns: []model.Vulnerability{},
+			expectedDiagnostic: model.BinaryDiagnostic{
+				Name:         "mockproj",
+				Module:       "example.com/mockorg/mockproj",
+				IsNotManaged: true,
+				GoVersion: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "go1.24.5",
+					Expected: "go1.24.5",
+				},
+				Platform: struct {
+					Actual   string
+					Expected string
+				}{
+					Actual:   "darwin/arm64",
+					Expected: "darwin/arm64",
+				},
+				Vulnerabilities: []model.Vulnerability{},
+			},
+			expectedHasIssues: true,
+		},
+		"error-get-build-info": {
+			path:                filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfoErr: errors.New("unexpected error"),
+			expectedErr:         errors.New("unexpected error"),
+		},
+		"error-get-module-file": {
+			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "linux/amd64",
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+				"/usr/local/bin/mockproj",
+			},
+			callIsSymlinkToDir:   true,
+			mockIsSymlinkToDir:   false,
+			callRuntimeVersion:   true,
+			mockRuntimeVersion:   "go1.23.11",
+			callGetModuleFile:    true,
+			mockGetModuleFileErr: errors.New("unexpected error"),
+			expectedErr:          errors.New("unexpected error"),
+		},
+		"error-vuln-check": {
+			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "linux/amd64",
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+				"/usr/local/bin/mockproj",
+			},
+			callIsSymlinkToDir: true,
+			mockIsSymlinkToDir: false,
+			callRuntimeVersion: true,
+			mockRuntimeVersion: "go1.23.11",
+			callGetModuleFile:  true,
+			mockGetModuleFile: &modfile.File{
+				Module: &modfile.Module{
+					Deprecated: "mock deprecated",
+				},
+				Retract: []*modfile.Retract{
+					{
+						VersionInterval: modfile.VersionInterval{
+							Low:  "v0.1.0",
+							High: "v0.1.0",
+						},
+						Rationale: "mock rationale",
+					},
+				},
+			},
+			callVulnCheck:    true,
+			mockVulnCheckErr: errors.New("unexpected error"),
+			expectedErr:      errors.New("unexpected error"),
+		},
+		"error-lookup-module-sum": {
+			path:                   filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callRuntimePlatform:    true,
+			mockRuntimePlatform:    "darwin/arm64",
+			callLocateBinaryInPath: true,
+			mockLocateBinaryInPath: []string{
+				filepath.Join(workspace.GetGoBinPath(), "mockproj"),
+			},
+			callIsSymlinkToDir:     true,
+			mockIsSymlinkToDir:     false,
+			callRuntimeVersion:     true,
+			mockRuntimeVersion:     "go1.24.5",
+			callGetModuleFile:      true,
+			mockGetModuleFile:      &modfile.File{Module: &modfile.Module{}},
+			mockLookupModuleSumErr: errors.New("unexpected error"),
+			expectedErr:            errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			runtime := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo(tc.path).
+				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callRuntimePlatform {
+				runtime.EXPECT().Platform().
+					Return(tc.mockRuntimePlatform).
+					Once()
+			}
+
+			if tc.callRuntimeVersion {
+				runtime.EXPECT().Version().
+					Return(tc.mockRuntimeVersion).
+					Once()
+			}
+
+			if tc.callGetELFInterpreter {
+				fs.EXPECT().GetELFInterpreter(tc.path).
+					Return(tc.mockGetELFInterpreter, nil).
+					Once()
+			}
+
+			if tc.callExists {
+				fs.EXPECT().Exists(tc.mockGetELFInterpreter).
+					Return(tc.mockExists).
+					Once()
+			}
+
+			if tc.callGetSymlinkTarget {
+				fs.EXPECT().GetSymlinkTarget(tc.path).
+					Return(tc.mockGetSymlinkTarget, tc.mockGetSymlinkTargetErr).
+					Once()
+			}
+
+			if tc.callLocateBinaryInPath {
+				fs.EXPECT().LocateBinaryInPath(filepath.Base(tc.path)).
+					Return(tc.mockLocateBinaryInPath).
+					Once()
+			}
+
+			if tc.callReadReceipt > 0 {
+				fs.EXPECT().ReadFile(filepath.Join(workspace.GetInternalReceiptPath(), filepath.Base(tc.path)+".json")).
+					Return(tc.mockReadReceipt, tc.mockReadReceiptErr).
+					Times(tc.callReadReceipt)
+			}
+
+			if tc.callProbeBinary {
+				toolchain.EXPECT().ProbeBinary(context.Background(), tc.path).
+					Return(tc.mockProbeBinaryErr).
+					Once()
+			}
+
+			if tc.callVerifySignature {
+				runtime.EXPECT().OS().
+					Return(tc.mockRuntimeOS).
+					Once()
+				toolchain.EXPECT().VerifySignature(context.Background(), tc.mockRuntimeOS, tc.path, "").
+					Return(tc.mockVerifySignatureErr).
+					Once()
+			}
+
+			if tc.callIsSymlinkToDir {
+				fs.EXPECT().IsSymlinkToDir(tc.path, intBinPath).
+					Return(tc.mockIsSymlinkToDir, tc.mockIsSymlinkToDirErr).
+					Once()
+			}
+
+			cachePath := filepath.Join(workspace.GetInternalCachePath(), "module-status.json")
+			mockReadCacheErr := error(nil)
+			if tc.mockModuleStatusCache == nil {
+				mockReadCacheErr = os.ErrNotExist
+			}
+
+			if tc.callGetModuleFile || tc.mockModuleStatusCache != nil {
+				fs.EXPECT().ReadFile(cachePath).
+					Return(tc.mockModuleStatusCache, mockReadCacheErr).
+					Once()
+			}
+
+			if tc.callGetModuleFile {
+				toolchain.EXPECT().GetModuleFile(
+					context.Background(),
+					model.NewLatestModule(tc.mockGetBuildInfo.Main.Path),
+				).Return(tc.mockGetModuleFile, tc.mockGetModuleFileErr).Once()
+			}
+
+			if tc.callGetModuleFile && tc.mockGetModuleFileErr == nil {
+				fs.EXPECT().ReadFile(cachePath).
+					Return(tc.mockModuleStatusCache, mockReadCacheErr).
+					Once()
+
+				fs.EXPECT().CreateDir(workspace.GetInternalCachePath(), os.FileMode(0700)).
+					Return(nil).
+					Once()
+
+				fs.EXPECT().WriteFile(cachePath, mock.Anything, os.FileMode(0600)).
+					RunAndReturn(func(_ string, data []byte, _ os.FileMode) error {
+						var cache map[string]json.RawMessage
+						require.NoError(t, json.Unmarshal(data, &cache))
+						assert.Contains(t, cache, tc.mockGetBuildInfo.Main.Path)
+						return nil
+					}).
+					Once()
+			}
+
+			if (tc.callGetModuleFile || tc.mockModuleStatusCache != nil) && tc.mockGetModuleFileErr == nil {
+				sumDBConfig := model.SumDBConfig{SumDB: "sum.golang.org"}
+				if tc.mockSumDBConfig != nil {
+					sumDBConfig = *tc.mockSumDBConfig
+				}
+
+				toolchain.EXPECT().GetSumDBConfig(context.Background()).
+					Return(sumDBConfig, nil).
+					Once()
+
+				mod := model.NewModule(tc.mockGetBuildInfo.Main.Path, model.NewVersion(tc.mockGetBuildInfo.Main.Version))
+				if _, disabled := sumDBConfig.GetDisabledReason(); !disabled && !sumDBConfig.IsExcluded(mod.Path) {
+					mockSum := tc.mockGetBuildInfo.Main.Sum
+					if tc.mockLookupModuleSum != "" || tc.mockLookupModuleSumErr != nil {
+						mockSum = tc.mockLookupModuleSum
+					}
+
+					toolchain.EXPECT().LookupModuleSum(context.Background(), sumDBConfig, mod).
+						Return(mockSum, tc.mockLookupModuleSumErr).
+						Once()
+				}
+			}
+
+			if tc.callVulnCheck {
+				toolchain.EXPECT().VulnCheck(context.Background(), tc.path).
+					Return(tc.mockVulnCheckVulns, tc.mockVulnCheckErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, runtime, toolchain, workspace, model.NewDefaultPinFormat(), tc.pinMode,
+				model.SigningConfig{},
+			)
+			diagnostic, diagErr := binaryManager.DiagnoseBinary(context.Background(), tc.path)
+			assert.Equal(t, tc.expectedDiagnostic, diagnostic)
+			assert.Equal(t, tc.expectedHasIssues, diagnostic.HasIssues())
+			assert.Equal(t, tc.expectedErr, diagErr)
+		})
+	}
+}
+
+func TestGoBinaryManager_DiagnoseHTTPProxies(t *testing.T) {
+	cases := map[string]struct {
+		mockGetProxies        []string
+		mockGetProxiesErr     error
+		callGetSumDBConfig    bool
+		mockGetSumDBConfig    model.SumDBConfig
+		mockGetSumDBConfigErr error
+		mockGetHTTPProxy      map[string]string
+		mockGetHTTPProxyErr   error
+		expectedRoutes        []model.HTTPRoute
+		expectedErr           error
+	}{
+		"success": {
+			mockGetProxies:     []string{"https://proxy.example.com", "https://proxy.golang.org", "direct"},
+			callGetSumDBConfig: true,
+			mockGetSumDBConfig: model.SumDBConfig{SumDB: "sum.golang.org"},
+			mockGetHTTPProxy: map[string]string{
+				"https://proxy.example.com": "",
+				"https://proxy.golang.org":  "http://proxy.corp:3128",
+				"https://sum.golang.org":    "http://proxy.corp:3128",
+				"https://vuln.go.dev":       "http://proxy.corp:3128",
+			},
+			expectedRoutes: []model.HTTPRoute{
+				{Endpoint: "module proxy", URL: "https://proxy.example.com"},
+				{Endpoint: "module proxy", URL: "https://proxy.golang.org", Proxy: "http://proxy.corp:3128"},
+				{Endpoint: "checksum database", URL: "https://sum.golang.org", Proxy: "http://proxy.corp:3128"},
+				{Endpoint: "vulnerability database", URL: "https://vuln.go.dev", Proxy: "http://proxy.corp:3128"},
+			},
+		},
+		"success-proxy-and-sumdb-off": {
+			callGetSumDBConfig: true,
+			mockGetSumDBConfig: model.SumDBConfig{SumDB: "off"},
+			mockGetHTTPProxy: map[string]string{
+				"https://vuln.go.dev": "",
+			},
+			expectedRoutes: []model.HTTPRoute{
+				{Endpoint: "vulnerability database", URL: "https://vuln.go.dev"},
+			},
+		},
+		"error-get-proxies": {
+			mockGetProxiesErr: errors.New("unexpected error"),
+			expectedErr:       errors.New("unexpected error"),
+		},
+		"error-get-sumdb-config": {
+			mockGetProxies:        []string{"https://proxy.golang.org"},
+			callGetSumDBConfig:    true,
+			mockGetSumDBConfigErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
+		},
+		"error-get-http-proxy": {
+			mockGetProxies:     []string{"https://proxy.golang.org"},
+			callGetSumDBConfig: true,
+			mockGetSumDBConfig: model.SumDBConfig{SumDB: "off"},
+			mockGetHTTPProxy: map[string]string{
+				"https://proxy.golang.org": "",
+			},
+			mockGetHTTPProxyErr: errors.New(`invalid proxy address "://corp": missing protocol scheme`),
+			expectedErr:         errors.New(`invalid proxy address "://corp": missing protocol scheme`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetProxies(context.Background()).
+				Return(tc.mockGetProxies, tc.mockGetProxiesErr).
+				Once()
+
+			if tc.callGetSumDBConfig {
+				toolchain.EXPECT().GetSumDBConfig(context.Background()).
+					Return(tc.mockGetSumDBConfig, tc.mockGetSumDBConfigErr).
+					Once()
+			}
+
+			for url, proxy := range tc.mockGetHTTPProxy {
+				toolchain.EXPECT().GetHTTPProxy(url).
+					Return(proxy, tc.mockGetHTTPProxyErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			routes, err := binaryManager.DiagnoseHTTPProxies(context.Background())
+			assert.Equal(t, tc.expectedRoutes, routes)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_DiagnoseNetwork(t *testing.T) {
+	cases := map[string]struct {
+		mockGetProxies    []string
+		mockGetProxiesErr error
+		mockProbeProxyErr map[string][]error
+		expectedHealths   []model.ProxyHealth
+		expectedErr       error
+	}{
+		"success": {
+			mockGetProxies: []string{"https://proxy.example.com", "https://proxy.golang.org"},
+			mockProbeProxyErr: map[string][]error{
+				"https://proxy.example.com": {nil, errors.New("exit status 1"), nil},
+				"https://proxy.golang.org":  {nil, nil, nil},
+			},
+			expectedHealths: []model.ProxyHealth{
+				{Proxy: "https://proxy.example.com", Probes: 3, Errors: 1},
+				{Proxy: "https://proxy.golang.org", Probes: 3, Errors: 0},
+			},
+		},
+		"success-proxy-down": {
+			mockGetProxies: []string{"https://proxy.example.com"},
+			mockProbeProxyErr: map[string][]error{
+				"https://proxy.example.com": {
+					errors.New("exit status 1"), errors.New("exit status 1"), errors.New("exit status 1"),
+				},
+			},
+			expectedHealths: []model.ProxyHealth{
+				{Proxy: "https://proxy.example.com", Probes: 3, Errors: 3},
+			},
+		},
+		"success-proxy-unauthorized": {
+			mockGetProxies: []string{"https://proxy.example.com"},
+			mockProbeProxyErr: map[string][]error{
+				"https://proxy.example.com": {
+					fmt.Errorf("%w: 401 Unauthorized", toolchain.ErrProxyAuthentication),
+					fmt.Errorf("%w: 401 Unauthorized", toolchain.ErrProxyAuthentication),
+					fmt.Errorf("%w: 401 Unauthorized", toolchain.ErrProxyAuthentication),
+				},
+			},
+			expectedHealths: []model.ProxyHealth{
+				{Proxy: "https://proxy.example.com", Probes: 3, Errors: 3, Unauthorized: true},
+			},
+		},
+		"error-get-proxies": {
+			mockGetProxiesErr: errors.New("unexpected error"),
+			expectedErr:       errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetProxies(context.Background()).
+				Return(tc.mockGetProxies, tc.mockGetProxiesErr).
+				Once()
+
+			for proxy, errs := range tc.mockProbeProxyErr {
+				for _, err := range errs {
+					toolchain.EXPECT().ProbeProxy(context.Background(), proxy).
+						Return(err).
+						Once()
+				}
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			healths, err := binaryManager.DiagnoseNetwork(context.Background())
+
+			for i := range healths {
+				healths[i].Latency = 0
+			}
+
+			assert.Equal(t, tc.expectedHealths, healths)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_EstimateDownloadSize(t *testing.T) {
+	type mockGetPackageDownloadSizeCall struct {
+		pkg    model.Package
+		module model.Module
+		size   model.ByteSize
+		err    error
+	}
+
+	cases := map[string]struct {
+		packages         []model.Package
+		mockCalls        []mockGetPackageDownloadSizeCall
+		expectedEstimate model.DownloadEstimate
+	}{
+		"success-distinct-modules": {
+			packages: []model.Package{
+				model.NewPackage("example.com/mockorg/mockproj1/cmd/mockproj1@v0.1.0"),
+				model.NewPackage("example.com/mockorg/mockproj2/cmd/mockproj2@v0.2.0"),
+			},
+			mockCalls: []mockGetPackageDownloadSizeCall{
+				{
+					pkg:    model.NewPackage("example.com/mockorg/mockproj1/cmd/mockproj1@v0.1.0"),
+					module: model.NewModule("example.com/mockorg/mockproj1", model.NewVersion("v0.1.0")),
+					size:   1000,
+				},
+				{
+					pkg:    model.NewPackage("example.com/mockorg/mockproj2/cmd/mockproj2@v0.2.0"),
+					module: model.NewModule("example.com/mockorg/mockproj2", model.NewVersion("v0.2.0")),
+					size:   2000,
+				},
+			},
+			expectedEstimate: model.DownloadEstimate{Modules: 2, Size: 3000},
+		},
+		"success-same-module": {
+			packages: []model.Package{
+				model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj1@v0.1.0"),
+				model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj2@v0.1.0"),
+			},
+			mockCalls: []mockGetPackageDownloadSizeCall{
+				{
+					pkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj1@v0.1.0"),
+					module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+					size:   1000,
+				},
+				{
+					pkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj2@v0.1.0"),
+					module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+					size:   1000,
+				},
+			},
+			expectedEstimate: model.DownloadEstimate{Modules: 1, Size: 1000},
+		},
+		"success-unknown-sizes": {
+			packages: []model.Package{
+				model.NewPackage("example.com/mockorg/mockproj1/cmd/mockproj1@v0.1.0"),
+				model.NewPackage("example.com/mockorg/mockproj2/cmd/mockproj2@latest"),
+				model.NewPackage("example.com/mockorg/mockproj3/cmd/mockproj3@latest"),
+			},
+			mockCalls: []mockGetPackageDownloadSizeCall{
+				{
+					pkg:    model.NewPackage("example.com/mockorg/mockproj1/cmd/mockproj1@v0.1.0"),
+					module: model.NewModule("example.com/mockorg/mockproj1", model.NewVersion("v0.1.0")),
+					size:   1000,
+				},
+				{
+					pkg:    model.NewPackage("example.com/mockorg/mockproj2/cmd/mockproj2@latest"),
+					module: model.NewModule("example.com/mockorg/mockproj2", model.NewVersion("v0.2.0")),
+					err:    toolchain.ErrDownloadSizeNotAvailable,
+				},
+				{
+					pkg: model.NewPackage("example.com/mockorg/mockproj3/cmd/mockproj3@latest"),
+					err: toolchain.ErrModuleNotFound,
+				},
+			},
+			expectedEstimate: model.DownloadEstimate{Modules: 3, Unknown: 2, Size: 1000},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			for _, call := range tc.mockCalls {
+				toolchain.EXPECT().GetPackageDownloadSize(context.Background(), call.pkg).
+					Return(call.module, call.size, call.err).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			estimate := binaryManager.EstimateDownloadSize(context.Background(), tc.packages...)
+			assert.Equal(t, tc.expectedEstimate, estimate)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetAllBinaryInfos(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+
+	cases := map[string]struct {
+		managed                   bool
+		mockListBinariesCalls     []mockListBinariesCall
+		mockGetBuildInfoCalls     []mockGetBuildInfoCall
+		mockGetSymlinkTargetCalls []mockGetSymlinkTargetCall
+		expectedInfos             []model.BinaryInfo
+		expectedErr               error
+	}{
+		"success-go-bin-path-binaries": {
+			managed: false,
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path:     goBinPath,
+					binaries: []string{filepath.Join(goBinPath, "bin1"), filepath.Join(goBinPath, "bin2")},
+				},
+			},
+			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
+				{path: filepath.Join(goBinPath, "bin1"), info: getBuildInfo("bin1", "v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin2"), info: getBuildInfo("bin2", "v0.1.0")},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "bin1"), target: filepath.Join(intBinPath, "bin1@v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin2"), err: os.ErrNotExist},
+			},
+			expectedInfos: []model.BinaryInfo{
+				getBinaryInfo(workspace, "bin1", "v0.1.0", false, true, false),
+				getBinaryInfo(workspace, "bin2", "v0.1.0", false, false, false),
+			},
+		},
+		"success-internal-bin-path-binaries": {
+			managed: true,
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: intBinPath,
+					binaries: []string{
+						filepath.Join(intBinPath, "bin1@v0.1.0"),
+						filepath.Join(intBinPath, "bin2@v0.1.0"),
+					},
+				},
+				{
+					path: goBinPath,
+					binaries: []string{
+						filepath.Join(goBinPath, "bin1"),
+						filepath.Join(goBinPath, "bin2"),
+					},
+				},
+				{
+					path: goBinPath,
+					binaries: []string{
+						filepath.Join(goBinPath, "bin1"),
+						filepath.Join(goBinPath, "bin2"),
+					},
+				},
+			},
+			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
+				{
+					path: filepath.Join(intBinPath, "bin1@v0.1.0"),
+					info: getBuildInfo("bin1", "v0.1.0"),
+				},
+				{
+					path: filepath.Join(intBinPath, "bin2@v0.1.0"),
+					info: getBuildInfo("bin2", "v0.1.0"),
+				},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(intBinPath, "bin1@v0.1.0"), err: os.ErrNotExist},
+				{path: filepath.Join(intBinPath, "bin2@v0.1.0"), err: os.ErrNotExist},
+				{path: filepath.Join(goBinPath, "bin1"), err: os.ErrNotExist},
+				{path: filepath.Join(goBinPath, "bin2"), target: filepath.Join(intBinPath, "bin2@v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin1"), err: os.ErrNotExist},
+				{path: filepath.Join(goBinPath, "bin2"), target: filepath.Join(intBinPath, "bin2@v0.1.0")},
+			},
+			expectedInfos: []model.BinaryInfo{
+				getBinaryInfo(workspace, "bin1", "v0.1.0", true, true, false),
+				getBinaryInfo(workspace, "bin2", "v0.1.0", true, true, true),
+			},
+		},
+		"success-skip-get-binary-info-error": {
+			managed: false,
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path:     goBinPath,
+					binaries: []string{filepath.Join(goBinPath, "bin1"), filepath.Join(goBinPath, "bin2")},
+				},
+			},
+			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
+				{
+					path: filepath.Join(goBinPath, "bin1"),
+					info: getBuildInfo("bin1", "v0.1.0"),
+				},
+				{
+					path: filepath.Join(goBinPath, "bin2"),
+					err:  toolchain.ErrBinaryBuiltWithoutGoModules,
+				},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "bin1"), target: filepath.Join(intBinPath, "bin1@v0.1.0")},
+			},
+			expectedInfos: []model.BinaryInfo{
+				getBinaryInfo(workspace, "bin1", "v0.1.0", false, true, false),
+			},
+		},
+		"error-list-binaries": {
+			managed: false,
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: goBinPath,
+					err:  os.ErrNotExist,
+				},
+			},
+			expectedErr: os.ErrNotExist,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			for _, call := range tc.mockListBinariesCalls {
+				fs.EXPECT().ListBinaries(call.path).
+					Return(call.binaries, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockGetBuildInfoCalls {
+				toolchain.EXPECT().GetBuildInfo(call.path).
+					Return(call.info, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockGetSymlinkTargetCalls {
+				fs.EXPECT().GetSymlinkTarget(call.path).
+					Return(call.target, call.err).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			infos, infosErr := binaryManager.GetAllBinaryInfos(tc.managed)
+			assert.Equal(t, tc.expectedInfos, infos)
+			assert.Equal(t, tc.expectedErr, infosErr)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetBinaryBuild(t *testing.T) {
+	cases := map[string]struct {
+		mockGetBuildInfo    *buildinfo.BuildInfo
+		mockGetBuildInfoErr error
+		callGetFileSize     bool
+		mockGetFileSize     int64
+		mockGetFileSizeErr  error
+		expectedBuild       model.BinaryBuild
+		expectedErr         error
+	}{
+		"success": {
+			mockGetBuildInfo: &buildinfo.BuildInfo{
+				Main: debug.Module{
+					Path:    "example.com/mockorg/mockproj",
+					Version: "v0.1.0",
+				},
+				GoVersion: "go1.24.5",
+				Deps: []*debug.Module{
+					{Path: "example.com/mockorg/dep", Version: "v1.0.0"},
+					{
+						Path:    "example.com/mockorg/replaced",
+						Version: "v1.0.0",
+						Replace: &debug.Module{Path: "example.com/fork/replaced", Version: "v1.0.1"},
+					},
+					{
+						Path:    "example.com/mockorg/local",
+						Version: "v1.0.0",
+						Replace: &debug.Module{Path: "../local"},
+					},
+				},
+				Settings: []debug.BuildSetting{
+					{Key: "CGO_ENABLED", Value: "0"},
+					{Key: "-trimpath", Value: "true"},
+				},
+			},
+			callGetFileSize: true,
+			mockGetFileSize: 1500000,
+			expectedBuild: model.BinaryBuild{
+				Module:    model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+				GoVersion: "go1.24.5",
+				Settings: map[string]string{
+					"CGO_ENABLED": "0",
+					"-trimpath":   "true",
+				},
+				Deps: map[string]string{
+					"example.com/mockorg/dep":      "v1.0.0",
+					"example.com/mockorg/replaced": "v1.0.1",
+					"example.com/mockorg/local":    "../local",
+				},
+				Size: 1500000,
+			},
+		},
+		"error-get-build-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-get-file-size": {
+			mockGetBuildInfo:   &buildinfo.BuildInfo{},
+			callGetFileSize:    true,
+			mockGetFileSizeErr: errors.New("unexpected error"),
+			expectedErr:        errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo("/mock/bin/mockproj@v0.1.0").
+				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callGetFileSize {
+				fs.EXPECT().GetFileSize("/mock/bin/mockproj@v0.1.0").
+					Return(tc.mockGetFileSize, tc.mockGetFileSizeErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			build, err := binaryManager.GetBinaryBuild("/mock/bin/mockproj@v0.1.0")
+			assert.Equal(t, tc.expectedBuild, build)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetBinaryEnv(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+
+	cases := map[string]struct {
+		mockGetBuildInfoErr error
+		callReadFile        bool
+		mockReadFile        []byte
+		mockReadFileErr     error
+		expectedEnv         []string
+		expectedErr         error
+	}{
+		"success": {
+			callReadFile: true,
+			mockReadFile: []byte(`{"name":"mockproj","env":["GOMEMLIMIT=1GiB"]}`),
+			expectedEnv:  []string{"GOMEMLIMIT=1GiB"},
+		},
+		"success-no-receipt": {
+			callReadFile:    true,
+			mockReadFileErr: os.ErrNotExist,
+		},
+		"error-get-binary-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-read-receipt": {
+			callReadFile:    true,
+			mockReadFileErr: os.ErrPermission,
+			expectedErr:     os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			var buildInfo *buildinfo.BuildInfo
+			if tc.mockGetBuildInfoErr == nil {
+				buildInfo = getBuildInfo("mockproj", "v0.1.0")
+
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+					Return(filepath.Join(intBinPath, "mockproj@v0.1.0"), nil).
+					Once()
+			}
+
+			toolchain.EXPECT().GetBuildInfo(filepath.Join(goBinPath, "mockproj")).
+				Return(buildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(tc.mockReadFile, tc.mockReadFileErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			env, err := binaryManager.GetBinaryEnv(model.NewBinaryFromString("mockproj"))
+			assert.Equal(t, tc.expectedEnv, env)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetBinaryInfo(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := workspace.GetInternalReceiptPath()
+
+	cases := map[string]struct {
+		path                      string
+		pinMode                   model.PinMode
+		mockGetBuildInfo          *buildinfo.BuildInfo
+		mockGetBuildInfoErr       error
+		mockGetSymlinkTargetCalls []mockGetSymlinkTargetCall
+		mockReadFileCalls         []mockReadFileCall
+		callListBinaries          bool
+		mockListBinaries          []string
+		mockListBinariesErr       error
+		expectedInfo              model.BinaryInfo
+		expectedErr               error
+	}{
+		"success-base-info-unmanaged-binary": {
+			path: filepath.Join(goBinPath, "mockproj"),
+			mockGetBuildInfo: &buildinfo.BuildInfo{
+				Path: "example.com/mockorg/mockproj/cmd/mockproj",
+				Main: debug.Module{
+					Path:    "example.com/mockorg/mockproj",
+					Version: "v0.1.0",
+					Sum:     "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+				},
+				GoVersion: "go1.24.5",
+				Settings: []debug.BuildSetting{
+					{Key: "GOOS", Value: "darwin"},
+					{Key: "GOARCH", Value: "arm64"},
+					{Key: "GOARM64", Value: "v8.0"},
+					{Key: "CGO_ENABLED", Value: "1"},
+				},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "mockproj"), err: os.ErrInvalid},
+			},
+			expectedInfo: model.BinaryInfo{
+				Binary:      model.NewBinaryFromString("mockproj"),
+				FullPath:    filepath.Join(goBinPath, "mockproj"),
+				InstallPath: filepath.Join(goBinPath, "mockproj"),
+				PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
+				Module:      model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+				ModuleSum:   "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+				GoVersion:   "go1.24.5",
+				OS:          "darwin",
+				Arch:        "arm64",
+				Feature:     "v8.0",
+				EnvVars:     []string{"GOARM64=v8.0", "CGO_ENABLED=1"},
+				BuildFlags:  model.BuildFlags{CGOEnabled: "1", GOARM64: "v8.0"},
+				IsManaged:   false,
+			},
+		},
+		"success-base-info-managed-binary": {
+			path: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			mockGetBuildInfo: &buildinfo.BuildInfo{
+				Path: "example.com/mockorg/mockproj/cmd/mockproj",
+				Main: debug.Module{
+					Path:    "example.com/mockorg/mockproj",
+					Version: "v0.1.0",
+					Sum:     "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+				},
+				GoVersion: "go1.24.5",
+				Settings: []debug.BuildSetting{
+					{Key: "GOOS", Value: "darwin"},
+					{Key: "GOARCH", Value: "arm64"},
+					{Key: "GOARM64", Value: "v8.0"},
+					{Key: "CGO_ENABLED", Value: "1"},
+				},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(intBinPath, "mockproj@v0.1.0"), err: os.ErrInvalid},
+				{path: filepath.Join(goBinPath, "mockproj"), err: os.ErrNotExist},
+				{path: filepath.Join(goBinPath, "mockproj-v0"), target: filepath.Join(intBinPath, "mockproj@v0.1.0")},
+			},
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(goBinPath, "mockproj"),
+				filepath.Join(goBinPath, "mockproj-v0"),
+			},
+			expectedInfo: model.BinaryInfo{
+				Binary:      model.NewBinary("mockproj", model.NewVersion("v0.1.0"), ""),
+				FullPath:    filepath.Join(intBinPath, "mockproj@v0.1.0"),
+				InstallPath: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+				PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
+				Module:      model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+				ModuleSum:   "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+				GoVersion:   "go1.24.5",
+				OS:          "darwin",
+				Arch:        "arm64",
+				Feature:     "v8.0",
+				EnvVars:     []string{"GOARM64=v8.0", "CGO_ENABLED=1"},
+				BuildFlags:  model.BuildFlags{CGOEnabled: "1", GOARM64: "v8.0"},
+				IsManaged:   true,
+				IsPinned:    true,
+			},
+		},
+		"success-all-info": {
+			path: filepath.Join(goBinPath, "mockproj"),
+			mockGetBuildInfo: &buildinfo.BuildInfo{
+				Path: "example.com/mockorg/mockproj/cmd/mockproj",
+				Main: debug.Module{
+					Path:    "example.com/mockorg/mockproj",
+					Version: "v0.1.2-0.20250729191454-dac745d99aac",
+				},
+				GoVersion: "go1.24.5",
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.revision", Value: "dac745d99aacf872dd3232e7eceab0f9047051da"},
+					{Key: "vcs.time", Value: "2025-07-29T19:14:54Z"},
+					{Key: "GOOS", Value: "darwin"},
+					{Key: "GOARCH", Value: "arm64"},
+					{Key: "GOARM64", Value: "v8.0"},
+					{Key: "CGO_ENABLED", Value: "1"},
+				},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{
+					path:   filepath.Join(goBinPath, "mockproj"),
+					target: filepath.Join(intBinPath, "mockproj@v0.1.2-0.20250729191454-dac745d99aac"),
+				},
+			},
+			expectedInfo: model.BinaryInfo{
+				Binary:      model.NewBinaryFromString("mockproj"),
+				FullPath:    filepath.Join(goBinPath, "mockproj"),
+				InstallPath: filepath.Join(intBinPath, "mockproj@v0.1.2-0.20250729191454-dac745d99aac"),
+				PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
+				Module: model.NewModule(
+					"example.com/mockorg/mockproj",
+					model.NewVersion("v0.1.2-0.20250729191454-dac745d99aac"),
+				),
+				GoVersion:      "go1.24.5",
+				CommitRevision: "dac745d99aacf872dd3232e7eceab0f9047051da",
+				CommitTime:     "2025-07-29T19:14:54Z",
+				OS:             "darwin",
+				Arch:           "arm64",
+				Feature:        "v8.0",
+				EnvVars:        []string{"GOARM64=v8.0", "CGO_ENABLED=1"},
+				BuildFlags:     model.BuildFlags{CGOEnabled: "1", GOARM64: "v8.0"},
+				IsManaged:      true,
+				IsPinned:       false,
+				IsDevBuild:     true,
+			},
+		},
+		"error-get-build-info": {
+			path:                filepath.Join(goBinPath, "mockproj"),
+			mockGetBuildInfoErr: toolchain.ErrBinaryBuiltWithoutGoModules,
+			expectedErr:         toolchain.ErrBinaryBuiltWithoutGoModules,
+		},
+		"success-copy-mode-managed-binary": {
+			path:    filepath.Join(goBinPath, "mockproj"),
+			pinMode: model.PinModeCopy,
+			mockGetBuildInfo: &buildinfo.BuildInfo{
+				Path: "example.com/mockorg/mockproj/cmd/mockproj",
+				Main: debug.Module{
+					Path:    "example.com/mockorg/mockproj",
+					Version: "v0.1.0",
+					Sum:     "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+				},
+				GoVersion: "go1.24.5",
+			},
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj.json"),
+					data: []byte(`{"name":"mockproj","target":` +
+						strconv.Quote(filepath.Join(intBinPath, "mockproj@v0.1.0")) + `}`),
+				},
+			},
+			expectedInfo: model.BinaryInfo{
+				Binary:      model.NewBinaryFromString("mockproj"),
+				FullPath:    filepath.Join(goBinPath, "mockproj"),
+				InstallPath: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+				PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
+				Module:      model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+				ModuleSum:   "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+				GoVersion:   "go1.24.5",
+				IsManaged:   true,
+			},
+		},
+		"success-copy-mode-unmanaged-binary": {
+			path:    filepath.Join(goBinPath, "mockproj"),
+			pinMode: model.PinModeCopy,
+			mockGetBuildInfo: &buildinfo.BuildInfo{
+				Path: "example.com/mockorg/mockproj/cmd/mockproj",
+				Main: debug.Module{
+					Path:    "example.com/mockorg/mockproj",
+					Version: "v0.1.0",
+					Sum:     "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+				},
+				GoVersion: "go1.24.5",
+			},
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+			},
+			expectedInfo: model.BinaryInfo{
+				Binary:      model.NewBinaryFromString("mockproj"),
+				FullPath:    filepath.Join(goBinPath, "mockproj"),
+				InstallPath: filepath.Join(goBinPath, "mockproj"),
+				PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
+				Module:      model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+				ModuleSum:   "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+				GoVersion:   "go1.24.5",
+			},
+		},
+		"error-list-binaries": {
+			path: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			mockGetBuildInfo: &buildinfo.BuildInfo{
+				Path: "example.com/mockorg/mockproj/cmd/mockproj",
+				Main: debug.Module{
+					Path:    "example.com/mockorg/mockproj",
+					Version: "v0.1.0",
+					Sum:     "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+				},
+				GoVersion: "go1.24.5",
+				Settings: []debug.BuildSetting{
+					{Key: "GOOS", Value: "darwin"},
+					{Key: "GOARCH", Value: "arm64"},
+					{Key: "GOARM64", Value: "v8.0"},
+					{Key: "CGO_ENABLED", Value: "1"},
+				},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(intBinPath, "mockproj@v0.1.0"), err: os.ErrInvalid},
+			},
+			callListBinaries:    true,
+			mockListBinariesErr: os.ErrNotExist,
+			expectedErr:         os.ErrNotExist,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo(tc.path).
+				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).Once()
+
+			for _, call := range tc.mockGetSymlinkTargetCalls {
+				fs.EXPECT().GetSymlinkTarget(call.path).
+					Return(call.target, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockReadFileCalls {
+				fs.EXPECT().ReadFile(call.path).
+					Return(call.data, call.err).
+					Once()
+			}
+
+			if tc.callListBinaries {
+				fs.EXPECT().ListBinaries(goBinPath).
+					Return(tc.mockListBinaries, tc.mockListBinariesErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), tc.pinMode,
+				model.SigningConfig{},
+			)
+			info, infoErr := binaryManager.GetBinaryInfo(tc.path)
+			assert.Equal(t, tc.expectedInfo, info)
+			assert.Equal(t, tc.expectedErr, infoErr)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetBinaryOrigin(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj-v0.json")
+	installedAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	getInfo := func(installPath string, managed bool) model.BinaryInfo {
+		return model.BinaryInfo{
+			Binary:      model.NewBinaryFromString("mockproj-v0"),
+			FullPath:    filepath.Join(goBinPath, "mockproj-v0"),
+			InstallPath: installPath,
+			PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
+			Module:      model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.2.0")),
+			ModuleSum:   "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+			GoVersion:   "go1.24.5",
+			OS:          "darwin",
+			Arch:        "arm64",
+			Feature:     "v8.0",
+			EnvVars:     []string{"GOARM64=v8.0", "CGO_ENABLED=1"},
+			BuildFlags:  model.BuildFlags{CGOEnabled: "1", GOARM64: "v8.0"},
+			IsManaged:   managed,
+		}
+	}
+
+	cases := map[string]struct {
+		mockGetBuildInfoErr  error
+		mockGetSymlinkTarget string
+		mockGetModTimeErr    error
+		callReadFile         bool
+		mockReadFile         []byte
+		mockReadFileErr      error
+		expectedOrigin       model.BinaryOrigin
+		expectedErr          error
+	}{
+		"success-managed": {
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.2.0"),
+			callReadFile:         true,
+			mockReadFile: []byte(`{"name":"mockproj-v0","protected":true,` +
+				`"migrated_from":"/home/user/go/bin/mockproj","previous_version":"v0.1.0"}`),
+			expectedOrigin: model.BinaryOrigin{
+				BinaryInfo:      getInfo(filepath.Join(intBinPath, "mockproj@v0.2.0"), true),
+				Kind:            model.KindMajor,
+				PinMode:         model.PinModeSymlink,
+				InstalledAt:     installedAt,
+				PreviousVersion: model.NewVersion("v0.1.0"),
+				MigratedFrom:    "/home/user/go/bin/mockproj",
+				Protected:       true,
+			},
+		},
+		"success-managed-no-receipt": {
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.2.0"),
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrNotExist,
+			expectedOrigin: model.BinaryOrigin{
+				BinaryInfo:  getInfo(filepath.Join(intBinPath, "mockproj@v0.2.0"), true),
+				Kind:        model.KindMajor,
+				PinMode:     model.PinModeSymlink,
+				InstalledAt: installedAt,
+			},
+		},
+		"success-unmanaged": {
+			expectedOrigin: model.BinaryOrigin{
+				BinaryInfo:  getInfo(filepath.Join(goBinPath, "mockproj-v0"), false),
+				Kind:        model.KindMajor,
+				InstalledAt: installedAt,
+			},
+		},
+		"error-get-binary-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-get-mod-time": {
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.2.0"),
+			mockGetModTimeErr:    os.ErrPermission,
+			expectedErr:          os.ErrPermission,
+		},
+		"error-read-receipt": {
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.2.0"),
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrPermission,
+			expectedErr:          os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			var buildInfo *buildinfo.BuildInfo
+			if tc.mockGetBuildInfoErr == nil {
+				buildInfo = getBuildInfo("mockproj", "v0.2.0")
+
+				installPath := filepath.Join(goBinPath, "mockproj-v0")
+				if tc.mockGetSymlinkTarget != "" {
+					installPath = tc.mockGetSymlinkTarget
+
+					fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj-v0")).
+						Return(tc.mockGetSymlinkTarget, nil).
+						Once()
+				} else {
+					fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj-v0")).
+						Return("", os.ErrInvalid).
+						Once()
+				}
+
+				fs.EXPECT().GetModTime(installPath).
+					Return(installedAt, tc.mockGetModTimeErr).
+					Once()
+			}
+
+			toolchain.EXPECT().GetBuildInfo(filepath.Join(goBinPath, "mockproj-v0")).
+				Return(buildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(tc.mockReadFile, tc.mockReadFileErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			origin, err := binaryManager.GetBinaryOrigin(model.NewBinaryFromString("mockproj-v0"))
+			assert.Equal(t, tc.expectedOrigin, origin)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetBinaryRepository(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+
+	cases := map[string]struct {
+		binary                  model.Binary
+		mockGetBuildInfo        *buildinfo.BuildInfo
+		mockGetBuildInfoErr     error
+		callGetSymlinkTarget    bool
+		mockGetSymlinkTarget    string
+		mockGetSymlinkTargetErr error
+		callGetModuleOrigin     bool
+		mockGetModuleOrigin     *model.ModuleOrigin
+		mockGetModuleOriginErr  error
+		expectedRepository      string
+		expectedErr             error
+	}{
+		"success-module-origin": {
+			binary:               model.NewBinaryFromString("mockproj"),
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callGetModuleOrigin:  true,
+			mockGetModuleOrigin: &model.ModuleOrigin{
+				URL: "https://github.com/mockorg/mockproj",
+			},
+			expectedRepository: "https://github.com/mockorg/mockproj",
+		},
+		"success-module-origin-not-available": {
+			binary:                 model.NewBinaryFromString("mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget:   true,
+			mockGetSymlinkTarget:   filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callGetModuleOrigin:    true,
+			mockGetModuleOriginErr: toolchain.ErrModuleOriginNotAvailable,
+			expectedRepository:     "https://example.com/mockorg/mockproj",
+		},
+		"success-module-not-found": {
+			binary:                 model.NewBinaryFromString("mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget:   true,
+			mockGetSymlinkTarget:   filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callGetModuleOrigin:    true,
+			mockGetModuleOriginErr: toolchain.ErrModuleNotFound,
+			expectedRepository:     "https://example.com/mockorg/mockproj",
+		},
+		"error-get-binary-info": {
+			binary:              model.NewBinaryFromString("mockproj"),
+			mockGetBuildInfoErr: toolchain.ErrBinaryBuiltWithoutGoModules,
+			expectedErr:         toolchain.ErrBinaryBuiltWithoutGoModules,
+		},
+		"error-get-module-origin": {
+			binary:                 model.NewBinaryFromString("mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget:   true,
+			mockGetSymlinkTarget:   filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callGetModuleOrigin:    true,
+			mockGetModuleOriginErr: errors.New("unexpected error"),
+			expectedErr:            errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().
+				GetBuildInfo(filepath.Join(goBinPath, tc.binary.String())).
+				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callGetSymlinkTarget {
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, tc.binary.String())).
+					Return(tc.mockGetSymlinkTarget, tc.mockGetSymlinkTargetErr).
+					Once()
+			}
+
+			if tc.callGetModuleOrigin {
+				toolchain.EXPECT().GetModuleOrigin(
+					context.Background(),
+					model.NewModule(
+						tc.mockGetBuildInfo.Main.Path,
+						model.NewVersion(tc.mockGetBuildInfo.Main.Version),
+					),
+				).Return(tc.mockGetModuleOrigin, tc.mockGetModuleOriginErr).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			repository, repoErr := binaryManager.GetBinaryRepository(context.Background(), tc.binary)
+			assert.Equal(t, tc.expectedRepository, repository)
+			assert.Equal(t, tc.expectedErr, repoErr)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetBinarySBOM(t *testing.T) {
+	cases := map[string]struct {
+		mockGetBuildInfo    *buildinfo.BuildInfo
+		mockGetBuildInfoErr error
+		expectedSBOM        model.SBOM
+		expectedErr         error
+	}{
+		"success": {
+			mockGetBuildInfo: &buildinfo.BuildInfo{
+				Main: debug.Module{
+					Path:    "example.com/mockorg/mockproj",
+					Version: "v0.1.0",
+					Sum:     "h1:mocksum=",
+				},
+				GoVersion: "go1.24.5",
+				Deps: []*debug.Module{
+					{Path: "example.com/mockorg/replaced", Version: "v1.0.0", Sum: "h1:replaced="},
+					{Path: "example.com/mockorg/dep", Version: "v1.0.0", Sum: "h1:dep="},
+					{
+						Path:    "example.com/mockorg/forked",
+						Version: "v1.0.0",
+						Replace: &debug.Module{Path: "example.com/fork/forked", Version: "v1.0.1", Sum: "h1:fork="},
+					},
+					{
+						Path:    "example.com/mockorg/local",
+						Version: "v1.0.0",
+						Replace: &debug.Module{Path: "../local"},
+					},
+				},
+			},
+			expectedSBOM: model.SBOM{
+				Name:      "mockproj@v0.1.0",
+				GoVersion: "go1.24.5",
+				Main: model.SBOMComponent{
+					Path: "example.com/mockorg/mockproj", Version: "v0.1.0", Sum: "h1:mocksum=",
+				},
+				Components: []model.SBOMComponent{
+					{Path: "example.com/fork/forked", Version: "v1.0.1", Sum: "h1:fork="},
+					{Path: "example.com/mockorg/dep", Version: "v1.0.0", Sum: "h1:dep="},
+					{Path: "example.com/mockorg/local"},
+					{Path: "example.com/mockorg/replaced", Version: "v1.0.0", Sum: "h1:replaced="},
+				},
+			},
+		},
+		"error-get-build-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo("/mock/bin/mockproj@v0.1.0").
+				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			sbom, err := binaryManager.GetBinarySBOM("/mock/bin/mockproj@v0.1.0")
+			assert.Equal(t, tc.expectedSBOM, sbom)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetBinarySizeHistory(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+
+	cases := map[string]struct {
+		mockGetBuildInfoErr error
+		callReadFile        bool
+		mockReadFile        []byte
+		mockReadFileErr     error
+		expectedHistory     model.SizeHistory
+		expectedErr         error
+	}{
+		"success": {
+			callReadFile: true,
+			mockReadFile: []byte(`{"name":"mockproj","size_history":[` +
+				`{"version":"v0.1.0","size":1000},{"version":"v0.2.0","size":1200}]}`),
+			expectedHistory: model.SizeHistory{
+				{Version: model.NewVersion("v0.1.0"), Size: 1000},
+				{Version: model.NewVersion("v0.2.0"), Size: 1200},
+			},
+		},
+		"success-no-receipt": {
+			callReadFile:    true,
+			mockReadFileErr: os.ErrNotExist,
+		},
+		"error-get-binary-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-read-receipt": {
+			callReadFile:    true,
+			mockReadFileErr: os.ErrPermission,
+			expectedErr:     os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			var buildInfo *buildinfo.BuildInfo
+			if tc.mockGetBuildInfoErr == nil {
+				buildInfo = getBuildInfo("mockproj", "v0.2.0")
+
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+					Return(filepath.Join(intBinPath, "mockproj@v0.2.0"), nil).
+					Once()
+			}
+
+			toolchain.EXPECT().GetBuildInfo(filepath.Join(goBinPath, "mockproj")).
+				Return(buildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(tc.mockReadFile, tc.mockReadFileErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			history, err := binaryManager.GetBinarySizeHistory(model.NewBinaryFromString("mockproj"))
+			assert.Equal(t, tc.expectedHistory, history)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetBinaryUpgradeInfo(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		info                            model.BinaryInfo
+		checkMajor                      bool
+		mockGetLatestModuleVersionCalls []mockGetLatestModuleVersionCall
+		expectedInfo                    model.BinaryUpgradeInfo
+		expectedErr                     error
+	}{
+		"success-check-minor-no-upgrade-available": {
+			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+			checkMajor: false,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+				IsUpgradeAvailable: false,
+			},
+		},
+		"success-check-major-no-upgrade-available": {
+			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+				},
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj/v2"),
+					err:    toolchain.ErrModuleNotFound,
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+				IsUpgradeAvailable: false,
+			},
+		},
+		"success-check-major-no-upgrade-available-v2": {
+			info:       getBinaryInfo(workspace, "mockproj", "v2.0.0", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj/v2"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
+				},
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj/v3"),
+					err:    toolchain.ErrModuleNotFound,
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v2.0.0", false, true, false),
+				LatestModule:       model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
+				IsUpgradeAvailable: false,
+			},
+		},
+		"success-check-minor-upgrade-available": {
+			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+			checkMajor: false,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				IsUpgradeAvailable: true,
+			},
+		},
+		"success-check-major-upgrade-available": {
+			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				},
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj/v2"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
+				},
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj/v3"),
+					err:    toolchain.ErrModuleNotFound,
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+				LatestModule:       model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
+				IsUpgradeAvailable: true,
+			},
+		},
+		"success-check-major-multiple-major-upgrades-available": {
+			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				},
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj/v2"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
+				},
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj/v3"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj/v3", model.NewVersion("v3.0.0")),
+				},
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj/v4"),
+					err:    toolchain.ErrModuleNotFound,
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+				LatestModule:       model.NewModule("example.com/mockorg/mockproj/v3", model.NewVersion("v3.0.0")),
+				IsUpgradeAvailable: true,
+			},
+		},
+		"success-check-major-pinned-version-upgrade-available": {
+			info:       getBinaryInfo(workspace, "mockproj-v1", "v1.1.0", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1")),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo:         getBinaryInfo(workspace, "mockproj-v1", "v1.1.0", false, true, false),
+				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
+				IsUpgradeAvailable: true,
+			},
+		},
+		"success-check-minor-pinned-version-upgrade-available": {
+			info:       getBinaryInfo(workspace, "mockproj-v1.1", "v1.1.0", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1")),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo:         getBinaryInfo(workspace, "mockproj-v1.1", "v1.1.0", false, true, false),
+				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
+				IsUpgradeAvailable: true,
+			},
+		},
+		"success-check-major-pinned-incompatible-version-upgrade-available": {
+			info:       getBinaryInfo(workspace, "mockproj-v3", "v3.1.0+incompatible", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module: model.Module{Path: "example.com/mockorg/mockproj", Version: model.NewVersion("v3")},
+					latestModule: model.NewModule(
+						"example.com/mockorg/mockproj", model.NewVersion("v3.2.0+incompatible"),
+					),
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo: getBinaryInfo(workspace, "mockproj-v3", "v3.1.0+incompatible", false, true, false),
+				LatestModule: model.NewModule(
+					"example.com/mockorg/mockproj", model.NewVersion("v3.2.0+incompatible"),
+				),
+				IsUpgradeAvailable: true,
+			},
+		},
+		"success-check-major-incompatible-version-upgrade-available": {
+			info:       getBinaryInfo(workspace, "mockproj", "v3.1.0+incompatible", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule(
+						"example.com/mockorg/mockproj", model.NewVersion("v3.2.0+incompatible"),
+					),
+				},
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj/v4"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj/v4", model.NewVersion("v4.0.0")),
+				},
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj/v5"),
+					err:    toolchain.ErrModuleNotFound,
+				},
+			},
+			expectedInfo: model.BinaryUpgradeInfo{
+				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v3.1.0+incompatible", false, true, false),
+				LatestModule:       model.NewModule("example.com/mockorg/mockproj/v4", model.NewVersion("v4.0.0")),
+				IsUpgradeAvailable: true,
+			},
+		},
+		"error-get-latest-module-minor-version": {
+			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj"),
+					err:    toolchain.ErrModuleInfoNotAvailable,
+				},
+			},
+			expectedErr: toolchain.ErrModuleInfoNotAvailable,
+		},
+		"error-get-latest-module-major-version": {
+			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
+			checkMajor: true,
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				},
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj/v2"),
+					err:    toolchain.ErrModuleInfoNotAvailable,
+				},
+			},
+			expectedErr: toolchain.ErrModuleInfoNotAvailable,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			for _, call := range tc.mockGetLatestModuleVersionCalls {
+				toolchain.EXPECT().GetLatestModuleVersion(context.Background(), call.module).
+					Return(call.latestModule, call.err).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			info, upgradeErr := binaryManager.GetBinaryUpgradeInfo(
+				context.Background(), tc.info, tc.checkMajor,
+			)
+			assert.Equal(t, tc.expectedInfo, info)
+			assert.Equal(t, tc.expectedErr, upgradeErr)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetBrokenPins(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+
+	cases := map[string]struct {
+		pinMode              model.PinMode
+		mockListSymlinks     []string
+		mockListSymlinksErr  error
+		callGetSymlinkTarget bool
+		expectedPins         []string
+		expectedErr          error
+	}{
+		"success": {
+			pinMode: model.PinModeSymlink,
+			mockListSymlinks: []string{
+				filepath.Join(goBinPath, "mockproj1"),
+				filepath.Join(goBinPath, "mockproj2-v1"),
+				filepath.Join(goBinPath, "mockproj3"),
+			},
+			callGetSymlinkTarget: true,
+			expectedPins:         []string{filepath.Join(goBinPath, "mockproj2-v1")},
+		},
+		"success-pin-mode-copy": {
+			pinMode: model.PinModeCopy,
+		},
+		"error-list-symlinks": {
+			pinMode:             model.PinModeSymlink,
+			mockListSymlinksErr: os.ErrPermission,
+			expectedErr:         os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+
+			if tc.pinMode == model.PinModeSymlink {
+				fs.EXPECT().ListSymlinks(goBinPath).
+					Return(tc.mockListSymlinks, tc.mockListSymlinksErr).
+					Once()
+			}
+
+			if tc.callGetSymlinkTarget {
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj1")).
+					Return(filepath.Join(intBinPath, "mockproj1@v0.1.0"), nil).
+					Once()
+				fs.EXPECT().Exists(filepath.Join(intBinPath, "mockproj1@v0.1.0")).Return(true).Once()
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj2-v1")).
+					Return(filepath.Join(intBinPath, "mockproj2@v1.2.0"), nil).
+					Once()
+				fs.EXPECT().Exists(filepath.Join(intBinPath, "mockproj2@v1.2.0")).Return(false).Once()
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj3")).
+					Return("/usr/local/bin/mockproj3", nil).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, nil, workspace, model.NewDefaultPinFormat(), tc.pinMode,
+				model.SigningConfig{},
+			)
+			pins, pinsErr := binaryManager.GetBrokenPins()
+			assert.Equal(t, tc.expectedPins, pins)
+			assert.Equal(t, tc.expectedErr, pinsErr)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetCacheArtifacts(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := workspace.GetInternalReceiptPath()
+
+	devBuildInfo := getBuildInfo("bin4", "v0.0.0-20250101000000-abcdef123456")
+	devBuildInfo.Main.Sum = ""
+
+	receipt, err := json.Marshal(model.Receipt{Name: "bin1", BuildFlags: model.BuildFlags{Strip: true}})
+	require.NoError(t, err)
+
+	compressedReceipt, err := json.Marshal(model.Receipt{
+		Name:        "bin3",
+		Compression: model.Compression{OriginalSize: 2048, CompressedSize: 1024},
+	})
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		mockListBinaries          []string
+		mockListBinariesErr       error
+		mockGetBuildInfoCalls     []mockGetBuildInfoCall
+		mockGetSymlinkTargetCalls []mockGetSymlinkTargetCall
+		mockReadFileCalls         []mockReadFileCall
+		expectedArtifacts         []model.CacheArtifact
+		expectedErr               error
+	}{
+		"success": {
+			mockListBinaries: []string{
+				filepath.Join(goBinPath, "bin1"),
+				filepath.Join(goBinPath, "bin1-v0"),
+				filepath.Join(goBinPath, "bin2"),
+				filepath.Join(goBinPath, "bin3"),
+				filepath.Join(goBinPath, "bin4"),
+				filepath.Join(goBinPath, "bin5"),
+			},
+			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
+				{path: filepath.Join(goBinPath, "bin1"), info: getBuildInfo("bin1", "v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin1-v0"), info: getBuildInfo("bin1", "v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin2"), info: getBuildInfo("bin2", "v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin3"), info: getBuildInfo("bin3", "v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin4"), info: devBuildInfo},
+				{path: filepath.Join(goBinPath, "bin5"), info: getBuildInfo("bin5", "v0.1.0")},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "bin1"), target: filepath.Join(intBinPath, "bin1@v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin1-v0"), target: filepath.Join(intBinPath, "bin1@v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin2"), err: os.ErrNotExist},
+				{path: filepath.Join(goBinPath, "bin3"), target: filepath.Join(intBinPath, "bin3@v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin4"), target: filepath.Join(intBinPath, "bin4@v0.0.0")},
+				{path: filepath.Join(goBinPath, "bin5"), target: filepath.Join(intBinPath, "bin5@v0.1.0")},
+			},
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "bin1.json"), data: receipt},
+				{path: filepath.Join(receiptPath, "bin3.json"), data: compressedReceipt},
+				{path: filepath.Join(receiptPath, "bin5.json"), err: errors.New("unexpected error")},
+			},
+			expectedArtifacts: []model.CacheArtifact{
+				{
+					Name:       "bin1@v0.1.0",
+					Package:    "example.com/mockorg/mockproj/cmd/bin1",
+					Module:     "example.com/mockorg/mockproj",
+					Version:    "v0.1.0",
+					ModuleSum:  "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+					GoVersion:  "go1.24.5",
+					Platform:   "darwin/arm64",
+					BuildFlags: model.BuildFlags{Strip: true},
+					Path:       filepath.Join(intBinPath, "bin1@v0.1.0"),
+				},
+			},
+		},
+		"error-list-binaries": {
+			mockListBinariesErr: os.ErrNotExist,
+			expectedErr:         os.ErrNotExist,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			fs.EXPECT().ListBinaries(goBinPath).
+				Return(tc.mockListBinaries, tc.mockListBinariesErr).
+				Once()
+
+			for _, call := range tc.mockGetBuildInfoCalls {
+				toolchain.EXPECT().GetBuildInfo(call.path).
+					Return(call.info, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockGetSymlinkTargetCalls {
+				fs.EXPECT().GetSymlinkTarget(call.path).
+					Return(call.target, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockReadFileCalls {
+				fs.EXPECT().ReadFile(call.path).
+					Return(call.data, call.err).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			artifacts, err := binaryManager.GetCacheArtifacts()
+			assert.Equal(t, tc.expectedArtifacts, artifacts)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetCrossBinaryInfos(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	platformDir := filepath.Join(intBinPath, "linux_arm64")
+	binPath := filepath.Join(platformDir, "bin1@v0.1.0")
+
+	crossInfo := getBinaryInfo(workspace, "bin1", "v0.1.0", true, true, false)
+	crossInfo.FullPath = binPath
+	crossInfo.InstallPath = binPath
+	crossInfo.IsCrossBuild = true
+
+	cases := map[string]struct {
+		mockExists          bool
+		callListDirs        bool
+		mockListDirs        []string
+		mockListDirsErr     error
+		callListBinaries    bool
+		mockListBinariesErr error
+		mockGetBuildInfoErr error
+		callListGoBinaries  bool
+		expectedInfos       []model.BinaryInfo
+		expectedErr         error
+	}{
+		"success": {
+			mockExists:         true,
+			callListDirs:       true,
+			mockListDirs:       []string{platformDir, filepath.Join(intBinPath, "cache")},
+			callListBinaries:   true,
+			callListGoBinaries: true,
+			expectedInfos:      []model.BinaryInfo{crossInfo},
+		},
+		"success-no-internal-bin-path": {},
+		"success-skip-get-binary-info-error": {
+			mockExists:          true,
+			callListDirs:        true,
+			mockListDirs:        []string{platformDir},
+			callListBinaries:    true,
+			mockGetBuildInfoErr: toolchain.ErrBinaryBuiltWithoutGoModules,
+		},
+		"error-list-dirs": {
+			mockExists:      true,
+			callListDirs:    true,
+			mockListDirsErr: os.ErrPermission,
+			expectedErr:     os.ErrPermission,
+		},
+		"error-list-binaries": {
+			mockExists:          true,
+			callListDirs:        true,
+			mockListDirs:        []string{platformDir},
+			callListBinaries:    true,
+			mockListBinariesErr: os.ErrPermission,
+			expectedErr:         os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchainMock := toolchainmocks.NewToolchain(t)
+
+			fs.EXPECT().Exists(intBinPath).Return(tc.mockExists).Once()
+
+			if tc.callListDirs {
+				fs.EXPECT().ListDirs(intBinPath).Return(tc.mockListDirs, tc.mockListDirsErr).Once()
+			}
+
+			if tc.callListBinaries {
+				fs.EXPECT().ListBinaries(platformDir).
+					Return([]string{binPath}, tc.mockListBinariesErr).
+					Once()
+			}
+
+			if tc.callListBinaries && tc.mockListBinariesErr == nil {
+				toolchainMock.EXPECT().GetBuildInfo(binPath).
+					Return(getBuildInfo("bin1", "v0.1.0"), tc.mockGetBuildInfoErr).
+					Once()
+			}
+
+			if tc.callListGoBinaries {
+				fs.EXPECT().GetSymlinkTarget(binPath).Return("", os.ErrNotExist).Once()
+				fs.EXPECT().ListBinaries(goBinPath).Return([]string{}, nil).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchainMock, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			infos, infosErr := binaryManager.GetCrossBinaryInfos()
+			assert.Equal(t, tc.expectedInfos, infos)
+			assert.Equal(t, tc.expectedErr, infosErr)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetCrossOS(t *testing.T) {
+	cases := map[string]struct {
+		goBinPath     string
+		mockRuntimeOS string
+		callIsWSL     bool
+		mockIsWSL     bool
+		expectedOS    string
+	}{
+		"wsl-windows-drive": {
+			goBinPath:     "/mnt/c/Users/user/go/bin",
+			mockRuntimeOS: "linux",
+			callIsWSL:     true,
+			mockIsWSL:     true,
+			expectedOS:    "windows",
+		},
+		"wsl-linux-path": {
+			goBinPath:     "/home/user/go/bin",
+			mockRuntimeOS: "linux",
+		},
+		"linux-mnt-path": {
+			goBinPath:     "/mnt/c/go/bin",
+			mockRuntimeOS: "linux",
+			callIsWSL:     true,
+		},
+		"windows-wsl-share": {
+			goBinPath:     `\\wsl$\Ubuntu\home\user\go\bin`,
+			mockRuntimeOS: "windows",
+			expectedOS:    "linux",
+		},
+		"windows-wsl-localhost-share": {
+			goBinPath:     `\\WSL.localhost\Ubuntu\home\user\go\bin`,
+			mockRuntimeOS: "windows",
+			expectedOS:    "linux",
+		},
+		"windows-drive": {
+			goBinPath:     `C:\Users\user\go\bin`,
+			mockRuntimeOS: "windows",
+		},
+		"darwin": {
+			goBinPath:     "/mnt/c/go/bin",
+			mockRuntimeOS: "darwin",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("GOBIN", tc.goBinPath)
+
+			workspace, err := system.NewWorkspace(
+				system.NewEnvironment(),
+				system.NewFileSystem(),
+				system.NewRuntime(),
+			)
+			require.NoError(t, err)
+
+			rt := systemmocks.NewRuntime(t)
+			rt.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+
+			if tc.callIsWSL {
+				rt.EXPECT().IsWSL().Return(tc.mockIsWSL).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, rt, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			assert.Equal(t, tc.expectedOS, binaryManager.GetCrossOS())
+		})
+	}
+}
+
+func TestGoBinaryManager_GetDebugInfoPath(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	binPath := filepath.Join(workspace.GetGoBinPath(), "mockproj")
+	debugPath := filepath.Join(workspace.GetInternalDataPath(), "mockproj", "debug", "mockaction.mockcontent.debug")
+
+	cases := map[string]struct {
+		mockGetBuildID    string
+		mockGetBuildIDErr error
+		callExists        bool
+		mockExists        bool
+		expectedPath      string
+		expectedErr       error
+	}{
+		"success": {
+			mockGetBuildID: "mockaction/mockcontent",
+			callExists:     true,
+			mockExists:     true,
+			expectedPath:   debugPath,
+		},
+		"error-debug-info-not-found": {
+			mockGetBuildID: "mockaction/mockcontent",
+			callExists:     true,
+			expectedErr:    manager.ErrDebugInfoNotFound,
+		},
+		"error-binary-not-found": {
+			mockGetBuildIDErr: toolchain.ErrBinaryNotFound,
+			expectedErr:       toolchain.ErrBinaryNotFound,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildID(context.Background(), binPath).
+				Return(tc.mockGetBuildID, tc.mockGetBuildIDErr).
+				Once()
+
+			if tc.callExists {
+				fs.EXPECT().Exists(debugPath).
+					Return(tc.mockExists).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			path, err := binaryManager.GetDebugInfoPath(context.Background(), binPath)
+			assert.Equal(t, tc.expectedPath, path)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetExpiredBinaries(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	binPaths := []string{
+		filepath.Join(intBinPath, "bin1@v0.1.0"),
+		filepath.Join(intBinPath, "bin2@v1.0.0"),
+		filepath.Join(intBinPath, "bin1@v0.3.0"),
+		filepath.Join(intBinPath, "bin1@v0.2.0"),
+	}
+	pinPath := filepath.Join(goBinPath, "bin1")
+	modTimes := map[string]time.Time{
+		binPaths[0]: time.Now().AddDate(0, 0, -30),
+		binPaths[1]: time.Now().AddDate(0, 0, -30),
+		binPaths[3]: time.Now().AddDate(0, 0, -1),
+	}
+
+	cases := map[string]struct {
+		policy              model.RetentionConfig
+		mockExists          bool
+		callListBinaries    bool
+		mockListBinariesErr error
+		callListPins        bool
+		mockListPinsErr     error
+		callGetModTime      bool
+		mockGetModTimeErr   error
+		expectedBins        []model.Binary
+		expectedErr         error
+	}{
+		"success-keep-last": {
+			policy:           model.RetentionConfig{KeepLast: 1},
+			mockExists:       true,
+			callListBinaries: true,
+			callListPins:     true,
+			callGetModTime:   true,
+			expectedBins: []model.Binary{
+				model.NewBinaryFromString("bin1@v0.2.0"),
+				model.NewBinaryFromString("bin1@v0.1.0"),
+			},
+		},
+		"success-keep-days": {
+			policy:           model.RetentionConfig{KeepDays: 7},
+			mockExists:       true,
+			callListBinaries: true,
+			callListPins:     true,
+			callGetModTime:   true,
+			expectedBins: []model.Binary{
+				model.NewBinaryFromString("bin1@v0.1.0"),
+				model.NewBinaryFromString("bin2@v1.0.0"),
+			},
+		},
+		"success-policy-not-set": {},
+		"success-no-internal-bin-path": {
+			policy: model.RetentionConfig{KeepLast: 1},
+		},
+		"error-list-binaries": {
+			policy:              model.RetentionConfig{KeepLast: 1},
+			mockExists:          true,
+			callListBinaries:    true,
+			mockListBinariesErr: os.ErrPermission,
+			expectedErr:         os.ErrPermission,
+		},
+		"error-list-pins": {
+			policy:           model.RetentionConfig{KeepLast: 1},
+			mockExists:       true,
+			callListBinaries: true,
+			callListPins:     true,
+			mockListPinsErr:  os.ErrPermission,
+			expectedErr:      os.ErrPermission,
+		},
+		"error-get-mod-time": {
+			policy:            model.RetentionConfig{KeepLast: 1},
+			mockExists:        true,
+			callListBinaries:  true,
+			callListPins:      true,
+			callGetModTime:    true,
+			mockGetModTimeErr: os.ErrPermission,
+			expectedErr:       os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+
+			if tc.policy.IsSet() {
+				fs.EXPECT().Exists(intBinPath).Return(tc.mockExists).Once()
+			}
+
+			if tc.callListBinaries {
+				fs.EXPECT().ListBinaries(intBinPath).Return(binPaths, tc.mockListBinariesErr).Once()
+			}
+
+			if tc.callListPins {
+				fs.EXPECT().ListBinaries(goBinPath).Return([]string{pinPath}, tc.mockListPinsErr).Once()
+			}
+
+			if tc.callListPins && tc.mockListPinsErr == nil {
+				fs.EXPECT().GetSymlinkTarget(pinPath).Return(binPaths[2], nil).Once()
+			}
+
+			if tc.callGetModTime && tc.mockGetModTimeErr != nil {
+				fs.EXPECT().GetModTime(binPaths[3]).Return(time.Time{}, tc.mockGetModTimeErr).Once()
+			} else if tc.callGetModTime {
+				for path, modTime := range modTimes {
+					fs.EXPECT().GetModTime(path).Return(modTime, nil).Once()
+				}
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			bins, binsErr := binaryManager.GetExpiredBinaries(tc.policy)
+			assert.Equal(t, tc.expectedBins, bins)
+			assert.Equal(t, tc.expectedErr, binsErr)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetGoReleases(t *testing.T) {
+	cases := map[string]struct {
+		mockGetGoReleases    model.GoReleases
+		mockGetGoReleasesErr error
+		expectedReleases     model.GoReleases
+		expectedErr          error
+	}{
+		"success": {
+			mockGetGoReleases: model.GoReleases{{Version: "go1.24.9", Stable: true}},
+			expectedReleases:  model.GoReleases{{Version: "go1.24.9", Stable: true}},
+		},
+		"error-get-go-releases": {
+			mockGetGoReleasesErr: errors.New("unexpected error"),
+			expectedErr:          errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetGoReleases(context.Background(), "https://go.dev/dl/?mode=json").
+				Return(tc.mockGetGoReleases, tc.mockGetGoReleasesErr).
+				Once()
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			releases, err := binaryManager.GetGoReleases(context.Background())
+			assert.Equal(t, tc.expectedReleases, releases)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetLinkSource(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+
+	cases := map[string]struct {
+		mockGetBuildInfoErr error
+		callReadFile        bool
+		mockReadFile        []byte
+		mockReadFileErr     error
+		expectedSource      string
+		expectedErr         error
+	}{
+		"success": {
+			callReadFile:   true,
+			mockReadFile:   []byte(`{"name":"mockproj","source":"/home/user/src/mockproj"}`),
+			expectedSource: "/home/user/src/mockproj",
+		},
+		"error-get-binary-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-read-receipt": {
+			callReadFile:    true,
+			mockReadFileErr: os.ErrPermission,
+			expectedErr:     os.ErrPermission,
+		},
+		"error-binary-not-linked": {
+			callReadFile: true,
+			mockReadFile: []byte(`{"name":"mockproj"}`),
+			expectedErr:  manager.ErrBinaryNotLinked,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo(filepath.Join(goBinPath, "mockproj")).
+				Return(getBuildInfo("mockproj", "(devel)"), tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callReadFile {
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+					Return(filepath.Join(intBinPath, "mockproj@(devel)"), nil).
+					Once()
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(tc.mockReadFile, tc.mockReadFileErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			source, err := binaryManager.GetLinkSource(model.NewBinaryFromString("mockproj"))
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedSource, source)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetLockedBinaries(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+
+	devBuildInfo := getBuildInfo("bin4", "v0.0.0-20250101000000-abcdef123456")
+	devBuildInfo.Main.Sum = ""
+
+	cases := map[string]struct {
+		mockListBinaries          []string
+		mockListBinariesErr       error
+		mockGetBuildInfoCalls     []mockGetBuildInfoCall
+		mockGetSymlinkTargetCalls []mockGetSymlinkTargetCall
+		mockRuntimeOS             string
+		expectedBins              []model.LockedBinary
+		expectedErr               error
+	}{
+		"success": {
+			mockListBinaries: []string{
+				filepath.Join(goBinPath, "bin1"),
+				filepath.Join(goBinPath, "bin1-v0"),
+				filepath.Join(goBinPath, "bin1-v0.1"),
+				filepath.Join(goBinPath, "bin2"),
+				filepath.Join(goBinPath, "bin4"),
+			},
+			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
+				{path: filepath.Join(goBinPath, "bin1"), info: getBuildInfo("bin1", "v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin1-v0"), info: getBuildInfo("bin1", "v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin1-v0.1"), info: getBuildInfo("bin1", "v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin2"), info: getBuildInfo("bin2", "v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin4"), info: devBuildInfo},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "bin1"), target: filepath.Join(intBinPath, "bin1@v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin1-v0"), target: filepath.Join(intBinPath, "bin1@v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin1-v0.1"), target: filepath.Join(intBinPath, "bin1@v0.1.0")},
+				{path: filepath.Join(goBinPath, "bin2"), err: os.ErrNotExist},
+				{path: filepath.Join(goBinPath, "bin4"), target: filepath.Join(intBinPath, "bin4@v0.0.0")},
+			},
+			mockRuntimeOS: "windows",
+			expectedBins: []model.LockedBinary{
+				{
+					Name:      "bin1",
+					Package:   "example.com/mockorg/mockproj/cmd/bin1",
+					Version:   "v0.1.0",
+					Kind:      model.KindLatest,
+					Extension: ".exe",
+				},
+				{
+					Name:      "bin1-v0",
+					Package:   "example.com/mockorg/mockproj/cmd/bin1",
+					Version:   "v0.1.0",
+					Kind:      model.KindMajor,
+					Extension: ".exe",
+				},
+				{
+					Name:      "bin1-v0.1",
+					Package:   "example.com/mockorg/mockproj/cmd/bin1",
+					Version:   "v0.1.0",
+					Kind:      model.KindMinor,
+					Extension: ".exe",
+				},
+			},
+		},
+		"error-list-binaries": {
+			mockListBinariesErr: os.ErrNotExist,
+			expectedErr:         os.ErrNotExist,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			rt := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			fs.EXPECT().ListBinaries(goBinPath).
+				Return(tc.mockListBinaries, tc.mockListBinariesErr).
+				Once()
+
+			for _, call := range tc.mockGetBuildInfoCalls {
+				toolchain.EXPECT().GetBuildInfo(call.path).
+					Return(call.info, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockGetSymlinkTargetCalls {
+				fs.EXPECT().GetSymlinkTarget(call.path).
+					Return(call.target, call.err).
+					Once()
+			}
+
+			if tc.mockRuntimeOS != "" {
+				rt.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, rt, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			bins, err := binaryManager.GetLockedBinaries()
+			assert.Equal(t, tc.expectedBins, bins)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetModuleBuild(t *testing.T) {
+	module := model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.2.0"))
+
+	cases := map[string]struct {
+		mockGetModuleFile    string
+		mockGetModuleFileErr error
+		expectedBuild        model.BinaryBuild
+		expectedErr          error
+	}{
+		"success-local-toolchain": {
+			mockGetModuleFile: "module example.com/mockorg/mockproj\n\ngo 1.23\n\n" +
+				"require example.com/mockorg/dep v1.1.0\n",
+			expectedBuild: model.BinaryBuild{
+				Module:    module,
+				GoVersion: "go1.24.5",
+				Deps:      map[string]string{"example.com/mockorg/dep": "v1.1.0"},
+			},
+		},
+		"success-go-directive": {
+			mockGetModuleFile: "module example.com/mockorg/mockproj\n\ngo 1.25.1\n",
+			expectedBuild: model.BinaryBuild{
+				Module:    module,
+				GoVersion: "go1.25.1",
+				Deps:      map[string]string{},
+			},
+		},
+		"success-toolchain-directive": {
+			mockGetModuleFile: "module example.com/mockorg/mockproj\n\ngo 1.24\n\ntoolchain go1.24.7\n",
+			expectedBuild: model.BinaryBuild{
+				Module:    module,
+				GoVersion: "go1.24.7",
+				Deps:      map[string]string{},
+			},
+		},
+		"error-get-module-file": {
+			mockGetModuleFileErr: toolchain.ErrModuleNotFound,
+			expectedErr:          toolchain.ErrModuleNotFound,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			runtime := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			var modFile *modfile.File
+			if tc.mockGetModuleFile != "" {
+				var err error
+				modFile, err = modfile.Parse("go.mod", []byte(tc.mockGetModuleFile), nil)
+				require.NoError(t, err)
+
+				runtime.EXPECT().Version().Return("go1.24.5").Once()
+			}
+
+			toolchain.EXPECT().GetModuleFile(context.Background(), module).
+				Return(modFile, tc.mockGetModuleFileErr).
+				Once()
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, runtime, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			build, err := binaryManager.GetModuleBuild(context.Background(), module)
+			assert.Equal(t, tc.expectedBuild, build)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetPinVersions(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+
+	intBins := []string{
+		filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+		filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+		filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+		filepath.Join(intBinPath, "mockproj2@v1.10.0"),
+		filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+	}
+
+	cases := map[string]struct {
+		bin                     model.Binary
+		mockExists              bool
+		callGetSymlinkTarget    bool
+		mockGetSymlinkTarget    string
+		mockGetSymlinkTargetErr error
+		callListBinaries        bool
+		mockListBinariesErr     error
+		expectedVersions        []model.PinVersion
+		expectedErr             error
+	}{
+		"success-kind-latest": {
+			bin:                  model.NewBinaryFromString("mockproj2"),
+			mockExists:           true,
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj2@v1.10.0"),
+			callListBinaries:     true,
+			expectedVersions: []model.PinVersion{
+				{Version: "v2.2.0"},
+				{Version: "v1.10.0", Pinned: true},
+				{Version: "v1.3.1"},
+				{Version: "v1.2.0"},
+			},
+		},
+		"success-kind-major": {
+			bin:                  model.NewBinaryFromString("mockproj2-v1"),
+			mockExists:           true,
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+			callListBinaries:     true,
+			expectedVersions: []model.PinVersion{
+				{Version: "v1.10.0"},
+				{Version: "v1.3.1", Pinned: true},
+				{Version: "v1.2.0"},
+			},
+		},
+		"error-binary-not-found": {
+			bin:         model.NewBinaryFromString("mockproj2"),
+			expectedErr: toolchain.ErrBinaryNotFound,
+		},
+		"error-binary-not-managed": {
+			bin:                     model.NewBinaryFromString("mockproj2"),
+			mockExists:              true,
+			callGetSymlinkTarget:    true,
+			mockGetSymlinkTargetErr: errors.New("not a symlink"),
+			expectedErr:             manager.ErrBinaryNotManaged,
+		},
+		"error-binary-outside-internal-bin-path": {
+			bin:                  model.NewBinaryFromString("mockproj2"),
+			mockExists:           true,
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join("/usr", "local", "bin", "mockproj2"),
+			expectedErr:          manager.ErrBinaryNotManaged,
+		},
+		"error-list-binaries": {
+			bin:                  model.NewBinaryFromString("mockproj2"),
+			mockExists:           true,
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj2@v1.10.0"),
+			callListBinaries:     true,
+			mockListBinariesErr:  os.ErrPermission,
+			expectedErr:          os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			path := filepath.Join(goBinPath, tc.bin.String())
+
+			fs.EXPECT().Exists(path).Return(tc.mockExists).Once()
+
+			if tc.callGetSymlinkTarget {
+				fs.EXPECT().GetSymlinkTarget(path).
+					Return(tc.mockGetSymlinkTarget, tc.mockGetSymlinkTargetErr).
+					Once()
+			}
+
+			if tc.callListBinaries {
+				fs.EXPECT().ListBinaries(intBinPath).Return(intBins, tc.mockListBinariesErr).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			versions, err := binaryManager.GetPinVersions(tc.bin)
+			assert.Equal(t, tc.expectedVersions, versions)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetRelatedPins(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+
+	cases := map[string]struct {
+		bin                       model.Binary
+		mockGetSymlinkTargetCalls []mockGetSymlinkTargetCall
+		mockListBinariesCalls     []mockListBinariesCall
+		expectedPins              []model.Binary
+		expectedErr               error
+	}{
+		"success-unmanaged-binary": {
+			bin: model.NewBinaryFromString("mockproj"),
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "mockproj"), err: os.ErrInvalid},
+			},
+		},
+		"success-no-related-pins": {
+			bin: model.NewBinaryFromString("mockproj"),
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "mockproj"), target: filepath.Join(intBinPath, "mockproj@v2.0.0")},
+				{path: filepath.Join(goBinPath, "mockproj2"), target: filepath.Join(intBinPath, "mockproj2@v1.0.0")},
+			},
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: goBinPath,
+					binaries: []string{
+						filepath.Join(goBinPath, "mockproj"),
+						filepath.Join(goBinPath, "mockproj2"),
+					},
+				},
+			},
+			expectedPins: []model.Binary{},
+		},
+		"success-related-pins": {
+			bin: model.NewBinaryFromString("mockproj"),
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "mockproj"), target: filepath.Join(intBinPath, "mockproj@v2.0.0")},
+				{path: filepath.Join(goBinPath, "mockproj-v1"), target: filepath.Join(intBinPath, "mockproj@v1.2.0")},
+				{path: filepath.Join(goBinPath, "mockproj-v2"), target: filepath.Join(intBinPath, "mockproj@v2.0.0")},
+				{path: filepath.Join(goBinPath, "mockproj2"), target: filepath.Join(intBinPath, "mockproj2@v1.0.0")},
+				{path: filepath.Join(goBinPath, "mockproj3"), err: os.ErrInvalid},
+			},
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: goBinPath,
+					binaries: []string{
+						filepath.Join(goBinPath, "mockproj"),
+						filepath.Join(goBinPath, "mockproj-v1"),
+						filepath.Join(goBinPath, "mockproj-v2"),
+						filepath.Join(goBinPath, "mockproj2"),
+						filepath.Join(goBinPath, "mockproj3"),
+					},
+				},
+			},
+			expectedPins: []model.Binary{
+				model.NewBinaryFromString("mockproj-v1"),
+				model.NewBinaryFromString("mockproj-v2"),
+			},
+		},
+		"error-list-binaries": {
+			bin: model.NewBinaryFromString("mockproj"),
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "mockproj"), target: filepath.Join(intBinPath, "mockproj@v2.0.0")},
+			},
+			mockListBinariesCalls: []mockListBinariesCall{
+				{path: goBinPath, err: errors.New("unexpected error")},
+			},
+			expectedErr: errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+
+			for _, call := range tc.mockGetSymlinkTargetCalls {
+				fs.EXPECT().GetSymlinkTarget(call.path).
+					Return(call.target, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockListBinariesCalls {
+				fs.EXPECT().ListBinaries(call.path).
+					Return(call.binaries, call.err).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			pins, err := binaryManager.GetRelatedPins(tc.bin)
+			assert.Equal(t, tc.expectedPins, pins)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetStoreUsage(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	binPaths := []string{
+		filepath.Join(intBinPath, "bin1@v0.1.0"),
+		filepath.Join(intBinPath, "bin1@v0.2.0"),
+		filepath.Join(intBinPath, "bin2@v1.0.0"),
+	}
+	pinPaths := []string{
+		filepath.Join(goBinPath, "bin1"),
+		filepath.Join(goBinPath, "bin2"),
+		filepath.Join(goBinPath, "bin3"),
+	}
+	sizes := map[string]int64{binPaths[0]: 1000, binPaths[1]: 1200, binPaths[2]: 3000}
+
+	cases := map[string]struct {
+		mockExists          bool
+		callListBinaries    bool
+		mockListBinariesErr error
+		callListPins        bool
+		mockListPinsErr     error
+		callGetFileSize     bool
+		mockGetFileSizeErr  error
+		expectedUsage       model.StoreUsage
+		expectedErr         error
+	}{
+		"success": {
+			mockExists:       true,
+			callListBinaries: true,
+			callListPins:     true,
+			callGetFileSize:  true,
+			expectedUsage: model.StoreUsage{
+				{Name: "bin2", Versions: 1, Size: 3000},
+				{Name: "bin1", Versions: 2, Size: 2200, StaleVersions: 1, StaleSize: 1000},
+			},
+		},
+		"success-no-internal-bin-path": {},
+		"error-list-binaries": {
+			mockExists:          true,
+			callListBinaries:    true,
+			mockListBinariesErr: os.ErrPermission,
+			expectedErr:         os.ErrPermission,
+		},
+		"error-list-pins": {
+			mockExists:       true,
+			callListBinaries: true,
+			callListPins:     true,
+			mockListPinsErr:  os.ErrPermission,
+			expectedErr:      os.ErrPermission,
+		},
+		"error-get-file-size": {
+			mockExists:         true,
+			callListBinaries:   true,
+			callListPins:       true,
+			callGetFileSize:    true,
+			mockGetFileSizeErr: os.ErrPermission,
+			expectedErr:        os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+
+			fs.EXPECT().Exists(intBinPath).Return(tc.mockExists).Once()
+
+			if tc.callListBinaries {
+				fs.EXPECT().ListBinaries(intBinPath).Return(binPaths, tc.mockListBinariesErr).Once()
+			}
+
+			if tc.callListPins {
+				fs.EXPECT().ListBinaries(goBinPath).Return(pinPaths, tc.mockListPinsErr).Once()
+			}
+
+			if tc.callListPins && tc.mockListPinsErr == nil {
+				fs.EXPECT().GetSymlinkTarget(pinPaths[0]).Return(binPaths[1], nil).Once()
+				fs.EXPECT().GetSymlinkTarget(pinPaths[1]).Return(binPaths[2], nil).Once()
+				fs.EXPECT().GetSymlinkTarget(pinPaths[2]).Return("", os.ErrInvalid).Once()
+			}
+
+			if tc.callGetFileSize && tc.mockGetFileSizeErr != nil {
+				fs.EXPECT().GetFileSize(binPaths[0]).Return(0, tc.mockGetFileSizeErr).Once()
+			} else if tc.callGetFileSize {
+				for _, binPath := range binPaths {
+					fs.EXPECT().GetFileSize(binPath).Return(sizes[binPath], nil).Once()
+				}
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			usage, usageErr := binaryManager.GetStoreUsage()
+			assert.Equal(t, tc.expectedUsage, usage)
+			assert.Equal(t, tc.expectedErr, usageErr)
+		})
+	}
+}
+
+func TestGoBinaryManager_GetSumDBConfig(t *testing.T) {
+	cases := map[string]struct {
+		mockGetSumDBConfig    model.SumDBConfig
+		mockGetSumDBConfigErr error
+		expectedConfig        model.SumDBConfig
+		expectedErr           error
+	}{
+		"success": {
+			mockGetSumDBConfig: model.SumDBConfig{SumDB: "sum.golang.org"},
+			expectedConfig:     model.SumDBConfig{SumDB: "sum.golang.org"},
+		},
+		"error-get-sumdb-config": {
+			mockGetSumDBConfigErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetSumDBConfig(context.Background()).
+				Return(tc.mockGetSumDBConfig, tc.mockGetSumDBConfigErr).
+				Once()
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			config, err := binaryManager.GetSumDBConfig(context.Background())
+			assert.Equal(t, tc.expectedConfig, config)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_InstallLocalPackage(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	tempPath := workspace.GetInternalTempPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+	binTempDir := filepath.Join(tempPath, "local-0123456789")
+	tempBinPath := filepath.Join(binTempDir, "mockproj")
+	dir := filepath.Join("home", "user", "src", "mockproj", "cmd", "mockproj")
+
+	devBuildInfo := getBuildInfo("mockproj", "v0.1.1-0.20250729191454-dac745d99aac+dirty")
+	devBuildInfo.Main.Sum = ""
+	devBinPath := filepath.Join(intBinPath, "mockproj@v0.1.1-0.20250729191454-dac745d99aac+dirty")
+	sizeHistory := model.SizeHistory{
+		{Version: model.NewVersion("v0.1.1-0.20250729191454-dac745d99aac+dirty"), Size: 1000},
+	}
+
+	cases := map[string]struct {
+		flags                model.BuildFlags
+		mockCreateTempDirErr error
+		callInstallLocal     bool
+		mockInstallLocalErr  error
+		callListBinaries     bool
+		mockListBinaries     []string
+		mockListBinariesErr  error
+		callGetBuildInfo     bool
+		mockGetBuildInfoErr  error
+		callMove             bool
+		mockMoveErr          error
+		callReplaceSymlink   bool
+		callRecordBuildFlags bool
+		callRecordSource     bool
+		mockRecordSourceErr  error
+		expectedErr          error
+	}{
+		"success": {
+			callInstallLocal:   true,
+			callListBinaries:   true,
+			mockListBinaries:   []string{tempBinPath},
+			callGetBuildInfo:   true,
+			callMove:           true,
+			callReplaceSymlink: true,
+			callRecordSource:   true,
+		},
+		"success-build-flags": {
+			flags:                model.BuildFlags{Tags: "netgo"},
+			callInstallLocal:     true,
+			callListBinaries:     true,
+			mockListBinaries:     []string{tempBinPath},
+			callGetBuildInfo:     true,
+			callMove:             true,
+			callReplaceSymlink:   true,
+			callRecordBuildFlags: true,
+			callRecordSource:     true,
+		},
+		"error-create-temp-dir": {
+			mockCreateTempDirErr: errors.New("unexpected error"),
+			expectedErr:          errors.New("unexpected error"),
+		},
+		"error-install-local": {
+			callInstallLocal:    true,
+			mockInstallLocalErr: toolchain.ErrBuildFailed,
+			expectedErr:         toolchain.ErrBuildFailed,
+		},
+		"error-list-binaries": {
+			callInstallLocal:    true,
+			callListBinaries:    true,
+			mockListBinariesErr: errors.New("unexpected error"),
+			expectedErr:         errors.New("unexpected error"),
+		},
+		"error-package-not-main": {
+			callInstallLocal: true,
+			callListBinaries: true,
+			mockListBinaries: []string{},
+			expectedErr:      manager.ErrPackageNotMain,
+		},
+		"error-get-build-info": {
+			callInstallLocal:    true,
+			callListBinaries:    true,
+			mockListBinaries:    []string{tempBinPath},
+			callGetBuildInfo:    true,
+			mockGetBuildInfoErr: errors.New("unexpected error"),
+			expectedErr:         errors.New("unexpected error"),
+		},
+		"error-record-source": {
+			callInstallLocal:    true,
+			callListBinaries:    true,
+			mockListBinaries:    []string{tempBinPath},
+			callGetBuildInfo:    true,
+			callMove:            true,
+			callReplaceSymlink:  true,
+			callRecordSource:    true,
+			mockRecordSourceErr: errors.New("unexpected error"),
+			expectedErr:         errors.New("unexpected error"),
+		},
+		"error-move": {
+			callInstallLocal: true,
+			callListBinaries: true,
+			mockListBinaries: []string{tempBinPath},
+			callGetBuildInfo: true,
+			callMove:         true,
+			mockMoveErr:      errors.New("unexpected error"),
+			expectedErr:      errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			rt := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			fs.EXPECT().CreateTempDir(tempPath, "local-*").
+				Return(binTempDir, func() error { return nil }, tc.mockCreateTempDirErr).
+				Once()
+
+			if tc.callInstallLocal {
+				toolchain.EXPECT().InstallLocal(mock.Anything, binTempDir, dir, tc.flags).
+					Return(tc.mockInstallLocalErr).
+					Once()
+			}
+
+			if tc.callListBinaries {
+				fs.EXPECT().ListBinaries(binTempDir).
+					Return(tc.mockListBinaries, tc.mockListBinariesErr).
+					Once()
+			}
+
+			if tc.callGetBuildInfo {
+				toolchain.EXPECT().GetBuildInfo(tempBinPath).
+					Return(devBuildInfo, tc.mockGetBuildInfoErr).
+					Once()
+			}
+
+			if tc.callGetBuildInfo && tc.mockGetBuildInfoErr == nil {
+				rt.EXPECT().OS().Return("linux").Once()
+			}
+
+			if tc.callMove {
+				fs.EXPECT().Move(tempBinPath, devBinPath).
+					Return(tc.mockMoveErr).
+					Once()
+			}
+
+			if tc.callReplaceSymlink {
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+					Return("", os.ErrNotExist).
+					Once()
+				fs.EXPECT().ReplaceSymlink(devBinPath, filepath.Join(goBinPath, "mockproj")).
+					Return(nil).
+					Once()
+				fs.EXPECT().GetFileSize(devBinPath).
+					Return(1000, nil).
+					Once()
+
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name:        "mockproj",
+					SizeHistory: sizeHistory,
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(nil, os.ErrNotExist).
+					Once()
+				fs.EXPECT().WriteFile(receiptPath, receipt, os.FileMode(0600)).
+					Return(nil).
+					Once()
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(receipt, nil).
+					Once()
+			}
+
+			if tc.callRecordBuildFlags {
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name:        "mockproj",
+					BuildFlags:  tc.flags,
+					SizeHistory: sizeHistory,
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().WriteFile(receiptPath, receipt, os.FileMode(0600)).
+					Return(nil).
+					Once()
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(receipt, nil).
+					Once()
+			}
+
+			if tc.callRecordSource {
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name:        "mockproj",
+					BuildFlags:  tc.flags,
+					Source:      dir,
+					SizeHistory: sizeHistory,
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().WriteFile(receiptPath, receipt, os.FileMode(0600)).
+					Return(tc.mockRecordSourceErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, rt, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.InstallLocalPackage(context.Background(), dir, tc.flags)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_InstallPackage(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	tempPath := workspace.GetInternalTempPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+
+	mainPkgInfo := model.PackageInfo{
+		Module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+		Exists: true,
+		IsMain: true,
+	}
+
+	cacheArtifact := model.CacheArtifact{
+		Name:     "mockproj@v1.0.0",
+		Package:  "example.com/mockorg/mockproj/cmd/mockproj",
+		Module:   "example.com/mockorg/mockproj",
+		Version:  "v1.0.0",
+		Platform: "linux/amd64",
+	}
+
+	cases := map[string]struct {
+		pkg                      model.Package
+		kind                     model.Kind
+		flags                    model.BuildFlags
+		pinMode                  model.PinMode
+		rebuild                  bool
+		universal                bool
+		signing                  model.SigningConfig
+		cacheFrom                string
+		mockCacheIndex           []model.CacheArtifact
+		mockCacheIndexErr        error
+		callGetArtifact          bool
+		mockCacheBuildInfo       *buildinfo.BuildInfo
+		callLookupModuleSum      bool
+		mockLookupModuleSum      string
+		callReadFile             bool
+		mockReadFile             []byte
+		mockReadFileErr          error
+		callGetModuleVersions    bool
+		mockGetModuleVersions    []model.Version
+		mockGetModuleVersionsErr error
+		callGetPackageInfo       bool
+		mockGetPackageInfoPkg    model.Package
+		mockGetPackageInfo       model.PackageInfo
+		mockGetPackageInfoErr    error
+		callCreateTempDir        bool
+		mockCreateTempDirPattern string
+		mockCreateTempDirPath    string
+		mockCreateTempDirErr     error
+		callInstall              bool
+		mockInstallPackage       model.Package
+		mockInstallErr           error
+		mockRuntimePlatform      string
+		mockInstallPlatforms     []string
+		mockInstallPlatformErr   error
+		callCreateUniversal      bool
+		mockCreateUniversalSrcs  []string
+		mockCreateUniversalErr   error
+		callRuntimeOS            bool
+		mockRuntimeOS            string
+		callGetBuildInfo         bool
+		mockGetBuildInfoPath     string
+		mockGetBuildInfo         *buildinfo.BuildInfo
+		mockGetBuildInfoErr      error
+		mockListBinariesCalls    []mockListBinariesCall
+		mockGetBuildID           string
+		mockGetBuildIDErr        error
+		callInstallDebugInfo     bool
+		mockLocateUPX            []string
+		mockCompressErr          error
+		mockCompression          model.Compression
+		callMove                 bool
+		mockMoveSrc              string
+		mockMoveDst              string
+		mockMoveErr              error
+		mockSignErr              error
+		callReplaceSymlink       bool
+		mockReplaceSymlinkSrc    string
+		mockReplaceSymlinkDst    string
+		mockReplaceSymlinkErr    error
+		callCopyPin              bool
+		mockCopyPinErr           error
+		mockGetFileSizeErr       error
+		callRecordBuildFlags     bool
+		mockRecordBuildFlagsErr  error
+		expectedErr              error
+	}{
+		"success-package": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-copy-mode": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			pinMode:                  model.PinModeCopy,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callCopyPin:              true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-cache-from": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			cacheFrom:                "http://cache.example.com:8080",
+			mockCacheIndex:           []model.CacheArtifact{cacheArtifact},
+			callGetArtifact:          true,
+			mockCacheBuildInfo:       getBuildInfo("mockproj", "v1.0.0"),
+			callLookupModuleSum:      true,
+			mockLookupModuleSum:      "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+			mockRuntimePlatform:      "linux/amd64",
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-cache-from-no-match": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			flags:                    model.BuildFlags{Strip: true},
+			cacheFrom:                "http://cache.example.com:8080",
+			mockCacheIndex:           []model.CacheArtifact{cacheArtifact},
+			mockRuntimePlatform:      "linux/amd64",
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+			callRecordBuildFlags:     true,
+		},
+		"success-cache-from-index-error": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			cacheFrom:                "http://cache.example.com:8080",
+			mockCacheIndexErr:        errors.New("unexpected error"),
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-cache-from-sum-mismatch": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			cacheFrom:                "http://cache.example.com:8080",
+			mockCacheIndex:           []model.CacheArtifact{cacheArtifact},
+			callGetArtifact:          true,
+			mockCacheBuildInfo:       getBuildInfo("mockproj", "v1.0.0"),
+			callLookupModuleSum:      true,
+			mockLookupModuleSum:      "h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+			mockRuntimePlatform:      "linux/amd64",
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-cache-from-invalid-binary": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			cacheFrom:                "http://cache.example.com:8080",
+			mockCacheIndex:           []model.CacheArtifact{cacheArtifact},
+			callGetArtifact:          true,
+			mockCacheBuildInfo:       getBuildInfo("mockproj", "v0.9.0"),
+			mockRuntimePlatform:      "linux/amd64",
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-package-with-build-flags": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			flags:                    model.BuildFlags{GOAMD64: "v3"},
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+			callRecordBuildFlags:     true,
+		},
+		"success-debug-info": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			flags:                    model.BuildFlags{Strip: true, DebugInfo: true},
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			mockGetBuildID:           "mockaction/mockcontent",
+			callInstallDebugInfo:     true,
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+			callRecordBuildFlags:     true,
+		},
+		"success-debug-info-build-id-failed": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			flags:                    model.BuildFlags{Strip: true, DebugInfo: true},
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			mockGetBuildIDErr:        errors.New("exit status 1: missing build ID"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+			callRecordBuildFlags:     true,
+		},
+		"success-compressed": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			flags:                    model.BuildFlags{Compress: true},
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			mockLocateUPX:            []string{"/usr/bin/upx"},
+			mockCompression:          model.Compression{OriginalSize: 10485760, CompressedSize: 3145728},
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-compress-upx-not-found": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			flags:                    model.BuildFlags{Compress: true},
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-compress-failed": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			flags:                    model.BuildFlags{Compress: true},
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			mockLocateUPX:            []string{"/usr/bin/upx"},
+			mockCompressErr:          errors.New("exit status 2: upx: mockproj: NotCompressibleException"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-signed": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			signing:                  model.SigningConfig{Linux: "mock@example.com"},
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-signing-other-os": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			signing:                  model.SigningConfig{Darwin: "Developer ID Application: Mock Org (MOCKTEAMID)"},
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"error-sign": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			signing:                  model.SigningConfig{Linux: "mock@example.com"},
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockSignErr:              errors.New("exit status 2: gpg: signing failed: No secret key"),
+			expectedErr:              errors.New("exit status 2: gpg: signing failed: No secret key"),
+		},
+		"success-windows": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "windows",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj.exe"),
+			mockGetBuildInfo:         getBuildInfo("mockproj.exe", "v1.0.0"),
+			mockListBinariesCalls: []mockListBinariesCall{
+				{path: intBinPath, binaries: []string{filepath.Join(intBinPath, "mockproj@v0.9.0.exe")}},
+				{path: goBinPath, binaries: []string{filepath.Join(goBinPath, "mockproj.exe")}},
+			},
+			callMove:              true,
+			mockMoveSrc:           filepath.Join(tempPath, "mockproj-0123456789/mockproj.exe"),
+			mockMoveDst:           filepath.Join(intBinPath, "mockproj@v1.0.0.exe"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj@v1.0.0.exe"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj.exe"),
+		},
+		"success-universal": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			universal:                true,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockRuntimePlatform:      "darwin/amd64",
+			mockInstallPlatforms:     []string{"darwin/amd64", "darwin/arm64"},
+			callCreateUniversal:      true,
+			mockCreateUniversalSrcs: []string{
+				filepath.Join(tempPath, "mockproj-0123456789/bin/mockproj"),
+				filepath.Join(tempPath, "mockproj-0123456789/bin/darwin_arm64/mockproj"),
+			},
+			callRuntimeOS:        true,
+			mockRuntimeOS:        "darwin",
+			callGetBuildInfo:     true,
+			mockGetBuildInfoPath: filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v1.0.0"),
+			mockListBinariesCalls: []mockListBinariesCall{
+				{path: intBinPath},
+				{path: goBinPath, err: os.ErrNotExist},
+			},
+			callMove:              true,
+			mockMoveSrc:           filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:           filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-package-with-version": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-package-version-suffix-with-version": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/v2@v2.0.0"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/v2@v2.0.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/v2@v2.0.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v2.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v2.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v2.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-package-kind-major": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			kind:                     model.KindMajor,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj-v1"),
+		},
+		"success-package-kind-minor": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			kind:                     model.KindMinor,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj-v1.0"),
+		},
+		"error-mkdir-temp": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirErr:     os.ErrNotExist,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			expectedErr:              os.ErrNotExist,
+		},
+		"error-install": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockInstallErr:           errors.New("exit status 1: unexpected error"),
+			expectedErr:              errors.New("exit status 1: unexpected error"),
+		},
+		"error-get-build-info": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.1.0"),
+			mockGetBuildInfoErr:      os.ErrNotExist,
+			expectedErr:              os.ErrNotExist,
+		},
+		"error-move": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.1.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			mockMoveErr:              os.ErrExist,
+			expectedErr:              os.ErrExist,
+		},
+		"success-version-previous": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@previous"),
+			kind:                     model.KindLatest,
+			callReadFile:             true,
+			mockReadFile:             []byte(`{"name":"mockproj","previous_version":"v1.0.0"}`),
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-version-latest-offset": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest-1"),
+			kind:                  model.KindLatest,
+			callGetModuleVersions: true,
+			mockGetModuleVersions: []model.Version{
+				model.NewVersion("v1.0.0"),
+				model.NewVersion("v1.1.0"),
+				model.NewVersion("v1.2.0-rc.1"),
+				model.NewVersion("v1.2.0"),
+			},
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.1.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"error-previous-version-not-found": {
+			pkg:             model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@previous"),
+			kind:            model.KindLatest,
+			callReadFile:    true,
+			mockReadFileErr: os.ErrNotExist,
+			mockRuntimeOS:   "linux",
+			expectedErr:     manager.ErrPreviousVersionNotFound,
+		},
+		"error-get-module-versions": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest-1"),
+			kind:                     model.KindLatest,
+			callGetModuleVersions:    true,
+			mockGetModuleVersionsErr: toolchain.ErrModuleNotFound,
+			expectedErr:              toolchain.ErrModuleNotFound,
+		},
+		"error-version-not-available": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest-2"),
+			kind:                  model.KindLatest,
+			callGetModuleVersions: true,
+			mockGetModuleVersions: []model.Version{
+				model.NewVersion("v1.0.0"),
+				model.NewVersion("v1.1.0-rc.1"),
+				model.NewVersion("v1.1.0"),
+			},
+			expectedErr: manager.ErrVersionNotAvailable,
+		},
+		"success-ref": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@main"),
+			kind:                  model.KindLatest,
+			callGetPackageInfo:    true,
+			mockGetPackageInfoPkg: model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@main"),
+			mockGetPackageInfo: model.PackageInfo{
+				Module: model.NewModule(
+					"example.com/mockorg/mockproj",
+					model.NewVersion("v1.1.1-0.20250101000000-0123456789ab"),
+				),
+				Exists: true,
+				IsMain: true,
+			},
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage: model.NewPackage(
+				"example.com/mockorg/mockproj/cmd/mockproj@v1.1.1-0.20250101000000-0123456789ab",
+			),
+			callRuntimeOS:         true,
+			mockRuntimeOS:         "linux",
+			callGetBuildInfo:      true,
+			mockGetBuildInfoPath:  filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:      getBuildInfo("mockproj", "v1.1.1-0.20250101000000-0123456789ab"),
+			callMove:              true,
+			mockMoveSrc:           filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:           filepath.Join(intBinPath, "mockproj@v1.1.1-0.20250101000000-0123456789ab"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj@v1.1.1-0.20250101000000-0123456789ab"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj"),
+		},
+		"error-ref-not-found": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@feature/x"),
+			kind:                  model.KindLatest,
+			callGetPackageInfo:    true,
+			mockGetPackageInfoPkg: model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@feature/x"),
+			mockGetPackageInfoErr: toolchain.ErrModuleNotFound,
+			callGetModuleVersions: true,
+			mockGetModuleVersions: []model.Version{model.NewVersion("v1.0.0")},
+			expectedErr: fmt.Errorf(
+				"%w: branch or tag not served by the proxy, set GOPRIVATE or use --direct",
+				manager.ErrRefNotFound,
+			),
+		},
+		"error-module-not-found": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			kind:                     model.KindLatest,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockGetPackageInfoErr:    toolchain.ErrModuleNotFound,
+			callGetModuleVersions:    true,
+			mockGetModuleVersionsErr: toolchain.ErrModuleNotFound,
+			expectedErr:              toolchain.ErrModuleNotFound,
+		},
+		"error-version-not-found": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v9.0.0"),
+			kind:                  model.KindLatest,
+			callGetPackageInfo:    true,
+			mockGetPackageInfoPkg: model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v9.0.0"),
+			mockInstallPackage:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v9.0.0"),
+			mockGetPackageInfoErr: toolchain.ErrModuleNotFound,
+			callGetModuleVersions: true,
+			mockGetModuleVersions: []model.Version{
+				model.NewVersion("v1.0.0"),
+				model.NewVersion("v1.1.0"),
+				model.NewVersion("v1.2.0"),
+				model.NewVersion("v1.3.0"),
+			},
+			expectedErr: fmt.Errorf(
+				"%w: latest versions are v1.3.0, v1.2.0, v1.1.0",
+				manager.ErrVersionNotAvailable,
+			),
+		},
+		"error-version-not-found-closest": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.2.7"),
+			kind:                  model.KindLatest,
+			callGetPackageInfo:    true,
+			mockGetPackageInfoPkg: model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.2.7"),
+			mockInstallPackage:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.2.7"),
+			mockGetPackageInfoErr: toolchain.ErrModuleNotFound,
+			callGetModuleVersions: true,
+			mockGetModuleVersions: []model.Version{
+				model.NewVersion("v1.2.0"),
+				model.NewVersion("v1.2.3"),
+				model.NewVersion("v1.3.0"),
+			},
+			expectedErr: fmt.Errorf("%w: did you mean @v1.2.3?", manager.ErrVersionNotAvailable),
+		},
+		"error-package-not-found": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mock@v1.0.0"),
+			kind:                  model.KindLatest,
+			callGetPackageInfo:    true,
+			mockGetPackageInfoPkg: model.NewPackage("example.com/mockorg/mockproj/cmd/mock@v1.0.0"),
+			mockInstallPackage:    model.NewPackage("example.com/mockorg/mockproj/cmd/mock@v1.0.0"),
+			mockGetPackageInfo: model.PackageInfo{
+				Module:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				MainPackages: []string{"example.com/mockorg/mockproj/cmd/mockproj"},
+			},
+			expectedErr: fmt.Errorf(
+				"%w: did you mean example.com/mockorg/mockproj/cmd/mockproj?",
+				manager.ErrPackageNotFound,
+			),
+		},
+		"error-package-not-main": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/pkg/mock@v1.0.0"),
+			kind:                  model.KindLatest,
+			callGetPackageInfo:    true,
+			mockGetPackageInfoPkg: model.NewPackage("example.com/mockorg/mockproj/pkg/mock@v1.0.0"),
+			mockInstallPackage:    model.NewPackage("example.com/mockorg/mockproj/pkg/mock@v1.0.0"),
+			mockGetPackageInfo: model.PackageInfo{
+				Module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				Exists: true,
+			},
+			expectedErr: manager.ErrPackageNotMain,
+		},
+		"error-get-package-info": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			kind:                  model.KindLatest,
+			callGetPackageInfo:    true,
+			mockGetPackageInfoPkg: model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockInstallPackage:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockGetPackageInfoErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
+		},
+		"error-universal-not-supported": {
+			pkg:           model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:          model.KindLatest,
+			universal:     true,
+			mockRuntimeOS: "linux",
+			expectedErr:   manager.ErrUniversalNotSupported,
+		},
+		"error-install-platform": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			universal:                true,
+			mockRuntimeOS:            "darwin",
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockRuntimePlatform:      "darwin/arm64",
+			mockInstallPlatforms:     []string{"darwin/arm64"},
+			mockInstallPlatformErr:   errors.New("unexpected error"),
+			expectedErr:              errors.New("unexpected error"),
+		},
+		"error-create-universal-binary": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			universal:                true,
+			mockRuntimeOS:            "darwin",
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockRuntimePlatform:      "darwin/arm64",
+			mockInstallPlatforms:     []string{"darwin/arm64", "darwin/amd64"},
+			callCreateUniversal:      true,
+			mockCreateUniversalSrcs: []string{
+				filepath.Join(tempPath, "mockproj-0123456789/bin/mockproj"),
+				filepath.Join(tempPath, "mockproj-0123456789/bin/darwin_amd64/mockproj"),
+			},
+			mockCreateUniversalErr: errors.New("unexpected error"),
+			expectedErr:            errors.New("unexpected error"),
+		},
+		"error-replace-symlink": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.1.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+			mockReplaceSymlinkErr:    errors.New("unexpected error"),
+			expectedErr:              errors.New("unexpected error"),
+		},
+		"error-record-size": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.1.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+			mockGetFileSizeErr:       errors.New("unexpected error"),
+			expectedErr:              errors.New("unexpected error"),
+		},
+		"error-record-build-flags": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			flags:                    model.BuildFlags{GOAMD64: "v3"},
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+			callRecordBuildFlags:     true,
+			mockRecordBuildFlagsErr:  errors.New("unexpected error"),
+			expectedErr:              errors.New("unexpected error"),
+		},
+		"error-name-collision-internal-bin-path": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "windows",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj.exe"),
+			mockGetBuildInfo:         getBuildInfo("mockproj.exe", "v1.0.0"),
+			mockListBinariesCalls: []mockListBinariesCall{
+				{path: intBinPath, binaries: []string{filepath.Join(intBinPath, "MockProj@v1.0.0.exe")}},
+			},
+			expectedErr: fmt.Errorf(
+				"%w: %q collides with %q", manager.ErrBinaryNameCollision, "mockproj@v1.0.0.exe", "MockProj@v1.0.0.exe",
+			),
+		},
+		"error-name-collision-go-bin-path": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "darwin",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			mockListBinariesCalls: []mockListBinariesCall{
+				{path: intBinPath},
+				{path: goBinPath, binaries: []string{filepath.Join(goBinPath, "MockProj")}},
+			},
+			expectedErr: fmt.Errorf("%w: %q collides with %q", manager.ErrBinaryNameCollision, "mockproj", "MockProj"),
+		},
+		"error-list-binaries": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "windows",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj.exe"),
+			mockGetBuildInfo:         getBuildInfo("mockproj.exe", "v1.0.0"),
+			mockListBinariesCalls: []mockListBinariesCall{
+				{path: intBinPath, err: os.ErrPermission},
+			},
+			expectedErr: os.ErrPermission,
+		},
+		"error-copy-pin": {
+			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                     model.KindLatest,
+			pinMode:                  model.PinModeCopy,
+			rebuild:                  false,
+			callGetPackageInfo:       true,
+			mockGetPackageInfoPkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo:       mainPkgInfo,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			callRuntimeOS:            true,
+			mockRuntimeOS:            "linux",
+			callGetBuildInfo:         true,
+			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callCopyPin:              true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+			mockCopyPinErr:           errors.New("unexpected error"),
+			expectedErr:              errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			binaryCache := registrymocks.NewBinaryCache(t)
+			fs := systemmocks.NewFileSystem(t)
+			rt := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			ctx := context.Background()
+
+			if tc.universal {
+				rt.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+			}
+
+			if tc.callReadFile {
+				rt.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+				fs.EXPECT().ReadFile(receiptPath).Return(tc.mockReadFile, tc.mockReadFileErr).Once()
+			}
+
+			if tc.callGetModuleVersions {
+				toolchain.EXPECT().GetModuleVersions(ctx, tc.pkg.Path).
+					Return(tc.mockGetModuleVersions, tc.mockGetModuleVersionsErr).Once()
+			}
+
+			if tc.callGetPackageInfo {
+				toolchain.EXPECT().GetPackageInfo(ctx, tc.mockGetPackageInfoPkg).
+					Return(tc.mockGetPackageInfo, tc.mockGetPackageInfoErr).Once()
+			}
+
+			if tc.callCreateTempDir {
+				fs.EXPECT().CreateTempDir(tempPath, tc.mockCreateTempDirPattern).
+					Return(tc.mockCreateTempDirPath, func() error { return nil }, tc.mockCreateTempDirErr).Once()
+			}
+
+			if tc.cacheFrom != "" {
+				rt.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+				binaryCache.EXPECT().GetIndex(ctx, tc.cacheFrom).
+					Return(tc.mockCacheIndex, tc.mockCacheIndexErr).
+					Once()
+			}
+
+			if tc.cacheFrom != "" && tc.mockCacheIndexErr == nil {
+				rt.EXPECT().Platform().Return(tc.mockRuntimePlatform).Once()
+			}
+
+			if tc.callGetArtifact {
+				binaryCache.EXPECT().GetArtifact(ctx, tc.cacheFrom, cacheArtifact).
+					Return([]byte("binary"), nil).
+					Once()
+				fs.EXPECT().WriteFile(tc.mockGetBuildInfoPath, []byte("binary"), os.FileMode(0755)).
+					Return(nil).
+					Once()
+				toolchain.EXPECT().GetBuildInfo(tc.mockGetBuildInfoPath).
+					Return(tc.mockCacheBuildInfo, nil).
+					Once()
+			}
+
+			if tc.callLookupModuleSum {
+				toolchain.EXPECT().GetSumDBConfig(ctx).
+					Return(model.SumDBConfig{SumDB: "sum.golang.org"}, nil).
+					Once()
+				toolchain.EXPECT().LookupModuleSum(ctx, model.SumDBConfig{SumDB: "sum.golang.org"}, model.NewModule(
+					tc.mockCacheBuildInfo.Main.Path,
+					model.NewVersion(tc.mockCacheBuildInfo.Main.Version),
+				)).
+					Return(tc.mockLookupModuleSum, nil).
+					Once()
+			}
+
+			if tc.callGetArtifact && tc.callInstall {
+				fs.EXPECT().Remove(tc.mockGetBuildInfoPath).
+					Return(nil).
+					Once()
+			}
+
+			if tc.callInstall {
+				toolchain.EXPECT().Install(
+					ctx,
+					tc.mockCreateTempDirPath,
+					tc.mockInstallPackage,
+					tc.flags,
+					tc.rebuild,
+				).Return(tc.mockInstallErr).Once()
+			}
+
+			if len(tc.mockInstallPlatforms) > 0 {
+				rt.EXPECT().Platform().Return(tc.mockRuntimePlatform).Once()
+			}
+
+			for _, platform := range tc.mockInstallPlatforms {
+				toolchain.EXPECT().InstallPlatform(
+					ctx,
+					tc.mockCreateTempDirPath,
+					tc.mockInstallPackage,
+					platform,
+					tc.flags,
+					tc.rebuild,
+				).Return(tc.mockInstallPlatformErr).Once()
+			}
+
+			if tc.callCreateUniversal {
+				fs.EXPECT().CreateUniversalBinary(
+					filepath.Join(tc.mockCreateTempDirPath, "mockproj"),
+					tc.mockCreateUniversalSrcs,
+				).Return(tc.mockCreateUniversalErr).Once()
+			}
+
+			if tc.callRuntimeOS {
+				rt.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+			}
+
+			if tc.callGetBuildInfo {
+				toolchain.EXPECT().GetBuildInfo(tc.mockGetBuildInfoPath).
+					Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
+					Once()
+			}
+
+			for _, call := range tc.mockListBinariesCalls {
+				fs.EXPECT().ListBinaries(call.path).
+					Return(call.binaries, call.err).
+					Once()
+			}
+
+			if tc.flags.KeepsDebugInfo() {
+				toolchain.EXPECT().GetBuildID(ctx, tc.mockGetBuildInfoPath).
+					Return(tc.mockGetBuildID, tc.mockGetBuildIDErr).
+					Once()
+			}
+
+			if tc.callInstallDebugInfo {
+				debugTempDir := filepath.Join(tc.mockCreateTempDirPath, "debug")
+				debugPath := filepath.Join(
+					workspace.GetInternalDataPath(), "mockproj", "debug", "mockaction.mockcontent.debug",
+				)
+
+				toolchain.EXPECT().Install(
+					ctx,
+					debugTempDir,
+					model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+					tc.flags.Unstripped(),
+					tc.rebuild,
+				).Return(nil).Once()
+				fs.EXPECT().CreateDir(filepath.Dir(debugPath), os.FileMode(0700)).
+					Return(nil).
+					Once()
+				fs.EXPECT().Move(filepath.Join(debugTempDir, "mockproj"), debugPath).
+					Return(nil).
+					Once()
+			}
+
+			if tc.flags.Compress {
+				fs.EXPECT().LocateBinaryInPath("upx").
+					Return(tc.mockLocateUPX).
+					Once()
+			}
+
+			if len(tc.mockLocateUPX) > 0 {
+				fs.EXPECT().GetFileSize(tc.mockGetBuildInfoPath).
+					Return(int64(tc.mockCompression.OriginalSize), nil).
+					Once()
+				toolchain.EXPECT().Compress(ctx, tc.mockGetBuildInfoPath).
+					Return(tc.mockCompressErr).
+					Once()
+			}
+
+			if len(tc.mockLocateUPX) > 0 && tc.mockCompressErr == nil {
+				fs.EXPECT().GetFileSize(tc.mockGetBuildInfoPath).
+					Return(int64(tc.mockCompression.CompressedSize), nil).
+					Once()
+			}
+
+			if tc.callMove {
+				fs.EXPECT().Move(tc.mockMoveSrc, tc.mockMoveDst).
+					Return(tc.mockMoveErr).Once()
+			}
+
+			signature := model.Signature{Identity: tc.signing.GetIdentity(tc.mockRuntimeOS)}
+			if tc.callMove && tc.mockMoveErr == nil && signature.Identity != "" {
+				signature.File = filepath.Join(
+					workspace.GetInternalDataPath(), "mockproj", "signatures", "mockproj@v1.0.0.sig",
+				)
+
+				fs.EXPECT().CreateDir(filepath.Dir(signature.File), os.FileMode(0700)).
+					Return(nil).
+					Once()
+				toolchain.EXPECT().Sign(ctx, tc.mockRuntimeOS, signature.Identity, tc.mockMoveDst, signature.File).
+					Return(tc.mockSignErr).
+					Once()
+			}
+
+			if tc.callReplaceSymlink {
+				fs.EXPECT().GetSymlinkTarget(tc.mockReplaceSymlinkDst).
+					Return("", os.ErrNotExist).Once()
+				fs.EXPECT().ReplaceSymlink(tc.mockReplaceSymlinkSrc, tc.mockReplaceSymlinkDst).
+					Return(tc.mockReplaceSymlinkErr).Once()
+			}
+
+			if tc.callCopyPin {
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(nil, os.ErrNotExist).
+					Once()
+
+				fs.EXPECT().Copy(tc.mockReplaceSymlinkSrc, tc.mockReplaceSymlinkDst).
+					Return(tc.mockCopyPinErr).
+					Once()
+			}
+
+			if tc.callCopyPin && tc.mockCopyPinErr == nil {
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name:   "mockproj",
+					Target: tc.mockReplaceSymlinkSrc,
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(nil, os.ErrNotExist).
+					Once()
+
+				fs.EXPECT().WriteFile(receiptPath, receipt, os.FileMode(0600)).
+					Return(nil).
+					Once()
+			}
+
+			if (tc.callReplaceSymlink && tc.mockReplaceSymlinkErr == nil) ||
+				(tc.callCopyPin && tc.mockCopyPinErr == nil) {
+				fs.EXPECT().GetFileSize(tc.mockMoveDst).
+					Return(1000, tc.mockGetFileSizeErr).
+					Once()
+			}
+
+			if ((tc.callReplaceSymlink && tc.mockReplaceSymlinkErr == nil) ||
+				(tc.callCopyPin && tc.mockCopyPinErr == nil)) && tc.mockGetFileSizeErr == nil {
+				pinName := filepath.Base(tc.mockReplaceSymlinkDst)
+				pinReceiptPath := filepath.Join(workspace.GetInternalReceiptPath(), pinName+".json")
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name: pinName,
+					SizeHistory: model.SizeHistory{
+						{Version: model.NewBinaryFromString(filepath.Base(tc.mockMoveDst)).Version, Size: 1000},
+					},
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().ReadFile(pinReceiptPath).
+					Return(nil, os.ErrNotExist).
+					Once()
+
+				fs.EXPECT().WriteFile(pinReceiptPath, receipt, os.FileMode(0600)).
+					Return(nil).
+					Once()
+			}
+
+			if tc.mockCompression != (model.Compression{}) {
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name:        "mockproj",
+					Compression: tc.mockCompression,
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(nil, os.ErrNotExist).
+					Once()
+
+				fs.EXPECT().WriteFile(receiptPath, receipt, os.FileMode(0600)).
+					Return(nil).
+					Once()
+			}
+
+			if signature.Identity != "" && tc.mockSignErr == nil {
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name:      "mockproj",
+					Signature: signature,
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(nil, os.ErrNotExist).
+					Once()
+
+				fs.EXPECT().WriteFile(receiptPath, receipt, os.FileMode(0600)).
+					Return(nil).
+					Once()
+			}
+
+			if tc.callRecordBuildFlags {
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name:       "mockproj",
+					BuildFlags: tc.flags,
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(nil, os.ErrNotExist).
+					Once()
+
+				fs.EXPECT().WriteFile(receiptPath, receipt, os.FileMode(0600)).
+					Return(tc.mockRecordBuildFlagsErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				binaryCache, fs, nil, rt, toolchain, workspace, model.NewDefaultPinFormat(), tc.pinMode, tc.signing,
+			)
+			err = binaryManager.InstallPackage(
+				ctx,
+				tc.pkg,
+				tc.kind,
+				tc.flags,
+				tc.rebuild,
+				tc.universal,
+				tc.cacheFrom,
+			)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_InstallPackagePlatform(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	intBinPath := workspace.GetInternalBinPath()
+	tempPath := workspace.GetInternalTempPath()
+	binTempDir := filepath.Join(tempPath, "mockproj-0123456789")
+
+	latestPkg := model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest")
+	pkg := model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.2.0")
+
+	cases := map[string]struct {
+		platform              string
+		mockGetPackageInfoErr error
+		callInstallPlatform   bool
+		mockInstallErr        error
+		callCreateDir         bool
+		mockCreateDirErr      error
+		mockMoveSrc           string
+		mockMoveDst           string
+		mockMoveErr           error
+		expectedPath          string
+		expectedErr           error
+	}{
+		"success-cross-platform": {
+			platform:            "linux/arm64",
+			callInstallPlatform: true,
+			callCreateDir:       true,
+			mockMoveSrc:         filepath.Join(binTempDir, "bin", "linux_arm64", "mockproj"),
+			mockMoveDst:         filepath.Join(intBinPath, "linux_arm64", "mockproj@v1.2.0"),
+			expectedPath:        filepath.Join(intBinPath, "linux_arm64", "mockproj@v1.2.0"),
+		},
+		"success-cross-platform-windows": {
+			platform:            "windows/amd64",
+			callInstallPlatform: true,
+			callCreateDir:       true,
+			mockMoveSrc:         filepath.Join(binTempDir, "bin", "windows_amd64", "mockproj.exe"),
+			mockMoveDst:         filepath.Join(intBinPath, "windows_amd64", "mockproj@v1.2.0.exe"),
+			expectedPath:        filepath.Join(intBinPath, "windows_amd64", "mockproj@v1.2.0.exe"),
+		},
+		"success-runtime-platform": {
+			platform:            "linux/amd64",
+			callInstallPlatform: true,
+			callCreateDir:       true,
+			mockMoveSrc:         filepath.Join(binTempDir, "bin", "mockproj"),
+			mockMoveDst:         filepath.Join(intBinPath, "linux_amd64", "mockproj@v1.2.0"),
+			expectedPath:        filepath.Join(intBinPath, "linux_amd64", "mockproj@v1.2.0"),
+		},
+		"error-get-package-info": {
+			platform:              "linux/arm64",
+			mockGetPackageInfoErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
+		},
+		"error-install-platform": {
+			platform:            "linux/arm64",
+			callInstallPlatform: true,
+			mockInstallErr:      toolchain.ErrBuildFailed,
+			expectedErr:         toolchain.ErrBuildFailed,
+		},
+		"error-create-dir": {
+			platform:            "linux/arm64",
+			callInstallPlatform: true,
+			callCreateDir:       true,
+			mockCreateDirErr:    errors.New("unexpected error"),
+			expectedErr:         errors.New("unexpected error"),
+		},
+		"error-move": {
+			platform:            "linux/arm64",
+			callInstallPlatform: true,
+			callCreateDir:       true,
+			mockMoveSrc:         filepath.Join(binTempDir, "bin", "linux_arm64", "mockproj"),
+			mockMoveDst:         filepath.Join(intBinPath, "linux_arm64", "mockproj@v1.2.0"),
+			mockMoveErr:         errors.New("unexpected error"),
+			expectedErr:         errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			runtime := systemmocks.NewRuntime(t)
+			toolchainMock := toolchainmocks.NewToolchain(t)
+
+			toolchainMock.EXPECT().GetPackageInfo(mock.Anything, latestPkg).
+				Return(model.PackageInfo{
+					Module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
+					Exists: true,
+					IsMain: true,
+				}, tc.mockGetPackageInfoErr).
+				Once()
+
+			if tc.callInstallPlatform {
+				fs.EXPECT().CreateTempDir(tempPath, "mockproj-*").
+					Return(binTempDir, func() error { return nil }, nil).
+					Once()
+
+				toolchainMock.EXPECT().InstallPlatform(
+					mock.Anything, binTempDir, pkg, tc.platform, model.BuildFlags{}, false,
+				).
+					Return(tc.mockInstallErr).
+					Once()
+			}
+
+			if tc.callInstallPlatform && tc.mockInstallErr == nil {
+				runtime.EXPECT().Platform().Return("linux/amd64").Once()
+			}
+
+			if tc.callCreateDir {
+				fs.EXPECT().CreateDir(filepath.Join(intBinPath, model.GetPlatformDir(tc.platform)), os.FileMode(0755)).
+					Return(tc.mockCreateDirErr).
+					Once()
+			}
+
+			if tc.mockMoveSrc != "" {
+				fs.EXPECT().Move(tc.mockMoveSrc, tc.mockMoveDst).
+					Return(tc.mockMoveErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, runtime, toolchainMock, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			path, err := binaryManager.InstallPackagePlatform(
+				context.Background(), latestPkg, tc.platform, model.BuildFlags{}, false,
+			)
+			assert.Equal(t, tc.expectedPath, path)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_MigrateBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	otherBinPath := filepath.Join(t.TempDir(), "bin")
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+
+	cases := map[string]struct {
+		path                    string
+		mockGetBuildInfo        *buildinfo.BuildInfo
+		mockGetBuildInfoErr     error
+		callGetSymlinkTarget    bool
+		mockGetSymlinkTarget    string
+		mockGetSymlinkTargetErr error
+		callMoveWithSymlink     bool
+		mockMoveWithSymlinkSrc  string
+		mockMoveWithSymlinkDst  string
+		mockMoveWithSymlinkErr  error
+		callGetBuildInfo2       bool
+		mockGetBuildInfo2Path   string
+		mockGetBuildInfo2Err    error
+		callMove                bool
+		mockMoveSrc             string
+		mockMoveDst             string
+		mockMoveErr             error
+		callReplaceSymlink      bool
+		mockReplaceSymlinkSrc   string
+		mockReplaceSymlinkDst   string
+		callRecordMigration     bool
+		mockMigratedFrom        string
+		expectedErr             error
+	}{
+		"success": {
+			path:                   filepath.Join(goBinPath, "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget:   true,
+			mockGetSymlinkTarget:   filepath.Join(goBinPath, "mockproj"),
+			callMoveWithSymlink:    true,
+			mockMoveWithSymlinkSrc: filepath.Join(goBinPath, "mockproj"),
+			mockMoveWithSymlinkDst: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callRecordMigration:    true,
+			mockMigratedFrom:       filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-adopt-binary-from-other-dir": {
+			path:                  filepath.Join(otherBinPath, "mockproj"),
+			mockGetBuildInfo:      getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget:  true,
+			mockGetSymlinkTarget:  filepath.Join(otherBinPath, "mockproj"),
+			callGetBuildInfo2:     true,
+			mockGetBuildInfo2Path: filepath.Join(goBinPath, "mockproj"),
+			mockGetBuildInfo2Err:  toolchain.ErrBinaryNotFound,
+			callMove:              true,
+			mockMoveSrc:           filepath.Join(otherBinPath, "mockproj"),
+			mockMoveDst:           filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj"),
+			callRecordMigration:   true,
+			mockMigratedFrom:      filepath.Join(otherBinPath, "mockproj"),
+		},
+		"error-adopt-binary-already-exists": {
+			path:                  filepath.Join(otherBinPath, "mockproj"),
+			mockGetBuildInfo:      getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget:  true,
+			mockGetSymlinkTarget:  filepath.Join(otherBinPath, "mockproj"),
+			callGetBuildInfo2:     true,
+			mockGetBuildInfo2Path: filepath.Join(goBinPath, "mockproj"),
+			expectedErr:           manager.ErrBinaryAlreadyExists,
+		},
+		"error-adopt-binary-move": {
+			path:                  filepath.Join(otherBinPath, "mockproj"),
+			mockGetBuildInfo:      getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget:  true,
+			mockGetSymlinkTarget:  filepath.Join(otherBinPath, "mockproj"),
+			callGetBuildInfo2:     true,
+			mockGetBuildInfo2Path: filepath.Join(goBinPath, "mockproj"),
+			mockGetBuildInfo2Err:  toolchain.ErrBinaryNotFound,
+			callMove:              true,
+			mockMoveSrc:           filepath.Join(otherBinPath, "mockproj"),
+			mockMoveDst:           filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			mockMoveErr:           errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
+		},
+		"error-get-binary-info": {
+			path:                filepath.Join(goBinPath, "mockproj"),
+			mockGetBuildInfo:    getBuildInfo("mockproj", "v0.1.0"),
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-already-managed": {
+			path:                 filepath.Join(goBinPath, "mockproj"),
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			expectedErr:          manager.ErrBinaryAlreadyManaged,
+		},
+		"error-move-with-symlink": {
+			path:                   filepath.Join(goBinPath, "mockproj"),
+			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget:   true,
+			mockGetSymlinkTarget:   filepath.Join(goBinPath, "mockproj"),
+			callMoveWithSymlink:    true,
+			mockMoveWithSymlinkSrc: filepath.Join(goBinPath, "mockproj"),
+			mockMoveWithSymlinkDst: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			mockMoveWithSymlinkErr: errors.New("unexpected error"),
+			expectedErr:            errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo(tc.path).
+				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callGetSymlinkTarget {
+				fs.EXPECT().GetSymlinkTarget(tc.path).
+					Return(tc.mockGetSymlinkTarget, tc.mockGetSymlinkTargetErr).
+					Once()
+			}
+
+			if tc.callMoveWithSymlink {
+				fs.EXPECT().MoveWithSymlink(tc.mockMoveWithSymlinkSrc, tc.mockMoveWithSymlinkDst).
+					Return(tc.mockMoveWithSymlinkErr).
+					Once()
+			}
+
+			if tc.callGetBuildInfo2 {
+				toolchain.EXPECT().GetBuildInfo(tc.mockGetBuildInfo2Path).
+					Return(nil, tc.mockGetBuildInfo2Err).
+					Once()
+			}
+
+			if tc.callMove {
+				fs.EXPECT().Move(tc.mockMoveSrc, tc.mockMoveDst).
+					Return(tc.mockMoveErr).
+					Once()
+			}
+
+			if tc.callReplaceSymlink {
+				fs.EXPECT().ReplaceSymlink(tc.mockReplaceSymlinkSrc, tc.mockReplaceSymlinkDst).
+					Return(nil).
+					Once()
+			}
+
+			if tc.callRecordMigration {
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name:         "mockproj",
+					MigratedFrom: tc.mockMigratedFrom,
+					BuildFlags:   model.BuildFlags{CGOEnabled: "1", GOARM64: "v8.0"},
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(nil, os.ErrNotExist).
+					Once()
+
+				fs.EXPECT().WriteFile(receiptPath, receipt, os.FileMode(0600)).
+					Return(nil).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.MigrateBinary(tc.path)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_PinBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj2.json")
+
+	cases := map[string]struct {
+		bin                   model.Binary
+		kind                  model.Kind
+		mockReadFileCalls     []mockReadFileCall
+		callListBinaries      bool
+		mockListBinaries      []string
+		mockListBinariesErr   error
+		mockBinPath           string
+		mockRuntimeOS         string
+		mockGoBinaries        []string
+		mockPinTarget         string
+		callWriteFile         bool
+		mockWriteFileData     []byte
+		callReplaceSymlink    bool
+		mockReplaceSymlinkSrc string
+		mockReplaceSymlinkDst string
+		mockReplaceSymlinkErr error
+		expectedErr           error
+	}{
+		"success-version-latest-kind-latest": {
+			bin:              model.NewBinaryFromString("mockproj2"),
+			kind:             model.KindLatest,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:           filepath.Join(goBinPath, "mockproj2"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
+		},
+		"success-version-latest-kind-major": {
+			bin:              model.NewBinaryFromString("mockproj2"),
+			kind:             model.KindMajor,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:           filepath.Join(goBinPath, "mockproj2-v2"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2-v2"),
+		},
+		"success-version-latest-kind-minor": {
+			bin:              model.NewBinaryFromString("mockproj2"),
+			kind:             model.KindMinor,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:           filepath.Join(goBinPath, "mockproj2-v2.2"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2-v2.2"),
+		},
+		"success-extension-before-version-kind-major": {
+			bin:              model.NewBinaryFromString("mockproj2.exe@v1"),
+			kind:             model.KindMajor,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0.exe"),
+				filepath.Join(intBinPath, "mockproj2@v1.2.0.exe"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.1.exe"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0.exe"),
+			},
+			mockBinPath:           filepath.Join(goBinPath, "mockproj2-v1.exe"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v1.3.1.exe"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2-v1.exe"),
+		},
+		"success-version-v1-kind-latest": {
+			bin:              model.NewBinaryFromString("mockproj2@v1"),
+			kind:             model.KindLatest,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:           filepath.Join(goBinPath, "mockproj2"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
+		},
+		"success-version-v1.2-kind-latest": {
+			bin:              model.NewBinaryFromString("mockproj2@v1.2"),
+			kind:             model.KindLatest,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:           filepath.Join(goBinPath, "mockproj2"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
+		},
+		"success-version-v1.3.0-kind-latest": {
+			bin:              model.NewBinaryFromString("mockproj2@v1.3.0"),
+			kind:             model.KindLatest,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:           filepath.Join(goBinPath, "mockproj2"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v1.3.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
+		},
+		"success-record-previous-version": {
+			bin:              model.NewBinaryFromString("mockproj2"),
+			kind:             model.KindLatest,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:   filepath.Join(goBinPath, "mockproj2"),
+			mockPinTarget: filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+			mockReadFileCalls: []mockReadFileCall{
+				{path: receiptPath, err: os.ErrNotExist},
+			},
+			callWriteFile:         true,
+			mockWriteFileData:     []byte("{\n  \"name\": \"mockproj2\",\n  \"previous_version\": \"v1.3.1\"\n}"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
+		},
+		"success-version-previous": {
+			bin:  model.NewBinaryFromString("mockproj2@previous"),
+			kind: model.KindLatest,
+			mockReadFileCalls: []mockReadFileCall{
+				{path: receiptPath, data: []byte(`{"name":"mockproj2","previous_version":"v1.3.1"}`)},
+				{path: receiptPath, data: []byte(`{"name":"mockproj2","previous_version":"v1.3.1"}`)},
+			},
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:           filepath.Join(goBinPath, "mockproj2"),
+			mockPinTarget:         filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			callWriteFile:         true,
+			mockWriteFileData:     []byte("{\n  \"name\": \"mockproj2\",\n  \"previous_version\": \"v2.2.0\"\n}"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
+		},
+		"error-previous-version-not-found": {
+			bin:  model.NewBinaryFromString("mockproj2@previous"),
+			kind: model.KindLatest,
+			mockReadFileCalls: []mockReadFileCall{
+				{path: receiptPath, err: os.ErrNotExist},
+			},
+			expectedErr: manager.ErrPreviousVersionNotFound,
+		},
+		"error-list-binaries": {
+			bin:                 model.NewBinaryFromString("mockproj1"),
+			kind:                model.KindLatest,
+			callListBinaries:    true,
+			mockListBinariesErr: os.ErrNotExist,
+			expectedErr:         os.ErrNotExist,
+		},
+		"error-binary-not-found": {
+			bin:              model.NewBinaryFromString("mockproj1@v0.4"),
+			kind:             model.KindLatest,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+			},
+			expectedErr: toolchain.ErrBinaryNotFound,
+		},
+		"error-replace-symlink": {
+			bin:              model.NewBinaryFromString("mockproj1"),
+			kind:             model.KindLatest,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+			},
+			mockBinPath:           filepath.Join(goBinPath, "mockproj1"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj1"),
+			mockReplaceSymlinkErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
+		},
+		"success-darwin-no-name-collision": {
+			bin:              model.NewBinaryFromString("mockproj2"),
+			kind:             model.KindLatest,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:   filepath.Join(goBinPath, "mockproj2"),
+			mockRuntimeOS: "darwin",
+			mockGoBinaries: []string{
+				filepath.Join(goBinPath, "mockproj1"),
+				filepath.Join(goBinPath, "mockproj2"),
+			},
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
+		},
+		"error-name-collision": {
+			bin:              model.NewBinaryFromString("mockproj2"),
+			kind:             model.KindLatest,
+			callListBinaries: true,
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+			},
+			mockBinPath:   filepath.Join(goBinPath, "mockproj2"),
+			mockRuntimeOS: "darwin",
+			mockGoBinaries: []string{
+				filepath.Join(goBinPath, "MockProj2"),
+			},
+			expectedErr: fmt.Errorf(
+				"%w: %q collides with %q", manager.ErrBinaryNameCollision, "mockproj2", "MockProj2",
+			),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			rt := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			for _, call := range tc.mockReadFileCalls {
+				fs.EXPECT().ReadFile(call.path).Return(call.data, call.err).Once()
+			}
+
+			if tc.callListBinaries {
+				fs.EXPECT().ListBinaries(intBinPath).
+					Return(tc.mockListBinaries, tc.mockListBinariesErr).
+					Once()
+			}
+
+			if tc.mockRuntimeOS != "" {
+				rt.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+				fs.EXPECT().ListBinaries(goBinPath).Return(tc.mockGoBinaries, nil).Once()
+			} else if tc.callReplaceSymlink {
+				rt.EXPECT().OS().Return("linux").Once()
+			}
+
+			if tc.callReplaceSymlink {
+				if tc.mockPinTarget == "" {
+					fs.EXPECT().GetSymlinkTarget(tc.mockReplaceSymlinkDst).
+						Return("", os.ErrNotExist).
+						Once()
+				} else {
+					fs.EXPECT().GetSymlinkTarget(tc.mockReplaceSymlinkDst).
+						Return(tc.mockPinTarget, nil).
+						Once()
+				}
+			}
+
+			if tc.callWriteFile {
+				fs.EXPECT().WriteFile(receiptPath, tc.mockWriteFileData, os.FileMode(0600)).
+					Return(nil).
+					Once()
+			}
+
+			if tc.callReplaceSymlink {
+				fs.EXPECT().ReplaceSymlink(tc.mockReplaceSymlinkSrc, tc.mockReplaceSymlinkDst).
+					Return(tc.mockReplaceSymlinkErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, rt, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.PinBinary(tc.bin, tc.kind)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_PlanInstallPackage(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+
+	cases := map[string]struct {
+		pkg                   model.Package
+		kind                  model.Kind
+		flags                 model.BuildFlags
+		mockGetPackageInfo    model.PackageInfo
+		mockGetPackageInfoErr error
+		callExists            bool
+		mockExistsPath        string
+		mockExists            bool
+		expectedActions       []model.Action
+		expectedErr           error
+	}{
+		"success-link": {
+			pkg:   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:  model.KindLatest,
+			flags: model.BuildFlags{Tags: "netgo"},
+			mockGetPackageInfo: model.PackageInfo{
+				Module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				Exists: true,
+				IsMain: true,
+			},
+			callExists:     true,
+			mockExistsPath: filepath.Join(goBinPath, "mockproj"),
+			expectedActions: []model.Action{
+				{
+					Type:   model.ActionInstall,
+					Path:   filepath.Join(intBinPath, "mockproj@v1.0.0"),
+					Source: "example.com/mockorg/mockproj/cmd/mockproj@v1.0.0",
+					Flags:  model.BuildFlags{Tags: "netgo"},
+				},
+				{
+					Type:   model.ActionLink,
+					Path:   filepath.Join(goBinPath, "mockproj"),
+					Source: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+				},
+			},
+		},
+		"success-replace-major": {
+			pkg:  model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1"),
+			kind: model.KindMajor,
+			mockGetPackageInfo: model.PackageInfo{
+				Module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
+				Exists: true,
+				IsMain: true,
+			},
+			callExists:     true,
+			mockExistsPath: filepath.Join(goBinPath, "mockproj-v1"),
+			mockExists:     true,
+			expectedActions: []model.Action{
+				{
+					Type:   model.ActionInstall,
+					Path:   filepath.Join(intBinPath, "mockproj@v1.2.0"),
+					Source: "example.com/mockorg/mockproj/cmd/mockproj@v1.2.0",
+				},
+				{
+					Type:   model.ActionReplace,
+					Path:   filepath.Join(goBinPath, "mockproj-v1"),
+					Source: filepath.Join(intBinPath, "mockproj@v1.2.0"),
+				},
+			},
+		},
+		"error-package-not-main": {
+			pkg:  model.NewPackage("example.com/mockorg/mockproj/pkg/mockproj"),
+			kind: model.KindLatest,
+			mockGetPackageInfo: model.PackageInfo{
+				Module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				Exists: true,
+			},
+			expectedErr: manager.ErrPackageNotMain,
+		},
+		"error-get-package-info": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			kind:                  model.KindLatest,
+			mockGetPackageInfoErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			rt := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetPackageInfo(context.Background(), tc.pkg).
+				Return(tc.mockGetPackageInfo, tc.mockGetPackageInfoErr).
+				Once()
+
+			if tc.callExists {
+				rt.EXPECT().OS().Return("linux").Once()
+				fs.EXPECT().Exists(tc.mockExistsPath).Return(tc.mockExists).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, rt, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			actions, err := binaryManager.PlanInstallPackage(context.Background(), tc.pkg, tc.kind, tc.flags)
+			assert.Equal(t, tc.expectedActions, actions)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_PlanPruneBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := workspace.GetInternalReceiptPath()
+
+	cases := map[string]struct {
+		bin             model.Binary
+		mockReadFile    []byte
+		callList        bool
+		expectedActions []model.Action
+		expectedErr     error
+	}{
+		"success": {
+			bin:      model.NewBinaryFromString("mockproj2"),
+			callList: true,
+			expectedActions: []model.Action{
+				{Type: model.ActionRemove, Path: filepath.Join(intBinPath, "mockproj2@v2.0.0")},
+			},
+		},
+		"error-binary-protected": {
+			bin:          model.NewBinaryFromString("mockproj2"),
+			mockReadFile: []byte(`{"name":"mockproj2","protected":true}`),
+			expectedErr:  manager.ErrBinaryProtected,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			readFileErr := os.ErrNotExist
+			if tc.mockReadFile != nil {
+				readFileErr = nil
+			}
+
+			fs.EXPECT().ReadFile(filepath.Join(receiptPath, "mockproj2.json")).
+				Return(tc.mockReadFile, readFileErr).
+				Once()
+
+			if tc.callList {
+				fs.EXPECT().ListBinaries(intBinPath).Return([]string{
+					filepath.Join(intBinPath, "mockproj1@v1.0.0"),
+					filepath.Join(intBinPath, "mockproj2@v2.0.0"),
+					filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+				}, nil).Once()
+				fs.EXPECT().ListBinaries(goBinPath).Return([]string{
+					filepath.Join(goBinPath, "mockproj2"),
+				}, nil).Twice()
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj2")).
+					Return(filepath.Join(intBinPath, "mockproj2@v2.1.0"), nil).
+					Twice()
+
+				for _, version := range []string{"v2.0.0", "v2.1.0"} {
+					path := filepath.Join(intBinPath, "mockproj2@"+version)
+					toolchain.EXPECT().GetBuildInfo(path).Return(getBuildInfo("mockproj2", version), nil).Once()
+					fs.EXPECT().GetSymlinkTarget(path).Return("", os.ErrNotExist).Once()
+				}
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			actions, err := binaryManager.PlanPruneBinary(tc.bin, false)
+			assert.Equal(t, tc.expectedActions, actions)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_PlanUninstallBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := workspace.GetInternalReceiptPath()
+	dataPath := filepath.Join(workspace.GetInternalDataPath(), "mockproj")
+
+	cases := map[string]struct {
+		purge             bool
+		mockReadFileCalls []mockReadFileCall
+		mockExists        bool
+		callPurge         bool
+		mockDataExists    bool
+		expectedActions   []model.Action
+		expectedErr       error
+	}{
+		"success": {
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+			},
+			mockExists: true,
+			expectedActions: []model.Action{
+				{Type: model.ActionRemove, Path: filepath.Join(goBinPath, "mockproj")},
+			},
+		},
+		"success-purge": {
+			purge: true,
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+				{path: filepath.Join(receiptPath, "mockproj-v1.json"), err: os.ErrNotExist},
+			},
+			mockExists:     true,
+			callPurge:      true,
+			mockDataExists: true,
+			expectedActions: []model.Action{
+				{Type: model.ActionRemove, Path: filepath.Join(goBinPath, "mockproj-v1")},
+				{Type: model.ActionRemove, Path: filepath.Join(intBinPath, "mockproj@v1.0.0")},
+				{Type: model.ActionRemove, Path: filepath.Join(intBinPath, "mockproj@v1.1.0")},
+				{Type: model.ActionRemove, Path: filepath.Join(goBinPath, "mockproj")},
+				{Type: model.ActionRemove, Path: dataPath},
+			},
+		},
+		"error-related-pin-protected": {
+			purge: true,
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+				{
+					path: filepath.Join(receiptPath, "mockproj-v1.json"),
+					data: []byte(`{"name":"mockproj-v1","protected":true}`),
+				},
+			},
+			mockExists:  true,
+			callPurge:   true,
+			expectedErr: manager.ErrBinaryProtected,
+		},
+		"error-binary-not-found": {
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+			},
+			expectedErr: os.ErrNotExist,
+		},
+		"error-binary-protected": {
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj.json"),
+					data: []byte(`{"name":"mockproj","protected":true}`),
+				},
+			},
+			expectedErr: manager.ErrBinaryProtected,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+
+			for _, call := range tc.mockReadFileCalls {
+				fs.EXPECT().ReadFile(call.path).Return(call.data, call.err).Once()
+			}
+
+			if tc.mockReadFileCalls[0].data == nil {
+				fs.EXPECT().Exists(filepath.Join(goBinPath, "mockproj")).Return(tc.mockExists).Once()
+			}
+
+			if tc.callPurge {
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+					Return(filepath.Join(intBinPath, "mockproj@v1.1.0"), nil).
+					Once()
+				fs.EXPECT().ListBinaries(goBinPath).Return([]string{
+					filepath.Join(goBinPath, "mockproj"),
+					filepath.Join(goBinPath, "mockproj-v1"),
+					filepath.Join(goBinPath, "othermockproj"),
+				}, nil).Once()
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj-v1")).
+					Return(filepath.Join(intBinPath, "mockproj@v1.0.0"), nil).
+					Once()
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "othermockproj")).
+					Return(filepath.Join(intBinPath, "othermockproj@v0.1.0"), nil).
+					Once()
+			}
+
+			if tc.callPurge && tc.expectedErr == nil {
+				fs.EXPECT().ListBinaries(intBinPath).Return([]string{
+					filepath.Join(intBinPath, "mockproj@v1.0.0"),
+					filepath.Join(intBinPath, "mockproj@v1.1.0"),
+					filepath.Join(intBinPath, "othermockproj@v0.1.0"),
+				}, nil).Once()
+				fs.EXPECT().Exists(dataPath).Return(tc.mockDataExists).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			actions, err := binaryManager.PlanUninstallBinary(model.NewBinaryFromString("mockproj"), false, tc.purge)
+			assert.Equal(t, tc.expectedActions, actions)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_PlanUpgradeBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := workspace.GetInternalReceiptPath()
+	binFullPath := filepath.Join(goBinPath, "mockproj")
+
+	cases := map[string]struct {
+		rebuild         bool
+		flags           model.BuildFlags
+		mockLatest      model.Version
+		callReadFile    bool
+		mockReadFile    []byte
+		expectedActions []model.Action
+		expectedErr     error
+	}{
+		"success-no-upgrade-available": {
+			mockLatest: model.NewVersion("v1.0.0"),
+		},
+		"success-upgrade-available": {
+			mockLatest:   model.NewVersion("v1.1.0"),
+			callReadFile: true,
+			expectedActions: []model.Action{
+				{
+					Type:   model.ActionInstall,
+					Path:   filepath.Join(intBinPath, "mockproj@v1.1.0"),
+					Source: "example.com/mockorg/mockproj/cmd/mockproj@v1.1.0",
+				},
+				{
+					Type:   model.ActionReplace,
+					Path:   binFullPath,
+					Source: filepath.Join(intBinPath, "mockproj@v1.1.0"),
+				},
+			},
+		},
+		"success-rebuild": {
+			rebuild:      true,
+			mockLatest:   model.NewVersion("v1.0.0"),
+			callReadFile: true,
+			expectedActions: []model.Action{
+				{
+					Type:   model.ActionInstall,
+					Path:   filepath.Join(intBinPath, "mockproj@v1.0.0"),
+					Source: "example.com/mockorg/mockproj/cmd/mockproj@v1.0.0",
+				},
+				{
+					Type:   model.ActionReplace,
+					Path:   binFullPath,
+					Source: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+				},
+			},
+		},
+		"success-rebuild-with-flags": {
+			rebuild:      true,
+			flags:        model.BuildFlags{CGOEnabled: "0"},
+			mockLatest:   model.NewVersion("v1.0.0"),
+			callReadFile: true,
+			mockReadFile: []byte(`{"name":"mockproj","build_flags":{"tags":"netgo","cgo_enabled":"1"}}`),
+			expectedActions: []model.Action{
+				{
+					Type:   model.ActionInstall,
+					Path:   filepath.Join(intBinPath, "mockproj@v1.0.0"),
+					Source: "example.com/mockorg/mockproj/cmd/mockproj@v1.0.0",
+					Flags:  model.BuildFlags{Tags: "netgo", CGOEnabled: "0"},
+				},
+				{
+					Type:   model.ActionReplace,
+					Path:   binFullPath,
+					Source: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+				},
+			},
+		},
+		"error-binary-protected": {
+			mockLatest:   model.NewVersion("v1.1.0"),
+			callReadFile: true,
+			mockReadFile: []byte(`{"name":"mockproj","protected":true}`),
+			expectedErr:  manager.ErrBinaryProtected,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			rt := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo(binFullPath).Return(getBuildInfo("mockproj", "v1.0.0"), nil).Once()
+			fs.EXPECT().GetSymlinkTarget(binFullPath).
+				Return(filepath.Join(intBinPath, "mockproj@v1.0.0"), nil).
+				Once()
+			toolchain.EXPECT().
+				GetLatestModuleVersion(context.Background(), model.NewLatestModule("example.com/mockorg/mockproj")).
+				Return(model.NewModule("example.com/mockorg/mockproj", tc.mockLatest), nil).
+				Once()
+
+			if tc.callReadFile {
+				readFileErr := os.ErrNotExist
+				if tc.mockReadFile != nil {
+					readFileErr = nil
+				}
+
+				fs.EXPECT().ReadFile(filepath.Join(receiptPath, "mockproj.json")).
+					Return(tc.mockReadFile, readFileErr).
+					Once()
+			}
+
+			if tc.expectedActions != nil {
+				rt.EXPECT().OS().Return("linux").Once()
+				fs.EXPECT().Exists(binFullPath).Return(true).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, rt, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			actions, err := binaryManager.PlanUpgradeBinary(
+				context.Background(), binFullPath, tc.flags, false, tc.rebuild, false,
+			)
+			assert.Equal(t, tc.expectedActions, actions)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_ProtectBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+
+	cases := map[string]struct {
+		bin                  model.Binary
+		protected            bool
+		mockGetBuildInfo     *buildinfo.BuildInfo
+		mockGetBuildInfoErr  error
+		callGetSymlinkTarget bool
+		mockGetSymlinkTarget string
+		callReadFile         bool
+		mockReadFile         []byte
+		mockReadFileErr      error
+		callWriteFile        bool
+		mockWriteFileData    []byte
+		mockWriteFileErr     error
+		expectedErr          error
+	}{
+		"success-protect": {
+			bin:                  model.NewBinaryFromString("mockproj"),
+			protected:            true,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrNotExist,
+			callWriteFile:        true,
+			mockWriteFileData:    []byte("{\n  \"name\": \"mockproj\",\n  \"protected\": true\n}"),
+		},
+		"success-unprotect": {
+			bin:                  model.NewBinaryFromString("mockproj"),
+			protected:            false,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callReadFile:         true,
+			mockReadFile:         []byte(`{"name":"mockproj","protected":true}`),
+			callWriteFile:        true,
+			mockWriteFileData:    []byte("{\n  \"name\": \"mockproj\"\n}"),
+		},
+		"error-get-binary-info": {
+			bin:                 model.NewBinaryFromString("mockproj"),
+			protected:           true,
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-read-receipt": {
+			bin:                  model.NewBinaryFromString("mockproj"),
+			protected:            true,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrPermission,
+			expectedErr:          os.ErrPermission,
+		},
+		"error-write-receipt": {
+			bin:                  model.NewBinaryFromString("mockproj"),
+			protected:            true,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrNotExist,
+			callWriteFile:        true,
+			mockWriteFileData:    []byte("{\n  \"name\": \"mockproj\",\n  \"protected\": true\n}"),
+			mockWriteFileErr:     errors.New("unexpected error"),
+			expectedErr:          errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo(filepath.Join(goBinPath, tc.bin.String())).
+				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callGetSymlinkTarget {
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, tc.bin.String())).
+					Return(tc.mockGetSymlinkTarget, nil).
+					Once()
+			}
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(tc.mockReadFile, tc.mockReadFileErr).
+					Once()
+			}
+
+			if tc.callWriteFile {
+				fs.EXPECT().WriteFile(receiptPath, tc.mockWriteFileData, os.FileMode(0600)).
+					Return(tc.mockWriteFileErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.ProtectBinary(tc.bin, tc.protected)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_PruneBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := workspace.GetInternalReceiptPath()
+
+	cases := map[string]struct {
+		bin                       model.Binary
+		force                     bool
+		mockReadFileCalls         []mockReadFileCall
+		mockListBinariesCalls     []mockListBinariesCall
+		mockGetBuildInfoCalls     []mockGetBuildInfoCall
+		mockGetSymlinkTargetCalls []mockGetSymlinkTargetCall
+		mockRemoveCalls           []mockRemoveCall
+		expectedErr               error
+	}{
+		"success-no-binaries-to-prune": {
+			bin: model.NewBinaryFromString("mockproj"),
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj.json"),
+					err:  os.ErrNotExist,
+				},
+			},
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: intBinPath,
+					binaries: []string{
+						filepath.Join(intBinPath, "mockproj1@v1.2.0"),
+						filepath.Join(intBinPath, "mockproj2@v2.0.0"),
+						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					},
+				},
+			},
+		},
+		"success-binaries-to-prune": {
+			bin: model.NewBinaryFromString("mockproj2@v2"),
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj2.json"),
+					err:  os.ErrNotExist,
+				},
+			},
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: intBinPath,
+					binaries: []string{
+						filepath.Join(intBinPath, "mockproj2@v1.8.0"),
+						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					},
+				},
+				{
+					path: goBinPath,
+					binaries: []string{
+						filepath.Join(goBinPath, "mockproj2"),
+					},
+				},
+			},
+			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
+				{
+					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					info: getBuildInfo("mockproj2@v2", "v2.1.0"),
+				},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{
+					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					err:  os.ErrNotExist,
+				},
+				{
+					path:   filepath.Join(goBinPath, "mockproj2"),
+					target: filepath.Join(intBinPath, "mockproj2@v1.8.0"),
+				},
+			},
+			mockRemoveCalls: []mockRemoveCall{
+				{
+					bin: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+				},
+			},
+		},
+		"success-skip-pinned-binary": {
+			bin: model.NewBinaryFromString("mockproj2"),
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj2.json"),
+					err:  os.ErrNotExist,
+				},
+			},
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: intBinPath,
+					binaries: []string{
+						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					},
+				},
+				{
+					path: goBinPath,
+					binaries: []string{
+						filepath.Join(goBinPath, "mockproj2"),
+					},
+				},
+			},
+			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
+				{
+					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					info: getBuildInfo("mockproj2@v2", "v2.1.0"),
+				},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{
+					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					err:  os.ErrNotExist,
+				},
+				{
+					path:   filepath.Join(goBinPath, "mockproj2"),
+					target: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+				},
+			},
+		},
+		"error-list-binaries": {
+			bin: model.NewBinaryFromString("mockproj2@v2"),
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj2.json"),
+					err:  os.ErrNotExist,
+				},
+			},
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: intBinPath,
+					err:  os.ErrNotExist,
+				},
+			},
+			expectedErr: os.ErrNotExist,
+		},
+		"error-get-binary-info": {
+			bin: model.NewBinaryFromString("mockproj2"),
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj2.json"),
+					err:  os.ErrNotExist,
+				},
+			},
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: intBinPath,
+					binaries: []string{
+						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					},
+				},
+			},
+			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
+				{
+					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					err:  toolchain.ErrBinaryBuiltWithoutGoModules,
+				},
+			},
+			expectedErr: toolchain.ErrBinaryBuiltWithoutGoModules,
+		},
+		"error-remove-binary": {
+			bin: model.NewBinaryFromString("mockproj2@v2"),
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj2.json"),
+					err:  os.ErrNotExist,
+				},
+			},
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: intBinPath,
+					binaries: []string{
+						filepath.Join(intBinPath, "mockproj2@v1.8.0"),
+						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					},
+				},
+				{
+					path: goBinPath,
+					binaries: []string{
+						filepath.Join(goBinPath, "mockproj2"),
+					},
+				},
+			},
+			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
+				{
+					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					info: getBuildInfo("mockproj2@v2", "v2.1.0"),
+				},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{
+					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					err:  os.ErrNotExist,
+				},
+				{
+					path:   filepath.Join(goBinPath, "mockproj2"),
+					target: filepath.Join(intBinPath, "mockproj2@v1.8.0"),
+				},
+			},
+			mockRemoveCalls: []mockRemoveCall{
+				{
+					bin: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+					err: errors.New("unexpected error"),
+				},
+			},
+			expectedErr: errors.New("unexpected error"),
+		},
+		"success-force-protected-binary": {
+			bin:   model.NewBinaryFromString("mockproj2"),
+			force: true,
+			mockListBinariesCalls: []mockListBinariesCall{
+				{
+					path: intBinPath,
+					binaries: []string{
+						filepath.Join(intBinPath, "mockproj1@v1.2.0"),
+					},
+				},
+			},
+		},
+		"error-binary-protected": {
+			bin: model.NewBinaryFromString("mockproj2"),
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj2.json"),
+					data: []byte(`{"name":"mockproj2","protected":true}`),
+				},
+			},
+			expectedErr: manager.ErrBinaryProtected,
+		},
+		"error-binary-protected-with-extension": {
+			bin: model.NewBinaryFromString("mockproj2.exe@v2"),
+			mockReadFileCalls: []mockReadFileCall{
+				{
+					path: filepath.Join(receiptPath, "mockproj2.exe.json"),
+					data: []byte(`{"name":"mockproj2.exe","protected":true}`),
+				},
+			},
+			expectedErr: manager.ErrBinaryProtected,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			for _, call := range tc.mockReadFileCalls {
+				fs.EXPECT().ReadFile(call.path).
+					Return(call.data, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockListBinariesCalls {
+				fs.EXPECT().ListBinaries(call.path).
+					Return(call.binaries, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockGetBuildInfoCalls {
+				toolchain.EXPECT().GetBuildInfo(call.path).
+					Return(call.info, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockGetSymlinkTargetCalls {
+				fs.EXPECT().GetSymlinkTarget(call.path).
+					Return(call.target, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockRemoveCalls {
+				fs.EXPECT().Remove(call.bin).
+					Return(call.err).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.PruneBinary(tc.bin, tc.force)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_PullBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	tempPath := workspace.GetInternalTempPath()
+	binTempDir := filepath.Join(tempPath, "mockproj-123")
+	tempBinPath := filepath.Join(binTempDir, "mockproj@v0.1.0")
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+	ref := model.OCIReference{Registry: "ghcr.io", Repository: "mockorg/tools", Tag: "mockproj"}
+
+	annotations := map[string]string{
+		"org.opencontainers.image.title":   "mockproj",
+		"org.opencontainers.image.version": "v0.1.0",
+		"dev.gobin.module":                 "example.com/mockorg/mockproj",
+		"dev.gobin.platform":               "linux/amd64",
+		"dev.gobin.build-flags":            `{"cgo_enabled":"1"}`,
+	}
+
+	cases := map[string]struct {
+		mockAnnotations  map[string]string
+		mockPullErr      error
+		callPlatform     bool
+		callInstall      bool
+		mockGetBuildInfo *buildinfo.BuildInfo
+		callPin          bool
+		callRecordFlags  bool
+		expectedBin      model.Binary
+		expectedErr      error
+	}{
+		"success": {
+			mockAnnotations:  annotations,
+			callPlatform:     true,
+			callInstall:      true,
+			mockGetBuildInfo: getBuildInfo("mockproj", "v0.1.0"),
+			callPin:          true,
+			callRecordFlags:  true,
+			expectedBin:      model.NewBinaryFromString("mockproj@v0.1.0"),
+		},
+		"success-without-build-flags": {
+			mockAnnotations: map[string]string{
+				"org.opencontainers.image.title":   "mockproj",
+				"org.opencontainers.image.version": "v0.1.0",
+				"dev.gobin.module":                 "example.com/mockorg/mockproj",
+				"dev.gobin.platform":               "linux/amd64",
+			},
+			callPlatform:     true,
+			callInstall:      true,
+			mockGetBuildInfo: getBuildInfo("mockproj", "v0.1.0"),
+			callPin:          true,
+			expectedBin:      model.NewBinaryFromString("mockproj@v0.1.0"),
+		},
+		"error-pull": {
+			mockPullErr: registry.ErrArtifactNotFound,
+			expectedErr: registry.ErrArtifactNotFound,
+		},
+		"error-missing-annotations": {
+			mockAnnotations: map[string]string{"dev.gobin.platform": "linux/amd64"},
+			expectedErr:     registry.ErrArtifactInvalid,
+		},
+		"error-platform-mismatch": {
+			mockAnnotations: map[string]string{
+				"org.opencontainers.image.title":   "mockproj",
+				"org.opencontainers.image.version": "v0.1.0",
+				"dev.gobin.platform":               "darwin/arm64",
+			},
+			callPlatform: true,
+			expectedErr:  manager.ErrPlatformMismatch,
+		},
+		"error-build-info-mismatch": {
+			mockAnnotations:  annotations,
+			callPlatform:     true,
+			callInstall:      true,
+			mockGetBuildInfo: getBuildInfo("mockproj", "v0.2.0"),
+			expectedErr:      registry.ErrArtifactInvalid,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			reg := registrymocks.NewRegistry(t)
+			rt := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			reg.EXPECT().Pull(mock.Anything, ref).
+				Return(model.OCIArtifact{Data: []byte("binary"), Annotations: tc.mockAnnotations}, tc.mockPullErr).
+				Once()
+
+			if tc.callPlatform {
+				rt.EXPECT().Platform().Return("linux/amd64").Once()
+			}
+
+			if tc.callInstall {
+				rt.EXPECT().OS().Return("linux").Once()
+
+				fs.EXPECT().CreateTempDir(tempPath, "mockproj-*").
+					Return(binTempDir, func() error { return nil }, nil).
+					Once()
+
+				fs.EXPECT().WriteFile(tempBinPath, []byte("binary"), os.FileMode(0755)).
+					Return(nil).
+					Once()
+
+				toolchain.EXPECT().GetBuildInfo(tempBinPath).
+					Return(tc.mockGetBuildInfo, nil).
+					Once()
+			}
+
+			if tc.callPin {
+				fs.EXPECT().Move(tempBinPath, filepath.Join(intBinPath, "mockproj@v0.1.0")).
+					Return(nil).
+					Once()
+
+				fs.EXPECT().ListBinaries(intBinPath).
+					Return([]string{filepath.Join(intBinPath, "mockproj@v0.1.0")}, nil).
+					Once()
+
+				rt.EXPECT().OS().Return("linux").Once()
+
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+					Return("", os.ErrNotExist).
+					Once()
+
+				fs.EXPECT().ReplaceSymlink(
+					filepath.Join(intBinPath, "mockproj@v0.1.0"), filepath.Join(goBinPath, "mockproj"),
+				).
+					Return(nil).
+					Once()
+			}
+
+			if tc.callRecordFlags {
+				fs.EXPECT().ReadFile(receiptPath).Return(nil, os.ErrNotExist).Once()
+				fs.EXPECT().WriteFile(
+					receiptPath,
+					[]byte("{\n  \"name\": \"mockproj\",\n  \"build_flags\": {\n    \"cgo_enabled\": \"1\"\n  }\n}"),
+					os.FileMode(0600),
+				).
+					Return(nil).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, reg, rt, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			bin, err := binaryManager.PullBinary(context.Background(), ref, model.KindLatest)
+			assert.Equal(t, tc.expectedBin, bin)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
 }
 
-func TestGoBinaryManager_GetAllBinaryInfos(t *testing.T) {
+func TestGoBinaryManager_PushBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	binPath := filepath.Join(workspace.GetGoBinPath(), "mockproj")
+	intBinPath := filepath.Join(workspace.GetInternalBinPath(), "mockproj@v0.1.0")
+	ref := model.OCIReference{Registry: "ghcr.io", Repository: "mockorg/tools", Tag: "mockproj"}
+
+	artifact := model.OCIArtifact{
+		Data: []byte("binary"),
+		Annotations: map[string]string{
+			"dev.gobin.build-flags":            `{"cgo_enabled":"1","goarm64":"v8.0"}`,
+			"dev.gobin.go-version":             "go1.24.5",
+			"dev.gobin.module":                 "example.com/mockorg/mockproj",
+			"dev.gobin.module-sum":             "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+			"dev.gobin.package":                "example.com/mockorg/mockproj/cmd/mockproj",
+			"dev.gobin.platform":               "darwin/arm64",
+			"org.opencontainers.image.title":   "mockproj",
+			"org.opencontainers.image.version": "v0.1.0",
+		},
+	}
+
+	cases := map[string]struct {
+		mockGetBuildInfoErr     error
+		mockGetSymlinkTarget    string
+		mockGetSymlinkTargetErr error
+		callReadFile            bool
+		mockReadFileErr         error
+		callPush                bool
+		mockPushErr             error
+		expectedErr             error
+	}{
+		"success": {
+			mockGetSymlinkTarget: intBinPath,
+			callReadFile:         true,
+			callPush:             true,
+		},
+		"error-get-binary-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-binary-not-managed": {
+			mockGetSymlinkTargetErr: os.ErrInvalid,
+			expectedErr:             manager.ErrBinaryNotManaged,
+		},
+		"error-read-file": {
+			mockGetSymlinkTarget: intBinPath,
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrPermission,
+			expectedErr:          os.ErrPermission,
+		},
+		"error-push": {
+			mockGetSymlinkTarget: intBinPath,
+			callReadFile:         true,
+			callPush:             true,
+			mockPushErr:          registry.ErrUnauthorized,
+			expectedErr:          registry.ErrUnauthorized,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			reg := registrymocks.NewRegistry(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			var mockGetBuildInfo *buildinfo.BuildInfo
+			if tc.mockGetBuildInfoErr == nil {
+				mockGetBuildInfo = getBuildInfo("mockproj", "v0.1.0")
+			}
+
+			toolchain.EXPECT().GetBuildInfo(binPath).
+				Return(mockGetBuildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.mockGetBuildInfoErr == nil {
+				fs.EXPECT().GetSymlinkTarget(binPath).
+					Return(tc.mockGetSymlinkTarget, tc.mockGetSymlinkTargetErr).
+					Once()
+			}
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(intBinPath).
+					Return([]byte("binary"), tc.mockReadFileErr).
+					Once()
+			}
+
+			if tc.callPush {
+				reg.EXPECT().Push(mock.Anything, ref, artifact).
+					Return(tc.mockPushErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, reg, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.PushBinary(context.Background(), binPath, ref)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_RebuildBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	binPath := filepath.Join(workspace.GetGoBinPath(), "mockproj")
+	intBinPath := filepath.Join(workspace.GetInternalBinPath(), "mockproj@v0.1.0")
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+	tempPath := workspace.GetInternalTempPath()
+	binTempDir := filepath.Join(tempPath, "mockproj-123")
+	pkg := model.NewPackageWithVersion("example.com/mockorg/mockproj/cmd/mockproj", model.NewVersion("v0.1.0"))
+
+	cases := map[string]struct {
+		mockGetBuildInfoErr error
+		callReadFile        bool
+		mockReadFile        []byte
+		callInstall         bool
+		mockInstallErr      error
+		expectedErr         error
+	}{
+		"error-get-binary-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-binary-protected": {
+			callReadFile: true,
+			mockReadFile: []byte(`{"name":"mockproj","protected":true}`),
+			expectedErr:  manager.ErrBinaryProtected,
+		},
+		"error-install": {
+			callReadFile:   true,
+			mockReadFile:   []byte(`{"name":"mockproj","build_flags":{"tags":"netgo"}}`),
+			callInstall:    true,
+			mockInstallErr: toolchain.ErrBuildFailed,
+			expectedErr:    toolchain.ErrBuildFailed,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo(binPath).
+				Return(getBuildInfo("mockproj", "v0.1.0"), tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callReadFile {
+				fs.EXPECT().GetSymlinkTarget(binPath).Return(intBinPath, nil).Once()
+				fs.EXPECT().ReadFile(receiptPath).Return(tc.mockReadFile, nil).Once()
+			}
+
+			if tc.callInstall {
+				fs.EXPECT().CreateTempDir(tempPath, "mockproj-*").
+					Return(binTempDir, func() error { return nil }, nil).
+					Once()
+				toolchain.EXPECT().Install(mock.Anything, binTempDir, pkg, model.BuildFlags{Tags: "netgo"}, true).
+					Return(tc.mockInstallErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.RebuildBinary(context.Background(), binPath)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_RelinkBinary(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
 
 	goBinPath := workspace.GetGoBinPath()
 	intBinPath := workspace.GetInternalBinPath()
+	tempPath := workspace.GetInternalTempPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+	binTempDir := filepath.Join(tempPath, "local-0123456789")
 
 	cases := map[string]struct {
-		managed                   bool
-		mockListBinariesCalls     []mockListBinariesCall
-		mockGetBuildInfoCalls     []mockGetBuildInfoCall
-		mockGetSymlinkTargetCalls []mockGetSymlinkTargetCall
-		expectedInfos             []model.BinaryInfo
-		expectedErr               error
+		mockGetBuildInfoErr error
+		callReadFile        bool
+		mockReadFile        []byte
+		callInstallLocal    bool
+		mockInstallLocalErr error
+		expectedErr         error
 	}{
-		"success-go-bin-path-binaries": {
-			managed: false,
-			mockListBinariesCalls: []mockListBinariesCall{
-				{
-					path:     goBinPath,
-					binaries: []string{filepath.Join(goBinPath, "bin1"), filepath.Join(goBinPath, "bin2")},
-				},
-			},
-			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
-				{path: filepath.Join(goBinPath, "bin1"), info: getBuildInfo("bin1", "v0.1.0")},
-				{path: filepath.Join(goBinPath, "bin2"), info: getBuildInfo("bin2", "v0.1.0")},
-			},
-			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{path: filepath.Join(goBinPath, "bin1"), target: filepath.Join(intBinPath, "bin1@v0.1.0")},
-				{path: filepath.Join(goBinPath, "bin2"), err: os.ErrNotExist},
-			},
-			expectedInfos: []model.BinaryInfo{
-				getBinaryInfo(workspace, "bin1", "v0.1.0", false, true, false),
-				getBinaryInfo(workspace, "bin2", "v0.1.0", false, false, false),
-			},
-		},
-		"success-internal-bin-path-binaries": {
-			managed: true,
-			mockListBinariesCalls: []mockListBinariesCall{
-				{
-					path: intBinPath,
-					binaries: []string{
-						filepath.Join(intBinPath, "bin1@v0.1.0"),
-						filepath.Join(intBinPath, "bin2@v0.1.0"),
-					},
-				},
-				{
-					path: goBinPath,
-					binaries: []string{
-						filepath.Join(goBinPath, "bin1"),
-						filepath.Join(goBinPath, "bin2"),
-					},
-				},
-				{
-					path: goBinPath,
-					binaries: []string{
-						filepath.Join(goBinPath, "bin1"),
-						filepath.Join(goBinPath, "bin2"),
-					},
-				},
-			},
-			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
-				{
-					path: filepath.Join(intBinPath, "bin1@v0.1.0"),
-					info: getBuildInfo("bin1", "v0.1.0"),
-				},
-				{
-					path: filepath.Join(intBinPath, "bin2@v0.1.0"),
-					info: getBuildInfo("bin2", "v0.1.0"),
-				},
-			},
-			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{path: filepath.Join(intBinPath, "bin1@v0.1.0"), err: os.ErrNotExist},
-				{path: filepath.Join(intBinPath, "bin2@v0.1.0"), err: os.ErrNotExist},
-				{path: filepath.Join(goBinPath, "bin1"), err: os.ErrNotExist},
-				{path: filepath.Join(goBinPath, "bin2"), target: filepath.Join(intBinPath, "bin2@v0.1.0")},
-				{path: filepath.Join(goBinPath, "bin1"), err: os.ErrNotExist},
-				{path: filepath.Join(goBinPath, "bin2"), target: filepath.Join(intBinPath, "bin2@v0.1.0")},
-			},
-			expectedInfos: []model.BinaryInfo{
-				getBinaryInfo(workspace, "bin1", "v0.1.0", true, true, false),
-				getBinaryInfo(workspace, "bin2", "v0.1.0", true, true, true),
-			},
+		"error-get-binary-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
 		},
-		"success-skip-get-binary-info-error": {
-			managed: false,
-			mockListBinariesCalls: []mockListBinariesCall{
-				{
-					path:     goBinPath,
-					binaries: []string{filepath.Join(goBinPath, "bin1"), filepath.Join(goBinPath, "bin2")},
-				},
-			},
-			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
-				{
-					path: filepath.Join(goBinPath, "bin1"),
-					info: getBuildInfo("bin1", "v0.1.0"),
-				},
-				{
-					path: filepath.Join(goBinPath, "bin2"),
-					err:  toolchain.ErrBinaryBuiltWithoutGoModules,
-				},
-			},
-			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{path: filepath.Join(goBinPath, "bin1"), target: filepath.Join(intBinPath, "bin1@v0.1.0")},
-			},
-			expectedInfos: []model.BinaryInfo{
-				getBinaryInfo(workspace, "bin1", "v0.1.0", false, true, false),
-			},
+		"error-binary-not-linked": {
+			callReadFile: true,
+			mockReadFile: []byte(`{"name":"mockproj"}`),
+			expectedErr:  manager.ErrBinaryNotLinked,
 		},
-		"error-list-binaries": {
-			managed: false,
-			mockListBinariesCalls: []mockListBinariesCall{
-				{
-					path: goBinPath,
-					err:  os.ErrNotExist,
-				},
-			},
-			expectedErr: os.ErrNotExist,
+		"error-install-local": {
+			callReadFile: true,
+			mockReadFile: []byte(
+				`{"name":"mockproj","build_flags":{"tags":"netgo"},"source":"/home/user/src/mockproj"}`,
+			),
+			callInstallLocal:    true,
+			mockInstallLocalErr: toolchain.ErrBuildFailed,
+			expectedErr:         toolchain.ErrBuildFailed,
 		},
 	}
 
@@ -526,526 +7638,435 @@ func TestGoBinaryManager_GetAllBinaryInfos(t *testing.T) {
 			fs := systemmocks.NewFileSystem(t)
 			toolchain := toolchainmocks.NewToolchain(t)
 
-			for _, call := range tc.mockListBinariesCalls {
-				fs.EXPECT().ListBinaries(call.path).
-					Return(call.binaries, call.err).
+			toolchain.EXPECT().GetBuildInfo(filepath.Join(goBinPath, "mockproj")).
+				Return(getBuildInfo("mockproj", "(devel)"), tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callReadFile {
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+					Return(filepath.Join(intBinPath, "mockproj@(devel)"), nil).
+					Once()
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(tc.mockReadFile, nil).
 					Once()
 			}
 
-			for _, call := range tc.mockGetBuildInfoCalls {
-				toolchain.EXPECT().GetBuildInfo(call.path).
-					Return(call.info, call.err).
-					Once()
-			}
+			if tc.callInstallLocal {
+				fs.EXPECT().CreateTempDir(tempPath, "local-*").
+					Return(binTempDir, func() error { return nil }, nil).
+					Once()
+				toolchain.EXPECT().InstallLocal(
+					mock.Anything,
+					binTempDir,
+					"/home/user/src/mockproj",
+					model.BuildFlags{Tags: "netgo", Compress: true},
+				).Return(tc.mockInstallLocalErr).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.RelinkBinary(
+				context.Background(), model.NewBinaryFromString("mockproj"), model.BuildFlags{Compress: true},
+			)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_RemoveStaleExecutable(t *testing.T) {
+	cases := map[string]struct {
+		mockExists    bool
+		callRemove    bool
+		mockRemoveErr error
+		expectedErr   error
+	}{
+		"success": {
+			mockExists: true,
+			callRemove: true,
+		},
+		"success-no-stale-executable": {},
+		"error-remove": {
+			mockExists:    true,
+			callRemove:    true,
+			mockRemoveErr: errors.New("unexpected error"),
+			expectedErr:   errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
 
-			for _, call := range tc.mockGetSymlinkTargetCalls {
-				fs.EXPECT().GetSymlinkTarget(call.path).
-					Return(call.target, call.err).
-					Once()
+			fs.EXPECT().Exists("/usr/local/bin/gobin.exe.old").Return(tc.mockExists).Once()
+
+			if tc.callRemove {
+				fs.EXPECT().Remove("/usr/local/bin/gobin.exe.old").Return(tc.mockRemoveErr).Once()
 			}
 
-			binaryManager := manager.NewGoBinaryManager(fs, nil, toolchain, workspace)
-			infos, infosErr := binaryManager.GetAllBinaryInfos(tc.managed)
-			assert.Equal(t, tc.expectedInfos, infos)
-			assert.Equal(t, tc.expectedErr, infosErr)
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, nil, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err := binaryManager.RemoveStaleExecutable("/usr/local/bin/gobin.exe")
+			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
 
-func TestGoBinaryManager_GetBinaryInfo(t *testing.T) {
+func TestGoBinaryManager_RepinBinary(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
 
 	goBinPath := workspace.GetGoBinPath()
 	intBinPath := workspace.GetInternalBinPath()
+	pinPath := filepath.Join(goBinPath, "mockproj2-v1")
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj2-v1.json")
 
 	cases := map[string]struct {
-		path                      string
-		mockGetBuildInfo          *buildinfo.BuildInfo
-		mockGetBuildInfoErr       error
-		mockGetSymlinkTargetCalls []mockGetSymlinkTargetCall
-		callListBinaries          bool
-		mockListBinaries          []string
-		mockListBinariesErr       error
-		expectedInfo              model.BinaryInfo
-		expectedErr               error
+		mockListBinaries []string
+		callReplacePin   bool
+		expectedErr      error
 	}{
-		"success-base-info-unmanaged-binary": {
-			path: filepath.Join(goBinPath, "mockproj"),
-			mockGetBuildInfo: &buildinfo.BuildInfo{
-				Path: "example.com/mockorg/mockproj/cmd/mockproj",
-				Main: debug.Module{
-					Path:    "example.com/mockorg/mockproj",
-					Version: "v0.1.0",
-					Sum:     "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
-				},
-				GoVersion: "go1.24.5",
-				Settings: []debug.BuildSetting{
-					{Key: "GOOS", Value: "darwin"},
-					{Key: "GOARCH", Value: "arm64"},
-					{Key: "GOARM64", Value: "v8.0"},
-					{Key: "CGO_ENABLED", Value: "1"},
-				},
-			},
-			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{path: filepath.Join(goBinPath, "mockproj"), err: os.ErrInvalid},
-			},
-			expectedInfo: model.BinaryInfo{
-				Binary:      model.NewBinaryFromString("mockproj"),
-				FullPath:    filepath.Join(goBinPath, "mockproj"),
-				InstallPath: filepath.Join(goBinPath, "mockproj"),
-				PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
-				Module:      model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
-				ModuleSum:   "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
-				GoVersion:   "go1.24.5",
-				OS:          "darwin",
-				Arch:        "arm64",
-				Feature:     "v8.0",
-				EnvVars:     []string{"CGO_ENABLED=1"},
-				IsManaged:   false,
-			},
-		},
-		"success-base-info-managed-binary": {
-			path: filepath.Join(intBinPath, "mockproj@v0.1.0"),
-			mockGetBuildInfo: &buildinfo.BuildInfo{
-				Path: "example.com/mockorg/mockproj/cmd/mockproj",
-				Main: debug.Module{
-					Path:    "example.com/mockorg/mockproj",
-					Version: "v0.1.0",
-					Sum:     "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
-				},
-				GoVersion: "go1.24.5",
-				Settings: []debug.BuildSetting{
-					{Key: "GOOS", Value: "darwin"},
-					{Key: "GOARCH", Value: "arm64"},
-					{Key: "GOARM64", Value: "v8.0"},
-					{Key: "CGO_ENABLED", Value: "1"},
-				},
-			},
-			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{path: filepath.Join(intBinPath, "mockproj@v0.1.0"), err: os.ErrInvalid},
-				{path: filepath.Join(goBinPath, "mockproj"), err: os.ErrNotExist},
-				{path: filepath.Join(goBinPath, "mockproj-v0"), target: filepath.Join(intBinPath, "mockproj@v0.1.0")},
-			},
-			callListBinaries: true,
+		"success": {
 			mockListBinaries: []string{
-				filepath.Join(goBinPath, "mockproj"),
-				filepath.Join(goBinPath, "mockproj-v0"),
-			},
-			expectedInfo: model.BinaryInfo{
-				Binary:      model.NewBinary("mockproj", model.NewVersion("v0.1.0"), ""),
-				FullPath:    filepath.Join(intBinPath, "mockproj@v0.1.0"),
-				InstallPath: filepath.Join(intBinPath, "mockproj@v0.1.0"),
-				PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
-				Module:      model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
-				ModuleSum:   "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
-				GoVersion:   "go1.24.5",
-				OS:          "darwin",
-				Arch:        "arm64",
-				Feature:     "v8.0",
-				EnvVars:     []string{"CGO_ENABLED=1"},
-				IsManaged:   true,
-				IsPinned:    true,
-			},
-		},
-		"success-all-info": {
-			path: filepath.Join(goBinPath, "mockproj"),
-			mockGetBuildInfo: &buildinfo.BuildInfo{
-				Path: "example.com/mockorg/mockproj/cmd/mockproj",
-				Main: debug.Module{
-					Path:    "example.com/mockorg/mockproj",
-					Version: "v0.1.2-0.20250729191454-dac745d99aac",
-				},
-				GoVersion: "go1.24.5",
-				Settings: []debug.BuildSetting{
-					{Key: "vcs.revision", Value: "dac745d99aacf872dd3232e7eceab0f9047051da"},
-					{Key: "vcs.time", Value: "2025-07-29T19:14:54Z"},
-					{Key: "GOOS", Value: "darwin"},
-					{Key: "GOARCH", Value: "arm64"},
-					{Key: "GOARM64", Value: "v8.0"},
-					{Key: "CGO_ENABLED", Value: "1"},
-				},
-			},
-			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{
-					path:   filepath.Join(goBinPath, "mockproj"),
-					target: filepath.Join(intBinPath, "mockproj@v0.1.2-0.20250729191454-dac745d99aac"),
-				},
-			},
-			expectedInfo: model.BinaryInfo{
-				Binary:      model.NewBinaryFromString("mockproj"),
-				FullPath:    filepath.Join(goBinPath, "mockproj"),
-				InstallPath: filepath.Join(intBinPath, "mockproj@v0.1.2-0.20250729191454-dac745d99aac"),
-				PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
-				Module: model.NewModule(
-					"example.com/mockorg/mockproj",
-					model.NewVersion("v0.1.2-0.20250729191454-dac745d99aac"),
-				),
-				GoVersion:      "go1.24.5",
-				CommitRevision: "dac745d99aacf872dd3232e7eceab0f9047051da",
-				CommitTime:     "2025-07-29T19:14:54Z",
-				OS:             "darwin",
-				Arch:           "arm64",
-				Feature:        "v8.0",
-				EnvVars:        []string{"CGO_ENABLED=1"},
-				IsManaged:      true,
-				IsPinned:       false,
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
 			},
+			callReplacePin: true,
 		},
-		"error-get-build-info": {
-			path:                filepath.Join(goBinPath, "mockproj"),
-			mockGetBuildInfoErr: toolchain.ErrBinaryBuiltWithoutGoModules,
-			expectedErr:         toolchain.ErrBinaryBuiltWithoutGoModules,
-		},
-		"error-list-binaries": {
-			path: filepath.Join(intBinPath, "mockproj@v0.1.0"),
-			mockGetBuildInfo: &buildinfo.BuildInfo{
-				Path: "example.com/mockorg/mockproj/cmd/mockproj",
-				Main: debug.Module{
-					Path:    "example.com/mockorg/mockproj",
-					Version: "v0.1.0",
-					Sum:     "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
-				},
-				GoVersion: "go1.24.5",
-				Settings: []debug.BuildSetting{
-					{Key: "GOOS", Value: "darwin"},
-					{Key: "GOARCH", Value: "arm64"},
-					{Key: "GOARM64", Value: "v8.0"},
-					{Key: "CGO_ENABLED", Value: "1"},
-				},
-			},
-			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{path: filepath.Join(intBinPath, "mockproj@v0.1.0"), err: os.ErrInvalid},
+		"error-binary-not-found": {
+			mockListBinaries: []string{
+				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
 			},
-			callListBinaries:    true,
-			mockListBinariesErr: os.ErrNotExist,
-			expectedErr:         os.ErrNotExist,
+			expectedErr: toolchain.ErrBinaryNotFound,
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			fs := systemmocks.NewFileSystem(t)
-			toolchain := toolchainmocks.NewToolchain(t)
+			rt := systemmocks.NewRuntime(t)
 
-			toolchain.EXPECT().GetBuildInfo(tc.path).
-				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).Once()
+			fs.EXPECT().ListBinaries(intBinPath).Return(tc.mockListBinaries, nil).Once()
 
-			for _, call := range tc.mockGetSymlinkTargetCalls {
-				fs.EXPECT().GetSymlinkTarget(call.path).
-					Return(call.target, call.err).
+			if tc.callReplacePin {
+				rt.EXPECT().OS().Return("linux").Once()
+				fs.EXPECT().GetSymlinkTarget(pinPath).
+					Return(filepath.Join(intBinPath, "mockproj2@v1.2.0"), nil).
 					Once()
-			}
-
-			if tc.callListBinaries {
-				fs.EXPECT().ListBinaries(goBinPath).
-					Return(tc.mockListBinaries, tc.mockListBinariesErr).
+				fs.EXPECT().ReadFile(receiptPath).Return(nil, os.ErrNotExist).Once()
+				fs.EXPECT().WriteFile(
+					receiptPath,
+					[]byte("{\n  \"name\": \"mockproj2-v1\",\n  \"previous_version\": \"v1.2.0\"\n}"),
+					os.FileMode(0600),
+				).Return(nil).Once()
+				fs.EXPECT().ReplaceSymlink(filepath.Join(intBinPath, "mockproj2@v1.3.1"), pinPath).
+					Return(nil).
 					Once()
 			}
 
-			binaryManager := manager.NewGoBinaryManager(fs, nil, toolchain, workspace)
-			info, infoErr := binaryManager.GetBinaryInfo(tc.path)
-			assert.Equal(t, tc.expectedInfo, info)
-			assert.Equal(t, tc.expectedErr, infoErr)
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, rt, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.RepinBinary(pinPath)
+			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
 
-func TestGoBinaryManager_GetBinaryRepository(t *testing.T) {
+func TestGoBinaryManager_ReproduceBinary(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
 
-	goBinPath := workspace.GetGoBinPath()
-	intBinPath := workspace.GetInternalBinPath()
+	binPath := filepath.Join(workspace.GetGoBinPath(), "mockproj")
+	intBinPath := filepath.Join(workspace.GetInternalBinPath(), "mockproj@v0.1.0")
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+	tempPath := workspace.GetInternalTempPath()
+	reproduceDir := filepath.Join(tempPath, "reproduce-123")
+	binTempDir := filepath.Join(tempPath, "mockproj-123")
+	rebuiltPath := filepath.Join(reproduceDir, "mockproj_v0.1.0_darwin_arm64")
+	pkg := model.NewPackageWithVersion("example.com/mockorg/mockproj/cmd/mockproj", model.NewVersion("v0.1.0"))
+	flags := model.BuildFlags{CGOEnabled: "1", GOARM64: "v8.0"}
+
+	rebuiltBuildInfo := getBuildInfo("mockproj", "v0.1.0")
+	rebuiltBuildInfo.Settings = append(rebuiltBuildInfo.Settings, debug.BuildSetting{Key: "-trimpath", Value: "true"})
 
 	cases := map[string]struct {
-		binary                  model.Binary
-		mockGetBuildInfo        *buildinfo.BuildInfo
-		mockGetBuildInfoErr     error
-		callGetSymlinkTarget    bool
-		mockGetSymlinkTarget    string
-		mockGetSymlinkTargetErr error
-		callGetModuleOrigin     bool
-		mockGetModuleOrigin     *model.ModuleOrigin
-		mockGetModuleOriginErr  error
-		expectedRepository      string
-		expectedErr             error
+		mockGetSymlinkTarget string
+		callBuild            bool
+		mockInstallErr       error
+		mockRebuiltData      []byte
+		expected             model.BinaryReproduction
+		expectedErr          error
 	}{
-		"success-module-origin": {
-			binary:               model.NewBinaryFromString("mockproj"),
-			mockGetBuildInfo:     getBuildInfo("mockproj", "v0.1.0"),
-			callGetSymlinkTarget: true,
-			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
-			callGetModuleOrigin:  true,
-			mockGetModuleOrigin: &model.ModuleOrigin{
-				URL: "https://github.com/mockorg/mockproj",
+		"success-reproducible": {
+			mockGetSymlinkTarget: intBinPath,
+			callBuild:            true,
+			mockRebuiltData:      []byte("binary"),
+			expected: model.BinaryReproduction{
+				Name:          "mockproj@v0.1.0",
+				Package:       pkg,
+				GoVersion:     "go1.24.5",
+				InstalledHash: "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd",
+				RebuiltHash:   "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd",
 			},
-			expectedRepository: "https://github.com/mockorg/mockproj",
 		},
-		"success-module-origin-not-available": {
-			binary:                 model.NewBinaryFromString("mockproj"),
-			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
-			callGetSymlinkTarget:   true,
-			mockGetSymlinkTarget:   filepath.Join(intBinPath, "mockproj@v0.1.0"),
-			callGetModuleOrigin:    true,
-			mockGetModuleOriginErr: toolchain.ErrModuleOriginNotAvailable,
-			expectedRepository:     "https://example.com/mockorg/mockproj",
-		},
-		"success-module-not-found": {
-			binary:                 model.NewBinaryFromString("mockproj"),
-			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
-			callGetSymlinkTarget:   true,
-			mockGetSymlinkTarget:   filepath.Join(intBinPath, "mockproj@v0.1.0"),
-			callGetModuleOrigin:    true,
-			mockGetModuleOriginErr: toolchain.ErrModuleNotFound,
-			expectedRepository:     "https://example.com/mockorg/mockproj",
-		},
-		"error-get-binary-info": {
-			binary:              model.NewBinaryFromString("mockproj"),
-			mockGetBuildInfoErr: toolchain.ErrBinaryBuiltWithoutGoModules,
-			expectedErr:         toolchain.ErrBinaryBuiltWithoutGoModules,
-		},
-		"error-get-module-origin": {
-			binary:                 model.NewBinaryFromString("mockproj"),
-			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
-			callGetSymlinkTarget:   true,
-			mockGetSymlinkTarget:   filepath.Join(intBinPath, "mockproj@v0.1.0"),
-			callGetModuleOrigin:    true,
-			mockGetModuleOriginErr: errors.New("unexpected error"),
-			expectedErr:            errors.New("unexpected error"),
+		"success-not-reproducible": {
+			mockGetSymlinkTarget: intBinPath,
+			callBuild:            true,
+			mockRebuiltData:      []byte("rebuilt"),
+			expected: model.BinaryReproduction{
+				Name:          "mockproj@v0.1.0",
+				Package:       pkg,
+				GoVersion:     "go1.24.5",
+				InstalledHash: "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd",
+				RebuiltHash:   "9ae832844d70dd5a06b8c70f3f4f68bbe1c907b116d378c9654b217d4a7b6b4c",
+				Settings: []model.BuildChange{
+					{Key: "-trimpath", To: "true"},
+				},
+			},
+		},
+		"error-binary-not-managed": {
+			mockGetSymlinkTarget: "",
+			expectedErr:          manager.ErrBinaryNotManaged,
+		},
+		"error-build-package": {
+			mockGetSymlinkTarget: intBinPath,
+			callBuild:            true,
+			mockInstallErr:       toolchain.ErrBuildFailed,
+			expectedErr:          toolchain.ErrBuildFailed,
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			fs := systemmocks.NewFileSystem(t)
+			rt := systemmocks.NewRuntime(t)
 			toolchain := toolchainmocks.NewToolchain(t)
 
-			toolchain.EXPECT().
-				GetBuildInfo(filepath.Join(goBinPath, tc.binary.String())).
-				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
-				Once()
+			toolchain.EXPECT().GetBuildInfo(binPath).Return(getBuildInfo("mockproj", "v0.1.0"), nil).Once()
 
-			if tc.callGetSymlinkTarget {
-				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, tc.binary.String())).
-					Return(tc.mockGetSymlinkTarget, tc.mockGetSymlinkTargetErr).
+			if tc.mockGetSymlinkTarget == "" {
+				fs.EXPECT().GetSymlinkTarget(binPath).Return("", os.ErrInvalid).Once()
+			} else {
+				fs.EXPECT().GetSymlinkTarget(binPath).Return(tc.mockGetSymlinkTarget, nil).Once()
+				fs.EXPECT().ReadFile(receiptPath).Return(nil, os.ErrNotExist).Once()
+			}
+
+			if tc.callBuild {
+				fs.EXPECT().CreateTempDir(tempPath, "reproduce-*").
+					Return(reproduceDir, func() error { return nil }, nil).
+					Once()
+
+				fs.EXPECT().CreateTempDir(tempPath, "mockproj-*").
+					Return(binTempDir, func() error { return nil }, nil).
+					Once()
+
+				toolchain.EXPECT().InstallPlatform(mock.Anything, binTempDir, pkg, "darwin/arm64", flags, false).
+					Return(tc.mockInstallErr).
 					Once()
 			}
 
-			if tc.callGetModuleOrigin {
-				toolchain.EXPECT().GetModuleOrigin(
-					context.Background(),
-					model.NewModule(
-						tc.mockGetBuildInfo.Main.Path,
-						model.NewVersion(tc.mockGetBuildInfo.Main.Version),
-					),
-				).Return(tc.mockGetModuleOrigin, tc.mockGetModuleOriginErr).Once()
+			if tc.mockRebuiltData != nil {
+				rt.EXPECT().Platform().Return("darwin/arm64").Once()
+				fs.EXPECT().CreateDir(reproduceDir, os.FileMode(0755)).Return(nil).Once()
+				fs.EXPECT().Move(filepath.Join(binTempDir, "bin", "mockproj"), rebuiltPath).Return(nil).Once()
+				fs.EXPECT().ReadFile(intBinPath).Return([]byte("binary"), nil).Once()
+				fs.EXPECT().ReadFile(rebuiltPath).Return(tc.mockRebuiltData, nil).Once()
 			}
 
-			binaryManager := manager.NewGoBinaryManager(fs, nil, toolchain, workspace)
-			repository, repoErr := binaryManager.GetBinaryRepository(context.Background(), tc.binary)
-			assert.Equal(t, tc.expectedRepository, repository)
-			assert.Equal(t, tc.expectedErr, repoErr)
+			if len(tc.expected.Settings) > 0 {
+				toolchain.EXPECT().GetBuildInfo(intBinPath).Return(getBuildInfo("mockproj", "v0.1.0"), nil).Once()
+				fs.EXPECT().GetFileSize(intBinPath).Return(6, nil).Once()
+				toolchain.EXPECT().GetBuildInfo(rebuiltPath).Return(rebuiltBuildInfo, nil).Once()
+				fs.EXPECT().GetFileSize(rebuiltPath).Return(7, nil).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, rt, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			reproduction, err := binaryManager.ReproduceBinary(context.Background(), binPath)
+			assert.Equal(t, tc.expected, reproduction)
+			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
 
-func TestGoBinaryManager_GetBinaryUpgradeInfo(t *testing.T) {
-	workspace, err := system.NewWorkspace(
-		system.NewEnvironment(),
-		nil,
-		system.NewRuntime(),
-	)
-	require.NoError(t, err)
+func TestGoBinaryManager_ResolvePackage(t *testing.T) {
+	mainPkgInfo := model.PackageInfo{
+		Module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
+		Exists: true,
+		IsMain: true,
+	}
 
 	cases := map[string]struct {
-		info                            model.BinaryInfo
-		checkMajor                      bool
-		mockGetLatestModuleVersionCalls []mockGetLatestModuleVersionCall
-		expectedInfo                    model.BinaryUpgradeInfo
-		expectedErr                     error
+		pkg                   model.Package
+		mockGetPackageInfo    model.PackageInfo
+		mockGetPackageInfoErr error
+		expectedPkg           model.Package
+		expectedErr           error
 	}{
-		"success-check-minor-no-upgrade-available": {
-			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-			checkMajor: false,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
-				},
-			},
-			expectedInfo: model.BinaryUpgradeInfo{
-				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
-				IsUpgradeAvailable: false,
-			},
-		},
-		"success-check-major-no-upgrade-available": {
-			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-			checkMajor: true,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
-				},
-				{
-					module: model.NewLatestModule("example.com/mockorg/mockproj/v2"),
-					err:    toolchain.ErrModuleNotFound,
-				},
-			},
-			expectedInfo: model.BinaryUpgradeInfo{
-				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
-				IsUpgradeAvailable: false,
-			},
+		"success-latest": {
+			pkg:                model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfo: mainPkgInfo,
+			expectedPkg:        model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.2.0"),
 		},
-		"success-check-major-no-upgrade-available-v2": {
-			info:       getBinaryInfo(workspace, "mockproj", "v2.0.0", false, true, false),
-			checkMajor: true,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj/v2"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
-				},
-				{
-					module: model.NewLatestModule("example.com/mockorg/mockproj/v3"),
-					err:    toolchain.ErrModuleNotFound,
-				},
-			},
-			expectedInfo: model.BinaryUpgradeInfo{
-				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v2.0.0", false, true, false),
-				LatestModule:       model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
-				IsUpgradeAvailable: false,
+		"success-ref": {
+			pkg: model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@main"),
+			mockGetPackageInfo: model.PackageInfo{
+				Module: model.NewModule(
+					"example.com/mockorg/mockproj",
+					model.NewVersion("v1.2.1-0.20250729191454-dac745d99aac"),
+				),
+				Exists: true,
+				IsMain: true,
 			},
+			expectedPkg: model.NewPackage(
+				"example.com/mockorg/mockproj/cmd/mockproj@v1.2.1-0.20250729191454-dac745d99aac",
+			),
 		},
-		"success-check-minor-upgrade-available": {
-			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-			checkMajor: false,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
-				},
-			},
-			expectedInfo: model.BinaryUpgradeInfo{
-				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
-				IsUpgradeAvailable: true,
-			},
+		"error-package-not-main": {
+			pkg:                model.NewPackage("example.com/mockorg/mockproj@latest"),
+			mockGetPackageInfo: model.PackageInfo{Exists: true},
+			expectedErr:        manager.ErrPackageNotMain,
 		},
-		"success-check-major-upgrade-available": {
-			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-			checkMajor: true,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
-				},
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj/v2"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
-				},
-				{
-					module: model.NewLatestModule("example.com/mockorg/mockproj/v3"),
-					err:    toolchain.ErrModuleNotFound,
-				},
-			},
-			expectedInfo: model.BinaryUpgradeInfo{
-				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-				LatestModule:       model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
-				IsUpgradeAvailable: true,
-			},
+		"error-get-package-info": {
+			pkg:                   model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
+			mockGetPackageInfoErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
 		},
-		"success-check-major-multiple-major-upgrades-available": {
-			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-			checkMajor: true,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
-				},
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj/v2"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj/v2", model.NewVersion("v2.0.0")),
-				},
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj/v3"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj/v3", model.NewVersion("v3.0.0")),
-				},
-				{
-					module: model.NewLatestModule("example.com/mockorg/mockproj/v4"),
-					err:    toolchain.ErrModuleNotFound,
-				},
-			},
-			expectedInfo: model.BinaryUpgradeInfo{
-				BinaryInfo:         getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-				LatestModule:       model.NewModule("example.com/mockorg/mockproj/v3", model.NewVersion("v3.0.0")),
-				IsUpgradeAvailable: true,
-			},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetPackageInfo(context.Background(), tc.pkg).
+				Return(tc.mockGetPackageInfo, tc.mockGetPackageInfoErr).
+				Once()
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			pkg, err := binaryManager.ResolvePackage(context.Background(), tc.pkg)
+			assert.Equal(t, tc.expectedPkg, pkg)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_RollbackBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	intBinPath := workspace.GetInternalBinPath()
+	pinPath := filepath.Join(workspace.GetGoBinPath(), "mockproj2-v1")
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj2-v1.json")
+
+	intBins := []string{
+		filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+		filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+		filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+		filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+	}
+
+	cases := map[string]struct {
+		mockPinTarget         string
+		callReplacePin        bool
+		mockReplaceSymlinkErr error
+		expectedVersion       model.Version
+		expectedErr           error
+	}{
+		"success": {
+			mockPinTarget:   filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+			callReplacePin:  true,
+			expectedVersion: "v1.2.0",
 		},
-		"success-check-major-pinned-version-upgrade-available": {
-			info:       getBinaryInfo(workspace, "mockproj-v1", "v1.1.0", false, true, false),
-			checkMajor: true,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1")),
-					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
-				},
-			},
-			expectedInfo: model.BinaryUpgradeInfo{
-				BinaryInfo:         getBinaryInfo(workspace, "mockproj-v1", "v1.1.0", false, true, false),
-				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
-				IsUpgradeAvailable: true,
-			},
+		"error-oldest-version": {
+			mockPinTarget: filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+			expectedErr:   manager.ErrRollbackVersionNotFound,
 		},
-		"success-check-minor-pinned-version-upgrade-available": {
-			info:       getBinaryInfo(workspace, "mockproj-v1.1", "v1.1.0", false, true, false),
-			checkMajor: true,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1")),
-					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
-				},
-			},
-			expectedInfo: model.BinaryUpgradeInfo{
-				BinaryInfo:         getBinaryInfo(workspace, "mockproj-v1.1", "v1.1.0", false, true, false),
-				LatestModule:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
-				IsUpgradeAvailable: true,
-			},
+		"error-pinned-version-missing": {
+			mockPinTarget: filepath.Join(intBinPath, "mockproj2@v1.4.0"),
+			expectedErr:   manager.ErrRollbackVersionNotFound,
 		},
-		"error-get-latest-module-minor-version": {
-			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-			checkMajor: true,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module: model.NewLatestModule("example.com/mockorg/mockproj"),
-					err:    toolchain.ErrModuleInfoNotAvailable,
-				},
-			},
-			expectedErr: toolchain.ErrModuleInfoNotAvailable,
+		"error-replace-symlink": {
+			mockPinTarget:         filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+			callReplacePin:        true,
+			mockReplaceSymlinkErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
 		},
-		"error-get-latest-module-major-version": {
-			info:       getBinaryInfo(workspace, "mockproj", "v0.1.0", false, true, false),
-			checkMajor: true,
-			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
-				{
-					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
-					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
-				},
-				{
-					module: model.NewLatestModule("example.com/mockorg/mockproj/v2"),
-					err:    toolchain.ErrModuleInfoNotAvailable,
-				},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+
+			fs.EXPECT().Exists(pinPath).Return(true).Once()
+			fs.EXPECT().GetSymlinkTarget(pinPath).Return(tc.mockPinTarget, nil).Once()
+			fs.EXPECT().ListBinaries(intBinPath).Return(intBins, nil).Once()
+
+			if tc.callReplacePin {
+				fs.EXPECT().GetSymlinkTarget(pinPath).Return(tc.mockPinTarget, nil).Once()
+				fs.EXPECT().ReadFile(receiptPath).Return(nil, os.ErrNotExist).Once()
+				fs.EXPECT().WriteFile(
+					receiptPath,
+					[]byte("{\n  \"name\": \"mockproj2-v1\",\n  \"previous_version\": \"v1.3.1\"\n}"),
+					os.FileMode(0600),
+				).Return(nil).Once()
+				fs.EXPECT().ReplaceSymlink(filepath.Join(intBinPath, "mockproj2@v1.2.0"), pinPath).
+					Return(tc.mockReplaceSymlinkErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			version, err := binaryManager.RollbackBinary(model.NewBinaryFromString("mockproj2-v1"))
+			assert.Equal(t, tc.expectedVersion, version)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_SearchPackages(t *testing.T) {
+	cases := map[string]struct {
+		mockSearchPackages    []model.PackageSearchResult
+		mockSearchPackagesErr error
+		expectedResults       []model.PackageSearchResult
+		expectedErr           error
+	}{
+		"success": {
+			mockSearchPackages: []model.PackageSearchResult{
+				{Path: "example.com/mockorg/mockproj/cmd/mockproj", IsCommand: true},
 			},
-			expectedErr: toolchain.ErrModuleInfoNotAvailable,
+			expectedResults: []model.PackageSearchResult{
+				{Path: "example.com/mockorg/mockproj/cmd/mockproj", IsCommand: true},
+			},
+		},
+		"error-search-packages": {
+			mockSearchPackagesErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
 		},
 	}
 
@@ -1053,553 +8074,361 @@ func TestGoBinaryManager_GetBinaryUpgradeInfo(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			toolchain := toolchainmocks.NewToolchain(t)
 
-			for _, call := range tc.mockGetLatestModuleVersionCalls {
-				toolchain.EXPECT().GetLatestModuleVersion(context.Background(), call.module).
-					Return(call.latestModule, call.err).
-					Once()
-			}
+			toolchain.EXPECT().SearchPackages(context.Background(), "https://pkg.go.dev/search", "mockproj", 10).
+				Return(tc.mockSearchPackages, tc.mockSearchPackagesErr).
+				Once()
 
-			binaryManager := manager.NewGoBinaryManager(nil, nil, toolchain, nil)
-			info, upgradeErr := binaryManager.GetBinaryUpgradeInfo(
-				context.Background(), tc.info, tc.checkMajor,
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
 			)
-			assert.Equal(t, tc.expectedInfo, info)
-			assert.Equal(t, tc.expectedErr, upgradeErr)
+			results, err := binaryManager.SearchPackages(context.Background(), "mockproj", 10)
+			assert.Equal(t, tc.expectedResults, results)
+			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
 
-func TestGoBinaryManager_InstallPackage(t *testing.T) {
+func TestGoBinaryManager_SetBinaryEnv(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
 
 	goBinPath := workspace.GetGoBinPath()
 	intBinPath := workspace.GetInternalBinPath()
-	tempPath := workspace.GetInternalTempPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+	shimDir := workspace.GetInternalShimPath()
+	shimPath := filepath.Join(shimDir, "mockproj")
 
 	cases := map[string]struct {
-		pkg                      model.Package
-		kind                     model.Kind
-		rebuild                  bool
-		callCreateTempDir        bool
-		mockCreateTempDirPattern string
-		mockCreateTempDirPath    string
-		mockCreateTempDirErr     error
-		callInstall              bool
-		mockInstallPackage       model.Package
-		mockInstallErr           error
-		callRuntimeOS            bool
-		mockRuntimeOS            string
-		callGetBuildInfo         bool
-		mockGetBuildInfoPath     string
-		mockGetBuildInfo         *buildinfo.BuildInfo
-		mockGetBuildInfoErr      error
-		callMove                 bool
-		mockMoveSrc              string
-		mockMoveDst              string
-		mockMoveErr              error
-		callReplaceSymlink       bool
-		mockReplaceSymlinkSrc    string
-		mockReplaceSymlinkDst    string
-		mockReplaceSymlinkErr    error
-		expectedErr              error
+		envVars              []string
+		mockGetBuildInfoErr  error
+		callReadFile         bool
+		mockReadFile         []byte
+		mockReadFileErr      error
+		callWriteReceipt     bool
+		mockWriteReceiptData []byte
+		mockWriteReceiptErr  error
+		mockRuntimeOSCalls   int
+		callCreateDir        bool
+		mockCreateDirErr     error
+		callWriteShim        bool
+		mockWriteShimData    []byte
+		mockWriteShimErr     error
+		expectedErr          error
 	}{
-		"success-package": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
-			kind:                     model.KindLatest,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
-			callRuntimeOS:            true,
-			mockRuntimeOS:            "linux",
-			callGetBuildInfo:         true,
-			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
-			callMove:                 true,
-			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
-			callReplaceSymlink:       true,
-			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
-			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
-		},
-		"success-windows": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
-			kind:                     model.KindLatest,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest"),
-			callRuntimeOS:            true,
-			mockRuntimeOS:            "windows",
-			callGetBuildInfo:         true,
-			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj.exe"),
-			mockGetBuildInfo:         getBuildInfo("mockproj.exe", "v1.0.0"),
-			callMove:                 true,
-			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj.exe"),
-			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0.exe"),
-			callReplaceSymlink:       true,
-			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0.exe"),
-			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj.exe"),
-		},
-		"success-package-with-version": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
-			kind:                     model.KindLatest,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
-			callRuntimeOS:            true,
-			mockRuntimeOS:            "linux",
-			callGetBuildInfo:         true,
-			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
-			callMove:                 true,
-			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
-			callReplaceSymlink:       true,
-			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
-			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
-		},
-		"success-package-version-suffix-with-version": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/v2@v2.0.0"),
-			kind:                     model.KindLatest,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/v2@v2.0.0"),
-			callRuntimeOS:            true,
-			mockRuntimeOS:            "linux",
-			callGetBuildInfo:         true,
-			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockGetBuildInfo:         getBuildInfo("mockproj", "v2.0.0"),
-			callMove:                 true,
-			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v2.0.0"),
-			callReplaceSymlink:       true,
-			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v2.0.0"),
-			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
-		},
-		"success-package-kind-major": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
-			kind:                     model.KindMajor,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
-			callRuntimeOS:            true,
-			mockRuntimeOS:            "linux",
-			callGetBuildInfo:         true,
-			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
-			callMove:                 true,
-			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
-			callReplaceSymlink:       true,
-			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
-			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj-v1"),
-		},
-		"success-package-kind-minor": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
-			kind:                     model.KindMinor,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
-			callRuntimeOS:            true,
-			mockRuntimeOS:            "linux",
-			callGetBuildInfo:         true,
-			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.0.0"),
-			callMove:                 true,
-			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.0.0"),
-			callReplaceSymlink:       true,
-			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.0.0"),
-			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj-v1.0"),
+		"success": {
+			envVars:              []string{"GOMEMLIMIT=1GiB"},
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrNotExist,
+			callWriteReceipt:     true,
+			mockWriteReceiptData: []byte("{\n  \"name\": \"mockproj\",\n  \"env\": [\n    \"GOMEMLIMIT=1GiB\"\n  ]\n}"),
+			mockRuntimeOSCalls:   2,
+			callCreateDir:        true,
+			callWriteShim:        true,
+			mockWriteShimData: model.GetShimScript(
+				"linux", filepath.Join(goBinPath, "mockproj"), []string{"GOMEMLIMIT=1GiB"},
+			),
 		},
-		"error-mkdir-temp": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
-			kind:                     model.KindLatest,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirErr:     os.ErrNotExist,
-			expectedErr:              os.ErrNotExist,
+		"success-replace-env-var": {
+			envVars:          []string{"TOOL_CONFIG=debug", "GOMEMLIMIT=1GiB"},
+			callReadFile:     true,
+			mockReadFile:     []byte(`{"name":"mockproj","env":["GOMEMLIMIT=512MiB","TOOL_MODE=fast"]}`),
+			callWriteReceipt: true,
+			mockWriteReceiptData: []byte("{\n  \"name\": \"mockproj\",\n  \"env\": [\n    \"GOMEMLIMIT=1GiB\",\n" +
+				"    \"TOOL_CONFIG=debug\",\n    \"TOOL_MODE=fast\"\n  ]\n}"),
+			mockRuntimeOSCalls: 2,
+			callCreateDir:      true,
+			callWriteShim:      true,
+			mockWriteShimData: model.GetShimScript(
+				"linux",
+				filepath.Join(goBinPath, "mockproj"),
+				[]string{"GOMEMLIMIT=1GiB", "TOOL_CONFIG=debug", "TOOL_MODE=fast"},
+			),
 		},
-		"error-install": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
-			kind:                     model.KindLatest,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
-			mockInstallErr:           errors.New("exit status 1: unexpected error"),
-			expectedErr:              errors.New("exit status 1: unexpected error"),
+		"error-get-binary-info": {
+			envVars:             []string{"GOMEMLIMIT=1GiB"},
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
 		},
-		"error-get-build-info": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
-			kind:                     model.KindLatest,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
-			callRuntimeOS:            true,
-			mockRuntimeOS:            "linux",
-			callGetBuildInfo:         true,
-			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.1.0"),
-			mockGetBuildInfoErr:      os.ErrNotExist,
-			expectedErr:              os.ErrNotExist,
+		"error-write-receipt": {
+			envVars:              []string{"GOMEMLIMIT=1GiB"},
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrNotExist,
+			callWriteReceipt:     true,
+			mockWriteReceiptData: []byte("{\n  \"name\": \"mockproj\",\n  \"env\": [\n    \"GOMEMLIMIT=1GiB\"\n  ]\n}"),
+			mockWriteReceiptErr:  errors.New("unexpected error"),
+			expectedErr:          errors.New("unexpected error"),
 		},
-		"error-move": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
-			kind:                     model.KindLatest,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
-			callRuntimeOS:            true,
-			mockRuntimeOS:            "linux",
-			callGetBuildInfo:         true,
-			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.1.0"),
-			callMove:                 true,
-			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.1.0"),
-			mockMoveErr:              os.ErrExist,
-			expectedErr:              os.ErrExist,
+		"error-create-shim-dir": {
+			envVars:              []string{"GOMEMLIMIT=1GiB"},
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrNotExist,
+			callWriteReceipt:     true,
+			mockWriteReceiptData: []byte("{\n  \"name\": \"mockproj\",\n  \"env\": [\n    \"GOMEMLIMIT=1GiB\"\n  ]\n}"),
+			mockRuntimeOSCalls:   1,
+			callCreateDir:        true,
+			mockCreateDirErr:     errors.New("unexpected error"),
+			expectedErr:          errors.New("unexpected error"),
 		},
-		"error-replace-symlink": {
-			pkg:                      model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
-			kind:                     model.KindLatest,
-			rebuild:                  false,
-			callCreateTempDir:        true,
-			mockCreateTempDirPattern: "mockproj-*",
-			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
-			callInstall:              true,
-			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
-			callRuntimeOS:            true,
-			mockRuntimeOS:            "linux",
-			callGetBuildInfo:         true,
-			mockGetBuildInfoPath:     filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockGetBuildInfo:         getBuildInfo("mockproj", "v1.1.0"),
-			callMove:                 true,
-			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789/mockproj"),
-			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.1.0"),
-			callReplaceSymlink:       true,
-			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.1.0"),
-			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
-			mockReplaceSymlinkErr:    errors.New("unexpected error"),
-			expectedErr:              errors.New("unexpected error"),
+		"error-write-shim": {
+			envVars:              []string{"GOMEMLIMIT=1GiB"},
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrNotExist,
+			callWriteReceipt:     true,
+			mockWriteReceiptData: []byte("{\n  \"name\": \"mockproj\",\n  \"env\": [\n    \"GOMEMLIMIT=1GiB\"\n  ]\n}"),
+			mockRuntimeOSCalls:   2,
+			callCreateDir:        true,
+			callWriteShim:        true,
+			mockWriteShimData: model.GetShimScript(
+				"linux", filepath.Join(goBinPath, "mockproj"), []string{"GOMEMLIMIT=1GiB"},
+			),
+			mockWriteShimErr: errors.New("unexpected error"),
+			expectedErr:      errors.New("unexpected error"),
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			fs := systemmocks.NewFileSystem(t)
-			rt := systemmocks.NewRuntime(t)
+			runtime := systemmocks.NewRuntime(t)
 			toolchain := toolchainmocks.NewToolchain(t)
 
-			if tc.callCreateTempDir {
-				fs.EXPECT().CreateTempDir(tempPath, tc.mockCreateTempDirPattern).
-					Return(tc.mockCreateTempDirPath, func() error { return nil }, tc.mockCreateTempDirErr).Once()
+			var buildInfo *buildinfo.BuildInfo
+			if tc.mockGetBuildInfoErr == nil {
+				buildInfo = getBuildInfo("mockproj", "v0.1.0")
+
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+					Return(filepath.Join(intBinPath, "mockproj@v0.1.0"), nil).
+					Once()
 			}
 
-			if tc.callInstall {
-				toolchain.EXPECT().Install(
-					context.Background(),
-					tc.mockCreateTempDirPath,
-					tc.pkg,
-					tc.rebuild,
-				).Return(tc.mockInstallErr).Once()
+			toolchain.EXPECT().GetBuildInfo(filepath.Join(goBinPath, "mockproj")).
+				Return(buildInfo, tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(tc.mockReadFile, tc.mockReadFileErr).
+					Once()
 			}
 
-			if tc.callRuntimeOS {
-				rt.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+			if tc.callWriteReceipt {
+				fs.EXPECT().WriteFile(receiptPath, tc.mockWriteReceiptData, os.FileMode(0600)).
+					Return(tc.mockWriteReceiptErr).
+					Once()
 			}
 
-			if tc.callGetBuildInfo {
-				toolchain.EXPECT().GetBuildInfo(tc.mockGetBuildInfoPath).
-					Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
+			for range tc.mockRuntimeOSCalls {
+				runtime.EXPECT().OS().
+					Return("linux").
 					Once()
 			}
 
-			if tc.callMove {
-				fs.EXPECT().Move(tc.mockMoveSrc, tc.mockMoveDst).
-					Return(tc.mockMoveErr).Once()
+			if tc.callCreateDir {
+				fs.EXPECT().CreateDir(shimDir, os.FileMode(0700)).
+					Return(tc.mockCreateDirErr).
+					Once()
 			}
 
-			if tc.callReplaceSymlink {
-				fs.EXPECT().ReplaceSymlink(tc.mockReplaceSymlinkSrc, tc.mockReplaceSymlinkDst).
-					Return(tc.mockReplaceSymlinkErr).Once()
+			if tc.callWriteShim {
+				fs.EXPECT().WriteFile(shimPath, tc.mockWriteShimData, os.FileMode(0700)).
+					Return(tc.mockWriteShimErr).
+					Once()
 			}
 
-			binaryManager := manager.NewGoBinaryManager(fs, rt, toolchain, workspace)
-			err = binaryManager.InstallPackage(context.Background(), tc.pkg, tc.kind, tc.rebuild)
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, runtime, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.SetBinaryEnv(model.NewBinaryFromString("mockproj"), tc.envVars...)
 			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
 
-func TestGoBinaryManager_MigrateBinary(t *testing.T) {
+func TestGoBinaryManager_StorePackage(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
 
-	goBinPath := workspace.GetGoBinPath()
 	intBinPath := workspace.GetInternalBinPath()
+	tempPath := workspace.GetInternalTempPath()
+	binTempDir := filepath.Join(tempPath, "mockproj-123")
+	binPath := filepath.Join(intBinPath, "mockproj@v1.2.0")
+
+	latestPkg := model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@latest")
+	pkg := model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.2.0")
 
 	cases := map[string]struct {
-		path                    string
-		mockGetBuildInfo        *buildinfo.BuildInfo
-		mockGetBuildInfoErr     error
-		callGetSymlinkTarget    bool
-		mockGetSymlinkTarget    string
-		mockGetSymlinkTargetErr error
-		callMoveWithSymlink     bool
-		mockMoveWithSymlinkSrc  string
-		mockMoveWithSymlinkDst  string
-		mockMoveWithSymlinkErr  error
-		expectedErr             error
+		pkg                   model.Package
+		callExistsExact       bool
+		mockExistsExact       bool
+		callGetPackageInfo    bool
+		mockGetPackageInfoErr error
+		callExists            bool
+		mockExists            bool
+		callInstall           bool
+		mockInstallErr        error
+		callMove              bool
+		mockMoveErr           error
+		expectedPath          string
+		expectedErr           error
 	}{
-		"success": {
-			path:                   filepath.Join(goBinPath, "mockproj"),
-			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
-			callGetSymlinkTarget:   true,
-			mockGetSymlinkTarget:   filepath.Join(goBinPath, "mockproj"),
-			callMoveWithSymlink:    true,
-			mockMoveWithSymlinkSrc: filepath.Join(goBinPath, "mockproj"),
-			mockMoveWithSymlinkDst: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+		"success-reuse-exact-version": {
+			pkg:             pkg,
+			callExistsExact: true,
+			mockExistsExact: true,
+			expectedPath:    binPath,
 		},
-		"error-get-binary-info": {
-			path:                filepath.Join(goBinPath, "mockproj"),
-			mockGetBuildInfo:    getBuildInfo("mockproj", "v0.1.0"),
-			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
-			expectedErr:         toolchain.ErrBinaryNotFound,
+		"success-reuse-resolved-version": {
+			pkg:                latestPkg,
+			callGetPackageInfo: true,
+			callExists:         true,
+			mockExists:         true,
+			expectedPath:       binPath,
 		},
-		"error-already-managed": {
-			path:                 filepath.Join(goBinPath, "mockproj"),
-			mockGetBuildInfo:     getBuildInfo("mockproj", "v0.1.0"),
-			callGetSymlinkTarget: true,
-			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
-			expectedErr:          manager.ErrBinaryAlreadyManaged,
+		"success-build-exact-version": {
+			pkg:                pkg,
+			callExistsExact:    true,
+			callGetPackageInfo: true,
+			callExists:         true,
+			callInstall:        true,
+			callMove:           true,
+			expectedPath:       binPath,
 		},
-		"error-move-with-symlink": {
-			path:                   filepath.Join(goBinPath, "mockproj"),
-			mockGetBuildInfo:       getBuildInfo("mockproj", "v0.1.0"),
-			callGetSymlinkTarget:   true,
-			mockGetSymlinkTarget:   filepath.Join(goBinPath, "mockproj"),
-			callMoveWithSymlink:    true,
-			mockMoveWithSymlinkSrc: filepath.Join(goBinPath, "mockproj"),
-			mockMoveWithSymlinkDst: filepath.Join(intBinPath, "mockproj@v0.1.0"),
-			mockMoveWithSymlinkErr: errors.New("unexpected error"),
-			expectedErr:            errors.New("unexpected error"),
+		"success-build-resolved-version": {
+			pkg:                latestPkg,
+			callGetPackageInfo: true,
+			callExists:         true,
+			callInstall:        true,
+			callMove:           true,
+			expectedPath:       binPath,
+		},
+		"error-get-package-info": {
+			pkg:                   latestPkg,
+			callGetPackageInfo:    true,
+			mockGetPackageInfoErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
+		},
+		"error-install": {
+			pkg:                latestPkg,
+			callGetPackageInfo: true,
+			callExists:         true,
+			callInstall:        true,
+			mockInstallErr:     toolchain.ErrBuildFailed,
+			expectedErr:        toolchain.ErrBuildFailed,
+		},
+		"error-move": {
+			pkg:                latestPkg,
+			callGetPackageInfo: true,
+			callExists:         true,
+			callInstall:        true,
+			callMove:           true,
+			mockMoveErr:        errors.New("unexpected error"),
+			expectedErr:        errors.New("unexpected error"),
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			fs := systemmocks.NewFileSystem(t)
-			toolchain := toolchainmocks.NewToolchain(t)
+			runtime := systemmocks.NewRuntime(t)
+			toolchainMock := toolchainmocks.NewToolchain(t)
 
-			toolchain.EXPECT().GetBuildInfo(tc.path).
-				Return(tc.mockGetBuildInfo, tc.mockGetBuildInfoErr).
-				Once()
+			runtime.EXPECT().OS().Return("linux").Once()
 
-			if tc.callGetSymlinkTarget {
-				fs.EXPECT().GetSymlinkTarget(tc.path).
-					Return(tc.mockGetSymlinkTarget, tc.mockGetSymlinkTargetErr).
+			if tc.callExistsExact {
+				fs.EXPECT().Exists(binPath).Return(tc.mockExistsExact).Once()
+			}
+
+			if tc.callGetPackageInfo {
+				toolchainMock.EXPECT().GetPackageInfo(mock.Anything, tc.pkg).
+					Return(model.PackageInfo{
+						Module: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.0")),
+						Exists: true,
+						IsMain: true,
+					}, tc.mockGetPackageInfoErr).
 					Once()
 			}
 
-			if tc.callMoveWithSymlink {
-				fs.EXPECT().MoveWithSymlink(tc.mockMoveWithSymlinkSrc, tc.mockMoveWithSymlinkDst).
-					Return(tc.mockMoveWithSymlinkErr).
+			if tc.callExists {
+				fs.EXPECT().Exists(binPath).Return(tc.mockExists).Once()
+			}
+
+			if tc.callInstall {
+				fs.EXPECT().CreateTempDir(tempPath, "mockproj-*").
+					Return(binTempDir, func() error { return nil }, nil).
+					Once()
+
+				toolchainMock.EXPECT().Install(mock.Anything, binTempDir, pkg, model.BuildFlags{}, false).
+					Return(tc.mockInstallErr).
 					Once()
 			}
 
-			binaryManager := manager.NewGoBinaryManager(fs, nil, toolchain, workspace)
-			err = binaryManager.MigrateBinary(tc.path)
+			if tc.callMove {
+				fs.EXPECT().Move(filepath.Join(binTempDir, "mockproj"), binPath).
+					Return(tc.mockMoveErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, runtime, toolchainMock, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			path, err := binaryManager.StorePackage(context.Background(), tc.pkg)
+			assert.Equal(t, tc.expectedPath, path)
 			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
 
-func TestGoBinaryManager_PinBinary(t *testing.T) {
+func TestGoBinaryManager_SwitchBinary(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
 
-	goBinPath := workspace.GetGoBinPath()
 	intBinPath := workspace.GetInternalBinPath()
+	pinPath := filepath.Join(workspace.GetGoBinPath(), "mockproj2-v1")
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj2-v1.json")
+
+	intBins := []string{
+		filepath.Join(intBinPath, "mockproj1@v0.3.0"),
+		filepath.Join(intBinPath, "mockproj2@v1.2.0"),
+		filepath.Join(intBinPath, "mockproj2@v1.3.1"),
+		filepath.Join(intBinPath, "mockproj2@v2.2.0"),
+	}
 
 	cases := map[string]struct {
-		bin                   model.Binary
-		kind                  model.Kind
-		mockListBinaries      []string
-		mockListBinariesErr   error
-		mockBinPath           string
-		callReplaceSymlink    bool
-		mockReplaceSymlinkSrc string
-		mockReplaceSymlinkDst string
+		version               model.Version
+		callReplacePin        bool
 		mockReplaceSymlinkErr error
 		expectedErr           error
 	}{
-		"success-version-latest-kind-latest": {
-			bin:  model.NewBinaryFromString("mockproj2"),
-			kind: model.KindLatest,
-			mockListBinaries: []string{
-				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
-				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
-				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
-			},
-			mockBinPath:           filepath.Join(goBinPath, "mockproj2"),
-			callReplaceSymlink:    true,
-			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v2.2.0"),
-			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
-		},
-		"success-version-latest-kind-major": {
-			bin:  model.NewBinaryFromString("mockproj2"),
-			kind: model.KindMajor,
-			mockListBinaries: []string{
-				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
-				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
-				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
-			},
-			mockBinPath:           filepath.Join(goBinPath, "mockproj2-v2"),
-			callReplaceSymlink:    true,
-			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v2.2.0"),
-			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2-v2"),
-		},
-		"success-version-latest-kind-minor": {
-			bin:  model.NewBinaryFromString("mockproj2"),
-			kind: model.KindMinor,
-			mockListBinaries: []string{
-				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
-				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
-				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
-			},
-			mockBinPath:           filepath.Join(goBinPath, "mockproj2-v2.2"),
-			callReplaceSymlink:    true,
-			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v2.2.0"),
-			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2-v2.2"),
-		},
-		"success-version-v1-kind-latest": {
-			bin:  model.NewBinaryFromString("mockproj2@v1"),
-			kind: model.KindLatest,
-			mockListBinaries: []string{
-				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
-				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
-				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
-			},
-			mockBinPath:           filepath.Join(goBinPath, "mockproj2"),
-			callReplaceSymlink:    true,
-			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v1.3.1"),
-			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
-		},
-		"success-version-v1.2-kind-latest": {
-			bin:  model.NewBinaryFromString("mockproj2@v1.2"),
-			kind: model.KindLatest,
-			mockListBinaries: []string{
-				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
-				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
-				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
-			},
-			mockBinPath:           filepath.Join(goBinPath, "mockproj2"),
-			callReplaceSymlink:    true,
-			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v1.2.0"),
-			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
-		},
-		"success-version-v1.3.0-kind-latest": {
-			bin:  model.NewBinaryFromString("mockproj2@v1.3.0"),
-			kind: model.KindLatest,
-			mockListBinaries: []string{
-				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v0.4.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.2.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.0"),
-				filepath.Join(intBinPath, "mockproj2@v1.3.1"),
-				filepath.Join(intBinPath, "mockproj3@v2.1.0"),
-				filepath.Join(intBinPath, "mockproj2@v2.2.0"),
-			},
-			mockBinPath:           filepath.Join(goBinPath, "mockproj2"),
-			callReplaceSymlink:    true,
-			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj2@v1.3.0"),
-			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj2"),
+		"success": {
+			version:        "v1.2.0",
+			callReplacePin: true,
 		},
-		"error-list-binaries": {
-			bin:                 model.NewBinaryFromString("mockproj1"),
-			kind:                model.KindLatest,
-			mockListBinariesErr: os.ErrNotExist,
-			expectedErr:         os.ErrNotExist,
+		"error-other-major-version": {
+			version:     "v2.2.0",
+			expectedErr: manager.ErrVersionNotInstalled,
 		},
-		"error-binary-not-found": {
-			bin:  model.NewBinaryFromString("mockproj1@v0.4"),
-			kind: model.KindLatest,
-			mockListBinaries: []string{
-				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
-			},
-			expectedErr: toolchain.ErrBinaryNotFound,
+		"error-version-not-installed": {
+			version:     "v1.4.0",
+			expectedErr: manager.ErrVersionNotInstalled,
 		},
 		"error-replace-symlink": {
-			bin:  model.NewBinaryFromString("mockproj1"),
-			kind: model.KindLatest,
-			mockListBinaries: []string{
-				filepath.Join(intBinPath, "mockproj1@v0.3.0"),
-			},
-			mockBinPath:           filepath.Join(goBinPath, "mockproj1"),
-			callReplaceSymlink:    true,
-			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj1@v0.3.0"),
-			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj1"),
+			version:               "v1.2.0",
+			callReplacePin:        true,
 			mockReplaceSymlinkErr: errors.New("unexpected error"),
 			expectedErr:           errors.New("unexpected error"),
 		},
@@ -1608,196 +8437,364 @@ func TestGoBinaryManager_PinBinary(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			fs := systemmocks.NewFileSystem(t)
-			toolchain := toolchainmocks.NewToolchain(t)
 
-			fs.EXPECT().ListBinaries(intBinPath).
-				Return(tc.mockListBinaries, tc.mockListBinariesErr).
-				Once()
+			pinTarget := filepath.Join(intBinPath, "mockproj2@v1.3.1")
+			fs.EXPECT().Exists(pinPath).Return(true).Once()
+			fs.EXPECT().GetSymlinkTarget(pinPath).Return(pinTarget, nil).Once()
+			fs.EXPECT().ListBinaries(intBinPath).Return(intBins, nil).Once()
 
-			if tc.callReplaceSymlink {
-				fs.EXPECT().ReplaceSymlink(tc.mockReplaceSymlinkSrc, tc.mockReplaceSymlinkDst).
+			if tc.callReplacePin {
+				fs.EXPECT().GetSymlinkTarget(pinPath).Return(pinTarget, nil).Once()
+				fs.EXPECT().ReadFile(receiptPath).Return(nil, os.ErrNotExist).Once()
+				fs.EXPECT().WriteFile(
+					receiptPath,
+					[]byte("{\n  \"name\": \"mockproj2-v1\",\n  \"previous_version\": \"v1.3.1\"\n}"),
+					os.FileMode(0600),
+				).Return(nil).Once()
+				fs.EXPECT().ReplaceSymlink(filepath.Join(intBinPath, "mockproj2@"+tc.version.String()), pinPath).
 					Return(tc.mockReplaceSymlinkErr).
 					Once()
 			}
 
-			binaryManager := manager.NewGoBinaryManager(fs, nil, toolchain, workspace)
-			err = binaryManager.PinBinary(tc.bin, tc.kind)
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, nil, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err := binaryManager.SwitchBinary(model.NewBinaryFromString("mockproj2-v1"), tc.version)
 			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
 
-func TestGoBinaryManager_PruneBinary(t *testing.T) {
+func TestGoBinaryManager_UninstallBinary(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
 
 	goBinPath := workspace.GetGoBinPath()
 	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := workspace.GetInternalReceiptPath()
+	dataPath := workspace.GetInternalDataPath()
+	shimPath := workspace.GetInternalShimPath()
 
 	cases := map[string]struct {
 		bin                       model.Binary
-		mockListBinariesCalls     []mockListBinariesCall
-		mockGetBuildInfoCalls     []mockGetBuildInfoCall
+		force                     bool
+		purge                     bool
+		mockReadFileCalls         []mockReadFileCall
 		mockGetSymlinkTargetCalls []mockGetSymlinkTargetCall
+		mockListBinariesCalls     []mockListBinariesCall
 		mockRemoveCalls           []mockRemoveCall
+		mockRemoveAllCalls        []mockRemoveCall
+		mockRuntimeOSCalls        int
 		expectedErr               error
 	}{
-		"success-no-binaries-to-prune": {
+		"success-unmanaged-binary": {
 			bin: model.NewBinaryFromString("mockproj"),
-			mockListBinariesCalls: []mockListBinariesCall{
-				{
-					path: intBinPath,
-					binaries: []string{
-						filepath.Join(intBinPath, "mockproj1@v1.2.0"),
-						filepath.Join(intBinPath, "mockproj2@v2.0.0"),
-						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					},
-				},
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+			},
+			mockRemoveCalls: []mockRemoveCall{
+				{bin: filepath.Join(goBinPath, "mockproj")},
 			},
 		},
-		"success-binaries-to-prune": {
-			bin: model.NewBinaryFromString("mockproj2@v2"),
-			mockListBinariesCalls: []mockListBinariesCall{
-				{
-					path: intBinPath,
-					binaries: []string{
-						filepath.Join(intBinPath, "mockproj2@v1.8.0"),
-						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					},
-				},
-				{
-					path: goBinPath,
-					binaries: []string{
-						filepath.Join(goBinPath, "mockproj2"),
-					},
-				},
+		"success-managed-binary": {
+			bin: model.NewBinaryFromString("mockproj"),
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), data: []byte(`{"name":"mockproj"}`)},
 			},
-			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
-				{
-					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					info: getBuildInfo("mockproj2@v2", "v2.1.0"),
-				},
+			mockRemoveCalls: []mockRemoveCall{
+				{bin: filepath.Join(goBinPath, "mockproj")},
+			},
+		},
+		"success-force-protected-binary": {
+			bin:   model.NewBinaryFromString("mockproj"),
+			force: true,
+			mockRemoveCalls: []mockRemoveCall{
+				{bin: filepath.Join(goBinPath, "mockproj")},
+			},
+		},
+		"success-purge-unmanaged-binary": {
+			bin:   model.NewBinaryFromString("mockproj"),
+			purge: true,
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
 			},
 			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{
-					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					err:  os.ErrNotExist,
-				},
-				{
-					path:   filepath.Join(goBinPath, "mockproj2"),
-					target: filepath.Join(intBinPath, "mockproj2@v1.8.0"),
-				},
+				{path: filepath.Join(goBinPath, "mockproj"), err: os.ErrInvalid},
 			},
+			mockRemoveAllCalls: []mockRemoveCall{
+				{bin: filepath.Join(dataPath, "mockproj")},
+			},
+			mockRuntimeOSCalls: 1,
 			mockRemoveCalls: []mockRemoveCall{
-				{
-					bin: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-				},
+				{bin: filepath.Join(goBinPath, "mockproj")},
+				{bin: filepath.Join(shimPath, "mockproj"), err: os.ErrNotExist},
+				{bin: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
 			},
 		},
-		"success-skip-pinned-binary": {
-			bin: model.NewBinaryFromString("mockproj2"),
+		"success-purge-managed-binary": {
+			bin:   model.NewBinaryFromString("mockproj"),
+			purge: true,
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+				{path: filepath.Join(receiptPath, "mockproj-v1.json"), err: os.ErrNotExist},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "mockproj"), target: filepath.Join(intBinPath, "mockproj@v2.0.0")},
+				{path: filepath.Join(goBinPath, "mockproj-v1"), target: filepath.Join(intBinPath, "mockproj@v1.2.0")},
+				{path: filepath.Join(goBinPath, "mockproj2"), target: filepath.Join(intBinPath, "mockproj2@v1.0.0")},
+				{path: filepath.Join(goBinPath, "mockproj3"), err: os.ErrInvalid},
+			},
 			mockListBinariesCalls: []mockListBinariesCall{
 				{
-					path: intBinPath,
+					path: goBinPath,
 					binaries: []string{
-						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
+						filepath.Join(goBinPath, "mockproj"),
+						filepath.Join(goBinPath, "mockproj-v1"),
+						filepath.Join(goBinPath, "mockproj2"),
+						filepath.Join(goBinPath, "mockproj3"),
 					},
 				},
 				{
-					path: goBinPath,
+					path: intBinPath,
 					binaries: []string{
-						filepath.Join(goBinPath, "mockproj2"),
+						filepath.Join(intBinPath, "mockproj@v1.2.0"),
+						filepath.Join(intBinPath, "mockproj@v2.0.0"),
+						filepath.Join(intBinPath, "mockproj2@v1.0.0"),
 					},
 				},
 			},
-			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
-				{
-					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					info: getBuildInfo("mockproj2@v2", "v2.1.0"),
-				},
+			mockRuntimeOSCalls: 2,
+			mockRemoveCalls: []mockRemoveCall{
+				{bin: filepath.Join(goBinPath, "mockproj-v1")},
+				{bin: filepath.Join(shimPath, "mockproj-v1"), err: os.ErrNotExist},
+				{bin: filepath.Join(receiptPath, "mockproj-v1.json"), err: os.ErrNotExist},
+				{bin: filepath.Join(intBinPath, "mockproj@v1.2.0")},
+				{bin: filepath.Join(intBinPath, "mockproj@v2.0.0")},
+				{bin: filepath.Join(goBinPath, "mockproj")},
+				{bin: filepath.Join(shimPath, "mockproj"), err: os.ErrNotExist},
+				{bin: filepath.Join(receiptPath, "mockproj.json")},
 			},
-			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{
-					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					err:  os.ErrNotExist,
-				},
-				{
-					path:   filepath.Join(goBinPath, "mockproj2"),
-					target: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-				},
+			mockRemoveAllCalls: []mockRemoveCall{
+				{bin: filepath.Join(dataPath, "mockproj")},
 			},
 		},
-		"error-list-binaries": {
-			bin: model.NewBinaryFromString("mockproj2@v2"),
-			mockListBinariesCalls: []mockListBinariesCall{
-				{
-					path: intBinPath,
-					err:  os.ErrNotExist,
-				},
+		"error-binary-protected": {
+			bin: model.NewBinaryFromString("mockproj"),
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), data: []byte(`{"name":"mockproj","protected":true}`)},
 			},
-			expectedErr: os.ErrNotExist,
+			expectedErr: manager.ErrBinaryProtected,
 		},
-		"error-get-binary-info": {
-			bin: model.NewBinaryFromString("mockproj2"),
-			mockListBinariesCalls: []mockListBinariesCall{
+		"error-purge-other-pin-protected": {
+			bin:   model.NewBinaryFromString("mockproj"),
+			purge: true,
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
 				{
-					path: intBinPath,
-					binaries: []string{
-						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					},
+					path: filepath.Join(receiptPath, "mockproj-v1.json"),
+					data: []byte(`{"name":"mockproj-v1","protected":true}`),
 				},
 			},
-			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
-				{
-					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					err:  toolchain.ErrBinaryBuiltWithoutGoModules,
-				},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "mockproj"), target: filepath.Join(intBinPath, "mockproj@v2.0.0")},
+				{path: filepath.Join(goBinPath, "mockproj-v1"), target: filepath.Join(intBinPath, "mockproj@v1.2.0")},
 			},
-			expectedErr: toolchain.ErrBinaryBuiltWithoutGoModules,
-		},
-		"error-remove-binary": {
-			bin: model.NewBinaryFromString("mockproj2@v2"),
 			mockListBinariesCalls: []mockListBinariesCall{
-				{
-					path: intBinPath,
-					binaries: []string{
-						filepath.Join(intBinPath, "mockproj2@v1.8.0"),
-						filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					},
-				},
 				{
 					path: goBinPath,
 					binaries: []string{
-						filepath.Join(goBinPath, "mockproj2"),
+						filepath.Join(goBinPath, "mockproj"),
+						filepath.Join(goBinPath, "mockproj-v1"),
 					},
 				},
 			},
-			mockGetBuildInfoCalls: []mockGetBuildInfoCall{
-				{
-					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					info: getBuildInfo("mockproj2@v2", "v2.1.0"),
-				},
+			expectedErr: manager.ErrBinaryProtected,
+		},
+		"error-binary-not-found": {
+			bin: model.NewBinaryFromString("mockproj"),
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+			},
+			mockRemoveCalls: []mockRemoveCall{
+				{bin: filepath.Join(goBinPath, "mockproj"), err: os.ErrNotExist},
+			},
+			expectedErr: os.ErrNotExist,
+		},
+		"error-remove-binary": {
+			bin: model.NewBinaryFromString("mockproj"),
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+			},
+			mockRemoveCalls: []mockRemoveCall{
+				{bin: filepath.Join(goBinPath, "mockproj"), err: errors.New("unexpected error")},
+			},
+			expectedErr: errors.New("unexpected error"),
+		},
+		"error-remove-receipt": {
+			bin:   model.NewBinaryFromString("mockproj"),
+			purge: true,
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
 			},
 			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
-				{
-					path: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					err:  os.ErrNotExist,
-				},
-				{
-					path:   filepath.Join(goBinPath, "mockproj2"),
-					target: filepath.Join(intBinPath, "mockproj2@v1.8.0"),
-				},
+				{path: filepath.Join(goBinPath, "mockproj"), err: os.ErrInvalid},
 			},
+			mockRemoveAllCalls: []mockRemoveCall{
+				{bin: filepath.Join(dataPath, "mockproj")},
+			},
+			mockRuntimeOSCalls: 1,
 			mockRemoveCalls: []mockRemoveCall{
-				{
-					bin: filepath.Join(intBinPath, "mockproj2@v2.1.0"),
-					err: errors.New("unexpected error"),
-				},
+				{bin: filepath.Join(goBinPath, "mockproj")},
+				{bin: filepath.Join(shimPath, "mockproj"), err: os.ErrNotExist},
+				{bin: filepath.Join(receiptPath, "mockproj.json"), err: errors.New("unexpected error")},
+			},
+			expectedErr: errors.New("unexpected error"),
+		},
+		"error-remove-binary-data": {
+			bin:   model.NewBinaryFromString("mockproj"),
+			purge: true,
+			mockReadFileCalls: []mockReadFileCall{
+				{path: filepath.Join(receiptPath, "mockproj.json"), err: os.ErrNotExist},
+			},
+			mockGetSymlinkTargetCalls: []mockGetSymlinkTargetCall{
+				{path: filepath.Join(goBinPath, "mockproj"), err: os.ErrInvalid},
+			},
+			mockRemoveAllCalls: []mockRemoveCall{
+				{bin: filepath.Join(dataPath, "mockproj"), err: errors.New("unexpected error")},
+			},
+			expectedErr: errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			runtime := systemmocks.NewRuntime(t)
+
+			for _, call := range tc.mockReadFileCalls {
+				fs.EXPECT().ReadFile(call.path).
+					Return(call.data, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockGetSymlinkTargetCalls {
+				fs.EXPECT().GetSymlinkTarget(call.path).
+					Return(call.target, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockListBinariesCalls {
+				fs.EXPECT().ListBinaries(call.path).
+					Return(call.binaries, call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockRemoveCalls {
+				fs.EXPECT().Remove(call.bin).
+					Return(call.err).
+					Once()
+			}
+
+			for _, call := range tc.mockRemoveAllCalls {
+				fs.EXPECT().RemoveAll(call.bin).
+					Return(call.err).
+					Once()
+			}
+
+			for range tc.mockRuntimeOSCalls {
+				runtime.EXPECT().OS().
+					Return("linux").
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, runtime, nil, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.UninstallBinary(tc.bin, tc.force, tc.purge)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_UnmigrateBinary(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	otherBinPath := filepath.Join(t.TempDir(), "bin")
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+	shimPath := filepath.Join(workspace.GetInternalShimPath(), "mockproj")
+
+	cases := map[string]struct {
+		mockGetBuildInfoErr  error
+		mockGetSymlinkTarget string
+		callReadFile         bool
+		mockReadFile         []byte
+		mockReadFileErr      error
+		mockRemoveCalls      []mockRemoveCall
+		callRuntimeOS        bool
+		callMove             bool
+		mockMoveDst          string
+		mockMoveErr          error
+		expectedErr          error
+	}{
+		"success-restore-to-go-bin-path": {
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrNotExist,
+			mockRemoveCalls: []mockRemoveCall{
+				{bin: filepath.Join(goBinPath, "mockproj")},
+				{bin: shimPath, err: os.ErrNotExist},
+				{bin: receiptPath, err: os.ErrNotExist},
+			},
+			callRuntimeOS: true,
+			callMove:      true,
+			mockMoveDst:   filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-restore-to-migrated-from-path": {
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callReadFile:         true,
+			mockReadFile: []byte(
+				`{"name":"mockproj","migrated_from":` + strconv.Quote(filepath.Join(otherBinPath, "mockproj")) + `}`,
+			),
+			mockRemoveCalls: []mockRemoveCall{
+				{bin: filepath.Join(goBinPath, "mockproj")},
+				{bin: shimPath, err: os.ErrNotExist},
+				{bin: receiptPath},
+			},
+			callRuntimeOS: true,
+			callMove:      true,
+			mockMoveDst:   filepath.Join(otherBinPath, "mockproj"),
+		},
+		"error-get-binary-info": {
+			mockGetBuildInfoErr: toolchain.ErrBinaryNotFound,
+			expectedErr:         toolchain.ErrBinaryNotFound,
+		},
+		"error-binary-not-managed": {
+			mockGetSymlinkTarget: filepath.Join(otherBinPath, "mockproj"),
+			expectedErr:          manager.ErrBinaryNotManaged,
+		},
+		"error-move-binary": {
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			callReadFile:         true,
+			mockReadFileErr:      os.ErrNotExist,
+			mockRemoveCalls: []mockRemoveCall{
+				{bin: filepath.Join(goBinPath, "mockproj")},
 			},
+			callMove:    true,
+			mockMoveDst: filepath.Join(goBinPath, "mockproj"),
+			mockMoveErr: errors.New("unexpected error"),
 			expectedErr: errors.New("unexpected error"),
 		},
 	}
@@ -1805,92 +8802,309 @@ func TestGoBinaryManager_PruneBinary(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			fs := systemmocks.NewFileSystem(t)
+			runtime := systemmocks.NewRuntime(t)
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetBuildInfo(filepath.Join(goBinPath, "mockproj")).
+				Return(getBuildInfo("mockproj", "v0.1.0"), tc.mockGetBuildInfoErr).
+				Once()
+
+			if tc.mockGetBuildInfoErr == nil {
+				fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+					Return(tc.mockGetSymlinkTarget, nil).
+					Once()
+			}
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(receiptPath).
+					Return(tc.mockReadFile, tc.mockReadFileErr).
+					Once()
+			}
+
+			for _, call := range tc.mockRemoveCalls {
+				fs.EXPECT().Remove(call.bin).
+					Return(call.err).
+					Once()
+			}
+
+			if tc.callRuntimeOS {
+				runtime.EXPECT().OS().
+					Return("linux").
+					Once()
+			}
+
+			if tc.callMove {
+				fs.EXPECT().Move(filepath.Join(intBinPath, "mockproj@v0.1.0"), tc.mockMoveDst).
+					Return(tc.mockMoveErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, runtime, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.UnmigrateBinary(model.NewBinaryFromString("mockproj"))
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoBinaryManager_UnsetBinaryEnv(t *testing.T) {
+	workspace, err := system.NewWorkspace(
+		system.NewEnvironment(),
+		system.NewFileSystem(),
+		system.NewRuntime(),
+	)
+	require.NoError(t, err)
+
+	goBinPath := workspace.GetGoBinPath()
+	intBinPath := workspace.GetInternalBinPath()
+	receiptPath := filepath.Join(workspace.GetInternalReceiptPath(), "mockproj.json")
+	shimDir := workspace.GetInternalShimPath()
+	shimPath := filepath.Join(shimDir, "mockproj")
+
+	cases := map[string]struct {
+		names                []string
+		mockReadFile         []byte
+		mockWriteReceiptData []byte
+		mockRuntimeOSCalls   int
+		callWriteShim        bool
+		mockWriteShimData    []byte
+		callRemoveShim       bool
+		mockRemoveShimErr    error
+		expectedErr          error
+	}{
+		"success-unset-env-var": {
+			names:                []string{"TOOL_MODE"},
+			mockReadFile:         []byte(`{"name":"mockproj","env":["GOMEMLIMIT=1GiB","TOOL_MODE=fast"]}`),
+			mockWriteReceiptData: []byte("{\n  \"name\": \"mockproj\",\n  \"env\": [\n    \"GOMEMLIMIT=1GiB\"\n  ]\n}"),
+			mockRuntimeOSCalls:   2,
+			callWriteShim:        true,
+			mockWriteShimData: model.GetShimScript(
+				"linux", filepath.Join(goBinPath, "mockproj"), []string{"GOMEMLIMIT=1GiB"},
+			),
+		},
+		"success-unset-last-env-var": {
+			names:                []string{"GOMEMLIMIT"},
+			mockReadFile:         []byte(`{"name":"mockproj","env":["GOMEMLIMIT=1GiB"]}`),
+			mockWriteReceiptData: []byte("{\n  \"name\": \"mockproj\"\n}"),
+			mockRuntimeOSCalls:   1,
+			callRemoveShim:       true,
+		},
+		"success-unset-all-env-vars": {
+			mockReadFile:         []byte(`{"name":"mockproj","env":["GOMEMLIMIT=1GiB","TOOL_MODE=fast"]}`),
+			mockWriteReceiptData: []byte("{\n  \"name\": \"mockproj\"\n}"),
+			mockRuntimeOSCalls:   1,
+			callRemoveShim:       true,
+			mockRemoveShimErr:    os.ErrNotExist,
+		},
+		"error-remove-shim": {
+			mockReadFile:         []byte(`{"name":"mockproj","env":["GOMEMLIMIT=1GiB"]}`),
+			mockWriteReceiptData: []byte("{\n  \"name\": \"mockproj\"\n}"),
+			mockRuntimeOSCalls:   1,
+			callRemoveShim:       true,
+			mockRemoveShimErr:    os.ErrPermission,
+			expectedErr:          os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			runtime := systemmocks.NewRuntime(t)
 			toolchain := toolchainmocks.NewToolchain(t)
 
-			for _, call := range tc.mockListBinariesCalls {
-				fs.EXPECT().ListBinaries(call.path).
-					Return(call.binaries, call.err).
+			toolchain.EXPECT().GetBuildInfo(filepath.Join(goBinPath, "mockproj")).
+				Return(getBuildInfo("mockproj", "v0.1.0"), nil).
+				Once()
+
+			fs.EXPECT().GetSymlinkTarget(filepath.Join(goBinPath, "mockproj")).
+				Return(filepath.Join(intBinPath, "mockproj@v0.1.0"), nil).
+				Once()
+
+			fs.EXPECT().ReadFile(receiptPath).
+				Return(tc.mockReadFile, nil).
+				Once()
+
+			fs.EXPECT().WriteFile(receiptPath, tc.mockWriteReceiptData, os.FileMode(0600)).
+				Return(nil).
+				Once()
+
+			for range tc.mockRuntimeOSCalls {
+				runtime.EXPECT().OS().
+					Return("linux").
 					Once()
 			}
 
-			for _, call := range tc.mockGetBuildInfoCalls {
-				toolchain.EXPECT().GetBuildInfo(call.path).
-					Return(call.info, call.err).
+			if tc.callWriteShim {
+				fs.EXPECT().CreateDir(shimDir, os.FileMode(0700)).
+					Return(nil).
 					Once()
-			}
 
-			for _, call := range tc.mockGetSymlinkTargetCalls {
-				fs.EXPECT().GetSymlinkTarget(call.path).
-					Return(call.target, call.err).
+				fs.EXPECT().WriteFile(shimPath, tc.mockWriteShimData, os.FileMode(0700)).
+					Return(nil).
 					Once()
 			}
 
-			for _, call := range tc.mockRemoveCalls {
-				fs.EXPECT().Remove(call.bin).
-					Return(call.err).
+			if tc.callRemoveShim {
+				fs.EXPECT().Remove(shimPath).
+					Return(tc.mockRemoveShimErr).
 					Once()
 			}
 
-			binaryManager := manager.NewGoBinaryManager(fs, nil, toolchain, workspace)
-			err = binaryManager.PruneBinary(tc.bin)
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, runtime, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err = binaryManager.UnsetBinaryEnv(model.NewBinaryFromString("mockproj"), tc.names...)
 			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
 
-func TestGoBinaryManager_UninstallBinary(t *testing.T) {
+//nolint:gocognit
+func TestGoBinaryManager_UpdateExecutable(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
 
-	goBinPath := workspace.GetGoBinPath()
+	tempPath := workspace.GetInternalTempPath()
+	binTempDir := filepath.Join(tempPath, "gobin-123")
+	pkg := model.NewPackage("github.com/brunoribeiro127/gobin/cmd/gobin@v0.6.0")
 
 	cases := map[string]struct {
-		bin           model.Binary
-		mockRemoveErr error
-		expectedErr   error
+		goos               string
+		path               string
+		mockRuntimeOSCalls int
+		mockInstallErr     error
+		callStage          bool
+		mockStageErr       error
+		callRenameAside    bool
+		mockRenameAsideErr error
+		callReplace        bool
+		mockReplaceErr     error
+		expectedErr        error
 	}{
-		"success-unmanaged-binary": {
-			bin: model.NewBinaryFromString("mockproj"),
+		"success": {
+			goos:               "linux",
+			path:               "/usr/local/bin/gobin",
+			mockRuntimeOSCalls: 2,
+			callStage:          true,
+			callReplace:        true,
 		},
-		"success-managed-binary": {
-			bin: model.NewBinaryFromString("mockproj"),
+		"success-windows": {
+			goos:               "windows",
+			path:               "/usr/local/bin/gobin.exe",
+			mockRuntimeOSCalls: 2,
+			callStage:          true,
+			callRenameAside:    true,
+			callReplace:        true,
 		},
-		"error-binary-not-found": {
-			bin:           model.NewBinaryFromString("mockproj"),
-			mockRemoveErr: os.ErrNotExist,
-			expectedErr:   os.ErrNotExist,
+		"error-install": {
+			goos:           "linux",
+			path:           "/usr/local/bin/gobin",
+			mockInstallErr: toolchain.ErrBuildFailed,
+			expectedErr:    toolchain.ErrBuildFailed,
 		},
-		"error-remove-binary": {
-			bin:           model.NewBinaryFromString("mockproj"),
-			mockRemoveErr: errors.New("unexpected error"),
-			expectedErr:   errors.New("unexpected error"),
+		"error-stage": {
+			goos:               "linux",
+			path:               "/usr/local/bin/gobin",
+			mockRuntimeOSCalls: 1,
+			callStage:          true,
+			mockStageErr:       errors.New("unexpected error"),
+			expectedErr:        errors.New("unexpected error"),
+		},
+		"error-rename-aside": {
+			goos:               "windows",
+			path:               "/usr/local/bin/gobin.exe",
+			mockRuntimeOSCalls: 2,
+			callStage:          true,
+			callRenameAside:    true,
+			mockRenameAsideErr: errors.New("unexpected error"),
+			expectedErr:        errors.New("unexpected error"),
+		},
+		"error-replace": {
+			goos:               "linux",
+			path:               "/usr/local/bin/gobin",
+			mockRuntimeOSCalls: 3,
+			callStage:          true,
+			callReplace:        true,
+			mockReplaceErr:     errors.New("unexpected error"),
+			expectedErr:        errors.New("unexpected error"),
+		},
+		"error-replace-windows": {
+			goos:               "windows",
+			path:               "/usr/local/bin/gobin.exe",
+			mockRuntimeOSCalls: 3,
+			callStage:          true,
+			callRenameAside:    true,
+			callReplace:        true,
+			mockReplaceErr:     errors.New("unexpected error"),
+			expectedErr:        errors.New("unexpected error"),
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			fs := systemmocks.NewFileSystem(t)
+			runtime := systemmocks.NewRuntime(t)
+			toolchainMock := toolchainmocks.NewToolchain(t)
+
+			newPath := tc.path + ".new"
+			oldPath := tc.path + ".old"
+
+			if tc.mockRuntimeOSCalls > 0 {
+				runtime.EXPECT().OS().Return(tc.goos).Times(tc.mockRuntimeOSCalls)
+			}
 
-			fs.EXPECT().Remove(filepath.Join(goBinPath, tc.bin.String())).
-				Return(tc.mockRemoveErr).
+			fs.EXPECT().CreateTempDir(tempPath, "gobin-*").
+				Return(binTempDir, func() error { return nil }, nil).
 				Once()
 
-			binaryManager := manager.NewGoBinaryManager(fs, nil, nil, workspace)
-			err = binaryManager.UninstallBinary(tc.bin)
+			toolchainMock.EXPECT().Install(mock.Anything, binTempDir, pkg, model.BuildFlags{}, false).
+				Return(tc.mockInstallErr).
+				Once()
+
+			if tc.callStage {
+				fs.EXPECT().Move(filepath.Join(binTempDir, "gobin"+model.GetBinaryExtension(tc.goos)), newPath).
+					Return(tc.mockStageErr).
+					Once()
+			}
+
+			if tc.callRenameAside {
+				fs.EXPECT().Exists(oldPath).Return(false).Once()
+				fs.EXPECT().Move(tc.path, oldPath).Return(tc.mockRenameAsideErr).Once()
+			}
+
+			if tc.callReplace {
+				fs.EXPECT().Move(newPath, tc.path).Return(tc.mockReplaceErr).Once()
+			}
+
+			if tc.mockRenameAsideErr != nil || tc.mockReplaceErr != nil {
+				fs.EXPECT().Remove(newPath).Return(nil).Once()
+			}
+
+			if tc.mockReplaceErr != nil && tc.goos == "windows" {
+				fs.EXPECT().Move(oldPath, tc.path).Return(nil).Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, runtime, toolchainMock, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err := binaryManager.UpdateExecutable(context.Background(), tc.path, pkg)
 			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
 
-//nolint:gocognit
 func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 	workspace, err := system.NewWorkspace(
 		system.NewEnvironment(),
-		nil,
+		system.NewFileSystem(),
 		system.NewRuntime(),
 	)
 	require.NoError(t, err)
@@ -1898,11 +9112,17 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 	goBinPath := workspace.GetGoBinPath()
 	intBinPath := workspace.GetInternalBinPath()
 	tempPath := workspace.GetInternalTempPath()
+	receiptPath := workspace.GetInternalReceiptPath()
 
 	cases := map[string]struct {
 		binFullPath                     string
+		flags                           model.BuildFlags
 		majorUpgrade                    bool
 		rebuild                         bool
+		force                           bool
+		callReadFile                    bool
+		mockReadFile                    []byte
+		mockReadFileErr                 error
 		mockGetBuildInfo                *buildinfo.BuildInfo
 		mockGetBuildInfoErr             error
 		callGetSymlinkTarget            bool
@@ -1915,6 +9135,8 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 		mockCreateTempDirErr            error
 		callInstall                     bool
 		mockInstallPackage              model.Package
+		mockInstallDirect               bool
+		mockInstallBuildFlags           model.BuildFlags
 		mockInstallErr                  error
 		callGetBuildInfo2               bool
 		mockGetBuildInfo2Path           string
@@ -1928,6 +9150,8 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 		mockReplaceSymlinkSrc           string
 		mockReplaceSymlinkDst           string
 		mockReplaceSymlinkErr           error
+		callRecordBuildFlags            bool
+		expectedWarnings                []string
 		expectedErr                     error
 	}{
 		"success-no-minor-upgrade-available": {
@@ -1975,6 +9199,8 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
 				},
 			},
+			callReadFile:             true,
+			mockReadFileErr:          os.ErrNotExist,
 			callCreateTempDir:        true,
 			mockCreateTempDirPattern: "mockproj-*",
 			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
@@ -2003,6 +9229,8 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1.0")),
 				},
 			},
+			callReadFile:             true,
+			mockReadFileErr:          os.ErrNotExist,
 			callCreateTempDir:        true,
 			mockCreateTempDirPattern: "mockproj-*",
 			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
@@ -2039,6 +9267,8 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 					err:    toolchain.ErrModuleNotFound,
 				},
 			},
+			callReadFile:             true,
+			mockReadFileErr:          os.ErrNotExist,
 			callCreateTempDir:        true,
 			mockCreateTempDirPattern: "mockproj-*",
 			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
@@ -2067,6 +9297,8 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.2.0")),
 				},
 			},
+			callReadFile:             true,
+			mockReadFileErr:          os.ErrNotExist,
 			callCreateTempDir:        true,
 			mockCreateTempDirPattern: "mockproj-*",
 			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
@@ -2095,6 +9327,8 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.1")),
 				},
 			},
+			callReadFile:             true,
+			mockReadFileErr:          os.ErrNotExist,
 			callCreateTempDir:        true,
 			mockCreateTempDirPattern: "mockproj-*",
 			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
@@ -2110,6 +9344,130 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v0.1.1"),
 			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj-v0.1"),
 		},
+		"success-force-protected-binary": {
+			binFullPath:          filepath.Join(goBinPath, "mockproj"),
+			majorUpgrade:         false,
+			rebuild:              false,
+			force:                true,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v1.0.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1.0")),
+				},
+			},
+			callReadFile:             true,
+			mockReadFile:             []byte(`{"name":"mockproj","protected":true}`),
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			callGetBuildInfo2:        true,
+			mockGetBuildInfo2Path:    filepath.Join(tempPath, "mockproj-0123456789", "mockproj"),
+			mockGetBuildInfo2:        getBuildInfo("mockproj", "v1.1.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789", "mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+		},
+		"success-upgrade-with-recorded-build-flags": {
+			binFullPath:          filepath.Join(goBinPath, "mockproj"),
+			majorUpgrade:         false,
+			rebuild:              false,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v1.0.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1.0")),
+				},
+			},
+			callReadFile: true,
+			mockReadFile: []byte(
+				`{"name":"mockproj","build_flags":{"tags":"netgo","ldflags":"-s -w","cgo_enabled":"0"}}`,
+			),
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockInstallBuildFlags: model.BuildFlags{
+				Tags:       "netgo",
+				LDFlags:    "-s -w",
+				CGOEnabled: "0",
+			},
+			callGetBuildInfo2:     true,
+			mockGetBuildInfo2Path: filepath.Join(tempPath, "mockproj-0123456789", "mockproj"),
+			mockGetBuildInfo2:     getBuildInfo("mockproj", "v1.1.0"),
+			callMove:              true,
+			mockMoveSrc:           filepath.Join(tempPath, "mockproj-0123456789", "mockproj"),
+			mockMoveDst:           filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj"),
+			callRecordBuildFlags:  true,
+		},
+		"success-rebuild-with-variant-override": {
+			binFullPath:          filepath.Join(goBinPath, "mockproj"),
+			flags:                model.BuildFlags{GOAMD64: "v3"},
+			majorUpgrade:         false,
+			rebuild:              true,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v1.0.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				},
+			},
+			callReadFile: true,
+			mockReadFile: []byte(
+				`{"name":"mockproj","build_flags":{"cgo_enabled":"0","goamd64":"v2"}}`,
+			),
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockInstallBuildFlags: model.BuildFlags{
+				CGOEnabled: "0",
+				GOAMD64:    "v3",
+			},
+			callGetBuildInfo2:     true,
+			mockGetBuildInfo2Path: filepath.Join(tempPath, "mockproj-0123456789", "mockproj"),
+			mockGetBuildInfo2:     getBuildInfo("mockproj", "v1.0.0"),
+			callMove:              true,
+			mockMoveSrc:           filepath.Join(tempPath, "mockproj-0123456789", "mockproj"),
+			mockMoveDst:           filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			callReplaceSymlink:    true,
+			mockReplaceSymlinkSrc: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockReplaceSymlinkDst: filepath.Join(goBinPath, "mockproj"),
+			callRecordBuildFlags:  true,
+		},
+		"error-binary-protected": {
+			binFullPath:          filepath.Join(goBinPath, "mockproj"),
+			majorUpgrade:         false,
+			rebuild:              false,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v1.0.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1.0")),
+				},
+			},
+			callReadFile: true,
+			mockReadFile: []byte(`{"name":"mockproj","protected":true}`),
+			expectedErr:  manager.ErrBinaryProtected,
+		},
 		"error-get-binary-info": {
 			binFullPath:         filepath.Join(goBinPath, "mockproj"),
 			majorUpgrade:        false,
@@ -2117,6 +9475,45 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 			mockGetBuildInfoErr: toolchain.ErrBinaryBuiltWithoutGoModules,
 			expectedErr:         toolchain.ErrBinaryBuiltWithoutGoModules,
 		},
+		"success-upgrade-available-direct-fallback": {
+			binFullPath:          filepath.Join(goBinPath, "mockproj"),
+			majorUpgrade:         false,
+			rebuild:              false,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v1.0.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v1.0.0"),
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj"),
+					err:    toolchain.ErrModuleNotFound,
+				},
+				{
+					module:       model.NewLatestModule("example.com/mockorg/mockproj"),
+					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1.0")),
+					direct:       true,
+				},
+			},
+			callReadFile:             true,
+			mockReadFileErr:          os.ErrNotExist,
+			callCreateTempDir:        true,
+			mockCreateTempDirPattern: "mockproj-*",
+			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
+			callInstall:              true,
+			mockInstallPackage:       model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.1.0"),
+			mockInstallDirect:        true,
+			callGetBuildInfo2:        true,
+			mockGetBuildInfo2Path:    filepath.Join(tempPath, "mockproj-0123456789", "mockproj"),
+			mockGetBuildInfo2:        getBuildInfo("mockproj", "v1.1.0"),
+			callMove:                 true,
+			mockMoveSrc:              filepath.Join(tempPath, "mockproj-0123456789", "mockproj"),
+			mockMoveDst:              filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			callReplaceSymlink:       true,
+			mockReplaceSymlinkSrc:    filepath.Join(intBinPath, "mockproj@v1.1.0"),
+			mockReplaceSymlinkDst:    filepath.Join(goBinPath, "mockproj"),
+			expectedWarnings: []string{
+				"module example.com/mockorg/mockproj not found in the module proxy, resolved directly",
+			},
+		},
 		"error-get-binary-upgrade-info": {
 			binFullPath:          filepath.Join(goBinPath, "mockproj"),
 			majorUpgrade:         false,
@@ -2132,6 +9529,29 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 			},
 			expectedErr: toolchain.ErrModuleInfoNotAvailable,
 		},
+		"error-get-binary-upgrade-info-direct-fallback": {
+			binFullPath:          filepath.Join(goBinPath, "mockproj"),
+			majorUpgrade:         false,
+			rebuild:              false,
+			mockGetBuildInfo:     getBuildInfo("mockproj", "v0.1.0"),
+			callGetSymlinkTarget: true,
+			mockGetSymlinkTarget: filepath.Join(intBinPath, "mockproj@v0.1.0"),
+			mockGetLatestModuleVersionCalls: []mockGetLatestModuleVersionCall{
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj"),
+					err:    toolchain.ErrModuleNotFound,
+				},
+				{
+					module: model.NewLatestModule("example.com/mockorg/mockproj"),
+					err:    toolchain.ErrModuleNotFound,
+					direct: true,
+				},
+			},
+			expectedWarnings: []string{
+				"module example.com/mockorg/mockproj not found in the module proxy, resolved directly",
+			},
+			expectedErr: toolchain.ErrModuleNotFound,
+		},
 		"error-install-package": {
 			binFullPath:          filepath.Join(goBinPath, "mockproj"),
 			majorUpgrade:         false,
@@ -2145,6 +9565,8 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 					latestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1.0")),
 				},
 			},
+			callReadFile:             true,
+			mockReadFileErr:          os.ErrNotExist,
 			callCreateTempDir:        true,
 			mockCreateTempDirPattern: "mockproj-*",
 			mockCreateTempDirPath:    filepath.Join(tempPath, "mockproj-0123456789"),
@@ -2157,6 +9579,19 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			warnings := internal.NewWarnings()
+			warningsCtx := internal.WithWarnings(context.Background(), warnings)
+
+			var ctx, directCtx context.Context
+			if tc.mockGetBuildInfo != nil {
+				ctx = internal.WithLogAttrs(
+					warningsCtx,
+					slog.String("module", tc.mockGetBuildInfo.Main.Path),
+					slog.String("version", tc.mockGetBuildInfo.Main.Version),
+				)
+				directCtx = toolchain.WithDirect(ctx)
+			}
+
 			fs := systemmocks.NewFileSystem(t)
 			rt := systemmocks.NewRuntime(t)
 			toolchain := toolchainmocks.NewToolchain(t)
@@ -2172,21 +9607,38 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 			}
 
 			for _, call := range tc.mockGetLatestModuleVersionCalls {
-				toolchain.EXPECT().GetLatestModuleVersion(context.Background(), call.module).
+				callCtx := ctx
+				if call.direct {
+					callCtx = directCtx
+				}
+
+				toolchain.EXPECT().GetLatestModuleVersion(callCtx, call.module).
 					Return(call.latestModule, call.err).
 					Once()
 			}
 
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(filepath.Join(receiptPath, filepath.Base(tc.binFullPath)+".json")).
+					Return(tc.mockReadFile, tc.mockReadFileErr).
+					Once()
+			}
+
 			if tc.callCreateTempDir {
 				fs.EXPECT().CreateTempDir(tempPath, tc.mockCreateTempDirPattern).
 					Return(tc.mockCreateTempDirPath, func() error { return nil }, tc.mockCreateTempDirErr).Once()
 			}
 
 			if tc.callInstall {
+				installCtx := ctx
+				if tc.mockInstallDirect {
+					installCtx = directCtx
+				}
+
 				toolchain.EXPECT().Install(
-					context.Background(),
+					installCtx,
 					tc.mockCreateTempDirPath,
 					tc.mockInstallPackage,
+					tc.mockInstallBuildFlags,
 					tc.rebuild,
 				).Return(tc.mockInstallErr).Once()
 			}
@@ -2205,18 +9657,173 @@ func TestGoBinaryManager_UpgradeBinary(t *testing.T) {
 			}
 
 			if tc.callReplaceSymlink {
+				fs.EXPECT().GetSymlinkTarget(tc.mockReplaceSymlinkDst).
+					Return("", os.ErrNotExist).Once()
 				fs.EXPECT().ReplaceSymlink(tc.mockReplaceSymlinkSrc, tc.mockReplaceSymlinkDst).
 					Return(tc.mockReplaceSymlinkErr).Once()
 			}
 
-			binaryManager := manager.NewGoBinaryManager(fs, rt, toolchain, workspace)
+			sizeHistory := model.SizeHistory{
+				{Version: model.NewBinaryFromString(filepath.Base(tc.mockMoveDst)).Version, Size: 1000},
+			}
+
+			var sizeReceipt []byte
+			if tc.callReplaceSymlink && tc.mockReplaceSymlinkErr == nil {
+				pinName := filepath.Base(tc.mockReplaceSymlinkDst)
+				pinReceipt := model.NewReceipt(pinName)
+
+				var readErr error = os.ErrNotExist
+				if tc.mockReadFile != nil {
+					require.NoError(t, json.Unmarshal(tc.mockReadFile, &pinReceipt))
+					readErr = nil
+				}
+
+				pinReceipt.SizeHistory = sizeHistory
+
+				var marshalErr error
+				sizeReceipt, marshalErr = json.MarshalIndent(pinReceipt, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().GetFileSize(tc.mockMoveDst).
+					Return(1000, nil).
+					Once()
+				fs.EXPECT().ReadFile(filepath.Join(receiptPath, pinName+".json")).
+					Return(tc.mockReadFile, readErr).
+					Once()
+				fs.EXPECT().WriteFile(filepath.Join(receiptPath, pinName+".json"), sizeReceipt, os.FileMode(0600)).
+					Return(nil).
+					Once()
+			}
+
+			if tc.callRecordBuildFlags {
+				receipt, marshalErr := json.MarshalIndent(model.Receipt{
+					Name:        "mockproj",
+					BuildFlags:  tc.mockInstallBuildFlags,
+					SizeHistory: sizeHistory,
+				}, "", "  ")
+				require.NoError(t, marshalErr)
+
+				fs.EXPECT().ReadFile(filepath.Join(receiptPath, "mockproj.json")).
+					Return(sizeReceipt, nil).
+					Once()
+
+				fs.EXPECT().WriteFile(filepath.Join(receiptPath, "mockproj.json"), receipt, os.FileMode(0600)).
+					Return(nil).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, fs, nil, rt, toolchain, workspace, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
 			err = binaryManager.UpgradeBinary(
-				context.Background(),
+				warningsCtx,
 				tc.binFullPath,
+				tc.flags,
 				tc.majorUpgrade,
 				tc.rebuild,
+				tc.force,
+				"",
 			)
 			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedWarnings, warnings.Get())
+		})
+	}
+}
+
+func TestGoBinaryManager_VerifyModuleSum(t *testing.T) {
+	mod := model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0"))
+
+	cases := map[string]struct {
+		sum                    string
+		mockSumDBConfig        model.SumDBConfig
+		mockGetSumDBConfigErr  error
+		callLookupModuleSum    bool
+		mockLookupModuleSum    string
+		mockLookupModuleSumErr error
+		expectedErr            error
+	}{
+		"success": {
+			sum:                 "h1:mocksum=",
+			mockSumDBConfig:     model.SumDBConfig{SumDB: "sum.golang.org"},
+			callLookupModuleSum: true,
+			mockLookupModuleSum: "h1:mocksum=",
+		},
+		"error-sum-mismatch": {
+			sum:                 "h1:mocksum=",
+			mockSumDBConfig:     model.SumDBConfig{SumDB: "sum.golang.org"},
+			callLookupModuleSum: true,
+			mockLookupModuleSum: "h1:othersum=",
+			expectedErr: fmt.Errorf(
+				"%w: recorded h1:mocksum=, checksum database h1:othersum=", manager.ErrModuleSumMismatch,
+			),
+		},
+		"error-no-sum": {
+			mockSumDBConfig: model.SumDBConfig{SumDB: "sum.golang.org"},
+			expectedErr:     fmt.Errorf("%w: no module sum, likely built locally", manager.ErrModuleSumNotVerifiable),
+		},
+		"error-sumdb-disabled": {
+			sum:             "h1:mocksum=",
+			mockSumDBConfig: model.SumDBConfig{SumDB: "off"},
+			expectedErr: fmt.Errorf(
+				"%w: checksum database disabled (GOSUMDB=off)", manager.ErrModuleSumNotVerifiable,
+			),
+		},
+		"error-module-excluded": {
+			sum:             "h1:mocksum=",
+			mockSumDBConfig: model.SumDBConfig{SumDB: "sum.golang.org", NoSumDB: "example.com/*"},
+			expectedErr: fmt.Errorf(
+				"%w: module excluded from the checksum database (GONOSUMDB=example.com/*)",
+				manager.ErrModuleSumNotVerifiable,
+			),
+		},
+		"error-sum-not-found": {
+			sum:                    "h1:mocksum=",
+			mockSumDBConfig:        model.SumDBConfig{SumDB: "sum.golang.org"},
+			callLookupModuleSum:    true,
+			mockLookupModuleSumErr: toolchain.ErrModuleSumNotFound,
+			expectedErr: fmt.Errorf(
+				"%w: not found in the checksum database https://sum.golang.org", manager.ErrModuleSumNotVerifiable,
+			),
+		},
+		"error-get-sumdb-config": {
+			sum:                   "h1:mocksum=",
+			mockGetSumDBConfigErr: errors.New("unexpected error"),
+			expectedErr:           errors.New("unexpected error"),
+		},
+		"error-lookup-module-sum": {
+			sum:                    "h1:mocksum=",
+			mockSumDBConfig:        model.SumDBConfig{SumDB: "sum.golang.org"},
+			callLookupModuleSum:    true,
+			mockLookupModuleSumErr: errors.New("unexpected error"),
+			expectedErr:            errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchainmocks.NewToolchain(t)
+
+			toolchain.EXPECT().GetSumDBConfig(context.Background()).
+				Return(tc.mockSumDBConfig, tc.mockGetSumDBConfigErr).
+				Once()
+
+			if tc.callLookupModuleSum {
+				toolchain.EXPECT().LookupModuleSum(context.Background(), tc.mockSumDBConfig, mod).
+					Return(tc.mockLookupModuleSum, tc.mockLookupModuleSumErr).
+					Once()
+			}
+
+			binaryManager := manager.NewGoBinaryManager(
+				nil, nil, nil, nil, toolchain, nil, model.NewDefaultPinFormat(), model.PinModeSymlink,
+				model.SigningConfig{},
+			)
+			err := binaryManager.VerifyModuleSum(context.Background(), mod, tc.sum)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
@@ -2228,7 +9835,7 @@ func getBinaryInfo(
 ) model.BinaryInfo {
 	packagePath := "example.com/mockorg/mockproj/cmd/" + name
 	modulePath := "example.com/mockorg/mockproj"
-	if major := semver.Major(version); major != "v0" && major != "v1" {
+	if major := semver.Major(version); major != "v0" && major != "v1" && semver.Build(version) != "+incompatible" {
 		packagePath = modulePath + "/" + major + "/cmd/" + name
 		modulePath = modulePath + "/" + major
 	}
@@ -2256,7 +9863,8 @@ func getBinaryInfo(
 		OS:          "darwin",
 		Arch:        "arm64",
 		Feature:     "v8.0",
-		EnvVars:     []string{"CGO_ENABLED=1"},
+		EnvVars:     []string{"GOARM64=v8.0", "CGO_ENABLED=1"},
+		BuildFlags:  model.BuildFlags{CGOEnabled: "1", GOARM64: "v8.0"},
 		IsManaged:   managed,
 		IsPinned:    pinned,
 	}
@@ -38,6 +38,162 @@ func (_m *BinaryManager) EXPECT() *BinaryManager_Expecter {
 	return &BinaryManager_Expecter{mock: &_m.Mock}
 }
 
+// BuildPackage provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) BuildPackage(ctx context.Context, pkg model.Package, platform string, flags model.BuildFlags, dir string) (string, error) {
+	ret := _mock.Called(ctx, pkg, platform, flags, dir)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildPackage")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package, string, model.BuildFlags, string) (string, error)); ok {
+		return returnFunc(ctx, pkg, platform, flags, dir)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package, string, model.BuildFlags, string) string); ok {
+		r0 = returnFunc(ctx, pkg, platform, flags, dir)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Package, string, model.BuildFlags, string) error); ok {
+		r1 = returnFunc(ctx, pkg, platform, flags, dir)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_BuildPackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildPackage'
+type BinaryManager_BuildPackage_Call struct {
+	*mock.Call
+}
+
+// BuildPackage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pkg model.Package
+//   - platform string
+//   - flags model.BuildFlags
+//   - dir string
+func (_e *BinaryManager_Expecter) BuildPackage(ctx interface{}, pkg interface{}, platform interface{}, flags interface{}, dir interface{}) *BinaryManager_BuildPackage_Call {
+	return &BinaryManager_BuildPackage_Call{Call: _e.mock.On("BuildPackage", ctx, pkg, platform, flags, dir)}
+}
+
+func (_c *BinaryManager_BuildPackage_Call) Run(run func(ctx context.Context, pkg model.Package, platform string, flags model.BuildFlags, dir string)) *BinaryManager_BuildPackage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Package
+		if args[1] != nil {
+			arg1 = args[1].(model.Package)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 model.BuildFlags
+		if args[3] != nil {
+			arg3 = args[3].(model.BuildFlags)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_BuildPackage_Call) Return(s string, err error) *BinaryManager_BuildPackage_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *BinaryManager_BuildPackage_Call) RunAndReturn(run func(ctx context.Context, pkg model.Package, platform string, flags model.BuildFlags, dir string) (string, error)) *BinaryManager_BuildPackage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BundlePackages provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) BundlePackages(ctx context.Context, path string, pkgs ...model.Package) error {
+	var tmpRet mock.Arguments
+	if len(pkgs) > 0 {
+		tmpRet = _mock.Called(ctx, path, pkgs)
+	} else {
+		tmpRet = _mock.Called(ctx, path)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for BundlePackages")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, ...model.Package) error); ok {
+		r0 = returnFunc(ctx, path, pkgs...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_BundlePackages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BundlePackages'
+type BinaryManager_BundlePackages_Call struct {
+	*mock.Call
+}
+
+// BundlePackages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+//   - pkgs ...model.Package
+func (_e *BinaryManager_Expecter) BundlePackages(ctx interface{}, path interface{}, pkgs ...interface{}) *BinaryManager_BundlePackages_Call {
+	return &BinaryManager_BundlePackages_Call{Call: _e.mock.On("BundlePackages",
+		append([]interface{}{ctx, path}, pkgs...)...)}
+}
+
+func (_c *BinaryManager_BundlePackages_Call) Run(run func(ctx context.Context, path string, pkgs ...model.Package)) *BinaryManager_BundlePackages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []model.Package
+		var variadicArgs []model.Package
+		if len(args) > 2 {
+			variadicArgs = args[2].([]model.Package)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_BundlePackages_Call) Return(err error) *BinaryManager_BundlePackages_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_BundlePackages_Call) RunAndReturn(run func(ctx context.Context, path string, pkgs ...model.Package) error) *BinaryManager_BundlePackages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DiagnoseBinary provides a mock function for the type BinaryManager
 func (_mock *BinaryManager) DiagnoseBinary(ctx context.Context, path string) (model.BinaryDiagnostic, error) {
 	ret := _mock.Called(ctx, path)
@@ -104,6 +260,196 @@ func (_c *BinaryManager_DiagnoseBinary_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// DiagnoseHTTPProxies provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) DiagnoseHTTPProxies(ctx context.Context) ([]model.HTTPRoute, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiagnoseHTTPProxies")
+	}
+
+	var r0 []model.HTTPRoute
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model.HTTPRoute, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model.HTTPRoute); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.HTTPRoute)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_DiagnoseHTTPProxies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiagnoseHTTPProxies'
+type BinaryManager_DiagnoseHTTPProxies_Call struct {
+	*mock.Call
+}
+
+// DiagnoseHTTPProxies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BinaryManager_Expecter) DiagnoseHTTPProxies(ctx interface{}) *BinaryManager_DiagnoseHTTPProxies_Call {
+	return &BinaryManager_DiagnoseHTTPProxies_Call{Call: _e.mock.On("DiagnoseHTTPProxies", ctx)}
+}
+
+func (_c *BinaryManager_DiagnoseHTTPProxies_Call) Run(run func(ctx context.Context)) *BinaryManager_DiagnoseHTTPProxies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_DiagnoseHTTPProxies_Call) Return(hTTPRoutes []model.HTTPRoute, err error) *BinaryManager_DiagnoseHTTPProxies_Call {
+	_c.Call.Return(hTTPRoutes, err)
+	return _c
+}
+
+func (_c *BinaryManager_DiagnoseHTTPProxies_Call) RunAndReturn(run func(ctx context.Context) ([]model.HTTPRoute, error)) *BinaryManager_DiagnoseHTTPProxies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DiagnoseNetwork provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) DiagnoseNetwork(ctx context.Context) ([]model.ProxyHealth, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiagnoseNetwork")
+	}
+
+	var r0 []model.ProxyHealth
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model.ProxyHealth, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model.ProxyHealth); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ProxyHealth)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_DiagnoseNetwork_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiagnoseNetwork'
+type BinaryManager_DiagnoseNetwork_Call struct {
+	*mock.Call
+}
+
+// DiagnoseNetwork is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BinaryManager_Expecter) DiagnoseNetwork(ctx interface{}) *BinaryManager_DiagnoseNetwork_Call {
+	return &BinaryManager_DiagnoseNetwork_Call{Call: _e.mock.On("DiagnoseNetwork", ctx)}
+}
+
+func (_c *BinaryManager_DiagnoseNetwork_Call) Run(run func(ctx context.Context)) *BinaryManager_DiagnoseNetwork_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_DiagnoseNetwork_Call) Return(proxyHealths []model.ProxyHealth, err error) *BinaryManager_DiagnoseNetwork_Call {
+	_c.Call.Return(proxyHealths, err)
+	return _c
+}
+
+func (_c *BinaryManager_DiagnoseNetwork_Call) RunAndReturn(run func(ctx context.Context) ([]model.ProxyHealth, error)) *BinaryManager_DiagnoseNetwork_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EstimateDownloadSize provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) EstimateDownloadSize(ctx context.Context, packages ...model.Package) model.DownloadEstimate {
+	var tmpRet mock.Arguments
+	if len(packages) > 0 {
+		tmpRet = _mock.Called(ctx, packages)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateDownloadSize")
+	}
+
+	var r0 model.DownloadEstimate
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...model.Package) model.DownloadEstimate); ok {
+		r0 = returnFunc(ctx, packages...)
+	} else {
+		r0 = ret.Get(0).(model.DownloadEstimate)
+	}
+	return r0
+}
+
+// BinaryManager_EstimateDownloadSize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateDownloadSize'
+type BinaryManager_EstimateDownloadSize_Call struct {
+	*mock.Call
+}
+
+// EstimateDownloadSize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - packages ...model.Package
+func (_e *BinaryManager_Expecter) EstimateDownloadSize(ctx interface{}, packages ...interface{}) *BinaryManager_EstimateDownloadSize_Call {
+	return &BinaryManager_EstimateDownloadSize_Call{Call: _e.mock.On("EstimateDownloadSize",
+		append([]interface{}{ctx}, packages...)...)}
+}
+
+func (_c *BinaryManager_EstimateDownloadSize_Call) Run(run func(ctx context.Context, packages ...model.Package)) *BinaryManager_EstimateDownloadSize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []model.Package
+		var variadicArgs []model.Package
+		if len(args) > 1 {
+			variadicArgs = args[1].([]model.Package)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_EstimateDownloadSize_Call) Return(downloadEstimate model.DownloadEstimate) *BinaryManager_EstimateDownloadSize_Call {
+	_c.Call.Return(downloadEstimate)
+	return _c
+}
+
+func (_c *BinaryManager_EstimateDownloadSize_Call) RunAndReturn(run func(ctx context.Context, packages ...model.Package) model.DownloadEstimate) *BinaryManager_EstimateDownloadSize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAllBinaryInfos provides a mock function for the type BinaryManager
 func (_mock *BinaryManager) GetAllBinaryInfos(managed bool) ([]model.BinaryInfo, error) {
 	ret := _mock.Called(managed)
@@ -166,113 +512,2893 @@ func (_c *BinaryManager_GetAllBinaryInfos_Call) RunAndReturn(run func(managed bo
 	return _c
 }
 
-// GetBinaryInfo provides a mock function for the type BinaryManager
-func (_mock *BinaryManager) GetBinaryInfo(path string) (model.BinaryInfo, error) {
+// GetBinaryBuild provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetBinaryBuild(path string) (model.BinaryBuild, error) {
 	ret := _mock.Called(path)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBinaryInfo")
+		panic("no return value specified for GetBinaryBuild")
 	}
 
-	var r0 model.BinaryInfo
+	var r0 model.BinaryBuild
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (model.BinaryInfo, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(string) (model.BinaryBuild, error)); ok {
 		return returnFunc(path)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) model.BinaryInfo); ok {
+	if returnFunc, ok := ret.Get(0).(func(string) model.BinaryBuild); ok {
 		r0 = returnFunc(path)
 	} else {
-		r0 = ret.Get(0).(model.BinaryInfo)
+		r0 = ret.Get(0).(model.BinaryBuild)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetBinaryBuild_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinaryBuild'
+type BinaryManager_GetBinaryBuild_Call struct {
+	*mock.Call
+}
+
+// GetBinaryBuild is a helper method to define mock.On call
+//   - path string
+func (_e *BinaryManager_Expecter) GetBinaryBuild(path interface{}) *BinaryManager_GetBinaryBuild_Call {
+	return &BinaryManager_GetBinaryBuild_Call{Call: _e.mock.On("GetBinaryBuild", path)}
+}
+
+func (_c *BinaryManager_GetBinaryBuild_Call) Run(run func(path string)) *BinaryManager_GetBinaryBuild_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryBuild_Call) Return(binaryBuild model.BinaryBuild, err error) *BinaryManager_GetBinaryBuild_Call {
+	_c.Call.Return(binaryBuild, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryBuild_Call) RunAndReturn(run func(path string) (model.BinaryBuild, error)) *BinaryManager_GetBinaryBuild_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBinaryEnv provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetBinaryEnv(bin model.Binary) ([]string, error) {
+	ret := _mock.Called(bin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBinaryEnv")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) ([]string, error)); ok {
+		return returnFunc(bin)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) []string); ok {
+		r0 = returnFunc(bin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Binary) error); ok {
+		r1 = returnFunc(bin)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetBinaryEnv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinaryEnv'
+type BinaryManager_GetBinaryEnv_Call struct {
+	*mock.Call
+}
+
+// GetBinaryEnv is a helper method to define mock.On call
+//   - bin model.Binary
+func (_e *BinaryManager_Expecter) GetBinaryEnv(bin interface{}) *BinaryManager_GetBinaryEnv_Call {
+	return &BinaryManager_GetBinaryEnv_Call{Call: _e.mock.On("GetBinaryEnv", bin)}
+}
+
+func (_c *BinaryManager_GetBinaryEnv_Call) Run(run func(bin model.Binary)) *BinaryManager_GetBinaryEnv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryEnv_Call) Return(strings []string, err error) *BinaryManager_GetBinaryEnv_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryEnv_Call) RunAndReturn(run func(bin model.Binary) ([]string, error)) *BinaryManager_GetBinaryEnv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBinaryInfo provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetBinaryInfo(path string) (model.BinaryInfo, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBinaryInfo")
+	}
+
+	var r0 model.BinaryInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (model.BinaryInfo, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) model.BinaryInfo); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Get(0).(model.BinaryInfo)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetBinaryInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinaryInfo'
+type BinaryManager_GetBinaryInfo_Call struct {
+	*mock.Call
+}
+
+// GetBinaryInfo is a helper method to define mock.On call
+//   - path string
+func (_e *BinaryManager_Expecter) GetBinaryInfo(path interface{}) *BinaryManager_GetBinaryInfo_Call {
+	return &BinaryManager_GetBinaryInfo_Call{Call: _e.mock.On("GetBinaryInfo", path)}
+}
+
+func (_c *BinaryManager_GetBinaryInfo_Call) Run(run func(path string)) *BinaryManager_GetBinaryInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryInfo_Call) Return(binaryInfo model.BinaryInfo, err error) *BinaryManager_GetBinaryInfo_Call {
+	_c.Call.Return(binaryInfo, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryInfo_Call) RunAndReturn(run func(path string) (model.BinaryInfo, error)) *BinaryManager_GetBinaryInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBinaryOrigin provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetBinaryOrigin(bin model.Binary) (model.BinaryOrigin, error) {
+	ret := _mock.Called(bin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBinaryOrigin")
+	}
+
+	var r0 model.BinaryOrigin
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) (model.BinaryOrigin, error)); ok {
+		return returnFunc(bin)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) model.BinaryOrigin); ok {
+		r0 = returnFunc(bin)
+	} else {
+		r0 = ret.Get(0).(model.BinaryOrigin)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Binary) error); ok {
+		r1 = returnFunc(bin)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetBinaryOrigin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinaryOrigin'
+type BinaryManager_GetBinaryOrigin_Call struct {
+	*mock.Call
+}
+
+// GetBinaryOrigin is a helper method to define mock.On call
+//   - bin model.Binary
+func (_e *BinaryManager_Expecter) GetBinaryOrigin(bin interface{}) *BinaryManager_GetBinaryOrigin_Call {
+	return &BinaryManager_GetBinaryOrigin_Call{Call: _e.mock.On("GetBinaryOrigin", bin)}
+}
+
+func (_c *BinaryManager_GetBinaryOrigin_Call) Run(run func(bin model.Binary)) *BinaryManager_GetBinaryOrigin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryOrigin_Call) Return(binaryOrigin model.BinaryOrigin, err error) *BinaryManager_GetBinaryOrigin_Call {
+	_c.Call.Return(binaryOrigin, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryOrigin_Call) RunAndReturn(run func(bin model.Binary) (model.BinaryOrigin, error)) *BinaryManager_GetBinaryOrigin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBinaryRepository provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetBinaryRepository(ctx context.Context, bin model.Binary) (string, error) {
+	ret := _mock.Called(ctx, bin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBinaryRepository")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Binary) (string, error)); ok {
+		return returnFunc(ctx, bin)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Binary) string); ok {
+		r0 = returnFunc(ctx, bin)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Binary) error); ok {
+		r1 = returnFunc(ctx, bin)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetBinaryRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinaryRepository'
+type BinaryManager_GetBinaryRepository_Call struct {
+	*mock.Call
+}
+
+// GetBinaryRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bin model.Binary
+func (_e *BinaryManager_Expecter) GetBinaryRepository(ctx interface{}, bin interface{}) *BinaryManager_GetBinaryRepository_Call {
+	return &BinaryManager_GetBinaryRepository_Call{Call: _e.mock.On("GetBinaryRepository", ctx, bin)}
+}
+
+func (_c *BinaryManager_GetBinaryRepository_Call) Run(run func(ctx context.Context, bin model.Binary)) *BinaryManager_GetBinaryRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Binary
+		if args[1] != nil {
+			arg1 = args[1].(model.Binary)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryRepository_Call) Return(s string, err error) *BinaryManager_GetBinaryRepository_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryRepository_Call) RunAndReturn(run func(ctx context.Context, bin model.Binary) (string, error)) *BinaryManager_GetBinaryRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBinarySBOM provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetBinarySBOM(path string) (model.SBOM, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBinarySBOM")
+	}
+
+	var r0 model.SBOM
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (model.SBOM, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) model.SBOM); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Get(0).(model.SBOM)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetBinarySBOM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinarySBOM'
+type BinaryManager_GetBinarySBOM_Call struct {
+	*mock.Call
+}
+
+// GetBinarySBOM is a helper method to define mock.On call
+//   - path string
+func (_e *BinaryManager_Expecter) GetBinarySBOM(path interface{}) *BinaryManager_GetBinarySBOM_Call {
+	return &BinaryManager_GetBinarySBOM_Call{Call: _e.mock.On("GetBinarySBOM", path)}
+}
+
+func (_c *BinaryManager_GetBinarySBOM_Call) Run(run func(path string)) *BinaryManager_GetBinarySBOM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetBinarySBOM_Call) Return(sBOM model.SBOM, err error) *BinaryManager_GetBinarySBOM_Call {
+	_c.Call.Return(sBOM, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetBinarySBOM_Call) RunAndReturn(run func(path string) (model.SBOM, error)) *BinaryManager_GetBinarySBOM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBinarySizeHistory provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetBinarySizeHistory(bin model.Binary) (model.SizeHistory, error) {
+	ret := _mock.Called(bin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBinarySizeHistory")
+	}
+
+	var r0 model.SizeHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) (model.SizeHistory, error)); ok {
+		return returnFunc(bin)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) model.SizeHistory); ok {
+		r0 = returnFunc(bin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.SizeHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Binary) error); ok {
+		r1 = returnFunc(bin)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetBinarySizeHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinarySizeHistory'
+type BinaryManager_GetBinarySizeHistory_Call struct {
+	*mock.Call
+}
+
+// GetBinarySizeHistory is a helper method to define mock.On call
+//   - bin model.Binary
+func (_e *BinaryManager_Expecter) GetBinarySizeHistory(bin interface{}) *BinaryManager_GetBinarySizeHistory_Call {
+	return &BinaryManager_GetBinarySizeHistory_Call{Call: _e.mock.On("GetBinarySizeHistory", bin)}
+}
+
+func (_c *BinaryManager_GetBinarySizeHistory_Call) Run(run func(bin model.Binary)) *BinaryManager_GetBinarySizeHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetBinarySizeHistory_Call) Return(sizeHistory model.SizeHistory, err error) *BinaryManager_GetBinarySizeHistory_Call {
+	_c.Call.Return(sizeHistory, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetBinarySizeHistory_Call) RunAndReturn(run func(bin model.Binary) (model.SizeHistory, error)) *BinaryManager_GetBinarySizeHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBinaryUpgradeInfo provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetBinaryUpgradeInfo(ctx context.Context, info model.BinaryInfo, checkMajor bool) (model.BinaryUpgradeInfo, error) {
+	ret := _mock.Called(ctx, info, checkMajor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBinaryUpgradeInfo")
+	}
+
+	var r0 model.BinaryUpgradeInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.BinaryInfo, bool) (model.BinaryUpgradeInfo, error)); ok {
+		return returnFunc(ctx, info, checkMajor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.BinaryInfo, bool) model.BinaryUpgradeInfo); ok {
+		r0 = returnFunc(ctx, info, checkMajor)
+	} else {
+		r0 = ret.Get(0).(model.BinaryUpgradeInfo)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.BinaryInfo, bool) error); ok {
+		r1 = returnFunc(ctx, info, checkMajor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetBinaryUpgradeInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinaryUpgradeInfo'
+type BinaryManager_GetBinaryUpgradeInfo_Call struct {
+	*mock.Call
+}
+
+// GetBinaryUpgradeInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - info model.BinaryInfo
+//   - checkMajor bool
+func (_e *BinaryManager_Expecter) GetBinaryUpgradeInfo(ctx interface{}, info interface{}, checkMajor interface{}) *BinaryManager_GetBinaryUpgradeInfo_Call {
+	return &BinaryManager_GetBinaryUpgradeInfo_Call{Call: _e.mock.On("GetBinaryUpgradeInfo", ctx, info, checkMajor)}
+}
+
+func (_c *BinaryManager_GetBinaryUpgradeInfo_Call) Run(run func(ctx context.Context, info model.BinaryInfo, checkMajor bool)) *BinaryManager_GetBinaryUpgradeInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.BinaryInfo
+		if args[1] != nil {
+			arg1 = args[1].(model.BinaryInfo)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryUpgradeInfo_Call) Return(binaryUpgradeInfo model.BinaryUpgradeInfo, err error) *BinaryManager_GetBinaryUpgradeInfo_Call {
+	_c.Call.Return(binaryUpgradeInfo, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetBinaryUpgradeInfo_Call) RunAndReturn(run func(ctx context.Context, info model.BinaryInfo, checkMajor bool) (model.BinaryUpgradeInfo, error)) *BinaryManager_GetBinaryUpgradeInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBrokenPins provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetBrokenPins() ([]string, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBrokenPins")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetBrokenPins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBrokenPins'
+type BinaryManager_GetBrokenPins_Call struct {
+	*mock.Call
+}
+
+// GetBrokenPins is a helper method to define mock.On call
+func (_e *BinaryManager_Expecter) GetBrokenPins() *BinaryManager_GetBrokenPins_Call {
+	return &BinaryManager_GetBrokenPins_Call{Call: _e.mock.On("GetBrokenPins")}
+}
+
+func (_c *BinaryManager_GetBrokenPins_Call) Run(run func()) *BinaryManager_GetBrokenPins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetBrokenPins_Call) Return(strings []string, err error) *BinaryManager_GetBrokenPins_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetBrokenPins_Call) RunAndReturn(run func() ([]string, error)) *BinaryManager_GetBrokenPins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCacheArtifacts provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetCacheArtifacts() ([]model.CacheArtifact, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCacheArtifacts")
+	}
+
+	var r0 []model.CacheArtifact
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]model.CacheArtifact, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []model.CacheArtifact); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CacheArtifact)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetCacheArtifacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCacheArtifacts'
+type BinaryManager_GetCacheArtifacts_Call struct {
+	*mock.Call
+}
+
+// GetCacheArtifacts is a helper method to define mock.On call
+func (_e *BinaryManager_Expecter) GetCacheArtifacts() *BinaryManager_GetCacheArtifacts_Call {
+	return &BinaryManager_GetCacheArtifacts_Call{Call: _e.mock.On("GetCacheArtifacts")}
+}
+
+func (_c *BinaryManager_GetCacheArtifacts_Call) Run(run func()) *BinaryManager_GetCacheArtifacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetCacheArtifacts_Call) Return(cacheArtifacts []model.CacheArtifact, err error) *BinaryManager_GetCacheArtifacts_Call {
+	_c.Call.Return(cacheArtifacts, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetCacheArtifacts_Call) RunAndReturn(run func() ([]model.CacheArtifact, error)) *BinaryManager_GetCacheArtifacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCrossBinaryInfos provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetCrossBinaryInfos() ([]model.BinaryInfo, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCrossBinaryInfos")
+	}
+
+	var r0 []model.BinaryInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]model.BinaryInfo, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []model.BinaryInfo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.BinaryInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetCrossBinaryInfos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCrossBinaryInfos'
+type BinaryManager_GetCrossBinaryInfos_Call struct {
+	*mock.Call
+}
+
+// GetCrossBinaryInfos is a helper method to define mock.On call
+func (_e *BinaryManager_Expecter) GetCrossBinaryInfos() *BinaryManager_GetCrossBinaryInfos_Call {
+	return &BinaryManager_GetCrossBinaryInfos_Call{Call: _e.mock.On("GetCrossBinaryInfos")}
+}
+
+func (_c *BinaryManager_GetCrossBinaryInfos_Call) Run(run func()) *BinaryManager_GetCrossBinaryInfos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetCrossBinaryInfos_Call) Return(binaryInfos []model.BinaryInfo, err error) *BinaryManager_GetCrossBinaryInfos_Call {
+	_c.Call.Return(binaryInfos, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetCrossBinaryInfos_Call) RunAndReturn(run func() ([]model.BinaryInfo, error)) *BinaryManager_GetCrossBinaryInfos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCrossOS provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetCrossOS() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCrossOS")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// BinaryManager_GetCrossOS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCrossOS'
+type BinaryManager_GetCrossOS_Call struct {
+	*mock.Call
+}
+
+// GetCrossOS is a helper method to define mock.On call
+func (_e *BinaryManager_Expecter) GetCrossOS() *BinaryManager_GetCrossOS_Call {
+	return &BinaryManager_GetCrossOS_Call{Call: _e.mock.On("GetCrossOS")}
+}
+
+func (_c *BinaryManager_GetCrossOS_Call) Run(run func()) *BinaryManager_GetCrossOS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetCrossOS_Call) Return(s string) *BinaryManager_GetCrossOS_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *BinaryManager_GetCrossOS_Call) RunAndReturn(run func() string) *BinaryManager_GetCrossOS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDebugInfoPath provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetDebugInfoPath(ctx context.Context, path string) (string, error) {
+	ret := _mock.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDebugInfoPath")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetDebugInfoPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDebugInfoPath'
+type BinaryManager_GetDebugInfoPath_Call struct {
+	*mock.Call
+}
+
+// GetDebugInfoPath is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *BinaryManager_Expecter) GetDebugInfoPath(ctx interface{}, path interface{}) *BinaryManager_GetDebugInfoPath_Call {
+	return &BinaryManager_GetDebugInfoPath_Call{Call: _e.mock.On("GetDebugInfoPath", ctx, path)}
+}
+
+func (_c *BinaryManager_GetDebugInfoPath_Call) Run(run func(ctx context.Context, path string)) *BinaryManager_GetDebugInfoPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetDebugInfoPath_Call) Return(s string, err error) *BinaryManager_GetDebugInfoPath_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetDebugInfoPath_Call) RunAndReturn(run func(ctx context.Context, path string) (string, error)) *BinaryManager_GetDebugInfoPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpiredBinaries provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetExpiredBinaries(policy model.RetentionConfig) ([]model.Binary, error) {
+	ret := _mock.Called(policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredBinaries")
+	}
+
+	var r0 []model.Binary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.RetentionConfig) ([]model.Binary, error)); ok {
+		return returnFunc(policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.RetentionConfig) []model.Binary); ok {
+		r0 = returnFunc(policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Binary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.RetentionConfig) error); ok {
+		r1 = returnFunc(policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetExpiredBinaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredBinaries'
+type BinaryManager_GetExpiredBinaries_Call struct {
+	*mock.Call
+}
+
+// GetExpiredBinaries is a helper method to define mock.On call
+//   - policy model.RetentionConfig
+func (_e *BinaryManager_Expecter) GetExpiredBinaries(policy interface{}) *BinaryManager_GetExpiredBinaries_Call {
+	return &BinaryManager_GetExpiredBinaries_Call{Call: _e.mock.On("GetExpiredBinaries", policy)}
+}
+
+func (_c *BinaryManager_GetExpiredBinaries_Call) Run(run func(policy model.RetentionConfig)) *BinaryManager_GetExpiredBinaries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.RetentionConfig
+		if args[0] != nil {
+			arg0 = args[0].(model.RetentionConfig)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetExpiredBinaries_Call) Return(binarys []model.Binary, err error) *BinaryManager_GetExpiredBinaries_Call {
+	_c.Call.Return(binarys, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetExpiredBinaries_Call) RunAndReturn(run func(policy model.RetentionConfig) ([]model.Binary, error)) *BinaryManager_GetExpiredBinaries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGoEnv provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetGoEnv(ctx context.Context) (model.GoEnv, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGoEnv")
+	}
+
+	var r0 model.GoEnv
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.GoEnv, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.GoEnv); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.GoEnv)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetGoEnv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGoEnv'
+type BinaryManager_GetGoEnv_Call struct {
+	*mock.Call
+}
+
+// GetGoEnv is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BinaryManager_Expecter) GetGoEnv(ctx interface{}) *BinaryManager_GetGoEnv_Call {
+	return &BinaryManager_GetGoEnv_Call{Call: _e.mock.On("GetGoEnv", ctx)}
+}
+
+func (_c *BinaryManager_GetGoEnv_Call) Run(run func(ctx context.Context)) *BinaryManager_GetGoEnv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetGoEnv_Call) Return(goEnv model.GoEnv, err error) *BinaryManager_GetGoEnv_Call {
+	_c.Call.Return(goEnv, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetGoEnv_Call) RunAndReturn(run func(ctx context.Context) (model.GoEnv, error)) *BinaryManager_GetGoEnv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGoReleases provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetGoReleases(ctx context.Context) (model.GoReleases, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGoReleases")
+	}
+
+	var r0 model.GoReleases
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.GoReleases, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.GoReleases); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.GoReleases)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetGoReleases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGoReleases'
+type BinaryManager_GetGoReleases_Call struct {
+	*mock.Call
+}
+
+// GetGoReleases is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BinaryManager_Expecter) GetGoReleases(ctx interface{}) *BinaryManager_GetGoReleases_Call {
+	return &BinaryManager_GetGoReleases_Call{Call: _e.mock.On("GetGoReleases", ctx)}
+}
+
+func (_c *BinaryManager_GetGoReleases_Call) Run(run func(ctx context.Context)) *BinaryManager_GetGoReleases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetGoReleases_Call) Return(goReleases model.GoReleases, err error) *BinaryManager_GetGoReleases_Call {
+	_c.Call.Return(goReleases, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetGoReleases_Call) RunAndReturn(run func(ctx context.Context) (model.GoReleases, error)) *BinaryManager_GetGoReleases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLinkSource provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetLinkSource(bin model.Binary) (string, error) {
+	ret := _mock.Called(bin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinkSource")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) (string, error)); ok {
+		return returnFunc(bin)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) string); ok {
+		r0 = returnFunc(bin)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Binary) error); ok {
+		r1 = returnFunc(bin)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetLinkSource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinkSource'
+type BinaryManager_GetLinkSource_Call struct {
+	*mock.Call
+}
+
+// GetLinkSource is a helper method to define mock.On call
+//   - bin model.Binary
+func (_e *BinaryManager_Expecter) GetLinkSource(bin interface{}) *BinaryManager_GetLinkSource_Call {
+	return &BinaryManager_GetLinkSource_Call{Call: _e.mock.On("GetLinkSource", bin)}
+}
+
+func (_c *BinaryManager_GetLinkSource_Call) Run(run func(bin model.Binary)) *BinaryManager_GetLinkSource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetLinkSource_Call) Return(s string, err error) *BinaryManager_GetLinkSource_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetLinkSource_Call) RunAndReturn(run func(bin model.Binary) (string, error)) *BinaryManager_GetLinkSource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLockedBinaries provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetLockedBinaries() ([]model.LockedBinary, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLockedBinaries")
+	}
+
+	var r0 []model.LockedBinary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]model.LockedBinary, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []model.LockedBinary); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.LockedBinary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetLockedBinaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLockedBinaries'
+type BinaryManager_GetLockedBinaries_Call struct {
+	*mock.Call
+}
+
+// GetLockedBinaries is a helper method to define mock.On call
+func (_e *BinaryManager_Expecter) GetLockedBinaries() *BinaryManager_GetLockedBinaries_Call {
+	return &BinaryManager_GetLockedBinaries_Call{Call: _e.mock.On("GetLockedBinaries")}
+}
+
+func (_c *BinaryManager_GetLockedBinaries_Call) Run(run func()) *BinaryManager_GetLockedBinaries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetLockedBinaries_Call) Return(lockedBinarys []model.LockedBinary, err error) *BinaryManager_GetLockedBinaries_Call {
+	_c.Call.Return(lockedBinarys, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetLockedBinaries_Call) RunAndReturn(run func() ([]model.LockedBinary, error)) *BinaryManager_GetLockedBinaries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetModuleBuild provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetModuleBuild(ctx context.Context, module model.Module) (model.BinaryBuild, error) {
+	ret := _mock.Called(ctx, module)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetModuleBuild")
+	}
+
+	var r0 model.BinaryBuild
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) (model.BinaryBuild, error)); ok {
+		return returnFunc(ctx, module)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) model.BinaryBuild); ok {
+		r0 = returnFunc(ctx, module)
+	} else {
+		r0 = ret.Get(0).(model.BinaryBuild)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Module) error); ok {
+		r1 = returnFunc(ctx, module)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetModuleBuild_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetModuleBuild'
+type BinaryManager_GetModuleBuild_Call struct {
+	*mock.Call
+}
+
+// GetModuleBuild is a helper method to define mock.On call
+//   - ctx context.Context
+//   - module model.Module
+func (_e *BinaryManager_Expecter) GetModuleBuild(ctx interface{}, module interface{}) *BinaryManager_GetModuleBuild_Call {
+	return &BinaryManager_GetModuleBuild_Call{Call: _e.mock.On("GetModuleBuild", ctx, module)}
+}
+
+func (_c *BinaryManager_GetModuleBuild_Call) Run(run func(ctx context.Context, module model.Module)) *BinaryManager_GetModuleBuild_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Module
+		if args[1] != nil {
+			arg1 = args[1].(model.Module)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetModuleBuild_Call) Return(binaryBuild model.BinaryBuild, err error) *BinaryManager_GetModuleBuild_Call {
+	_c.Call.Return(binaryBuild, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetModuleBuild_Call) RunAndReturn(run func(ctx context.Context, module model.Module) (model.BinaryBuild, error)) *BinaryManager_GetModuleBuild_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPinVersions provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetPinVersions(bin model.Binary) ([]model.PinVersion, error) {
+	ret := _mock.Called(bin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPinVersions")
+	}
+
+	var r0 []model.PinVersion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) ([]model.PinVersion, error)); ok {
+		return returnFunc(bin)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) []model.PinVersion); ok {
+		r0 = returnFunc(bin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PinVersion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Binary) error); ok {
+		r1 = returnFunc(bin)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetPinVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPinVersions'
+type BinaryManager_GetPinVersions_Call struct {
+	*mock.Call
+}
+
+// GetPinVersions is a helper method to define mock.On call
+//   - bin model.Binary
+func (_e *BinaryManager_Expecter) GetPinVersions(bin interface{}) *BinaryManager_GetPinVersions_Call {
+	return &BinaryManager_GetPinVersions_Call{Call: _e.mock.On("GetPinVersions", bin)}
+}
+
+func (_c *BinaryManager_GetPinVersions_Call) Run(run func(bin model.Binary)) *BinaryManager_GetPinVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetPinVersions_Call) Return(pinVersions []model.PinVersion, err error) *BinaryManager_GetPinVersions_Call {
+	_c.Call.Return(pinVersions, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetPinVersions_Call) RunAndReturn(run func(bin model.Binary) ([]model.PinVersion, error)) *BinaryManager_GetPinVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRelatedPins provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetRelatedPins(bin model.Binary) ([]model.Binary, error) {
+	ret := _mock.Called(bin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRelatedPins")
+	}
+
+	var r0 []model.Binary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) ([]model.Binary, error)); ok {
+		return returnFunc(bin)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) []model.Binary); ok {
+		r0 = returnFunc(bin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Binary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Binary) error); ok {
+		r1 = returnFunc(bin)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetRelatedPins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRelatedPins'
+type BinaryManager_GetRelatedPins_Call struct {
+	*mock.Call
+}
+
+// GetRelatedPins is a helper method to define mock.On call
+//   - bin model.Binary
+func (_e *BinaryManager_Expecter) GetRelatedPins(bin interface{}) *BinaryManager_GetRelatedPins_Call {
+	return &BinaryManager_GetRelatedPins_Call{Call: _e.mock.On("GetRelatedPins", bin)}
+}
+
+func (_c *BinaryManager_GetRelatedPins_Call) Run(run func(bin model.Binary)) *BinaryManager_GetRelatedPins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetRelatedPins_Call) Return(binarys []model.Binary, err error) *BinaryManager_GetRelatedPins_Call {
+	_c.Call.Return(binarys, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetRelatedPins_Call) RunAndReturn(run func(bin model.Binary) ([]model.Binary, error)) *BinaryManager_GetRelatedPins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStoreUsage provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetStoreUsage() (model.StoreUsage, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStoreUsage")
+	}
+
+	var r0 model.StoreUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (model.StoreUsage, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() model.StoreUsage); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.StoreUsage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetStoreUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStoreUsage'
+type BinaryManager_GetStoreUsage_Call struct {
+	*mock.Call
+}
+
+// GetStoreUsage is a helper method to define mock.On call
+func (_e *BinaryManager_Expecter) GetStoreUsage() *BinaryManager_GetStoreUsage_Call {
+	return &BinaryManager_GetStoreUsage_Call{Call: _e.mock.On("GetStoreUsage")}
+}
+
+func (_c *BinaryManager_GetStoreUsage_Call) Run(run func()) *BinaryManager_GetStoreUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetStoreUsage_Call) Return(storeUsage model.StoreUsage, err error) *BinaryManager_GetStoreUsage_Call {
+	_c.Call.Return(storeUsage, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetStoreUsage_Call) RunAndReturn(run func() (model.StoreUsage, error)) *BinaryManager_GetStoreUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSumDBConfig provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) GetSumDBConfig(ctx context.Context) (model.SumDBConfig, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSumDBConfig")
+	}
+
+	var r0 model.SumDBConfig
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.SumDBConfig, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.SumDBConfig); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.SumDBConfig)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_GetSumDBConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSumDBConfig'
+type BinaryManager_GetSumDBConfig_Call struct {
+	*mock.Call
+}
+
+// GetSumDBConfig is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BinaryManager_Expecter) GetSumDBConfig(ctx interface{}) *BinaryManager_GetSumDBConfig_Call {
+	return &BinaryManager_GetSumDBConfig_Call{Call: _e.mock.On("GetSumDBConfig", ctx)}
+}
+
+func (_c *BinaryManager_GetSumDBConfig_Call) Run(run func(ctx context.Context)) *BinaryManager_GetSumDBConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_GetSumDBConfig_Call) Return(sumDBConfig model.SumDBConfig, err error) *BinaryManager_GetSumDBConfig_Call {
+	_c.Call.Return(sumDBConfig, err)
+	return _c
+}
+
+func (_c *BinaryManager_GetSumDBConfig_Call) RunAndReturn(run func(ctx context.Context) (model.SumDBConfig, error)) *BinaryManager_GetSumDBConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InstallLocalPackage provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) InstallLocalPackage(ctx context.Context, dir string, flags model.BuildFlags) error {
+	ret := _mock.Called(ctx, dir, flags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InstallLocalPackage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.BuildFlags) error); ok {
+		r0 = returnFunc(ctx, dir, flags)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_InstallLocalPackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallLocalPackage'
+type BinaryManager_InstallLocalPackage_Call struct {
+	*mock.Call
+}
+
+// InstallLocalPackage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dir string
+//   - flags model.BuildFlags
+func (_e *BinaryManager_Expecter) InstallLocalPackage(ctx interface{}, dir interface{}, flags interface{}) *BinaryManager_InstallLocalPackage_Call {
+	return &BinaryManager_InstallLocalPackage_Call{Call: _e.mock.On("InstallLocalPackage", ctx, dir, flags)}
+}
+
+func (_c *BinaryManager_InstallLocalPackage_Call) Run(run func(ctx context.Context, dir string, flags model.BuildFlags)) *BinaryManager_InstallLocalPackage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.BuildFlags
+		if args[2] != nil {
+			arg2 = args[2].(model.BuildFlags)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_InstallLocalPackage_Call) Return(err error) *BinaryManager_InstallLocalPackage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_InstallLocalPackage_Call) RunAndReturn(run func(ctx context.Context, dir string, flags model.BuildFlags) error) *BinaryManager_InstallLocalPackage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InstallPackage provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) InstallPackage(ctx context.Context, pkg model.Package, kind model.Kind, flags model.BuildFlags, rebuild bool, universal bool, cacheFrom string) error {
+	ret := _mock.Called(ctx, pkg, kind, flags, rebuild, universal, cacheFrom)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InstallPackage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package, model.Kind, model.BuildFlags, bool, bool, string) error); ok {
+		r0 = returnFunc(ctx, pkg, kind, flags, rebuild, universal, cacheFrom)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_InstallPackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallPackage'
+type BinaryManager_InstallPackage_Call struct {
+	*mock.Call
+}
+
+// InstallPackage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pkg model.Package
+//   - kind model.Kind
+//   - flags model.BuildFlags
+//   - rebuild bool
+//   - universal bool
+//   - cacheFrom string
+func (_e *BinaryManager_Expecter) InstallPackage(ctx interface{}, pkg interface{}, kind interface{}, flags interface{}, rebuild interface{}, universal interface{}, cacheFrom interface{}) *BinaryManager_InstallPackage_Call {
+	return &BinaryManager_InstallPackage_Call{Call: _e.mock.On("InstallPackage", ctx, pkg, kind, flags, rebuild, universal, cacheFrom)}
+}
+
+func (_c *BinaryManager_InstallPackage_Call) Run(run func(ctx context.Context, pkg model.Package, kind model.Kind, flags model.BuildFlags, rebuild bool, universal bool, cacheFrom string)) *BinaryManager_InstallPackage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Package
+		if args[1] != nil {
+			arg1 = args[1].(model.Package)
+		}
+		var arg2 model.Kind
+		if args[2] != nil {
+			arg2 = args[2].(model.Kind)
+		}
+		var arg3 model.BuildFlags
+		if args[3] != nil {
+			arg3 = args[3].(model.BuildFlags)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		var arg5 bool
+		if args[5] != nil {
+			arg5 = args[5].(bool)
+		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_InstallPackage_Call) Return(err error) *BinaryManager_InstallPackage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_InstallPackage_Call) RunAndReturn(run func(ctx context.Context, pkg model.Package, kind model.Kind, flags model.BuildFlags, rebuild bool, universal bool, cacheFrom string) error) *BinaryManager_InstallPackage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InstallPackagePlatform provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) InstallPackagePlatform(ctx context.Context, pkg model.Package, platform string, flags model.BuildFlags, rebuild bool) (string, error) {
+	ret := _mock.Called(ctx, pkg, platform, flags, rebuild)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InstallPackagePlatform")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package, string, model.BuildFlags, bool) (string, error)); ok {
+		return returnFunc(ctx, pkg, platform, flags, rebuild)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package, string, model.BuildFlags, bool) string); ok {
+		r0 = returnFunc(ctx, pkg, platform, flags, rebuild)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Package, string, model.BuildFlags, bool) error); ok {
+		r1 = returnFunc(ctx, pkg, platform, flags, rebuild)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_InstallPackagePlatform_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallPackagePlatform'
+type BinaryManager_InstallPackagePlatform_Call struct {
+	*mock.Call
+}
+
+// InstallPackagePlatform is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pkg model.Package
+//   - platform string
+//   - flags model.BuildFlags
+//   - rebuild bool
+func (_e *BinaryManager_Expecter) InstallPackagePlatform(ctx interface{}, pkg interface{}, platform interface{}, flags interface{}, rebuild interface{}) *BinaryManager_InstallPackagePlatform_Call {
+	return &BinaryManager_InstallPackagePlatform_Call{Call: _e.mock.On("InstallPackagePlatform", ctx, pkg, platform, flags, rebuild)}
+}
+
+func (_c *BinaryManager_InstallPackagePlatform_Call) Run(run func(ctx context.Context, pkg model.Package, platform string, flags model.BuildFlags, rebuild bool)) *BinaryManager_InstallPackagePlatform_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Package
+		if args[1] != nil {
+			arg1 = args[1].(model.Package)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 model.BuildFlags
+		if args[3] != nil {
+			arg3 = args[3].(model.BuildFlags)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_InstallPackagePlatform_Call) Return(s string, err error) *BinaryManager_InstallPackagePlatform_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *BinaryManager_InstallPackagePlatform_Call) RunAndReturn(run func(ctx context.Context, pkg model.Package, platform string, flags model.BuildFlags, rebuild bool) (string, error)) *BinaryManager_InstallPackagePlatform_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MigrateBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) MigrateBinary(path string) error {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_MigrateBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateBinary'
+type BinaryManager_MigrateBinary_Call struct {
+	*mock.Call
+}
+
+// MigrateBinary is a helper method to define mock.On call
+//   - path string
+func (_e *BinaryManager_Expecter) MigrateBinary(path interface{}) *BinaryManager_MigrateBinary_Call {
+	return &BinaryManager_MigrateBinary_Call{Call: _e.mock.On("MigrateBinary", path)}
+}
+
+func (_c *BinaryManager_MigrateBinary_Call) Run(run func(path string)) *BinaryManager_MigrateBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_MigrateBinary_Call) Return(err error) *BinaryManager_MigrateBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_MigrateBinary_Call) RunAndReturn(run func(path string) error) *BinaryManager_MigrateBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PinBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) PinBinary(bin model.Binary, kind model.Kind) error {
+	ret := _mock.Called(bin, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, model.Kind) error); ok {
+		r0 = returnFunc(bin, kind)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_PinBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PinBinary'
+type BinaryManager_PinBinary_Call struct {
+	*mock.Call
+}
+
+// PinBinary is a helper method to define mock.On call
+//   - bin model.Binary
+//   - kind model.Kind
+func (_e *BinaryManager_Expecter) PinBinary(bin interface{}, kind interface{}) *BinaryManager_PinBinary_Call {
+	return &BinaryManager_PinBinary_Call{Call: _e.mock.On("PinBinary", bin, kind)}
+}
+
+func (_c *BinaryManager_PinBinary_Call) Run(run func(bin model.Binary, kind model.Kind)) *BinaryManager_PinBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		var arg1 model.Kind
+		if args[1] != nil {
+			arg1 = args[1].(model.Kind)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_PinBinary_Call) Return(err error) *BinaryManager_PinBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_PinBinary_Call) RunAndReturn(run func(bin model.Binary, kind model.Kind) error) *BinaryManager_PinBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanInstallPackage provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) PlanInstallPackage(ctx context.Context, pkg model.Package, kind model.Kind, flags model.BuildFlags) ([]model.Action, error) {
+	ret := _mock.Called(ctx, pkg, kind, flags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanInstallPackage")
+	}
+
+	var r0 []model.Action
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package, model.Kind, model.BuildFlags) ([]model.Action, error)); ok {
+		return returnFunc(ctx, pkg, kind, flags)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package, model.Kind, model.BuildFlags) []model.Action); ok {
+		r0 = returnFunc(ctx, pkg, kind, flags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Action)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Package, model.Kind, model.BuildFlags) error); ok {
+		r1 = returnFunc(ctx, pkg, kind, flags)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_PlanInstallPackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanInstallPackage'
+type BinaryManager_PlanInstallPackage_Call struct {
+	*mock.Call
+}
+
+// PlanInstallPackage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pkg model.Package
+//   - kind model.Kind
+//   - flags model.BuildFlags
+func (_e *BinaryManager_Expecter) PlanInstallPackage(ctx interface{}, pkg interface{}, kind interface{}, flags interface{}) *BinaryManager_PlanInstallPackage_Call {
+	return &BinaryManager_PlanInstallPackage_Call{Call: _e.mock.On("PlanInstallPackage", ctx, pkg, kind, flags)}
+}
+
+func (_c *BinaryManager_PlanInstallPackage_Call) Run(run func(ctx context.Context, pkg model.Package, kind model.Kind, flags model.BuildFlags)) *BinaryManager_PlanInstallPackage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Package
+		if args[1] != nil {
+			arg1 = args[1].(model.Package)
+		}
+		var arg2 model.Kind
+		if args[2] != nil {
+			arg2 = args[2].(model.Kind)
+		}
+		var arg3 model.BuildFlags
+		if args[3] != nil {
+			arg3 = args[3].(model.BuildFlags)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_PlanInstallPackage_Call) Return(actions []model.Action, err error) *BinaryManager_PlanInstallPackage_Call {
+	_c.Call.Return(actions, err)
+	return _c
+}
+
+func (_c *BinaryManager_PlanInstallPackage_Call) RunAndReturn(run func(ctx context.Context, pkg model.Package, kind model.Kind, flags model.BuildFlags) ([]model.Action, error)) *BinaryManager_PlanInstallPackage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanPruneBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) PlanPruneBinary(bin model.Binary, force bool) ([]model.Action, error) {
+	ret := _mock.Called(bin, force)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanPruneBinary")
+	}
+
+	var r0 []model.Action
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, bool) ([]model.Action, error)); ok {
+		return returnFunc(bin, force)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, bool) []model.Action); ok {
+		r0 = returnFunc(bin, force)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Action)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Binary, bool) error); ok {
+		r1 = returnFunc(bin, force)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_PlanPruneBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanPruneBinary'
+type BinaryManager_PlanPruneBinary_Call struct {
+	*mock.Call
+}
+
+// PlanPruneBinary is a helper method to define mock.On call
+//   - bin model.Binary
+//   - force bool
+func (_e *BinaryManager_Expecter) PlanPruneBinary(bin interface{}, force interface{}) *BinaryManager_PlanPruneBinary_Call {
+	return &BinaryManager_PlanPruneBinary_Call{Call: _e.mock.On("PlanPruneBinary", bin, force)}
+}
+
+func (_c *BinaryManager_PlanPruneBinary_Call) Run(run func(bin model.Binary, force bool)) *BinaryManager_PlanPruneBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_PlanPruneBinary_Call) Return(actions []model.Action, err error) *BinaryManager_PlanPruneBinary_Call {
+	_c.Call.Return(actions, err)
+	return _c
+}
+
+func (_c *BinaryManager_PlanPruneBinary_Call) RunAndReturn(run func(bin model.Binary, force bool) ([]model.Action, error)) *BinaryManager_PlanPruneBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanUninstallBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) PlanUninstallBinary(bin model.Binary, force bool, purge bool) ([]model.Action, error) {
+	ret := _mock.Called(bin, force, purge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanUninstallBinary")
+	}
+
+	var r0 []model.Action
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, bool, bool) ([]model.Action, error)); ok {
+		return returnFunc(bin, force, purge)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, bool, bool) []model.Action); ok {
+		r0 = returnFunc(bin, force, purge)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Action)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Binary, bool, bool) error); ok {
+		r1 = returnFunc(bin, force, purge)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_PlanUninstallBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanUninstallBinary'
+type BinaryManager_PlanUninstallBinary_Call struct {
+	*mock.Call
+}
+
+// PlanUninstallBinary is a helper method to define mock.On call
+//   - bin model.Binary
+//   - force bool
+//   - purge bool
+func (_e *BinaryManager_Expecter) PlanUninstallBinary(bin interface{}, force interface{}, purge interface{}) *BinaryManager_PlanUninstallBinary_Call {
+	return &BinaryManager_PlanUninstallBinary_Call{Call: _e.mock.On("PlanUninstallBinary", bin, force, purge)}
+}
+
+func (_c *BinaryManager_PlanUninstallBinary_Call) Run(run func(bin model.Binary, force bool, purge bool)) *BinaryManager_PlanUninstallBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_PlanUninstallBinary_Call) Return(actions []model.Action, err error) *BinaryManager_PlanUninstallBinary_Call {
+	_c.Call.Return(actions, err)
+	return _c
+}
+
+func (_c *BinaryManager_PlanUninstallBinary_Call) RunAndReturn(run func(bin model.Binary, force bool, purge bool) ([]model.Action, error)) *BinaryManager_PlanUninstallBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanUpgradeBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) PlanUpgradeBinary(ctx context.Context, binFullPath string, flags model.BuildFlags, majorUpgrade bool, rebuild bool, force bool) ([]model.Action, error) {
+	ret := _mock.Called(ctx, binFullPath, flags, majorUpgrade, rebuild, force)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanUpgradeBinary")
+	}
+
+	var r0 []model.Action
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.BuildFlags, bool, bool, bool) ([]model.Action, error)); ok {
+		return returnFunc(ctx, binFullPath, flags, majorUpgrade, rebuild, force)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.BuildFlags, bool, bool, bool) []model.Action); ok {
+		r0 = returnFunc(ctx, binFullPath, flags, majorUpgrade, rebuild, force)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Action)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.BuildFlags, bool, bool, bool) error); ok {
+		r1 = returnFunc(ctx, binFullPath, flags, majorUpgrade, rebuild, force)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_PlanUpgradeBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanUpgradeBinary'
+type BinaryManager_PlanUpgradeBinary_Call struct {
+	*mock.Call
+}
+
+// PlanUpgradeBinary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - binFullPath string
+//   - flags model.BuildFlags
+//   - majorUpgrade bool
+//   - rebuild bool
+//   - force bool
+func (_e *BinaryManager_Expecter) PlanUpgradeBinary(ctx interface{}, binFullPath interface{}, flags interface{}, majorUpgrade interface{}, rebuild interface{}, force interface{}) *BinaryManager_PlanUpgradeBinary_Call {
+	return &BinaryManager_PlanUpgradeBinary_Call{Call: _e.mock.On("PlanUpgradeBinary", ctx, binFullPath, flags, majorUpgrade, rebuild, force)}
+}
+
+func (_c *BinaryManager_PlanUpgradeBinary_Call) Run(run func(ctx context.Context, binFullPath string, flags model.BuildFlags, majorUpgrade bool, rebuild bool, force bool)) *BinaryManager_PlanUpgradeBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.BuildFlags
+		if args[2] != nil {
+			arg2 = args[2].(model.BuildFlags)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		var arg5 bool
+		if args[5] != nil {
+			arg5 = args[5].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_PlanUpgradeBinary_Call) Return(actions []model.Action, err error) *BinaryManager_PlanUpgradeBinary_Call {
+	_c.Call.Return(actions, err)
+	return _c
+}
+
+func (_c *BinaryManager_PlanUpgradeBinary_Call) RunAndReturn(run func(ctx context.Context, binFullPath string, flags model.BuildFlags, majorUpgrade bool, rebuild bool, force bool) ([]model.Action, error)) *BinaryManager_PlanUpgradeBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProtectBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) ProtectBinary(bin model.Binary, protected bool) error {
+	ret := _mock.Called(bin, protected)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProtectBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, bool) error); ok {
+		r0 = returnFunc(bin, protected)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_ProtectBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProtectBinary'
+type BinaryManager_ProtectBinary_Call struct {
+	*mock.Call
+}
+
+// ProtectBinary is a helper method to define mock.On call
+//   - bin model.Binary
+//   - protected bool
+func (_e *BinaryManager_Expecter) ProtectBinary(bin interface{}, protected interface{}) *BinaryManager_ProtectBinary_Call {
+	return &BinaryManager_ProtectBinary_Call{Call: _e.mock.On("ProtectBinary", bin, protected)}
+}
+
+func (_c *BinaryManager_ProtectBinary_Call) Run(run func(bin model.Binary, protected bool)) *BinaryManager_ProtectBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_ProtectBinary_Call) Return(err error) *BinaryManager_ProtectBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_ProtectBinary_Call) RunAndReturn(run func(bin model.Binary, protected bool) error) *BinaryManager_ProtectBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) PruneBinary(bin model.Binary, force bool) error {
+	ret := _mock.Called(bin, force)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, bool) error); ok {
+		r0 = returnFunc(bin, force)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_PruneBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneBinary'
+type BinaryManager_PruneBinary_Call struct {
+	*mock.Call
+}
+
+// PruneBinary is a helper method to define mock.On call
+//   - bin model.Binary
+//   - force bool
+func (_e *BinaryManager_Expecter) PruneBinary(bin interface{}, force interface{}) *BinaryManager_PruneBinary_Call {
+	return &BinaryManager_PruneBinary_Call{Call: _e.mock.On("PruneBinary", bin, force)}
+}
+
+func (_c *BinaryManager_PruneBinary_Call) Run(run func(bin model.Binary, force bool)) *BinaryManager_PruneBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_PruneBinary_Call) Return(err error) *BinaryManager_PruneBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_PruneBinary_Call) RunAndReturn(run func(bin model.Binary, force bool) error) *BinaryManager_PruneBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PullBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) PullBinary(ctx context.Context, ref model.OCIReference, kind model.Kind) (model.Binary, error) {
+	ret := _mock.Called(ctx, ref, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PullBinary")
+	}
+
+	var r0 model.Binary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.OCIReference, model.Kind) (model.Binary, error)); ok {
+		return returnFunc(ctx, ref, kind)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.OCIReference, model.Kind) model.Binary); ok {
+		r0 = returnFunc(ctx, ref, kind)
+	} else {
+		r0 = ret.Get(0).(model.Binary)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.OCIReference, model.Kind) error); ok {
+		r1 = returnFunc(ctx, ref, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_PullBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PullBinary'
+type BinaryManager_PullBinary_Call struct {
+	*mock.Call
+}
+
+// PullBinary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ref model.OCIReference
+//   - kind model.Kind
+func (_e *BinaryManager_Expecter) PullBinary(ctx interface{}, ref interface{}, kind interface{}) *BinaryManager_PullBinary_Call {
+	return &BinaryManager_PullBinary_Call{Call: _e.mock.On("PullBinary", ctx, ref, kind)}
+}
+
+func (_c *BinaryManager_PullBinary_Call) Run(run func(ctx context.Context, ref model.OCIReference, kind model.Kind)) *BinaryManager_PullBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.OCIReference
+		if args[1] != nil {
+			arg1 = args[1].(model.OCIReference)
+		}
+		var arg2 model.Kind
+		if args[2] != nil {
+			arg2 = args[2].(model.Kind)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_PullBinary_Call) Return(binary model.Binary, err error) *BinaryManager_PullBinary_Call {
+	_c.Call.Return(binary, err)
+	return _c
+}
+
+func (_c *BinaryManager_PullBinary_Call) RunAndReturn(run func(ctx context.Context, ref model.OCIReference, kind model.Kind) (model.Binary, error)) *BinaryManager_PullBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) PushBinary(ctx context.Context, path string, ref model.OCIReference) error {
+	ret := _mock.Called(ctx, path, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.OCIReference) error); ok {
+		r0 = returnFunc(ctx, path, ref)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_PushBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushBinary'
+type BinaryManager_PushBinary_Call struct {
+	*mock.Call
+}
+
+// PushBinary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+//   - ref model.OCIReference
+func (_e *BinaryManager_Expecter) PushBinary(ctx interface{}, path interface{}, ref interface{}) *BinaryManager_PushBinary_Call {
+	return &BinaryManager_PushBinary_Call{Call: _e.mock.On("PushBinary", ctx, path, ref)}
+}
+
+func (_c *BinaryManager_PushBinary_Call) Run(run func(ctx context.Context, path string, ref model.OCIReference)) *BinaryManager_PushBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.OCIReference
+		if args[2] != nil {
+			arg2 = args[2].(model.OCIReference)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_PushBinary_Call) Return(err error) *BinaryManager_PushBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_PushBinary_Call) RunAndReturn(run func(ctx context.Context, path string, ref model.OCIReference) error) *BinaryManager_PushBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RebuildBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) RebuildBinary(ctx context.Context, path string) error {
+	ret := _mock.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebuildBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_RebuildBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebuildBinary'
+type BinaryManager_RebuildBinary_Call struct {
+	*mock.Call
+}
+
+// RebuildBinary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *BinaryManager_Expecter) RebuildBinary(ctx interface{}, path interface{}) *BinaryManager_RebuildBinary_Call {
+	return &BinaryManager_RebuildBinary_Call{Call: _e.mock.On("RebuildBinary", ctx, path)}
+}
+
+func (_c *BinaryManager_RebuildBinary_Call) Run(run func(ctx context.Context, path string)) *BinaryManager_RebuildBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_RebuildBinary_Call) Return(err error) *BinaryManager_RebuildBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_RebuildBinary_Call) RunAndReturn(run func(ctx context.Context, path string) error) *BinaryManager_RebuildBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RelinkBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) RelinkBinary(ctx context.Context, bin model.Binary, flags model.BuildFlags) error {
+	ret := _mock.Called(ctx, bin, flags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RelinkBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Binary, model.BuildFlags) error); ok {
+		r0 = returnFunc(ctx, bin, flags)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_RelinkBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RelinkBinary'
+type BinaryManager_RelinkBinary_Call struct {
+	*mock.Call
+}
+
+// RelinkBinary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bin model.Binary
+//   - flags model.BuildFlags
+func (_e *BinaryManager_Expecter) RelinkBinary(ctx interface{}, bin interface{}, flags interface{}) *BinaryManager_RelinkBinary_Call {
+	return &BinaryManager_RelinkBinary_Call{Call: _e.mock.On("RelinkBinary", ctx, bin, flags)}
+}
+
+func (_c *BinaryManager_RelinkBinary_Call) Run(run func(ctx context.Context, bin model.Binary, flags model.BuildFlags)) *BinaryManager_RelinkBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Binary
+		if args[1] != nil {
+			arg1 = args[1].(model.Binary)
+		}
+		var arg2 model.BuildFlags
+		if args[2] != nil {
+			arg2 = args[2].(model.BuildFlags)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_RelinkBinary_Call) Return(err error) *BinaryManager_RelinkBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_RelinkBinary_Call) RunAndReturn(run func(ctx context.Context, bin model.Binary, flags model.BuildFlags) error) *BinaryManager_RelinkBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveStaleExecutable provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) RemoveStaleExecutable(path string) error {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveStaleExecutable")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_RemoveStaleExecutable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveStaleExecutable'
+type BinaryManager_RemoveStaleExecutable_Call struct {
+	*mock.Call
+}
+
+// RemoveStaleExecutable is a helper method to define mock.On call
+//   - path string
+func (_e *BinaryManager_Expecter) RemoveStaleExecutable(path interface{}) *BinaryManager_RemoveStaleExecutable_Call {
+	return &BinaryManager_RemoveStaleExecutable_Call{Call: _e.mock.On("RemoveStaleExecutable", path)}
+}
+
+func (_c *BinaryManager_RemoveStaleExecutable_Call) Run(run func(path string)) *BinaryManager_RemoveStaleExecutable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_RemoveStaleExecutable_Call) Return(err error) *BinaryManager_RemoveStaleExecutable_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_RemoveStaleExecutable_Call) RunAndReturn(run func(path string) error) *BinaryManager_RemoveStaleExecutable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RepinBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) RepinBinary(path string) error {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepinBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// BinaryManager_RepinBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepinBinary'
+type BinaryManager_RepinBinary_Call struct {
+	*mock.Call
+}
+
+// RepinBinary is a helper method to define mock.On call
+//   - path string
+func (_e *BinaryManager_Expecter) RepinBinary(path interface{}) *BinaryManager_RepinBinary_Call {
+	return &BinaryManager_RepinBinary_Call{Call: _e.mock.On("RepinBinary", path)}
+}
+
+func (_c *BinaryManager_RepinBinary_Call) Run(run func(path string)) *BinaryManager_RepinBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_RepinBinary_Call) Return(err error) *BinaryManager_RepinBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *BinaryManager_RepinBinary_Call) RunAndReturn(run func(path string) error) *BinaryManager_RepinBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReproduceBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) ReproduceBinary(ctx context.Context, path string) (model.BinaryReproduction, error) {
+	ret := _mock.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReproduceBinary")
+	}
+
+	var r0 model.BinaryReproduction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.BinaryReproduction, error)); ok {
+		return returnFunc(ctx, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.BinaryReproduction); ok {
+		r0 = returnFunc(ctx, path)
+	} else {
+		r0 = ret.Get(0).(model.BinaryReproduction)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_ReproduceBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReproduceBinary'
+type BinaryManager_ReproduceBinary_Call struct {
+	*mock.Call
+}
+
+// ReproduceBinary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *BinaryManager_Expecter) ReproduceBinary(ctx interface{}, path interface{}) *BinaryManager_ReproduceBinary_Call {
+	return &BinaryManager_ReproduceBinary_Call{Call: _e.mock.On("ReproduceBinary", ctx, path)}
+}
+
+func (_c *BinaryManager_ReproduceBinary_Call) Run(run func(ctx context.Context, path string)) *BinaryManager_ReproduceBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_ReproduceBinary_Call) Return(binaryReproduction model.BinaryReproduction, err error) *BinaryManager_ReproduceBinary_Call {
+	_c.Call.Return(binaryReproduction, err)
+	return _c
+}
+
+func (_c *BinaryManager_ReproduceBinary_Call) RunAndReturn(run func(ctx context.Context, path string) (model.BinaryReproduction, error)) *BinaryManager_ReproduceBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolvePackage provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) ResolvePackage(ctx context.Context, pkg model.Package) (model.Package, error) {
+	ret := _mock.Called(ctx, pkg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolvePackage")
+	}
+
+	var r0 model.Package
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package) (model.Package, error)); ok {
+		return returnFunc(ctx, pkg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package) model.Package); ok {
+		r0 = returnFunc(ctx, pkg)
+	} else {
+		r0 = ret.Get(0).(model.Package)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Package) error); ok {
+		r1 = returnFunc(ctx, pkg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_ResolvePackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolvePackage'
+type BinaryManager_ResolvePackage_Call struct {
+	*mock.Call
+}
+
+// ResolvePackage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pkg model.Package
+func (_e *BinaryManager_Expecter) ResolvePackage(ctx interface{}, pkg interface{}) *BinaryManager_ResolvePackage_Call {
+	return &BinaryManager_ResolvePackage_Call{Call: _e.mock.On("ResolvePackage", ctx, pkg)}
+}
+
+func (_c *BinaryManager_ResolvePackage_Call) Run(run func(ctx context.Context, pkg model.Package)) *BinaryManager_ResolvePackage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Package
+		if args[1] != nil {
+			arg1 = args[1].(model.Package)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_ResolvePackage_Call) Return(packageParam model.Package, err error) *BinaryManager_ResolvePackage_Call {
+	_c.Call.Return(packageParam, err)
+	return _c
+}
+
+func (_c *BinaryManager_ResolvePackage_Call) RunAndReturn(run func(ctx context.Context, pkg model.Package) (model.Package, error)) *BinaryManager_ResolvePackage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RollbackBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) RollbackBinary(bin model.Binary) (model.Version, error) {
+	ret := _mock.Called(bin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackBinary")
+	}
+
+	var r0 model.Version
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) (model.Version, error)); ok {
+		return returnFunc(bin)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) model.Version); ok {
+		r0 = returnFunc(bin)
+	} else {
+		r0 = ret.Get(0).(model.Version)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Binary) error); ok {
+		r1 = returnFunc(bin)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_RollbackBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackBinary'
+type BinaryManager_RollbackBinary_Call struct {
+	*mock.Call
+}
+
+// RollbackBinary is a helper method to define mock.On call
+//   - bin model.Binary
+func (_e *BinaryManager_Expecter) RollbackBinary(bin interface{}) *BinaryManager_RollbackBinary_Call {
+	return &BinaryManager_RollbackBinary_Call{Call: _e.mock.On("RollbackBinary", bin)}
+}
+
+func (_c *BinaryManager_RollbackBinary_Call) Run(run func(bin model.Binary)) *BinaryManager_RollbackBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Binary
+		if args[0] != nil {
+			arg0 = args[0].(model.Binary)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_RollbackBinary_Call) Return(version model.Version, err error) *BinaryManager_RollbackBinary_Call {
+	_c.Call.Return(version, err)
+	return _c
+}
+
+func (_c *BinaryManager_RollbackBinary_Call) RunAndReturn(run func(bin model.Binary) (model.Version, error)) *BinaryManager_RollbackBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchPackages provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) SearchPackages(ctx context.Context, query string, limit int) ([]model.PackageSearchResult, error) {
+	ret := _mock.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchPackages")
+	}
+
+	var r0 []model.PackageSearchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]model.PackageSearchResult, error)); ok {
+		return returnFunc(ctx, query, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []model.PackageSearchResult); ok {
+		r0 = returnFunc(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PackageSearchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BinaryManager_SearchPackages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchPackages'
+type BinaryManager_SearchPackages_Call struct {
+	*mock.Call
+}
+
+// SearchPackages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+func (_e *BinaryManager_Expecter) SearchPackages(ctx interface{}, query interface{}, limit interface{}) *BinaryManager_SearchPackages_Call {
+	return &BinaryManager_SearchPackages_Call{Call: _e.mock.On("SearchPackages", ctx, query, limit)}
+}
+
+func (_c *BinaryManager_SearchPackages_Call) Run(run func(ctx context.Context, query string, limit int)) *BinaryManager_SearchPackages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *BinaryManager_SearchPackages_Call) Return(packageSearchResults []model.PackageSearchResult, err error) *BinaryManager_SearchPackages_Call {
+	_c.Call.Return(packageSearchResults, err)
+	return _c
+}
+
+func (_c *BinaryManager_SearchPackages_Call) RunAndReturn(run func(ctx context.Context, query string, limit int) ([]model.PackageSearchResult, error)) *BinaryManager_SearchPackages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBinaryEnv provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) SetBinaryEnv(bin model.Binary, envVars ...string) error {
+	var tmpRet mock.Arguments
+	if len(envVars) > 0 {
+		tmpRet = _mock.Called(bin, envVars)
+	} else {
+		tmpRet = _mock.Called(bin)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBinaryEnv")
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(path)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, ...string) error); ok {
+		r0 = returnFunc(bin, envVars...)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// BinaryManager_GetBinaryInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinaryInfo'
-type BinaryManager_GetBinaryInfo_Call struct {
+// BinaryManager_SetBinaryEnv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBinaryEnv'
+type BinaryManager_SetBinaryEnv_Call struct {
 	*mock.Call
 }
 
-// GetBinaryInfo is a helper method to define mock.On call
-//   - path string
-func (_e *BinaryManager_Expecter) GetBinaryInfo(path interface{}) *BinaryManager_GetBinaryInfo_Call {
-	return &BinaryManager_GetBinaryInfo_Call{Call: _e.mock.On("GetBinaryInfo", path)}
+// SetBinaryEnv is a helper method to define mock.On call
+//   - bin model.Binary
+//   - envVars ...string
+func (_e *BinaryManager_Expecter) SetBinaryEnv(bin interface{}, envVars ...interface{}) *BinaryManager_SetBinaryEnv_Call {
+	return &BinaryManager_SetBinaryEnv_Call{Call: _e.mock.On("SetBinaryEnv",
+		append([]interface{}{bin}, envVars...)...)}
 }
 
-func (_c *BinaryManager_GetBinaryInfo_Call) Run(run func(path string)) *BinaryManager_GetBinaryInfo_Call {
+func (_c *BinaryManager_SetBinaryEnv_Call) Run(run func(bin model.Binary, envVars ...string)) *BinaryManager_SetBinaryEnv_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 model.Binary
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(model.Binary)
 		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
 		run(
 			arg0,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *BinaryManager_GetBinaryInfo_Call) Return(binaryInfo model.BinaryInfo, err error) *BinaryManager_GetBinaryInfo_Call {
-	_c.Call.Return(binaryInfo, err)
+func (_c *BinaryManager_SetBinaryEnv_Call) Return(err error) *BinaryManager_SetBinaryEnv_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *BinaryManager_GetBinaryInfo_Call) RunAndReturn(run func(path string) (model.BinaryInfo, error)) *BinaryManager_GetBinaryInfo_Call {
+func (_c *BinaryManager_SetBinaryEnv_Call) RunAndReturn(run func(bin model.Binary, envVars ...string) error) *BinaryManager_SetBinaryEnv_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBinaryRepository provides a mock function for the type BinaryManager
-func (_mock *BinaryManager) GetBinaryRepository(ctx context.Context, bin model.Binary) (string, error) {
-	ret := _mock.Called(ctx, bin)
+// StorePackage provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) StorePackage(ctx context.Context, pkg model.Package) (string, error) {
+	ret := _mock.Called(ctx, pkg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBinaryRepository")
+		panic("no return value specified for StorePackage")
 	}
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Binary) (string, error)); ok {
-		return returnFunc(ctx, bin)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package) (string, error)); ok {
+		return returnFunc(ctx, pkg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Binary) string); ok {
-		r0 = returnFunc(ctx, bin)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package) string); ok {
+		r0 = returnFunc(ctx, pkg)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Binary) error); ok {
-		r1 = returnFunc(ctx, bin)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Package) error); ok {
+		r1 = returnFunc(ctx, pkg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// BinaryManager_GetBinaryRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinaryRepository'
-type BinaryManager_GetBinaryRepository_Call struct {
+// BinaryManager_StorePackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StorePackage'
+type BinaryManager_StorePackage_Call struct {
 	*mock.Call
 }
 
-// GetBinaryRepository is a helper method to define mock.On call
+// StorePackage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - bin model.Binary
-func (_e *BinaryManager_Expecter) GetBinaryRepository(ctx interface{}, bin interface{}) *BinaryManager_GetBinaryRepository_Call {
-	return &BinaryManager_GetBinaryRepository_Call{Call: _e.mock.On("GetBinaryRepository", ctx, bin)}
+//   - pkg model.Package
+func (_e *BinaryManager_Expecter) StorePackage(ctx interface{}, pkg interface{}) *BinaryManager_StorePackage_Call {
+	return &BinaryManager_StorePackage_Call{Call: _e.mock.On("StorePackage", ctx, pkg)}
 }
 
-func (_c *BinaryManager_GetBinaryRepository_Call) Run(run func(ctx context.Context, bin model.Binary)) *BinaryManager_GetBinaryRepository_Call {
+func (_c *BinaryManager_StorePackage_Call) Run(run func(ctx context.Context, pkg model.Package)) *BinaryManager_StorePackage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.Binary
+		var arg1 model.Package
 		if args[1] != nil {
-			arg1 = args[1].(model.Binary)
+			arg1 = args[1].(model.Package)
 		}
 		run(
 			arg0,
@@ -282,190 +3408,169 @@ func (_c *BinaryManager_GetBinaryRepository_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *BinaryManager_GetBinaryRepository_Call) Return(s string, err error) *BinaryManager_GetBinaryRepository_Call {
+func (_c *BinaryManager_StorePackage_Call) Return(s string, err error) *BinaryManager_StorePackage_Call {
 	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *BinaryManager_GetBinaryRepository_Call) RunAndReturn(run func(ctx context.Context, bin model.Binary) (string, error)) *BinaryManager_GetBinaryRepository_Call {
+func (_c *BinaryManager_StorePackage_Call) RunAndReturn(run func(ctx context.Context, pkg model.Package) (string, error)) *BinaryManager_StorePackage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBinaryUpgradeInfo provides a mock function for the type BinaryManager
-func (_mock *BinaryManager) GetBinaryUpgradeInfo(ctx context.Context, info model.BinaryInfo, checkMajor bool) (model.BinaryUpgradeInfo, error) {
-	ret := _mock.Called(ctx, info, checkMajor)
+// SwitchBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) SwitchBinary(bin model.Binary, version model.Version) error {
+	ret := _mock.Called(bin, version)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBinaryUpgradeInfo")
+		panic("no return value specified for SwitchBinary")
 	}
 
-	var r0 model.BinaryUpgradeInfo
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.BinaryInfo, bool) (model.BinaryUpgradeInfo, error)); ok {
-		return returnFunc(ctx, info, checkMajor)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.BinaryInfo, bool) model.BinaryUpgradeInfo); ok {
-		r0 = returnFunc(ctx, info, checkMajor)
-	} else {
-		r0 = ret.Get(0).(model.BinaryUpgradeInfo)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.BinaryInfo, bool) error); ok {
-		r1 = returnFunc(ctx, info, checkMajor)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, model.Version) error); ok {
+		r0 = returnFunc(bin, version)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// BinaryManager_GetBinaryUpgradeInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBinaryUpgradeInfo'
-type BinaryManager_GetBinaryUpgradeInfo_Call struct {
+// BinaryManager_SwitchBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwitchBinary'
+type BinaryManager_SwitchBinary_Call struct {
 	*mock.Call
 }
 
-// GetBinaryUpgradeInfo is a helper method to define mock.On call
-//   - ctx context.Context
-//   - info model.BinaryInfo
-//   - checkMajor bool
-func (_e *BinaryManager_Expecter) GetBinaryUpgradeInfo(ctx interface{}, info interface{}, checkMajor interface{}) *BinaryManager_GetBinaryUpgradeInfo_Call {
-	return &BinaryManager_GetBinaryUpgradeInfo_Call{Call: _e.mock.On("GetBinaryUpgradeInfo", ctx, info, checkMajor)}
+// SwitchBinary is a helper method to define mock.On call
+//   - bin model.Binary
+//   - version model.Version
+func (_e *BinaryManager_Expecter) SwitchBinary(bin interface{}, version interface{}) *BinaryManager_SwitchBinary_Call {
+	return &BinaryManager_SwitchBinary_Call{Call: _e.mock.On("SwitchBinary", bin, version)}
 }
 
-func (_c *BinaryManager_GetBinaryUpgradeInfo_Call) Run(run func(ctx context.Context, info model.BinaryInfo, checkMajor bool)) *BinaryManager_GetBinaryUpgradeInfo_Call {
+func (_c *BinaryManager_SwitchBinary_Call) Run(run func(bin model.Binary, version model.Version)) *BinaryManager_SwitchBinary_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 model.Binary
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
+			arg0 = args[0].(model.Binary)
 		}
-		var arg1 model.BinaryInfo
+		var arg1 model.Version
 		if args[1] != nil {
-			arg1 = args[1].(model.BinaryInfo)
-		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
+			arg1 = args[1].(model.Version)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *BinaryManager_GetBinaryUpgradeInfo_Call) Return(binaryUpgradeInfo model.BinaryUpgradeInfo, err error) *BinaryManager_GetBinaryUpgradeInfo_Call {
-	_c.Call.Return(binaryUpgradeInfo, err)
+func (_c *BinaryManager_SwitchBinary_Call) Return(err error) *BinaryManager_SwitchBinary_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *BinaryManager_GetBinaryUpgradeInfo_Call) RunAndReturn(run func(ctx context.Context, info model.BinaryInfo, checkMajor bool) (model.BinaryUpgradeInfo, error)) *BinaryManager_GetBinaryUpgradeInfo_Call {
+func (_c *BinaryManager_SwitchBinary_Call) RunAndReturn(run func(bin model.Binary, version model.Version) error) *BinaryManager_SwitchBinary_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InstallPackage provides a mock function for the type BinaryManager
-func (_mock *BinaryManager) InstallPackage(ctx context.Context, pkg model.Package, kind model.Kind, rebuild bool) error {
-	ret := _mock.Called(ctx, pkg, kind, rebuild)
+// UninstallBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) UninstallBinary(bin model.Binary, force bool, purge bool) error {
+	ret := _mock.Called(bin, force, purge)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InstallPackage")
+		panic("no return value specified for UninstallBinary")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package, model.Kind, bool) error); ok {
-		r0 = returnFunc(ctx, pkg, kind, rebuild)
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, bool, bool) error); ok {
+		r0 = returnFunc(bin, force, purge)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// BinaryManager_InstallPackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallPackage'
-type BinaryManager_InstallPackage_Call struct {
+// BinaryManager_UninstallBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UninstallBinary'
+type BinaryManager_UninstallBinary_Call struct {
 	*mock.Call
 }
 
-// InstallPackage is a helper method to define mock.On call
-//   - ctx context.Context
-//   - pkg model.Package
-//   - kind model.Kind
-//   - rebuild bool
-func (_e *BinaryManager_Expecter) InstallPackage(ctx interface{}, pkg interface{}, kind interface{}, rebuild interface{}) *BinaryManager_InstallPackage_Call {
-	return &BinaryManager_InstallPackage_Call{Call: _e.mock.On("InstallPackage", ctx, pkg, kind, rebuild)}
+// UninstallBinary is a helper method to define mock.On call
+//   - bin model.Binary
+//   - force bool
+//   - purge bool
+func (_e *BinaryManager_Expecter) UninstallBinary(bin interface{}, force interface{}, purge interface{}) *BinaryManager_UninstallBinary_Call {
+	return &BinaryManager_UninstallBinary_Call{Call: _e.mock.On("UninstallBinary", bin, force, purge)}
 }
 
-func (_c *BinaryManager_InstallPackage_Call) Run(run func(ctx context.Context, pkg model.Package, kind model.Kind, rebuild bool)) *BinaryManager_InstallPackage_Call {
+func (_c *BinaryManager_UninstallBinary_Call) Run(run func(bin model.Binary, force bool, purge bool)) *BinaryManager_UninstallBinary_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 model.Binary
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
+			arg0 = args[0].(model.Binary)
 		}
-		var arg1 model.Package
+		var arg1 bool
 		if args[1] != nil {
-			arg1 = args[1].(model.Package)
+			arg1 = args[1].(bool)
 		}
-		var arg2 model.Kind
+		var arg2 bool
 		if args[2] != nil {
-			arg2 = args[2].(model.Kind)
-		}
-		var arg3 bool
-		if args[3] != nil {
-			arg3 = args[3].(bool)
+			arg2 = args[2].(bool)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *BinaryManager_InstallPackage_Call) Return(err error) *BinaryManager_InstallPackage_Call {
+func (_c *BinaryManager_UninstallBinary_Call) Return(err error) *BinaryManager_UninstallBinary_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *BinaryManager_InstallPackage_Call) RunAndReturn(run func(ctx context.Context, pkg model.Package, kind model.Kind, rebuild bool) error) *BinaryManager_InstallPackage_Call {
+func (_c *BinaryManager_UninstallBinary_Call) RunAndReturn(run func(bin model.Binary, force bool, purge bool) error) *BinaryManager_UninstallBinary_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MigrateBinary provides a mock function for the type BinaryManager
-func (_mock *BinaryManager) MigrateBinary(path string) error {
-	ret := _mock.Called(path)
+// UnmigrateBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) UnmigrateBinary(bin model.Binary) error {
+	ret := _mock.Called(bin)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MigrateBinary")
+		panic("no return value specified for UnmigrateBinary")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(path)
+	if returnFunc, ok := ret.Get(0).(func(model.Binary) error); ok {
+		r0 = returnFunc(bin)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// BinaryManager_MigrateBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateBinary'
-type BinaryManager_MigrateBinary_Call struct {
+// BinaryManager_UnmigrateBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnmigrateBinary'
+type BinaryManager_UnmigrateBinary_Call struct {
 	*mock.Call
 }
 
-// MigrateBinary is a helper method to define mock.On call
-//   - path string
-func (_e *BinaryManager_Expecter) MigrateBinary(path interface{}) *BinaryManager_MigrateBinary_Call {
-	return &BinaryManager_MigrateBinary_Call{Call: _e.mock.On("MigrateBinary", path)}
+// UnmigrateBinary is a helper method to define mock.On call
+//   - bin model.Binary
+func (_e *BinaryManager_Expecter) UnmigrateBinary(bin interface{}) *BinaryManager_UnmigrateBinary_Call {
+	return &BinaryManager_UnmigrateBinary_Call{Call: _e.mock.On("UnmigrateBinary", bin)}
 }
 
-func (_c *BinaryManager_MigrateBinary_Call) Run(run func(path string)) *BinaryManager_MigrateBinary_Call {
+func (_c *BinaryManager_UnmigrateBinary_Call) Run(run func(bin model.Binary)) *BinaryManager_UnmigrateBinary_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 model.Binary
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(model.Binary)
 		}
 		run(
 			arg0,
@@ -474,240 +3579,291 @@ func (_c *BinaryManager_MigrateBinary_Call) Run(run func(path string)) *BinaryMa
 	return _c
 }
 
-func (_c *BinaryManager_MigrateBinary_Call) Return(err error) *BinaryManager_MigrateBinary_Call {
+func (_c *BinaryManager_UnmigrateBinary_Call) Return(err error) *BinaryManager_UnmigrateBinary_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *BinaryManager_MigrateBinary_Call) RunAndReturn(run func(path string) error) *BinaryManager_MigrateBinary_Call {
+func (_c *BinaryManager_UnmigrateBinary_Call) RunAndReturn(run func(bin model.Binary) error) *BinaryManager_UnmigrateBinary_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PinBinary provides a mock function for the type BinaryManager
-func (_mock *BinaryManager) PinBinary(bin model.Binary, kind model.Kind) error {
-	ret := _mock.Called(bin, kind)
+// UnsetBinaryEnv provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) UnsetBinaryEnv(bin model.Binary, names ...string) error {
+	var tmpRet mock.Arguments
+	if len(names) > 0 {
+		tmpRet = _mock.Called(bin, names)
+	} else {
+		tmpRet = _mock.Called(bin)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for PinBinary")
+		panic("no return value specified for UnsetBinaryEnv")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(model.Binary, model.Kind) error); ok {
-		r0 = returnFunc(bin, kind)
+	if returnFunc, ok := ret.Get(0).(func(model.Binary, ...string) error); ok {
+		r0 = returnFunc(bin, names...)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// BinaryManager_PinBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PinBinary'
-type BinaryManager_PinBinary_Call struct {
+// BinaryManager_UnsetBinaryEnv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnsetBinaryEnv'
+type BinaryManager_UnsetBinaryEnv_Call struct {
 	*mock.Call
 }
 
-// PinBinary is a helper method to define mock.On call
+// UnsetBinaryEnv is a helper method to define mock.On call
 //   - bin model.Binary
-//   - kind model.Kind
-func (_e *BinaryManager_Expecter) PinBinary(bin interface{}, kind interface{}) *BinaryManager_PinBinary_Call {
-	return &BinaryManager_PinBinary_Call{Call: _e.mock.On("PinBinary", bin, kind)}
+//   - names ...string
+func (_e *BinaryManager_Expecter) UnsetBinaryEnv(bin interface{}, names ...interface{}) *BinaryManager_UnsetBinaryEnv_Call {
+	return &BinaryManager_UnsetBinaryEnv_Call{Call: _e.mock.On("UnsetBinaryEnv",
+		append([]interface{}{bin}, names...)...)}
 }
 
-func (_c *BinaryManager_PinBinary_Call) Run(run func(bin model.Binary, kind model.Kind)) *BinaryManager_PinBinary_Call {
+func (_c *BinaryManager_UnsetBinaryEnv_Call) Run(run func(bin model.Binary, names ...string)) *BinaryManager_UnsetBinaryEnv_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 model.Binary
 		if args[0] != nil {
 			arg0 = args[0].(model.Binary)
 		}
-		var arg1 model.Kind
-		if args[1] != nil {
-			arg1 = args[1].(model.Kind)
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
 		}
+		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *BinaryManager_PinBinary_Call) Return(err error) *BinaryManager_PinBinary_Call {
+func (_c *BinaryManager_UnsetBinaryEnv_Call) Return(err error) *BinaryManager_UnsetBinaryEnv_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *BinaryManager_PinBinary_Call) RunAndReturn(run func(bin model.Binary, kind model.Kind) error) *BinaryManager_PinBinary_Call {
+func (_c *BinaryManager_UnsetBinaryEnv_Call) RunAndReturn(run func(bin model.Binary, names ...string) error) *BinaryManager_UnsetBinaryEnv_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PruneBinary provides a mock function for the type BinaryManager
-func (_mock *BinaryManager) PruneBinary(bin model.Binary) error {
-	ret := _mock.Called(bin)
+// UpdateExecutable provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) UpdateExecutable(ctx context.Context, path string, pkg model.Package) error {
+	ret := _mock.Called(ctx, path, pkg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PruneBinary")
+		panic("no return value specified for UpdateExecutable")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(model.Binary) error); ok {
-		r0 = returnFunc(bin)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Package) error); ok {
+		r0 = returnFunc(ctx, path, pkg)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// BinaryManager_PruneBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneBinary'
-type BinaryManager_PruneBinary_Call struct {
+// BinaryManager_UpdateExecutable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateExecutable'
+type BinaryManager_UpdateExecutable_Call struct {
 	*mock.Call
 }
 
-// PruneBinary is a helper method to define mock.On call
-//   - bin model.Binary
-func (_e *BinaryManager_Expecter) PruneBinary(bin interface{}) *BinaryManager_PruneBinary_Call {
-	return &BinaryManager_PruneBinary_Call{Call: _e.mock.On("PruneBinary", bin)}
+// UpdateExecutable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+//   - pkg model.Package
+func (_e *BinaryManager_Expecter) UpdateExecutable(ctx interface{}, path interface{}, pkg interface{}) *BinaryManager_UpdateExecutable_Call {
+	return &BinaryManager_UpdateExecutable_Call{Call: _e.mock.On("UpdateExecutable", ctx, path, pkg)}
 }
 
-func (_c *BinaryManager_PruneBinary_Call) Run(run func(bin model.Binary)) *BinaryManager_PruneBinary_Call {
+func (_c *BinaryManager_UpdateExecutable_Call) Run(run func(ctx context.Context, path string, pkg model.Package)) *BinaryManager_UpdateExecutable_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model.Binary
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(model.Binary)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.Package
+		if args[2] != nil {
+			arg2 = args[2].(model.Package)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *BinaryManager_PruneBinary_Call) Return(err error) *BinaryManager_PruneBinary_Call {
+func (_c *BinaryManager_UpdateExecutable_Call) Return(err error) *BinaryManager_UpdateExecutable_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *BinaryManager_PruneBinary_Call) RunAndReturn(run func(bin model.Binary) error) *BinaryManager_PruneBinary_Call {
+func (_c *BinaryManager_UpdateExecutable_Call) RunAndReturn(run func(ctx context.Context, path string, pkg model.Package) error) *BinaryManager_UpdateExecutable_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UninstallBinary provides a mock function for the type BinaryManager
-func (_mock *BinaryManager) UninstallBinary(bin model.Binary) error {
-	ret := _mock.Called(bin)
+// UpgradeBinary provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) UpgradeBinary(ctx context.Context, binFullPath string, flags model.BuildFlags, majorUpgrade bool, rebuild bool, force bool, cacheFrom string) error {
+	ret := _mock.Called(ctx, binFullPath, flags, majorUpgrade, rebuild, force, cacheFrom)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UninstallBinary")
+		panic("no return value specified for UpgradeBinary")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(model.Binary) error); ok {
-		r0 = returnFunc(bin)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.BuildFlags, bool, bool, bool, string) error); ok {
+		r0 = returnFunc(ctx, binFullPath, flags, majorUpgrade, rebuild, force, cacheFrom)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// BinaryManager_UninstallBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UninstallBinary'
-type BinaryManager_UninstallBinary_Call struct {
+// BinaryManager_UpgradeBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpgradeBinary'
+type BinaryManager_UpgradeBinary_Call struct {
 	*mock.Call
 }
 
-// UninstallBinary is a helper method to define mock.On call
-//   - bin model.Binary
-func (_e *BinaryManager_Expecter) UninstallBinary(bin interface{}) *BinaryManager_UninstallBinary_Call {
-	return &BinaryManager_UninstallBinary_Call{Call: _e.mock.On("UninstallBinary", bin)}
+// UpgradeBinary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - binFullPath string
+//   - flags model.BuildFlags
+//   - majorUpgrade bool
+//   - rebuild bool
+//   - force bool
+//   - cacheFrom string
+func (_e *BinaryManager_Expecter) UpgradeBinary(ctx interface{}, binFullPath interface{}, flags interface{}, majorUpgrade interface{}, rebuild interface{}, force interface{}, cacheFrom interface{}) *BinaryManager_UpgradeBinary_Call {
+	return &BinaryManager_UpgradeBinary_Call{Call: _e.mock.On("UpgradeBinary", ctx, binFullPath, flags, majorUpgrade, rebuild, force, cacheFrom)}
 }
 
-func (_c *BinaryManager_UninstallBinary_Call) Run(run func(bin model.Binary)) *BinaryManager_UninstallBinary_Call {
+func (_c *BinaryManager_UpgradeBinary_Call) Run(run func(ctx context.Context, binFullPath string, flags model.BuildFlags, majorUpgrade bool, rebuild bool, force bool, cacheFrom string)) *BinaryManager_UpgradeBinary_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model.Binary
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(model.Binary)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.BuildFlags
+		if args[2] != nil {
+			arg2 = args[2].(model.BuildFlags)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		var arg5 bool
+		if args[5] != nil {
+			arg5 = args[5].(bool)
+		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
 		)
 	})
 	return _c
 }
 
-func (_c *BinaryManager_UninstallBinary_Call) Return(err error) *BinaryManager_UninstallBinary_Call {
+func (_c *BinaryManager_UpgradeBinary_Call) Return(err error) *BinaryManager_UpgradeBinary_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *BinaryManager_UninstallBinary_Call) RunAndReturn(run func(bin model.Binary) error) *BinaryManager_UninstallBinary_Call {
+func (_c *BinaryManager_UpgradeBinary_Call) RunAndReturn(run func(ctx context.Context, binFullPath string, flags model.BuildFlags, majorUpgrade bool, rebuild bool, force bool, cacheFrom string) error) *BinaryManager_UpgradeBinary_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpgradeBinary provides a mock function for the type BinaryManager
-func (_mock *BinaryManager) UpgradeBinary(ctx context.Context, binFullPath string, majorUpgrade bool, rebuild bool) error {
-	ret := _mock.Called(ctx, binFullPath, majorUpgrade, rebuild)
+// VerifyModuleSum provides a mock function for the type BinaryManager
+func (_mock *BinaryManager) VerifyModuleSum(ctx context.Context, module model.Module, sum string) error {
+	ret := _mock.Called(ctx, module, sum)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpgradeBinary")
+		panic("no return value specified for VerifyModuleSum")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool, bool) error); ok {
-		r0 = returnFunc(ctx, binFullPath, majorUpgrade, rebuild)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module, string) error); ok {
+		r0 = returnFunc(ctx, module, sum)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// BinaryManager_UpgradeBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpgradeBinary'
-type BinaryManager_UpgradeBinary_Call struct {
+// BinaryManager_VerifyModuleSum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyModuleSum'
+type BinaryManager_VerifyModuleSum_Call struct {
 	*mock.Call
 }
 
-// UpgradeBinary is a helper method to define mock.On call
+// VerifyModuleSum is a helper method to define mock.On call
 //   - ctx context.Context
-//   - binFullPath string
-//   - majorUpgrade bool
-//   - rebuild bool
-func (_e *BinaryManager_Expecter) UpgradeBinary(ctx interface{}, binFullPath interface{}, majorUpgrade interface{}, rebuild interface{}) *BinaryManager_UpgradeBinary_Call {
-	return &BinaryManager_UpgradeBinary_Call{Call: _e.mock.On("UpgradeBinary", ctx, binFullPath, majorUpgrade, rebuild)}
+//   - module model.Module
+//   - sum string
+func (_e *BinaryManager_Expecter) VerifyModuleSum(ctx interface{}, module interface{}, sum interface{}) *BinaryManager_VerifyModuleSum_Call {
+	return &BinaryManager_VerifyModuleSum_Call{Call: _e.mock.On("VerifyModuleSum", ctx, module, sum)}
 }
 
-func (_c *BinaryManager_UpgradeBinary_Call) Run(run func(ctx context.Context, binFullPath string, majorUpgrade bool, rebuild bool)) *BinaryManager_UpgradeBinary_Call {
+func (_c *BinaryManager_VerifyModuleSum_Call) Run(run func(ctx context.Context, module model.Module, sum string)) *BinaryManager_VerifyModuleSum_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 model.Module
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(model.Module)
 		}
-		var arg2 bool
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(bool)
-		}
-		var arg3 bool
-		if args[3] != nil {
-			arg3 = args[3].(bool)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *BinaryManager_UpgradeBinary_Call) Return(err error) *BinaryManager_UpgradeBinary_Call {
+func (_c *BinaryManager_VerifyModuleSum_Call) Return(err error) *BinaryManager_VerifyModuleSum_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *BinaryManager_UpgradeBinary_Call) RunAndReturn(run func(ctx context.Context, binFullPath string, majorUpgrade bool, rebuild bool) error) *BinaryManager_UpgradeBinary_Call {
+func (_c *BinaryManager_VerifyModuleSum_Call) RunAndReturn(run func(ctx context.Context, module model.Module, sum string) error) *BinaryManager_VerifyModuleSum_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ActionType is the type of a filesystem action planned by an operation run in
+// dry-run mode.
+type ActionType string
+
+const (
+	// ActionInstall builds a package and stores the binary in the internal
+	// binary directory.
+	ActionInstall ActionType = "install"
+	// ActionLink pins a binary to the Go binary directory.
+	ActionLink ActionType = "link"
+	// ActionReplace replaces an existing pin or binary in the Go binary
+	// directory.
+	ActionReplace ActionType = "replace"
+	// ActionRemove removes a file or directory.
+	ActionRemove ActionType = "remove"
+)
+
+// Action represents a filesystem action planned by an operation: the path it
+// applies to and its source, the package built for the install actions or the
+// internal binary pinned for the link and replace actions. Flags is the build
+// flags the package is built with, only set for the install actions.
+type Action struct {
+	Type   ActionType
+	Path   string
+	Source string
+	Flags  BuildFlags
+}
+
+// String returns the string representation of the action, ex.
+// "link /home/user/go/bin/dlv → /home/user/.gobin/bin/dlv@v1.25.0".
+func (a Action) String() string {
+	switch a.Type {
+	case ActionInstall:
+		if args := append(a.Flags.Args(), a.Flags.Env()...); len(args) > 0 {
+			return fmt.Sprintf("%s %s into %s with %s", a.Type, a.Source, a.Path, strings.Join(args, " "))
+		}
+
+		return fmt.Sprintf("%s %s into %s", a.Type, a.Source, a.Path)
+	case ActionLink, ActionReplace:
+		return fmt.Sprintf("%s %s → %s", a.Type, a.Path, a.Source)
+	default:
+		return fmt.Sprintf("%s %s", a.Type, a.Path)
+	}
+}
@@ -0,0 +1,61 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestAction_String(t *testing.T) {
+	cases := map[string]struct {
+		action   model.Action
+		expected string
+	}{
+		"install": {
+			action: model.Action{
+				Type:   model.ActionInstall,
+				Path:   "/home/user/.gobin/bin/mockproj@v0.1.0",
+				Source: "example.com/mockorg/mockproj@v0.1.0",
+			},
+			expected: "install example.com/mockorg/mockproj@v0.1.0 into /home/user/.gobin/bin/mockproj@v0.1.0",
+		},
+		"install-with-flags": {
+			action: model.Action{
+				Type:   model.ActionInstall,
+				Path:   "/home/user/.gobin/bin/mockproj@v0.1.0",
+				Source: "example.com/mockorg/mockproj@v0.1.0",
+				Flags:  model.BuildFlags{Tags: "netgo", CGOEnabled: "0"},
+			},
+			expected: "install example.com/mockorg/mockproj@v0.1.0 into /home/user/.gobin/bin/mockproj@v0.1.0 " +
+				"with -tags=netgo CGO_ENABLED=0",
+		},
+		"link": {
+			action: model.Action{
+				Type:   model.ActionLink,
+				Path:   "/home/user/go/bin/mockproj",
+				Source: "/home/user/.gobin/bin/mockproj@v0.1.0",
+			},
+			expected: "link /home/user/go/bin/mockproj → /home/user/.gobin/bin/mockproj@v0.1.0",
+		},
+		"replace": {
+			action: model.Action{
+				Type:   model.ActionReplace,
+				Path:   "/home/user/go/bin/mockproj",
+				Source: "/home/user/.gobin/bin/mockproj@v0.1.0",
+			},
+			expected: "replace /home/user/go/bin/mockproj → /home/user/.gobin/bin/mockproj@v0.1.0",
+		},
+		"remove": {
+			action:   model.Action{Type: model.ActionRemove, Path: "/home/user/go/bin/mockproj"},
+			expected: "remove /home/user/go/bin/mockproj",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.action.String())
+		})
+	}
+}
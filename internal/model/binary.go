@@ -1,9 +1,9 @@
 package model
 
-import (
-	"path/filepath"
-	"strings"
-)
+import "strings"
+
+// windowsExtension is the extension of the binaries on Windows.
+const windowsExtension = ".exe"
 
 // Binary represents a binary.
 type Binary struct {
@@ -27,19 +27,21 @@ func NewBinary(
 
 // NewBinaryFromString creates a new binary from a binary name, version and
 // extension. If the binary name does not have a version, it defaults to
-// "latest". Extension is the extension of the binary and can be empty.
+// "latest". Extension is the extension of the binary and can be empty. The
+// ".exe" extension is recognized in any case after the name or the version,
+// ex. "dlv.exe@v1" or "dlv@v1.exe", and normalized to lowercase.
 func NewBinaryFromString(bin string) Binary {
-	extension := filepath.Ext(bin)
-	if extension != ".exe" {
-		extension = ""
-	}
-
-	version := "latest"
-	name := strings.TrimSuffix(bin, extension)
+	name, version := bin, "latest"
 
 	//nolint:mnd // expected version format: name@version
 	if parts := strings.Split(bin, "@"); len(parts) == 2 {
-		name, version = parts[0], strings.TrimSuffix(parts[1], extension)
+		name, version = parts[0], parts[1]
+	}
+
+	name, extension := trimExtension(name)
+	version, versionExt := trimExtension(version)
+	if versionExt != "" {
+		extension = versionExt
 	}
 
 	return Binary{
@@ -49,47 +51,76 @@ func NewBinaryFromString(bin string) Binary {
 	}
 }
 
-// GetPinKind returns the pin kind of the binary. If the binary name contains
-// a version suffix, it returns the kind. Otherwise, it returns latest.
-func (b Binary) GetPinKind() Kind {
-	parts := strings.Split(b.Name, "-")
-	if version := NewVersion(parts[len(parts)-1]); version.IsValid() {
-		if strings.Contains(version.String(), ".") {
-			return KindMinor
-		}
-
-		return KindMajor
+// GetBinaryExtension returns the extension of the binaries on the given
+// operating system, ".exe" on Windows or empty otherwise.
+func GetBinaryExtension(goos string) string {
+	if goos == "windows" {
+		return windowsExtension
 	}
 
-	return KindLatest
+	return ""
 }
 
-// GetPinnedVersion returns the pinned version of the binary. If the binary
-// name contains a version suffix, it returns the version. Otherwise, it
-// returns "latest".
-func (b Binary) GetPinnedVersion() Version {
-	parts := strings.Split(b.Name, "-")
-	if len(parts) > 1 {
-		if version := NewVersion(parts[len(parts)-1]); version.IsValid() {
-			return version
-		}
+// GetPlatformDir returns the name of the directory of the internal binaries
+// cross compiled for the given platform, ex. "linux_amd64" for "linux/amd64".
+func GetPlatformDir(platform string) string {
+	return strings.Replace(platform, "/", "_", 1)
+}
+
+// ParsePlatformDir returns the platform of the given directory of cross
+// compiled internal binaries, ex. "linux/amd64" for "linux_amd64", or false if
+// the name is not a platform directory.
+func ParsePlatformDir(dir string) (string, bool) {
+	goos, goarch, ok := strings.Cut(dir, "_")
+	if !ok || goos == "" || goarch == "" || strings.ContainsAny(goarch, "_@.") {
+		return "", false
 	}
 
-	return NewLatestVersion()
+	return goos + "/" + goarch, true
+}
+
+// GetBaseName returns the name of the binary with its extension and without
+// version, ex. "dlv.exe".
+func (b Binary) GetBaseName() string {
+	return b.Name + b.Extension
+}
+
+// GetPinKind returns the pin kind of the binary. If the binary name contains
+// a version in the given pin format, it returns the kind. Otherwise, it
+// returns latest.
+func (b Binary) GetPinKind(format PinFormat) Kind {
+	_, version := format.Parse(b.Name)
+	if version.IsLatest() {
+		return KindLatest
+	}
+
+	if strings.Contains(version.String(), ".") {
+		return KindMinor
+	}
+
+	return KindMajor
+}
+
+// GetPinnedVersion returns the pinned version of the binary. If the binary
+// name contains a version in the given pin format, it returns the version.
+// Otherwise, it returns "latest".
+func (b Binary) GetPinnedVersion(format PinFormat) Version {
+	_, version := format.Parse(b.Name)
+	return version
 }
 
 // GetTargetBinName returns the target binary name for a binary based on the
-// pin kind.
-func (b Binary) GetTargetBinName(kind Kind) string {
+// pin kind and the given pin format.
+func (b Binary) GetTargetBinName(kind Kind, format PinFormat) string {
 	var name string
 
 	switch kind {
 	case KindLatest:
 		name = b.Name + b.Extension
 	case KindMajor:
-		name = b.Name + "-" + b.Version.Major() + b.Extension
+		name = format.Format(b.Name, NewVersion(b.Version.Major()), b.Extension)
 	case KindMinor:
-		name = b.Name + "-" + b.Version.MajorMinor() + b.Extension
+		name = format.Format(b.Name, NewVersion(b.Version.MajorMinor()), b.Extension)
 	}
 
 	return name
@@ -122,3 +153,25 @@ func (b Binary) String() string {
 
 	return b.Name + "@" + b.Version.String() + b.Extension
 }
+
+// WithOSExtension returns the binary with the extension of the binaries on the
+// given operating system, unless it already has an extension.
+func (b Binary) WithOSExtension(goos string) Binary {
+	if b.Extension == "" {
+		b.Extension = GetBinaryExtension(goos)
+	}
+
+	return b
+}
+
+// trimExtension trims the ".exe" extension, in any case, from the end of the
+// given string. It returns the trimmed string and the lowercase extension, or
+// the string unchanged and an empty extension if it has no ".exe" extension.
+func trimExtension(s string) (string, string) {
+	if len(s) > len(windowsExtension) &&
+		strings.EqualFold(s[len(s)-len(windowsExtension):], windowsExtension) {
+		return s[:len(s)-len(windowsExtension)], windowsExtension
+	}
+
+	return s, ""
+}
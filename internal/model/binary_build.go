@@ -0,0 +1,60 @@
+package model
+
+import (
+	"maps"
+	"slices"
+)
+
+// BinaryBuild represents the build of a binary: the main module, the Go
+// version, the build settings and the dependency versions embedded in the
+// binary, and the binary size. A zero size means the size is unknown, ex. for a
+// build resolved from the module file.
+type BinaryBuild struct {
+	Module    Module
+	GoVersion string
+	Settings  map[string]string
+	Deps      map[string]string
+	Size      ByteSize
+}
+
+// BuildChange represents a change of a build setting or dependency between two
+// builds. An empty From means it was added and an empty To means it was
+// removed.
+type BuildChange struct {
+	Key  string
+	From string
+	To   string
+}
+
+// DiffDeps returns the dependency changes from the build to another build,
+// sorted by module path.
+func (b BinaryBuild) DiffDeps(other BinaryBuild) []BuildChange {
+	return diffBuildValues(b.Deps, other.Deps)
+}
+
+// DiffSettings returns the build setting changes from the build to another
+// build, sorted by key.
+func (b BinaryBuild) DiffSettings(other BinaryBuild) []BuildChange {
+	return diffBuildValues(b.Settings, other.Settings)
+}
+
+// diffBuildValues returns the changes between two sets of values, sorted by
+// key.
+func diffBuildValues(from, to map[string]string) []BuildChange {
+	keys := slices.Collect(maps.Keys(from))
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var changes []BuildChange
+	for _, key := range keys {
+		if from[key] != to[key] {
+			changes = append(changes, BuildChange{Key: key, From: from[key], To: to[key]})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,79 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestBinaryBuild_DiffDeps(t *testing.T) {
+	cases := map[string]struct {
+		from     model.BinaryBuild
+		to       model.BinaryBuild
+		expected []model.BuildChange
+	}{
+		"no-changes": {
+			from: model.BinaryBuild{Deps: map[string]string{"example.com/a": "v1.0.0"}},
+			to:   model.BinaryBuild{Deps: map[string]string{"example.com/a": "v1.0.0"}},
+		},
+		"changes": {
+			from: model.BinaryBuild{Deps: map[string]string{
+				"example.com/a": "v1.0.0",
+				"example.com/b": "v1.0.0",
+				"example.com/c": "v1.0.0",
+			}},
+			to: model.BinaryBuild{Deps: map[string]string{
+				"example.com/a": "v1.0.0",
+				"example.com/c": "v1.1.0",
+				"example.com/d": "v0.1.0",
+			}},
+			expected: []model.BuildChange{
+				{Key: "example.com/b", From: "v1.0.0"},
+				{Key: "example.com/c", From: "v1.0.0", To: "v1.1.0"},
+				{Key: "example.com/d", To: "v0.1.0"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.from.DiffDeps(tc.to))
+		})
+	}
+}
+
+func TestBinaryBuild_DiffSettings(t *testing.T) {
+	cases := map[string]struct {
+		from     model.BinaryBuild
+		to       model.BinaryBuild
+		expected []model.BuildChange
+	}{
+		"no-changes": {
+			from: model.BinaryBuild{Settings: map[string]string{"CGO_ENABLED": "0"}},
+			to:   model.BinaryBuild{Settings: map[string]string{"CGO_ENABLED": "0"}},
+		},
+		"changes": {
+			from: model.BinaryBuild{Settings: map[string]string{
+				"CGO_ENABLED": "1",
+				"-trimpath":   "true",
+			}},
+			to: model.BinaryBuild{Settings: map[string]string{
+				"CGO_ENABLED": "0",
+				"-ldflags":    "-s -w",
+			}},
+			expected: []model.BuildChange{
+				{Key: "-ldflags", To: "-s -w"},
+				{Key: "-trimpath", From: "true"},
+				{Key: "CGO_ENABLED", From: "1", To: "0"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.from.DiffSettings(tc.to))
+		})
+	}
+}
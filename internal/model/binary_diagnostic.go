@@ -1,5 +1,7 @@
 package model
 
+import "strings"
+
 // BinaryDiagnostic represents the diagnostic results for a binary.
 type BinaryDiagnostic struct {
 	Name                  string
@@ -17,9 +19,26 @@ type BinaryDiagnostic struct {
 		Actual   string
 		Expected string
 	}
-	Retracted       string
-	Deprecated      string
-	Vulnerabilities []Vulnerability
+	MissingInterpreter string
+	LongPath           string
+	// Module is the path of the module of the binary, set when its status is
+	// diagnosed.
+	Module     string
+	Retracted  string
+	Deprecated string
+	// CompressedNotExecutable is the error of a binary compressed with UPX
+	// failing to execute.
+	CompressedNotExecutable string
+	// InvalidSignature is the error of a binary signed after its install whose
+	// signature is no longer valid.
+	InvalidSignature string
+	// SumMismatch is the error of a binary whose module sum differs from the
+	// one recorded in the checksum database.
+	SumMismatch string
+	// SumNotVerifiable is the reason the module sum of a binary cannot be
+	// verified against the checksum database.
+	SumNotVerifiable string
+	Vulnerabilities  []Vulnerability
 }
 
 // HasIssues returns whether the binary has any issues.
@@ -32,7 +51,34 @@ func (d BinaryDiagnostic) HasIssues() bool {
 		d.IsOrphaned ||
 		d.GoVersion.Actual != d.GoVersion.Expected ||
 		d.Platform.Actual != d.Platform.Expected ||
+		d.MissingInterpreter != "" ||
+		d.LongPath != "" ||
 		d.Retracted != "" ||
 		d.Deprecated != "" ||
+		d.CompressedNotExecutable != "" ||
+		d.InvalidSignature != "" ||
+		d.SumMismatch != "" ||
+		d.SumNotVerifiable != "" ||
 		len(d.Vulnerabilities) > 0
 }
+
+// HasWarnings returns whether the binary has issues which are warnings rather
+// than errors, ex. a pseudo-version, a deprecated module, a compressed binary
+// failing to execute or a module sum not verifiable.
+func (d BinaryDiagnostic) HasWarnings() bool {
+	return d.IsPseudoVersion || d.Deprecated != "" || d.CompressedNotExecutable != "" || d.SumNotVerifiable != ""
+}
+
+// GetMissingLibc returns the C library the binary is dynamically linked
+// against, based on the missing program interpreter, or an empty string if
+// unknown.
+func (d BinaryDiagnostic) GetMissingLibc() string {
+	switch {
+	case strings.Contains(d.MissingInterpreter, "ld-musl"):
+		return "musl"
+	case strings.Contains(d.MissingInterpreter, "ld-linux"):
+		return "glibc"
+	default:
+		return ""
+	}
+}
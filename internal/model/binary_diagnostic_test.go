@@ -45,14 +45,43 @@ func TestBinaryDiagnostic_HasIssues(t *testing.T) {
 					Actual:   "linux/amd64",
 					Expected: "darwin/arm64",
 				},
-				Retracted:  "mock-retracted",
-				Deprecated: "mock-deprecated",
+				MissingInterpreter: "/lib/ld-musl-x86_64.so.1",
+				Retracted:          "mock-retracted",
+				Deprecated:         "mock-deprecated",
 				Vulnerabilities: []model.Vulnerability{
 					{ID: "GO-2025-3770", URL: "https://pkg.go.dev/vuln/GO-2025-3770"},
 				},
 			},
 			expected: true,
 		},
+		"missing-interpreter": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:               "mockproj",
+				MissingInterpreter: "/lib64/ld-linux-x86-64.so.2",
+			},
+			expected: true,
+		},
+		"long-path": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:     "mockproj.exe",
+				LongPath: `C:\Users\mockuser\AppData\Local\gobin\bin\mockproj@v0.0.0-20250714171936-2fc2d3f24795.exe`,
+			},
+			expected: true,
+		},
+		"invalid-signature": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:             "mockproj",
+				InvalidSignature: "exit status 1: mockproj: invalid signature",
+			},
+			expected: true,
+		},
+		"sum-mismatch": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:        "mockproj",
+				SumMismatch: "module sum mismatch: recorded h1:mocksum=, checksum database h1:othersum=",
+			},
+			expected: true,
+		},
 	}
 
 	for name, tc := range cases {
@@ -61,3 +90,90 @@ func TestBinaryDiagnostic_HasIssues(t *testing.T) {
 		})
 	}
 }
+
+func TestBinaryDiagnostic_HasWarnings(t *testing.T) {
+	cases := map[string]struct {
+		binaryDiagnostic model.BinaryDiagnostic
+		expected         bool
+	}{
+		"no-warnings": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:      "mockproj",
+				NotInPath: true,
+				Retracted: "mock-retracted",
+			},
+			expected: false,
+		},
+		"pseudo-version": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:            "mockproj",
+				IsPseudoVersion: true,
+			},
+			expected: true,
+		},
+		"deprecated": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:       "mockproj",
+				Deprecated: "mock-deprecated",
+			},
+			expected: true,
+		},
+		"compressed-not-executable": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:                    "mockproj",
+				CompressedNotExecutable: "signal: killed",
+			},
+			expected: true,
+		},
+		"invalid-signature": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:             "mockproj",
+				InvalidSignature: "exit status 1: mockproj: invalid signature",
+			},
+			expected: false,
+		},
+		"sum-not-verifiable": {
+			binaryDiagnostic: model.BinaryDiagnostic{
+				Name:             "mockproj",
+				SumNotVerifiable: "module sum not verifiable: no module sum, likely built locally",
+			},
+			expected: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.binaryDiagnostic.HasWarnings())
+		})
+	}
+}
+
+func TestBinaryDiagnostic_GetMissingLibc(t *testing.T) {
+	cases := map[string]struct {
+		missingInterpreter string
+		expected           string
+	}{
+		"musl": {
+			missingInterpreter: "/lib/ld-musl-x86_64.so.1",
+			expected:           "musl",
+		},
+		"glibc": {
+			missingInterpreter: "/lib64/ld-linux-x86-64.so.2",
+			expected:           "glibc",
+		},
+		"unknown": {
+			missingInterpreter: "/system/bin/linker64",
+			expected:           "",
+		},
+		"none": {
+			expected: "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diag := model.BinaryDiagnostic{MissingInterpreter: tc.missingInterpreter}
+			assert.Equal(t, tc.expected, diag.GetMissingLibc())
+		})
+	}
+}
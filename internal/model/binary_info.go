@@ -19,9 +19,12 @@ type BinaryInfo struct {
 	Arch           string
 	Feature        string
 	EnvVars        []string
+	BuildFlags     BuildFlags
 
-	IsManaged bool
-	IsPinned  bool
+	IsManaged    bool
+	IsPinned     bool
+	IsDevBuild   bool
+	IsCrossBuild bool
 }
 
 // BinaryUpgradeInfo represents the upgrade information for a binary.
@@ -32,15 +35,29 @@ type BinaryUpgradeInfo struct {
 	IsUpgradeAvailable bool
 }
 
+// BinaryGoUpdateInfo represents the Go update information for a binary built
+// with a Go version with a newer patch release, carrying security fixes.
+// IsSupported is whether the Go minor version it is built with is still
+// supported, otherwise the latest Go version is the latest patch release of the
+// oldest supported Go minor version.
+type BinaryGoUpdateInfo struct {
+	BinaryInfo
+
+	LatestGoVersion string
+	IsSupported     bool
+}
+
 // GetUpgradePackage returns the package for a binary upgrade. If the latest
 // version is a major version v2 or higher, it adjusts the package path to
-// include the major version, following the Go module versioning rules.
+// include the major version, following the Go module versioning rules, unless
+// the latest version is +incompatible.
 func (b BinaryUpgradeInfo) GetUpgradePackage() Package {
 	baseModule := b.LatestModule.GetBaseModule()
 	packageSuffix := strings.TrimPrefix(b.PackagePath, b.Module.Path)
 
 	pkg := baseModule + packageSuffix
-	if major := b.LatestModule.Version.Major(); major != "v0" && major != "v1" {
+	if major := b.LatestModule.Version.Major(); major != "v0" && major != "v1" &&
+		!b.LatestModule.Version.IsIncompatible() {
 		pkg = baseModule + "/" + major + packageSuffix
 	}
 
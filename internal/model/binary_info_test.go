@@ -61,6 +61,18 @@ func TestBinaryUpgradeInfo_GetUpgradePackage(t *testing.T) {
 			},
 			expected: model.NewPackage("example.com/mockorg/mockproj/v2/cmd/mockproj@v2.0.0"),
 		},
+		"different-module-package-paths-incompatible-version": {
+			binaryInfo: model.BinaryUpgradeInfo{
+				BinaryInfo: model.BinaryInfo{
+					PackagePath: "example.com/mockorg/mockproj/cmd/mockproj",
+					Module: model.Module{
+						Path: "example.com/mockorg/mockproj",
+					},
+				},
+				LatestModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v3.2.0+incompatible")),
+			},
+			expected: model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v3.2.0+incompatible"),
+		},
 	}
 
 	for name, tc := range cases {
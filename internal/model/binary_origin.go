@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// BinaryOrigin represents where a binary in the Go binary directory came from:
+// its binary info, the pin kind resolving its version on upgrades, the pin mode
+// linking it to the Go binary directory, the time it was installed, and the
+// metadata recorded in its receipt. PinMode is empty for the binaries not
+// managed by gobin.
+type BinaryOrigin struct {
+	BinaryInfo
+
+	Kind            Kind
+	PinMode         PinMode
+	InstalledAt     time.Time
+	PreviousVersion Version
+	MigratedFrom    string
+	Source          string
+	Protected       bool
+}
+
+// GetLinkedBy returns who links the binary to the Go binary directory, ex.
+// "gobin (symlink to /home/user/.gobin/bin/dlv@v1.25.0)".
+func (o BinaryOrigin) GetLinkedBy() string {
+	switch {
+	case !o.IsManaged:
+		return "external (not managed by gobin)"
+	case o.PinMode == PinModeCopy:
+		return "gobin (copy of " + o.InstallPath + ")"
+	default:
+		return "gobin (symlink to " + o.InstallPath + ")"
+	}
+}
+
+// GetResolution returns how the version of the binary is resolved on upgrades,
+// ex. "latest v1 release (major pin)".
+func (o BinaryOrigin) GetResolution() string {
+	switch {
+	case o.Source != "":
+		return "development build of " + o.Source
+	case !o.IsManaged:
+		return "unknown (not managed by gobin)"
+	case o.Kind == KindMajor:
+		return fmt.Sprintf("latest %s release (major pin)", o.Module.Version.Major())
+	case o.Kind == KindMinor:
+		return fmt.Sprintf("latest %s release (minor pin)", o.Module.Version.MajorMinor())
+	default:
+		return "latest release"
+	}
+}
@@ -0,0 +1,92 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestBinaryOrigin_GetLinkedBy(t *testing.T) {
+	cases := map[string]struct {
+		origin   model.BinaryOrigin
+		expected string
+	}{
+		"symlink": {
+			origin: model.BinaryOrigin{
+				BinaryInfo: model.BinaryInfo{
+					InstallPath: "/home/user/.gobin/bin/mockproj@v0.1.0",
+					IsManaged:   true,
+				},
+				PinMode: model.PinModeSymlink,
+			},
+			expected: "gobin (symlink to /home/user/.gobin/bin/mockproj@v0.1.0)",
+		},
+		"copy": {
+			origin: model.BinaryOrigin{
+				BinaryInfo: model.BinaryInfo{
+					InstallPath: "/home/user/.gobin/bin/mockproj@v0.1.0",
+					IsManaged:   true,
+				},
+				PinMode: model.PinModeCopy,
+			},
+			expected: "gobin (copy of /home/user/.gobin/bin/mockproj@v0.1.0)",
+		},
+		"external": {
+			origin: model.BinaryOrigin{
+				BinaryInfo: model.BinaryInfo{InstallPath: "/home/user/go/bin/mockproj"},
+			},
+			expected: "external (not managed by gobin)",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.origin.GetLinkedBy())
+		})
+	}
+}
+
+func TestBinaryOrigin_GetResolution(t *testing.T) {
+	info := model.BinaryInfo{
+		Module:    model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.2.3")),
+		IsManaged: true,
+	}
+
+	cases := map[string]struct {
+		origin   model.BinaryOrigin
+		expected string
+	}{
+		"latest": {
+			origin:   model.BinaryOrigin{BinaryInfo: info, Kind: model.KindLatest},
+			expected: "latest release",
+		},
+		"major": {
+			origin:   model.BinaryOrigin{BinaryInfo: info, Kind: model.KindMajor},
+			expected: "latest v1 release (major pin)",
+		},
+		"minor": {
+			origin:   model.BinaryOrigin{BinaryInfo: info, Kind: model.KindMinor},
+			expected: "latest v1.2 release (minor pin)",
+		},
+		"development-build": {
+			origin: model.BinaryOrigin{
+				BinaryInfo: info,
+				Kind:       model.KindLatest,
+				Source:     "/home/user/src/mockproj",
+			},
+			expected: "development build of /home/user/src/mockproj",
+		},
+		"unmanaged": {
+			origin:   model.BinaryOrigin{Kind: model.KindLatest},
+			expected: "unknown (not managed by gobin)",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.origin.GetResolution())
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+// BinaryReproduction represents the result of rebuilding an installed binary
+// with the same package version, build flags and Go toolchain. It holds the
+// SHA-256 hashes of the installed and rebuilt binaries, and the build settings
+// that differ between them.
+type BinaryReproduction struct {
+	Name          string
+	Package       Package
+	GoVersion     string
+	InstalledHash string
+	RebuiltHash   string
+	Settings      []BuildChange
+}
+
+// IsReproducible returns whether the rebuilt binary is identical to the
+// installed one.
+func (r BinaryReproduction) IsReproducible() bool {
+	return r.InstalledHash != "" && r.InstalledHash == r.RebuiltHash
+}
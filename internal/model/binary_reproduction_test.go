@@ -0,0 +1,36 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestBinaryReproduction_IsReproducible(t *testing.T) {
+	cases := map[string]struct {
+		reproduction model.BinaryReproduction
+		expected     bool
+	}{
+		"reproducible": {
+			reproduction: model.BinaryReproduction{InstalledHash: "abc", RebuiltHash: "abc"},
+			expected:     true,
+		},
+		"not-reproducible": {
+			reproduction: model.BinaryReproduction{InstalledHash: "abc", RebuiltHash: "def"},
+			expected:     false,
+		},
+		"no-hashes": {
+			reproduction: model.BinaryReproduction{},
+			expected:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.reproduction.IsReproducible()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
@@ -45,6 +45,22 @@ func TestNewBinaryFromString(t *testing.T) {
 				".exe",
 			),
 		},
+		"with-extension-before-version": {
+			bin: "mockproj.exe@v1.2.3",
+			expected: model.NewBinary(
+				"mockproj",
+				model.NewVersion("v1.2.3"),
+				".exe",
+			),
+		},
+		"with-uppercase-extension": {
+			bin: "mockproj@v1.2.3.EXE",
+			expected: model.NewBinary(
+				"mockproj",
+				model.NewVersion("v1.2.3"),
+				".exe",
+			),
+		},
 		"with-major-pinned-version": {
 			bin: "mockproj-v1",
 			expected: model.NewBinary(
@@ -87,40 +103,145 @@ func TestNewBinaryFromString(t *testing.T) {
 	}
 }
 
+func TestGetBinaryExtension(t *testing.T) {
+	cases := map[string]struct {
+		goos     string
+		expected string
+	}{
+		"windows": {
+			goos:     "windows",
+			expected: ".exe",
+		},
+		"linux": {
+			goos:     "linux",
+			expected: "",
+		},
+		"darwin": {
+			goos:     "darwin",
+			expected: "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := model.GetBinaryExtension(tc.goos)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestGetPlatformDir(t *testing.T) {
+	assert.Equal(t, "linux_amd64", model.GetPlatformDir("linux/amd64"))
+}
+
+func TestParsePlatformDir(t *testing.T) {
+	cases := map[string]struct {
+		dir              string
+		expectedPlatform string
+		expectedOk       bool
+	}{
+		"success": {
+			dir:              "linux_amd64",
+			expectedPlatform: "linux/amd64",
+			expectedOk:       true,
+		},
+		"not-platform-no-underscore": {
+			dir: "dlv",
+		},
+		"not-platform-binary": {
+			dir: "my_tool@v1.0.0",
+		},
+		"not-platform-multiple-underscores": {
+			dir: "my_cool_tool",
+		},
+		"not-platform-missing-arch": {
+			dir: "linux_",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			platform, ok := model.ParsePlatformDir(tc.dir)
+			assert.Equal(t, tc.expectedPlatform, platform)
+			assert.Equal(t, tc.expectedOk, ok)
+		})
+	}
+}
+
+func TestBinary_GetBaseName(t *testing.T) {
+	cases := map[string]struct {
+		bin      model.Binary
+		expected string
+	}{
+		"without-extension": {
+			bin:      model.NewBinaryFromString("mockproj@v1.2.3"),
+			expected: "mockproj",
+		},
+		"with-extension": {
+			bin:      model.NewBinaryFromString("mockproj@v1.2.3.exe"),
+			expected: "mockproj.exe",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.bin.GetBaseName()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestBinary_GetPinKind(t *testing.T) {
 	cases := map[string]struct {
 		bin      model.Binary
+		format   model.PinFormat
 		expected model.Kind
 	}{
 		"latest": {
 			bin:      model.NewBinaryFromString("mockproj"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.KindLatest,
 		},
 		"latest-multiple-parts": {
 			bin:      model.NewBinaryFromString("mockproj-test"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.KindLatest,
 		},
 		"major-pinned-version": {
 			bin:      model.NewBinaryFromString("mockproj-v1"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.KindMajor,
 		},
 		"major-pinned-version-multiple-parts": {
 			bin:      model.NewBinaryFromString("mockproj-test-v1"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.KindMajor,
 		},
 		"minor-pinned-version": {
 			bin:      model.NewBinaryFromString("mockproj-v1.2"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.KindMinor,
 		},
 		"minor-pinned-version-multiple-parts": {
 			bin:      model.NewBinaryFromString("mockproj-test-v1.2"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.KindMinor,
 		},
+		"minor-pinned-version-prefix": {
+			bin:      model.NewBinaryFromString("v1.2_mockproj"),
+			format:   model.NewPinFormat("_", model.PinPlacementPrefix),
+			expected: model.KindMinor,
+		},
+		"latest-prefix": {
+			bin:      model.NewBinaryFromString("mockproj_test"),
+			format:   model.NewPinFormat("_", model.PinPlacementPrefix),
+			expected: model.KindLatest,
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			result := tc.bin.GetPinKind()
+			result := tc.bin.GetPinKind(tc.format)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -129,37 +250,54 @@ func TestBinary_GetPinKind(t *testing.T) {
 func TestBinary_GetPinnedVersion(t *testing.T) {
 	cases := map[string]struct {
 		bin      model.Binary
+		format   model.PinFormat
 		expected model.Version
 	}{
 		"latest": {
 			bin:      model.NewBinaryFromString("mockproj"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.NewLatestVersion(),
 		},
 		"latest-multiple-parts": {
 			bin:      model.NewBinaryFromString("mockproj-test"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.NewLatestVersion(),
 		},
 		"with-major-pinned-version": {
 			bin:      model.NewBinaryFromString("mockproj-v1"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.NewVersion("v1"),
 		},
 		"with-major-pinned-version-multiple-parts": {
 			bin:      model.NewBinaryFromString("mockproj-test-v1"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.NewVersion("v1"),
 		},
 		"with-minor-pinned-version": {
 			bin:      model.NewBinaryFromString("mockproj-v1.2"),
+			format:   model.NewDefaultPinFormat(),
 			expected: model.NewVersion("v1.2"),
 		},
 		"with-minor-pinned-version-multiple-parts": {
 			bin:      model.NewBinaryFromString("mockproj-test-v1.2"),
+			format:   model.NewDefaultPinFormat(),
+			expected: model.NewVersion("v1.2"),
+		},
+		"with-minor-pinned-version-custom-separator": {
+			bin:      model.NewBinaryFromString("mockproj-test~v1.2"),
+			format:   model.NewPinFormat("~", model.PinPlacementSuffix),
 			expected: model.NewVersion("v1.2"),
 		},
+		"with-major-pinned-version-prefix": {
+			bin:      model.NewBinaryFromString("v1_mockproj_test"),
+			format:   model.NewPinFormat("_", model.PinPlacementPrefix),
+			expected: model.NewVersion("v1"),
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			result := tc.bin.GetPinnedVersion()
+			result := tc.bin.GetPinnedVersion(tc.format)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -168,44 +306,69 @@ func TestBinary_GetPinnedVersion(t *testing.T) {
 func TestBinary_GetTargetBinName(t *testing.T) {
 	cases := map[string]struct {
 		bin      model.Binary
+		format   model.PinFormat
 		kind     model.Kind
 		expected string
 	}{
 		"latest": {
 			bin:      model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ""),
+			format:   model.NewDefaultPinFormat(),
 			kind:     model.KindLatest,
 			expected: "mockproj",
 		},
 		"latest-with-extension": {
 			bin:      model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ".exe"),
+			format:   model.NewDefaultPinFormat(),
 			kind:     model.KindLatest,
 			expected: "mockproj.exe",
 		},
 		"major": {
 			bin:      model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ""),
+			format:   model.NewDefaultPinFormat(),
 			kind:     model.KindMajor,
 			expected: "mockproj-v1",
 		},
 		"major-with-extension": {
 			bin:      model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ".exe"),
+			format:   model.NewDefaultPinFormat(),
 			kind:     model.KindMajor,
 			expected: "mockproj-v1.exe",
 		},
 		"minor": {
 			bin:      model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ""),
+			format:   model.NewDefaultPinFormat(),
 			kind:     model.KindMinor,
 			expected: "mockproj-v1.2",
 		},
 		"minor-with-extension": {
 			bin:      model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ".exe"),
+			format:   model.NewDefaultPinFormat(),
 			kind:     model.KindMinor,
 			expected: "mockproj-v1.2.exe",
 		},
+		"major-with-extension-before-version": {
+			bin:      model.NewBinaryFromString("mockproj.exe@v1.2.3"),
+			format:   model.NewDefaultPinFormat(),
+			kind:     model.KindMajor,
+			expected: "mockproj-v1.exe",
+		},
+		"minor-with-extension-custom-separator": {
+			bin:      model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ".exe"),
+			kind:     model.KindMinor,
+			format:   model.NewPinFormat("_", model.PinPlacementSuffix),
+			expected: "mockproj_v1.2.exe",
+		},
+		"minor-with-extension-prefix": {
+			bin:      model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ".exe"),
+			kind:     model.KindMinor,
+			format:   model.NewPinFormat("_", model.PinPlacementPrefix),
+			expected: "v1.2_mockproj.exe",
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			result := tc.bin.GetTargetBinName(tc.kind)
+			result := tc.bin.GetTargetBinName(tc.kind, tc.format)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -311,3 +474,39 @@ func TestBinary_String(t *testing.T) {
 		})
 	}
 }
+
+func TestBinary_WithOSExtension(t *testing.T) {
+	cases := map[string]struct {
+		bin      model.Binary
+		goos     string
+		expected model.Binary
+	}{
+		"windows-without-extension": {
+			bin:      model.NewBinaryFromString("mockproj@v1.2.3"),
+			goos:     "windows",
+			expected: model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ".exe"),
+		},
+		"windows-with-extension": {
+			bin:      model.NewBinaryFromString("mockproj@v1.2.3.exe"),
+			goos:     "windows",
+			expected: model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ".exe"),
+		},
+		"linux-without-extension": {
+			bin:      model.NewBinaryFromString("mockproj@v1.2.3"),
+			goos:     "linux",
+			expected: model.NewBinary("mockproj", model.NewVersion("v1.2.3"), ""),
+		},
+		"linux-with-extension": {
+			bin:      model.NewBinaryFromString("mockproj.exe"),
+			goos:     "linux",
+			expected: model.NewBinary("mockproj", model.NewLatestVersion(), ".exe"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.bin.WithOSExtension(tc.goos)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
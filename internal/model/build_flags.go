@@ -0,0 +1,223 @@
+package model
+
+import (
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+// stripLDFlags are the linker flags omitting the symbol table and the DWARF
+// debug information from a binary.
+const stripLDFlags = "-s -w"
+
+// BuildFlags represents the build flags a binary was built with, as recorded
+// in its build info, to rebuild it with equivalent flags. Provenance records
+// the provenance of the binary in its build info, see WithProvenance. Compress
+// compresses the binary with UPX once built, and is not recorded.
+type BuildFlags struct {
+	Tags       string `json:"tags,omitempty"`
+	LDFlags    string `json:"ldflags,omitempty"`
+	CGOEnabled string `json:"cgo_enabled,omitempty"`
+	GOARM      string `json:"goarm,omitempty"`
+	GOAMD64    string `json:"goamd64,omitempty"`
+	GOARM64    string `json:"goarm64,omitempty"`
+	Strip      bool   `json:"strip,omitempty"`
+	DebugInfo  bool   `json:"debug_info,omitempty"`
+	Provenance bool   `json:"provenance,omitempty"`
+	Compress   bool   `json:"-"`
+
+	provenance Provenance
+}
+
+// NewBuildFlags creates the build flags from the given build info settings.
+func NewBuildFlags(settings []debug.BuildSetting) BuildFlags {
+	var flags BuildFlags
+	for _, s := range settings {
+		switch s.Key {
+		case "-tags":
+			flags.Tags = s.Value
+		case "-ldflags":
+			flags.provenance, flags.LDFlags = parseProvenance(s.Value)
+			flags.Provenance = flags.provenance != (Provenance{})
+		case "CGO_ENABLED":
+			flags.CGOEnabled = s.Value
+		case "GOARM":
+			flags.GOARM = s.Value
+		case "GOAMD64":
+			flags.GOAMD64 = s.Value
+		case "GOARM64":
+			flags.GOARM64 = s.Value
+		}
+	}
+
+	return flags
+}
+
+// Args returns the go install arguments for the build flags. The strip flag
+// maps to the -s -w linker flags, and the provenance to -X linker flags
+// appended last.
+func (f BuildFlags) Args() []string {
+	var args []string
+	if f.Tags != "" {
+		args = append(args, "-tags="+f.Tags)
+	}
+	if ldflags := f.getLDFlags(); ldflags != "" {
+		args = append(args, "-ldflags="+ldflags)
+	}
+
+	return args
+}
+
+// Env returns the environment variables for the build flags.
+func (f BuildFlags) Env() []string {
+	var env []string
+	if f.CGOEnabled != "" {
+		env = append(env, "CGO_ENABLED="+f.CGOEnabled)
+	}
+	if f.GOARM != "" {
+		env = append(env, "GOARM="+f.GOARM)
+	}
+	if f.GOAMD64 != "" {
+		env = append(env, "GOAMD64="+f.GOAMD64)
+	}
+	if f.GOARM64 != "" {
+		env = append(env, "GOARM64="+f.GOARM64)
+	}
+
+	return env
+}
+
+// Merge returns the build flags overridden by the non-empty flags of other.
+func (f BuildFlags) Merge(other BuildFlags) BuildFlags {
+	if other.Tags != "" {
+		f.Tags = other.Tags
+	}
+	if other.LDFlags != "" {
+		f.LDFlags = other.LDFlags
+	}
+	if other.CGOEnabled != "" {
+		f.CGOEnabled = other.CGOEnabled
+	}
+	if other.GOARM != "" {
+		f.GOARM = other.GOARM
+	}
+	if other.GOAMD64 != "" {
+		f.GOAMD64 = other.GOAMD64
+	}
+	if other.GOARM64 != "" {
+		f.GOARM64 = other.GOARM64
+	}
+	if other.Strip {
+		f.Strip = true
+	}
+	if other.DebugInfo {
+		f.DebugInfo = true
+	}
+	if other.Provenance {
+		f.Provenance = true
+	}
+	if other.Compress {
+		f.Compress = true
+	}
+	if other.provenance != (Provenance{}) {
+		f.provenance = other.provenance
+	}
+
+	return f
+}
+
+// GetProvenance returns the provenance recorded in the build info of the
+// binary, or a zero provenance if not built by gobin with provenance.
+func (f BuildFlags) GetProvenance() Provenance {
+	return f.provenance
+}
+
+// IsStripped returns whether the binary is built without its symbol table,
+// either with the strip flag or with the -s linker flag.
+func (f BuildFlags) IsStripped() bool {
+	return f.Strip || slices.Contains(strings.Fields(f.LDFlags), "-s")
+}
+
+// KeepsDebugInfo returns whether the debug information of the binary is kept
+// apart when stripped.
+func (f BuildFlags) KeepsDebugInfo() bool {
+	return f.DebugInfo && f.IsStripped()
+}
+
+// Uncompressed returns the build flags without the compress flag, as recorded
+// in the receipts and cache indexes, since compression applies once built.
+func (f BuildFlags) Uncompressed() BuildFlags {
+	f.Compress = false
+	return f
+}
+
+// Unstripped returns the build flags without the strip flag and the strip
+// linker flags, to build the binary with its symbol table and debug
+// information.
+func (f BuildFlags) Unstripped() BuildFlags {
+	ldflags := strings.Fields(f.LDFlags)
+	ldflags = slices.DeleteFunc(ldflags, func(flag string) bool {
+		return flag == "-s" || flag == "-w"
+	})
+
+	f.LDFlags = strings.Join(ldflags, " ")
+	f.Strip = false
+	f.DebugInfo = false
+
+	return f
+}
+
+// WithEnv returns the build flags with the given build environment variables,
+// in the form "name=value", ex. CGO_ENABLED=1. Only the CGO_ENABLED, GOARM,
+// GOAMD64 and GOARM64 variables are supported, since they are the ones recorded
+// in the build info. It returns an error if a variable is not valid or not
+// supported.
+func (f BuildFlags) WithEnv(envVars ...string) (BuildFlags, error) {
+	for _, envVar := range envVars {
+		name, value, err := ParseEnvVar(envVar)
+		if err != nil {
+			return BuildFlags{}, err
+		}
+
+		switch name {
+		case "CGO_ENABLED":
+			f.CGOEnabled = value
+		case "GOARM":
+			f.GOARM = value
+		case "GOAMD64":
+			f.GOAMD64 = value
+		case "GOARM64":
+			f.GOARM64 = value
+		default:
+			return BuildFlags{}, fmt.Errorf(
+				"unsupported build environment variable %q, expected CGO_ENABLED, GOARM, GOAMD64 or GOARM64", name,
+			)
+		}
+	}
+
+	return f, nil
+}
+
+// WithProvenance returns the build flags recording the given provenance in the
+// build info of the binary, if the provenance flag is set.
+func (f BuildFlags) WithProvenance(provenance Provenance) BuildFlags {
+	f.provenance = provenance
+	return f
+}
+
+// getLDFlags returns the linker flags, with the strip linker flags appended if
+// the strip flag is set and the linker flags do not strip the binary already,
+// and the provenance linker flags appended if the provenance flag is set.
+func (f BuildFlags) getLDFlags() string {
+	ldflags := f.LDFlags
+	if f.Strip && !slices.Contains(strings.Fields(ldflags), "-s") {
+		ldflags = strings.TrimSpace(ldflags + " " + stripLDFlags)
+	}
+
+	if f.Provenance && f.provenance != (Provenance{}) {
+		ldflags = strings.TrimSpace(ldflags + " " + f.provenance.LDFlags())
+	}
+
+	return ldflags
+}
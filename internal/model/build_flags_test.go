@@ -0,0 +1,330 @@
+package model_test
+
+import (
+	"errors"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestNewBuildFlags(t *testing.T) {
+	cases := map[string]struct {
+		settings []debug.BuildSetting
+		expected model.BuildFlags
+	}{
+		"no-settings": {
+			expected: model.BuildFlags{},
+		},
+		"all-flags": {
+			settings: []debug.BuildSetting{
+				{Key: "-buildmode", Value: "exe"},
+				{Key: "-ldflags", Value: "-s -w -X main.version=v1.0.0"},
+				{Key: "-tags", Value: "netgo,osusergo"},
+				{Key: "CGO_ENABLED", Value: "0"},
+				{Key: "GOOS", Value: "linux"},
+			},
+			expected: model.BuildFlags{
+				Tags:       "netgo,osusergo",
+				LDFlags:    "-s -w -X main.version=v1.0.0",
+				CGOEnabled: "0",
+			},
+		},
+		"arch-variants": {
+			settings: []debug.BuildSetting{
+				{Key: "GOARCH", Value: "amd64"},
+				{Key: "GOAMD64", Value: "v3"},
+				{Key: "GOARM", Value: "7"},
+				{Key: "GOARM64", Value: "v8.2"},
+			},
+			expected: model.BuildFlags{
+				GOARM:   "7",
+				GOAMD64: "v3",
+				GOARM64: "v8.2",
+			},
+		},
+		"provenance": {
+			settings: []debug.BuildSetting{
+				{
+					Key: "-ldflags",
+					Value: "-s -w -X=dev.gobin.provenance.builder=gobin@v1.0.0 " +
+						"-X=dev.gobin.provenance.spec=example.com/mockorg/mockproj/cmd/mockproj@latest " +
+						"-X=dev.gobin.provenance.source=install",
+				},
+			},
+			expected: model.BuildFlags{LDFlags: "-s -w", Provenance: true}.WithProvenance(model.Provenance{
+				Builder: "gobin@v1.0.0",
+				Spec:    "example.com/mockorg/mockproj/cmd/mockproj@latest",
+				Source:  "install",
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, model.NewBuildFlags(tc.settings))
+		})
+	}
+}
+
+func TestBuildFlags_Args(t *testing.T) {
+	cases := map[string]struct {
+		flags    model.BuildFlags
+		expected []string
+	}{
+		"no-flags": {
+			flags: model.BuildFlags{CGOEnabled: "1"},
+		},
+		"tags-and-ldflags": {
+			flags: model.BuildFlags{Tags: "netgo", LDFlags: "-s -w"},
+			expected: []string{
+				"-tags=netgo",
+				"-ldflags=-s -w",
+			},
+		},
+		"strip": {
+			flags:    model.BuildFlags{Strip: true},
+			expected: []string{"-ldflags=-s -w"},
+		},
+		"strip-with-ldflags": {
+			flags:    model.BuildFlags{LDFlags: "-X main.version=v1.0.0", Strip: true},
+			expected: []string{"-ldflags=-X main.version=v1.0.0 -s -w"},
+		},
+		"strip-already-stripped": {
+			flags:    model.BuildFlags{LDFlags: "-s -w -X main.version=v1.0.0", Strip: true},
+			expected: []string{"-ldflags=-s -w -X main.version=v1.0.0"},
+		},
+		"provenance": {
+			flags: model.BuildFlags{LDFlags: "-X main.version=v1.0.0", Strip: true, Provenance: true}.
+				WithProvenance(model.Provenance{Builder: "gobin@v1.0.0", Source: "upgrade"}),
+			expected: []string{
+				"-ldflags=-X main.version=v1.0.0 -s -w -X=dev.gobin.provenance.builder=gobin@v1.0.0 " +
+					"-X=dev.gobin.provenance.source=upgrade",
+			},
+		},
+		"provenance-disabled": {
+			flags: model.BuildFlags{}.WithProvenance(model.Provenance{Builder: "gobin@v1.0.0", Source: "upgrade"}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.flags.Args())
+		})
+	}
+}
+
+func TestBuildFlags_Env(t *testing.T) {
+	cases := map[string]struct {
+		flags    model.BuildFlags
+		expected []string
+	}{
+		"no-cgo": {
+			flags: model.BuildFlags{Tags: "netgo"},
+		},
+		"cgo-disabled": {
+			flags:    model.BuildFlags{CGOEnabled: "0"},
+			expected: []string{"CGO_ENABLED=0"},
+		},
+		"arch-variants": {
+			flags:    model.BuildFlags{CGOEnabled: "1", GOARM: "6", GOAMD64: "v2", GOARM64: "v9.0"},
+			expected: []string{"CGO_ENABLED=1", "GOARM=6", "GOAMD64=v2", "GOARM64=v9.0"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.flags.Env())
+		})
+	}
+}
+
+func TestBuildFlags_Merge(t *testing.T) {
+	cases := map[string]struct {
+		flags    model.BuildFlags
+		other    model.BuildFlags
+		expected model.BuildFlags
+	}{
+		"empty-other": {
+			flags:    model.BuildFlags{Tags: "netgo", GOAMD64: "v2"},
+			expected: model.BuildFlags{Tags: "netgo", GOAMD64: "v2"},
+		},
+		"override-variant": {
+			flags:    model.BuildFlags{Tags: "netgo", CGOEnabled: "0", GOAMD64: "v2"},
+			other:    model.BuildFlags{GOAMD64: "v3"},
+			expected: model.BuildFlags{Tags: "netgo", CGOEnabled: "0", GOAMD64: "v3"},
+		},
+		"override-all": {
+			flags: model.BuildFlags{Tags: "netgo", LDFlags: "-s", CGOEnabled: "0"},
+			other: model.BuildFlags{
+				Tags:       "osusergo",
+				LDFlags:    "-w",
+				CGOEnabled: "1",
+				GOARM:      "7",
+				GOAMD64:    "v4",
+				GOARM64:    "v8.1",
+			},
+			expected: model.BuildFlags{
+				Tags:       "osusergo",
+				LDFlags:    "-w",
+				CGOEnabled: "1",
+				GOARM:      "7",
+				GOAMD64:    "v4",
+				GOARM64:    "v8.1",
+			},
+		},
+		"keep-strip": {
+			flags:    model.BuildFlags{Strip: true},
+			other:    model.BuildFlags{GOAMD64: "v3"},
+			expected: model.BuildFlags{GOAMD64: "v3", Strip: true},
+		},
+		"override-strip": {
+			flags:    model.BuildFlags{GOAMD64: "v3"},
+			other:    model.BuildFlags{Strip: true, DebugInfo: true},
+			expected: model.BuildFlags{GOAMD64: "v3", Strip: true, DebugInfo: true},
+		},
+		"keep-provenance": {
+			flags: model.BuildFlags{Provenance: true}.WithProvenance(model.Provenance{Source: "install"}),
+			other: model.BuildFlags{GOAMD64: "v3"},
+			expected: model.BuildFlags{GOAMD64: "v3", Provenance: true}.
+				WithProvenance(model.Provenance{Source: "install"}),
+		},
+		"override-compress": {
+			flags:    model.BuildFlags{Tags: "netgo"},
+			other:    model.BuildFlags{Compress: true},
+			expected: model.BuildFlags{Tags: "netgo", Compress: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.flags.Merge(tc.other))
+		})
+	}
+}
+
+func TestBuildFlags_IsStripped(t *testing.T) {
+	cases := map[string]struct {
+		flags    model.BuildFlags
+		expected bool
+	}{
+		"not-stripped": {
+			flags: model.BuildFlags{LDFlags: "-X main.version=v1.0.0"},
+		},
+		"strip-flag": {
+			flags:    model.BuildFlags{Strip: true},
+			expected: true,
+		},
+		"strip-ldflags": {
+			flags:    model.BuildFlags{LDFlags: "-s -w -X main.version=v1.0.0"},
+			expected: true,
+		},
+		"dwarf-only-ldflags": {
+			flags: model.BuildFlags{LDFlags: "-w"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.flags.IsStripped())
+		})
+	}
+}
+
+func TestBuildFlags_KeepsDebugInfo(t *testing.T) {
+	cases := map[string]struct {
+		flags    model.BuildFlags
+		expected bool
+	}{
+		"not-stripped": {
+			flags: model.BuildFlags{DebugInfo: true},
+		},
+		"stripped-without-debug-info": {
+			flags: model.BuildFlags{Strip: true},
+		},
+		"strip-flag": {
+			flags:    model.BuildFlags{Strip: true, DebugInfo: true},
+			expected: true,
+		},
+		"strip-ldflags": {
+			flags:    model.BuildFlags{LDFlags: "-s -w", DebugInfo: true},
+			expected: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.flags.KeepsDebugInfo())
+		})
+	}
+}
+
+func TestBuildFlags_Uncompressed(t *testing.T) {
+	flags := model.BuildFlags{Tags: "netgo", Strip: true, Compress: true}
+	assert.Equal(t, model.BuildFlags{Tags: "netgo", Strip: true}, flags.Uncompressed())
+}
+
+func TestBuildFlags_Unstripped(t *testing.T) {
+	cases := map[string]struct {
+		flags    model.BuildFlags
+		expected model.BuildFlags
+	}{
+		"strip-flag": {
+			flags:    model.BuildFlags{Tags: "netgo", Strip: true, DebugInfo: true},
+			expected: model.BuildFlags{Tags: "netgo"},
+		},
+		"strip-ldflags": {
+			flags:    model.BuildFlags{LDFlags: "-s -w -X main.version=v1.0.0", GOAMD64: "v3", DebugInfo: true},
+			expected: model.BuildFlags{LDFlags: "-X main.version=v1.0.0", GOAMD64: "v3"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.flags.Unstripped())
+		})
+	}
+}
+
+func TestBuildFlags_WithEnv(t *testing.T) {
+	cases := map[string]struct {
+		flags       model.BuildFlags
+		envVars     []string
+		expected    model.BuildFlags
+		expectedErr error
+	}{
+		"success": {
+			flags:    model.BuildFlags{Tags: "netgo", CGOEnabled: "0"},
+			envVars:  []string{"CGO_ENABLED=1", "GOAMD64=v3"},
+			expected: model.BuildFlags{Tags: "netgo", CGOEnabled: "1", GOAMD64: "v3"},
+		},
+		"success-no-env-vars": {
+			flags:    model.BuildFlags{Tags: "netgo"},
+			expected: model.BuildFlags{Tags: "netgo"},
+		},
+		"error-invalid-env-var": {
+			envVars:     []string{"CGO_ENABLED"},
+			expectedErr: errors.New(`invalid environment variable "CGO_ENABLED", expected KEY=VALUE`),
+		},
+		"error-unsupported-env-var": {
+			envVars: []string{"GOOS=linux"},
+			expectedErr: errors.New(
+				`unsupported build environment variable "GOOS", expected CGO_ENABLED, GOARM, GOAMD64 or GOARM64`,
+			),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			flags, err := tc.flags.WithEnv(tc.envVars...)
+			assert.Equal(t, tc.expected, flags)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a size in bytes. It implements the [flag.Value] interface,
+// parsing sizes with decimal (KB, MB, GB) or binary (KiB, MiB, GiB) units, ex.
+// "500MB" or "1.5GiB". A size without unit is in bytes.
+type ByteSize int64
+
+// byteSizeUnits maps the lowercase units to their multiplier.
+//
+//nolint:gochecknoglobals // global variable to define allowed units
+var byteSizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+}
+
+// String returns the string representation of the size with the largest
+// decimal unit not exceeding it, ex. "1.5 MB".
+func (s *ByteSize) String() string {
+	size := float64(*s)
+	for _, unit := range []string{"GB", "MB", "kB"} {
+		multiplier := byteSizeUnits[strings.ToLower(unit)]
+		if size >= multiplier {
+			return strconv.FormatFloat(size/multiplier, 'f', 1, 64) + " " + unit
+		}
+	}
+
+	return strconv.FormatInt(int64(*s), 10) + " B"
+}
+
+// Set sets the size from a string.
+func (s *ByteSize) Set(value string) error {
+	trimmed := strings.TrimSpace(value)
+	idx := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if idx < 0 {
+		idx = len(trimmed)
+	}
+
+	number, err := strconv.ParseFloat(trimmed[:idx], 64)
+	multiplier, ok := byteSizeUnits[strings.ToLower(strings.TrimSpace(trimmed[idx:]))]
+	if err != nil || !ok {
+		return fmt.Errorf("invalid size %q, ex. 500MB or 1.5GiB", value)
+	}
+
+	*s = ByteSize(number * multiplier)
+	return nil
+}
+
+// Type returns the type of the size.
+func (s *ByteSize) Type() string {
+	return "size"
+}
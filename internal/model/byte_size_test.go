@@ -0,0 +1,87 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestByteSize_String(t *testing.T) {
+	cases := map[string]struct {
+		size     model.ByteSize
+		expected string
+	}{
+		"bytes": {
+			size:     512,
+			expected: "512 B",
+		},
+		"kilobytes": {
+			size:     1500,
+			expected: "1.5 kB",
+		},
+		"megabytes": {
+			size:     12_300_000,
+			expected: "12.3 MB",
+		},
+		"gigabytes": {
+			size:     2_000_000_000,
+			expected: "2.0 GB",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.size.String())
+		})
+	}
+}
+
+func TestByteSize_Set(t *testing.T) {
+	cases := map[string]struct {
+		value       string
+		expected    model.ByteSize
+		expectedErr error
+	}{
+		"bytes": {
+			value:    "512",
+			expected: 512,
+		},
+		"decimal-unit": {
+			value:    "500MB",
+			expected: 500_000_000,
+		},
+		"decimal-unit-shorthand": {
+			value:    "2g",
+			expected: 2_000_000_000,
+		},
+		"binary-unit-fraction": {
+			value:    "1.5 KiB",
+			expected: 1536,
+		},
+		"invalid-unit": {
+			value:       "10XB",
+			expectedErr: errors.New(`invalid size "10XB", ex. 500MB or 1.5GiB`),
+		},
+		"invalid-number": {
+			value:       "MB",
+			expectedErr: errors.New(`invalid size "MB", ex. 500MB or 1.5GiB`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var size model.ByteSize
+			err := size.Set(tc.value)
+			assert.Equal(t, tc.expected, size)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestByteSize_Type(t *testing.T) {
+	var size model.ByteSize
+	assert.Equal(t, "size", size.Type())
+}
@@ -0,0 +1,33 @@
+package model
+
+// CacheArtifact represents a binary served by a binary cache server, keyed by
+// its module version and platform, with the build flags recorded in its
+// receipt and the sha256 digest of its content, set in the index.
+type CacheArtifact struct {
+	Name       string     `json:"name"`
+	Package    string     `json:"package"`
+	Module     string     `json:"module"`
+	Version    string     `json:"version"`
+	ModuleSum  string     `json:"module_sum"`
+	GoVersion  string     `json:"go_version"`
+	Platform   string     `json:"platform"`
+	BuildFlags BuildFlags `json:"build_flags"`
+	Digest     string     `json:"digest,omitempty"`
+
+	Path string `json:"-"`
+}
+
+// Matches checks if the artifact is the binary of the given package, resolved
+// to an exact version, built for the given platform with the given build flags,
+// ignoring the compress flag, as the binaries are compressed once downloaded.
+func (a CacheArtifact) Matches(pkg Package, platform string, flags BuildFlags) bool {
+	return a.Package == pkg.Path &&
+		a.Version == pkg.Version.String() &&
+		a.Platform == platform &&
+		a.BuildFlags.Uncompressed() == flags.Uncompressed()
+}
+
+// String returns the key of the artifact, in the form module@version/platform.
+func (a CacheArtifact) String() string {
+	return a.Module + "@" + a.Version + "/" + a.Platform
+}
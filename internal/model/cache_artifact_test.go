@@ -0,0 +1,75 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestCacheArtifact_Matches(t *testing.T) {
+	artifact := model.CacheArtifact{
+		Name:       "dlv@v1.25.1",
+		Package:    "github.com/go-delve/delve/cmd/dlv",
+		Module:     "github.com/go-delve/delve",
+		Version:    "v1.25.1",
+		Platform:   "linux/amd64",
+		BuildFlags: model.BuildFlags{Strip: true},
+	}
+
+	cases := map[string]struct {
+		pkg      model.Package
+		platform string
+		flags    model.BuildFlags
+		expected bool
+	}{
+		"match": {
+			pkg:      model.NewPackageWithVersion("github.com/go-delve/delve/cmd/dlv", model.NewVersion("v1.25.1")),
+			platform: "linux/amd64",
+			flags:    model.BuildFlags{Strip: true},
+			expected: true,
+		},
+		"match-compress": {
+			pkg:      model.NewPackageWithVersion("github.com/go-delve/delve/cmd/dlv", model.NewVersion("v1.25.1")),
+			platform: "linux/amd64",
+			flags:    model.BuildFlags{Strip: true, Compress: true},
+			expected: true,
+		},
+		"other-package": {
+			pkg:      model.NewPackageWithVersion("github.com/go-delve/delve/cmd/other", model.NewVersion("v1.25.1")),
+			platform: "linux/amd64",
+			flags:    model.BuildFlags{Strip: true},
+		},
+		"other-version": {
+			pkg:      model.NewPackageWithVersion("github.com/go-delve/delve/cmd/dlv", model.NewVersion("v1.25.0")),
+			platform: "linux/amd64",
+			flags:    model.BuildFlags{Strip: true},
+		},
+		"other-platform": {
+			pkg:      model.NewPackageWithVersion("github.com/go-delve/delve/cmd/dlv", model.NewVersion("v1.25.1")),
+			platform: "darwin/arm64",
+			flags:    model.BuildFlags{Strip: true},
+		},
+		"other-build-flags": {
+			pkg:      model.NewPackageWithVersion("github.com/go-delve/delve/cmd/dlv", model.NewVersion("v1.25.1")),
+			platform: "linux/amd64",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, artifact.Matches(tc.pkg, tc.platform, tc.flags))
+		})
+	}
+}
+
+func TestCacheArtifact_String(t *testing.T) {
+	artifact := model.CacheArtifact{
+		Module:   "github.com/go-delve/delve",
+		Version:  "v1.25.1",
+		Platform: "linux/amd64",
+	}
+
+	assert.Equal(t, "github.com/go-delve/delve@v1.25.1/linux/amd64", artifact.String())
+}
@@ -0,0 +1,482 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config represents the gobin configuration. Cache configures the module
+// lookups cache. Defaults sets the default values of the command flags. Deny is
+// a list of package paths or glob patterns, ex. "github.com/mockorg/*", of
+// packages refused to be installed. A pattern denies the packages matching it,
+// and the packages under the paths matching it. Network configures the module
+// proxy requests. PinMode configures how binaries are pinned to the Go binary
+// directory. Retention configures the versions kept in the internal binary
+// directory when pruning by policy. DisableReleaseCheck disables the daily
+// check for a new gobin release. Signing configures the signing of the newly
+// built binaries. StorePath is the absolute path of the internal binary
+// directory, in the internal base directory if empty. Telemetry configures the
+// opt-in anonymous usage metrics. UPX compresses the newly built binaries with
+// UPX, if available.
+type Config struct {
+	Cache               CacheConfig     `yaml:"cache,omitempty"`
+	Defaults            DefaultsConfig  `yaml:"defaults,omitempty"`
+	Deny                []string        `yaml:"deny,omitempty"`
+	DisableReleaseCheck bool            `yaml:"disable_release_check,omitempty"`
+	Network             NetworkConfig   `yaml:"network,omitempty"`
+	PinMode             PinMode         `yaml:"pin_mode,omitempty"`
+	Retention           RetentionConfig `yaml:"retention,omitempty"`
+	Signing             SigningConfig   `yaml:"signing,omitempty"`
+	StorePath           string          `yaml:"store_path,omitempty"`
+	Telemetry           TelemetryConfig `yaml:"telemetry,omitempty"`
+	UPX                 bool            `yaml:"upx,omitempty"`
+}
+
+// PinMode is the way binaries are pinned to the Go binary directory.
+type PinMode string
+
+const (
+	// PinModeSymlink pins binaries with symlinks to the internal binaries, the
+	// default.
+	PinModeSymlink PinMode = "symlink"
+	// PinModeCopy pins binaries with copies of the internal binaries, for file
+	// systems without symlinks, ex. FAT or some container volumes. The internal
+	// binary targeted by each pin is recorded in its receipt.
+	PinModeCopy PinMode = "copy"
+)
+
+// ConfigKeys is the list of keys of the configuration file, with the nested
+// keys separated by dots.
+//
+//nolint:gochecknoglobals // global variable to define config keys
+var ConfigKeys = []string{
+	"cache.ttl",
+	"defaults.exclude",
+	"defaults.goflags",
+	"defaults.kind",
+	"defaults.output",
+	"defaults.parallelism",
+	"deny",
+	"disable_release_check",
+	"network.backoff",
+	"network.concurrency",
+	"network.retries",
+	"network.timeout",
+	"pin_mode",
+	"retention.auto_prune",
+	"retention.keep_days",
+	"retention.keep_last",
+	"signing.darwin",
+	"signing.linux",
+	"signing.windows",
+	"store_path",
+	"telemetry.enabled",
+	"telemetry.endpoint",
+	"upx",
+}
+
+// CacheConfig represents the configuration of the module lookups cache. TTL is
+// the time a cached module lookup is used for, an hour if zero.
+type CacheConfig struct {
+	TTL time.Duration `yaml:"ttl,omitempty"`
+}
+
+// OutputJSON is the output format of the defaults printing the output of the
+// commands as JSON, as with --json.
+const OutputJSON = "json"
+
+// DefaultsConfig represents the default values of the command flags, the flags
+// given in the command line overriding them. Parallelism is the default number
+// of concurrent operations, the number of CPU cores if zero. Kind is the
+// default pin kind of install, pin and pull. Output is the output format, text
+// or json. GoFlags is the GOFLAGS of the go commands, if not set in the
+// environment. Exclude is a list of binary names or glob patterns, ex.
+// "golangci-lint*", of binaries skipped when upgrading all binaries and
+// listing the outdated ones.
+type DefaultsConfig struct {
+	Exclude     []string `yaml:"exclude,omitempty"`
+	GoFlags     string   `yaml:"goflags,omitempty"`
+	Kind        Kind     `yaml:"kind,omitempty"`
+	Output      string   `yaml:"output,omitempty"`
+	Parallelism int      `yaml:"parallelism,omitempty"`
+}
+
+// IsExcluded checks if the binary with the given name, as pinned in the Go
+// binary directory, matches an entry of the exclude list.
+func (c DefaultsConfig) IsExcluded(name string) bool {
+	return slices.ContainsFunc(c.Exclude, func(pattern string) bool {
+		ok, err := path.Match(pattern, name)
+		return err == nil && ok
+	})
+}
+
+// NetworkConfig represents the configuration of the module proxy requests.
+// Retries is the number of times a failed request is retried, waiting Backoff
+// before the first retry and doubling it on each subsequent one. Timeout limits
+// each request, with no limit if zero. Concurrency limits the number of go
+// commands requesting the network at once, with no limit if zero.
+type NetworkConfig struct {
+	Retries     int           `yaml:"retries,omitempty"`
+	Backoff     time.Duration `yaml:"backoff,omitempty"`
+	Timeout     time.Duration `yaml:"timeout,omitempty"`
+	Concurrency int           `yaml:"concurrency,omitempty"`
+}
+
+// RetentionConfig represents the retention policy of the versions of the
+// binaries in the internal binary directory. A version is kept if it is one of
+// the KeepLast latest versions of its binary, or if it was installed in the
+// last KeepDays days, the versions targeted by a pin being always kept. The
+// policy is not set if both are zero. AutoPrune prunes the versions not kept
+// after each successful upgrade.
+type RetentionConfig struct {
+	KeepLast  int  `yaml:"keep_last,omitempty"`
+	KeepDays  int  `yaml:"keep_days,omitempty"`
+	AutoPrune bool `yaml:"auto_prune,omitempty"`
+}
+
+// IsSet checks if the retention policy is set, ie. keeping the latest versions
+// or the recent ones.
+func (c RetentionConfig) IsSet() bool {
+	return c.KeepLast > 0 || c.KeepDays > 0
+}
+
+// IsRetained checks if a version of a binary is kept by the retention policy,
+// given its rank among the versions of the binary, from the latest one at rank
+// zero, and the time it was installed. All versions are kept if the policy is
+// not set.
+func (c RetentionConfig) IsRetained(rank int, installedAt, now time.Time) bool {
+	if !c.IsSet() {
+		return true
+	}
+
+	if c.KeepLast > 0 && rank < c.KeepLast {
+		return true
+	}
+
+	//nolint:mnd // hours per day
+	return c.KeepDays > 0 && now.Sub(installedAt) < time.Duration(c.KeepDays)*24*time.Hour
+}
+
+// SigningConfig represents the configuration of the signing of the newly built
+// binaries, with the identity used on each operating system. Darwin is the
+// codesign identity, Windows is the subject name of the signtool certificate and
+// Linux is the GPG key of the detached signatures. Binaries are not signed on
+// the operating systems without an identity.
+type SigningConfig struct {
+	Darwin  string `yaml:"darwin,omitempty"`
+	Linux   string `yaml:"linux,omitempty"`
+	Windows string `yaml:"windows,omitempty"`
+}
+
+// GetIdentity returns the signing identity of the given operating system, or an
+// empty string if the binaries are not signed on it.
+func (c SigningConfig) GetIdentity(goos string) string {
+	switch goos {
+	case "darwin":
+		return c.Darwin
+	case "linux":
+		return c.Linux
+	case "windows":
+		return c.Windows
+	default:
+		return ""
+	}
+}
+
+// TelemetryConfig represents the configuration of the anonymous usage metrics,
+// recording the commands run and the category of their failures. They are
+// disabled unless Enabled is set. Endpoint is the http or https URL the metrics
+// are posted to, or the path of a local file they are appended to, with a file
+// in the internal base directory if empty.
+type TelemetryConfig struct {
+	Enabled  bool   `yaml:"enabled,omitempty"`
+	Endpoint string `yaml:"endpoint,omitempty"`
+}
+
+// NewConfig creates a new empty configuration.
+func NewConfig() Config {
+	return Config{}
+}
+
+// ParseConfig parses the configuration from the given YAML data. It returns an
+// error if the data is not valid YAML, the network, retention, cache or
+// parallelism settings are negative, the pin mode, default kind or output
+// format is unknown, the store path is not absolute or the telemetry endpoint
+// is a URL other than http or https.
+func ParseConfig(data []byte) (Config, error) {
+	config := NewConfig()
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Config{}, err
+	}
+
+	if config.Network.Retries < 0 || config.Network.Backoff < 0 || config.Network.Timeout < 0 ||
+		config.Network.Concurrency < 0 {
+		return Config{}, errors.New("network retries, backoff, timeout and concurrency must not be negative")
+	}
+
+	if config.Retention.KeepLast < 0 || config.Retention.KeepDays < 0 {
+		return Config{}, errors.New("retention keep_last and keep_days must not be negative")
+	}
+
+	if config.Cache.TTL < 0 || config.Defaults.Parallelism < 0 {
+		return Config{}, errors.New("cache ttl and defaults parallelism must not be negative")
+	}
+
+	if config.Defaults.Kind != "" && !config.Defaults.Kind.IsValid() {
+		return Config{}, fmt.Errorf("invalid default kind %q, allowed values are: %v", config.Defaults.Kind,
+			allowedKinds)
+	}
+
+	if config.Defaults.Output != "" && config.Defaults.Output != "text" && config.Defaults.Output != OutputJSON {
+		return Config{}, fmt.Errorf("invalid default output %q, allowed values are: %v", config.Defaults.Output,
+			[]string{"text", OutputJSON})
+	}
+
+	if config.StorePath != "" && !filepath.IsAbs(config.StorePath) {
+		return Config{}, fmt.Errorf("store path %q must be an absolute path", config.StorePath)
+	}
+
+	if config.PinMode != "" && config.PinMode != PinModeSymlink && config.PinMode != PinModeCopy {
+		return Config{}, fmt.Errorf("invalid pin mode %q, allowed values are: %v", config.PinMode,
+			[]PinMode{PinModeSymlink, PinModeCopy})
+	}
+
+	if scheme, _, ok := strings.Cut(config.Telemetry.Endpoint, "://"); ok && scheme != "http" && scheme != "https" {
+		return Config{}, fmt.Errorf("invalid telemetry endpoint %q, must be an http or https URL or a file path",
+			config.Telemetry.Endpoint)
+	}
+
+	return config, nil
+}
+
+// GetDenyRule returns the deny list entry matching the given package, checking
+// the package path and each of its parent paths. It returns false if the
+// package is not denied.
+func (c Config) GetDenyRule(pkg Package) (string, bool) {
+	for _, rule := range c.Deny {
+		rule = normalizePath(rule)
+		if rule == "" {
+			continue
+		}
+
+		for p := pkg.Path; p != "" && p != "."; p = path.Dir(p) {
+			if ok, err := path.Match(rule, p); err == nil && ok {
+				return rule, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// GetValue returns the value of the given key of the configuration, with the
+// deny and exclude list entries separated by commas, or an empty string if the key is not
+// set. It returns an error if the key is unknown.
+func (c Config) GetValue(key string) (string, error) {
+	switch key {
+	case "cache.ttl":
+		return c.Cache.TTL.String(), nil
+	case "defaults.exclude":
+		return strings.Join(c.Defaults.Exclude, ","), nil
+	case "defaults.goflags":
+		return c.Defaults.GoFlags, nil
+	case "defaults.kind":
+		return string(c.Defaults.Kind), nil
+	case "defaults.output":
+		return c.Defaults.Output, nil
+	case "defaults.parallelism":
+		return strconv.Itoa(c.Defaults.Parallelism), nil
+	case "deny":
+		return strings.Join(c.Deny, ","), nil
+	case "disable_release_check":
+		return strconv.FormatBool(c.DisableReleaseCheck), nil
+	case "network.backoff":
+		return c.Network.Backoff.String(), nil
+	case "network.concurrency":
+		return strconv.Itoa(c.Network.Concurrency), nil
+	case "network.retries":
+		return strconv.Itoa(c.Network.Retries), nil
+	case "network.timeout":
+		return c.Network.Timeout.String(), nil
+	case "pin_mode":
+		return string(c.PinMode), nil
+	case "retention.auto_prune":
+		return strconv.FormatBool(c.Retention.AutoPrune), nil
+	case "retention.keep_days":
+		return strconv.Itoa(c.Retention.KeepDays), nil
+	case "retention.keep_last":
+		return strconv.Itoa(c.Retention.KeepLast), nil
+	case "signing.darwin":
+		return c.Signing.Darwin, nil
+	case "signing.linux":
+		return c.Signing.Linux, nil
+	case "signing.windows":
+		return c.Signing.Windows, nil
+	case "store_path":
+		return c.StorePath, nil
+	case "telemetry.enabled":
+		return strconv.FormatBool(c.Telemetry.Enabled), nil
+	case "telemetry.endpoint":
+		return c.Telemetry.Endpoint, nil
+	case "upx":
+		return strconv.FormatBool(c.UPX), nil
+	default:
+		return "", newUnknownConfigKeyError(key)
+	}
+}
+
+// SetConfigValue sets the given key to the value in the configuration YAML
+// data, keeping the other settings and the comments. The deny and exclude list
+// entries are separated by commas. It returns the updated data, or an error if the key is
+// unknown, the data is not valid YAML or the updated configuration is invalid.
+func SetConfigValue(data []byte, key, value string) ([]byte, error) {
+	if !slices.Contains(ConfigKeys, key) {
+		return nil, newUnknownConfigKeyError(key)
+	}
+
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+	if key == "deny" || key == "defaults.exclude" {
+		valueNode = &yaml.Node{Kind: yaml.SequenceNode}
+		for rule := range strings.SplitSeq(value, ",") {
+			if rule = strings.TrimSpace(rule); rule != "" {
+				valueNode.Content = append(valueNode.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: rule})
+			}
+		}
+	}
+
+	return editConfig(data, func(mapping *yaml.Node) {
+		setConfigNode(mapping, strings.Split(key, "."), valueNode)
+	})
+}
+
+// UnsetConfigValue removes the given key from the configuration YAML data,
+// keeping the other settings and the comments. It returns the updated data, or
+// an error if the key is unknown or the data is not valid YAML.
+func UnsetConfigValue(data []byte, key string) ([]byte, error) {
+	if !slices.Contains(ConfigKeys, key) {
+		return nil, newUnknownConfigKeyError(key)
+	}
+
+	return editConfig(data, func(mapping *yaml.Node) {
+		unsetConfigNode(mapping, strings.Split(key, "."))
+	})
+}
+
+// editConfig applies the edit to the root mapping of the configuration YAML
+// data, created if the data is empty. It returns the updated data, or an error
+// if the data is not a valid YAML mapping or the updated configuration is
+// invalid.
+func editConfig(data []byte, edit func(mapping *yaml.Node)) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("config is not a YAML mapping")
+	}
+
+	edit(doc.Content[0])
+
+	var buf strings.Builder
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2) //nolint:mnd // two spaces indentation
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+
+	updated := []byte(buf.String())
+	if _, err := ParseConfig(updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// findConfigNode returns the index of the given key in the mapping node, or -1
+// if the key is not found.
+func findConfigNode(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// setConfigNode sets the value of the key path in the mapping node, creating
+// the intermediate mappings. The comments of a replaced value are kept.
+func setConfigNode(mapping *yaml.Node, keyPath []string, value *yaml.Node) {
+	idx := findConfigNode(mapping, keyPath[0])
+	if idx < 0 {
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: keyPath[0]}, nil)
+		idx = len(mapping.Content) - 2 //nolint:mnd // key and value nodes
+	}
+
+	if len(keyPath) == 1 {
+		if previous := mapping.Content[idx+1]; previous != nil {
+			value.HeadComment = previous.HeadComment
+			value.LineComment = previous.LineComment
+			value.FootComment = previous.FootComment
+		}
+
+		mapping.Content[idx+1] = value
+		return
+	}
+
+	child := mapping.Content[idx+1]
+	if child == nil || child.Kind != yaml.MappingNode {
+		child = &yaml.Node{Kind: yaml.MappingNode}
+		mapping.Content[idx+1] = child
+	}
+
+	setConfigNode(child, keyPath[1:], value)
+}
+
+// unsetConfigNode removes the key path from the mapping node, removing the
+// intermediate mappings left empty. The head comment of a removed first key,
+// holding the comment on top of the file or section, is moved to the next key.
+func unsetConfigNode(mapping *yaml.Node, keyPath []string) {
+	idx := findConfigNode(mapping, keyPath[0])
+	if idx < 0 {
+		return
+	}
+
+	if len(keyPath) > 1 {
+		child := mapping.Content[idx+1]
+		if child.Kind != yaml.MappingNode {
+			return
+		}
+
+		unsetConfigNode(child, keyPath[1:])
+		if len(child.Content) > 0 {
+			return
+		}
+	}
+
+	headComment := mapping.Content[idx].HeadComment
+	if idx == 0 && headComment != "" && len(mapping.Content) > 2 { //nolint:mnd // key and value nodes
+		next := mapping.Content[idx+2]
+		next.HeadComment = strings.TrimSpace(headComment + "\n" + next.HeadComment)
+	}
+
+	mapping.Content = slices.Delete(mapping.Content, idx, idx+2) //nolint:mnd // key and value nodes
+}
+
+// newUnknownConfigKeyError returns the error of an unknown configuration key.
+func newUnknownConfigKeyError(key string) error {
+	return fmt.Errorf("unknown config key %q, allowed values are: %v", key, ConfigKeys)
+}
@@ -0,0 +1,611 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestParseConfig(t *testing.T) {
+	cases := map[string]struct {
+		data           []byte
+		expectedConfig model.Config
+		expectedErr    string
+	}{
+		"empty": {
+			data:           []byte(""),
+			expectedConfig: model.NewConfig(),
+		},
+		"cache": {
+			data: []byte("cache:\n  ttl: 24h\n"),
+			expectedConfig: model.Config{
+				Cache: model.CacheConfig{TTL: 24 * time.Hour},
+			},
+		},
+		"defaults": {
+			data: []byte("defaults:\n  parallelism: 4\n  kind: major\n  goflags: -trimpath\n  output: json\n" +
+				"  exclude:\n    - gopls\n    - golangci-lint*\n"),
+			expectedConfig: model.Config{
+				Defaults: model.DefaultsConfig{
+					Exclude:     []string{"gopls", "golangci-lint*"},
+					GoFlags:     "-trimpath",
+					Kind:        model.KindMajor,
+					Output:      model.OutputJSON,
+					Parallelism: 4,
+				},
+			},
+		},
+		"store-path": {
+			data: []byte("store_path: /mnt/data/gobin/bin\n"),
+			expectedConfig: model.Config{
+				StorePath: "/mnt/data/gobin/bin",
+			},
+		},
+		"deny-list": {
+			data: []byte("deny:\n  - example.com/mockorg/mockproj\n  - example.com/mockfork/*\n"),
+			expectedConfig: model.Config{
+				Deny: []string{"example.com/mockorg/mockproj", "example.com/mockfork/*"},
+			},
+		},
+		"network": {
+			data: []byte("network:\n  retries: 2\n  backoff: 500ms\n  timeout: 30s\n  concurrency: 4\n"),
+			expectedConfig: model.Config{
+				Network: model.NetworkConfig{
+					Retries:     2,
+					Backoff:     500 * time.Millisecond,
+					Timeout:     30 * time.Second,
+					Concurrency: 4,
+				},
+			},
+		},
+		"disable-release-check": {
+			data: []byte("disable_release_check: true\n"),
+			expectedConfig: model.Config{
+				DisableReleaseCheck: true,
+			},
+		},
+		"pin-mode": {
+			data: []byte("pin_mode: copy\n"),
+			expectedConfig: model.Config{
+				PinMode: model.PinModeCopy,
+			},
+		},
+		"retention": {
+			data: []byte("retention:\n  keep_last: 3\n  keep_days: 30\n  auto_prune: true\n"),
+			expectedConfig: model.Config{
+				Retention: model.RetentionConfig{
+					KeepLast:  3,
+					KeepDays:  30,
+					AutoPrune: true,
+				},
+			},
+		},
+		"telemetry": {
+			data: []byte("telemetry:\n  enabled: true\n  endpoint: https://metrics.example.com/gobin\n"),
+			expectedConfig: model.Config{
+				Telemetry: model.TelemetryConfig{
+					Enabled:  true,
+					Endpoint: "https://metrics.example.com/gobin",
+				},
+			},
+		},
+		"invalid-pin-mode": {
+			data:        []byte("pin_mode: hardlink\n"),
+			expectedErr: `invalid pin mode "hardlink", allowed values are: [symlink copy]`,
+		},
+		"invalid-telemetry-endpoint": {
+			data: []byte("telemetry:\n  endpoint: ftp://metrics.example.com/gobin\n"),
+			expectedErr: `invalid telemetry endpoint "ftp://metrics.example.com/gobin", must be an http or https URL ` +
+				`or a file path`,
+		},
+		"invalid-default-kind": {
+			data:        []byte("defaults:\n  kind: patch\n"),
+			expectedErr: `invalid default kind "patch", allowed values are: [latest major minor]`,
+		},
+		"invalid-default-output": {
+			data:        []byte("defaults:\n  output: yaml\n"),
+			expectedErr: `invalid default output "yaml", allowed values are: [text json]`,
+		},
+		"relative-store-path": {
+			data:        []byte("store_path: data/bin\n"),
+			expectedErr: `store path "data/bin" must be an absolute path`,
+		},
+		"negative-cache-ttl": {
+			data:        []byte("cache:\n  ttl: -1h\n"),
+			expectedErr: "cache ttl and defaults parallelism must not be negative",
+		},
+		"negative-defaults-parallelism": {
+			data:        []byte("defaults:\n  parallelism: -1\n"),
+			expectedErr: "cache ttl and defaults parallelism must not be negative",
+		},
+		"negative-network-setting": {
+			data:        []byte("network:\n  retries: -1\n"),
+			expectedErr: "network retries, backoff, timeout and concurrency must not be negative",
+		},
+		"negative-retention-setting": {
+			data:        []byte("retention:\n  keep_days: -1\n"),
+			expectedErr: "retention keep_last and keep_days must not be negative",
+		},
+		"negative-network-concurrency": {
+			data:        []byte("network:\n  concurrency: -1\n"),
+			expectedErr: "network retries, backoff, timeout and concurrency must not be negative",
+		},
+		"invalid": {
+			data:        []byte("deny: ["),
+			expectedErr: "yaml: line 1: did not find expected node content",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config, err := model.ParseConfig(tc.data)
+			assert.Equal(t, tc.expectedConfig, config)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_GetDenyRule(t *testing.T) {
+	cases := map[string]struct {
+		config         model.Config
+		pkg            model.Package
+		expectedRule   string
+		expectedDenied bool
+	}{
+		"empty": {
+			config: model.NewConfig(),
+			pkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+		},
+		"exact-path": {
+			config:         model.Config{Deny: []string{"example.com/mockorg/mockproj/cmd/mockproj"}},
+			pkg:            model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			expectedRule:   "example.com/mockorg/mockproj/cmd/mockproj",
+			expectedDenied: true,
+		},
+		"parent-path": {
+			config:         model.Config{Deny: []string{"example.com/mockorg/mockproj"}},
+			pkg:            model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+			expectedRule:   "example.com/mockorg/mockproj",
+			expectedDenied: true,
+		},
+		"glob": {
+			config:         model.Config{Deny: []string{"example.com/mockfork/*"}},
+			pkg:            model.NewPackage("example.com/mockfork/mockproj/cmd/mockproj"),
+			expectedRule:   "example.com/mockfork/*",
+			expectedDenied: true,
+		},
+		"normalized-rule": {
+			config:         model.Config{Deny: []string{"Example.com/mockorg/mockproj/"}},
+			pkg:            model.NewPackage("example.com/mockorg/mockproj"),
+			expectedRule:   "example.com/mockorg/mockproj",
+			expectedDenied: true,
+		},
+		"no-match": {
+			config: model.Config{Deny: []string{"example.com/mockorg/mockproj2", "example.com/mockfork/*"}},
+			pkg:    model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+		},
+		"invalid-glob": {
+			config: model.Config{Deny: []string{"example.com/mockorg/["}},
+			pkg:    model.NewPackage("example.com/mockorg/mockproj"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rule, denied := tc.config.GetDenyRule(tc.pkg)
+			assert.Equal(t, tc.expectedRule, rule)
+			assert.Equal(t, tc.expectedDenied, denied)
+		})
+	}
+}
+
+func TestConfig_GetValue(t *testing.T) {
+	config := model.Config{
+		Cache: model.CacheConfig{TTL: 24 * time.Hour},
+		Defaults: model.DefaultsConfig{
+			Exclude:     []string{"gopls", "golangci-lint*"},
+			GoFlags:     "-trimpath",
+			Kind:        model.KindMinor,
+			Output:      model.OutputJSON,
+			Parallelism: 4,
+		},
+		Deny:                []string{"example.com/mockorg/mockproj", "example.com/mockfork/*"},
+		DisableReleaseCheck: true,
+		Network: model.NetworkConfig{
+			Retries:     2,
+			Backoff:     500 * time.Millisecond,
+			Timeout:     30 * time.Second,
+			Concurrency: 4,
+		},
+		PinMode: model.PinModeCopy,
+		Retention: model.RetentionConfig{
+			KeepLast:  3,
+			AutoPrune: true,
+		},
+		Signing: model.SigningConfig{
+			Darwin: "Developer ID Application: Mock Org (MOCKTEAMID)",
+			Linux:  "mock@example.com",
+		},
+		StorePath: "/mnt/data/gobin/bin",
+		Telemetry: model.TelemetryConfig{
+			Enabled:  true,
+			Endpoint: "/var/log/gobin-metrics.jsonl",
+		},
+		UPX: true,
+	}
+
+	cases := map[string]struct {
+		key           string
+		expectedValue string
+		expectedErr   string
+	}{
+		"cache-ttl": {
+			key:           "cache.ttl",
+			expectedValue: "24h0m0s",
+		},
+		"defaults-exclude": {
+			key:           "defaults.exclude",
+			expectedValue: "gopls,golangci-lint*",
+		},
+		"defaults-goflags": {
+			key:           "defaults.goflags",
+			expectedValue: "-trimpath",
+		},
+		"defaults-kind": {
+			key:           "defaults.kind",
+			expectedValue: "minor",
+		},
+		"defaults-output": {
+			key:           "defaults.output",
+			expectedValue: "json",
+		},
+		"defaults-parallelism": {
+			key:           "defaults.parallelism",
+			expectedValue: "4",
+		},
+		"deny": {
+			key:           "deny",
+			expectedValue: "example.com/mockorg/mockproj,example.com/mockfork/*",
+		},
+		"disable-release-check": {
+			key:           "disable_release_check",
+			expectedValue: "true",
+		},
+		"network-backoff": {
+			key:           "network.backoff",
+			expectedValue: "500ms",
+		},
+		"network-concurrency": {
+			key:           "network.concurrency",
+			expectedValue: "4",
+		},
+		"network-retries": {
+			key:           "network.retries",
+			expectedValue: "2",
+		},
+		"network-timeout": {
+			key:           "network.timeout",
+			expectedValue: "30s",
+		},
+		"pin-mode": {
+			key:           "pin_mode",
+			expectedValue: "copy",
+		},
+		"retention-auto-prune": {
+			key:           "retention.auto_prune",
+			expectedValue: "true",
+		},
+		"retention-keep-days": {
+			key:           "retention.keep_days",
+			expectedValue: "0",
+		},
+		"retention-keep-last": {
+			key:           "retention.keep_last",
+			expectedValue: "3",
+		},
+		"signing-darwin": {
+			key:           "signing.darwin",
+			expectedValue: "Developer ID Application: Mock Org (MOCKTEAMID)",
+		},
+		"signing-linux": {
+			key:           "signing.linux",
+			expectedValue: "mock@example.com",
+		},
+		"signing-windows": {
+			key: "signing.windows",
+		},
+		"store-path": {
+			key:           "store_path",
+			expectedValue: "/mnt/data/gobin/bin",
+		},
+		"telemetry-enabled": {
+			key:           "telemetry.enabled",
+			expectedValue: "true",
+		},
+		"telemetry-endpoint": {
+			key:           "telemetry.endpoint",
+			expectedValue: "/var/log/gobin-metrics.jsonl",
+		},
+		"upx": {
+			key:           "upx",
+			expectedValue: "true",
+		},
+		"unknown-key": {
+			key: "defaults.color",
+			expectedErr: `unknown config key "defaults.color", allowed values are: [cache.ttl defaults.exclude ` +
+				`defaults.goflags defaults.kind defaults.output defaults.parallelism deny disable_release_check ` +
+				`network.backoff network.concurrency network.retries network.timeout pin_mode ` +
+				`retention.auto_prune retention.keep_days retention.keep_last signing.darwin signing.linux ` +
+				`signing.windows store_path telemetry.enabled telemetry.endpoint upx]`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			value, err := config.GetValue(tc.key)
+			assert.Equal(t, tc.expectedValue, value)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDefaultsConfig_IsExcluded(t *testing.T) {
+	defaults := model.DefaultsConfig{Exclude: []string{"gopls", "golangci-lint*", "["}}
+
+	cases := map[string]struct {
+		name     string
+		expected bool
+	}{
+		"exact-name": {
+			name:     "gopls",
+			expected: true,
+		},
+		"glob-pattern": {
+			name:     "golangci-lint-v2",
+			expected: true,
+		},
+		"not-excluded": {
+			name:     "dlv",
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, defaults.IsExcluded(tc.name))
+		})
+	}
+}
+
+func TestRetentionConfig_IsRetained(t *testing.T) {
+	now := time.Date(2025, 7, 29, 19, 14, 54, 0, time.UTC)
+
+	cases := map[string]struct {
+		config      model.RetentionConfig
+		rank        int
+		installedAt time.Time
+		expected    bool
+	}{
+		"not-set": {
+			rank:        5,
+			installedAt: now.AddDate(-1, 0, 0),
+			expected:    true,
+		},
+		"keep-last-retained": {
+			config:      model.RetentionConfig{KeepLast: 2},
+			rank:        1,
+			installedAt: now.AddDate(-1, 0, 0),
+			expected:    true,
+		},
+		"keep-last-expired": {
+			config:      model.RetentionConfig{KeepLast: 2},
+			rank:        2,
+			installedAt: now,
+		},
+		"keep-days-retained": {
+			config:      model.RetentionConfig{KeepDays: 30},
+			rank:        5,
+			installedAt: now.AddDate(0, 0, -29),
+			expected:    true,
+		},
+		"keep-days-expired": {
+			config:      model.RetentionConfig{KeepDays: 30},
+			installedAt: now.AddDate(0, 0, -31),
+		},
+		"keep-last-or-days-retained": {
+			config:      model.RetentionConfig{KeepLast: 1, KeepDays: 30},
+			rank:        3,
+			installedAt: now.AddDate(0, 0, -1),
+			expected:    true,
+		},
+		"keep-last-and-days-expired": {
+			config:      model.RetentionConfig{KeepLast: 1, KeepDays: 30},
+			rank:        1,
+			installedAt: now.AddDate(0, 0, -31),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.config.IsRetained(tc.rank, tc.installedAt, now))
+		})
+	}
+}
+
+func TestSigningConfig_GetIdentity(t *testing.T) {
+	config := model.SigningConfig{
+		Darwin:  "Developer ID Application: Mock Org (MOCKTEAMID)",
+		Linux:   "mock@example.com",
+		Windows: "Mock Org",
+	}
+
+	cases := map[string]struct {
+		goos             string
+		expectedIdentity string
+	}{
+		"darwin": {
+			goos:             "darwin",
+			expectedIdentity: "Developer ID Application: Mock Org (MOCKTEAMID)",
+		},
+		"linux": {
+			goos:             "linux",
+			expectedIdentity: "mock@example.com",
+		},
+		"windows": {
+			goos:             "windows",
+			expectedIdentity: "Mock Org",
+		},
+		"unsupported-os": {
+			goos: "freebsd",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedIdentity, config.GetIdentity(tc.goos))
+		})
+	}
+}
+
+func TestSetConfigValue(t *testing.T) {
+	cases := map[string]struct {
+		data         []byte
+		key          string
+		value        string
+		expectedData string
+		expectedErr  string
+	}{
+		"empty": {
+			key:          "network.retries",
+			value:        "3",
+			expectedData: "network:\n  retries: 3\n",
+		},
+		"keep-comments": {
+			data:         []byte("# gobin config\npin_mode: copy # no symlinks\n"),
+			key:          "pin_mode",
+			value:        "symlink",
+			expectedData: "# gobin config\npin_mode: symlink # no symlinks\n",
+		},
+		"nested-key": {
+			data:         []byte("network:\n  backoff: 500ms\n"),
+			key:          "network.timeout",
+			value:        "30s",
+			expectedData: "network:\n  backoff: 500ms\n  timeout: 30s\n",
+		},
+		"deny-list": {
+			data:         []byte("pin_mode: copy\n"),
+			key:          "deny",
+			value:        "example.com/mockorg/mockproj, *",
+			expectedData: "pin_mode: copy\ndeny:\n  - example.com/mockorg/mockproj\n  - '*'\n",
+		},
+		"exclude-list": {
+			key:          "defaults.exclude",
+			value:        "gopls,golangci-lint*",
+			expectedData: "defaults:\n  exclude:\n    - gopls\n    - golangci-lint*\n",
+		},
+		"invalid-value": {
+			key:         "pin_mode",
+			value:       "hardlink",
+			expectedErr: `invalid pin mode "hardlink", allowed values are: [symlink copy]`,
+		},
+		"invalid-type": {
+			key:         "network.backoff",
+			value:       "fast",
+			expectedErr: "yaml: unmarshal errors:\n  line 2: cannot unmarshal !!str `fast` into time.Duration",
+		},
+		"invalid-data": {
+			data:        []byte("deny: ["),
+			key:         "pin_mode",
+			value:       "copy",
+			expectedErr: "yaml: line 1: did not find expected node content",
+		},
+		"not-mapping": {
+			data:        []byte("- pin_mode\n"),
+			key:         "pin_mode",
+			value:       "copy",
+			expectedErr: "config is not a YAML mapping",
+		},
+		"unknown-key": {
+			key:   "defaults.color",
+			value: "blue",
+			expectedErr: `unknown config key "defaults.color", allowed values are: [cache.ttl defaults.exclude ` +
+				`defaults.goflags defaults.kind defaults.output defaults.parallelism deny disable_release_check ` +
+				`network.backoff network.concurrency network.retries network.timeout pin_mode ` +
+				`retention.auto_prune retention.keep_days retention.keep_last signing.darwin signing.linux ` +
+				`signing.windows store_path telemetry.enabled telemetry.endpoint upx]`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := model.SetConfigValue(tc.data, tc.key, tc.value)
+			assert.Equal(t, tc.expectedData, string(data))
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUnsetConfigValue(t *testing.T) {
+	cases := map[string]struct {
+		data         []byte
+		key          string
+		expectedData string
+		expectedErr  string
+	}{
+		"top-level-key": {
+			data:         []byte("# gobin config\npin_mode: copy\ndisable_release_check: true\n"),
+			key:          "pin_mode",
+			expectedData: "# gobin config\ndisable_release_check: true\n",
+		},
+		"nested-key": {
+			data:         []byte("network:\n  retries: 2\n  backoff: 500ms\n"),
+			key:          "network.retries",
+			expectedData: "network:\n  backoff: 500ms\n",
+		},
+		"last-nested-key": {
+			data:         []byte("pin_mode: copy\nnetwork:\n  retries: 2\n"),
+			key:          "network.retries",
+			expectedData: "pin_mode: copy\n",
+		},
+		"missing-key": {
+			data:         []byte("pin_mode: copy\n"),
+			key:          "network.retries",
+			expectedData: "pin_mode: copy\n",
+		},
+		"unknown-key": {
+			key: "defaults.color",
+			expectedErr: `unknown config key "defaults.color", allowed values are: [cache.ttl defaults.exclude ` +
+				`defaults.goflags defaults.kind defaults.output defaults.parallelism deny disable_release_check ` +
+				`network.backoff network.concurrency network.retries network.timeout pin_mode ` +
+				`retention.auto_prune retention.keep_days retention.keep_last signing.darwin signing.linux ` +
+				`signing.windows store_path telemetry.enabled telemetry.endpoint upx]`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := model.UnsetConfigValue(tc.data, tc.key)
+			assert.Equal(t, tc.expectedData, string(data))
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package model
+
+// DownloadEstimate represents the estimated download size of the modules
+// providing a set of packages. Unknown is the number of modules whose download
+// size is not available from the module proxies.
+type DownloadEstimate struct {
+	Modules int
+	Unknown int
+	Size    ByteSize
+}
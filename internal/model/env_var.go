@@ -0,0 +1,29 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// envVarNameRegexp matches the valid environment variable names, ex. GOMEMLIMIT
+// or TOOL_CONFIG.
+var envVarNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseEnvVar parses an environment variable in the form "name=value". It
+// returns the name and the value, or an error if the form or the name is not
+// valid.
+func ParseEnvVar(envVar string) (string, string, error) {
+	name, value, ok := strings.Cut(envVar, "=")
+	if !ok || !IsValidEnvVarName(name) {
+		return "", "", fmt.Errorf("invalid environment variable %q, expected KEY=VALUE", envVar)
+	}
+
+	return name, value, nil
+}
+
+// IsValidEnvVarName checks if the given environment variable name is valid: a
+// letter or an underscore followed by letters, digits or underscores.
+func IsValidEnvVarName(name string) bool {
+	return envVarNameRegexp.MatchString(name)
+}
@@ -0,0 +1,51 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestParseEnvVar(t *testing.T) {
+	cases := map[string]struct {
+		envVar        string
+		expectedName  string
+		expectedValue string
+		expectedErr   error
+	}{
+		"valid": {
+			envVar:        "GOMEMLIMIT=1GiB",
+			expectedName:  "GOMEMLIMIT",
+			expectedValue: "1GiB",
+		},
+		"valid-empty-value": {
+			envVar:       "TOOL_CONFIG=",
+			expectedName: "TOOL_CONFIG",
+		},
+		"valid-value-with-equals": {
+			envVar:        "TOOL_FLAGS=--level=debug",
+			expectedName:  "TOOL_FLAGS",
+			expectedValue: "--level=debug",
+		},
+		"invalid-no-value": {
+			envVar:      "GOMEMLIMIT",
+			expectedErr: errors.New(`invalid environment variable "GOMEMLIMIT", expected KEY=VALUE`),
+		},
+		"invalid-name": {
+			envVar:      "1TOOL=value",
+			expectedErr: errors.New(`invalid environment variable "1TOOL=value", expected KEY=VALUE`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			envName, envValue, err := model.ParseEnvVar(tc.envVar)
+			assert.Equal(t, tc.expectedName, envName)
+			assert.Equal(t, tc.expectedValue, envValue)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package model
+
+// GoEnv represents the environment settings of the Go toolchain relevant to
+// gobin.
+type GoEnv struct {
+	GoCache    string `json:"GOCACHE"`
+	GoModCache string `json:"GOMODCACHE"`
+	GoProxy    string `json:"GOPROXY"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	goversion "go/version"
+	"strings"
+)
+
+// GoRelease represents a Go release listed in the Go release feed.
+type GoRelease struct {
+	Version string `json:"version"`
+	Stable  bool   `json:"stable"`
+}
+
+// GoReleases represents the Go releases listed in the Go release feed, the
+// latest patch release of each supported Go minor version.
+type GoReleases []GoRelease
+
+// GetBinaryGoUpdateInfo returns the Go update information for a binary: the
+// latest stable patch release of the Go minor version it is built with if still
+// supported, or else the latest stable patch release of the oldest supported
+// Go minor version. It returns false if the binary is built with an unknown Go
+// version, or with a Go version not older than the release found.
+func (r GoReleases) GetBinaryGoUpdateInfo(info BinaryInfo) (BinaryGoUpdateInfo, bool) {
+	current, _, _ := strings.Cut(info.GoVersion, " ")
+	if !goversion.IsValid(current) {
+		return BinaryGoUpdateInfo{}, false
+	}
+
+	var latest, oldest string
+	for _, release := range r {
+		if !release.Stable || !goversion.IsValid(release.Version) {
+			continue
+		}
+
+		lang := goversion.Lang(release.Version)
+		if lang == goversion.Lang(current) && goversion.Compare(release.Version, latest) > 0 {
+			latest = release.Version
+		}
+
+		if oldest == "" || goversion.Compare(lang, goversion.Lang(oldest)) < 0 ||
+			(lang == goversion.Lang(oldest) && goversion.Compare(release.Version, oldest) > 0) {
+			oldest = release.Version
+		}
+	}
+
+	updateInfo := BinaryGoUpdateInfo{
+		BinaryInfo:      info,
+		LatestGoVersion: latest,
+		IsSupported:     latest != "",
+	}
+
+	if !updateInfo.IsSupported {
+		updateInfo.LatestGoVersion = oldest
+	}
+
+	if updateInfo.LatestGoVersion == "" || goversion.Compare(current, updateInfo.LatestGoVersion) >= 0 {
+		return BinaryGoUpdateInfo{}, false
+	}
+
+	return updateInfo, true
+}
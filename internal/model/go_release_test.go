@@ -0,0 +1,68 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestGoReleases_GetBinaryGoUpdateInfo(t *testing.T) {
+	releases := model.GoReleases{
+		{Version: "go1.25rc2", Stable: false},
+		{Version: "go1.24.9", Stable: true},
+		{Version: "go1.23.12", Stable: true},
+	}
+
+	cases := map[string]struct {
+		goVersion          string
+		expectedUpdateInfo model.BinaryGoUpdateInfo
+		expectedOK         bool
+	}{
+		"supported-outdated": {
+			goVersion: "go1.24.5",
+			expectedUpdateInfo: model.BinaryGoUpdateInfo{
+				BinaryInfo:      model.BinaryInfo{GoVersion: "go1.24.5"},
+				LatestGoVersion: "go1.24.9",
+				IsSupported:     true,
+			},
+			expectedOK: true,
+		},
+		"supported-outdated-experiment": {
+			goVersion: "go1.23.2 X:boringcrypto",
+			expectedUpdateInfo: model.BinaryGoUpdateInfo{
+				BinaryInfo:      model.BinaryInfo{GoVersion: "go1.23.2 X:boringcrypto"},
+				LatestGoVersion: "go1.23.12",
+				IsSupported:     true,
+			},
+			expectedOK: true,
+		},
+		"unsupported": {
+			goVersion: "go1.21.3",
+			expectedUpdateInfo: model.BinaryGoUpdateInfo{
+				BinaryInfo:      model.BinaryInfo{GoVersion: "go1.21.3"},
+				LatestGoVersion: "go1.23.12",
+				IsSupported:     false,
+			},
+			expectedOK: true,
+		},
+		"up-to-date": {
+			goVersion: "go1.24.9",
+		},
+		"newer-than-supported": {
+			goVersion: "go1.25rc2",
+		},
+		"devel": {
+			goVersion: "devel go1.26-abcdef",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updateInfo, ok := releases.GetBinaryGoUpdateInfo(model.BinaryInfo{GoVersion: tc.goVersion})
+			assert.Equal(t, tc.expectedUpdateInfo, updateInfo)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package model
+
+// HTTPRoute represents the HTTP proxy traversed to reach an endpoint, ex. a
+// module proxy, according to the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+// environment variables.
+type HTTPRoute struct {
+	Endpoint string
+	URL      string
+	Proxy    string
+}
+
+// IsDirect returns whether the endpoint is reached without an HTTP proxy.
+func (r HTTPRoute) IsDirect() bool {
+	return r.Proxy == ""
+}
@@ -0,0 +1,32 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestHTTPRoute_IsDirect(t *testing.T) {
+	cases := map[string]struct {
+		route    model.HTTPRoute
+		expected bool
+	}{
+		"direct": {
+			route:    model.HTTPRoute{URL: "https://proxy.golang.org"},
+			expected: true,
+		},
+		"proxied": {
+			route:    model.HTTPRoute{URL: "https://proxy.golang.org", Proxy: "http://proxy.corp:3128"},
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.route.IsDirect()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
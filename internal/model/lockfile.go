@@ -0,0 +1,95 @@
+package model
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLockfileName is the name of the lockfile written by gobin export and
+// read by gobin sync when no path is given.
+const DefaultLockfileName = "gobin.lock"
+
+// LockedBinary represents a managed binary pinned to the Go binary directory,
+// as listed in a lockfile: the pin name, the package and version it is built
+// from, and the pin kind. The extension of the pin depends on the operating
+// system, so it is not part of the lockfile.
+type LockedBinary struct {
+	Name      string  `yaml:"name"`
+	Package   string  `yaml:"package"`
+	Version   Version `yaml:"version"`
+	Kind      Kind    `yaml:"kind"`
+	Extension string  `yaml:"-"`
+}
+
+// GetBinary returns the pin of the locked binary in the Go binary directory.
+func (b LockedBinary) GetBinary() Binary {
+	return NewBinary(b.Name, NewLatestVersion(), b.Extension)
+}
+
+// GetPackage returns the package of the locked binary at its locked version.
+func (b LockedBinary) GetPackage() Package {
+	return NewPackageWithVersion(b.Package, b.Version)
+}
+
+// String returns the string representation of the locked binary, ex.
+// "dlv-v1 (github.com/go-delve/delve/cmd/dlv@v1.25.1)".
+func (b LockedBinary) String() string {
+	return fmt.Sprintf("%s (%s)", b.Name, b.GetPackage().String())
+}
+
+// Lockfile represents the manifest of the managed binaries, to reproduce them
+// on another machine or in CI with gobin sync.
+type Lockfile struct {
+	Binaries []LockedBinary `yaml:"binaries"`
+}
+
+// NewLockfile creates a new lockfile with the given binaries, sorted by name.
+func NewLockfile(bins ...LockedBinary) Lockfile {
+	bins = slices.Clone(bins)
+	slices.SortFunc(bins, func(a, b LockedBinary) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return Lockfile{Binaries: bins}
+}
+
+// ParseLockfile parses the lockfile from the given YAML data. It returns an
+// error if the data is not valid YAML, or if a binary has no name or package,
+// a version other than an exact one, an unknown pin kind or the same name as
+// another binary.
+func ParseLockfile(data []byte) (Lockfile, error) {
+	var lockfile Lockfile
+	if err := yaml.Unmarshal(data, &lockfile); err != nil {
+		return Lockfile{}, err
+	}
+
+	names := make(map[string]struct{}, len(lockfile.Binaries))
+	for _, bin := range lockfile.Binaries {
+		switch {
+		case bin.Name == "" || bin.Package == "":
+			return Lockfile{}, fmt.Errorf("binary %q must have a name and a package", bin.Name)
+		case !bin.Version.IsExact():
+			return Lockfile{}, fmt.Errorf("invalid version %q for binary %q, must be an exact version",
+				bin.Version, bin.Name)
+		case !bin.Kind.IsValid():
+			return Lockfile{}, fmt.Errorf("invalid kind %q for binary %q, allowed values are: %v",
+				bin.Kind, bin.Name, allowedKinds)
+		}
+
+		if _, ok := names[bin.Name]; ok {
+			return Lockfile{}, fmt.Errorf("duplicate binary %q", bin.Name)
+		}
+
+		names[bin.Name] = struct{}{}
+	}
+
+	return lockfile, nil
+}
+
+// Marshal returns the lockfile as YAML data.
+func (l Lockfile) Marshal() ([]byte, error) {
+	return yaml.Marshal(l)
+}
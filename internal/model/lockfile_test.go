@@ -0,0 +1,141 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestLockedBinary_GetBinary(t *testing.T) {
+	bin := model.LockedBinary{
+		Name:      "dlv-v1",
+		Package:   "github.com/go-delve/delve/cmd/dlv",
+		Version:   "v1.25.1",
+		Kind:      model.KindMajor,
+		Extension: ".exe",
+	}
+
+	assert.Equal(t, model.NewBinaryFromString("dlv-v1.exe"), bin.GetBinary())
+}
+
+func TestLockedBinary_String(t *testing.T) {
+	bin := model.LockedBinary{
+		Name:    "dlv-v1",
+		Package: "github.com/go-delve/delve/cmd/dlv",
+		Version: "v1.25.1",
+		Kind:    model.KindMajor,
+	}
+
+	assert.Equal(t, "dlv-v1 (github.com/go-delve/delve/cmd/dlv@v1.25.1)", bin.String())
+}
+
+func TestLockfile_Marshal(t *testing.T) {
+	lockfile := model.NewLockfile(
+		model.LockedBinary{
+			Name:      "gopls",
+			Package:   "golang.org/x/tools/gopls",
+			Version:   "v0.20.0",
+			Kind:      model.KindLatest,
+			Extension: ".exe",
+		},
+		model.LockedBinary{
+			Name:    "dlv-v1",
+			Package: "github.com/go-delve/delve/cmd/dlv",
+			Version: "v1.25.1",
+			Kind:    model.KindMajor,
+		},
+	)
+
+	data, err := lockfile.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, `binaries:
+    - name: dlv-v1
+      package: github.com/go-delve/delve/cmd/dlv
+      version: v1.25.1
+      kind: major
+    - name: gopls
+      package: golang.org/x/tools/gopls
+      version: v0.20.0
+      kind: latest
+`, string(data))
+}
+
+func TestParseLockfile(t *testing.T) {
+	cases := map[string]struct {
+		data             string
+		expectedLockfile model.Lockfile
+		expectedErr      error
+	}{
+		"success": {
+			data: `binaries:
+  - name: dlv-v1
+    package: github.com/go-delve/delve/cmd/dlv
+    version: v1.25.1
+    kind: major
+`,
+			expectedLockfile: model.Lockfile{
+				Binaries: []model.LockedBinary{
+					{
+						Name:    "dlv-v1",
+						Package: "github.com/go-delve/delve/cmd/dlv",
+						Version: "v1.25.1",
+						Kind:    model.KindMajor,
+					},
+				},
+			},
+		},
+		"success-empty": {
+			data: "",
+		},
+		"error-missing-package": {
+			data: `binaries:
+  - name: dlv
+    version: v1.25.1
+    kind: latest
+`,
+			expectedErr: errors.New(`binary "dlv" must have a name and a package`),
+		},
+		"error-version-selector": {
+			data: `binaries:
+  - name: dlv
+    package: github.com/go-delve/delve/cmd/dlv
+    version: latest
+    kind: latest
+`,
+			expectedErr: errors.New(`invalid version "latest" for binary "dlv", must be an exact version`),
+		},
+		"error-invalid-kind": {
+			data: `binaries:
+  - name: dlv
+    package: github.com/go-delve/delve/cmd/dlv
+    version: v1.25.1
+    kind: patch
+`,
+			expectedErr: errors.New(`invalid kind "patch" for binary "dlv", allowed values are: [latest major minor]`),
+		},
+		"error-duplicate-binary": {
+			data: `binaries:
+  - name: dlv
+    package: github.com/go-delve/delve/cmd/dlv
+    version: v1.25.1
+    kind: latest
+  - name: dlv
+    package: github.com/go-delve/delve/cmd/dlv
+    version: v1.25.0
+    kind: latest
+`,
+			expectedErr: errors.New(`duplicate binary "dlv"`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			lockfile, err := model.ParseLockfile([]byte(tc.data))
+			assert.Equal(t, tc.expectedLockfile, lockfile)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
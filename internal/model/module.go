@@ -3,6 +3,8 @@ package model
 import (
 	"strconv"
 	"strings"
+
+	"golang.org/x/mod/module"
 )
 
 // Module represents a module.
@@ -11,8 +13,18 @@ type Module struct {
 	Version Version
 }
 
-// NewModule creates a new module from a module path and version.
+// NewModule creates a new module from a module path and version. The module
+// path is normalized, and the major version suffix is added to the path if
+// missing for versions v2 or higher, unless the version is +incompatible.
 func NewModule(path string, version Version) Module {
+	path = normalizePath(path)
+
+	major := version.Major()
+	if _, pathMajor, ok := module.SplitPathVersion(path); ok && pathMajor == "" &&
+		major != "" && major != "v0" && major != "v1" && !version.IsIncompatible() {
+		path += "/" + major
+	}
+
 	return Module{
 		Path:    path,
 		Version: version,
@@ -38,10 +50,16 @@ func (m Module) IsValid() bool {
 }
 
 // NextMajorModule returns the next major module. It returns the latest module
-// with the base module path and the next major version.
+// with the base module path and the next major version. For +incompatible
+// versions, the next major version follows the major version of the module
+// version, since the path has no major version suffix.
 func (m Module) NextMajorModule() Module {
 	baseModule, versionSuffix := getBaseModuleAndVersionSuffix(m.Path)
 
+	if versionSuffix == "" && m.Version.IsIncompatible() {
+		return NewLatestModule(baseModule + "/" + m.Version.NextMajorVersion().String())
+	}
+
 	if versionSuffix == "" {
 		return NewLatestModule(baseModule + "/v2")
 	}
@@ -54,6 +72,26 @@ func (m Module) String() string {
 	return m.Path + "@" + m.Version.String()
 }
 
+// normalizePath normalizes a module or package path. It trims spaces and
+// slashes, removes empty path elements and lowercases the host, which is case
+// insensitive. The remaining elements are kept, since they are case sensitive.
+func normalizePath(path string) string {
+	var elems []string
+	for elem := range strings.SplitSeq(strings.TrimSpace(path), "/") {
+		if elem != "" {
+			elems = append(elems, elem)
+		}
+	}
+
+	if len(elems) == 0 {
+		return ""
+	}
+
+	elems[0] = strings.ToLower(elems[0])
+
+	return strings.Join(elems, "/")
+}
+
 // getBaseModuleAndVersionSuffix gets the base module path and the version suffix
 // from a module path. If the module path does not have a version suffix, it
 // returns the module path unchanged and an empty string.
@@ -8,6 +8,78 @@ import (
 	"github.com/brunoribeiro127/gobin/internal/model"
 )
 
+func TestNewModule(t *testing.T) {
+	cases := map[string]struct {
+		path     string
+		version  model.Version
+		expected model.Module
+	}{
+		"regular": {
+			path:    "example.com/mockorg/mockproj",
+			version: model.NewVersion("v1.2.3"),
+			expected: model.Module{
+				Path:    "example.com/mockorg/mockproj",
+				Version: model.NewVersion("v1.2.3"),
+			},
+		},
+		"with-host-case-and-trailing-slash": {
+			path:    "Example.COM/MockOrg/MockProj/",
+			version: model.NewVersion("v1.2.3"),
+			expected: model.Module{
+				Path:    "example.com/MockOrg/MockProj",
+				Version: model.NewVersion("v1.2.3"),
+			},
+		},
+		"with-major-suffix": {
+			path:    "example.com/mockorg/mockproj/v2",
+			version: model.NewVersion("v2.1.0"),
+			expected: model.Module{
+				Path:    "example.com/mockorg/mockproj/v2",
+				Version: model.NewVersion("v2.1.0"),
+			},
+		},
+		"missing-major-suffix": {
+			path:    "example.com/mockorg/mockproj",
+			version: model.NewVersion("v2.1.0"),
+			expected: model.Module{
+				Path:    "example.com/mockorg/mockproj/v2",
+				Version: model.NewVersion("v2.1.0"),
+			},
+		},
+		"incompatible": {
+			path:    "example.com/mockorg/mockproj",
+			version: model.NewVersion("v2.1.0+incompatible"),
+			expected: model.Module{
+				Path:    "example.com/mockorg/mockproj",
+				Version: model.NewVersion("v2.1.0+incompatible"),
+			},
+		},
+		"gopkg-in": {
+			path:    "gopkg.in/mockproj.v2",
+			version: model.NewVersion("v2.1.0"),
+			expected: model.Module{
+				Path:    "gopkg.in/mockproj.v2",
+				Version: model.NewVersion("v2.1.0"),
+			},
+		},
+		"latest": {
+			path:    "example.com/mockorg/mockproj",
+			version: model.NewLatestVersion(),
+			expected: model.Module{
+				Path:    "example.com/mockorg/mockproj",
+				Version: model.NewLatestVersion(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := model.NewModule(tc.path, tc.version)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestModule_GetBaseModule(t *testing.T) {
 	cases := map[string]struct {
 		module   model.Module
@@ -75,6 +147,10 @@ func TestModule_NextMajorModule(t *testing.T) {
 			module:   model.NewLatestModule("example.com/mockorg/mockproj/v2"),
 			expected: model.NewLatestModule("example.com/mockorg/mockproj/v3"),
 		},
+		"incompatible-version": {
+			module:   model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v3.2.0+incompatible")),
+			expected: model.NewLatestModule("example.com/mockorg/mockproj/v4"),
+		},
 	}
 
 	for name, tc := range cases {
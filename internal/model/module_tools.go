@@ -0,0 +1,105 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	// GoModFileName is the name of the go.mod file of a module, read by gobin
+	// install --from-gomod when no path is given.
+	GoModFileName = "go.mod"
+	// GoSumFileName is the name of the go.sum file of a module, next to its
+	// go.mod file.
+	GoSumFileName = "go.sum"
+	// ToolsFileName is the name of the file declaring the tool dependencies of
+	// a module with blank imports, before the tool directives of Go 1.24.
+	ToolsFileName = "tools.go"
+)
+
+// ParseModuleTools parses the tool dependencies of a module from its go.mod
+// file, the tool directives, and its tools.go file, the blank imports, if not
+// nil. Each tool is returned as a package at the version of the module
+// providing it in the require directives, sorted by path. The versions must be
+// recorded in the go.sum file, as done by go mod tidy. The replace directives
+// are not honored, as they are not by go install with a version. It returns an
+// error if the go.mod or tools.go file cannot be parsed, if the module of a
+// tool is not required, or if its version is missing from the go.sum file.
+func ParseModuleTools(goMod, goSum, toolsGo []byte) ([]Package, error) {
+	modFile, err := modfile.Parse(GoModFileName, goMod, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(modFile.Tool))
+	for _, tool := range modFile.Tool {
+		paths = append(paths, tool.Path)
+	}
+
+	if toolsGo != nil {
+		file, parseErr := parser.ParseFile(token.NewFileSet(), ToolsFileName, toolsGo, parser.ImportsOnly)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
+		for _, spec := range file.Imports {
+			if spec.Name != nil && spec.Name.Name == "_" {
+				path, _ := strconv.Unquote(spec.Path.Value)
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	sums := parseGoSum(goSum)
+
+	packages := make([]Package, 0, len(paths))
+	for _, path := range paths {
+		var req *modfile.Require
+		for _, r := range modFile.Require {
+			if (path == r.Mod.Path || strings.HasPrefix(path, r.Mod.Path+"/")) &&
+				(req == nil || len(r.Mod.Path) > len(req.Mod.Path)) {
+				req = r
+			}
+		}
+
+		if req == nil {
+			return nil, fmt.Errorf("module of tool %q not required in %s", path, GoModFileName)
+		}
+
+		if _, ok := sums[req.Mod.String()]; !ok {
+			return nil, fmt.Errorf("missing %s entry for module %s of tool %q", GoSumFileName, req.Mod, path)
+		}
+
+		packages = append(packages, NewPackageWithVersion(path, NewVersion(req.Mod.Version)))
+	}
+
+	return packages, nil
+}
+
+// parseGoSum parses the module versions recorded in a go.sum file with the hash
+// of their content, ex. "golang.org/x/mod@v0.27.0", ignoring the go.mod file
+// hashes.
+func parseGoSum(data []byte) map[string]struct{} {
+	sums := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		//nolint:mnd // expected go.sum line format: path version hash
+		if fields := strings.Fields(scanner.Text()); len(fields) == 3 && !strings.HasSuffix(fields[1], "/go.mod") {
+			sums[fields[0]+"@"+fields[1]] = struct{}{}
+		}
+	}
+
+	return sums
+}
@@ -0,0 +1,121 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestParseModuleTools(t *testing.T) {
+	goMod := `module example.com/project
+
+go 1.24
+
+tool (
+	github.com/go-delve/delve/cmd/dlv
+	golang.org/x/tools/cmd/stringer
+)
+
+require (
+	github.com/go-delve/delve v1.25.1 // indirect
+	golang.org/x/tools v0.35.0 // indirect
+)
+`
+
+	goSum := `github.com/go-delve/delve v1.25.1 h1:abc=
+github.com/go-delve/delve v1.25.1/go.mod h1:def=
+golang.org/x/tools v0.35.0 h1:ghi=
+golang.org/x/tools v0.35.0/go.mod h1:jkl=
+`
+
+	cases := map[string]struct {
+		goMod            string
+		goSum            string
+		toolsGo          []byte
+		expectedPackages []model.Package
+		expectedErr      error
+	}{
+		"success-tool-directives": {
+			goMod: goMod,
+			goSum: goSum,
+			expectedPackages: []model.Package{
+				model.NewPackage("github.com/go-delve/delve/cmd/dlv@v1.25.1"),
+				model.NewPackage("golang.org/x/tools/cmd/stringer@v0.35.0"),
+			},
+		},
+		"success-tools-go": {
+			goMod: `module example.com/project
+
+go 1.22
+
+require golang.org/x/tools v0.35.0
+`,
+			goSum: goSum,
+			toolsGo: []byte(`//go:build tools
+
+package tools
+
+import (
+	"fmt"
+
+	_ "golang.org/x/tools/cmd/goimports"
+	_ "golang.org/x/tools/cmd/stringer"
+)
+`),
+			expectedPackages: []model.Package{
+				model.NewPackage("golang.org/x/tools/cmd/goimports@v0.35.0"),
+				model.NewPackage("golang.org/x/tools/cmd/stringer@v0.35.0"),
+			},
+		},
+		"success-nested-module": {
+			goMod: `module example.com/project
+
+tool golang.org/x/tools/gopls
+
+require (
+	golang.org/x/tools v0.35.0
+	golang.org/x/tools/gopls v0.20.0
+)
+`,
+			goSum: "golang.org/x/tools/gopls v0.20.0 h1:abc=\n",
+			expectedPackages: []model.Package{
+				model.NewPackage("golang.org/x/tools/gopls@v0.20.0"),
+			},
+		},
+		"success-no-tools": {
+			goMod:            "module example.com/project\n",
+			expectedPackages: []model.Package{},
+		},
+		"error-invalid-go-mod": {
+			goMod:       "module",
+			expectedErr: errors.New("go.mod:1: usage: module module/path"),
+		},
+		"error-module-not-required": {
+			goMod:       "module example.com/project\n\ntool golang.org/x/tools/cmd/stringer\n",
+			expectedErr: errors.New(`module of tool "golang.org/x/tools/cmd/stringer" not required in go.mod`),
+		},
+		"error-missing-go-sum-entry": {
+			goMod: goMod,
+			goSum: "golang.org/x/tools v0.35.0/go.mod h1:jkl=\n",
+			expectedErr: errors.New(
+				`missing go.sum entry for module github.com/go-delve/delve@v1.25.1 of tool ` +
+					`"github.com/go-delve/delve/cmd/dlv"`,
+			),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			packages, err := model.ParseModuleTools([]byte(tc.goMod), []byte(tc.goSum), tc.toolsGo)
+			assert.Equal(t, tc.expectedPackages, packages)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+import "strings"
+
+// NetrcCredentials represents the login and password of a machine in a .netrc
+// file.
+type NetrcCredentials struct {
+	Login    string
+	Password string
+}
+
+// Netrc represents the credentials of the machines in a .netrc file, by host.
+type Netrc map[string]NetrcCredentials
+
+// ParseNetrc parses the content of a .netrc file as the go command does: the
+// machine, login and password tokens are read until the default machine, and
+// the macro definitions are skipped. The first credentials of a machine win.
+func ParseNetrc(data string) Netrc {
+	netrc := Netrc{}
+
+	var machine string
+	var creds NetrcCredentials
+	inMacro := false
+
+	for line := range strings.SplitSeq(data, "\n") {
+		if inMacro {
+			inMacro = line != ""
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		i := 0
+		for ; i < len(fields)-1; i += 2 {
+			switch fields[i] {
+			case "machine":
+				machine, creds = fields[i+1], NetrcCredentials{}
+			case "login":
+				creds.Login = fields[i+1]
+			case "password":
+				creds.Password = fields[i+1]
+			case "macdef":
+				inMacro = true
+			}
+
+			if machine != "" && creds.Login != "" && creds.Password != "" {
+				if _, ok := netrc[machine]; !ok {
+					netrc[machine] = creds
+				}
+
+				machine, creds = "", NetrcCredentials{}
+			}
+		}
+
+		if i < len(fields) && fields[i] == "default" {
+			break
+		}
+	}
+
+	return netrc
+}
+
+// Get returns the credentials of the given host, ex. "proxy.example.com", and
+// whether the host has credentials.
+func (n Netrc) Get(host string) (NetrcCredentials, bool) {
+	creds, ok := n[host]
+	return creds, ok
+}
@@ -0,0 +1,73 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestParseNetrc(t *testing.T) {
+	cases := map[string]struct {
+		data     string
+		expected model.Netrc
+	}{
+		"single-line": {
+			data: "machine proxy.example.com login user password secret\n",
+			expected: model.Netrc{
+				"proxy.example.com": {Login: "user", Password: "secret"},
+			},
+		},
+		"multi-line": {
+			data: "machine proxy.example.com\n  login user\n  password secret\n" +
+				"machine nexus.example.com login other password token\n",
+			expected: model.Netrc{
+				"proxy.example.com": {Login: "user", Password: "secret"},
+				"nexus.example.com": {Login: "other", Password: "token"},
+			},
+		},
+		"first-machine-wins": {
+			data: "machine proxy.example.com login user password secret\n" +
+				"machine proxy.example.com login other password token\n",
+			expected: model.Netrc{
+				"proxy.example.com": {Login: "user", Password: "secret"},
+			},
+		},
+		"skip-macro": {
+			data: "macdef init\nmachine ignored.example.com login user password secret\n\n" +
+				"machine proxy.example.com login user password secret\n",
+			expected: model.Netrc{
+				"proxy.example.com": {Login: "user", Password: "secret"},
+			},
+		},
+		"stop-at-default": {
+			data: "machine proxy.example.com login user password secret\n" +
+				"default\nmachine ignored.example.com login user password secret\n",
+			expected: model.Netrc{
+				"proxy.example.com": {Login: "user", Password: "secret"},
+			},
+		},
+		"missing-password": {
+			data:     "machine proxy.example.com login user\n",
+			expected: model.Netrc{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, model.ParseNetrc(tc.data))
+		})
+	}
+}
+
+func TestNetrc_Get(t *testing.T) {
+	netrc := model.Netrc{"proxy.example.com": {Login: "user", Password: "secret"}}
+
+	creds, ok := netrc.Get("proxy.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, model.NetrcCredentials{Login: "user", Password: "secret"}, creds)
+
+	_, ok = netrc.Get("other.example.com")
+	assert.False(t, ok)
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ociScheme is the scheme prefixing the references to OCI artifacts.
+	ociScheme = "oci://"
+	// ociDefaultTag is the tag of an OCI reference without tag.
+	ociDefaultTag = "latest"
+)
+
+// OCIReference represents a reference to an artifact in an OCI registry, ex.
+// oci://ghcr.io/org/tools:dlv-v1.25.0.
+type OCIReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+// ParseOCIReference parses an OCI reference in the form
+// oci://registry/repository[:tag], the tag defaulting to latest. It returns an
+// error if the reference is not prefixed with oci:// or has no registry or
+// repository.
+func ParseOCIReference(ref string) (OCIReference, error) {
+	errInvalid := fmt.Errorf("invalid OCI reference %q: expected %sregistry/repository[:tag]", ref, ociScheme)
+
+	rest, ok := strings.CutPrefix(ref, ociScheme)
+	if !ok {
+		return OCIReference{}, errInvalid
+	}
+
+	registry, repository, ok := strings.Cut(rest, "/")
+	if !ok || registry == "" || repository == "" {
+		return OCIReference{}, errInvalid
+	}
+
+	tag := ociDefaultTag
+	if i := strings.LastIndex(repository, ":"); i != -1 {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+
+	if repository == "" || tag == "" || repository != strings.ToLower(repository) {
+		return OCIReference{}, errInvalid
+	}
+
+	return OCIReference{
+		Registry:   registry,
+		Repository: repository,
+		Tag:        tag,
+	}, nil
+}
+
+// String returns the string representation of the OCI reference.
+func (r OCIReference) String() string {
+	return ociScheme + r.Registry + "/" + r.Repository + ":" + r.Tag
+}
+
+// OCIArtifact represents a binary stored as the single layer of an artifact in
+// an OCI registry, along with the annotations of its manifest.
+type OCIArtifact struct {
+	Data        []byte
+	Annotations map[string]string
+}
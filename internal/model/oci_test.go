@@ -0,0 +1,78 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	cases := map[string]struct {
+		ref         string
+		expected    model.OCIReference
+		expectedErr error
+	}{
+		"success-tag": {
+			ref: "oci://ghcr.io/org/tools:dlv-v1.25.0",
+			expected: model.OCIReference{
+				Registry:   "ghcr.io",
+				Repository: "org/tools",
+				Tag:        "dlv-v1.25.0",
+			},
+		},
+		"success-default-tag": {
+			ref: "oci://ghcr.io/org/tools",
+			expected: model.OCIReference{
+				Registry:   "ghcr.io",
+				Repository: "org/tools",
+				Tag:        "latest",
+			},
+		},
+		"success-registry-port": {
+			ref: "oci://localhost:5000/tools:dlv",
+			expected: model.OCIReference{
+				Registry:   "localhost:5000",
+				Repository: "tools",
+				Tag:        "dlv",
+			},
+		},
+		"error-missing-scheme": {
+			ref: "ghcr.io/org/tools:dlv",
+			expectedErr: errors.New(
+				`invalid OCI reference "ghcr.io/org/tools:dlv": expected oci://registry/repository[:tag]`,
+			),
+		},
+		"error-missing-repository": {
+			ref:         "oci://ghcr.io",
+			expectedErr: errors.New(`invalid OCI reference "oci://ghcr.io": expected oci://registry/repository[:tag]`),
+		},
+		"error-empty-tag": {
+			ref: "oci://ghcr.io/org/tools:",
+			expectedErr: errors.New(
+				`invalid OCI reference "oci://ghcr.io/org/tools:": expected oci://registry/repository[:tag]`,
+			),
+		},
+		"error-uppercase-repository": {
+			ref: "oci://ghcr.io/Org/tools:dlv",
+			expectedErr: errors.New(
+				`invalid OCI reference "oci://ghcr.io/Org/tools:dlv": expected oci://registry/repository[:tag]`,
+			),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ref, err := model.ParseOCIReference(tc.ref)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestOCIReference_String(t *testing.T) {
+	ref := model.OCIReference{Registry: "ghcr.io", Repository: "org/tools", Tag: "dlv-v1.25.0"}
+	assert.Equal(t, "oci://ghcr.io/org/tools:dlv-v1.25.0", ref.String())
+}
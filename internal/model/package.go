@@ -13,7 +13,8 @@ type Package struct {
 }
 
 // NewPackage creates a new package from a package version string. If the
-// package does not contain a version, it defaults to "latest".
+// package does not contain a version, it defaults to "latest". The package path
+// is normalized, lowercasing the host and removing extra slashes.
 func NewPackage(pkg string) Package {
 	//nolint:goconst,nolintlint
 	path, version := pkg, "latest"
@@ -24,15 +25,43 @@ func NewPackage(pkg string) Package {
 	}
 
 	return Package{
-		Path:    path,
+		Path:    normalizePath(path),
 		Version: NewVersion(version),
 	}
 }
 
+// NewPackages creates packages from a package version string, expanding a
+// brace group in the path into one package per comma separated element, ex.
+// "example.com/org/repo/{cmd/a,cmd/b}@v1.2.3". All packages share the same
+// version. If the path contains no brace group, a single package is returned.
+func NewPackages(pkg string) []Package {
+	path, version, found := strings.Cut(pkg, "@")
+	if found {
+		version = "@" + version
+	}
+
+	start := strings.Index(path, "{")
+	end := strings.LastIndex(path, "}")
+	if start == -1 || end < start {
+		return []Package{NewPackage(pkg)}
+	}
+
+	prefix, group, suffix := path[:start], path[start+1:end], path[end+1:]
+	elems := strings.Split(group, ",")
+
+	packages := make([]Package, len(elems))
+	for i, elem := range elems {
+		packages[i] = NewPackage(prefix + elem + suffix + version)
+	}
+
+	return packages
+}
+
 // NewPackageWithVersion creates a new package with the given path and version.
+// The package path is normalized.
 func NewPackageWithVersion(path string, version Version) Package {
 	return Package{
-		Path:    path,
+		Path:    normalizePath(path),
 		Version: version,
 	}
 }
@@ -50,10 +79,15 @@ func (p Package) GetBinaryName() string {
 	return binName
 }
 
+// IsRef checks if the package version is a branch or tag ref.
+func (p Package) IsRef() bool {
+	return p.Version.IsRef()
+}
+
 // IsValid checks if the package is valid. A package is valid if it has a
-// non-empty path and a valid version.
+// non-empty path and a valid version or a branch or tag ref.
 func (p Package) IsValid() bool {
-	return strings.TrimSpace(p.Path) != "" && p.Version.IsValid()
+	return strings.TrimSpace(p.Path) != "" && (p.Version.IsValid() || p.Version.IsRef())
 }
 
 // String returns the string representation of the package.
@@ -0,0 +1,11 @@
+package model
+
+// PackageInfo represents the information for a package at a given version,
+// containing the module providing it, whether the package exists in the module
+// and is a main package, and the main packages available in the module.
+type PackageInfo struct {
+	Module       Module
+	Exists       bool
+	IsMain       bool
+	MainPackages []string
+}
@@ -0,0 +1,10 @@
+package model
+
+// PackageSearchResult represents a package found searching the package index,
+// with its import path, synopsis and whether it is a command, ie. a main package
+// installable as a binary.
+type PackageSearchResult struct {
+	Path      string `json:"path"`
+	Synopsis  string `json:"synopsis"`
+	IsCommand bool   `json:"is_command"`
+}
@@ -27,6 +27,20 @@ func TestNewPackage(t *testing.T) {
 				model.NewVersion("v1.2.3"),
 			),
 		},
+		"with-host-case": {
+			pkg: "Example.COM/MockOrg/MockProj@v1.2.3",
+			expected: model.Package{
+				Path:    "example.com/MockOrg/MockProj",
+				Version: model.NewVersion("v1.2.3"),
+			},
+		},
+		"with-extra-slashes": {
+			pkg: " example.com//mockorg/mockproj/@v1.2.3",
+			expected: model.Package{
+				Path:    "example.com/mockorg/mockproj",
+				Version: model.NewVersion("v1.2.3"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -37,6 +51,54 @@ func TestNewPackage(t *testing.T) {
 	}
 }
 
+func TestNewPackages(t *testing.T) {
+	cases := map[string]struct {
+		pkg      string
+		expected []model.Package
+	}{
+		"single": {
+			pkg: "example.com/mockorg/mockproj@v1.2.3",
+			expected: []model.Package{
+				model.NewPackage("example.com/mockorg/mockproj@v1.2.3"),
+			},
+		},
+		"brace-group": {
+			pkg: "example.com/mockorg/mockproj/{cmd/mockproj,cmd/mockproj2}@v1.2.3",
+			expected: []model.Package{
+				model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.2.3"),
+				model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj2@v1.2.3"),
+			},
+		},
+		"brace-group-latest": {
+			pkg: "example.com/mockorg/mockproj/cmd/{mockproj,mockproj2}",
+			expected: []model.Package{
+				model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj"),
+				model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj2"),
+			},
+		},
+		"brace-group-with-root": {
+			pkg: "example.com/mockorg/mockproj/{,cmd/mockproj2}@v1.2.3",
+			expected: []model.Package{
+				model.NewPackage("example.com/mockorg/mockproj@v1.2.3"),
+				model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj2@v1.2.3"),
+			},
+		},
+		"unclosed-brace": {
+			pkg: "example.com/mockorg/mockproj/{cmd/mockproj@v1.2.3",
+			expected: []model.Package{
+				model.NewPackage("example.com/mockorg/mockproj/{cmd/mockproj@v1.2.3"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := model.NewPackages(tc.pkg)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestPackage_GetBinaryName(t *testing.T) {
 	cases := map[string]struct {
 		pkg      string
@@ -81,10 +143,18 @@ func TestPackage_IsValid(t *testing.T) {
 			pkg:      "",
 			expected: false,
 		},
+		"valid-with-ref": {
+			pkg:      "example.com/mockorg/mockproj@feature/x",
+			expected: true,
+		},
 		"invalid-version": {
 			pkg:      "example.com/mockorg/mockproj@1.2.3",
 			expected: false,
 		},
+		"invalid-ref": {
+			pkg:      "example.com/mockorg/mockproj@feature x",
+			expected: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -95,6 +165,33 @@ func TestPackage_IsValid(t *testing.T) {
 	}
 }
 
+func TestPackage_IsRef(t *testing.T) {
+	cases := map[string]struct {
+		pkg      string
+		expected bool
+	}{
+		"branch": {
+			pkg:      "example.com/mockorg/mockproj@main",
+			expected: true,
+		},
+		"version": {
+			pkg:      "example.com/mockorg/mockproj@v1.2.3",
+			expected: false,
+		},
+		"latest": {
+			pkg:      "example.com/mockorg/mockproj",
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := model.NewPackage(tc.pkg).IsRef()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestPackage_String(t *testing.T) {
 	cases := map[string]struct {
 		pkg      string
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PinPlacement is the placement of the version in the name of a pin.
+type PinPlacement string
+
+const (
+	// PinPlacementSuffix places the version after the binary name, ex.
+	// "gobin-v1.2".
+	PinPlacementSuffix PinPlacement = "suffix"
+	// PinPlacementPrefix places the version before the binary name, ex.
+	// "v1.2-gobin".
+	PinPlacementPrefix PinPlacement = "prefix"
+
+	// defaultPinSeparator is the default separator between the binary name and
+	// the version of a pin.
+	defaultPinSeparator = "-"
+	// reservedPinChars are the characters not allowed in a pin separator, as
+	// they are used in internal binary names, versions and extensions.
+	reservedPinChars = "@."
+	// illegalFileNameChars are the characters not allowed in file names.
+	illegalFileNameChars = "/\x00"
+	// illegalWindowsFileNameChars are the characters not allowed in file names
+	// on Windows.
+	illegalWindowsFileNameChars = `<>:"/\|?*` + "\x00"
+)
+
+// allowedPinPlacements is a list of allowed pin placements.
+//
+//nolint:gochecknoglobals // global variable to define allowed pin placements
+var allowedPinPlacements = []PinPlacement{
+	PinPlacementSuffix,
+	PinPlacementPrefix,
+}
+
+// PinFormat represents the format of the names of the pins with a version. It
+// defines the separator between the binary name and the version, and where the
+// version is placed. The binary extension is always kept at the end.
+type PinFormat struct {
+	Separator string
+	Placement PinPlacement
+}
+
+// NewPinFormat creates a new pin format with the given separator and placement.
+func NewPinFormat(separator string, placement PinPlacement) PinFormat {
+	return PinFormat{
+		Separator: separator,
+		Placement: PinPlacement(strings.ToLower(strings.TrimSpace(string(placement)))),
+	}
+}
+
+// NewDefaultPinFormat creates a new pin format with the version placed after
+// the binary name and separated by "-", ex. "gobin-v1.2".
+func NewDefaultPinFormat() PinFormat {
+	return NewPinFormat(defaultPinSeparator, PinPlacementSuffix)
+}
+
+// Format returns the name of the pin for the given binary name, version and
+// extension.
+func (f PinFormat) Format(name string, version Version, extension string) string {
+	if f.Placement == PinPlacementPrefix {
+		return version.String() + f.Separator + name + extension
+	}
+
+	return name + f.Separator + version.String() + extension
+}
+
+// Parse parses the name of a pin, without extension, returning the binary name
+// and the pinned version. If the name does not contain a version, it returns
+// the name unchanged and "latest".
+func (f PinFormat) Parse(name string) (string, Version) {
+	var binName, version string
+	if f.Placement == PinPlacementPrefix {
+		version, binName, _ = strings.Cut(name, f.Separator)
+	} else if idx := strings.LastIndex(name, f.Separator); idx >= 0 {
+		binName, version = name[:idx], name[idx+len(f.Separator):]
+	}
+
+	if v := NewVersion(version); binName != "" && v.Major() != "" {
+		return binName, v
+	}
+
+	return name, NewLatestVersion()
+}
+
+// Validate checks if the pin format is valid for the given operating system.
+// The separator must not be empty, and must not contain characters reserved
+// for internal binary names or characters illegal in file names on the OS.
+func (f PinFormat) Validate(goos string) error {
+	if !slices.Contains(allowedPinPlacements, f.Placement) {
+		return fmt.Errorf(
+			"invalid pin placement %q, allowed values are: %v",
+			f.Placement, allowedPinPlacements,
+		)
+	}
+
+	illegal := illegalFileNameChars
+	if goos == "windows" {
+		illegal = illegalWindowsFileNameChars
+	}
+
+	if f.Separator == "" || strings.ContainsAny(f.Separator, reservedPinChars+illegal) ||
+		strings.ContainsFunc(f.Separator, func(r rune) bool { return r < ' ' }) {
+		return fmt.Errorf("invalid pin separator %q for %s", f.Separator, goos)
+	}
+
+	return nil
+}
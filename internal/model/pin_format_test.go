@@ -0,0 +1,180 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestNewPinFormat(t *testing.T) {
+	cases := map[string]struct {
+		separator string
+		placement model.PinPlacement
+		expected  model.PinFormat
+	}{
+		"suffix": {
+			separator: "-",
+			placement: model.PinPlacementSuffix,
+			expected:  model.PinFormat{Separator: "-", Placement: model.PinPlacementSuffix},
+		},
+		"prefix-with-case-and-spaces": {
+			separator: "_",
+			placement: model.PinPlacement(" Prefix "),
+			expected:  model.PinFormat{Separator: "_", Placement: model.PinPlacementPrefix},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := model.NewPinFormat(tc.separator, tc.placement)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestPinFormat_Format(t *testing.T) {
+	cases := map[string]struct {
+		format    model.PinFormat
+		name      string
+		version   model.Version
+		extension string
+		expected  string
+	}{
+		"default": {
+			format:   model.NewDefaultPinFormat(),
+			name:     "mockproj",
+			version:  model.NewVersion("v1.2"),
+			expected: "mockproj-v1.2",
+		},
+		"suffix-with-extension": {
+			format:    model.NewPinFormat("_", model.PinPlacementSuffix),
+			name:      "mockproj",
+			version:   model.NewVersion("v1"),
+			extension: ".exe",
+			expected:  "mockproj_v1.exe",
+		},
+		"prefix-with-extension": {
+			format:    model.NewPinFormat("_", model.PinPlacementPrefix),
+			name:      "mockproj",
+			version:   model.NewVersion("v1.2"),
+			extension: ".exe",
+			expected:  "v1.2_mockproj.exe",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.format.Format(tc.name, tc.version, tc.extension)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestPinFormat_Parse(t *testing.T) {
+	cases := map[string]struct {
+		format          model.PinFormat
+		name            string
+		expectedName    string
+		expectedVersion model.Version
+	}{
+		"suffix": {
+			format:          model.NewDefaultPinFormat(),
+			name:            "mockproj-test-v1.2",
+			expectedName:    "mockproj-test",
+			expectedVersion: model.NewVersion("v1.2"),
+		},
+		"suffix-without-version": {
+			format:          model.NewDefaultPinFormat(),
+			name:            "mockproj-test",
+			expectedName:    "mockproj-test",
+			expectedVersion: model.NewLatestVersion(),
+		},
+		"suffix-only-version": {
+			format:          model.NewDefaultPinFormat(),
+			name:            "-v1",
+			expectedName:    "-v1",
+			expectedVersion: model.NewLatestVersion(),
+		},
+		"prefix": {
+			format:          model.NewPinFormat("_", model.PinPlacementPrefix),
+			name:            "v1_mockproj_test",
+			expectedName:    "mockproj_test",
+			expectedVersion: model.NewVersion("v1"),
+		},
+		"prefix-without-version": {
+			format:          model.NewPinFormat("_", model.PinPlacementPrefix),
+			name:            "mockproj_test",
+			expectedName:    "mockproj_test",
+			expectedVersion: model.NewLatestVersion(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			binName, version := tc.format.Parse(tc.name)
+			assert.Equal(t, tc.expectedName, binName)
+			assert.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
+
+func TestPinFormat_Validate(t *testing.T) {
+	cases := map[string]struct {
+		format      model.PinFormat
+		goos        string
+		expectedErr error
+	}{
+		"default": {
+			format: model.NewDefaultPinFormat(),
+			goos:   "linux",
+		},
+		"default-windows": {
+			format: model.NewDefaultPinFormat(),
+			goos:   "windows",
+		},
+		"colon-linux": {
+			format: model.NewPinFormat(":", model.PinPlacementSuffix),
+			goos:   "linux",
+		},
+		"colon-windows": {
+			format:      model.NewPinFormat(":", model.PinPlacementSuffix),
+			goos:        "windows",
+			expectedErr: errors.New("invalid pin separator \":\" for windows"),
+		},
+		"empty-separator": {
+			format:      model.NewPinFormat("", model.PinPlacementSuffix),
+			goos:        "linux",
+			expectedErr: errors.New("invalid pin separator \"\" for linux"),
+		},
+		"reserved-separator": {
+			format:      model.NewPinFormat("@", model.PinPlacementSuffix),
+			goos:        "linux",
+			expectedErr: errors.New("invalid pin separator \"@\" for linux"),
+		},
+		"path-separator": {
+			format:      model.NewPinFormat("/", model.PinPlacementSuffix),
+			goos:        "darwin",
+			expectedErr: errors.New("invalid pin separator \"/\" for darwin"),
+		},
+		"control-separator": {
+			format:      model.NewPinFormat("\t", model.PinPlacementSuffix),
+			goos:        "linux",
+			expectedErr: errors.New("invalid pin separator \"\\t\" for linux"),
+		},
+		"invalid-placement": {
+			format:      model.NewPinFormat("-", model.PinPlacement("middle")),
+			goos:        "linux",
+			expectedErr: errors.New("invalid pin placement \"middle\", allowed values are: [suffix prefix]"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.format.Validate(tc.goos)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
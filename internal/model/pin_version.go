@@ -0,0 +1,8 @@
+package model
+
+// PinVersion represents a version of a binary in the internal binary directory
+// a pin can target, and whether the pin targets it.
+type PinVersion struct {
+	Version Version
+	Pinned  bool
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// provenanceLDFlagPrefix is the prefix of the linker flags recording the
+// provenance of a binary built by gobin. They set variables of a package no
+// binary imports, so the linker ignores them, but they are recorded in the
+// -ldflags build setting of the binary, surviving copies to other machines.
+const provenanceLDFlagPrefix = "-X=dev.gobin.provenance."
+
+// Provenance represents the provenance of a binary built by gobin: the gobin
+// version building it, the package spec built, ex.
+// example.com/mockorg/mockproj/cmd/mockproj@latest, and the command requesting
+// the build, ex. install or upgrade.
+type Provenance struct {
+	Builder string
+	Spec    string
+	Source  string
+}
+
+// NewProvenance creates the provenance of the binaries built by the running
+// gobin for the given source command, ex. install. The spec is set when
+// building each package.
+func NewProvenance(source string) Provenance {
+	provenance := Provenance{
+		Builder: "gobin",
+		Source:  source,
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		provenance.Builder += "@" + info.Main.Version
+	}
+
+	return provenance
+}
+
+// LDFlags returns the linker flags recording the provenance, skipping the empty
+// fields.
+func (p Provenance) LDFlags() string {
+	var flags []string
+	for _, field := range []struct{ key, value string }{
+		{"builder", p.Builder},
+		{"spec", p.Spec},
+		{"source", p.Source},
+	} {
+		if field.value != "" {
+			flags = append(flags, provenanceLDFlagPrefix+field.key+"="+field.value)
+		}
+	}
+
+	return strings.Join(flags, " ")
+}
+
+// parseProvenance parses the provenance recorded in the given linker flags. It
+// returns the provenance and the linker flags without the provenance flags, or
+// the linker flags untouched if they record no provenance.
+func parseProvenance(ldflags string) (Provenance, string) {
+	var provenance Provenance
+	var found bool
+
+	fields := strings.Fields(ldflags)
+	others := make([]string, 0, len(fields))
+	for _, field := range fields {
+		key, value, ok := strings.Cut(strings.TrimPrefix(field, provenanceLDFlagPrefix), "=")
+		if !strings.HasPrefix(field, provenanceLDFlagPrefix) || !ok {
+			others = append(others, field)
+			continue
+		}
+
+		found = true
+		switch key {
+		case "builder":
+			provenance.Builder = value
+		case "spec":
+			provenance.Spec = value
+		case "source":
+			provenance.Source = value
+		}
+	}
+
+	if !found {
+		return Provenance{}, ldflags
+	}
+
+	return provenance, strings.Join(others, " ")
+}
@@ -0,0 +1,46 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestNewProvenance(t *testing.T) {
+	provenance := model.NewProvenance("install")
+	assert.True(t, strings.HasPrefix(provenance.Builder, "gobin"))
+	assert.Empty(t, provenance.Spec)
+	assert.Equal(t, "install", provenance.Source)
+}
+
+func TestProvenance_LDFlags(t *testing.T) {
+	cases := map[string]struct {
+		provenance model.Provenance
+		expected   string
+	}{
+		"empty": {},
+		"all-fields": {
+			provenance: model.Provenance{
+				Builder: "gobin@v1.0.0",
+				Spec:    "example.com/mockorg/mockproj/cmd/mockproj@latest",
+				Source:  "install",
+			},
+			expected: "-X=dev.gobin.provenance.builder=gobin@v1.0.0 " +
+				"-X=dev.gobin.provenance.spec=example.com/mockorg/mockproj/cmd/mockproj@latest " +
+				"-X=dev.gobin.provenance.source=install",
+		},
+		"no-spec": {
+			provenance: model.Provenance{Builder: "gobin@v1.0.0", Source: "upgrade"},
+			expected:   "-X=dev.gobin.provenance.builder=gobin@v1.0.0 -X=dev.gobin.provenance.source=upgrade",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.provenance.LDFlags())
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// ProxyHealth represents the health of a module proxy. Latency is the average
+// latency of the successful probes, Errors the number of failed probes and
+// Unauthorized whether the module proxy rejected the credentials.
+type ProxyHealth struct {
+	Proxy        string
+	Probes       int
+	Errors       int
+	Latency      time.Duration
+	Unauthorized bool
+}
+
+// IsDown returns whether all the probes to the module proxy failed.
+func (h ProxyHealth) IsDown() bool {
+	return h.Probes > 0 && h.Errors == h.Probes
+}
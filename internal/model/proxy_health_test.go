@@ -0,0 +1,40 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestProxyHealth_IsDown(t *testing.T) {
+	cases := map[string]struct {
+		health   model.ProxyHealth
+		expected bool
+	}{
+		"up": {
+			health:   model.ProxyHealth{Probes: 3, Errors: 0},
+			expected: false,
+		},
+		"degraded": {
+			health:   model.ProxyHealth{Probes: 3, Errors: 2},
+			expected: false,
+		},
+		"down": {
+			health:   model.ProxyHealth{Probes: 3, Errors: 3},
+			expected: true,
+		},
+		"not-probed": {
+			health:   model.ProxyHealth{},
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.health.IsDown()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
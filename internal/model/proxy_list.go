@@ -0,0 +1,97 @@
+package model
+
+import (
+	"slices"
+	"strings"
+)
+
+// ProxyList represents the GOPROXY list of module proxies, separated by commas
+// or pipes. After a comma, the next proxy is only tried when the module is not
+// found, while after a pipe it is tried on any error.
+type ProxyList string
+
+// proxyListEntry represents a module proxy in a list and the separator
+// following it.
+type proxyListEntry struct {
+	proxy     string
+	separator string
+}
+
+// FallsBackOnError returns whether the module proxy following the given one in
+// the list is tried on any error, rather than only when the module is not
+// found, that is whether the given proxy is followed by a pipe.
+func (l ProxyList) FallsBackOnError(proxy string) bool {
+	for _, entry := range l.entries() {
+		if entry.proxy == proxy {
+			return entry.separator == "|"
+		}
+	}
+
+	return false
+}
+
+// GetProxies returns the module proxies in the list, in the order they are
+// tried, skipping "off".
+func (l ProxyList) GetProxies() []string {
+	var proxies []string
+	for _, entry := range l.entries() {
+		if entry.proxy != "off" {
+			proxies = append(proxies, entry.proxy)
+		}
+	}
+
+	return proxies
+}
+
+// GetProxyForURL returns the module proxy in the list serving the given URL.
+// It returns false if no module proxy serves the URL.
+func (l ProxyList) GetProxyForURL(url string) (string, bool) {
+	for _, proxy := range l.GetProxies() {
+		if base := strings.TrimSuffix(proxy, "/"); strings.HasPrefix(url, base+"/") {
+			return proxy, true
+		}
+	}
+
+	return "", false
+}
+
+// Without returns the list without the given module proxies, keeping the
+// separators of the remaining ones. It returns false if no module proxy
+// remains in the list.
+func (l ProxyList) Without(proxies ...string) (ProxyList, bool) {
+	var b strings.Builder
+	for _, entry := range l.entries() {
+		if slices.Contains(proxies, entry.proxy) {
+			continue
+		}
+
+		b.WriteString(entry.proxy)
+		b.WriteString(entry.separator)
+	}
+
+	list := strings.TrimRight(b.String(), ",|")
+	return ProxyList(list), list != ""
+}
+
+// entries returns the entries of the list. The last entry is always followed by
+// a comma.
+func (l ProxyList) entries() []proxyListEntry {
+	var entries []proxyListEntry
+
+	list := strings.TrimSpace(string(l))
+	for list != "" {
+		idx := strings.IndexAny(list, ",|")
+		proxy, separator := list, ","
+		if idx >= 0 {
+			proxy, separator, list = list[:idx], list[idx:idx+1], list[idx+1:]
+		} else {
+			list = ""
+		}
+
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			entries = append(entries, proxyListEntry{proxy: proxy, separator: separator})
+		}
+	}
+
+	return entries
+}
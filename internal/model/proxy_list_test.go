@@ -0,0 +1,147 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestProxyList_FallsBackOnError(t *testing.T) {
+	list := model.ProxyList("https://proxy.example.com|https://proxy.golang.org,direct")
+
+	cases := map[string]struct {
+		proxy    string
+		expected bool
+	}{
+		"followed-by-pipe": {
+			proxy:    "https://proxy.example.com",
+			expected: true,
+		},
+		"followed-by-comma": {
+			proxy:    "https://proxy.golang.org",
+			expected: false,
+		},
+		"last-proxy": {
+			proxy:    "direct",
+			expected: false,
+		},
+		"unknown-proxy": {
+			proxy:    "https://proxy.unknown.com",
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := list.FallsBackOnError(tc.proxy)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestProxyList_GetProxies(t *testing.T) {
+	cases := map[string]struct {
+		list     model.ProxyList
+		expected []string
+	}{
+		"single": {
+			list:     model.ProxyList("https://proxy.golang.org"),
+			expected: []string{"https://proxy.golang.org"},
+		},
+		"multiple": {
+			list:     model.ProxyList("https://proxy.example.com, https://proxy.golang.org|direct"),
+			expected: []string{"https://proxy.example.com", "https://proxy.golang.org", "direct"},
+		},
+		"off": {
+			list: model.ProxyList("off"),
+		},
+		"empty": {
+			list: model.ProxyList(""),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.list.GetProxies()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestProxyList_GetProxyForURL(t *testing.T) {
+	list := model.ProxyList("https://proxy.example.com/go/,https://proxy.golang.org|direct")
+
+	cases := map[string]struct {
+		url           string
+		expected      string
+		expectedFound bool
+	}{
+		"first-proxy": {
+			url:           "https://proxy.example.com/go/example.com/mockorg/mockproj/@v/list",
+			expected:      "https://proxy.example.com/go/",
+			expectedFound: true,
+		},
+		"second-proxy": {
+			url:           "https://proxy.golang.org/example.com/mockorg/mockproj/@v/list",
+			expected:      "https://proxy.golang.org",
+			expectedFound: true,
+		},
+		"no-proxy": {
+			url: "https://github.com/mockorg/mockproj",
+		},
+		"host-prefix": {
+			url: "https://proxy.golang.org.example.com/example.com/mockorg/mockproj/@v/list",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result, found := list.GetProxyForURL(tc.url)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
+
+func TestProxyList_Without(t *testing.T) {
+	cases := map[string]struct {
+		list          model.ProxyList
+		proxies       []string
+		expected      model.ProxyList
+		expectedFound bool
+	}{
+		"first-proxy": {
+			list:          model.ProxyList("https://proxy.example.com,https://proxy.golang.org|direct"),
+			proxies:       []string{"https://proxy.example.com"},
+			expected:      model.ProxyList("https://proxy.golang.org|direct"),
+			expectedFound: true,
+		},
+		"middle-proxy": {
+			list:          model.ProxyList("https://proxy.example.com,https://proxy.golang.org|direct"),
+			proxies:       []string{"https://proxy.golang.org"},
+			expected:      model.ProxyList("https://proxy.example.com,direct"),
+			expectedFound: true,
+		},
+		"last-proxy": {
+			list:          model.ProxyList("https://proxy.example.com|https://proxy.golang.org"),
+			proxies:       []string{"https://proxy.golang.org"},
+			expected:      model.ProxyList("https://proxy.example.com"),
+			expectedFound: true,
+		},
+		"all-proxies": {
+			list:     model.ProxyList("https://proxy.example.com,https://proxy.golang.org"),
+			proxies:  []string{"https://proxy.example.com", "https://proxy.golang.org"},
+			expected: model.ProxyList(""),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result, found := tc.list.Without(tc.proxies...)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
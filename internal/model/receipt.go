@@ -0,0 +1,45 @@
+package model
+
+// Compression represents the sizes, in bytes, of a binary before and after its
+// compression with UPX.
+type Compression struct {
+	OriginalSize   ByteSize `json:"original_size"`
+	CompressedSize ByteSize `json:"compressed_size"`
+}
+
+// Signature represents the signature of a binary, signed with the identity of
+// the operating system it was installed on. File is the detached signature, only
+// recorded for the binaries signed with GPG.
+type Signature struct {
+	Identity string `json:"identity"`
+	File     string `json:"file,omitempty"`
+}
+
+// Receipt represents the metadata recorded by gobin for a binary pinned to the
+// Go binary directory. Target is the internal binary copied to the pin, only
+// recorded when pinning with copies instead of symlinks. Compression is only
+// recorded for binaries compressed with UPX. Source is the local directory the
+// binary is built from, only recorded for binaries linked from a local package.
+// Env is the environment variables, in the form "name=value", set by the exec
+// shim of the binary before running it. SizeHistory is the size of each version
+// installed to the pin.
+type Receipt struct {
+	Name            string      `json:"name"`
+	Protected       bool        `json:"protected,omitempty"`
+	MigratedFrom    string      `json:"migrated_from,omitempty"`
+	BuildFlags      BuildFlags  `json:"build_flags,omitzero"`
+	PreviousVersion Version     `json:"previous_version,omitempty"`
+	Target          string      `json:"target,omitempty"`
+	Compression     Compression `json:"compression,omitzero"`
+	Signature       Signature   `json:"signature,omitzero"`
+	Source          string      `json:"source,omitempty"`
+	Env             []string    `json:"env,omitempty"`
+	SizeHistory     SizeHistory `json:"size_history,omitempty"`
+}
+
+// NewReceipt creates a new receipt for the given pin name.
+func NewReceipt(name string) Receipt {
+	return Receipt{
+		Name: name,
+	}
+}
@@ -0,0 +1,292 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SBOMFormat is a software bill of materials format supported by the sbom
+// command.
+type SBOMFormat string
+
+const (
+	// SBOMFormatCycloneDX is the CycloneDX 1.5 JSON format.
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+	// SBOMFormatSPDX is the SPDX 2.3 JSON format.
+	SBOMFormatSPDX SBOMFormat = "spdx"
+)
+
+// allowedSBOMFormats is a list of allowed SBOM formats.
+//
+//nolint:gochecknoglobals // global variable to define allowed SBOM formats
+var allowedSBOMFormats = []SBOMFormat{
+	SBOMFormatCycloneDX,
+	SBOMFormatSPDX,
+}
+
+// NewSBOMFormat creates a new SBOM format from a string. It returns an error if
+// the format is not supported.
+func NewSBOMFormat(value string) (SBOMFormat, error) {
+	format := SBOMFormat(strings.ToLower(value))
+	if !format.IsValid() {
+		return "", fmt.Errorf("invalid SBOM format %q, allowed values are: %v", value, allowedSBOMFormats)
+	}
+
+	return format, nil
+}
+
+// IsValid checks if the SBOM format is valid.
+func (f SBOMFormat) IsValid() bool {
+	return slices.Contains(allowedSBOMFormats, f)
+}
+
+// String returns the string representation of the SBOM format.
+func (f SBOMFormat) String() string {
+	return string(f)
+}
+
+// SBOMComponent represents a module compiled into a binary, as listed in its
+// build info: the module path, version and checksum, if any.
+type SBOMComponent struct {
+	Path    string
+	Version string
+	Sum     string
+}
+
+// GetPURL returns the package URL of the component, ex.
+// "pkg:golang/golang.org/x/mod@v0.27.0".
+func (c SBOMComponent) GetPURL() string {
+	if c.Version == "" {
+		return "pkg:golang/" + c.Path
+	}
+
+	return "pkg:golang/" + c.Path + "@" + c.Version
+}
+
+// SBOM represents the software bill of materials of a binary: its name, the
+// Go version it is built with, its main module and the modules it depends on,
+// sorted by path.
+type SBOM struct {
+	Name       string
+	GoVersion  string
+	Main       SBOMComponent
+	Components []SBOMComponent
+}
+
+// Marshal encodes the SBOM as a JSON document in the given format, created at
+// the given time. It returns an error if the format is not supported.
+func (s SBOM) Marshal(format SBOMFormat, created time.Time) ([]byte, error) {
+	var doc any
+	switch format {
+	case SBOMFormatCycloneDX:
+		doc = s.toCycloneDX(created)
+	case SBOMFormatSPDX:
+		doc = s.toSPDX(created)
+	default:
+		return nil, fmt.Errorf("invalid SBOM format %q, allowed values are: %v", format, allowedSBOMFormats)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// cycloneDXDocument is a CycloneDX 1.5 JSON document.
+type cycloneDXDocument struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	Version      int                   `json:"version"`
+	Metadata     cycloneDXMetadata     `json:"metadata"`
+	Components   []cycloneDXComponent  `json:"components"`
+	Dependencies []cycloneDXDependency `json:"dependencies"`
+}
+
+// cycloneDXMetadata is the metadata of a CycloneDX document.
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     cycloneDXTools     `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+// cycloneDXTools are the tools creating a CycloneDX document.
+type cycloneDXTools struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+// cycloneDXComponent is a component of a CycloneDX document.
+type cycloneDXComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+// cycloneDXProperty is a name-value property of a CycloneDX component.
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// cycloneDXDependency is the dependencies of a component of a CycloneDX
+// document.
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// toCycloneDX converts the SBOM to a CycloneDX document created at the given
+// time, the binary being the main component depending on all the others.
+func (s SBOM) toCycloneDX(created time.Time) cycloneDXDocument {
+	main := newCycloneDXComponent("application", s.Main)
+	main.Properties = append(main.Properties, cycloneDXProperty{Name: "gobin:binary", Value: s.Name})
+	if s.GoVersion != "" {
+		main.Properties = append(main.Properties, cycloneDXProperty{Name: "gobin:go_version", Value: s.GoVersion})
+	}
+
+	doc := cycloneDXDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: created.UTC().Format(time.RFC3339),
+			Tools: cycloneDXTools{
+				Components: []cycloneDXComponent{{Type: "application", Name: "gobin"}},
+			},
+			Component: main,
+		},
+		Components:   make([]cycloneDXComponent, 0, len(s.Components)),
+		Dependencies: []cycloneDXDependency{{Ref: main.BOMRef, DependsOn: make([]string, 0, len(s.Components))}},
+	}
+
+	for _, c := range s.Components {
+		component := newCycloneDXComponent("library", c)
+		doc.Components = append(doc.Components, component)
+		doc.Dependencies[0].DependsOn = append(doc.Dependencies[0].DependsOn, component.BOMRef)
+	}
+
+	return doc
+}
+
+// newCycloneDXComponent creates a CycloneDX component of the given type for a
+// module, identified by its package URL.
+func newCycloneDXComponent(componentType string, c SBOMComponent) cycloneDXComponent {
+	component := cycloneDXComponent{
+		Type:    componentType,
+		BOMRef:  c.GetPURL(),
+		Name:    c.Path,
+		Version: c.Version,
+		PURL:    c.GetPURL(),
+	}
+
+	if c.Sum != "" {
+		component.Properties = []cycloneDXProperty{{Name: "gobin:module_sum", Value: c.Sum}}
+	}
+
+	return component
+}
+
+// spdxDocument is an SPDX 2.3 JSON document.
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+// spdxCreationInfo is the creation information of an SPDX document.
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+// spdxPackage is a package of an SPDX document.
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	Comment          string            `json:"comment,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+// spdxExternalRef is an external reference of an SPDX package, ex. its package
+// URL.
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// spdxRelationship is a relationship between two elements of an SPDX document.
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// toSPDX converts the SBOM to an SPDX document created at the given time, the
+// document describing the main package, which depends on all the others.
+func (s SBOM) toSPDX(created time.Time) spdxDocument {
+	const mainID = "SPDXRef-Package-0"
+
+	doc := spdxDocument{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        s.Name,
+		DocumentNamespace: fmt.Sprintf(
+			"https://spdx.org/spdxdocs/%s-%s-%d", s.Name, s.Main.Version, created.UnixNano(),
+		),
+		CreationInfo: spdxCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: gobin"},
+		},
+		Packages: make([]spdxPackage, 0, len(s.Components)+1),
+		Relationships: []spdxRelationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: mainID},
+		},
+	}
+
+	main := newSPDXPackage(mainID, s.Main)
+	if s.GoVersion != "" {
+		main.Comment = "built with " + s.GoVersion
+	}
+	doc.Packages = append(doc.Packages, main)
+
+	for i, c := range s.Components {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		doc.Packages = append(doc.Packages, newSPDXPackage(id, c))
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID: mainID, RelationshipType: "DEPENDS_ON", RelatedSPDXElement: id,
+		})
+	}
+
+	return doc
+}
+
+// newSPDXPackage creates an SPDX package with the given identifier for a
+// module, referenced by its package URL.
+func newSPDXPackage(id string, c SBOMComponent) spdxPackage {
+	return spdxPackage{
+		Name:             c.Path,
+		SPDXID:           id,
+		VersionInfo:      c.Version,
+		DownloadLocation: "NOASSERTION",
+		ExternalRefs: []spdxExternalRef{
+			{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: c.GetPURL()},
+		},
+	}
+}
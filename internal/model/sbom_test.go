@@ -0,0 +1,217 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestNewSBOMFormat(t *testing.T) {
+	cases := map[string]struct {
+		value       string
+		expected    model.SBOMFormat
+		expectedErr error
+	}{
+		"cyclonedx": {
+			value:    "cyclonedx",
+			expected: model.SBOMFormatCycloneDX,
+		},
+		"spdx-uppercase": {
+			value:    "SPDX",
+			expected: model.SBOMFormatSPDX,
+		},
+		"invalid": {
+			value:       "syft",
+			expectedErr: errors.New(`invalid SBOM format "syft", allowed values are: [cyclonedx spdx]`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			format, err := model.NewSBOMFormat(tc.value)
+			assert.Equal(t, tc.expected, format)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestSBOMComponent_GetPURL(t *testing.T) {
+	cases := map[string]struct {
+		component model.SBOMComponent
+		expected  string
+	}{
+		"with-version": {
+			component: model.SBOMComponent{Path: "golang.org/x/mod", Version: "v0.27.0"},
+			expected:  "pkg:golang/golang.org/x/mod@v0.27.0",
+		},
+		"without-version": {
+			component: model.SBOMComponent{Path: "example.com/mockorg/local"},
+			expected:  "pkg:golang/example.com/mockorg/local",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.component.GetPURL())
+		})
+	}
+}
+
+func TestSBOM_Marshal(t *testing.T) {
+	sbom := model.SBOM{
+		Name:      "mockproj",
+		GoVersion: "go1.24.5",
+		Main:      model.SBOMComponent{Path: "example.com/mockorg/mockproj", Version: "v0.1.0", Sum: "h1:mocksum="},
+		Components: []model.SBOMComponent{
+			{Path: "golang.org/x/mod", Version: "v0.27.0", Sum: "h1:modsum="},
+		},
+	}
+	created := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		format       model.SBOMFormat
+		expectedData string
+		expectedErr  error
+	}{
+		"cyclonedx": {
+			format: model.SBOMFormatCycloneDX,
+			expectedData: `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {
+    "timestamp": "2025-08-01T10:00:00Z",
+    "tools": {
+      "components": [
+        {
+          "type": "application",
+          "name": "gobin"
+        }
+      ]
+    },
+    "component": {
+      "type": "application",
+      "bom-ref": "pkg:golang/example.com/mockorg/mockproj@v0.1.0",
+      "name": "example.com/mockorg/mockproj",
+      "version": "v0.1.0",
+      "purl": "pkg:golang/example.com/mockorg/mockproj@v0.1.0",
+      "properties": [
+        {
+          "name": "gobin:module_sum",
+          "value": "h1:mocksum="
+        },
+        {
+          "name": "gobin:binary",
+          "value": "mockproj"
+        },
+        {
+          "name": "gobin:go_version",
+          "value": "go1.24.5"
+        }
+      ]
+    }
+  },
+  "components": [
+    {
+      "type": "library",
+      "bom-ref": "pkg:golang/golang.org/x/mod@v0.27.0",
+      "name": "golang.org/x/mod",
+      "version": "v0.27.0",
+      "purl": "pkg:golang/golang.org/x/mod@v0.27.0",
+      "properties": [
+        {
+          "name": "gobin:module_sum",
+          "value": "h1:modsum="
+        }
+      ]
+    }
+  ],
+  "dependencies": [
+    {
+      "ref": "pkg:golang/example.com/mockorg/mockproj@v0.1.0",
+      "dependsOn": [
+        "pkg:golang/golang.org/x/mod@v0.27.0"
+      ]
+    }
+  ]
+}
+`,
+		},
+		"spdx": {
+			format: model.SBOMFormatSPDX,
+			expectedData: `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "mockproj",
+  "documentNamespace": "https://spdx.org/spdxdocs/mockproj-v0.1.0-1754042400000000000",
+  "creationInfo": {
+    "created": "2025-08-01T10:00:00Z",
+    "creators": [
+      "Tool: gobin"
+    ]
+  },
+  "packages": [
+    {
+      "name": "example.com/mockorg/mockproj",
+      "SPDXID": "SPDXRef-Package-0",
+      "versionInfo": "v0.1.0",
+      "downloadLocation": "NOASSERTION",
+      "filesAnalyzed": false,
+      "comment": "built with go1.24.5",
+      "externalRefs": [
+        {
+          "referenceCategory": "PACKAGE-MANAGER",
+          "referenceType": "purl",
+          "referenceLocator": "pkg:golang/example.com/mockorg/mockproj@v0.1.0"
+        }
+      ]
+    },
+    {
+      "name": "golang.org/x/mod",
+      "SPDXID": "SPDXRef-Package-1",
+      "versionInfo": "v0.27.0",
+      "downloadLocation": "NOASSERTION",
+      "filesAnalyzed": false,
+      "externalRefs": [
+        {
+          "referenceCategory": "PACKAGE-MANAGER",
+          "referenceType": "purl",
+          "referenceLocator": "pkg:golang/golang.org/x/mod@v0.27.0"
+        }
+      ]
+    }
+  ],
+  "relationships": [
+    {
+      "spdxElementId": "SPDXRef-DOCUMENT",
+      "relationshipType": "DESCRIBES",
+      "relatedSpdxElement": "SPDXRef-Package-0"
+    },
+    {
+      "spdxElementId": "SPDXRef-Package-0",
+      "relationshipType": "DEPENDS_ON",
+      "relatedSpdxElement": "SPDXRef-Package-1"
+    }
+  ]
+}
+`,
+		},
+		"error-invalid-format": {
+			format:      model.SBOMFormat("syft"),
+			expectedErr: errors.New(`invalid SBOM format "syft", allowed values are: [cyclonedx spdx]`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := sbom.Marshal(tc.format, created)
+			assert.Equal(t, tc.expectedData, string(data))
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
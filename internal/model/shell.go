@@ -0,0 +1,62 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Shell is a shell supported by the init command.
+type Shell string
+
+const (
+	// ShellBash is the bash shell.
+	ShellBash Shell = "bash"
+	// ShellFish is the fish shell.
+	ShellFish Shell = "fish"
+	// ShellPowerShell is the PowerShell shell.
+	ShellPowerShell Shell = "powershell"
+	// ShellZsh is the zsh shell.
+	ShellZsh Shell = "zsh"
+)
+
+// allowedShells is a list of allowed shells.
+//
+//nolint:gochecknoglobals // global variable to define allowed shells
+var allowedShells = []Shell{
+	ShellBash,
+	ShellFish,
+	ShellPowerShell,
+	ShellZsh,
+}
+
+// NewShell creates a new shell from a string. It returns an error if the shell
+// is not supported.
+func NewShell(value string) (Shell, error) {
+	shell := Shell(strings.ToLower(value))
+	if !shell.IsValid() {
+		return "", fmt.Errorf("invalid shell %q, allowed values are: %v", value, allowedShells)
+	}
+
+	return shell, nil
+}
+
+// GetShells returns the supported shells.
+func GetShells() []string {
+	shells := make([]string, 0, len(allowedShells))
+	for _, shell := range allowedShells {
+		shells = append(shells, string(shell))
+	}
+
+	return shells
+}
+
+// IsValid checks if the shell is valid.
+func (s Shell) IsValid() bool {
+	return slices.Contains(allowedShells, s)
+}
+
+// String returns the string representation of the shell.
+func (s Shell) String() string {
+	return string(s)
+}
@@ -0,0 +1,43 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestNewShell(t *testing.T) {
+	cases := map[string]struct {
+		value       string
+		expected    model.Shell
+		expectedErr error
+	}{
+		"bash": {
+			value:    "bash",
+			expected: model.ShellBash,
+		},
+		"powershell-uppercase": {
+			value:    "PowerShell",
+			expected: model.ShellPowerShell,
+		},
+		"invalid": {
+			value:       "tcsh",
+			expectedErr: errors.New(`invalid shell "tcsh", allowed values are: [bash fish powershell zsh]`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			shell, err := model.NewShell(tc.value)
+			assert.Equal(t, tc.expected, shell)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGetShells(t *testing.T) {
+	assert.Equal(t, []string{"bash", "fish", "powershell", "zsh"}, model.GetShells())
+}
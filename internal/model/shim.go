@@ -0,0 +1,54 @@
+package model
+
+import (
+	"strings"
+)
+
+// windowsShimExtension is the extension of the exec shims on Windows.
+const windowsShimExtension = ".cmd"
+
+// GetShimName returns the name of the exec shim of the binary with the given
+// name on the given operating system, a batch file on Windows or the binary
+// name otherwise.
+func GetShimName(goos, name string) string {
+	if goos == "windows" {
+		return strings.TrimSuffix(name, GetBinaryExtension(goos)) + windowsShimExtension
+	}
+
+	return name
+}
+
+// GetShimScript returns the exec shim of the binary at the given path on the
+// given operating system, setting the given environment variables, in the form
+// "name=value", before running the binary with the arguments of the shim: a
+// batch file on Windows or a POSIX shell script otherwise.
+func GetShimScript(goos, path string, envVars []string) []byte {
+	var script strings.Builder
+
+	if goos == "windows" {
+		script.WriteString("@echo off\r\n")
+		script.WriteString("rem Code generated by gobin env set; DO NOT EDIT.\r\n")
+		for _, envVar := range envVars {
+			script.WriteString(`set "` + strings.ReplaceAll(envVar, "%", "%%") + "\"\r\n")
+		}
+		script.WriteString(`"` + path + "\" %*\r\n")
+		script.WriteString("exit /b %ERRORLEVEL%\r\n")
+
+		return []byte(script.String())
+	}
+
+	script.WriteString("#!/bin/sh\n")
+	script.WriteString("# Code generated by gobin env set; DO NOT EDIT.\n")
+	for _, envVar := range envVars {
+		name, value, _ := strings.Cut(envVar, "=")
+		script.WriteString("export " + name + "=" + shellQuote(value) + "\n")
+	}
+	script.WriteString("exec " + shellQuote(path) + " \"$@\"\n")
+
+	return []byte(script.String())
+}
+
+// shellQuote quotes the given value for a POSIX shell with single quotes.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,71 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestGetShimName(t *testing.T) {
+	cases := map[string]struct {
+		goos     string
+		name     string
+		expected string
+	}{
+		"linux": {
+			goos:     "linux",
+			name:     "mockproj",
+			expected: "mockproj",
+		},
+		"windows": {
+			goos:     "windows",
+			name:     "mockproj.exe",
+			expected: "mockproj.cmd",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, model.GetShimName(tc.goos, tc.name))
+		})
+	}
+}
+
+func TestGetShimScript(t *testing.T) {
+	cases := map[string]struct {
+		goos     string
+		path     string
+		envVars  []string
+		expected string
+	}{
+		"linux": {
+			goos:    "linux",
+			path:    "/home/user/go/bin/mockproj",
+			envVars: []string{"GOMEMLIMIT=1GiB", "TOOL_CONFIG=it's a config"},
+			expected: "#!/bin/sh\n" +
+				"# Code generated by gobin env set; DO NOT EDIT.\n" +
+				"export GOMEMLIMIT='1GiB'\n" +
+				"export TOOL_CONFIG='it'\\''s a config'\n" +
+				"exec '/home/user/go/bin/mockproj' \"$@\"\n",
+		},
+		"windows": {
+			goos:    "windows",
+			path:    `C:\Users\user\go\bin\mockproj.exe`,
+			envVars: []string{"GOMEMLIMIT=1GiB", "TOOL_CONFIG=100%"},
+			expected: "@echo off\r\n" +
+				"rem Code generated by gobin env set; DO NOT EDIT.\r\n" +
+				"set \"GOMEMLIMIT=1GiB\"\r\n" +
+				"set \"TOOL_CONFIG=100%%\"\r\n" +
+				"\"C:\\Users\\user\\go\\bin\\mockproj.exe\" %*\r\n" +
+				"exit /b %ERRORLEVEL%\r\n",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, string(model.GetShimScript(tc.goos, tc.path, tc.envVars)))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"strings"
+)
+
+// maxSizeHistory is the maximum number of sizes recorded in the size history of
+// a binary, dropping the oldest ones.
+const maxSizeHistory = 50
+
+// sparklineBars are the bars of a sparkline, from the lowest to the highest.
+const sparklineBars = "▁▂▃▄▅▆▇█"
+
+// SizeRecord represents the size of a binary installed at a version.
+type SizeRecord struct {
+	Version Version  `json:"version"`
+	Size    ByteSize `json:"size"`
+}
+
+// SizeHistory represents the sizes of the versions of a binary, in the order
+// they were installed.
+type SizeHistory []SizeRecord
+
+// Record returns the history with the size of the given version appended. A
+// size recorded for the same version as the last one replaces it, ex. when
+// rebuilding the binary, and the oldest sizes are dropped beyond the maximum
+// history length.
+func (h SizeHistory) Record(version Version, size ByteSize) SizeHistory {
+	record := SizeRecord{Version: version, Size: size}
+
+	history := append(SizeHistory{}, h...)
+	if len(history) > 0 && history[len(history)-1].Version == version {
+		history[len(history)-1] = record
+	} else {
+		history = append(history, record)
+	}
+
+	if len(history) > maxSizeHistory {
+		history = history[len(history)-maxSizeHistory:]
+	}
+
+	return history
+}
+
+// Sparkline returns the sizes of the history as a sparkline, one bar per size
+// scaled between the smallest and largest sizes.
+func (h SizeHistory) Sparkline() string {
+	if len(h) == 0 {
+		return ""
+	}
+
+	minSize, maxSize := h[0].Size, h[0].Size
+	for _, record := range h[1:] {
+		minSize = min(minSize, record.Size)
+		maxSize = max(maxSize, record.Size)
+	}
+
+	bars := []rune(sparklineBars)
+
+	var sb strings.Builder
+	for _, record := range h {
+		idx := 0
+		if maxSize > minSize {
+			idx = int(int64(record.Size-minSize) * int64(len(bars)-1) / int64(maxSize-minSize))
+		}
+
+		sb.WriteRune(bars[idx])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,117 @@
+package model_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestSizeHistory_Record(t *testing.T) {
+	full := make(model.SizeHistory, 50)
+	for i := range full {
+		full[i] = model.SizeRecord{Version: model.NewVersion(fmt.Sprintf("v1.0.%d", i)), Size: model.ByteSize(i)}
+	}
+
+	cases := map[string]struct {
+		history  model.SizeHistory
+		version  model.Version
+		size     model.ByteSize
+		expected model.SizeHistory
+	}{
+		"empty": {
+			version: model.NewVersion("v1.0.0"),
+			size:    1000,
+			expected: model.SizeHistory{
+				{Version: model.NewVersion("v1.0.0"), Size: 1000},
+			},
+		},
+		"new-version": {
+			history: model.SizeHistory{
+				{Version: model.NewVersion("v1.0.0"), Size: 1000},
+			},
+			version: model.NewVersion("v1.1.0"),
+			size:    1200,
+			expected: model.SizeHistory{
+				{Version: model.NewVersion("v1.0.0"), Size: 1000},
+				{Version: model.NewVersion("v1.1.0"), Size: 1200},
+			},
+		},
+		"same-version": {
+			history: model.SizeHistory{
+				{Version: model.NewVersion("v1.0.0"), Size: 1000},
+				{Version: model.NewVersion("v1.1.0"), Size: 1200},
+			},
+			version: model.NewVersion("v1.1.0"),
+			size:    800,
+			expected: model.SizeHistory{
+				{Version: model.NewVersion("v1.0.0"), Size: 1000},
+				{Version: model.NewVersion("v1.1.0"), Size: 800},
+			},
+		},
+		"previous-version": {
+			history: model.SizeHistory{
+				{Version: model.NewVersion("v1.0.0"), Size: 1000},
+				{Version: model.NewVersion("v1.1.0"), Size: 1200},
+			},
+			version: model.NewVersion("v1.0.0"),
+			size:    1000,
+			expected: model.SizeHistory{
+				{Version: model.NewVersion("v1.0.0"), Size: 1000},
+				{Version: model.NewVersion("v1.1.0"), Size: 1200},
+				{Version: model.NewVersion("v1.0.0"), Size: 1000},
+			},
+		},
+		"drop-oldest": {
+			history:  full,
+			version:  model.NewVersion("v2.0.0"),
+			size:     100,
+			expected: append(append(model.SizeHistory{}, full[1:]...), model.SizeRecord{Version: "v2.0.0", Size: 100}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.history.Record(tc.version, tc.size))
+		})
+	}
+}
+
+func TestSizeHistory_Sparkline(t *testing.T) {
+	cases := map[string]struct {
+		history  model.SizeHistory
+		expected string
+	}{
+		"empty": {
+			expected: "",
+		},
+		"single": {
+			history:  model.SizeHistory{{Version: "v1.0.0", Size: 1000}},
+			expected: "▁",
+		},
+		"same-size": {
+			history: model.SizeHistory{
+				{Version: "v1.0.0", Size: 1000},
+				{Version: "v1.1.0", Size: 1000},
+			},
+			expected: "▁▁",
+		},
+		"growth": {
+			history: model.SizeHistory{
+				{Version: "v1.0.0", Size: 1000},
+				{Version: "v1.1.0", Size: 1350},
+				{Version: "v1.2.0", Size: 1700},
+				{Version: "v1.3.0", Size: 1200},
+			},
+			expected: "▁▄█▃",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.history.Sparkline())
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package model
+
+// BinaryUsage represents the disk usage of the versions of a binary in the
+// internal binary directory: the number of versions and their size, and the
+// number and size of the stale versions, ie. not targeted by any pin, removed
+// by the prune command.
+type BinaryUsage struct {
+	Name          string
+	Versions      int
+	Size          ByteSize
+	StaleVersions int
+	StaleSize     ByteSize
+}
+
+// StoreUsage represents the disk usage of the internal binary directory, per
+// binary.
+type StoreUsage []BinaryUsage
+
+// GetSize returns the total size of the binaries in the internal binary
+// directory.
+func (u StoreUsage) GetSize() ByteSize {
+	var size ByteSize
+	for _, usage := range u {
+		size += usage.Size
+	}
+
+	return size
+}
+
+// GetStaleSize returns the total size of the stale versions, ie. the space
+// freed by pruning all binaries.
+func (u StoreUsage) GetStaleSize() ByteSize {
+	var size ByteSize
+	for _, usage := range u {
+		size += usage.StaleSize
+	}
+
+	return size
+}
+
+// GetStaleVersions returns the total number of stale versions.
+func (u StoreUsage) GetStaleVersions() int {
+	var versions int
+	for _, usage := range u {
+		versions += usage.StaleVersions
+	}
+
+	return versions
+}
+
+// GetVersions returns the total number of versions.
+func (u StoreUsage) GetVersions() int {
+	var versions int
+	for _, usage := range u {
+		versions += usage.Versions
+	}
+
+	return versions
+}
@@ -0,0 +1,40 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestStoreUsage_Totals(t *testing.T) {
+	cases := map[string]struct {
+		usage                 model.StoreUsage
+		expectedSize          model.ByteSize
+		expectedVersions      int
+		expectedStaleSize     model.ByteSize
+		expectedStaleVersions int
+	}{
+		"empty": {},
+		"binaries": {
+			usage: model.StoreUsage{
+				{Name: "mockproj1", Versions: 3, Size: 3000, StaleVersions: 2, StaleSize: 2000},
+				{Name: "mockproj2", Versions: 1, Size: 500},
+			},
+			expectedSize:          3500,
+			expectedVersions:      4,
+			expectedStaleSize:     2000,
+			expectedStaleVersions: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSize, tc.usage.GetSize())
+			assert.Equal(t, tc.expectedVersions, tc.usage.GetVersions())
+			assert.Equal(t, tc.expectedStaleSize, tc.usage.GetStaleSize())
+			assert.Equal(t, tc.expectedStaleVersions, tc.usage.GetStaleVersions())
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// sumGolangOrgKey is the verifier key of sum.golang.org, known by the go
+// command, also used by its sum.golang.google.cn mirror.
+const sumGolangOrgKey = "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ykp6ZQkO9rr9xQpqV"
+
+// SumDBConfig represents the checksum database configuration of the Go
+// toolchain.
+type SumDBConfig struct {
+	SumDB   string `json:"GOSUMDB"`
+	NoSumDB string `json:"GONOSUMDB"`
+}
+
+// GetDisabledReason returns the setting disabling the checksum database
+// verification for all modules, ex. "GOSUMDB=off" or "GONOSUMDB=*". It returns
+// false if the verification is enabled for at least some modules.
+func (c SumDBConfig) GetDisabledReason() (string, bool) {
+	if c.SumDB == "off" {
+		return "GOSUMDB=off", true
+	}
+
+	for pattern := range strings.SplitSeq(c.NoSumDB, ",") {
+		if strings.TrimSpace(pattern) == "*" {
+			return "GONOSUMDB=" + c.NoSumDB, true
+		}
+	}
+
+	return "", false
+}
+
+// GetKey returns the verifier key of the checksum database, ex.
+// "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ykp6ZQkO9rr9xQpqV", known
+// by the go command for sum.golang.org and sum.golang.google.cn, or given in
+// "<name>+<key>". It returns false if the checksum database is disabled or its
+// key is unknown.
+func (c SumDBConfig) GetKey() (string, bool) {
+	if c.SumDB == "" || c.SumDB == "off" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(c.SumDB, " ")
+	switch {
+	case name == "sum.golang.org" || name == "sum.golang.google.cn":
+		return sumGolangOrgKey, true
+	case strings.Contains(name, "+"):
+		return name, true
+	default:
+		return "", false
+	}
+}
+
+// GetURL returns the URL of the checksum database, ex. "https://sum.golang.org"
+// for "sum.golang.org" or the URL given in "<name>+<key> <url>". It returns
+// false if the checksum database is disabled.
+func (c SumDBConfig) GetURL() (string, bool) {
+	if c.SumDB == "" || c.SumDB == "off" {
+		return "", false
+	}
+
+	name, url, found := strings.Cut(c.SumDB, " ")
+	if found {
+		return strings.TrimSpace(url), true
+	}
+
+	name, _, _ = strings.Cut(name, "+")
+	return "https://" + name, true
+}
+
+// IsExcluded returns whether the checksum database verification is disabled
+// for the module at the given path by a GONOSUMDB pattern, ex. a private module.
+func (c SumDBConfig) IsExcluded(modulePath string) bool {
+	return module.MatchPrefixPatterns(c.NoSumDB, modulePath)
+}
@@ -0,0 +1,164 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestSumDBConfig_GetDisabledReason(t *testing.T) {
+	cases := map[string]struct {
+		config           model.SumDBConfig
+		expectedReason   string
+		expectedDisabled bool
+	}{
+		"enabled": {
+			config: model.SumDBConfig{SumDB: "sum.golang.org"},
+		},
+		"enabled-with-private-modules": {
+			config: model.SumDBConfig{SumDB: "sum.golang.org", NoSumDB: "example.com/*"},
+		},
+		"disabled-sumdb-off": {
+			config:           model.SumDBConfig{SumDB: "off"},
+			expectedReason:   "GOSUMDB=off",
+			expectedDisabled: true,
+		},
+		"disabled-all-modules": {
+			config:           model.SumDBConfig{SumDB: "sum.golang.org", NoSumDB: "example.com/*,*"},
+			expectedReason:   "GONOSUMDB=example.com/*,*",
+			expectedDisabled: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reason, disabled := tc.config.GetDisabledReason()
+			assert.Equal(t, tc.expectedReason, reason)
+			assert.Equal(t, tc.expectedDisabled, disabled)
+		})
+	}
+}
+
+func TestSumDBConfig_GetKey(t *testing.T) {
+	cases := map[string]struct {
+		config        model.SumDBConfig
+		expectedKey   string
+		expectedFound bool
+	}{
+		"default": {
+			config:        model.SumDBConfig{SumDB: "sum.golang.org"},
+			expectedKey:   "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ykp6ZQkO9rr9xQpqV",
+			expectedFound: true,
+		},
+		"mirror": {
+			config:        model.SumDBConfig{SumDB: "sum.golang.google.cn"},
+			expectedKey:   "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ykp6ZQkO9rr9xQpqV",
+			expectedFound: true,
+		},
+		"name-with-key": {
+			config:        model.SumDBConfig{SumDB: "sum.example.com+abcdef+ghijkl"},
+			expectedKey:   "sum.example.com+abcdef+ghijkl",
+			expectedFound: true,
+		},
+		"name-with-key-and-url": {
+			config:        model.SumDBConfig{SumDB: "sum.example.com+abcdef+ghijkl https://sum.example.com/sumdb"},
+			expectedKey:   "sum.example.com+abcdef+ghijkl",
+			expectedFound: true,
+		},
+		"unknown-name": {
+			config:        model.SumDBConfig{SumDB: "sum.example.com"},
+			expectedFound: false,
+		},
+		"off": {
+			config:        model.SumDBConfig{SumDB: "off"},
+			expectedFound: false,
+		},
+		"empty": {
+			config:        model.SumDBConfig{},
+			expectedFound: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			key, found := tc.config.GetKey()
+			assert.Equal(t, tc.expectedKey, key)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
+
+func TestSumDBConfig_GetURL(t *testing.T) {
+	cases := map[string]struct {
+		config        model.SumDBConfig
+		expectedURL   string
+		expectedFound bool
+	}{
+		"default": {
+			config:        model.SumDBConfig{SumDB: "sum.golang.org"},
+			expectedURL:   "https://sum.golang.org",
+			expectedFound: true,
+		},
+		"name-with-key": {
+			config:        model.SumDBConfig{SumDB: "sum.example.com+abcdef"},
+			expectedURL:   "https://sum.example.com",
+			expectedFound: true,
+		},
+		"name-with-key-and-url": {
+			config:        model.SumDBConfig{SumDB: "sum.golang.org+abcdef https://sum.example.com/sumdb"},
+			expectedURL:   "https://sum.example.com/sumdb",
+			expectedFound: true,
+		},
+		"off": {
+			config:        model.SumDBConfig{SumDB: "off"},
+			expectedFound: false,
+		},
+		"empty": {
+			config:        model.SumDBConfig{},
+			expectedFound: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			url, found := tc.config.GetURL()
+			assert.Equal(t, tc.expectedURL, url)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
+
+func TestSumDBConfig_IsExcluded(t *testing.T) {
+	cases := map[string]struct {
+		config           model.SumDBConfig
+		modulePath       string
+		expectedExcluded bool
+	}{
+		"no-patterns": {
+			config:     model.SumDBConfig{SumDB: "sum.golang.org"},
+			modulePath: "example.com/mockproj",
+		},
+		"matching-pattern": {
+			config:           model.SumDBConfig{SumDB: "sum.golang.org", NoSumDB: "github.com/mockorg,example.com/*"},
+			modulePath:       "example.com/mockproj",
+			expectedExcluded: true,
+		},
+		"matching-prefix": {
+			config:           model.SumDBConfig{SumDB: "sum.golang.org", NoSumDB: "example.com/mockproj"},
+			modulePath:       "example.com/mockproj/v2",
+			expectedExcluded: true,
+		},
+		"not-matching-pattern": {
+			config:     model.SumDBConfig{SumDB: "sum.golang.org", NoSumDB: "github.com/mockorg"},
+			modulePath: "example.com/mockproj",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedExcluded, tc.config.IsExcluded(tc.modulePath))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// minAvailableMemory is the available memory below which the system is
+	// under memory pressure, about what the build of a large module takes.
+	minAvailableMemory ByteSize = 1 << 30
+	// minAvailableMemoryRatio is the ratio of the total memory below which the
+	// available memory puts the system under memory pressure.
+	minAvailableMemoryRatio = 0.1
+	// maxLoadPerCPU is the load average per CPU above which the system is
+	// under CPU pressure.
+	maxLoadPerCPU = 2
+	// kiloByte is the size of the kB unit of /proc/meminfo.
+	kiloByte = 1024
+)
+
+// vmStatPageSizeRegexp matches the page size in the header of the vm_stat
+// output, ex. "(page size of 16384 bytes)".
+var vmStatPageSizeRegexp = regexp.MustCompile(`page size of (\d+) bytes`)
+
+// SystemPressure represents the memory and CPU pressure of the system. Load is
+// the load average over the last minute.
+type SystemPressure struct {
+	MemoryTotal     ByteSize
+	MemoryAvailable ByteSize
+	Load            float64
+	CPUs            int
+}
+
+// IsHigh returns whether the system is under pressure, when the available
+// memory is below 1GiB or a tenth of the total memory, or the load average
+// exceeds twice the number of CPUs.
+func (p SystemPressure) IsHigh() bool {
+	if p.MemoryTotal > 0 && (p.MemoryAvailable < minAvailableMemory ||
+		float64(p.MemoryAvailable) < float64(p.MemoryTotal)*minAvailableMemoryRatio) {
+		return true
+	}
+
+	return p.CPUs > 0 && p.Load > float64(p.CPUs)*maxLoadPerCPU
+}
+
+// ParseDarwinPressure parses the pressure of a macOS system with the given
+// number of CPUs from the output of sysctl -n hw.memsize vm.loadavg and of
+// vm_stat. The available memory is the free, inactive and speculative pages.
+func ParseDarwinPressure(sysctl, vmStat []byte, cpus int) (SystemPressure, error) {
+	fields := strings.Fields(strings.NewReplacer("{", " ", "}", " ").Replace(string(sysctl)))
+	if len(fields) < 2 { //nolint:mnd // memory size and load average
+		return SystemPressure{}, fmt.Errorf("invalid sysctl output %q", sysctl)
+	}
+
+	total, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return SystemPressure{}, fmt.Errorf("invalid memory size %q: %w", fields[0], err)
+	}
+
+	load, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return SystemPressure{}, fmt.Errorf("invalid load average %q: %w", fields[1], err)
+	}
+
+	match := vmStatPageSizeRegexp.FindSubmatch(vmStat)
+	if match == nil {
+		return SystemPressure{}, fmt.Errorf("invalid vm_stat output %q", vmStat)
+	}
+
+	pageSize, _ := strconv.ParseInt(string(match[1]), 10, 64)
+
+	var pages int64
+	scanner := bufio.NewScanner(bytes.NewReader(vmStat))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "Pages free", "Pages inactive", "Pages speculative":
+			count, parseErr := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(value), "."), 10, 64)
+			if parseErr != nil {
+				return SystemPressure{}, fmt.Errorf("invalid %s %q: %w", strings.ToLower(key), value, parseErr)
+			}
+
+			pages += count
+		}
+	}
+
+	return SystemPressure{
+		MemoryTotal:     ByteSize(total),
+		MemoryAvailable: ByteSize(pages * pageSize),
+		Load:            load,
+		CPUs:            cpus,
+	}, nil
+}
+
+// ParseLinuxPressure parses the pressure of a Linux system with the given
+// number of CPUs from the contents of /proc/meminfo and /proc/loadavg.
+func ParseLinuxPressure(meminfo, loadavg []byte, cpus int) (SystemPressure, error) {
+	pressure := SystemPressure{CPUs: cpus}
+
+	scanner := bufio.NewScanner(bytes.NewReader(meminfo))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || (key != "MemTotal" && key != "MemAvailable") {
+			continue
+		}
+
+		size, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(value), " kB"), 10, 64)
+		if err != nil {
+			return SystemPressure{}, fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+
+		if key == "MemTotal" {
+			pressure.MemoryTotal = ByteSize(size * kiloByte)
+		} else {
+			pressure.MemoryAvailable = ByteSize(size * kiloByte)
+		}
+	}
+
+	if pressure.MemoryTotal == 0 {
+		return SystemPressure{}, fmt.Errorf("invalid meminfo %q", meminfo)
+	}
+
+	fields := strings.Fields(string(loadavg))
+	if len(fields) == 0 {
+		return SystemPressure{}, fmt.Errorf("invalid loadavg %q", loadavg)
+	}
+
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return SystemPressure{}, fmt.Errorf("invalid load average %q: %w", fields[0], err)
+	}
+
+	pressure.Load = load
+
+	return pressure, nil
+}
@@ -0,0 +1,155 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestSystemPressure_IsHigh(t *testing.T) {
+	cases := map[string]struct {
+		pressure model.SystemPressure
+		expected bool
+	}{
+		"low": {
+			pressure: model.SystemPressure{MemoryTotal: 16 << 30, MemoryAvailable: 8 << 30, Load: 4, CPUs: 8},
+			expected: false,
+		},
+		"low-memory-below-minimum": {
+			pressure: model.SystemPressure{MemoryTotal: 4 << 30, MemoryAvailable: 512 << 20, Load: 1, CPUs: 8},
+			expected: true,
+		},
+		"low-memory-below-ratio": {
+			pressure: model.SystemPressure{MemoryTotal: 64 << 30, MemoryAvailable: 4 << 30, Load: 1, CPUs: 8},
+			expected: true,
+		},
+		"high-load": {
+			pressure: model.SystemPressure{MemoryTotal: 16 << 30, MemoryAvailable: 8 << 30, Load: 17, CPUs: 8},
+			expected: true,
+		},
+		"unknown": {
+			pressure: model.SystemPressure{},
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.pressure.IsHigh()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestParseDarwinPressure(t *testing.T) {
+	vmStat := `Mach Virtual Memory Statistics: (page size of 16384 bytes)
+Pages free:                               10000.
+Pages active:                            300000.
+Pages inactive:                           50000.
+Pages speculative:                         5000.
+Pages throttled:                              0.
+`
+
+	cases := map[string]struct {
+		sysctl      string
+		vmStat      string
+		expected    model.SystemPressure
+		expectedErr string
+	}{
+		"success": {
+			sysctl: "17179869184\n{ 2.15 2.30 2.41 }\n",
+			vmStat: vmStat,
+			expected: model.SystemPressure{
+				MemoryTotal:     17179869184,
+				MemoryAvailable: 65000 * 16384,
+				Load:            2.15,
+				CPUs:            8,
+			},
+		},
+		"error-invalid-sysctl": {
+			sysctl:      "17179869184\n",
+			vmStat:      vmStat,
+			expectedErr: `invalid sysctl output "17179869184\n"`,
+		},
+		"error-invalid-memory-size": {
+			sysctl:      "16GB\n{ 2.15 2.30 2.41 }\n",
+			vmStat:      vmStat,
+			expectedErr: `invalid memory size "16GB": strconv.ParseInt: parsing "16GB": invalid syntax`,
+		},
+		"error-invalid-vm-stat": {
+			sysctl:      "17179869184\n{ 2.15 2.30 2.41 }\n",
+			vmStat:      "Pages free: 10000.\n",
+			expectedErr: `invalid vm_stat output "Pages free: 10000.\n"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pressure, err := model.ParseDarwinPressure([]byte(tc.sysctl), []byte(tc.vmStat), 8)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, pressure)
+		})
+	}
+}
+
+func TestParseLinuxPressure(t *testing.T) {
+	meminfo := `MemTotal:       16318036 kB
+MemFree:         1183124 kB
+MemAvailable:    9123456 kB
+Buffers:          512340 kB
+`
+
+	cases := map[string]struct {
+		meminfo     string
+		loadavg     string
+		expected    model.SystemPressure
+		expectedErr string
+	}{
+		"success": {
+			meminfo: meminfo,
+			loadavg: "3.42 2.91 2.50 4/1234 56789\n",
+			expected: model.SystemPressure{
+				MemoryTotal:     16318036 * 1024,
+				MemoryAvailable: 9123456 * 1024,
+				Load:            3.42,
+				CPUs:            8,
+			},
+		},
+		"error-invalid-meminfo": {
+			meminfo:     "Buffers: 512340 kB\n",
+			loadavg:     "3.42 2.91 2.50 4/1234 56789\n",
+			expectedErr: `invalid meminfo "Buffers: 512340 kB\n"`,
+		},
+		"error-invalid-mem-total": {
+			meminfo:     "MemTotal: 16GB\n",
+			loadavg:     "3.42 2.91 2.50 4/1234 56789\n",
+			expectedErr: `invalid MemTotal " 16GB": strconv.ParseInt: parsing "16GB": invalid syntax`,
+		},
+		"error-invalid-loadavg": {
+			meminfo:     meminfo,
+			loadavg:     "",
+			expectedErr: `invalid loadavg ""`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pressure, err := model.ParseLinuxPressure([]byte(tc.meminfo), []byte(tc.loadavg), 8)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, pressure)
+		})
+	}
+}
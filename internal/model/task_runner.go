@@ -0,0 +1,56 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TaskRunner is a task runner supported by the generate command.
+type TaskRunner string
+
+const (
+	// TaskRunnerMake is the make task runner, with a Makefile.
+	TaskRunnerMake TaskRunner = "make"
+	// TaskRunnerTask is the Task task runner, with a Taskfile.
+	TaskRunnerTask TaskRunner = "task"
+)
+
+// allowedTaskRunners is a list of allowed task runners.
+//
+//nolint:gochecknoglobals // global variable to define allowed task runners
+var allowedTaskRunners = []TaskRunner{
+	TaskRunnerMake,
+	TaskRunnerTask,
+}
+
+// NewTaskRunner creates a new task runner from a string. It returns an error if
+// the task runner is not supported.
+func NewTaskRunner(value string) (TaskRunner, error) {
+	runner := TaskRunner(strings.ToLower(value))
+	if !runner.IsValid() {
+		return "", fmt.Errorf("invalid task runner %q, allowed values are: %v", value, allowedTaskRunners)
+	}
+
+	return runner, nil
+}
+
+// GetTaskRunners returns the supported task runners.
+func GetTaskRunners() []string {
+	runners := make([]string, 0, len(allowedTaskRunners))
+	for _, runner := range allowedTaskRunners {
+		runners = append(runners, string(runner))
+	}
+
+	return runners
+}
+
+// IsValid checks if the task runner is valid.
+func (r TaskRunner) IsValid() bool {
+	return slices.Contains(allowedTaskRunners, r)
+}
+
+// String returns the string representation of the task runner.
+func (r TaskRunner) String() string {
+	return string(r)
+}
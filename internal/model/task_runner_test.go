@@ -0,0 +1,39 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+func TestNewTaskRunner(t *testing.T) {
+	cases := map[string]struct {
+		value       string
+		expected    model.TaskRunner
+		expectedErr error
+	}{
+		"make": {
+			value:    "make",
+			expected: model.TaskRunnerMake,
+		},
+		"task-uppercase": {
+			value:    "Task",
+			expected: model.TaskRunnerTask,
+		},
+		"invalid": {
+			value:       "just",
+			expectedErr: errors.New(`invalid task runner "just", allowed values are: [make task]`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			runner, err := model.NewTaskRunner(tc.value)
+			assert.Equal(t, tc.expected, runner)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
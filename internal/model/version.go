@@ -11,9 +11,27 @@ import (
 // Version represents a version.
 type Version string
 
-// NewVersion creates a new version from a version string.
+const (
+	// previousVersion is the version selector for the version installed before
+	// the current one.
+	previousVersion = "previous"
+	// latestOffsetPrefix is the prefix of the version selector for a release
+	// behind the latest one, ex. "latest-1".
+	latestOffsetPrefix = "latest-"
+	// incompatibleBuild is the build suffix of versions v2 or higher of modules
+	// without the major version suffix in their path.
+	incompatibleBuild = "+incompatible"
+)
+
+// NewVersion creates a new version from a version string. Branch and tag refs
+// keep their case, since they are case sensitive.
 func NewVersion(version string) Version {
-	return Version(strings.ToLower(strings.TrimSpace(version)))
+	version = strings.TrimSpace(version)
+	if v := Version(strings.ToLower(version)); v == "" || v.IsValid() {
+		return v
+	}
+
+	return Version(version)
 }
 
 // NewLatestVersion creates a new version "latest".
@@ -21,6 +39,12 @@ func NewLatestVersion() Version {
 	return NewVersion("latest")
 }
 
+// NewPreviousVersion creates a new version "previous", referring to the version
+// installed before the current one.
+func NewPreviousVersion() Version {
+	return NewVersion(previousVersion)
+}
+
 // Compare compares two versions.
 func (v Version) Compare(other Version) int {
 	return semver.Compare(string(v), string(other))
@@ -32,6 +56,28 @@ func (v Version) IsLatest() bool {
 	return string(v) == "latest"
 }
 
+// IsPrevious checks if the version is "previous".
+func (v Version) IsPrevious() bool {
+	return string(v) == previousVersion
+}
+
+// LatestOffset returns the number of releases behind the latest one for a
+// version "latest-N", ex. 1 for "latest-1". It returns false if the version is
+// not in that format or N is not a positive integer.
+func (v Version) LatestOffset() (int, bool) {
+	offset, ok := strings.CutPrefix(string(v), latestOffsetPrefix)
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(offset)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+
+	return n, true
+}
+
 // IsPartOf checks if a full version is part of a base version. If base version
 // is a major or major.minor version, it checks if the full version is greater
 // than or equal to the base version and less than the next major or major.minor
@@ -56,17 +102,126 @@ func (v Version) IsPartOf(baseVersion Version) bool {
 	}
 }
 
-// IsValid checks if the version is valid. If the version is "latest", it is
-// considered valid. Otherwise it checks if the version is a valid semantic
-// version.
+// IsExact checks if the version is a full semantic version, ex. "v1.2.3" or a
+// pseudo-version, rather than a version selector, ex. "v1.2" or "latest".
+func (v Version) IsExact() bool {
+	return semver.IsValid(string(v)) &&
+		semver.Canonical(string(v)) == strings.TrimSuffix(string(v), incompatibleBuild)
+}
+
+// IsIncompatible checks if the version is a +incompatible version, ex.
+// "v3.2.0+incompatible", of a module without the major version suffix in its
+// path.
+func (v Version) IsIncompatible() bool {
+	return semver.Build(string(v)) == incompatibleBuild
+}
+
+// IsPrerelease checks if the version is a pre-release version.
+func (v Version) IsPrerelease() bool {
+	return semver.Prerelease(string(v)) != ""
+}
+
+// IsRef checks if the version is a branch or tag ref, ex. "main" or
+// "feature/x", to be resolved to a pseudo-version. Versions, version selectors
+// and near-miss versions with a suggestion are not considered refs.
+func (v Version) IsRef() bool {
+	ref := string(v)
+	if ref == "" || v.IsValid() {
+		return false
+	}
+
+	if _, ok := v.Suggest(); ok {
+		return false
+	}
+
+	if strings.HasPrefix(ref, "-") || strings.HasPrefix(ref, ".") ||
+		strings.HasSuffix(ref, "/") || strings.Contains(ref, "..") {
+		return false
+	}
+
+	for _, r := range ref {
+		if !isRefChar(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsValid checks if the version is valid. If the version is "latest",
+// "previous" or "latest-N", it is considered valid. Otherwise it checks if the
+// version is a valid semantic version.
 func (v Version) IsValid() bool {
-	if v.IsLatest() {
+	if v.IsLatest() || v.IsPrevious() {
+		return true
+	}
+
+	if _, ok := v.LatestOffset(); ok {
 		return true
 	}
 
 	return semver.IsValid(string(v))
 }
 
+// Closest returns the latest version from the given versions, sorted in
+// ascending order, sharing the major and minor version with the version, or
+// the major version otherwise. It returns false if no version is close.
+func (v Version) Closest(versions []Version) (Version, bool) {
+	if v.Major() == "" {
+		return "", false
+	}
+
+	for _, prefix := range []string{v.MajorMinor(), v.Major()} {
+		for i := len(versions) - 1; i >= 0; i-- {
+			if versions[i].IsPartOf(Version(prefix)) {
+				return versions[i], true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Suggest returns the version most likely meant by an invalid version, ex.
+// "v1.2.3" for "1.2.3", "v1.2.3" for "v01.02.03", "v1" for "^1.2.3", "v1.2"
+// for "~1.2.3" or "latest-1" for "latest-0". It returns false if the version
+// is valid or there is no suggestion.
+func (v Version) Suggest() (Version, bool) {
+	if v.IsValid() {
+		return "", false
+	}
+
+	version := string(v)
+
+	if offset, ok := strings.CutPrefix(version, latestOffsetPrefix); ok {
+		if n, err := strconv.Atoi(offset); err == nil && n < 1 {
+			return Version(latestOffsetPrefix + "1"), true
+		}
+
+		return "", false
+	}
+
+	operator := ""
+	if version != "" && strings.ContainsRune("=^~", rune(version[0])) {
+		operator, version = version[:1], version[1:]
+	}
+
+	version = "v" + strings.TrimPrefix(strings.ToLower(version), "v")
+	version = trimLeadingZeros(version)
+	if !semver.IsValid(version) {
+		return "", false
+	}
+
+	switch operator {
+	case "^":
+		return NewVersion(semver.Major(version)), true
+	case "~":
+		return NewVersion(semver.MajorMinor(version)), true
+	default:
+		return NewVersion(version), true
+	}
+}
+
 // Major returns the major version.
 func (v Version) Major() string {
 	return semver.Major(string(v))
@@ -92,3 +247,31 @@ func (v Version) NextMajorVersion() Version {
 func (v Version) String() string {
 	return string(v)
 }
+
+// trimLeadingZeros trims the leading zeros of the major, minor and patch parts
+// of a version, ex. "v01.02.03" to "v1.2.3".
+func trimLeadingZeros(version string) string {
+	core, rest := version[1:], ""
+	if i := strings.IndexAny(core, "-+"); i != -1 {
+		core, rest = core[:i], core[i:]
+	}
+
+	parts := strings.Split(core, ".")
+	for i, part := range parts {
+		if trimmed := strings.TrimLeft(part, "0"); trimmed != part {
+			if trimmed == "" {
+				trimmed = "0"
+			}
+
+			parts[i] = trimmed
+		}
+	}
+
+	return "v" + strings.Join(parts, ".") + rest
+}
+
+// isRefChar checks if the rune is allowed in a branch or tag ref.
+func isRefChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') ||
+		strings.ContainsRune("-_./+", r)
+}
@@ -33,6 +33,10 @@ func TestNewVersion(t *testing.T) {
 			version:  "v1.2.3-ALPHA",
 			expected: model.Version("v1.2.3-alpha"),
 		},
+		"ref-with-case": {
+			version:  " Feature/X ",
+			expected: model.Version("Feature/X"),
+		},
 	}
 
 	for name, tc := range cases {
@@ -125,6 +129,72 @@ func TestVersion_IsLatest(t *testing.T) {
 	}
 }
 
+func TestVersion_IsPrevious(t *testing.T) {
+	cases := map[string]struct {
+		version  model.Version
+		expected bool
+	}{
+		"previous": {
+			version:  model.NewPreviousVersion(),
+			expected: true,
+		},
+		"latest": {
+			version:  model.Version("latest"),
+			expected: false,
+		},
+		"semantic-version": {
+			version:  model.Version("v1.2.3"),
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.version.IsPrevious()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestVersion_LatestOffset(t *testing.T) {
+	cases := map[string]struct {
+		version        model.Version
+		expectedOffset int
+		expectedOk     bool
+	}{
+		"latest-1": {
+			version:        model.Version("latest-1"),
+			expectedOffset: 1,
+			expectedOk:     true,
+		},
+		"latest-3": {
+			version:        model.Version("latest-3"),
+			expectedOffset: 3,
+			expectedOk:     true,
+		},
+		"latest": {
+			version: model.Version("latest"),
+		},
+		"latest-0": {
+			version: model.Version("latest-0"),
+		},
+		"latest-negative": {
+			version: model.Version("latest--1"),
+		},
+		"latest-not-number": {
+			version: model.Version("latest-one"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			offset, ok := tc.version.LatestOffset()
+			assert.Equal(t, tc.expectedOffset, offset)
+			assert.Equal(t, tc.expectedOk, ok)
+		})
+	}
+}
+
 func TestVersion_IsPartOf(t *testing.T) {
 	cases := map[string]struct {
 		version     model.Version
@@ -171,6 +241,166 @@ func TestVersion_IsPartOf(t *testing.T) {
 	}
 }
 
+func TestVersion_IsExact(t *testing.T) {
+	cases := map[string]struct {
+		version  model.Version
+		expected bool
+	}{
+		"release": {
+			version:  model.Version("v1.2.3"),
+			expected: true,
+		},
+		"pseudo-version": {
+			version:  model.Version("v0.0.0-20250729191454-dac745d99aac"),
+			expected: true,
+		},
+		"incompatible": {
+			version:  model.Version("v3.2.0+incompatible"),
+			expected: true,
+		},
+		"major-minor": {
+			version:  model.Version("v1.2"),
+			expected: false,
+		},
+		"latest": {
+			version:  model.Version("latest"),
+			expected: false,
+		},
+		"ref": {
+			version:  model.Version("main"),
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.version.IsExact()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestVersion_IsIncompatible(t *testing.T) {
+	cases := map[string]struct {
+		version  model.Version
+		expected bool
+	}{
+		"incompatible": {
+			version:  model.Version("v3.2.0+incompatible"),
+			expected: true,
+		},
+		"release": {
+			version:  model.Version("v3.2.0"),
+			expected: false,
+		},
+		"other-build": {
+			version:  model.Version("v3.2.0+build.1"),
+			expected: false,
+		},
+		"latest": {
+			version:  model.Version("latest"),
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.version.IsIncompatible()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestVersion_IsPrerelease(t *testing.T) {
+	cases := map[string]struct {
+		version  model.Version
+		expected bool
+	}{
+		"release": {
+			version:  model.Version("v1.2.3"),
+			expected: false,
+		},
+		"prerelease": {
+			version:  model.Version("v1.2.3-rc.1"),
+			expected: true,
+		},
+		"latest": {
+			version:  model.Version("latest"),
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.version.IsPrerelease()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestVersion_IsRef(t *testing.T) {
+	cases := map[string]struct {
+		version  model.Version
+		expected bool
+	}{
+		"branch": {
+			version:  model.Version("main"),
+			expected: true,
+		},
+		"branch-with-slash": {
+			version:  model.Version("feature/x"),
+			expected: true,
+		},
+		"tag": {
+			version:  model.Version("release-2024.1"),
+			expected: true,
+		},
+		"semantic-version": {
+			version:  model.Version("v1.2.3"),
+			expected: false,
+		},
+		"semantic-version-without-prefix": {
+			version:  model.Version("1.2.3"),
+			expected: false,
+		},
+		"semantic-version-with-leading-zeros": {
+			version:  model.Version("v01.2.3"),
+			expected: false,
+		},
+		"invalid-latest-offset": {
+			version:  model.Version("latest-0"),
+			expected: false,
+		},
+		"latest": {
+			version:  model.Version("latest"),
+			expected: false,
+		},
+		"empty": {
+			version:  model.Version(""),
+			expected: false,
+		},
+		"invalid-characters": {
+			version:  model.Version("feature x"),
+			expected: false,
+		},
+		"invalid-prefix": {
+			version:  model.Version("-main"),
+			expected: false,
+		},
+		"invalid-double-dot": {
+			version:  model.Version("feature..x"),
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := tc.version.IsRef()
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestVersion_IsValid(t *testing.T) {
 	cases := map[string]struct {
 		version  model.Version
@@ -192,6 +422,18 @@ func TestVersion_IsValid(t *testing.T) {
 			version:  model.Version("v0.0.0-20250806180942-db69247c9fc7"),
 			expected: true,
 		},
+		"valid-previous": {
+			version:  model.Version("previous"),
+			expected: true,
+		},
+		"valid-latest-offset": {
+			version:  model.Version("latest-1"),
+			expected: true,
+		},
+		"invalid-latest-offset": {
+			version:  model.Version("latest-0"),
+			expected: false,
+		},
 		"invalid-without-v-prefix": {
 			version:  model.Version("1.2.3"),
 			expected: false,
@@ -214,6 +456,108 @@ func TestVersion_IsValid(t *testing.T) {
 	}
 }
 
+func TestVersion_Closest(t *testing.T) {
+	versions := []model.Version{
+		model.NewVersion("v1.1.0"),
+		model.NewVersion("v1.2.0"),
+		model.NewVersion("v1.2.3"),
+		model.NewVersion("v1.3.0"),
+		model.NewVersion("v2.0.0"),
+	}
+
+	cases := map[string]struct {
+		version       model.Version
+		expected      model.Version
+		expectedFound bool
+	}{
+		"same-minor": {
+			version:       model.NewVersion("v1.2.7"),
+			expected:      model.NewVersion("v1.2.3"),
+			expectedFound: true,
+		},
+		"same-major": {
+			version:       model.NewVersion("v1.4"),
+			expected:      model.NewVersion("v1.3.0"),
+			expectedFound: true,
+		},
+		"no-match": {
+			version: model.NewVersion("v3.0.0"),
+		},
+		"latest": {
+			version: model.NewLatestVersion(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result, found := tc.version.Closest(versions)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
+
+func TestVersion_Suggest(t *testing.T) {
+	cases := map[string]struct {
+		version       model.Version
+		expected      model.Version
+		expectedFound bool
+	}{
+		"valid": {
+			version: model.NewVersion("v1.2.3"),
+		},
+		"missing-prefix": {
+			version:       model.NewVersion("1.2.3"),
+			expected:      model.NewVersion("v1.2.3"),
+			expectedFound: true,
+		},
+		"missing-prefix-major-minor": {
+			version:       model.NewVersion("1.2"),
+			expected:      model.NewVersion("v1.2"),
+			expectedFound: true,
+		},
+		"leading-zeros": {
+			version:       model.NewVersion("v01.02.03"),
+			expected:      model.NewVersion("v1.2.3"),
+			expectedFound: true,
+		},
+		"caret-range": {
+			version:       model.NewVersion("^1.2.3"),
+			expected:      model.NewVersion("v1"),
+			expectedFound: true,
+		},
+		"tilde-range": {
+			version:       model.NewVersion("~1.2.3"),
+			expected:      model.NewVersion("v1.2"),
+			expectedFound: true,
+		},
+		"equal-operator": {
+			version:       model.NewVersion("=v1.2.3"),
+			expected:      model.NewVersion("v1.2.3"),
+			expectedFound: true,
+		},
+		"latest-offset-zero": {
+			version:       model.NewVersion("latest-0"),
+			expected:      model.NewVersion("latest-1"),
+			expectedFound: true,
+		},
+		"ref": {
+			version: model.NewVersion("main"),
+		},
+		"latest-prefixed-ref": {
+			version: model.NewVersion("latest-release"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result, found := tc.version.Suggest()
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
+
 func TestVersion_Major(t *testing.T) {
 	cases := map[string]struct {
 		version  model.Version
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ProgressInterval is the interval between two renderings of a progress
+	// line, advancing its spinner.
+	ProgressInterval = 100 * time.Millisecond
+	// clearLine is the terminal escape sequence returning the cursor to the
+	// start of the line and clearing it.
+	clearLine = "\r\033[K"
+	// maxProgressItems is the maximum number of running items shown in a
+	// progress line, the others being counted.
+	maxProgressItems = 3
+)
+
+// spinnerFrames are the frames of the spinner of a progress line.
+//
+//nolint:gochecknoglobals // global variable to define the spinner frames
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Progress renders the progress of an operation run on several items, ex. the
+// packages installed in parallel, as a single line rewritten in place with a
+// spinner, the number and percentage of items done, and the items running, ex.
+// "⠋ installing 2/5 (40%): dlv, gopls". It is safe for concurrent use, and a
+// nil Progress renders nothing.
+type Progress struct {
+	mutex   sync.Mutex
+	writer  io.Writer
+	action  string
+	total   int
+	done    int
+	frame   int
+	running []string
+	drawn   bool
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewProgress creates a new Progress rendering the given action, ex.
+// "installing", on the given total of items to the given writer, usually a
+// terminal.
+func NewProgress(writer io.Writer, action string, total int) *Progress {
+	return &Progress{
+		writer:  writer,
+		action:  action,
+		total:   total,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start starts rendering the progress line at the given interval, until Stop
+// is called.
+func (p *Progress) Start(interval time.Duration) {
+	if p == nil {
+		return
+	}
+
+	go func() {
+		defer close(p.stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.mutex.Lock()
+				p.frame = (p.frame + 1) % len(spinnerFrames)
+				p.render()
+				p.mutex.Unlock()
+			}
+		}
+	}()
+}
+
+// Stop stops rendering the progress line and clears it.
+func (p *Progress) Stop() {
+	if p == nil {
+		return
+	}
+
+	close(p.stop)
+	<-p.stopped
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.clear()
+}
+
+// Begin marks the given item as running.
+func (p *Progress) Begin(item string) {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.running = append(p.running, item)
+	p.render()
+}
+
+// End marks the given item as done.
+func (p *Progress) End(item string) {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if idx := slices.Index(p.running, item); idx >= 0 {
+		p.running = slices.Delete(p.running, idx, idx+1)
+	}
+
+	p.done++
+	p.render()
+}
+
+// String returns the progress line, without the escape sequences rewriting it.
+func (p *Progress) String() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.line()
+}
+
+// Writer returns a writer writing to the given writer above the progress line:
+// the line is cleared before each write and rendered again after it. The writes
+// are expected to end with a new line.
+func (p *Progress) Writer(writer io.Writer) io.Writer {
+	return &progressWriter{progress: p, writer: writer}
+}
+
+// clear clears the progress line if rendered. The mutex must be held.
+func (p *Progress) clear() {
+	if p.drawn {
+		fmt.Fprint(p.writer, clearLine)
+		p.drawn = false
+	}
+}
+
+// line returns the progress line. The mutex must be held.
+func (p *Progress) line() string {
+	var percent int
+	if p.total > 0 {
+		percent = p.done * 100 / p.total //nolint:mnd // percentage
+	}
+
+	line := fmt.Sprintf("%s %s %d/%d (%d%%)", spinnerFrames[p.frame], p.action, p.done, p.total, percent)
+	if len(p.running) == 0 {
+		return line
+	}
+
+	items := p.running[:min(len(p.running), maxProgressItems)]
+	line += ": " + strings.Join(items, ", ")
+	if more := len(p.running) - len(items); more > 0 {
+		line += fmt.Sprintf(" and %d more", more)
+	}
+
+	return line
+}
+
+// render renders the progress line in place of the previous one. The mutex
+// must be held.
+func (p *Progress) render() {
+	fmt.Fprint(p.writer, clearLine+p.line())
+	p.drawn = true
+}
+
+// progressWriter is a writer writing above a progress line.
+type progressWriter struct {
+	progress *Progress
+	writer   io.Writer
+}
+
+// Write clears the progress line, writes the given bytes to the underlying
+// writer and renders the progress line again.
+func (w *progressWriter) Write(b []byte) (int, error) {
+	w.progress.mutex.Lock()
+	defer w.progress.mutex.Unlock()
+
+	w.progress.clear()
+	n, err := w.writer.Write(b)
+	w.progress.render()
+
+	return n, err
+}
@@ -0,0 +1,97 @@
+package internal_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal"
+)
+
+func TestProgress_String(t *testing.T) {
+	cases := map[string]struct {
+		total        int
+		begin        []string
+		end          []string
+		expectedLine string
+	}{
+		"not-started": {
+			total:        4,
+			expectedLine: "⠋ installing 0/4 (0%)",
+		},
+		"running": {
+			total:        4,
+			begin:        []string{"mockproj1", "mockproj2", "mockproj3"},
+			end:          []string{"mockproj2"},
+			expectedLine: "⠋ installing 1/4 (25%): mockproj1, mockproj3",
+		},
+		"running-more": {
+			total:        5,
+			begin:        []string{"mockproj1", "mockproj2", "mockproj3", "mockproj4", "mockproj5"},
+			expectedLine: "⠋ installing 0/5 (0%): mockproj1, mockproj2, mockproj3 and 2 more",
+		},
+		"done": {
+			total:        2,
+			begin:        []string{"mockproj1", "mockproj2"},
+			end:          []string{"mockproj1", "mockproj2"},
+			expectedLine: "⠋ installing 2/2 (100%)",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			progress := internal.NewProgress(&buf, "installing", tc.total)
+
+			for _, item := range tc.begin {
+				progress.Begin(item)
+			}
+
+			for _, item := range tc.end {
+				progress.End(item)
+			}
+
+			assert.Equal(t, tc.expectedLine, progress.String())
+			assert.Equal(t, len(tc.begin)+len(tc.end), bytes.Count(buf.Bytes(), []byte("\r\033[K")))
+		})
+	}
+}
+
+func TestProgress_Stop(t *testing.T) {
+	var buf bytes.Buffer
+	progress := internal.NewProgress(&buf, "upgrading", 2)
+
+	progress.Start(time.Millisecond)
+	progress.Begin("mockproj")
+	time.Sleep(10 * time.Millisecond)
+	progress.Stop()
+
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("\r\033[K")))
+	assert.Contains(t, buf.String(), "upgrading 0/2 (0%): mockproj")
+}
+
+func TestProgress_Writer(t *testing.T) {
+	var progressBuf, outBuf bytes.Buffer
+	progress := internal.NewProgress(&progressBuf, "diagnosing", 2)
+	progress.Begin("mockproj")
+	progressBuf.Reset()
+
+	fmt.Fprintln(progress.Writer(&outBuf), "✅ mockproj")
+
+	assert.Equal(t, "✅ mockproj\n", outBuf.String())
+	assert.Equal(t, "\r\033[K\r\033[K⠋ diagnosing 0/2 (0%): mockproj", progressBuf.String())
+}
+
+func TestProgress_Nil(t *testing.T) {
+	var progress *internal.Progress
+
+	assert.NotPanics(t, func() {
+		progress.Start(time.Millisecond)
+		progress.Begin("mockproj")
+		progress.End("mockproj")
+		progress.Stop()
+	})
+}
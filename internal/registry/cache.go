@@ -0,0 +1,259 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/brunoribeiro127/gobin/internal"
+	"github.com/brunoribeiro127/gobin/internal/model"
+	"github.com/brunoribeiro127/gobin/internal/system"
+)
+
+const (
+	// cacheArtifactsPath is the path prefix of the binaries served by a binary
+	// cache server, followed by the artifact name.
+	cacheArtifactsPath = "/v1/artifacts/"
+	// cacheDigestHeader is the header holding the sha256 digest of the binaries
+	// served by a binary cache server, for the clients not reading it from the
+	// index.
+	cacheDigestHeader = "X-Gobin-Digest"
+	// cacheIndexPath is the path of the artifact index of a binary cache server.
+	cacheIndexPath = "/v1/index.json"
+	// maxCacheIndexSize is the maximum size of an index read from a binary
+	// cache server.
+	maxCacheIndexSize = 4 << 20
+)
+
+// BinaryCache is an interface for a binary cache server, serving the managed
+// binaries of another gobin installation.
+type BinaryCache interface {
+	// GetArtifact gets the binary of an artifact from a binary cache server.
+	GetArtifact(
+		ctx context.Context,
+		baseURL string,
+		artifact model.CacheArtifact,
+	) ([]byte, error)
+	// GetIndex gets the artifacts served by a binary cache server.
+	GetIndex(
+		ctx context.Context,
+		baseURL string,
+	) ([]model.CacheArtifact, error)
+}
+
+// HTTPBinaryCache is a client of the binary cache servers started with gobin
+// serve-cache.
+type HTTPBinaryCache struct{}
+
+// NewHTTPBinaryCache creates a new HTTPBinaryCache.
+func NewHTTPBinaryCache() *HTTPBinaryCache {
+	return &HTTPBinaryCache{}
+}
+
+// GetArtifact gets the binary of the given artifact, as listed in the index of
+// the binary cache server at the given URL, verifying its content against the
+// digest of the index entry. It fails if the artifact is not found, or its
+// content does not match the digest, including an index entry without digest.
+func (c *HTTPBinaryCache) GetArtifact(
+	ctx context.Context,
+	baseURL string,
+	artifact model.CacheArtifact,
+) ([]byte, error) {
+	logger := slog.Default().With("url", baseURL, "artifact", artifact.String())
+	logger.InfoContext(ctx, "downloading artifact")
+
+	data, err := c.get(ctx, strings.TrimSuffix(baseURL, "/")+cacheArtifactsPath+artifact.Name, -1)
+	if err != nil {
+		logger.ErrorContext(ctx, "error downloading artifact", "err", err)
+		return nil, err
+	}
+
+	if artifact.Digest == "" || artifact.Digest != getDigest(data) {
+		logger.ErrorContext(ctx, "error downloading artifact", "err", ErrDigestMismatch)
+		return nil, ErrDigestMismatch
+	}
+
+	return data, nil
+}
+
+// GetIndex gets the artifacts served by the binary cache server at the given
+// URL.
+func (c *HTTPBinaryCache) GetIndex(ctx context.Context, baseURL string) ([]model.CacheArtifact, error) {
+	logger := slog.Default().With("url", baseURL)
+
+	data, err := c.get(ctx, strings.TrimSuffix(baseURL, "/")+cacheIndexPath, maxCacheIndexSize)
+	if err != nil {
+		logger.ErrorContext(ctx, "error getting cache index", "err", err)
+		return nil, err
+	}
+
+	var artifacts []model.CacheArtifact
+	if err = json.Unmarshal(data, &artifacts); err != nil {
+		logger.ErrorContext(ctx, "error parsing cache index", "err", err)
+		return nil, err
+	}
+
+	return artifacts, nil
+}
+
+// get gets the content at the given URL, reading at most the given size, if
+// not negative. It fails if the content is not found or the response status is
+// not OK.
+func (c *HTTPBinaryCache) get(ctx context.Context, rawURL string, size int64) ([]byte, error) {
+	ctx, span := internal.StartSpan(ctx, http.MethodGet, attribute.String("url.full", rawURL))
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, internal.RecordSpanError(span, ErrArtifactNotFound)
+	default:
+		return nil, internal.RecordSpanError(span, fmt.Errorf("unexpected status: %s", resp.Status))
+	}
+
+	body := io.Reader(resp.Body)
+	if size >= 0 {
+		body = io.LimitReader(resp.Body, size)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	return data, nil
+}
+
+// NewBinaryCacheHandler creates an HTTP handler serving the artifacts listed
+// by the given function as a binary cache: the index of the artifacts, as JSON,
+// with the sha256 digest of each binary, at /v1/index.json, and the binary of
+// each artifact at /v1/artifacts/<name>, with its digest in the X-Gobin-Digest
+// header too. The artifacts are listed on each request, so the binaries
+// installed or removed while serving are reflected.
+func NewBinaryCacheHandler(
+	fs system.FileSystem,
+	list func() ([]model.CacheArtifact, error),
+) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+cacheIndexPath, func(w http.ResponseWriter, r *http.Request) {
+		artifacts, err := list()
+		if err != nil {
+			slog.Default().ErrorContext(r.Context(), "error listing artifacts", "err", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if artifacts == nil {
+			artifacts = []model.CacheArtifact{}
+		}
+
+		for i, artifact := range artifacts {
+			digest, digestErr := getFileDigest(fs, artifact.Path)
+			if digestErr != nil {
+				slog.Default().ErrorContext(
+					r.Context(), "error reading artifact", "err", digestErr, "path", artifact.Path,
+				)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			artifacts[i].Digest = digest
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(artifacts)
+	})
+
+	mux.HandleFunc("GET "+cacheArtifactsPath+"{name}", func(w http.ResponseWriter, r *http.Request) {
+		logger := slog.Default().With("name", r.PathValue("name"), "remote_addr", r.RemoteAddr)
+
+		artifacts, err := list()
+		if err != nil {
+			logger.ErrorContext(r.Context(), "error listing artifacts", "err", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		for _, artifact := range artifacts {
+			if artifact.Name != r.PathValue("name") {
+				continue
+			}
+
+			serveArtifact(w, r, fs, artifact)
+			return
+		}
+
+		http.NotFound(w, r)
+	})
+
+	return mux
+}
+
+// getFileDigest returns the sha256 digest of the file at the given path,
+// streaming its content into the hash. It fails if the file cannot be read.
+func getFileDigest(fs system.FileSystem, path string) (string, error) {
+	file, err := fs.OpenFile(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// serveArtifact writes the binary of the given artifact to the response, with
+// its digest in the X-Gobin-Digest header. The binary is streamed from its
+// file, once to hash it and once to write it, so it is never held in memory.
+func serveArtifact(w http.ResponseWriter, r *http.Request, fs system.FileSystem, artifact model.CacheArtifact) {
+	logger := slog.Default().With("name", artifact.Name, "path", artifact.Path, "remote_addr", r.RemoteAddr)
+
+	digest, err := getFileDigest(fs, artifact.Path)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "error reading artifact", "err", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	file, err := fs.OpenFile(artifact.Path)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "error reading artifact", "err", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	logger.InfoContext(r.Context(), "serving artifact", "artifact", artifact.String())
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set(cacheDigestHeader, digest)
+	if _, err = io.Copy(w, file); err != nil {
+		logger.WarnContext(r.Context(), "error serving artifact", "err", err)
+	}
+}
@@ -0,0 +1,186 @@
+package registry_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+	"github.com/brunoribeiro127/gobin/internal/registry"
+	"github.com/brunoribeiro127/gobin/internal/system/mocks"
+)
+
+func TestHTTPBinaryCache_GetArtifact(t *testing.T) {
+	artifact := model.CacheArtifact{
+		Name:     "dlv@v1.25.1",
+		Package:  "github.com/go-delve/delve/cmd/dlv",
+		Module:   "github.com/go-delve/delve",
+		Version:  "v1.25.1",
+		Platform: "linux/amd64",
+		Digest:   getDigest([]byte("binary")),
+		Path:     "/home/user/.gobin/bin/dlv@v1.25.1",
+	}
+
+	cases := map[string]struct {
+		name            string
+		digest          *string
+		tamper          bool
+		mockOpenFile    []byte
+		mockOpenFileErr error
+		expectedData    []byte
+		expectedErr     error
+	}{
+		"success": {
+			name:         "dlv@v1.25.1",
+			mockOpenFile: []byte("binary"),
+			expectedData: []byte("binary"),
+		},
+		"error-artifact-not-found": {
+			name:        "gopls@v0.20.0",
+			expectedErr: registry.ErrArtifactNotFound,
+		},
+		"error-read-file": {
+			name:            "dlv@v1.25.1",
+			mockOpenFileErr: errors.New("unexpected error"),
+			expectedErr:     errors.New("unexpected status: 500 Internal Server Error"),
+		},
+		"error-digest-mismatch": {
+			name:        "dlv@v1.25.1",
+			tamper:      true,
+			expectedErr: registry.ErrDigestMismatch,
+		},
+		"error-digest-missing": {
+			name:         "dlv@v1.25.1",
+			digest:       new(string),
+			mockOpenFile: []byte("binary"),
+			expectedErr:  registry.ErrDigestMismatch,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := mocks.NewFileSystem(t)
+
+			switch {
+			case tc.mockOpenFileErr != nil:
+				fs.EXPECT().OpenFile(artifact.Path).
+					Return(nil, tc.mockOpenFileErr).
+					Once()
+			case tc.mockOpenFile != nil:
+				fs.EXPECT().OpenFile(artifact.Path).
+					RunAndReturn(func(string) (io.ReadCloser, error) {
+						return io.NopCloser(bytes.NewReader(tc.mockOpenFile)), nil
+					}).
+					Twice()
+			}
+
+			handler := registry.NewBinaryCacheHandler(fs, func() ([]model.CacheArtifact, error) {
+				return []model.CacheArtifact{artifact}, nil
+			})
+
+			if tc.tamper {
+				handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("X-Gobin-Digest", getDigest([]byte("binary")))
+					_, _ = w.Write([]byte("tamper"))
+				})
+			}
+
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			requested := artifact
+			requested.Name = tc.name
+			if tc.digest != nil {
+				requested.Digest = *tc.digest
+			}
+
+			data, err := registry.NewHTTPBinaryCache().GetArtifact(context.Background(), server.URL, requested)
+			assert.Equal(t, tc.expectedData, data)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestHTTPBinaryCache_GetIndex(t *testing.T) {
+	artifact := model.CacheArtifact{
+		Name:       "dlv@v1.25.1",
+		Package:    "github.com/go-delve/delve/cmd/dlv",
+		Module:     "github.com/go-delve/delve",
+		Version:    "v1.25.1",
+		ModuleSum:  "h1:abc=",
+		GoVersion:  "go1.25.1",
+		Platform:   "linux/amd64",
+		BuildFlags: model.BuildFlags{Strip: true},
+		Path:       "/home/user/.gobin/bin/dlv@v1.25.1",
+	}
+
+	cases := map[string]struct {
+		mockList          []model.CacheArtifact
+		mockListErr       error
+		mockOpenFileErr   error
+		expectedArtifacts []model.CacheArtifact
+		expectedErr       error
+	}{
+		"success": {
+			mockList: []model.CacheArtifact{artifact},
+			expectedArtifacts: []model.CacheArtifact{
+				{
+					Name:       "dlv@v1.25.1",
+					Package:    "github.com/go-delve/delve/cmd/dlv",
+					Module:     "github.com/go-delve/delve",
+					Version:    "v1.25.1",
+					ModuleSum:  "h1:abc=",
+					GoVersion:  "go1.25.1",
+					Platform:   "linux/amd64",
+					BuildFlags: model.BuildFlags{Strip: true},
+					Digest:     getDigest([]byte("binary")),
+				},
+			},
+		},
+		"success-empty": {
+			expectedArtifacts: []model.CacheArtifact{},
+		},
+		"error-list": {
+			mockListErr: errors.New("unexpected error"),
+			expectedErr: errors.New("unexpected status: 500 Internal Server Error"),
+		},
+		"error-read-file": {
+			mockList:        []model.CacheArtifact{artifact},
+			mockOpenFileErr: errors.New("unexpected error"),
+			expectedErr:     errors.New("unexpected status: 500 Internal Server Error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := mocks.NewFileSystem(t)
+
+			for _, artifact := range tc.mockList {
+				if tc.mockOpenFileErr != nil {
+					fs.EXPECT().OpenFile(artifact.Path).Return(nil, tc.mockOpenFileErr).Once()
+					continue
+				}
+
+				fs.EXPECT().OpenFile(artifact.Path).
+					Return(io.NopCloser(strings.NewReader("binary")), nil).
+					Once()
+			}
+
+			server := httptest.NewServer(registry.NewBinaryCacheHandler(fs, func() ([]model.CacheArtifact, error) {
+				return tc.mockList, tc.mockListErr
+			}))
+			defer server.Close()
+
+			artifacts, err := registry.NewHTTPBinaryCache().GetIndex(context.Background(), server.URL+"/")
+			assert.Equal(t, tc.expectedArtifacts, artifacts)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,501 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/brunoribeiro127/gobin/internal"
+	"github.com/brunoribeiro127/gobin/internal/model"
+	"github.com/brunoribeiro127/gobin/internal/system"
+)
+
+const (
+	// PasswordEnvVar is the environment variable for the password, or token,
+	// used to authenticate to OCI registries.
+	PasswordEnvVar = "GOBIN_REGISTRY_PASSWORD"
+	// UsernameEnvVar is the environment variable for the username used to
+	// authenticate to OCI registries.
+	UsernameEnvVar = "GOBIN_REGISTRY_USERNAME"
+
+	// ArtifactType is the artifact type of the manifests of the binaries pushed
+	// by gobin.
+	ArtifactType = "application/vnd.gobin.binary.v1"
+	// layerMediaType is the media type of the layer holding the binary.
+	layerMediaType = "application/vnd.gobin.binary.layer.v1"
+	// manifestMediaType is the media type of OCI image manifests.
+	manifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// emptyMediaType is the media type of the empty config of artifacts.
+	emptyMediaType = "application/vnd.oci.empty.v1+json"
+	// emptyConfig is the content of the empty config of artifacts.
+	emptyConfig = "{}"
+	// maxManifestSize is the maximum size of a manifest read from a registry.
+	maxManifestSize = 4 << 20
+)
+
+var (
+	// ErrArtifactInvalid is returned when an artifact is not a binary pushed by
+	// gobin.
+	ErrArtifactInvalid = errors.New("artifact is not a gobin binary")
+
+	// ErrArtifactNotFound is returned when an artifact is not found in the
+	// registry.
+	ErrArtifactNotFound = errors.New("artifact not found")
+
+	// ErrDigestMismatch is returned when the content of a pulled binary does
+	// not match the digest of its manifest.
+	ErrDigestMismatch = errors.New("digest mismatch")
+
+	// ErrUnauthorized is returned when the registry refuses the credentials, or
+	// the lack of them.
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// Registry is an interface for an OCI registry.
+type Registry interface {
+	// Pull pulls a binary artifact from an OCI registry.
+	Pull(
+		ctx context.Context,
+		ref model.OCIReference,
+	) (model.OCIArtifact, error)
+	// Push pushes a binary artifact to an OCI registry.
+	Push(
+		ctx context.Context,
+		ref model.OCIReference,
+		artifact model.OCIArtifact,
+	) error
+}
+
+// descriptor represents an OCI content descriptor.
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// manifest represents an OCI image manifest holding an artifact.
+type manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType"`
+	Config        descriptor        `json:"config"`
+	Layers        []descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// OCIRegistry is a client of the OCI distribution API storing binaries as
+// single layer artifacts.
+type OCIRegistry struct {
+	env  system.Environment
+	auth map[string]string
+	mu   sync.Mutex
+}
+
+// NewOCIRegistry creates a new OCIRegistry. The credentials, if any, are read
+// from the GOBIN_REGISTRY_USERNAME and GOBIN_REGISTRY_PASSWORD environment
+// variables when a registry requires authentication.
+func NewOCIRegistry(env system.Environment) *OCIRegistry {
+	return &OCIRegistry{
+		env:  env,
+		auth: make(map[string]string),
+	}
+}
+
+// Pull pulls the binary artifact with the given reference, verifying its
+// content against the digest of its manifest. It fails if the artifact is not
+// found, is not a binary pushed by gobin or its content does not match the
+// digest.
+func (r *OCIRegistry) Pull(ctx context.Context, ref model.OCIReference) (model.OCIArtifact, error) {
+	logger := slog.Default().With("ref", ref.String())
+	logger.Info("pulling artifact")
+
+	data, err := r.get(ctx, ref, r.getURL(ref, "manifests", ref.Tag), manifestMediaType, maxManifestSize)
+	if err != nil {
+		logger.Error("error pulling manifest", "err", err)
+		return model.OCIArtifact{}, err
+	}
+
+	var m manifest
+	if err = json.Unmarshal(data, &m); err != nil {
+		logger.Error("error parsing manifest", "err", err)
+		return model.OCIArtifact{}, err
+	}
+
+	if m.ArtifactType != ArtifactType || len(m.Layers) != 1 || m.Layers[0].MediaType != layerMediaType {
+		logger.Error("error pulling artifact", "err", ErrArtifactInvalid)
+		return model.OCIArtifact{}, ErrArtifactInvalid
+	}
+
+	layer := m.Layers[0]
+	data, err = r.get(ctx, ref, r.getURL(ref, "blobs", layer.Digest), "", layer.Size)
+	if err != nil {
+		logger.Error("error pulling binary", "err", err)
+		return model.OCIArtifact{}, err
+	}
+
+	if getDigest(data) != layer.Digest {
+		logger.Error("error pulling binary", "err", ErrDigestMismatch)
+		return model.OCIArtifact{}, ErrDigestMismatch
+	}
+
+	return model.OCIArtifact{
+		Data:        data,
+		Annotations: m.Annotations,
+	}, nil
+}
+
+// Push pushes the given binary artifact with the given reference, uploading
+// the empty config and the binary as blobs, unless already present, and the
+// manifest, with the artifact annotations, under the reference tag. It fails
+// if any upload fails.
+func (r *OCIRegistry) Push(ctx context.Context, ref model.OCIReference, artifact model.OCIArtifact) error {
+	logger := slog.Default().With("ref", ref.String())
+	logger.Info("pushing artifact")
+
+	config := descriptor{
+		MediaType: emptyMediaType,
+		Digest:    getDigest([]byte(emptyConfig)),
+		Size:      int64(len(emptyConfig)),
+	}
+	layer := descriptor{
+		MediaType: layerMediaType,
+		Digest:    getDigest(artifact.Data),
+		Size:      int64(len(artifact.Data)),
+	}
+
+	for _, blob := range [][]byte{[]byte(emptyConfig), artifact.Data} {
+		if err := r.pushBlob(ctx, ref, blob); err != nil {
+			logger.Error("error pushing blob", "err", err)
+			return err
+		}
+	}
+
+	data, err := json.Marshal(manifest{
+		SchemaVersion: 2, //nolint:mnd // OCI image manifest schema version
+		MediaType:     manifestMediaType,
+		ArtifactType:  ArtifactType,
+		Config:        config,
+		Layers:        []descriptor{layer},
+		Annotations:   artifact.Annotations,
+	})
+	if err != nil {
+		logger.Error("error serializing manifest", "err", err)
+		return err
+	}
+
+	resp, err := r.do(ctx, ref, http.MethodPut, r.getURL(ref, "manifests", ref.Tag), manifestMediaType, data)
+	if err != nil {
+		logger.Error("error pushing manifest", "err", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		err = fmt.Errorf("unexpected status: %s", resp.Status)
+		logger.Error("error pushing manifest", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// authenticate answers the given authentication challenge of a registry. A
+// basic challenge is answered with the credentials from the environment, and a
+// bearer challenge with a token requested to the challenge realm, with the
+// credentials, if any. It fails if the challenge is not supported or the
+// credentials are missing or refused.
+func (r *OCIRegistry) authenticate(ctx context.Context, registry, challenge string) error {
+	username, _ := r.env.Get(UsernameEnvVar)
+	password, _ := r.env.Get(PasswordEnvVar)
+
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if username == "" {
+			return ErrUnauthorized
+		}
+
+		r.setAuth(registry, "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+		return nil
+	case "bearer":
+		token, err := r.getToken(ctx, params, username, password)
+		if err != nil {
+			return err
+		}
+
+		r.setAuth(registry, "Bearer "+token)
+		return nil
+	default:
+		return ErrUnauthorized
+	}
+}
+
+// do sends a request to a registry, answering the authentication challenge
+// and retrying once if the registry responds with unauthorized.
+func (r *OCIRegistry) do(
+	ctx context.Context,
+	ref model.OCIReference,
+	method, rawURL, contentType string,
+	body []byte,
+) (*http.Response, error) {
+	resp, err := r.send(ctx, ref, method, rawURL, contentType, body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	if err = r.authenticate(ctx, ref.Registry, resp.Header.Get("WWW-Authenticate")); err != nil {
+		return nil, err
+	}
+
+	resp, err = r.send(ctx, ref, method, rawURL, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		return nil, ErrUnauthorized
+	}
+
+	return resp, nil
+}
+
+// get gets the content at the given URL of a registry, accepting the given
+// media type, if any, and reading at most the given size. It fails if the
+// content is not found or the response status is not OK.
+func (r *OCIRegistry) get(
+	ctx context.Context,
+	ref model.OCIReference,
+	rawURL, accept string,
+	size int64,
+) ([]byte, error) {
+	resp, err := r.do(ctx, ref, http.MethodGet, rawURL, accept, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(io.LimitReader(resp.Body, size))
+	case http.StatusNotFound:
+		return nil, ErrArtifactNotFound
+	default:
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+}
+
+// getToken requests a bearer token to the realm of the given challenge
+// parameters, for its service and scope, with the given credentials, if any.
+func (r *OCIRegistry) getToken(
+	ctx context.Context,
+	params map[string]string,
+	username, password string,
+) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", ErrUnauthorized
+	}
+
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", ErrUnauthorized
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+
+	return "", ErrUnauthorized
+}
+
+// getURL returns the URL of the given kind of content, manifests or blobs, of
+// the reference repository. Registries on the loopback interface are reached
+// over HTTP, the others over HTTPS.
+func (r *OCIRegistry) getURL(ref model.OCIReference, kind, name string) string {
+	scheme := "https"
+	host := ref.Registry
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		scheme = "http"
+	}
+
+	return scheme + "://" + ref.Registry + "/v2/" + ref.Repository + "/" + kind + "/" + name
+}
+
+// pushBlob uploads the given blob to the reference repository in a single
+// request, unless already present.
+func (r *OCIRegistry) pushBlob(ctx context.Context, ref model.OCIReference, blob []byte) error {
+	digest := getDigest(blob)
+
+	resp, err := r.do(ctx, ref, http.MethodHead, r.getURL(ref, "blobs", digest), "", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = r.do(ctx, ref, http.MethodPost, r.getURL(ref, "blobs", "uploads/"), "", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return err
+	}
+
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = r.do(ctx, ref, http.MethodPut, location.String(), "application/octet-stream", blob)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// send sends a request to a registry with the authorization obtained for it,
+// if any. The content type is sent as the Accept header of requests without a
+// body.
+func (r *OCIRegistry) send(
+	ctx context.Context,
+	ref model.OCIReference,
+	method, rawURL, contentType string,
+	body []byte,
+) (*http.Response, error) {
+	ctx, span := internal.StartSpan(ctx, method, attribute.String("url.full", rawURL))
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	if contentType != "" && body == nil {
+		req.Header.Set("Accept", contentType)
+	} else if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	r.mu.Lock()
+	if auth, ok := r.auth[ref.Registry]; ok {
+		req.Header.Set("Authorization", auth)
+	}
+	r.mu.Unlock()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	return resp, nil
+}
+
+// setAuth sets the authorization for the requests to the given registry.
+func (r *OCIRegistry) setAuth(registry, auth string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.auth[registry] = auth
+}
+
+// getDigest returns the sha256 digest of the given content.
+func getDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// parseChallenge parses the given WWW-Authenticate header into its scheme and
+// parameters, ex. Bearer realm="https://ghcr.io/token",service="ghcr.io".
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := make(map[string]string)
+
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+
+		if quoted, found := strings.CutPrefix(value, `"`); found {
+			value, rest, _ = strings.Cut(quoted, `"`)
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+		}
+
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+		rest = strings.TrimLeft(rest, ", ")
+	}
+
+	return scheme, params
+}
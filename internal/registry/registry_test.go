@@ -0,0 +1,204 @@
+package registry_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+	"github.com/brunoribeiro127/gobin/internal/registry"
+	"github.com/brunoribeiro127/gobin/internal/system/mocks"
+)
+
+// fakeRegistry is an in-memory OCI registry, optionally requiring a bearer
+// token issued to the given credentials.
+type fakeRegistry struct {
+	username  string
+	password  string
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	mu        sync.Mutex
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/token" {
+		if username, password, _ := r.BasicAuth(); username != f.username || password != f.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"token":"secret"}`)
+		return
+	}
+
+	if f.username != "" && r.Header.Get("Authorization") != "Bearer secret" {
+		w.Header().Set(
+			"WWW-Authenticate",
+			`Bearer realm="http://`+r.Host+`/token",service="fake",scope="repository:tools:pull,push"`,
+		)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/tools/")
+	switch {
+	case r.Method == http.MethodPost && path == "blobs/uploads/":
+		w.Header().Set("Location", "/v2/tools/blobs/uploads/1")
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPut && path == "blobs/uploads/1":
+		data, _ := io.ReadAll(r.Body)
+		f.blobs[r.URL.Query().Get("digest")] = data
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "manifests/"):
+		data, _ := io.ReadAll(r.Body)
+		f.manifests[strings.TrimPrefix(path, "manifests/")] = data
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "blobs/"):
+		data, ok := f.blobs[strings.TrimPrefix(path, "blobs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case strings.HasPrefix(path, "manifests/"):
+		data, ok := f.manifests[strings.TrimPrefix(path, "manifests/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func getDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestOCIRegistry_PushPull(t *testing.T) {
+	cases := map[string]struct {
+		serverUsername string
+		serverPassword string
+		mockUsername   string
+		mockPassword   string
+		expectedErr    error
+	}{
+		"success-anonymous": {},
+		"success-bearer-token": {
+			serverUsername: "user",
+			serverPassword: "pass",
+			mockUsername:   "user",
+			mockPassword:   "pass",
+		},
+		"error-unauthorized": {
+			serverUsername: "user",
+			serverPassword: "pass",
+			mockUsername:   "user",
+			mockPassword:   "wrong",
+			expectedErr:    registry.ErrUnauthorized,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(&fakeRegistry{
+				username:  tc.serverUsername,
+				password:  tc.serverPassword,
+				blobs:     make(map[string][]byte),
+				manifests: make(map[string][]byte),
+			})
+			defer server.Close()
+
+			env := mocks.NewEnvironment(t)
+			env.EXPECT().Get(registry.UsernameEnvVar).Return(tc.mockUsername, tc.mockUsername != "").Maybe()
+			env.EXPECT().Get(registry.PasswordEnvVar).Return(tc.mockPassword, tc.mockPassword != "").Maybe()
+
+			ref := model.OCIReference{
+				Registry:   strings.TrimPrefix(server.URL, "http://"),
+				Repository: "tools",
+				Tag:        "dlv",
+			}
+			artifact := model.OCIArtifact{
+				Data:        []byte("binary"),
+				Annotations: map[string]string{"org.opencontainers.image.title": "dlv"},
+			}
+
+			reg := registry.NewOCIRegistry(env)
+			err := reg.Push(context.Background(), ref, artifact)
+			assert.Equal(t, tc.expectedErr, err)
+
+			if tc.expectedErr != nil {
+				return
+			}
+
+			result, err := registry.NewOCIRegistry(env).Pull(context.Background(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, artifact, result)
+		})
+	}
+}
+
+func TestOCIRegistry_Pull(t *testing.T) {
+	cases := map[string]struct {
+		manifests   map[string][]byte
+		blobs       map[string][]byte
+		expectedErr error
+	}{
+		"error-artifact-not-found": {
+			manifests:   map[string][]byte{},
+			expectedErr: registry.ErrArtifactNotFound,
+		},
+		"error-artifact-invalid": {
+			manifests: map[string][]byte{
+				"dlv": []byte(
+					`{"schemaVersion":2,"artifactType":"application/vnd.oci.image.config.v1+json","layers":[]}`,
+				),
+			},
+			expectedErr: registry.ErrArtifactInvalid,
+		},
+		"error-digest-mismatch": {
+			manifests: map[string][]byte{
+				"dlv": []byte(`{"schemaVersion":2,"artifactType":"application/vnd.gobin.binary.v1","layers":[` +
+					`{"mediaType":"application/vnd.gobin.binary.layer.v1","digest":"` + getDigest([]byte("binary")) +
+					`","size":6}]}`),
+			},
+			blobs: map[string][]byte{
+				getDigest([]byte("binary")): []byte("tamper"),
+			},
+			expectedErr: registry.ErrDigestMismatch,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(&fakeRegistry{
+				blobs:     tc.blobs,
+				manifests: tc.manifests,
+			})
+			defer server.Close()
+
+			ref := model.OCIReference{
+				Registry:   strings.TrimPrefix(server.URL, "http://"),
+				Repository: "tools",
+				Tag:        "dlv",
+			}
+
+			_, err := registry.NewOCIRegistry(mocks.NewEnvironment(t)).Pull(context.Background(), ref)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
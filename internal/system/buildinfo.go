@@ -1,6 +1,19 @@
 package system
 
-import "debug/buildinfo"
+import (
+	"bytes"
+	"debug/buildinfo"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// upxHeaderSize is the size of the binary header searched for the UPX magic.
+const upxHeaderSize = 4096
+
+// upxMagic is the magic marking the binaries compressed with UPX.
+var upxMagic = []byte("UPX!")
 
 // BuildInfo is the interface for reading build info.
 type BuildInfo interface {
@@ -16,7 +29,44 @@ func NewBuildInfo() BuildInfo {
 	return &buildInfo{}
 }
 
-// Read reads the build info from the given path.
+// Read reads the build info from the given path. As the build info of binaries
+// compressed with UPX is not readable, it is read from a decompressed copy of
+// them if the upx command is available.
 func (b *buildInfo) Read(path string) (*buildinfo.BuildInfo, error) {
-	return buildinfo.ReadFile(path)
+	info, err := buildinfo.ReadFile(path)
+	if err == nil || !isUPXCompressed(path) {
+		return info, err
+	}
+
+	if _, lookErr := exec.LookPath("upx"); lookErr != nil {
+		return nil, err
+	}
+
+	tempDir, tempErr := os.MkdirTemp("", "gobin-upx-*")
+	if tempErr != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	tempPath := filepath.Join(tempDir, filepath.Base(path))
+	if upxErr := exec.Command("upx", "-d", "-q", "-o", tempPath, path).Run(); upxErr != nil {
+		return nil, err
+	}
+
+	return buildinfo.ReadFile(tempPath)
+}
+
+// isUPXCompressed returns whether the binary at the given path is compressed
+// with UPX, based on the UPX magic in its header.
+func isUPXCompressed(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, upxHeaderSize)
+	n, _ := io.ReadFull(file, header)
+
+	return bytes.Contains(header[:n], upxMagic)
 }
@@ -5,6 +5,9 @@ import "os"
 // Environment is the interface for the environment.
 type Environment interface {
 	Get(key string) (string, bool)
+	List() []string
+	Set(key, value string) error
+	UserConfigDir() (string, error)
 	UserHomeDir() (string, error)
 }
 
@@ -21,6 +24,22 @@ func (e *env) Get(key string) (string, bool) {
 	return os.LookupEnv(key)
 }
 
+// List lists the environment variables in the form "key=value".
+func (e *env) List() []string {
+	return os.Environ()
+}
+
+// Set sets the value of the environment variable with the given key for the
+// current process and the commands it executes.
+func (e *env) Set(key, value string) error {
+	return os.Setenv(key, value)
+}
+
+// UserConfigDir returns the configuration directory of the current user.
+func (e *env) UserConfigDir() (string, error) {
+	return os.UserConfigDir()
+}
+
 // UserHomeDir returns the home directory of the current user.
 func (e *env) UserHomeDir() (string, error) {
 	return os.UserHomeDir()
@@ -2,14 +2,21 @@ package system
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
+	"time"
 )
 
+// attachWaitDelay is the delay given to an attached command to exit after
+// being interrupted, before it is killed.
+const attachWaitDelay = 5 * time.Second
+
 // Exec is the interface for creating commands to be executed.
 type Exec interface {
 	Run(ctx context.Context, name string, args ...string) ExecRun
 	CombinedOutput(ctx context.Context, name string, args ...string) ExecCombinedOutput
+	Attach(ctx context.Context, name string, args ...string) ExecAttach
 }
 
 // ExecRun is an interface that represents a command that can be run and inject
@@ -19,10 +26,19 @@ type ExecRun interface {
 	InjectEnv(env ...string)
 }
 
-// ExecCombinedOutput is an interface that represents a command that can be run
-// and returns the combined output.
+// ExecCombinedOutput is an interface that represents a command that can be run,
+// inject environment variables and returns the combined output.
 type ExecCombinedOutput interface {
 	CombinedOutput() ([]byte, error)
+	InjectEnv(env ...string)
+}
+
+// ExecAttach is an interface that represents a command attached to the
+// standard input, output and error of the current process, that can be run and
+// inject environment variables, returning its exit code.
+type ExecAttach interface {
+	Run() (int, error)
+	InjectEnv(env ...string)
 }
 
 // execCmd is the default implementation of the Exec interface.
@@ -43,6 +59,12 @@ func (e *execCmd) CombinedOutput(ctx context.Context, name string, args ...strin
 	return NewExecCombinedOutput(ctx, name, args...)
 }
 
+// Attach creates a new ExecAttach that runs a command attached to the standard
+// streams of the current process.
+func (e *execCmd) Attach(ctx context.Context, name string, args ...string) ExecAttach {
+	return NewExecAttach(ctx, name, args...)
+}
+
 // execRun is the default implementation of ExecRun that runs a command.
 type execRun struct {
 	cmd *exec.Cmd
@@ -102,3 +124,58 @@ func NewExecCombinedOutput(
 func (e *execCombinedOutput) CombinedOutput() ([]byte, error) {
 	return e.cmd.CombinedOutput()
 }
+
+// InjectEnv injects environment variables into the command.
+func (e *execCombinedOutput) InjectEnv(env ...string) {
+	e.cmd.Env = append(e.cmd.Env, env...)
+}
+
+// execAttach is the default implementation of ExecAttach that runs a command
+// attached to the standard streams of the current process.
+type execAttach struct {
+	cmd *exec.Cmd
+}
+
+// NewExecAttach creates a new ExecAttach that runs a command attached to the
+// standard input, output and error of the current process. It uses the exec
+// package to run the command, injecting the environment variables from the
+// current process. When the context is done, the command is interrupted and
+// given some time to exit before being killed.
+func NewExecAttach(
+	ctx context.Context,
+	name string,
+	args ...string,
+) ExecAttach {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = attachWaitDelay
+
+	return &execAttach{
+		cmd: cmd,
+	}
+}
+
+// Run runs the command and returns its exit code. It returns an error only if
+// the command cannot be started or waited for, not when it exits with a
+// non-zero exit code.
+func (e *execAttach) Run() (int, error) {
+	err := e.cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+
+	return 0, err
+}
+
+// InjectEnv injects environment variables into the command.
+func (e *execAttach) InjectEnv(env ...string) {
+	e.cmd.Env = append(e.cmd.Env, env...)
+}
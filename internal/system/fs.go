@@ -1,11 +1,36 @@
 package system
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"debug/elf"
+	"debug/macho"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	iofs "io/fs"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// WindowsMaxPath is the maximum length of a path in the Windows API
+	// (MAX_PATH), unless extended with the \\?\ prefix.
+	WindowsMaxPath = 260
+
+	// universalAlign is the alignment, as a power of 2, of each architecture
+	// in a macOS universal binary, matching the 16KB page size of arm64.
+	universalAlign = 14
 )
 
 // CleanupFunc is a function that cleans up a resource.
@@ -13,26 +38,59 @@ type CleanupFunc func() error
 
 // FileSystem is the interface for the file system.
 type FileSystem interface {
+	// Copy replaces a file with a copy of another file.
+	Copy(source, target string) error
+	// CreateArchive creates a gzip compressed tar archive of a directory.
+	CreateArchive(path, dir, name string) error
 	// CreateDir creates a directory with the given path and permissions.
 	CreateDir(path string, perm os.FileMode) error
 	// CreateTempDir creates a temporary directory with the given path and pattern.
 	CreateTempDir(dir, pattern string) (string, CleanupFunc, error)
+	// CreateUniversalBinary merges macOS binaries into a universal binary.
+	CreateUniversalBinary(target string, sources ...string) error
+	// Exists checks if a path exists.
+	Exists(path string) bool
+	// ExtractArchive extracts a gzip compressed tar archive into a directory.
+	ExtractArchive(path, dir string) error
+	// GetELFInterpreter gets the program interpreter of an ELF binary.
+	GetELFInterpreter(path string) (string, error)
+	// GetFileSize gets the size of a file in bytes.
+	GetFileSize(path string) (int64, error)
+	// GetLatestModTime gets the latest modification time of the files in a
+	// directory.
+	GetLatestModTime(dir string) (time.Time, error)
+	// GetModTime gets the modification time of a file.
+	GetModTime(path string) (time.Time, error)
 	// IsSymlinkToDir checks if a path is a symlink to another directory.
 	IsSymlinkToDir(path string, baseDir string) (bool, error)
 	// ListBinaries lists the binaries in a directory.
 	ListBinaries(path string) ([]string, error)
+	// ListDirs lists the directories in a directory.
+	ListDirs(path string) ([]string, error)
+	// ListSymlinks lists the symlinks in a directory.
+	ListSymlinks(path string) ([]string, error)
 	// LocateBinaryInPath locates a binary in the PATH environment variable.
 	LocateBinaryInPath(name string) []string
 	// Move moves a file or directory.
 	Move(source, target string) error
 	// MoveWithSymlink moves a file and creates a symlink to the original file.
 	MoveWithSymlink(source, target string) error
+	// OpenFile opens a file for reading.
+	OpenFile(path string) (io.ReadCloser, error)
+	// ReadFile reads the contents of a file.
+	ReadFile(path string) ([]byte, error)
 	// Remove removes a file or directory.
 	Remove(path string) error
+	// RemoveAll removes a path and any children it contains.
+	RemoveAll(path string) error
 	// ReplaceSymlink replaces a symlink with a new source.
 	ReplaceSymlink(source, target string) error
 	// GetSymlinkTarget gets the target of a symlink.
 	GetSymlinkTarget(path string) (string, error)
+	// WriteFile writes data to a file with the given permissions.
+	WriteFile(path string, data []byte, perm os.FileMode) error
+	// WriteZip writes files to a zip archive.
+	WriteZip(path string, files map[string][]byte) error
 }
 
 // fileSystem is the default implementation of the FileSystem interface.
@@ -43,10 +101,107 @@ func NewFileSystem() FileSystem {
 	return &fileSystem{}
 }
 
+// Copy replaces the target file with a copy of the source file, preserving the
+// file permissions. The copy is written next to the target and renamed over
+// it, so a running target binary is never truncated. It returns an error if
+// the file cannot be copied.
+func (fs *fileSystem) Copy(source, target string) error {
+	logger := slog.Default().With("source", source, "target", target)
+
+	tempTarget := target + ".tmp"
+	if err := fs.copyFile(source, tempTarget); err != nil {
+		logger.Error("error while copying file", "err", err)
+		_ = os.Remove(extendedPath(tempTarget))
+		return err
+	}
+
+	if err := os.Rename(extendedPath(tempTarget), extendedPath(target)); err != nil {
+		logger.Error("error while replacing file", "err", err)
+		_ = os.Remove(extendedPath(tempTarget))
+		return err
+	}
+
+	return nil
+}
+
+// CreateArchive creates a gzip compressed tar archive at the given path with
+// the directory of the given name, relative to the given directory, ex.
+// cache/download, keeping the relative name in the archive entries. Only
+// directories and regular files are archived. It returns an error if the
+// directory cannot be walked or the archive cannot be written.
+func (fs *fileSystem) CreateArchive(path, dir, name string) error {
+	logger := slog.Default().With("path", path, "dir", dir, "name", name)
+
+	//nolint:mnd // owner only permissions
+	file, err := os.OpenFile(extendedPath(path), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		logger.Error("error while creating archive", "err", err)
+		return err
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err = filepath.WalkDir(filepath.Join(dir, name), func(entryPath string, entry iofs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if !entry.IsDir() && !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			return infoErr
+		}
+
+		header, headerErr := tar.FileInfoHeader(info, "")
+		if headerErr != nil {
+			return headerErr
+		}
+
+		relPath, relErr := filepath.Rel(dir, entryPath)
+		if relErr != nil {
+			return relErr
+		}
+
+		header.Name = filepath.ToSlash(relPath)
+		if entry.IsDir() {
+			header.Name += "/"
+		}
+
+		if writeErr := tarWriter.WriteHeader(header); writeErr != nil {
+			return writeErr
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		return fs.archiveFile(tarWriter, entryPath)
+	})
+	if err != nil {
+		logger.Error("error while writing archive", "err", err)
+		return err
+	}
+
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+
+	if err = gzipWriter.Close(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
 // CreateDir creates a directory with the given path and permissions. It returns
 // an error if the directory cannot be created.
 func (fs *fileSystem) CreateDir(path string, perm os.FileMode) error {
-	return os.MkdirAll(path, perm)
+	return os.MkdirAll(extendedPath(path), perm)
 }
 
 // CreateTempDir creates a temporary directory with the given path and pattern.
@@ -55,14 +210,15 @@ func (fs *fileSystem) CreateDir(path string, perm os.FileMode) error {
 func (fs *fileSystem) CreateTempDir(dir, pattern string) (string, CleanupFunc, error) {
 	logger := slog.Default().With("dir", dir, "pattern", pattern)
 
-	tempDir, err := os.MkdirTemp(dir, pattern)
+	tempDir, err := os.MkdirTemp(extendedPath(dir), pattern)
 	if err != nil {
 		logger.Error("error while creating temp dir", "err", err)
 		return "", nil, err
 	}
+	tempDir = filepath.Join(dir, filepath.Base(tempDir))
 
 	cleanup := func() error {
-		if rmErr := os.RemoveAll(tempDir); rmErr != nil {
+		if rmErr := os.RemoveAll(extendedPath(tempDir)); rmErr != nil {
 			logger.Error("error while removing temp dir", "err", rmErr)
 			return rmErr
 		}
@@ -73,11 +229,224 @@ func (fs *fileSystem) CreateTempDir(dir, pattern string) (string, CleanupFunc, e
 	return tempDir, cleanup, nil
 }
 
+// CreateUniversalBinary merges single architecture macOS (Mach-O) binaries into
+// a universal (fat) binary at the target path, like lipo -create. The first
+// source is the architecture read by tools that do not support universal
+// binaries, ex. the build info. It returns an error if a source cannot be read
+// or is not a Mach-O binary, or the target cannot be written.
+func (fs *fileSystem) CreateUniversalBinary(target string, sources ...string) error {
+	logger := slog.Default().With("target", target, "sources", sources)
+
+	const fatHeaderSize, fatArchSize = 8, 20
+
+	headers := make([]macho.FatArchHeader, 0, len(sources))
+	datas := make([][]byte, 0, len(sources))
+	offset := alignUniversal(fatHeaderSize + fatArchSize*len(sources))
+
+	for _, source := range sources {
+		data, err := os.ReadFile(extendedPath(source))
+		if err != nil {
+			logger.Error("error while reading binary", "err", err, "source", source)
+			return err
+		}
+
+		f, err := macho.NewFile(bytes.NewReader(data))
+		if err != nil {
+			logger.Error("error while parsing mach-o binary", "err", err, "source", source)
+			return err
+		}
+
+		headers = append(headers, macho.FatArchHeader{
+			Cpu:    f.Cpu,
+			SubCpu: f.SubCpu,
+			Offset: uint32(offset),    //nolint:gosec // binaries are smaller than 4GB
+			Size:   uint32(len(data)), //nolint:gosec // binaries are smaller than 4GB
+			Align:  universalAlign,
+		})
+		datas = append(datas, data)
+		offset = alignUniversal(offset + len(data))
+	}
+
+	var buf bytes.Buffer
+	//nolint:gosec // a universal binary has a few architectures
+	_ = binary.Write(&buf, binary.BigEndian, [2]uint32{macho.MagicFat, uint32(len(sources))})
+	_ = binary.Write(&buf, binary.BigEndian, headers)
+
+	for i, data := range datas {
+		buf.Write(make([]byte, int(headers[i].Offset)-buf.Len()))
+		buf.Write(data)
+	}
+
+	//nolint:gosec // universal binaries must be executable
+	if err := os.WriteFile(extendedPath(target), buf.Bytes(), 0755); err != nil {
+		logger.Error("error while writing universal binary", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// Exists checks if a path exists, following symlinks.
+func (fs *fileSystem) Exists(path string) bool {
+	_, err := os.Stat(extendedPath(path))
+	return err == nil
+}
+
+// ExtractArchive extracts the gzip compressed tar archive at the given path
+// into the given directory, creating the directories of its entries. Only
+// directories and regular files are extracted, the latter made writable by the
+// owner. It returns an error if the archive cannot be read, or
+// an entry is not local to the directory.
+func (fs *fileSystem) ExtractArchive(path, dir string) error {
+	logger := slog.Default().With("path", path, "dir", dir)
+
+	file, err := os.Open(extendedPath(path))
+	if err != nil {
+		logger.Error("error while opening archive", "err", err)
+		return err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		logger.Error("error while reading archive", "err", err)
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, nextErr := tarReader.Next()
+		if errors.Is(nextErr, io.EOF) {
+			return nil
+		} else if nextErr != nil {
+			logger.Error("error while reading archive", "err", nextErr)
+			return nextErr
+		}
+
+		if !filepath.IsLocal(filepath.FromSlash(header.Name)) {
+			logger.Error("archive entry not local to the directory", "entry", header.Name)
+			return fmt.Errorf("archive entry %q not local to the directory", header.Name)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			//nolint:mnd // directory permissions
+			if err = os.MkdirAll(extendedPath(target), 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = fs.extractFile(tarReader, target, header.FileInfo().Mode()); err != nil {
+				logger.Error("error while extracting archive entry", "entry", header.Name, "err", err)
+				return err
+			}
+		}
+	}
+}
+
+// GetELFInterpreter gets the program interpreter (dynamic linker) of an ELF
+// binary, ex. /lib64/ld-linux-x86-64.so.2 for glibc. It returns an empty
+// string if the binary is not an ELF file or is statically linked, or an error
+// if the binary cannot be read.
+func (fs *fileSystem) GetELFInterpreter(path string) (string, error) {
+	logger := slog.Default().With("path", path)
+
+	f, err := elf.Open(path)
+	if err != nil {
+		var formatErr *elf.FormatError
+		if errors.As(err, &formatErr) {
+			return "", nil
+		}
+
+		logger.Error("error while opening elf file", "err", err)
+		return "", err
+	}
+	defer f.Close()
+
+	for _, prog := range f.Progs {
+		if prog.Type != elf.PT_INTERP {
+			continue
+		}
+
+		interp, readErr := io.ReadAll(prog.Open())
+		if readErr != nil {
+			logger.Error("error while reading elf interpreter", "err", readErr)
+			return "", readErr
+		}
+
+		return strings.TrimRight(string(interp), "\x00"), nil
+	}
+
+	return "", nil
+}
+
+// GetFileSize gets the size of a file in bytes, following symlinks.
+func (fs *fileSystem) GetFileSize(path string) (int64, error) {
+	info, err := os.Stat(extendedPath(path))
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+// GetLatestModTime gets the latest modification time of the regular files in a
+// directory and its subdirectories, skipping the hidden ones, ex. .git.
+func (fs *fileSystem) GetLatestModTime(dir string) (time.Time, error) {
+	var latest time.Time
+
+	err := filepath.WalkDir(extendedPath(dir), func(path string, entry iofs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if strings.HasPrefix(entry.Name(), ".") && path != extendedPath(dir) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			return infoErr
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+
+		return nil
+	})
+	if err != nil {
+		slog.Default().Error("error while getting latest modification time", "dir", dir, "err", err)
+		return time.Time{}, err
+	}
+
+	return latest, nil
+}
+
+// GetModTime gets the modification time of a file, following symlinks.
+func (fs *fileSystem) GetModTime(path string) (time.Time, error) {
+	info, err := os.Stat(extendedPath(path))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return info.ModTime(), nil
+}
+
 // IsSymlinkToDir checks if a path is a symlink to another directory.
 func (fs *fileSystem) IsSymlinkToDir(path string, baseDir string) (bool, error) {
 	logger := slog.Default().With("path", path, "base_dir", baseDir)
 
-	info, err := os.Lstat(path)
+	info, err := os.Lstat(extendedPath(path))
 	if err != nil {
 		logger.Error("error while getting symlink info", "err", err)
 		return false, err
@@ -87,7 +456,7 @@ func (fs *fileSystem) IsSymlinkToDir(path string, baseDir string) (bool, error)
 		return false, nil
 	}
 
-	target, err := os.Readlink(path)
+	target, err := os.Readlink(extendedPath(path))
 	if err != nil {
 		logger.Error("error while reading symlink", "err", err)
 		return false, err
@@ -101,7 +470,7 @@ func (fs *fileSystem) IsSymlinkToDir(path string, baseDir string) (bool, error)
 func (fs *fileSystem) ListBinaries(path string) ([]string, error) {
 	logger := slog.Default().With("path", path)
 
-	entries, err := os.ReadDir(path)
+	entries, err := os.ReadDir(extendedPath(path))
 	if err != nil {
 		logger.Error("error while listing directory", "err", err)
 		return nil, err
@@ -118,6 +487,48 @@ func (fs *fileSystem) ListBinaries(path string) ([]string, error) {
 	return binaries, nil
 }
 
+// ListDirs lists the directories in a directory, symlinks excluded. It returns
+// an error if the directory cannot be read.
+func (fs *fileSystem) ListDirs(path string) ([]string, error) {
+	logger := slog.Default().With("path", path)
+
+	entries, err := os.ReadDir(extendedPath(path))
+	if err != nil {
+		logger.Error("error while listing directory", "err", err)
+		return nil, err
+	}
+
+	dirs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	return dirs, nil
+}
+
+// ListSymlinks lists the symlinks in a directory, whether their target exists
+// or not. It returns an error if the directory cannot be read.
+func (fs *fileSystem) ListSymlinks(path string) ([]string, error) {
+	logger := slog.Default().With("path", path)
+
+	entries, err := os.ReadDir(extendedPath(path))
+	if err != nil {
+		logger.Error("error while listing directory", "err", err)
+		return nil, err
+	}
+
+	symlinks := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSymlink != 0 {
+			symlinks = append(symlinks, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	return symlinks, nil
+}
+
 // LocateBinaryInPath locates a binary in the PATH environment variable. It
 // returns a list of full paths to the binary, or an empty list if the binary is
 // not found.
@@ -135,10 +546,24 @@ func (fs *fileSystem) LocateBinaryInPath(name string) []string {
 	return locations
 }
 
-// Move moves a file or directory. It returns an error if the file or directory
-// cannot be moved.
+// Move moves a file or directory. When the source and target are on different
+// devices, it falls back to copying the file and removing the source. It
+// returns an error if the file or directory cannot be moved.
 func (fs *fileSystem) Move(source, target string) error {
-	return os.Rename(source, target)
+	err := os.Rename(extendedPath(source), extendedPath(target))
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	logger := slog.Default().With("source", source, "target", target)
+	logger.Info("source and target on different devices, copying file")
+
+	if err = fs.copyFile(source, target); err != nil {
+		logger.Error("error while copying file", "err", err)
+		return err
+	}
+
+	return os.Remove(extendedPath(source))
 }
 
 // MoveWithSymlink moves a file and creates a symlink to the original file. It
@@ -146,12 +571,12 @@ func (fs *fileSystem) Move(source, target string) error {
 func (fs *fileSystem) MoveWithSymlink(source, target string) error {
 	logger := slog.Default().With("source", source, "target", target)
 
-	if err := os.Rename(source, target); err != nil {
+	if err := os.Rename(extendedPath(source), extendedPath(target)); err != nil {
 		logger.Error("error while moving file", "err", err)
 		return err
 	}
 
-	if err := os.Symlink(target, source); err != nil {
+	if err := os.Symlink(target, extendedPath(source)); err != nil {
 		logger.Error("error while creating symlink", "err", err)
 		return err
 	}
@@ -159,10 +584,33 @@ func (fs *fileSystem) MoveWithSymlink(source, target string) error {
 	return nil
 }
 
+// OpenFile opens the file at the given path for reading. It returns an error
+// if the file cannot be opened.
+func (fs *fileSystem) OpenFile(path string) (io.ReadCloser, error) {
+	file, err := os.Open(extendedPath(path))
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// ReadFile reads the contents of a file. It returns an error if the file cannot
+// be read.
+func (fs *fileSystem) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(extendedPath(path))
+}
+
 // Remove removes a file or directory. It returns an error if the file or
 // directory cannot be removed.
 func (fs *fileSystem) Remove(path string) error {
-	return os.Remove(path)
+	return os.Remove(extendedPath(path))
+}
+
+// RemoveAll removes a path and any children it contains. It returns nil if the
+// path does not exist, or an error if the path cannot be removed.
+func (fs *fileSystem) RemoveAll(path string) error {
+	return os.RemoveAll(extendedPath(path))
 }
 
 // ReplaceSymlink replaces a symlink with a new source. It returns an error if
@@ -170,12 +618,12 @@ func (fs *fileSystem) Remove(path string) error {
 func (fs *fileSystem) ReplaceSymlink(source, target string) error {
 	logger := slog.Default().With("source", source, "target", target)
 
-	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+	if err := os.Remove(extendedPath(target)); err != nil && !os.IsNotExist(err) {
 		logger.Error("error while removing symlink", "err", err)
 		return err
 	}
 
-	if err := os.Symlink(source, target); err != nil {
+	if err := os.Symlink(source, extendedPath(target)); err != nil {
 		logger.Error("error while creating symlink", "err", err)
 		return err
 	}
@@ -185,20 +633,120 @@ func (fs *fileSystem) ReplaceSymlink(source, target string) error {
 
 // GetSymlinkTarget gets the target of a symlink.
 func (fs *fileSystem) GetSymlinkTarget(path string) (string, error) {
-	return os.Readlink(path)
+	return os.Readlink(extendedPath(path))
+}
+
+// WriteFile writes data to a file with the given permissions, creating the file
+// if it does not exist or truncating it otherwise. It returns an error if the
+// file cannot be written.
+func (fs *fileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(extendedPath(path), data, perm)
+}
+
+// WriteZip writes the given files, keyed by their name in the archive, to a zip
+// archive at the given path, in name order. It returns an error if the archive
+// cannot be written.
+func (fs *fileSystem) WriteZip(path string, files map[string][]byte) error {
+	//nolint:mnd // owner only permissions
+	file, err := os.OpenFile(extendedPath(path), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		entry, createErr := archive.Create(name)
+		if createErr != nil {
+			return createErr
+		}
+
+		if _, err = entry.Write(files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err = archive.Close(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// archiveFile writes the content of the file at the given path to the writer
+// of an archive entry.
+func (fs *fileSystem) archiveFile(writer io.Writer, path string) error {
+	file, err := os.Open(extendedPath(path))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(writer, file)
+	return err
+}
+
+// copyFile copies a regular file from source to target, preserving the file
+// permissions. It returns an error if the file cannot be copied.
+func (fs *fileSystem) copyFile(source, target string) error {
+	src, err := os.Open(extendedPath(source))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(extendedPath(target), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+
+	return dst.Close()
+}
+
+// extractFile writes the content of the reader to the file at the given path,
+// creating its directory, with the permissions of the given mode made writable
+// by the owner, so the extracted files can be removed.
+func (fs *fileSystem) extractFile(reader io.Reader, path string, mode os.FileMode) error {
+	//nolint:mnd // directory permissions
+	if err := os.MkdirAll(extendedPath(filepath.Dir(path)), 0755); err != nil {
+		return err
+	}
+
+	//nolint:mnd // owner read and write permissions
+	file, err := os.OpenFile(extendedPath(path), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(file, reader); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
 }
 
 // isBinary checks if a path is a binary file. It returns true if the path is a
 // regular file and executable for Unix, or if it is a Windows executable.
 func (fs *fileSystem) isBinary(path string) bool {
-	info, err := os.Stat(path)
+	info, err := os.Stat(extendedPath(path))
 	if err != nil || info.IsDir() {
 		return false
 	}
 
 	//nolint:goconst,nolintlint
 	if runtime.GOOS == "windows" {
-		f, openErr := os.Open(path)
+		f, openErr := os.Open(extendedPath(path))
 		if openErr != nil {
 			return false
 		}
@@ -214,3 +762,29 @@ func (fs *fileSystem) isBinary(path string) bool {
 
 	return info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0
 }
+
+// alignUniversal aligns an offset of a macOS universal binary to the alignment
+// of its architectures.
+func alignUniversal(offset int) int {
+	const size = 1 << universalAlign
+	return (offset + size - 1) &^ (size - 1)
+}
+
+// extendedPath extends an absolute path with the \\?\ prefix on Windows, or
+// \\?\UNC\ for network paths, lifting the MAX_PATH limit of the Windows API
+// for the deep paths of the internal binary directory. Other paths, and paths
+// on other OSes, are returned as is. Extended paths are not normalized by
+// Windows, so the path is cleaned first.
+func extendedPath(path string) string {
+	//nolint:goconst,nolintlint
+	if runtime.GOOS != "windows" || !filepath.IsAbs(path) || strings.HasPrefix(path, `\\?\`) {
+		return path
+	}
+
+	path = filepath.Clean(path)
+	if strings.HasPrefix(path, `\\`) {
+		return `\\?\UNC\` + path[2:]
+	}
+
+	return `\\?\` + path
+}
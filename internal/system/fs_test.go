@@ -1,10 +1,19 @@
 package system_test
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"debug/macho"
+	"encoding/binary"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,6 +21,67 @@ import (
 	"github.com/brunoribeiro127/gobin/internal/system"
 )
 
+func TestFileSystem_Copy(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+	source := filepath.Join(tempDir, "source")
+	target := filepath.Join(tempDir, "target")
+
+	require.NoError(t, os.WriteFile(source, []byte("new"), 0700))
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0600))
+
+	err := fs.Copy(source, target)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), data)
+
+	if runtime.GOOS != "windows" {
+		stat, statErr := os.Stat(target)
+		require.NoError(t, statErr)
+		assert.Equal(t, os.FileMode(0700), stat.Mode().Perm())
+	}
+
+	_, err = os.Stat(target + ".tmp")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	err = fs.Copy(filepath.Join(tempDir, "missing"), target)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileSystem_CreateArchive(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+		zipDir := filepath.Join(dir, "cache", "download", "example.com", "mockproj", "@v")
+		require.NoError(t, os.MkdirAll(zipDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(zipDir, "v1.0.0.zip"), []byte("aaa"), 0444))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte("b"), 0600))
+
+		path := filepath.Join(t.TempDir(), "test.bundle")
+		require.NoError(t, fs.CreateArchive(path, dir, filepath.Join("cache", "download")))
+
+		target := t.TempDir()
+		require.NoError(t, fs.ExtractArchive(path, target))
+
+		zipPath := filepath.Join(target, "cache", "download", "example.com", "mockproj", "@v", "v1.0.0.zip")
+		data, err := os.ReadFile(zipPath)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("aaa"), data)
+		assert.NoFileExists(t, filepath.Join(target, "ignored.txt"))
+	})
+
+	t.Run("error-dir-not-found", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.bundle")
+
+		err := fs.CreateArchive(path, t.TempDir(), "missing")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
 func TestFileSystem_CreateDir(t *testing.T) {
 	fs := system.NewFileSystem()
 
@@ -44,6 +114,214 @@ func TestFileSystem_CreateTempDir(t *testing.T) {
 	assert.ErrorIs(t, err, os.ErrNotExist)
 }
 
+func TestFileSystem_CreateUniversalBinary(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	writeMachO := func(name string, cpu macho.Cpu) string {
+		var buf bytes.Buffer
+		require.NoError(t, binary.Write(&buf, binary.LittleEndian, macho.FileHeader{
+			Magic: macho.Magic64,
+			Cpu:   cpu,
+			Type:  macho.TypeExec,
+		}))
+		buf.Write(make([]byte, 4)) // reserved field of the 64-bit header
+
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0755))
+		return path
+	}
+
+	arm64 := writeMachO("bin-arm64", macho.CpuArm64)
+	amd64 := writeMachO("bin-amd64", macho.CpuAmd64)
+
+	target := filepath.Join(tempDir, "bin")
+	err := fs.CreateUniversalBinary(target, arm64, amd64)
+	require.NoError(t, err)
+
+	fat, err := macho.OpenFat(target)
+	require.NoError(t, err)
+	defer fat.Close()
+
+	require.Len(t, fat.Arches, 2)
+	assert.Equal(t, macho.CpuArm64, fat.Arches[0].Cpu)
+	assert.Equal(t, macho.CpuAmd64, fat.Arches[1].Cpu)
+	assert.Equal(t, uint32(1<<14), fat.Arches[0].Offset)
+	assert.Equal(t, uint32(2<<14), fat.Arches[1].Offset)
+
+	err = fs.CreateUniversalBinary(target, filepath.Join(tempDir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "script"), []byte("#!/bin/sh\n"), 0755))
+	err = fs.CreateUniversalBinary(target, filepath.Join(tempDir, "script"))
+	require.Error(t, err)
+}
+
+func TestFileSystem_Exists(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tempDir, "file"), []byte("content"), 0600)
+	require.NoError(t, err)
+
+	assert.True(t, fs.Exists(filepath.Join(tempDir, "file")))
+	assert.False(t, fs.Exists(filepath.Join(tempDir, "missing")))
+}
+
+func TestFileSystem_ExtractArchive(t *testing.T) {
+	writeArchive := func(t *testing.T, headers ...*tar.Header) string {
+		path := filepath.Join(t.TempDir(), "test.bundle")
+
+		var buf bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buf)
+		tarWriter := tar.NewWriter(gzipWriter)
+		for _, header := range headers {
+			require.NoError(t, tarWriter.WriteHeader(header))
+			_, err := tarWriter.Write([]byte(strings.Repeat("a", int(header.Size))))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tarWriter.Close())
+		require.NoError(t, gzipWriter.Close())
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+
+		return path
+	}
+
+	fs := system.NewFileSystem()
+
+	t.Run("success", func(t *testing.T) {
+		path := writeArchive(
+			t,
+			&tar.Header{Name: "cache/download/", Typeflag: tar.TypeDir, Mode: 0755},
+			&tar.Header{
+				Name:     "cache/download/example.com/mockproj/@v/v1.0.0.zip",
+				Typeflag: tar.TypeReg,
+				Mode:     0444,
+				Size:     3,
+			},
+			&tar.Header{Name: "cache/download/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+		)
+		dir := t.TempDir()
+
+		require.NoError(t, fs.ExtractArchive(path, dir))
+
+		data, err := os.ReadFile(filepath.Join(dir, "cache", "download", "example.com", "mockproj", "@v", "v1.0.0.zip"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("aaa"), data)
+		assert.NoFileExists(t, filepath.Join(dir, "cache", "download", "link"))
+	})
+
+	t.Run("error-entry-not-local", func(t *testing.T) {
+		path := writeArchive(t, &tar.Header{Name: "../escape.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 1})
+		dir := t.TempDir()
+
+		err := fs.ExtractArchive(path, dir)
+		assert.EqualError(t, err, `archive entry "../escape.txt" not local to the directory`)
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(dir), "escape.txt"))
+	})
+
+	t.Run("error-not-gzip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.bundle")
+		require.NoError(t, os.WriteFile(path, []byte("not an archive"), 0600))
+
+		assert.Error(t, fs.ExtractArchive(path, t.TempDir()))
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		err := fs.ExtractArchive(filepath.Join(t.TempDir(), "missing.bundle"), t.TempDir())
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestFileSystem_GetELFInterpreter(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tempDir, "script"), []byte("#!/bin/sh\n"), 0755)
+	require.NoError(t, err)
+
+	interpreter, err := fs.GetELFInterpreter(filepath.Join(tempDir, "script"))
+	require.NoError(t, err)
+	assert.Empty(t, interpreter)
+
+	_, err = fs.GetELFInterpreter(filepath.Join(tempDir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileSystem_GetFileSize(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tempDir, "file"), []byte("content"), 0600)
+	require.NoError(t, err)
+
+	size, err := fs.GetFileSize(filepath.Join(tempDir, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), size)
+
+	_, err = fs.GetFileSize(filepath.Join(tempDir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileSystem_GetLatestModTime(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+	modTime := time.Date(2025, 7, 29, 19, 14, 54, 0, time.UTC)
+
+	err := os.MkdirAll(filepath.Join(tempDir, "cmd", "mockproj"), 0700)
+	require.NoError(t, err)
+
+	err = os.MkdirAll(filepath.Join(tempDir, ".git"), 0700)
+	require.NoError(t, err)
+
+	for path, mtime := range map[string]time.Time{
+		"go.mod":                   modTime,
+		"cmd/mockproj/main.go":     modTime.Add(time.Hour),
+		".git/index":               modTime.Add(2 * time.Hour),
+		"cmd/mockproj/.main.go.sw": modTime.Add(3 * time.Hour),
+	} {
+		path = filepath.Join(tempDir, filepath.FromSlash(path))
+
+		err = os.WriteFile(path, []byte{}, 0600)
+		require.NoError(t, err)
+
+		err = os.Chtimes(path, mtime, mtime)
+		require.NoError(t, err)
+	}
+
+	latest, err := fs.GetLatestModTime(tempDir)
+	require.NoError(t, err)
+	assert.True(t, modTime.Add(time.Hour).Equal(latest))
+
+	_, err = fs.GetLatestModTime(filepath.Join(tempDir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileSystem_GetModTime(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+	modTime := time.Date(2025, 7, 29, 19, 14, 54, 0, time.UTC)
+
+	err := os.WriteFile(filepath.Join(tempDir, "file"), []byte("content"), 0600)
+	require.NoError(t, err)
+
+	err = os.Chtimes(filepath.Join(tempDir, "file"), modTime, modTime)
+	require.NoError(t, err)
+
+	mtime, err := fs.GetModTime(filepath.Join(tempDir, "file"))
+	require.NoError(t, err)
+	assert.True(t, modTime.Equal(mtime))
+
+	_, err = fs.GetModTime(filepath.Join(tempDir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestFileSystem_IsSymlinkToDir(t *testing.T) {
 	fs := system.NewFileSystem()
 
@@ -97,6 +375,44 @@ func TestFileSystem_ListBinaries(t *testing.T) {
 	}
 }
 
+func TestFileSystem_ListDirs(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	err := os.Mkdir(filepath.Join(tempDir, "dir"), 0700)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(tempDir, "file"), []byte{}, 0644)
+	require.NoError(t, err)
+
+	dirs, err := fs.ListDirs(tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(tempDir, "dir")}, dirs)
+
+	_, err = fs.ListDirs(filepath.Join(tempDir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestFileSystem_ListSymlinks(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tempDir, "bin"), []byte{}, 0755)
+	require.NoError(t, err)
+
+	err = os.Symlink(filepath.Join(tempDir, "bin"), filepath.Join(tempDir, "link"))
+	require.NoError(t, err)
+
+	err = os.Symlink(filepath.Join(tempDir, "missing"), filepath.Join(tempDir, "broken"))
+	require.NoError(t, err)
+
+	symlinks, err := fs.ListSymlinks(tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(tempDir, "broken"), filepath.Join(tempDir, "link")}, symlinks)
+}
+
 func TestFileSystem_LocateBinaryInPath(t *testing.T) {
 	fs := system.NewFileSystem()
 
@@ -172,6 +488,42 @@ func TestFileSystem_MoveWithSymlink(t *testing.T) {
 	assert.True(t, info.Mode().IsRegular())
 }
 
+func TestFileSystem_OpenFile(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tempDir, "file"), []byte("content"), 0600)
+	require.NoError(t, err)
+
+	file, err := fs.OpenFile(filepath.Join(tempDir, "file"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content"), data)
+
+	_, err = fs.OpenFile(filepath.Join(tempDir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileSystem_ReadFile(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tempDir, "file"), []byte("content"), 0600)
+	require.NoError(t, err)
+
+	data, err := fs.ReadFile(filepath.Join(tempDir, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content"), data)
+
+	_, err = fs.ReadFile(filepath.Join(tempDir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestFileSystem_Remove(t *testing.T) {
 	fs := system.NewFileSystem()
 
@@ -196,6 +548,27 @@ func TestFileSystem_Remove(t *testing.T) {
 	require.ErrorIs(t, err, os.ErrNotExist)
 }
 
+func TestFileSystem_RemoveAll(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	err := os.MkdirAll(filepath.Join(tempDir, "dir", "subdir"), 0700)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(tempDir, "dir", "subdir", "file"), []byte{}, 0600)
+	require.NoError(t, err)
+
+	err = fs.RemoveAll(filepath.Join(tempDir, "dir"))
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(tempDir, "dir"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	err = fs.RemoveAll(filepath.Join(tempDir, "dir"))
+	require.NoError(t, err)
+}
+
 func TestFileSystem_ReplaceSymlink(t *testing.T) {
 	fs := system.NewFileSystem()
 
@@ -233,3 +606,86 @@ func TestFileSystem_GetSymlinkTarget(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, filepath.Join(tempDir, "bin1"), target)
 }
+
+func TestFileSystem_WriteFile(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	tempDir := t.TempDir()
+
+	err := fs.WriteFile(filepath.Join(tempDir, "file"), []byte("content"), 0600)
+	require.NoError(t, err)
+
+	err = fs.WriteFile(filepath.Join(tempDir, "file"), []byte("new"), 0600)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), data)
+}
+
+func TestFileSystem_WriteZip(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	path := filepath.Join(t.TempDir(), "test.zip")
+
+	err := fs.WriteZip(path, map[string][]byte{
+		"b.txt":      []byte("b"),
+		"dir/a.txt":  []byte("a"),
+		"empty.yaml": nil,
+	})
+	require.NoError(t, err)
+
+	archive, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer archive.Close()
+
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	assert.Equal(t, []string{"b.txt", "dir/a.txt", "empty.yaml"}, names)
+
+	entry, err := archive.File[1].Open()
+	require.NoError(t, err)
+	defer entry.Close()
+
+	data, err := io.ReadAll(entry)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a"), data)
+
+	err = fs.WriteZip(filepath.Join(t.TempDir(), "missing", "test.zip"), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileSystem_LongPath(t *testing.T) {
+	fs := system.NewFileSystem()
+
+	dir := filepath.Join(
+		t.TempDir(),
+		strings.Repeat("a", 100),
+		strings.Repeat("b", 100),
+		strings.Repeat("c", 100),
+	)
+	require.Greater(t, len(dir), system.WindowsMaxPath)
+
+	require.NoError(t, fs.CreateDir(dir, 0700))
+
+	tempDir, cleanup, err := fs.CreateTempDir(dir, "test-*")
+	require.NoError(t, err)
+	assert.Equal(t, dir, filepath.Dir(tempDir))
+
+	source := filepath.Join(tempDir, "test@v1.2.3-0.20250714171936-2fc2d3f24795")
+	require.NoError(t, fs.WriteFile(source, []byte("test"), 0600))
+
+	target := filepath.Join(dir, filepath.Base(source))
+	require.NoError(t, fs.Move(source, target))
+	assert.True(t, fs.Exists(target))
+
+	data, err := fs.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("test"), data)
+
+	require.NoError(t, cleanup())
+	require.NoError(t, fs.Remove(target))
+	assert.False(t, fs.Exists(target))
+}
@@ -95,6 +95,162 @@ func (_c *Environment_Get_Call) RunAndReturn(run func(key string) (string, bool)
 	return _c
 }
 
+// List provides a mock function for the type Environment
+func (_mock *Environment) List() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// Environment_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type Environment_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+func (_e *Environment_Expecter) List() *Environment_List_Call {
+	return &Environment_List_Call{Call: _e.mock.On("List")}
+}
+
+func (_c *Environment_List_Call) Run(run func()) *Environment_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Environment_List_Call) Return(strings []string) *Environment_List_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *Environment_List_Call) RunAndReturn(run func() []string) *Environment_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type Environment
+func (_mock *Environment) Set(key string, value string) error {
+	ret := _mock.Called(key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Environment_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type Environment_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - key string
+//   - value string
+func (_e *Environment_Expecter) Set(key interface{}, value interface{}) *Environment_Set_Call {
+	return &Environment_Set_Call{Call: _e.mock.On("Set", key, value)}
+}
+
+func (_c *Environment_Set_Call) Run(run func(key string, value string)) *Environment_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Environment_Set_Call) Return(err error) *Environment_Set_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Environment_Set_Call) RunAndReturn(run func(key string, value string) error) *Environment_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserConfigDir provides a mock function for the type Environment
+func (_mock *Environment) UserConfigDir() (string, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserConfigDir")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (string, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Environment_UserConfigDir_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserConfigDir'
+type Environment_UserConfigDir_Call struct {
+	*mock.Call
+}
+
+// UserConfigDir is a helper method to define mock.On call
+func (_e *Environment_Expecter) UserConfigDir() *Environment_UserConfigDir_Call {
+	return &Environment_UserConfigDir_Call{Call: _e.mock.On("UserConfigDir")}
+}
+
+func (_c *Environment_UserConfigDir_Call) Run(run func()) *Environment_UserConfigDir_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Environment_UserConfigDir_Call) Return(s string, err error) *Environment_UserConfigDir_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *Environment_UserConfigDir_Call) RunAndReturn(run func() (string, error)) *Environment_UserConfigDir_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserHomeDir provides a mock function for the type Environment
 func (_mock *Environment) UserHomeDir() (string, error) {
 	ret := _mock.Called()
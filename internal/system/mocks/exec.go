@@ -38,6 +38,80 @@ func (_m *Exec) EXPECT() *Exec_Expecter {
 	return &Exec_Expecter{mock: &_m.Mock}
 }
 
+// Attach provides a mock function for the type Exec
+func (_mock *Exec) Attach(ctx context.Context, name string, args ...string) system.ExecAttach {
+	var tmpRet mock.Arguments
+	if len(args) > 0 {
+		tmpRet = _mock.Called(ctx, name, args)
+	} else {
+		tmpRet = _mock.Called(ctx, name)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Attach")
+	}
+
+	var r0 system.ExecAttach
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, ...string) system.ExecAttach); ok {
+		r0 = returnFunc(ctx, name, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(system.ExecAttach)
+		}
+	}
+	return r0
+}
+
+// Exec_Attach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Attach'
+type Exec_Attach_Call struct {
+	*mock.Call
+}
+
+// Attach is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - args ...string
+func (_e *Exec_Expecter) Attach(ctx interface{}, name interface{}, args ...interface{}) *Exec_Attach_Call {
+	return &Exec_Attach_Call{Call: _e.mock.On("Attach",
+		append([]interface{}{ctx, name}, args...)...)}
+}
+
+func (_c *Exec_Attach_Call) Run(run func(ctx context.Context, name string, args ...string)) *Exec_Attach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		var variadicArgs []string
+		if len(args) > 2 {
+			variadicArgs = args[2].([]string)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *Exec_Attach_Call) Return(execAttach system.ExecAttach) *Exec_Attach_Call {
+	_c.Call.Return(execAttach)
+	return _c
+}
+
+func (_c *Exec_Attach_Call) RunAndReturn(run func(ctx context.Context, name string, args ...string) system.ExecAttach) *Exec_Attach_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CombinedOutput provides a mock function for the type Exec
 func (_mock *Exec) CombinedOutput(ctx context.Context, name string, args ...string) system.ExecCombinedOutput {
 	var tmpRet mock.Arguments
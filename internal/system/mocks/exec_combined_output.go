@@ -89,3 +89,51 @@ func (_c *ExecCombinedOutput_CombinedOutput_Call) RunAndReturn(run func() ([]byt
 	_c.Call.Return(run)
 	return _c
 }
+
+// InjectEnv provides a mock function for the type ExecCombinedOutput
+func (_mock *ExecCombinedOutput) InjectEnv(env ...string) {
+	if len(env) > 0 {
+		_mock.Called(env)
+	} else {
+		_mock.Called()
+	}
+
+	return
+}
+
+// ExecCombinedOutput_InjectEnv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InjectEnv'
+type ExecCombinedOutput_InjectEnv_Call struct {
+	*mock.Call
+}
+
+// InjectEnv is a helper method to define mock.On call
+//   - env ...string
+func (_e *ExecCombinedOutput_Expecter) InjectEnv(env ...interface{}) *ExecCombinedOutput_InjectEnv_Call {
+	return &ExecCombinedOutput_InjectEnv_Call{Call: _e.mock.On("InjectEnv",
+		append([]interface{}{}, env...)...)}
+}
+
+func (_c *ExecCombinedOutput_InjectEnv_Call) Run(run func(env ...string)) *ExecCombinedOutput_InjectEnv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		var variadicArgs []string
+		if len(args) > 0 {
+			variadicArgs = args[0].([]string)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *ExecCombinedOutput_InjectEnv_Call) Return() *ExecCombinedOutput_InjectEnv_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ExecCombinedOutput_InjectEnv_Call) RunAndReturn(run func(env ...string)) *ExecCombinedOutput_InjectEnv_Call {
+	_c.Run(run)
+	return _c
+}
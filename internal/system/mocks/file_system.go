@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	"io"
 	"os"
+	"time"
 
 	"github.com/brunoribeiro127/gobin/internal/system"
 	mock "github.com/stretchr/testify/mock"
@@ -38,6 +40,126 @@ func (_m *FileSystem) EXPECT() *FileSystem_Expecter {
 	return &FileSystem_Expecter{mock: &_m.Mock}
 }
 
+// Copy provides a mock function for the type FileSystem
+func (_mock *FileSystem) Copy(source string, target string) error {
+	ret := _mock.Called(source, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Copy")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(source, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// FileSystem_Copy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Copy'
+type FileSystem_Copy_Call struct {
+	*mock.Call
+}
+
+// Copy is a helper method to define mock.On call
+//   - source string
+//   - target string
+func (_e *FileSystem_Expecter) Copy(source interface{}, target interface{}) *FileSystem_Copy_Call {
+	return &FileSystem_Copy_Call{Call: _e.mock.On("Copy", source, target)}
+}
+
+func (_c *FileSystem_Copy_Call) Run(run func(source string, target string)) *FileSystem_Copy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_Copy_Call) Return(err error) *FileSystem_Copy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *FileSystem_Copy_Call) RunAndReturn(run func(source string, target string) error) *FileSystem_Copy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateArchive provides a mock function for the type FileSystem
+func (_mock *FileSystem) CreateArchive(path string, dir string, name string) error {
+	ret := _mock.Called(path, dir, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateArchive")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(path, dir, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// FileSystem_CreateArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateArchive'
+type FileSystem_CreateArchive_Call struct {
+	*mock.Call
+}
+
+// CreateArchive is a helper method to define mock.On call
+//   - path string
+//   - dir string
+//   - name string
+func (_e *FileSystem_Expecter) CreateArchive(path interface{}, dir interface{}, name interface{}) *FileSystem_CreateArchive_Call {
+	return &FileSystem_CreateArchive_Call{Call: _e.mock.On("CreateArchive", path, dir, name)}
+}
+
+func (_c *FileSystem_CreateArchive_Call) Run(run func(path string, dir string, name string)) *FileSystem_CreateArchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_CreateArchive_Call) Return(err error) *FileSystem_CreateArchive_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *FileSystem_CreateArchive_Call) RunAndReturn(run func(path string, dir string, name string) error) *FileSystem_CreateArchive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateDir provides a mock function for the type FileSystem
 func (_mock *FileSystem) CreateDir(path string, perm os.FileMode) error {
 	ret := _mock.Called(path, perm)
@@ -169,6 +291,360 @@ func (_c *FileSystem_CreateTempDir_Call) RunAndReturn(run func(dir string, patte
 	return _c
 }
 
+// CreateUniversalBinary provides a mock function for the type FileSystem
+func (_mock *FileSystem) CreateUniversalBinary(target string, sources ...string) error {
+	var tmpRet mock.Arguments
+	if len(sources) > 0 {
+		tmpRet = _mock.Called(target, sources)
+	} else {
+		tmpRet = _mock.Called(target)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUniversalBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, ...string) error); ok {
+		r0 = returnFunc(target, sources...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// FileSystem_CreateUniversalBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUniversalBinary'
+type FileSystem_CreateUniversalBinary_Call struct {
+	*mock.Call
+}
+
+// CreateUniversalBinary is a helper method to define mock.On call
+//   - target string
+//   - sources ...string
+func (_e *FileSystem_Expecter) CreateUniversalBinary(target interface{}, sources ...interface{}) *FileSystem_CreateUniversalBinary_Call {
+	return &FileSystem_CreateUniversalBinary_Call{Call: _e.mock.On("CreateUniversalBinary",
+		append([]interface{}{target}, sources...)...)}
+}
+
+func (_c *FileSystem_CreateUniversalBinary_Call) Run(run func(target string, sources ...string)) *FileSystem_CreateUniversalBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_CreateUniversalBinary_Call) Return(err error) *FileSystem_CreateUniversalBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *FileSystem_CreateUniversalBinary_Call) RunAndReturn(run func(target string, sources ...string) error) *FileSystem_CreateUniversalBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type FileSystem
+func (_mock *FileSystem) Exists(path string) bool {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// FileSystem_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type FileSystem_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - path string
+func (_e *FileSystem_Expecter) Exists(path interface{}) *FileSystem_Exists_Call {
+	return &FileSystem_Exists_Call{Call: _e.mock.On("Exists", path)}
+}
+
+func (_c *FileSystem_Exists_Call) Run(run func(path string)) *FileSystem_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_Exists_Call) Return(b bool) *FileSystem_Exists_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *FileSystem_Exists_Call) RunAndReturn(run func(path string) bool) *FileSystem_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExtractArchive provides a mock function for the type FileSystem
+func (_mock *FileSystem) ExtractArchive(path string, dir string) error {
+	ret := _mock.Called(path, dir)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtractArchive")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(path, dir)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// FileSystem_ExtractArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtractArchive'
+type FileSystem_ExtractArchive_Call struct {
+	*mock.Call
+}
+
+// ExtractArchive is a helper method to define mock.On call
+//   - path string
+//   - dir string
+func (_e *FileSystem_Expecter) ExtractArchive(path interface{}, dir interface{}) *FileSystem_ExtractArchive_Call {
+	return &FileSystem_ExtractArchive_Call{Call: _e.mock.On("ExtractArchive", path, dir)}
+}
+
+func (_c *FileSystem_ExtractArchive_Call) Run(run func(path string, dir string)) *FileSystem_ExtractArchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_ExtractArchive_Call) Return(err error) *FileSystem_ExtractArchive_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *FileSystem_ExtractArchive_Call) RunAndReturn(run func(path string, dir string) error) *FileSystem_ExtractArchive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetELFInterpreter provides a mock function for the type FileSystem
+func (_mock *FileSystem) GetELFInterpreter(path string) (string, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetELFInterpreter")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// FileSystem_GetELFInterpreter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetELFInterpreter'
+type FileSystem_GetELFInterpreter_Call struct {
+	*mock.Call
+}
+
+// GetELFInterpreter is a helper method to define mock.On call
+//   - path string
+func (_e *FileSystem_Expecter) GetELFInterpreter(path interface{}) *FileSystem_GetELFInterpreter_Call {
+	return &FileSystem_GetELFInterpreter_Call{Call: _e.mock.On("GetELFInterpreter", path)}
+}
+
+func (_c *FileSystem_GetELFInterpreter_Call) Run(run func(path string)) *FileSystem_GetELFInterpreter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_GetELFInterpreter_Call) Return(s string, err error) *FileSystem_GetELFInterpreter_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *FileSystem_GetELFInterpreter_Call) RunAndReturn(run func(path string) (string, error)) *FileSystem_GetELFInterpreter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileSize provides a mock function for the type FileSystem
+func (_mock *FileSystem) GetFileSize(path string) (int64, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileSize")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// FileSystem_GetFileSize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileSize'
+type FileSystem_GetFileSize_Call struct {
+	*mock.Call
+}
+
+// GetFileSize is a helper method to define mock.On call
+//   - path string
+func (_e *FileSystem_Expecter) GetFileSize(path interface{}) *FileSystem_GetFileSize_Call {
+	return &FileSystem_GetFileSize_Call{Call: _e.mock.On("GetFileSize", path)}
+}
+
+func (_c *FileSystem_GetFileSize_Call) Run(run func(path string)) *FileSystem_GetFileSize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_GetFileSize_Call) Return(n int64, err error) *FileSystem_GetFileSize_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *FileSystem_GetFileSize_Call) RunAndReturn(run func(path string) (int64, error)) *FileSystem_GetFileSize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestModTime provides a mock function for the type FileSystem
+func (_mock *FileSystem) GetLatestModTime(dir string) (time.Time, error) {
+	ret := _mock.Called(dir)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestModTime")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (time.Time, error)); ok {
+		return returnFunc(dir)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) time.Time); ok {
+		r0 = returnFunc(dir)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(dir)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// FileSystem_GetLatestModTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestModTime'
+type FileSystem_GetLatestModTime_Call struct {
+	*mock.Call
+}
+
+// GetLatestModTime is a helper method to define mock.On call
+//   - dir string
+func (_e *FileSystem_Expecter) GetLatestModTime(dir interface{}) *FileSystem_GetLatestModTime_Call {
+	return &FileSystem_GetLatestModTime_Call{Call: _e.mock.On("GetLatestModTime", dir)}
+}
+
+func (_c *FileSystem_GetLatestModTime_Call) Run(run func(dir string)) *FileSystem_GetLatestModTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_GetLatestModTime_Call) Return(time1 time.Time, err error) *FileSystem_GetLatestModTime_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *FileSystem_GetLatestModTime_Call) RunAndReturn(run func(dir string) (time.Time, error)) *FileSystem_GetLatestModTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSymlinkTarget provides a mock function for the type FileSystem
 func (_mock *FileSystem) GetSymlinkTarget(path string) (string, error) {
 	ret := _mock.Called(path)
@@ -229,6 +705,66 @@ func (_c *FileSystem_GetSymlinkTarget_Call) RunAndReturn(run func(path string) (
 	return _c
 }
 
+// GetModTime provides a mock function for the type FileSystem
+func (_mock *FileSystem) GetModTime(path string) (time.Time, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetModTime")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (time.Time, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) time.Time); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// FileSystem_GetModTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetModTime'
+type FileSystem_GetModTime_Call struct {
+	*mock.Call
+}
+
+// GetModTime is a helper method to define mock.On call
+//   - path string
+func (_e *FileSystem_Expecter) GetModTime(path interface{}) *FileSystem_GetModTime_Call {
+	return &FileSystem_GetModTime_Call{Call: _e.mock.On("GetModTime", path)}
+}
+
+func (_c *FileSystem_GetModTime_Call) Run(run func(path string)) *FileSystem_GetModTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_GetModTime_Call) Return(modTime time.Time, err error) *FileSystem_GetModTime_Call {
+	_c.Call.Return(modTime, err)
+	return _c
+}
+
+func (_c *FileSystem_GetModTime_Call) RunAndReturn(run func(path string) (time.Time, error)) *FileSystem_GetModTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsSymlinkToDir provides a mock function for the type FileSystem
 func (_mock *FileSystem) IsSymlinkToDir(path string, baseDir string) (bool, error) {
 	ret := _mock.Called(path, baseDir)
@@ -242,65 +778,189 @@ func (_mock *FileSystem) IsSymlinkToDir(path string, baseDir string) (bool, erro
 	if returnFunc, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
 		return returnFunc(path, baseDir)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string) bool); ok {
-		r0 = returnFunc(path, baseDir)
+	if returnFunc, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = returnFunc(path, baseDir)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = returnFunc(path, baseDir)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// FileSystem_IsSymlinkToDir_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsSymlinkToDir'
+type FileSystem_IsSymlinkToDir_Call struct {
+	*mock.Call
+}
+
+// IsSymlinkToDir is a helper method to define mock.On call
+//   - path string
+//   - baseDir string
+func (_e *FileSystem_Expecter) IsSymlinkToDir(path interface{}, baseDir interface{}) *FileSystem_IsSymlinkToDir_Call {
+	return &FileSystem_IsSymlinkToDir_Call{Call: _e.mock.On("IsSymlinkToDir", path, baseDir)}
+}
+
+func (_c *FileSystem_IsSymlinkToDir_Call) Run(run func(path string, baseDir string)) *FileSystem_IsSymlinkToDir_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_IsSymlinkToDir_Call) Return(b bool, err error) *FileSystem_IsSymlinkToDir_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *FileSystem_IsSymlinkToDir_Call) RunAndReturn(run func(path string, baseDir string) (bool, error)) *FileSystem_IsSymlinkToDir_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBinaries provides a mock function for the type FileSystem
+func (_mock *FileSystem) ListBinaries(path string) ([]string, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBinaries")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// FileSystem_ListBinaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBinaries'
+type FileSystem_ListBinaries_Call struct {
+	*mock.Call
+}
+
+// ListBinaries is a helper method to define mock.On call
+//   - path string
+func (_e *FileSystem_Expecter) ListBinaries(path interface{}) *FileSystem_ListBinaries_Call {
+	return &FileSystem_ListBinaries_Call{Call: _e.mock.On("ListBinaries", path)}
+}
+
+func (_c *FileSystem_ListBinaries_Call) Run(run func(path string)) *FileSystem_ListBinaries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_ListBinaries_Call) Return(strings []string, err error) *FileSystem_ListBinaries_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *FileSystem_ListBinaries_Call) RunAndReturn(run func(path string) ([]string, error)) *FileSystem_ListBinaries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDirs provides a mock function for the type FileSystem
+func (_mock *FileSystem) ListDirs(path string) ([]string, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDirs")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = returnFunc(path)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = returnFunc(path, baseDir)
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// FileSystem_IsSymlinkToDir_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsSymlinkToDir'
-type FileSystem_IsSymlinkToDir_Call struct {
+// FileSystem_ListDirs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDirs'
+type FileSystem_ListDirs_Call struct {
 	*mock.Call
 }
 
-// IsSymlinkToDir is a helper method to define mock.On call
+// ListDirs is a helper method to define mock.On call
 //   - path string
-//   - baseDir string
-func (_e *FileSystem_Expecter) IsSymlinkToDir(path interface{}, baseDir interface{}) *FileSystem_IsSymlinkToDir_Call {
-	return &FileSystem_IsSymlinkToDir_Call{Call: _e.mock.On("IsSymlinkToDir", path, baseDir)}
+func (_e *FileSystem_Expecter) ListDirs(path interface{}) *FileSystem_ListDirs_Call {
+	return &FileSystem_ListDirs_Call{Call: _e.mock.On("ListDirs", path)}
 }
 
-func (_c *FileSystem_IsSymlinkToDir_Call) Run(run func(path string, baseDir string)) *FileSystem_IsSymlinkToDir_Call {
+func (_c *FileSystem_ListDirs_Call) Run(run func(path string)) *FileSystem_ListDirs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *FileSystem_IsSymlinkToDir_Call) Return(b bool, err error) *FileSystem_IsSymlinkToDir_Call {
-	_c.Call.Return(b, err)
+func (_c *FileSystem_ListDirs_Call) Return(strings []string, err error) *FileSystem_ListDirs_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *FileSystem_IsSymlinkToDir_Call) RunAndReturn(run func(path string, baseDir string) (bool, error)) *FileSystem_IsSymlinkToDir_Call {
+func (_c *FileSystem_ListDirs_Call) RunAndReturn(run func(path string) ([]string, error)) *FileSystem_ListDirs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListBinaries provides a mock function for the type FileSystem
-func (_mock *FileSystem) ListBinaries(path string) ([]string, error) {
+// ListSymlinks provides a mock function for the type FileSystem
+func (_mock *FileSystem) ListSymlinks(path string) ([]string, error) {
 	ret := _mock.Called(path)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListBinaries")
+		panic("no return value specified for ListSymlinks")
 	}
 
 	var r0 []string
@@ -323,18 +983,18 @@ func (_mock *FileSystem) ListBinaries(path string) ([]string, error) {
 	return r0, r1
 }
 
-// FileSystem_ListBinaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBinaries'
-type FileSystem_ListBinaries_Call struct {
+// FileSystem_ListSymlinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSymlinks'
+type FileSystem_ListSymlinks_Call struct {
 	*mock.Call
 }
 
-// ListBinaries is a helper method to define mock.On call
+// ListSymlinks is a helper method to define mock.On call
 //   - path string
-func (_e *FileSystem_Expecter) ListBinaries(path interface{}) *FileSystem_ListBinaries_Call {
-	return &FileSystem_ListBinaries_Call{Call: _e.mock.On("ListBinaries", path)}
+func (_e *FileSystem_Expecter) ListSymlinks(path interface{}) *FileSystem_ListSymlinks_Call {
+	return &FileSystem_ListSymlinks_Call{Call: _e.mock.On("ListSymlinks", path)}
 }
 
-func (_c *FileSystem_ListBinaries_Call) Run(run func(path string)) *FileSystem_ListBinaries_Call {
+func (_c *FileSystem_ListSymlinks_Call) Run(run func(path string)) *FileSystem_ListSymlinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -347,12 +1007,12 @@ func (_c *FileSystem_ListBinaries_Call) Run(run func(path string)) *FileSystem_L
 	return _c
 }
 
-func (_c *FileSystem_ListBinaries_Call) Return(strings []string, err error) *FileSystem_ListBinaries_Call {
+func (_c *FileSystem_ListSymlinks_Call) Return(strings []string, err error) *FileSystem_ListSymlinks_Call {
 	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *FileSystem_ListBinaries_Call) RunAndReturn(run func(path string) ([]string, error)) *FileSystem_ListBinaries_Call {
+func (_c *FileSystem_ListSymlinks_Call) RunAndReturn(run func(path string) ([]string, error)) *FileSystem_ListSymlinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -524,6 +1184,130 @@ func (_c *FileSystem_MoveWithSymlink_Call) RunAndReturn(run func(source string,
 	return _c
 }
 
+// OpenFile provides a mock function for the type FileSystem
+func (_mock *FileSystem) OpenFile(path string) (io.ReadCloser, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenFile")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (io.ReadCloser, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) io.ReadCloser); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// FileSystem_OpenFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenFile'
+type FileSystem_OpenFile_Call struct {
+	*mock.Call
+}
+
+// OpenFile is a helper method to define mock.On call
+//   - path string
+func (_e *FileSystem_Expecter) OpenFile(path interface{}) *FileSystem_OpenFile_Call {
+	return &FileSystem_OpenFile_Call{Call: _e.mock.On("OpenFile", path)}
+}
+
+func (_c *FileSystem_OpenFile_Call) Run(run func(path string)) *FileSystem_OpenFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_OpenFile_Call) Return(readCloser io.ReadCloser, err error) *FileSystem_OpenFile_Call {
+	_c.Call.Return(readCloser, err)
+	return _c
+}
+
+func (_c *FileSystem_OpenFile_Call) RunAndReturn(run func(path string) (io.ReadCloser, error)) *FileSystem_OpenFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadFile provides a mock function for the type FileSystem
+func (_mock *FileSystem) ReadFile(path string) ([]byte, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadFile")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]byte, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// FileSystem_ReadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadFile'
+type FileSystem_ReadFile_Call struct {
+	*mock.Call
+}
+
+// ReadFile is a helper method to define mock.On call
+//   - path string
+func (_e *FileSystem_Expecter) ReadFile(path interface{}) *FileSystem_ReadFile_Call {
+	return &FileSystem_ReadFile_Call{Call: _e.mock.On("ReadFile", path)}
+}
+
+func (_c *FileSystem_ReadFile_Call) Run(run func(path string)) *FileSystem_ReadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_ReadFile_Call) Return(bytes []byte, err error) *FileSystem_ReadFile_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *FileSystem_ReadFile_Call) RunAndReturn(run func(path string) ([]byte, error)) *FileSystem_ReadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Remove provides a mock function for the type FileSystem
 func (_mock *FileSystem) Remove(path string) error {
 	ret := _mock.Called(path)
@@ -575,6 +1359,57 @@ func (_c *FileSystem_Remove_Call) RunAndReturn(run func(path string) error) *Fil
 	return _c
 }
 
+// RemoveAll provides a mock function for the type FileSystem
+func (_mock *FileSystem) RemoveAll(path string) error {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// FileSystem_RemoveAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveAll'
+type FileSystem_RemoveAll_Call struct {
+	*mock.Call
+}
+
+// RemoveAll is a helper method to define mock.On call
+//   - path string
+func (_e *FileSystem_Expecter) RemoveAll(path interface{}) *FileSystem_RemoveAll_Call {
+	return &FileSystem_RemoveAll_Call{Call: _e.mock.On("RemoveAll", path)}
+}
+
+func (_c *FileSystem_RemoveAll_Call) Run(run func(path string)) *FileSystem_RemoveAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_RemoveAll_Call) Return(err error) *FileSystem_RemoveAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *FileSystem_RemoveAll_Call) RunAndReturn(run func(path string) error) *FileSystem_RemoveAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReplaceSymlink provides a mock function for the type FileSystem
 func (_mock *FileSystem) ReplaceSymlink(source string, target string) error {
 	ret := _mock.Called(source, target)
@@ -631,3 +1466,123 @@ func (_c *FileSystem_ReplaceSymlink_Call) RunAndReturn(run func(source string, t
 	_c.Call.Return(run)
 	return _c
 }
+
+// WriteFile provides a mock function for the type FileSystem
+func (_mock *FileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
+	ret := _mock.Called(path, data, perm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, []byte, os.FileMode) error); ok {
+		r0 = returnFunc(path, data, perm)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// FileSystem_WriteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteFile'
+type FileSystem_WriteFile_Call struct {
+	*mock.Call
+}
+
+// WriteFile is a helper method to define mock.On call
+//   - path string
+//   - data []byte
+//   - perm os.FileMode
+func (_e *FileSystem_Expecter) WriteFile(path interface{}, data interface{}, perm interface{}) *FileSystem_WriteFile_Call {
+	return &FileSystem_WriteFile_Call{Call: _e.mock.On("WriteFile", path, data, perm)}
+}
+
+func (_c *FileSystem_WriteFile_Call) Run(run func(path string, data []byte, perm os.FileMode)) *FileSystem_WriteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 os.FileMode
+		if args[2] != nil {
+			arg2 = args[2].(os.FileMode)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_WriteFile_Call) Return(err error) *FileSystem_WriteFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *FileSystem_WriteFile_Call) RunAndReturn(run func(path string, data []byte, perm os.FileMode) error) *FileSystem_WriteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteZip provides a mock function for the type FileSystem
+func (_mock *FileSystem) WriteZip(path string, files map[string][]byte) error {
+	ret := _mock.Called(path, files)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteZip")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, map[string][]byte) error); ok {
+		r0 = returnFunc(path, files)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// FileSystem_WriteZip_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteZip'
+type FileSystem_WriteZip_Call struct {
+	*mock.Call
+}
+
+// WriteZip is a helper method to define mock.On call
+//   - path string
+//   - files map[string][]byte
+func (_e *FileSystem_Expecter) WriteZip(path interface{}, files interface{}) *FileSystem_WriteZip_Call {
+	return &FileSystem_WriteZip_Call{Call: _e.mock.On("WriteZip", path, files)}
+}
+
+func (_c *FileSystem_WriteZip_Call) Run(run func(path string, files map[string][]byte)) *FileSystem_WriteZip_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 map[string][]byte
+		if args[1] != nil {
+			arg1 = args[1].(map[string][]byte)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *FileSystem_WriteZip_Call) Return(err error) *FileSystem_WriteZip_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *FileSystem_WriteZip_Call) RunAndReturn(run func(path string, files map[string][]byte) error) *FileSystem_WriteZip_Call {
+	_c.Call.Return(run)
+	return _c
+}
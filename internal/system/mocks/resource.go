@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/brunoribeiro127/gobin/internal/model"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -37,6 +38,129 @@ func (_m *Resource) EXPECT() *Resource_Expecter {
 	return &Resource_Expecter{mock: &_m.Mock}
 }
 
+// GetPressure provides a mock function for the type Resource
+func (_mock *Resource) GetPressure(ctx context.Context) (model.SystemPressure, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPressure")
+	}
+
+	var r0 model.SystemPressure
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.SystemPressure, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.SystemPressure); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.SystemPressure)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Resource_GetPressure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPressure'
+type Resource_GetPressure_Call struct {
+	*mock.Call
+}
+
+// GetPressure is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Resource_Expecter) GetPressure(ctx interface{}) *Resource_GetPressure_Call {
+	return &Resource_GetPressure_Call{Call: _e.mock.On("GetPressure", ctx)}
+}
+
+func (_c *Resource_GetPressure_Call) Run(run func(ctx context.Context)) *Resource_GetPressure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Resource_GetPressure_Call) Return(systemPressure model.SystemPressure, err error) *Resource_GetPressure_Call {
+	_c.Call.Return(systemPressure, err)
+	return _c
+}
+
+func (_c *Resource_GetPressure_Call) RunAndReturn(run func(ctx context.Context) (model.SystemPressure, error)) *Resource_GetPressure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Notify provides a mock function for the type Resource
+func (_mock *Resource) Notify(ctx context.Context, title string, message string) error {
+	ret := _mock.Called(ctx, title, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Notify")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, title, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Resource_Notify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Notify'
+type Resource_Notify_Call struct {
+	*mock.Call
+}
+
+// Notify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - title string
+//   - message string
+func (_e *Resource_Expecter) Notify(ctx interface{}, title interface{}, message interface{}) *Resource_Notify_Call {
+	return &Resource_Notify_Call{Call: _e.mock.On("Notify", ctx, title, message)}
+}
+
+func (_c *Resource_Notify_Call) Run(run func(ctx context.Context, title string, message string)) *Resource_Notify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Resource_Notify_Call) Return(err error) *Resource_Notify_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Resource_Notify_Call) RunAndReturn(run func(ctx context.Context, title string, message string) error) *Resource_Notify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Open provides a mock function for the type Resource
 func (_mock *Resource) Open(ctx context.Context, resource string) error {
 	ret := _mock.Called(ctx, resource)
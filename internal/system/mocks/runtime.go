@@ -35,6 +35,50 @@ func (_m *Runtime) EXPECT() *Runtime_Expecter {
 	return &Runtime_Expecter{mock: &_m.Mock}
 }
 
+// IsWSL provides a mock function for the type Runtime
+func (_mock *Runtime) IsWSL() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsWSL")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// Runtime_IsWSL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsWSL'
+type Runtime_IsWSL_Call struct {
+	*mock.Call
+}
+
+// IsWSL is a helper method to define mock.On call
+func (_e *Runtime_Expecter) IsWSL() *Runtime_IsWSL_Call {
+	return &Runtime_IsWSL_Call{Call: _e.mock.On("IsWSL")}
+}
+
+func (_c *Runtime_IsWSL_Call) Run(run func()) *Runtime_IsWSL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Runtime_IsWSL_Call) Return(b bool) *Runtime_IsWSL_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *Runtime_IsWSL_Call) RunAndReturn(run func() bool) *Runtime_IsWSL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OS provides a mock function for the type Runtime
 func (_mock *Runtime) OS() string {
 	ret := _mock.Called()
@@ -167,6 +167,270 @@ func (_c *Workspace_GetInternalBinPath_Call) RunAndReturn(run func() string) *Wo
 	return _c
 }
 
+// GetInternalCachePath provides a mock function for the type Workspace
+func (_mock *Workspace) GetInternalCachePath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInternalCachePath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Workspace_GetInternalCachePath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInternalCachePath'
+type Workspace_GetInternalCachePath_Call struct {
+	*mock.Call
+}
+
+// GetInternalCachePath is a helper method to define mock.On call
+func (_e *Workspace_Expecter) GetInternalCachePath() *Workspace_GetInternalCachePath_Call {
+	return &Workspace_GetInternalCachePath_Call{Call: _e.mock.On("GetInternalCachePath")}
+}
+
+func (_c *Workspace_GetInternalCachePath_Call) Run(run func()) *Workspace_GetInternalCachePath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Workspace_GetInternalCachePath_Call) Return(s string) *Workspace_GetInternalCachePath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Workspace_GetInternalCachePath_Call) RunAndReturn(run func() string) *Workspace_GetInternalCachePath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInternalConfigPath provides a mock function for the type Workspace
+func (_mock *Workspace) GetInternalConfigPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInternalConfigPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Workspace_GetInternalConfigPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInternalConfigPath'
+type Workspace_GetInternalConfigPath_Call struct {
+	*mock.Call
+}
+
+// GetInternalConfigPath is a helper method to define mock.On call
+func (_e *Workspace_Expecter) GetInternalConfigPath() *Workspace_GetInternalConfigPath_Call {
+	return &Workspace_GetInternalConfigPath_Call{Call: _e.mock.On("GetInternalConfigPath")}
+}
+
+func (_c *Workspace_GetInternalConfigPath_Call) Run(run func()) *Workspace_GetInternalConfigPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Workspace_GetInternalConfigPath_Call) Return(s string) *Workspace_GetInternalConfigPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Workspace_GetInternalConfigPath_Call) RunAndReturn(run func() string) *Workspace_GetInternalConfigPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInternalDataPath provides a mock function for the type Workspace
+func (_mock *Workspace) GetInternalDataPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInternalDataPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Workspace_GetInternalDataPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInternalDataPath'
+type Workspace_GetInternalDataPath_Call struct {
+	*mock.Call
+}
+
+// GetInternalDataPath is a helper method to define mock.On call
+func (_e *Workspace_Expecter) GetInternalDataPath() *Workspace_GetInternalDataPath_Call {
+	return &Workspace_GetInternalDataPath_Call{Call: _e.mock.On("GetInternalDataPath")}
+}
+
+func (_c *Workspace_GetInternalDataPath_Call) Run(run func()) *Workspace_GetInternalDataPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Workspace_GetInternalDataPath_Call) Return(s string) *Workspace_GetInternalDataPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Workspace_GetInternalDataPath_Call) RunAndReturn(run func() string) *Workspace_GetInternalDataPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInternalLogPath provides a mock function for the type Workspace
+func (_mock *Workspace) GetInternalLogPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInternalLogPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Workspace_GetInternalLogPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInternalLogPath'
+type Workspace_GetInternalLogPath_Call struct {
+	*mock.Call
+}
+
+// GetInternalLogPath is a helper method to define mock.On call
+func (_e *Workspace_Expecter) GetInternalLogPath() *Workspace_GetInternalLogPath_Call {
+	return &Workspace_GetInternalLogPath_Call{Call: _e.mock.On("GetInternalLogPath")}
+}
+
+func (_c *Workspace_GetInternalLogPath_Call) Run(run func()) *Workspace_GetInternalLogPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Workspace_GetInternalLogPath_Call) Return(s string) *Workspace_GetInternalLogPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Workspace_GetInternalLogPath_Call) RunAndReturn(run func() string) *Workspace_GetInternalLogPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInternalReceiptPath provides a mock function for the type Workspace
+func (_mock *Workspace) GetInternalReceiptPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInternalReceiptPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Workspace_GetInternalReceiptPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInternalReceiptPath'
+type Workspace_GetInternalReceiptPath_Call struct {
+	*mock.Call
+}
+
+// GetInternalReceiptPath is a helper method to define mock.On call
+func (_e *Workspace_Expecter) GetInternalReceiptPath() *Workspace_GetInternalReceiptPath_Call {
+	return &Workspace_GetInternalReceiptPath_Call{Call: _e.mock.On("GetInternalReceiptPath")}
+}
+
+func (_c *Workspace_GetInternalReceiptPath_Call) Run(run func()) *Workspace_GetInternalReceiptPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Workspace_GetInternalReceiptPath_Call) Return(s string) *Workspace_GetInternalReceiptPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Workspace_GetInternalReceiptPath_Call) RunAndReturn(run func() string) *Workspace_GetInternalReceiptPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInternalShimPath provides a mock function for the type Workspace
+func (_mock *Workspace) GetInternalShimPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInternalShimPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Workspace_GetInternalShimPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInternalShimPath'
+type Workspace_GetInternalShimPath_Call struct {
+	*mock.Call
+}
+
+// GetInternalShimPath is a helper method to define mock.On call
+func (_e *Workspace_Expecter) GetInternalShimPath() *Workspace_GetInternalShimPath_Call {
+	return &Workspace_GetInternalShimPath_Call{Call: _e.mock.On("GetInternalShimPath")}
+}
+
+func (_c *Workspace_GetInternalShimPath_Call) Run(run func()) *Workspace_GetInternalShimPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Workspace_GetInternalShimPath_Call) Return(s string) *Workspace_GetInternalShimPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Workspace_GetInternalShimPath_Call) RunAndReturn(run func() string) *Workspace_GetInternalShimPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetInternalTempPath provides a mock function for the type Workspace
 func (_mock *Workspace) GetInternalTempPath() string {
 	ret := _mock.Called()
@@ -254,3 +518,43 @@ func (_c *Workspace_Initialize_Call) RunAndReturn(run func() error) *Workspace_I
 	_c.Call.Return(run)
 	return _c
 }
+
+// SetInternalBinPath provides a mock function for the type Workspace
+func (_mock *Workspace) SetInternalBinPath(path string) {
+	_mock.Called(path)
+	return
+}
+
+// Workspace_SetInternalBinPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetInternalBinPath'
+type Workspace_SetInternalBinPath_Call struct {
+	*mock.Call
+}
+
+// SetInternalBinPath is a helper method to define mock.On call
+//   - path string
+func (_e *Workspace_Expecter) SetInternalBinPath(path interface{}) *Workspace_SetInternalBinPath_Call {
+	return &Workspace_SetInternalBinPath_Call{Call: _e.mock.On("SetInternalBinPath", path)}
+}
+
+func (_c *Workspace_SetInternalBinPath_Call) Run(run func(path string)) *Workspace_SetInternalBinPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Workspace_SetInternalBinPath_Call) Return() *Workspace_SetInternalBinPath_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Workspace_SetInternalBinPath_Call) RunAndReturn(run func(path string)) *Workspace_SetInternalBinPath_Call {
+	_c.Run(run)
+	return _c
+}
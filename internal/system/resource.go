@@ -4,11 +4,36 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"runtime"
 	"strings"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+)
+
+const (
+	// procMeminfo is the file with the memory usage of the system on Linux.
+	procMeminfo = "/proc/meminfo"
+	// procLoadavg is the file with the load average of the system on Linux.
+	procLoadavg = "/proc/loadavg"
 )
 
+// windowsToastScript is the PowerShell script raising a toast notification on
+// Windows, formatted with the title and the message quoted for PowerShell.
+const windowsToastScript = `
+$type = [Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime]
+$template = $type::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode('%s')) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode('%s')) | Out-Null
+$type::CreateToastNotifier('gobin').Show([Windows.UI.Notifications.ToastNotification]::new($template))`
+
 // Resource is the interface for handling resources.
 type Resource interface {
+	// GetPressure gets the memory and CPU pressure of the system.
+	GetPressure(ctx context.Context) (model.SystemPressure, error)
+	// Notify raises a desktop notification using the default system tools.
+	Notify(ctx context.Context, title, message string) error
 	// Open opens a resource using the default system tools.
 	Open(ctx context.Context, resource string) error
 }
@@ -30,6 +55,70 @@ func NewResource(
 	}
 }
 
+// GetPressure gets the memory and CPU pressure of the system, reading the
+// /proc/meminfo and /proc/loadavg files on Linux, and running sysctl and
+// vm_stat on macOS. It returns an error if the pressure cannot be determined or
+// the platform is not supported.
+func (r *resource) GetPressure(ctx context.Context) (model.SystemPressure, error) {
+	logger := slog.Default()
+
+	var (
+		pressure model.SystemPressure
+		err      error
+	)
+
+	runtimeOS := r.runtime.OS()
+	switch runtimeOS {
+	case "darwin":
+		pressure, err = r.getDarwinPressure(ctx)
+	case "linux":
+		pressure, err = getLinuxPressure()
+	default:
+		err = fmt.Errorf("unsupported platform: %s", runtimeOS)
+	}
+
+	if err != nil {
+		logger.DebugContext(ctx, "error getting system pressure", "err", err)
+		return model.SystemPressure{}, err
+	}
+
+	return pressure, nil
+}
+
+// Notify raises a desktop notification with the given title and message using
+// the default system tools: osascript on macOS, notify-send on Linux and a
+// PowerShell toast on Windows. It returns an error if the notification cannot
+// be raised or the platform is not supported.
+func (r *resource) Notify(ctx context.Context, title, message string) error {
+	logger := slog.Default().With("title", title)
+
+	var cmd ExecCombinedOutput
+	runtimeOS := r.runtime.OS()
+	switch runtimeOS {
+	case "darwin":
+		script := fmt.Sprintf(
+			"display notification %s with title %s", appleScriptQuote(message), appleScriptQuote(title),
+		)
+		cmd = r.exec.CombinedOutput(ctx, "osascript", "-e", script)
+	case "linux":
+		cmd = r.exec.CombinedOutput(ctx, "notify-send", "--app-name=gobin", title, message)
+	case "windows": //nolint:goconst,nolintlint
+		script := fmt.Sprintf(windowsToastScript, powerShellQuote(title), powerShellQuote(message))
+		cmd = r.exec.CombinedOutput(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+	default:
+		err := fmt.Errorf("unsupported platform: %s", runtimeOS)
+		logger.ErrorContext(ctx, "error raising notification", "err", err)
+		return err
+	}
+
+	if err := runCombinedOutput(cmd); err != nil {
+		logger.ErrorContext(ctx, "error raising notification", "err", err)
+		return err
+	}
+
+	return nil
+}
+
 // Open opens a resource using the default system tools. It returns an
 // error if the resource cannot be opened or the platform is not supported.
 func (r *resource) Open(ctx context.Context, resource string) error {
@@ -50,6 +139,49 @@ func (r *resource) Open(ctx context.Context, resource string) error {
 		return err
 	}
 
+	if err := runCombinedOutput(cmd); err != nil {
+		logger.ErrorContext(ctx, "error opening resource", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// getDarwinPressure gets the memory and CPU pressure of a macOS system from the
+// output of sysctl and vm_stat.
+func (r *resource) getDarwinPressure(ctx context.Context) (model.SystemPressure, error) {
+	sysctl, err := r.exec.CombinedOutput(ctx, "sysctl", "-n", "hw.memsize", "vm.loadavg").CombinedOutput()
+	if err != nil {
+		return model.SystemPressure{}, err
+	}
+
+	vmStat, err := r.exec.CombinedOutput(ctx, "vm_stat").CombinedOutput()
+	if err != nil {
+		return model.SystemPressure{}, err
+	}
+
+	return model.ParseDarwinPressure(sysctl, vmStat, runtime.NumCPU())
+}
+
+// getLinuxPressure gets the memory and CPU pressure of a Linux system from the
+// /proc/meminfo and /proc/loadavg files.
+func getLinuxPressure() (model.SystemPressure, error) {
+	meminfo, err := os.ReadFile(procMeminfo)
+	if err != nil {
+		return model.SystemPressure{}, err
+	}
+
+	loadavg, err := os.ReadFile(procLoadavg)
+	if err != nil {
+		return model.SystemPressure{}, err
+	}
+
+	return model.ParseLinuxPressure(meminfo, loadavg, runtime.NumCPU())
+}
+
+// runCombinedOutput runs the command, adding its output to the error if the
+// command fails.
+func runCombinedOutput(cmd ExecCombinedOutput) error {
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		outputStr := strings.TrimSpace(string(output))
@@ -57,9 +189,18 @@ func (r *resource) Open(ctx context.Context, resource string) error {
 			err = fmt.Errorf("%w: %s", err, outputStr)
 		}
 
-		logger.ErrorContext(ctx, "error opening resource", "err", err)
 		return err
 	}
 
 	return nil
 }
+
+// appleScriptQuote returns the string as an AppleScript string literal.
+func appleScriptQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// powerShellQuote escapes the string for a PowerShell single-quoted string.
+func powerShellQuote(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
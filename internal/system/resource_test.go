@@ -3,14 +3,191 @@ package system_test
 import (
 	"context"
 	"errors"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/brunoribeiro127/gobin/internal/model"
 	"github.com/brunoribeiro127/gobin/internal/system"
 	"github.com/brunoribeiro127/gobin/internal/system/mocks"
 )
 
+func TestResource_GetPressure(t *testing.T) {
+	vmStat := "Mach Virtual Memory Statistics: (page size of 16384 bytes)\nPages free: 10000.\n"
+
+	cases := map[string]struct {
+		mockRuntimeOS    string
+		callSysctl       bool
+		mockSysctl       []byte
+		mockSysctlErr    error
+		callVMStat       bool
+		expectedPressure model.SystemPressure
+		expectedErr      error
+	}{
+		"success-darwin": {
+			mockRuntimeOS: "darwin",
+			callSysctl:    true,
+			mockSysctl:    []byte("17179869184\n{ 2.15 2.30 2.41 }\n"),
+			callVMStat:    true,
+			expectedPressure: model.SystemPressure{
+				MemoryTotal:     17179869184,
+				MemoryAvailable: 10000 * 16384,
+				Load:            2.15,
+				CPUs:            runtime.NumCPU(),
+			},
+		},
+		"error-darwin-sysctl": {
+			mockRuntimeOS: "darwin",
+			callSysctl:    true,
+			mockSysctlErr: errors.New("exit status 1"),
+			expectedErr:   errors.New("exit status 1"),
+		},
+		"error-unsupported-platform": {
+			mockRuntimeOS: "windows",
+			expectedErr:   errors.New("unsupported platform: windows"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := mocks.NewExec(t)
+			rt := mocks.NewRuntime(t)
+
+			rt.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+
+			if tc.callSysctl {
+				sysctlCmd := mocks.NewExecCombinedOutput(t)
+				exec.EXPECT().CombinedOutput(
+					context.Background(), "sysctl", []string{"-n", "hw.memsize", "vm.loadavg"},
+				).Return(sysctlCmd).Once()
+				sysctlCmd.EXPECT().CombinedOutput().Return(tc.mockSysctl, tc.mockSysctlErr).Once()
+			}
+
+			if tc.callVMStat {
+				vmStatCmd := mocks.NewExecCombinedOutput(t)
+				exec.EXPECT().CombinedOutput(context.Background(), "vm_stat").
+					Return(vmStatCmd).
+					Once()
+				vmStatCmd.EXPECT().CombinedOutput().Return([]byte(vmStat), nil).Once()
+			}
+
+			resource := system.NewResource(exec, rt)
+			pressure, err := resource.GetPressure(context.Background())
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedPressure, pressure)
+		})
+	}
+}
+
+func TestResource_GetPressure_Linux(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("reads the /proc files of Linux")
+	}
+
+	rt := mocks.NewRuntime(t)
+	rt.EXPECT().OS().Return("linux").Once()
+
+	resource := system.NewResource(nil, rt)
+	pressure, err := resource.GetPressure(context.Background())
+	require.NoError(t, err)
+	assert.Positive(t, pressure.MemoryTotal)
+	assert.Equal(t, runtime.NumCPU(), pressure.CPUs)
+}
+
+func TestResource_Notify(t *testing.T) {
+	cases := map[string]struct {
+		title         string
+		message       string
+		mockRuntimeOS string
+		callCmd       bool
+		mockCmdName   string
+		mockCmdArgs   []string
+		mockCmdOutput []byte
+		mockCmdErr    error
+		expectedErr   error
+	}{
+		"success-darwin": {
+			title:         "gobin",
+			message:       `1 outdated binary: "dlv"`,
+			mockRuntimeOS: "darwin",
+			callCmd:       true,
+			mockCmdName:   "osascript",
+			mockCmdArgs:   []string{"-e", `display notification "1 outdated binary: \"dlv\"" with title "gobin"`},
+		},
+		"success-linux": {
+			title:         "gobin",
+			message:       "1 outdated binary: dlv",
+			mockRuntimeOS: "linux",
+			callCmd:       true,
+			mockCmdName:   "notify-send",
+			mockCmdArgs:   []string{"--app-name=gobin", "gobin", "1 outdated binary: dlv"},
+		},
+		"success-windows": {
+			title:         "gobin",
+			message:       "1 outdated binary: it's dlv",
+			mockRuntimeOS: "windows",
+			callCmd:       true,
+			mockCmdName:   "powershell",
+			mockCmdArgs: []string{"-NoProfile", "-NonInteractive", "-Command", `
+$type = [Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime]
+$template = $type::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode('gobin')) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode('1 outdated binary: it''s dlv')) | Out-Null
+$type::CreateToastNotifier('gobin').Show([Windows.UI.Notifications.ToastNotification]::new($template))`},
+		},
+		"error-unsupported-platform": {
+			title:         "gobin",
+			message:       "1 outdated binary: dlv",
+			mockRuntimeOS: "unsupported",
+			expectedErr:   errors.New("unsupported platform: unsupported"),
+		},
+		"error-cmd-output": {
+			title:         "gobin",
+			message:       "1 outdated binary: dlv",
+			mockRuntimeOS: "linux",
+			callCmd:       true,
+			mockCmdName:   "notify-send",
+			mockCmdArgs:   []string{"--app-name=gobin", "gobin", "1 outdated binary: dlv"},
+			mockCmdOutput: []byte("cannot connect to the notification server"),
+			mockCmdErr:    errors.New("exit status 1"),
+			expectedErr:   errors.New("exit status 1: cannot connect to the notification server"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := mocks.NewExec(t)
+			execCmd := mocks.NewExecCombinedOutput(t)
+			runtime := mocks.NewRuntime(t)
+
+			runtime.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+
+			if tc.callCmd {
+				exec.EXPECT().CombinedOutput(context.Background(), tc.mockCmdName, tc.mockCmdArgs).
+					Return(execCmd).
+					Once()
+
+				execCmd.EXPECT().CombinedOutput().Return(tc.mockCmdOutput, tc.mockCmdErr).Once()
+			}
+
+			resource := system.NewResource(exec, runtime)
+			err := resource.Notify(context.Background(), tc.title, tc.message)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestResource_Open(t *testing.T) {
 	cases := map[string]struct {
 		resource      string
@@ -1,9 +1,19 @@
 package system
 
-import "runtime"
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// wslKernelRelease is the file holding the kernel release, which mentions
+// Microsoft when running under WSL.
+const wslKernelRelease = "/proc/sys/kernel/osrelease"
 
 // Runtime is the interface for the runtime.
 type Runtime interface {
+	// IsWSL checks if running under the Windows Subsystem for Linux.
+	IsWSL() bool
 	// OS returns the operating system.
 	OS() string
 	// Platform returns the platform in the format "os/arch".
@@ -20,6 +30,21 @@ func NewRuntime() Runtime {
 	return &rt{}
 }
 
+// IsWSL checks if running under the Windows Subsystem for Linux, detected by
+// the kernel release built by Microsoft.
+func (r *rt) IsWSL() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+
+	release, err := os.ReadFile(wslKernelRelease)
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(release)), "microsoft")
+}
+
 // OS returns the operating system.
 func (r *rt) OS() string {
 	return runtime.GOOS
@@ -0,0 +1,333 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// powerShellProfile is the file name of the PowerShell current user,
+	// current host profile.
+	powerShellProfile = "Microsoft.PowerShell_profile.ps1"
+	// windowsUserEnvironment is the registry key of the Windows user
+	// environment variables.
+	windowsUserEnvironment = `HKCU\Environment`
+)
+
+// PathAddition describes how a directory is added to the user PATH.
+type PathAddition struct {
+	// Dir is the directory added to the user PATH.
+	Dir string
+	// Target is the shell profile file, or the Windows registry key, changed.
+	Target string
+	// Change is the line appended to the shell profile file, or the value
+	// appended to the Windows user PATH.
+	Change string
+}
+
+// UserPath is the interface for handling the PATH of the user.
+type UserPath interface {
+	// Add adds a directory to the user PATH.
+	Add(ctx context.Context, addition PathAddition) error
+	// Contains checks if a directory is in the PATH of the current process.
+	Contains(dir string) bool
+	// CountAdditions counts the times a directory was added to the user PATH.
+	CountAdditions(addition PathAddition) (int, error)
+	// GetAddition gets how a directory is added to the user PATH.
+	GetAddition(dir string) (PathAddition, error)
+	// GetCompletionPath gets the completion script file of a shell.
+	GetCompletionPath(shell string) (string, error)
+	// GetShell gets the shell of the user.
+	GetShell() string
+	// GetShellProfile gets the profile file of a shell.
+	GetShellProfile(shell string) (string, error)
+	// RemoveDuplicateAdditions removes the repeated additions of a directory
+	// to the user PATH.
+	RemoveDuplicateAdditions(addition PathAddition) error
+}
+
+// userPath is the default implementation of the UserPath interface.
+type userPath struct {
+	env     Environment
+	exec    Exec
+	fs      FileSystem
+	runtime Runtime
+}
+
+// NewUserPath creates a new UserPath.
+func NewUserPath(
+	env Environment,
+	exec Exec,
+	fs FileSystem,
+	runtime Runtime,
+) UserPath {
+	return &userPath{
+		env:     env,
+		exec:    exec,
+		fs:      fs,
+		runtime: runtime,
+	}
+}
+
+// Add adds a directory to the user PATH. On Windows, the directory is appended
+// to the user Path environment variable in the registry. Otherwise, the change
+// is appended to the shell profile file, created if it does not exist. It
+// returns an error if the user PATH cannot be changed.
+func (p *userPath) Add(ctx context.Context, addition PathAddition) error {
+	logger := slog.Default().With("dir", addition.Dir, "target", addition.Target)
+
+	if addition.Target == windowsUserEnvironment {
+		script := fmt.Sprintf(
+			"$path = [Environment]::GetEnvironmentVariable('Path', 'User'); "+
+				"[Environment]::SetEnvironmentVariable('Path', ($path.TrimEnd(';') + ';%s').TrimStart(';'), 'User')",
+			strings.ReplaceAll(addition.Change, "'", "''"),
+		)
+
+		cmd := p.exec.CombinedOutput(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			outputStr := strings.TrimSpace(string(output))
+			if outputStr != "" {
+				err = fmt.Errorf("%w: %s", err, outputStr)
+			}
+
+			logger.ErrorContext(ctx, "error adding dir to user path", "err", err)
+			return err
+		}
+
+		return nil
+	}
+
+	content, err := p.fs.ReadFile(addition.Target)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.ErrorContext(ctx, "error reading shell profile", "err", err)
+		return err
+	}
+
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		content = append(content, '\n')
+	}
+
+	content = append(content, []byte(getAdditionBlock(addition))...)
+
+	//nolint:mnd // directory permissions
+	if err = p.fs.CreateDir(filepath.Dir(addition.Target), 0755); err != nil {
+		logger.ErrorContext(ctx, "error creating shell profile dir", "err", err)
+		return err
+	}
+
+	//nolint:mnd // file permissions
+	if err = p.fs.WriteFile(addition.Target, content, 0644); err != nil {
+		logger.ErrorContext(ctx, "error writing shell profile", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// Contains checks if a directory is in the PATH of the current process. The
+// comparison is case-insensitive on Windows.
+func (p *userPath) Contains(dir string) bool {
+	path, _ := p.env.Get("PATH")
+	dir = filepath.Clean(dir)
+
+	for entry := range strings.SplitSeq(path, string(filepath.ListSeparator)) {
+		if entry == "" {
+			continue
+		}
+
+		entry = filepath.Clean(entry)
+		if entry == dir || (p.runtime.OS() == "windows" && strings.EqualFold(entry, dir)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CountAdditions counts the times a directory was appended to the user PATH in
+// the shell profile file, ex. by running doctor --fix with another shell
+// profile sourcing the first one. The Windows user PATH is not inspected, so
+// its additions are not counted. It returns an error if the shell profile file
+// cannot be read.
+func (p *userPath) CountAdditions(addition PathAddition) (int, error) {
+	if addition.Target == windowsUserEnvironment {
+		return 0, nil
+	}
+
+	content, err := p.fs.ReadFile(addition.Target)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		slog.Default().Error("error reading shell profile", "target", addition.Target, "err", err)
+		return 0, err
+	}
+
+	return strings.Count(string(content), getAdditionBlock(addition)), nil
+}
+
+// GetAddition gets how a directory is added to the user PATH. On Windows, the
+// directory is appended to the user Path environment variable. Otherwise, the
+// change is appended to the profile file of the shell in the SHELL environment
+// variable. It returns an error if the user home directory cannot be
+// determined.
+func (p *userPath) GetAddition(dir string) (PathAddition, error) {
+	if p.runtime.OS() == "windows" {
+		return PathAddition{
+			Dir:    dir,
+			Target: windowsUserEnvironment,
+			Change: dir,
+		}, nil
+	}
+
+	shell, _ := p.env.Get("SHELL")
+	shell = filepath.Base(shell)
+
+	target, err := p.GetShellProfile(shell)
+	if err != nil {
+		return PathAddition{}, err
+	}
+
+	addition := PathAddition{
+		Dir:    dir,
+		Target: target,
+		Change: fmt.Sprintf(`export PATH="$PATH:%s"`, dir),
+	}
+
+	if shell == "fish" {
+		addition.Change = fmt.Sprintf(`fish_add_path --append "%s"`, dir)
+	}
+
+	return addition, nil
+}
+
+// GetCompletionPath gets the completion script file of a shell: bash uses the
+// bash-completion user directory ($XDG_DATA_HOME/bash-completion/completions),
+// zsh uses ~/.zfunc/_gobin, to be added to fpath, fish uses the fish
+// completions directory ($XDG_CONFIG_HOME/fish/completions) and powershell
+// uses the shell profile. It returns an error if the shell is not supported or
+// the user home directory cannot be determined.
+func (p *userPath) GetCompletionPath(shell string) (string, error) {
+	home, err := p.env.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch shell {
+	case "bash":
+		dataHome, ok := p.env.Get("XDG_DATA_HOME")
+		if !ok || dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+
+		return filepath.Join(dataHome, "bash-completion", "completions", "gobin"), nil
+	case "zsh":
+		return filepath.Join(home, ".zfunc", "_gobin"), nil
+	case "fish":
+		configHome, ok := p.env.Get("XDG_CONFIG_HOME")
+		if !ok || configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+
+		return filepath.Join(configHome, "fish", "completions", "gobin.fish"), nil
+	case "powershell":
+		return p.GetShellProfile(shell)
+	default:
+		return "", fmt.Errorf("completion not supported for shell %q", shell)
+	}
+}
+
+// GetShell gets the shell of the user from the SHELL environment variable, or
+// powershell on Windows when it is not set.
+func (p *userPath) GetShell() string {
+	if shell, ok := p.env.Get("SHELL"); ok && shell != "" {
+		return strings.TrimSuffix(filepath.Base(shell), ".exe")
+	}
+
+	if p.runtime.OS() == "windows" {
+		return "powershell"
+	}
+
+	return ""
+}
+
+// GetShellProfile gets the profile file of a shell: zsh uses ~/.zshrc, bash
+// uses ~/.bashrc (~/.bash_profile on macOS), fish uses
+// ~/.config/fish/config.fish, powershell uses the current user profile of
+// PowerShell 6 or higher and any other shell uses ~/.profile. It returns an
+// error if the user home directory cannot be determined.
+func (p *userPath) GetShellProfile(shell string) (string, error) {
+	home, err := p.env.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch shell {
+	case "zsh":
+		return filepath.Join(home, ".zshrc"), nil
+	case "bash":
+		if p.runtime.OS() == "darwin" {
+			return filepath.Join(home, ".bash_profile"), nil
+		}
+
+		return filepath.Join(home, ".bashrc"), nil
+	case "fish":
+		return filepath.Join(home, ".config", "fish", "config.fish"), nil
+	case "powershell":
+		if p.runtime.OS() == "windows" {
+			return filepath.Join(home, "Documents", "PowerShell", powerShellProfile), nil
+		}
+
+		return filepath.Join(home, ".config", "powershell", powerShellProfile), nil
+	default:
+		return filepath.Join(home, ".profile"), nil
+	}
+}
+
+// RemoveDuplicateAdditions removes the repeated additions of a directory to the
+// user PATH from the shell profile file, keeping the first one. The Windows
+// user PATH is left unchanged. It returns an error if the shell profile file
+// cannot be read or written.
+func (p *userPath) RemoveDuplicateAdditions(addition PathAddition) error {
+	if addition.Target == windowsUserEnvironment {
+		return nil
+	}
+
+	logger := slog.Default().With("dir", addition.Dir, "target", addition.Target)
+
+	content, err := p.fs.ReadFile(addition.Target)
+	if err != nil {
+		logger.Error("error reading shell profile", "err", err)
+		return err
+	}
+
+	text := string(content)
+	block := getAdditionBlock(addition)
+
+	first := strings.Index(text, block)
+	if first < 0 {
+		return nil
+	}
+
+	first += len(block)
+	text = text[:first] + strings.ReplaceAll(text[first:], block, "")
+
+	//nolint:mnd // file permissions
+	if err = p.fs.WriteFile(addition.Target, []byte(text), 0644); err != nil {
+		logger.Error("error writing shell profile", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// getAdditionBlock returns the block appended to a shell profile file to add a
+// directory to the user PATH.
+func getAdditionBlock(addition PathAddition) string {
+	return "\n# Added by gobin\n" + addition.Change + "\n"
+}
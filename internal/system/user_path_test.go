@@ -0,0 +1,641 @@
+package system_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/brunoribeiro127/gobin/internal/system"
+	"github.com/brunoribeiro127/gobin/internal/system/mocks"
+)
+
+func TestUserPath_Add(t *testing.T) {
+	cases := map[string]struct {
+		addition       system.PathAddition
+		callCmd        bool
+		mockCmdOutput  []byte
+		mockCmdErr     error
+		callReadFile   bool
+		mockReadFile   []byte
+		mockReadErr    error
+		callCreateDir  bool
+		mockCreateErr  error
+		callWriteFile  bool
+		expectedWrite  string
+		mockWriteErr   error
+		expectedErrMsg string
+	}{
+		"success-profile": {
+			addition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.zshrc",
+				Change: `export PATH="$PATH:/home/user/go/bin"`,
+			},
+			callReadFile:  true,
+			mockReadFile:  []byte("alias ll='ls -l'"),
+			callCreateDir: true,
+			callWriteFile: true,
+			expectedWrite: "alias ll='ls -l'\n\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\n",
+		},
+		"success-profile-not-exist": {
+			addition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.config/fish/config.fish",
+				Change: `fish_add_path --append "/home/user/go/bin"`,
+			},
+			callReadFile:  true,
+			mockReadErr:   os.ErrNotExist,
+			callCreateDir: true,
+			callWriteFile: true,
+			expectedWrite: "\n# Added by gobin\nfish_add_path --append \"/home/user/go/bin\"\n",
+		},
+		"success-windows": {
+			addition: system.PathAddition{
+				Dir:    `C:\Users\user\go\bin`,
+				Target: `HKCU\Environment`,
+				Change: `C:\Users\user\go\bin`,
+			},
+			callCmd: true,
+		},
+		"error-windows-cmd": {
+			addition: system.PathAddition{
+				Dir:    `C:\Users\user\go\bin`,
+				Target: `HKCU\Environment`,
+				Change: `C:\Users\user\go\bin`,
+			},
+			callCmd:        true,
+			mockCmdOutput:  []byte("access denied"),
+			mockCmdErr:     errors.New("exit status 1"),
+			expectedErrMsg: "exit status 1: access denied",
+		},
+		"error-read-file": {
+			addition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.profile",
+				Change: `export PATH="$PATH:/home/user/go/bin"`,
+			},
+			callReadFile:   true,
+			mockReadErr:    os.ErrPermission,
+			expectedErrMsg: os.ErrPermission.Error(),
+		},
+		"error-create-dir": {
+			addition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.profile",
+				Change: `export PATH="$PATH:/home/user/go/bin"`,
+			},
+			callReadFile:   true,
+			callCreateDir:  true,
+			mockCreateErr:  os.ErrPermission,
+			expectedErrMsg: os.ErrPermission.Error(),
+		},
+		"error-write-file": {
+			addition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.profile",
+				Change: `export PATH="$PATH:/home/user/go/bin"`,
+			},
+			callReadFile:   true,
+			mockReadFile:   []byte("umask 022\n"),
+			callCreateDir:  true,
+			callWriteFile:  true,
+			expectedWrite:  "umask 022\n\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\n",
+			mockWriteErr:   os.ErrPermission,
+			expectedErrMsg: os.ErrPermission.Error(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := mocks.NewExec(t)
+			execCmd := mocks.NewExecCombinedOutput(t)
+			fs := mocks.NewFileSystem(t)
+
+			if tc.callCmd {
+				exec.EXPECT().CombinedOutput(
+					context.Background(),
+					"powershell",
+					mock.MatchedBy(func(args []string) bool {
+						return len(args) == 4 &&
+							assert.Equal(t, []string{"-NoProfile", "-NonInteractive", "-Command"}, args[:3]) &&
+							assert.Contains(t, args[3], `';C:\Users\user\go\bin'`)
+					}),
+				).Return(execCmd).Once()
+
+				execCmd.EXPECT().CombinedOutput().Return(tc.mockCmdOutput, tc.mockCmdErr).Once()
+			}
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(tc.addition.Target).Return(tc.mockReadFile, tc.mockReadErr).Once()
+			}
+
+			if tc.callCreateDir {
+				fs.EXPECT().CreateDir(mock.Anything, os.FileMode(0755)).Return(tc.mockCreateErr).Once()
+			}
+
+			if tc.callWriteFile {
+				fs.EXPECT().WriteFile(
+					tc.addition.Target,
+					[]byte(tc.expectedWrite),
+					os.FileMode(0644),
+				).Return(tc.mockWriteErr).Once()
+			}
+
+			userPath := system.NewUserPath(nil, exec, fs, nil)
+			err := userPath.Add(context.Background(), tc.addition)
+			if tc.expectedErrMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUserPath_Contains(t *testing.T) {
+	cases := map[string]struct {
+		dir           string
+		mockPath      string
+		mockRuntimeOS string
+		expected      bool
+	}{
+		"in-path": {
+			dir:           "/home/user/go/bin",
+			mockPath:      "/usr/bin:/home/user/go/bin/",
+			mockRuntimeOS: "linux",
+			expected:      true,
+		},
+		"not-in-path": {
+			dir:           "/home/user/go/bin",
+			mockPath:      "/usr/bin::/usr/local/bin",
+			mockRuntimeOS: "linux",
+			expected:      false,
+		},
+		"case-sensitive": {
+			dir:           "/home/user/go/bin",
+			mockPath:      "/HOME/USER/GO/BIN",
+			mockRuntimeOS: "darwin",
+			expected:      false,
+		},
+		"case-insensitive-windows": {
+			dir:           "/home/user/go/bin",
+			mockPath:      "/HOME/USER/GO/BIN",
+			mockRuntimeOS: "windows",
+			expected:      true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := mocks.NewEnvironment(t)
+			runtime := mocks.NewRuntime(t)
+
+			env.EXPECT().Get("PATH").Return(tc.mockPath, true).Once()
+			runtime.EXPECT().OS().Return(tc.mockRuntimeOS).Maybe()
+
+			userPath := system.NewUserPath(env, nil, nil, runtime)
+			assert.Equal(t, tc.expected, userPath.Contains(tc.dir))
+		})
+	}
+}
+
+func TestUserPath_CountAdditions(t *testing.T) {
+	profileAddition := system.PathAddition{
+		Dir:    "/home/user/go/bin",
+		Target: "/home/user/.zshrc",
+		Change: `export PATH="$PATH:/home/user/go/bin"`,
+	}
+
+	cases := map[string]struct {
+		addition      system.PathAddition
+		callReadFile  bool
+		mockReadFile  []byte
+		mockReadErr   error
+		expectedCount int
+		expectedErr   error
+	}{
+		"success-profile": {
+			addition:     profileAddition,
+			callReadFile: true,
+			mockReadFile: []byte(
+				"alias ll='ls -l'\n\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\n" +
+					"\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\n",
+			),
+			expectedCount: 2,
+		},
+		"success-profile-not-exist": {
+			addition:     profileAddition,
+			callReadFile: true,
+			mockReadErr:  os.ErrNotExist,
+		},
+		"success-windows": {
+			addition: system.PathAddition{
+				Dir:    `C:\Users\user\go\bin`,
+				Target: `HKCU\Environment`,
+				Change: `C:\Users\user\go\bin`,
+			},
+		},
+		"error-read-file": {
+			addition:     profileAddition,
+			callReadFile: true,
+			mockReadErr:  os.ErrPermission,
+			expectedErr:  os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := mocks.NewFileSystem(t)
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(tc.addition.Target).Return(tc.mockReadFile, tc.mockReadErr).Once()
+			}
+
+			userPath := system.NewUserPath(nil, nil, fs, nil)
+			count, err := userPath.CountAdditions(tc.addition)
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestUserPath_GetAddition(t *testing.T) {
+	cases := map[string]struct {
+		mockRuntimeOS    string
+		callUserHomeDir  bool
+		mockUserHomeDir  string
+		mockUserHomeErr  error
+		callShell        bool
+		mockShell        string
+		expectedAddition system.PathAddition
+		expectedErr      error
+	}{
+		"zsh": {
+			mockRuntimeOS:   "darwin",
+			callUserHomeDir: true,
+			mockUserHomeDir: "/home/user",
+			callShell:       true,
+			mockShell:       "/bin/zsh",
+			expectedAddition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.zshrc",
+				Change: `export PATH="$PATH:/home/user/go/bin"`,
+			},
+		},
+		"bash-linux": {
+			mockRuntimeOS:   "linux",
+			callUserHomeDir: true,
+			mockUserHomeDir: "/home/user",
+			callShell:       true,
+			mockShell:       "/usr/bin/bash",
+			expectedAddition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.bashrc",
+				Change: `export PATH="$PATH:/home/user/go/bin"`,
+			},
+		},
+		"bash-darwin": {
+			mockRuntimeOS:   "darwin",
+			callUserHomeDir: true,
+			mockUserHomeDir: "/home/user",
+			callShell:       true,
+			mockShell:       "/bin/bash",
+			expectedAddition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.bash_profile",
+				Change: `export PATH="$PATH:/home/user/go/bin"`,
+			},
+		},
+		"fish": {
+			mockRuntimeOS:   "linux",
+			callUserHomeDir: true,
+			mockUserHomeDir: "/home/user",
+			callShell:       true,
+			mockShell:       "/usr/bin/fish",
+			expectedAddition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.config/fish/config.fish",
+				Change: `fish_add_path --append "/home/user/go/bin"`,
+			},
+		},
+		"other-shell": {
+			mockRuntimeOS:   "linux",
+			callUserHomeDir: true,
+			mockUserHomeDir: "/home/user",
+			callShell:       true,
+			mockShell:       "/bin/sh",
+			expectedAddition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: "/home/user/.profile",
+				Change: `export PATH="$PATH:/home/user/go/bin"`,
+			},
+		},
+		"windows": {
+			mockRuntimeOS: "windows",
+			expectedAddition: system.PathAddition{
+				Dir:    "/home/user/go/bin",
+				Target: `HKCU\Environment`,
+				Change: "/home/user/go/bin",
+			},
+		},
+		"error-user-home-dir": {
+			mockRuntimeOS:   "linux",
+			callUserHomeDir: true,
+			mockUserHomeErr: errors.New("unexpected error"),
+			callShell:       true,
+			mockShell:       "/bin/sh",
+			expectedErr:     errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := mocks.NewEnvironment(t)
+			runtime := mocks.NewRuntime(t)
+
+			runtime.EXPECT().OS().Return(tc.mockRuntimeOS)
+
+			if tc.callUserHomeDir {
+				env.EXPECT().UserHomeDir().Return(tc.mockUserHomeDir, tc.mockUserHomeErr).Once()
+			}
+
+			if tc.callShell {
+				env.EXPECT().Get("SHELL").Return(tc.mockShell, true).Once()
+			}
+
+			userPath := system.NewUserPath(env, nil, nil, runtime)
+			addition, err := userPath.GetAddition("/home/user/go/bin")
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedAddition, addition)
+		})
+	}
+}
+
+func TestUserPath_GetCompletionPath(t *testing.T) {
+	cases := map[string]struct {
+		shell           string
+		mockEnvVars     map[string]string
+		callRuntimeOS   bool
+		mockRuntimeOS   string
+		mockUserHomeErr error
+		expectedPath    string
+		expectedErr     error
+	}{
+		"bash": {
+			shell:        "bash",
+			expectedPath: "/home/user/.local/share/bash-completion/completions/gobin",
+		},
+		"bash-xdg-data-home": {
+			shell:        "bash",
+			mockEnvVars:  map[string]string{"XDG_DATA_HOME": "/home/user/.data"},
+			expectedPath: "/home/user/.data/bash-completion/completions/gobin",
+		},
+		"zsh": {
+			shell:        "zsh",
+			expectedPath: "/home/user/.zfunc/_gobin",
+		},
+		"fish": {
+			shell:        "fish",
+			expectedPath: "/home/user/.config/fish/completions/gobin.fish",
+		},
+		"fish-xdg-config-home": {
+			shell:        "fish",
+			mockEnvVars:  map[string]string{"XDG_CONFIG_HOME": "/home/user/.cfg"},
+			expectedPath: "/home/user/.cfg/fish/completions/gobin.fish",
+		},
+		"powershell": {
+			shell:         "powershell",
+			callRuntimeOS: true,
+			mockRuntimeOS: "linux",
+			expectedPath:  "/home/user/.config/powershell/Microsoft.PowerShell_profile.ps1",
+		},
+		"error-unsupported-shell": {
+			shell:       "sh",
+			expectedErr: errors.New(`completion not supported for shell "sh"`),
+		},
+		"error-user-home-dir": {
+			shell:           "zsh",
+			mockUserHomeErr: errors.New("unexpected error"),
+			expectedErr:     errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := mocks.NewEnvironment(t)
+			runtime := mocks.NewRuntime(t)
+
+			env.EXPECT().UserHomeDir().Return("/home/user", tc.mockUserHomeErr)
+
+			if tc.mockUserHomeErr == nil && (tc.shell == "bash" || tc.shell == "fish") {
+				envVar := "XDG_DATA_HOME"
+				if tc.shell == "fish" {
+					envVar = "XDG_CONFIG_HOME"
+				}
+
+				value, ok := tc.mockEnvVars[envVar]
+				env.EXPECT().Get(envVar).Return(value, ok).Once()
+			}
+
+			if tc.callRuntimeOS {
+				runtime.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+			}
+
+			userPath := system.NewUserPath(env, nil, nil, runtime)
+			path, err := userPath.GetCompletionPath(tc.shell)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedPath, filepath.ToSlash(path))
+		})
+	}
+}
+
+func TestUserPath_GetShell(t *testing.T) {
+	cases := map[string]struct {
+		mockShell     string
+		callRuntimeOS bool
+		mockRuntimeOS string
+		expectedShell string
+	}{
+		"shell-env-var": {
+			mockShell:     "/usr/bin/zsh",
+			expectedShell: "zsh",
+		},
+		"windows": {
+			callRuntimeOS: true,
+			mockRuntimeOS: "windows",
+			expectedShell: "powershell",
+		},
+		"unknown": {
+			callRuntimeOS: true,
+			mockRuntimeOS: "linux",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := mocks.NewEnvironment(t)
+			runtime := mocks.NewRuntime(t)
+
+			env.EXPECT().Get("SHELL").Return(tc.mockShell, tc.mockShell != "").Once()
+
+			if tc.callRuntimeOS {
+				runtime.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+			}
+
+			userPath := system.NewUserPath(env, nil, nil, runtime)
+			assert.Equal(t, tc.expectedShell, userPath.GetShell())
+		})
+	}
+}
+
+func TestUserPath_GetShellProfile(t *testing.T) {
+	cases := map[string]struct {
+		shell           string
+		callRuntimeOS   bool
+		mockRuntimeOS   string
+		mockUserHomeErr error
+		expectedProfile string
+		expectedErr     error
+	}{
+		"zsh": {
+			shell:           "zsh",
+			expectedProfile: "/home/user/.zshrc",
+		},
+		"bash-darwin": {
+			shell:           "bash",
+			callRuntimeOS:   true,
+			mockRuntimeOS:   "darwin",
+			expectedProfile: "/home/user/.bash_profile",
+		},
+		"fish": {
+			shell:           "fish",
+			expectedProfile: "/home/user/.config/fish/config.fish",
+		},
+		"powershell-linux": {
+			shell:           "powershell",
+			callRuntimeOS:   true,
+			mockRuntimeOS:   "linux",
+			expectedProfile: "/home/user/.config/powershell/Microsoft.PowerShell_profile.ps1",
+		},
+		"powershell-windows": {
+			shell:           "powershell",
+			callRuntimeOS:   true,
+			mockRuntimeOS:   "windows",
+			expectedProfile: "/home/user/Documents/PowerShell/Microsoft.PowerShell_profile.ps1",
+		},
+		"other-shell": {
+			shell:           "sh",
+			expectedProfile: "/home/user/.profile",
+		},
+		"error-user-home-dir": {
+			shell:           "zsh",
+			mockUserHomeErr: errors.New("unexpected error"),
+			expectedErr:     errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := mocks.NewEnvironment(t)
+			runtime := mocks.NewRuntime(t)
+
+			env.EXPECT().UserHomeDir().Return("/home/user", tc.mockUserHomeErr).Once()
+
+			if tc.callRuntimeOS {
+				runtime.EXPECT().OS().Return(tc.mockRuntimeOS).Once()
+			}
+
+			userPath := system.NewUserPath(env, nil, nil, runtime)
+			profile, err := userPath.GetShellProfile(tc.shell)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedProfile, filepath.ToSlash(profile))
+		})
+	}
+}
+
+func TestUserPath_RemoveDuplicateAdditions(t *testing.T) {
+	profileAddition := system.PathAddition{
+		Dir:    "/home/user/go/bin",
+		Target: "/home/user/.zshrc",
+		Change: `export PATH="$PATH:/home/user/go/bin"`,
+	}
+
+	cases := map[string]struct {
+		addition      system.PathAddition
+		callReadFile  bool
+		mockReadFile  []byte
+		mockReadErr   error
+		callWriteFile bool
+		expectedWrite string
+		mockWriteErr  error
+		expectedErr   error
+	}{
+		"success-profile": {
+			addition:     profileAddition,
+			callReadFile: true,
+			mockReadFile: []byte(
+				"alias ll='ls -l'\n\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\n" +
+					"umask 022\n\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\n",
+			),
+			callWriteFile: true,
+			expectedWrite: "alias ll='ls -l'\n\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\numask 022\n",
+		},
+		"success-profile-no-addition": {
+			addition:     profileAddition,
+			callReadFile: true,
+			mockReadFile: []byte("alias ll='ls -l'\n"),
+		},
+		"success-windows": {
+			addition: system.PathAddition{
+				Dir:    `C:\Users\user\go\bin`,
+				Target: `HKCU\Environment`,
+				Change: `C:\Users\user\go\bin`,
+			},
+		},
+		"error-read-file": {
+			addition:     profileAddition,
+			callReadFile: true,
+			mockReadErr:  os.ErrPermission,
+			expectedErr:  os.ErrPermission,
+		},
+		"error-write-file": {
+			addition:     profileAddition,
+			callReadFile: true,
+			mockReadFile: []byte(
+				"\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\n" +
+					"\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\n",
+			),
+			callWriteFile: true,
+			expectedWrite: "\n# Added by gobin\nexport PATH=\"$PATH:/home/user/go/bin\"\n",
+			mockWriteErr:  os.ErrPermission,
+			expectedErr:   os.ErrPermission,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := mocks.NewFileSystem(t)
+
+			if tc.callReadFile {
+				fs.EXPECT().ReadFile(tc.addition.Target).Return(tc.mockReadFile, tc.mockReadErr).Once()
+			}
+
+			if tc.callWriteFile {
+				fs.EXPECT().WriteFile(
+					tc.addition.Target,
+					[]byte(tc.expectedWrite),
+					os.FileMode(0644),
+				).Return(tc.mockWriteErr).Once()
+			}
+
+			userPath := system.NewUserPath(nil, nil, fs, nil)
+			err := userPath.RemoveDuplicateAdditions(tc.addition)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
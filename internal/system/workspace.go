@@ -13,18 +13,38 @@ type Workspace interface {
 	GetInternalBasePath() string
 	// GetInternalBinPath returns the internal binary directory.
 	GetInternalBinPath() string
+	// GetInternalCachePath returns the internal cache directory.
+	GetInternalCachePath() string
+	// GetInternalConfigPath returns the internal configuration file path.
+	GetInternalConfigPath() string
+	// GetInternalDataPath returns the internal per-binary data directory.
+	GetInternalDataPath() string
+	// GetInternalLogPath returns the internal log directory.
+	GetInternalLogPath() string
+	// GetInternalReceiptPath returns the internal receipt directory.
+	GetInternalReceiptPath() string
+	// GetInternalShimPath returns the internal exec shim directory.
+	GetInternalShimPath() string
 	// GetInternalTempPath returns the internal temporary directory.
 	GetInternalTempPath() string
 	// Initialize initializes the workspace.
 	Initialize() error
+	// SetInternalBinPath sets the internal binary directory.
+	SetInternalBinPath(path string)
 }
 
 // workspace is the default implementation of the Workspace interface.
 type workspace struct {
-	goBinPath        string
-	internalBasePath string
-	internalBinPath  string
-	internalTempPath string
+	goBinPath           string
+	internalBasePath    string
+	internalBinPath     string
+	internalCachePath   string
+	internalConfigPath  string
+	internalDataPath    string
+	internalLogPath     string
+	internalReceiptPath string
+	internalShimPath    string
+	internalTempPath    string
 
 	env     Environment
 	fs      FileSystem
@@ -52,6 +72,7 @@ func NewWorkspace(
 
 	w.loadGoBinPath(homeDir)
 	w.loadInternalPaths(homeDir)
+	w.loadInternalConfigPath()
 
 	return w, nil
 }
@@ -71,16 +92,63 @@ func (w *workspace) GetInternalBinPath() string {
 	return w.internalBinPath
 }
 
+// GetInternalCachePath returns the cache directory, holding the metadata cached
+// by gobin, such as the module lookups. It is created with the first cached
+// entry.
+func (w *workspace) GetInternalCachePath() string {
+	return w.internalCachePath
+}
+
+// GetInternalConfigPath returns the configuration file path, in the gobin
+// directory of the user configuration directory, or in the base directory when
+// the configuration file is only found there, its previous location.
+func (w *workspace) GetInternalConfigPath() string {
+	return w.internalConfigPath
+}
+
+// GetInternalDataPath returns the per-binary data directory, holding the data
+// generated by gobin for each binary in a directory named after it, such as the
+// debug information of its stripped versions and the detached signatures of its
+// signed versions.
+func (w *workspace) GetInternalDataPath() string {
+	return w.internalDataPath
+}
+
+// GetInternalLogPath returns the log directory, holding the log file of all
+// runs.
+func (w *workspace) GetInternalLogPath() string {
+	return w.internalLogPath
+}
+
+// GetInternalReceiptPath returns the receipt directory.
+func (w *workspace) GetInternalReceiptPath() string {
+	return w.internalReceiptPath
+}
+
+// GetInternalShimPath returns the exec shim directory, holding the shims
+// setting the environment variables of the binaries before running them, to
+// add to PATH before the Go binary path. It is created with the first shim.
+func (w *workspace) GetInternalShimPath() string {
+	return w.internalShimPath
+}
+
 // GetTempPath returns the temporary directory.
 func (w *workspace) GetInternalTempPath() string {
 	return w.internalTempPath
 }
 
-// Initialize initializes the workspace. It creates the base, binary, and
-// temporary directories. It returns an error if the directories cannot be
-// created.
+// Initialize initializes the workspace. It creates the base, binary, temporary,
+// receipt, data and log directories. It returns an error if the directories
+// cannot be created.
 func (w *workspace) Initialize() error {
-	for _, dir := range []string{w.internalBasePath, w.internalBinPath, w.internalTempPath} {
+	for _, dir := range []string{
+		w.internalBasePath,
+		w.internalBinPath,
+		w.internalTempPath,
+		w.internalReceiptPath,
+		w.internalDataPath,
+		w.internalLogPath,
+	} {
 		//nolint:mnd // owner only permissions
 		if err := w.fs.CreateDir(dir, 0700); err != nil {
 			slog.Default().Error("failed to create directory", "dir", dir, "err", err)
@@ -91,6 +159,13 @@ func (w *workspace) Initialize() error {
 	return nil
 }
 
+// SetInternalBinPath sets the binary directory, replacing the one in the base
+// directory, ex. to keep the binaries on another disk. It must be set before
+// the workspace is initialized.
+func (w *workspace) SetInternalBinPath(path string) {
+	w.internalBinPath = path
+}
+
 // loadGoBinPath loads the Go binary path.
 func (w *workspace) loadGoBinPath(homeDir string) {
 	if gobin, ok := w.env.Get("GOBIN"); ok {
@@ -106,6 +181,25 @@ func (w *workspace) loadGoBinPath(homeDir string) {
 	w.goBinPath = filepath.Join(homeDir, "go", "bin")
 }
 
+// loadInternalConfigPath loads the configuration file path. The configuration
+// file in the base directory is used while the one in the user configuration
+// directory does not exist, or when the user configuration directory is unknown.
+func (w *workspace) loadInternalConfigPath() {
+	basePath := filepath.Join(w.internalBasePath, "config.yaml")
+
+	configDir, err := w.env.UserConfigDir()
+	if err != nil {
+		slog.Default().Warn("failed to get user config directory", "err", err)
+		w.internalConfigPath = basePath
+		return
+	}
+
+	w.internalConfigPath = filepath.Join(configDir, "gobin", "config.yaml")
+	if !w.fs.Exists(w.internalConfigPath) && w.fs.Exists(basePath) {
+		w.internalConfigPath = basePath
+	}
+}
+
 // loadInternalPaths loads the internal paths.
 func (w *workspace) loadInternalPaths(homeDir string) {
 	var baseDir, binDir, tmpDir string
@@ -122,5 +216,10 @@ func (w *workspace) loadInternalPaths(homeDir string) {
 
 	w.internalBasePath = baseDir
 	w.internalBinPath = binDir
+	w.internalCachePath = filepath.Join(baseDir, "cache")
+	w.internalDataPath = filepath.Join(baseDir, "data")
+	w.internalLogPath = filepath.Join(baseDir, "logs")
+	w.internalReceiptPath = filepath.Join(baseDir, "receipts")
+	w.internalShimPath = filepath.Join(baseDir, "shims")
 	w.internalTempPath = tmpDir
 }
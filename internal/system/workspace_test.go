@@ -20,22 +20,32 @@ type mockMkdirAllCall struct {
 
 func TestWorkspace(t *testing.T) {
 	cases := map[string]struct {
-		mockUserHomeDir          string
-		mockUserHomeDirErr       error
-		callGetGOBINEnvVar       bool
-		mockGOBINEnvVar          string
-		mockGOBINEnvVarOk        bool
-		callGetGOPATHEnvVar      bool
-		mockGOPATHEnvVar         string
-		mockGOPATHEnvVarOk       bool
-		callRuntimeOS            bool
-		mockRuntimeOS            string
-		mockMkdirAllCalls        []mockMkdirAllCall
-		expectedGoBinPath        string
-		expectedInternalBasePath string
-		expectedInternalBinPath  string
-		expectedInternalTempPath string
-		expectedErr              error
+		mockUserHomeDir             string
+		mockUserHomeDirErr          error
+		callGetGOBINEnvVar          bool
+		mockGOBINEnvVar             string
+		mockGOBINEnvVarOk           bool
+		callGetGOPATHEnvVar         bool
+		mockGOPATHEnvVar            string
+		mockGOPATHEnvVarOk          bool
+		callRuntimeOS               bool
+		mockRuntimeOS               string
+		mockUserConfigDir           string
+		mockUserConfigDirErr        error
+		mockConfigExists            bool
+		mockBaseConfigExists        bool
+		mockMkdirAllCalls           []mockMkdirAllCall
+		expectedGoBinPath           string
+		expectedInternalBasePath    string
+		expectedInternalBinPath     string
+		expectedInternalCachePath   string
+		expectedInternalConfigPath  string
+		expectedInternalDataPath    string
+		expectedInternalLogPath     string
+		expectedInternalReceiptPath string
+		expectedInternalShimPath    string
+		expectedInternalTempPath    string
+		expectedErr                 error
 	}{
 		"success-unix-default-go-bin-path": {
 			mockUserHomeDir:     filepath.Join("home", "user"),
@@ -43,6 +53,7 @@ func TestWorkspace(t *testing.T) {
 			callGetGOPATHEnvVar: true,
 			callRuntimeOS:       true,
 			mockRuntimeOS:       "linux",
+			mockUserConfigDir:   filepath.Join("home", "user", ".config"),
 			mockMkdirAllCalls: []mockMkdirAllCall{
 				{
 					dir:  filepath.Join("home", "user", ".gobin"),
@@ -56,11 +67,29 @@ func TestWorkspace(t *testing.T) {
 					dir:  filepath.Join("home", "user", ".gobin", ".tmp"),
 					perm: 0700,
 				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "receipts"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "data"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "logs"),
+					perm: 0700,
+				},
 			},
-			expectedGoBinPath:        filepath.Join("home", "user", "go", "bin"),
-			expectedInternalBasePath: filepath.Join("home", "user", ".gobin"),
-			expectedInternalBinPath:  filepath.Join("home", "user", ".gobin", "bin"),
-			expectedInternalTempPath: filepath.Join("home", "user", ".gobin", ".tmp"),
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", ".gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", ".gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", ".gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", ".config", "gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", ".gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", ".gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", ".gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", ".gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", ".gobin", ".tmp"),
 		},
 		"success-unix-gobin-env-var": {
 			mockUserHomeDir:    filepath.Join("home", "user"),
@@ -69,6 +98,7 @@ func TestWorkspace(t *testing.T) {
 			mockGOBINEnvVarOk:  true,
 			callRuntimeOS:      true,
 			mockRuntimeOS:      "linux",
+			mockUserConfigDir:  filepath.Join("home", "user", ".config"),
 			mockMkdirAllCalls: []mockMkdirAllCall{
 				{
 					dir:  filepath.Join("home", "user", ".gobin"),
@@ -82,11 +112,29 @@ func TestWorkspace(t *testing.T) {
 					dir:  filepath.Join("home", "user", ".gobin", ".tmp"),
 					perm: 0700,
 				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "receipts"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "data"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "logs"),
+					perm: 0700,
+				},
 			},
-			expectedGoBinPath:        filepath.Join("home", "user", "go", "bin"),
-			expectedInternalBasePath: filepath.Join("home", "user", ".gobin"),
-			expectedInternalBinPath:  filepath.Join("home", "user", ".gobin", "bin"),
-			expectedInternalTempPath: filepath.Join("home", "user", ".gobin", ".tmp"),
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", ".gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", ".gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", ".gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", ".config", "gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", ".gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", ".gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", ".gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", ".gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", ".gobin", ".tmp"),
 		},
 		"success-unix-gopath-env-var": {
 			mockUserHomeDir:     filepath.Join("home", "user"),
@@ -96,6 +144,7 @@ func TestWorkspace(t *testing.T) {
 			mockGOPATHEnvVarOk:  true,
 			callRuntimeOS:       true,
 			mockRuntimeOS:       "linux",
+			mockUserConfigDir:   filepath.Join("home", "user", ".config"),
 			mockMkdirAllCalls: []mockMkdirAllCall{
 				{
 					dir:  filepath.Join("home", "user", ".gobin"),
@@ -109,11 +158,29 @@ func TestWorkspace(t *testing.T) {
 					dir:  filepath.Join("home", "user", ".gobin", ".tmp"),
 					perm: 0700,
 				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "receipts"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "data"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "logs"),
+					perm: 0700,
+				},
 			},
-			expectedGoBinPath:        filepath.Join("home", "user", "go", "bin"),
-			expectedInternalBasePath: filepath.Join("home", "user", ".gobin"),
-			expectedInternalBinPath:  filepath.Join("home", "user", ".gobin", "bin"),
-			expectedInternalTempPath: filepath.Join("home", "user", ".gobin", ".tmp"),
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", ".gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", ".gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", ".gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", ".config", "gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", ".gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", ".gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", ".gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", ".gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", ".gobin", ".tmp"),
 		},
 		"success-windows-default-go-bin-path": {
 			mockUserHomeDir:     filepath.Join("home", "user"),
@@ -121,6 +188,7 @@ func TestWorkspace(t *testing.T) {
 			callGetGOPATHEnvVar: true,
 			callRuntimeOS:       true,
 			mockRuntimeOS:       "windows",
+			mockUserConfigDir:   filepath.Join("home", "user", "AppData", "Roaming"),
 			mockMkdirAllCalls: []mockMkdirAllCall{
 				{
 					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin"),
@@ -134,11 +202,29 @@ func TestWorkspace(t *testing.T) {
 					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "tmp"),
 					perm: 0700,
 				},
+				{
+					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "receipts"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "data"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "logs"),
+					perm: 0700,
+				},
 			},
-			expectedGoBinPath:        filepath.Join("home", "user", "go", "bin"),
-			expectedInternalBasePath: filepath.Join("home", "user", "AppData", "Local", "gobin"),
-			expectedInternalBinPath:  filepath.Join("home", "user", "AppData", "Local", "gobin", "bin"),
-			expectedInternalTempPath: filepath.Join("home", "user", "AppData", "Local", "gobin", "tmp"),
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", "AppData", "Local", "gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", "AppData", "Local", "gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", "AppData", "Local", "gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", "AppData", "Roaming", "gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", "AppData", "Local", "gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", "AppData", "Local", "gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", "AppData", "Local", "gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", "AppData", "Local", "gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", "AppData", "Local", "gobin", "tmp"),
 		},
 		"success-windows-gobin-env-var": {
 			mockUserHomeDir:    filepath.Join("home", "user"),
@@ -147,6 +233,7 @@ func TestWorkspace(t *testing.T) {
 			mockGOBINEnvVarOk:  true,
 			callRuntimeOS:      true,
 			mockRuntimeOS:      "windows",
+			mockUserConfigDir:  filepath.Join("home", "user", "AppData", "Roaming"),
 			mockMkdirAllCalls: []mockMkdirAllCall{
 				{
 					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin"),
@@ -160,11 +247,29 @@ func TestWorkspace(t *testing.T) {
 					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "tmp"),
 					perm: 0700,
 				},
+				{
+					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "receipts"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "data"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "logs"),
+					perm: 0700,
+				},
 			},
-			expectedGoBinPath:        filepath.Join("home", "user", "go", "bin"),
-			expectedInternalBasePath: filepath.Join("home", "user", "AppData", "Local", "gobin"),
-			expectedInternalBinPath:  filepath.Join("home", "user", "AppData", "Local", "gobin", "bin"),
-			expectedInternalTempPath: filepath.Join("home", "user", "AppData", "Local", "gobin", "tmp"),
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", "AppData", "Local", "gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", "AppData", "Local", "gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", "AppData", "Local", "gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", "AppData", "Roaming", "gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", "AppData", "Local", "gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", "AppData", "Local", "gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", "AppData", "Local", "gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", "AppData", "Local", "gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", "AppData", "Local", "gobin", "tmp"),
 		},
 		"success-windows-gopath-env-var": {
 			mockUserHomeDir:     filepath.Join("home", "user"),
@@ -174,6 +279,7 @@ func TestWorkspace(t *testing.T) {
 			mockGOPATHEnvVarOk:  true,
 			callRuntimeOS:       true,
 			mockRuntimeOS:       "windows",
+			mockUserConfigDir:   filepath.Join("home", "user", "AppData", "Roaming"),
 			mockMkdirAllCalls: []mockMkdirAllCall{
 				{
 					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin"),
@@ -187,11 +293,163 @@ func TestWorkspace(t *testing.T) {
 					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "tmp"),
 					perm: 0700,
 				},
+				{
+					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "receipts"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "data"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", "AppData", "Local", "gobin", "logs"),
+					perm: 0700,
+				},
 			},
-			expectedGoBinPath:        filepath.Join("home", "user", "go", "bin"),
-			expectedInternalBasePath: filepath.Join("home", "user", "AppData", "Local", "gobin"),
-			expectedInternalBinPath:  filepath.Join("home", "user", "AppData", "Local", "gobin", "bin"),
-			expectedInternalTempPath: filepath.Join("home", "user", "AppData", "Local", "gobin", "tmp"),
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", "AppData", "Local", "gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", "AppData", "Local", "gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", "AppData", "Local", "gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", "AppData", "Roaming", "gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", "AppData", "Local", "gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", "AppData", "Local", "gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", "AppData", "Local", "gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", "AppData", "Local", "gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", "AppData", "Local", "gobin", "tmp"),
+		},
+		"success-unix-config-file": {
+			mockUserHomeDir:     filepath.Join("home", "user"),
+			callGetGOBINEnvVar:  true,
+			callGetGOPATHEnvVar: true,
+			callRuntimeOS:       true,
+			mockRuntimeOS:       "linux",
+			mockUserConfigDir:   filepath.Join("home", "user", ".config"),
+			mockConfigExists:    true,
+			mockMkdirAllCalls: []mockMkdirAllCall{
+				{
+					dir:  filepath.Join("home", "user", ".gobin"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "bin"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", ".tmp"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "receipts"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "data"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "logs"),
+					perm: 0700,
+				},
+			},
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", ".gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", ".gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", ".gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", ".config", "gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", ".gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", ".gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", ".gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", ".gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", ".gobin", ".tmp"),
+		},
+		"success-unix-base-config-file": {
+			mockUserHomeDir:      filepath.Join("home", "user"),
+			callGetGOBINEnvVar:   true,
+			callGetGOPATHEnvVar:  true,
+			callRuntimeOS:        true,
+			mockRuntimeOS:        "linux",
+			mockUserConfigDir:    filepath.Join("home", "user", ".config"),
+			mockBaseConfigExists: true,
+			mockMkdirAllCalls: []mockMkdirAllCall{
+				{
+					dir:  filepath.Join("home", "user", ".gobin"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "bin"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", ".tmp"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "receipts"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "data"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "logs"),
+					perm: 0700,
+				},
+			},
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", ".gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", ".gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", ".gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", ".gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", ".gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", ".gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", ".gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", ".gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", ".gobin", ".tmp"),
+		},
+		"success-unix-user-config-dir-error": {
+			mockUserHomeDir:      filepath.Join("home", "user"),
+			callGetGOBINEnvVar:   true,
+			callGetGOPATHEnvVar:  true,
+			callRuntimeOS:        true,
+			mockRuntimeOS:        "linux",
+			mockUserConfigDirErr: errors.New("unexpected error"),
+			mockMkdirAllCalls: []mockMkdirAllCall{
+				{
+					dir:  filepath.Join("home", "user", ".gobin"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "bin"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", ".tmp"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "receipts"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "data"),
+					perm: 0700,
+				},
+				{
+					dir:  filepath.Join("home", "user", ".gobin", "logs"),
+					perm: 0700,
+				},
+			},
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", ".gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", ".gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", ".gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", ".gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", ".gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", ".gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", ".gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", ".gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", ".gobin", ".tmp"),
 		},
 		"error-user-home-dir": {
 			mockUserHomeDirErr: errors.New("unexpected error"),
@@ -203,6 +461,7 @@ func TestWorkspace(t *testing.T) {
 			callGetGOPATHEnvVar: true,
 			callRuntimeOS:       true,
 			mockRuntimeOS:       "linux",
+			mockUserConfigDir:   filepath.Join("home", "user", ".config"),
 			mockMkdirAllCalls: []mockMkdirAllCall{
 				{
 					dir:  filepath.Join("home", "user", ".gobin"),
@@ -210,11 +469,17 @@ func TestWorkspace(t *testing.T) {
 					err:  errors.New("unexpected error"),
 				},
 			},
-			expectedGoBinPath:        filepath.Join("home", "user", "go", "bin"),
-			expectedInternalBasePath: filepath.Join("home", "user", ".gobin"),
-			expectedInternalBinPath:  filepath.Join("home", "user", ".gobin", "bin"),
-			expectedInternalTempPath: filepath.Join("home", "user", ".gobin", ".tmp"),
-			expectedErr:              errors.New("unexpected error"),
+			expectedGoBinPath:           filepath.Join("home", "user", "go", "bin"),
+			expectedInternalBasePath:    filepath.Join("home", "user", ".gobin"),
+			expectedInternalBinPath:     filepath.Join("home", "user", ".gobin", "bin"),
+			expectedInternalCachePath:   filepath.Join("home", "user", ".gobin", "cache"),
+			expectedInternalConfigPath:  filepath.Join("home", "user", ".config", "gobin", "config.yaml"),
+			expectedInternalDataPath:    filepath.Join("home", "user", ".gobin", "data"),
+			expectedInternalLogPath:     filepath.Join("home", "user", ".gobin", "logs"),
+			expectedInternalReceiptPath: filepath.Join("home", "user", ".gobin", "receipts"),
+			expectedInternalShimPath:    filepath.Join("home", "user", ".gobin", "shims"),
+			expectedInternalTempPath:    filepath.Join("home", "user", ".gobin", ".tmp"),
+			expectedErr:                 errors.New("unexpected error"),
 		},
 	}
 
@@ -244,6 +509,21 @@ func TestWorkspace(t *testing.T) {
 				rt.EXPECT().OS().
 					Return(tc.mockRuntimeOS).
 					Once()
+				env.EXPECT().UserConfigDir().
+					Return(tc.mockUserConfigDir, tc.mockUserConfigDirErr).
+					Once()
+			}
+
+			if tc.callRuntimeOS && tc.mockUserConfigDirErr == nil {
+				fs.EXPECT().Exists(filepath.Join(tc.mockUserConfigDir, "gobin", "config.yaml")).
+					Return(tc.mockConfigExists).
+					Once()
+			}
+
+			if tc.callRuntimeOS && tc.mockUserConfigDirErr == nil && !tc.mockConfigExists {
+				fs.EXPECT().Exists(filepath.Join(tc.expectedInternalBasePath, "config.yaml")).
+					Return(tc.mockBaseConfigExists).
+					Once()
 			}
 
 			for _, call := range tc.mockMkdirAllCalls {
@@ -259,6 +539,12 @@ func TestWorkspace(t *testing.T) {
 				assert.Equal(t, tc.expectedGoBinPath, workspace.GetGoBinPath())
 				assert.Equal(t, tc.expectedInternalBasePath, workspace.GetInternalBasePath())
 				assert.Equal(t, tc.expectedInternalBinPath, workspace.GetInternalBinPath())
+				assert.Equal(t, tc.expectedInternalCachePath, workspace.GetInternalCachePath())
+				assert.Equal(t, tc.expectedInternalConfigPath, workspace.GetInternalConfigPath())
+				assert.Equal(t, tc.expectedInternalDataPath, workspace.GetInternalDataPath())
+				assert.Equal(t, tc.expectedInternalLogPath, workspace.GetInternalLogPath())
+				assert.Equal(t, tc.expectedInternalReceiptPath, workspace.GetInternalReceiptPath())
+				assert.Equal(t, tc.expectedInternalShimPath, workspace.GetInternalShimPath())
 				assert.Equal(t, tc.expectedInternalTempPath, workspace.GetInternalTempPath())
 
 				err = workspace.Initialize()
@@ -267,3 +553,35 @@ func TestWorkspace(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspace_SetInternalBinPath(t *testing.T) {
+	env := mocks.NewEnvironment(t)
+	fs := mocks.NewFileSystem(t)
+	rt := mocks.NewRuntime(t)
+
+	env.EXPECT().UserHomeDir().Return(filepath.Join("home", "user"), nil).Once()
+	env.EXPECT().Get("GOBIN").Return(filepath.Join("home", "user", "go", "bin"), true).Once()
+	rt.EXPECT().OS().Return("linux").Once()
+	env.EXPECT().UserConfigDir().Return(filepath.Join("home", "user", ".config"), nil).Once()
+	fs.EXPECT().Exists(filepath.Join("home", "user", ".config", "gobin", "config.yaml")).Return(true).Once()
+
+	binPath := filepath.Join("mnt", "data", "gobin", "bin")
+	for _, dir := range []string{
+		filepath.Join("home", "user", ".gobin"),
+		binPath,
+		filepath.Join("home", "user", ".gobin", ".tmp"),
+		filepath.Join("home", "user", ".gobin", "receipts"),
+		filepath.Join("home", "user", ".gobin", "data"),
+		filepath.Join("home", "user", ".gobin", "logs"),
+	} {
+		fs.EXPECT().CreateDir(dir, os.FileMode(0700)).Return(nil).Once()
+	}
+
+	workspace, err := system.NewWorkspace(env, fs, rt)
+	assert.NoError(t, err)
+
+	workspace.SetInternalBinPath(binPath)
+	assert.Equal(t, binPath, workspace.GetInternalBinPath())
+	assert.Equal(t, filepath.Join("home", "user", ".config", "gobin", "config.yaml"), workspace.GetInternalConfigPath())
+	assert.NoError(t, workspace.Initialize())
+}
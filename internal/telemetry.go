@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// UsageEvent represents a command run recorded by the anonymous usage metrics.
+// It holds the command and the category of its outcome, the gobin version, the
+// platform and the hour of the run, but no arguments, paths or identifiers of
+// the user or the machine.
+type UsageEvent struct {
+	Command  string    `json:"command"`
+	Outcome  string    `json:"outcome"`
+	Version  string    `json:"version"`
+	Platform string    `json:"platform"`
+	Time     time.Time `json:"time"`
+}
+
+// NewUsageEvent creates a usage event for the given command and outcome, run
+// now on the current platform. The time is truncated to the hour.
+func NewUsageEvent(command, outcome string) UsageEvent {
+	event := UsageEvent{
+		Command:  command,
+		Outcome:  outcome,
+		Platform: runtime.GOOS + "/" + runtime.GOARCH,
+		Time:     time.Now().UTC().Truncate(time.Hour),
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		event.Version = info.Main.Version
+	}
+
+	return event
+}
+
+// RecordUsage records the usage event to the given endpoint. The event is
+// posted as JSON to an http or https URL, or appended as a JSON line to the
+// local file at the endpoint otherwise. It returns an error if the event cannot
+// be sent or written.
+func RecordUsage(ctx context.Context, endpoint string, event UsageEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		//nolint:mnd // owner only permissions
+		f, openErr := os.OpenFile(endpoint, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if openErr != nil {
+			return openErr
+		}
+		defer f.Close()
+
+		_, err = f.Write(append(data, '\n'))
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return nil
+}
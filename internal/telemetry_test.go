@@ -0,0 +1,95 @@
+package internal_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brunoribeiro127/gobin/internal"
+)
+
+func TestNewUsageEvent(t *testing.T) {
+	event := internal.NewUsageEvent("gobin install", "success")
+
+	assert.Equal(t, "gobin install", event.Command)
+	assert.Equal(t, "success", event.Outcome)
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, event.Platform)
+	assert.Equal(t, event.Time.Truncate(time.Hour), event.Time)
+}
+
+func TestRecordUsage(t *testing.T) {
+	event := internal.UsageEvent{
+		Command:  "gobin install",
+		Outcome:  "network",
+		Version:  "v1.0.0",
+		Platform: "linux/amd64",
+		Time:     time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC),
+	}
+	line := `{"command":"gobin install","outcome":"network","version":"v1.0.0","platform":"linux/amd64",` +
+		`"time":"2025-08-01T10:00:00Z"}`
+
+	cases := map[string]struct {
+		status      int
+		expectedErr bool
+	}{
+		"success": {
+			status: http.StatusNoContent,
+		},
+		"error-status": {
+			status:      http.StatusInternalServerError,
+			expectedErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run("http-"+name, func(t *testing.T) {
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				var err error
+				body, err = io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			err := internal.RecordUsage(context.Background(), server.URL, event)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.JSONEq(t, line, string(body))
+		})
+	}
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+
+		require.NoError(t, internal.RecordUsage(context.Background(), path, event))
+		require.NoError(t, internal.RecordUsage(context.Background(), path, event))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, line+"\n"+line+"\n", string(data))
+	})
+
+	t.Run("file-error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "telemetry.jsonl")
+
+		err := internal.RecordUsage(context.Background(), path, event)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Phase is a phase of an operation whose wall time is tracked.
+type Phase string
+
+const (
+	// PhaseResolve is the phase resolving module versions.
+	PhaseResolve Phase = "resolve"
+	// PhaseDownload is the phase downloading modules.
+	PhaseDownload Phase = "download"
+	// PhaseCompile is the phase compiling binaries.
+	PhaseCompile Phase = "compile"
+	// PhaseLink is the phase moving binaries and linking or copying pins.
+	PhaseLink Phase = "link/copy"
+	// PhaseVulnCheck is the phase checking binaries for vulnerabilities.
+	PhaseVulnCheck Phase = "vulncheck"
+)
+
+// phases is the list of phases in the order they happen.
+//
+//nolint:gochecknoglobals // global variable to define the order of phases
+var phases = []Phase{
+	PhaseResolve,
+	PhaseDownload,
+	PhaseCompile,
+	PhaseLink,
+	PhaseVulnCheck,
+}
+
+// GetPhases returns the list of phases in the order they happen.
+func GetPhases() []Phase {
+	return phases
+}
+
+// Timings records the wall time spent per phase of an operation. It is safe for
+// concurrent use.
+type Timings struct {
+	mutex     sync.Mutex
+	durations map[Phase]time.Duration
+}
+
+// NewTimings creates a new empty Timings.
+func NewTimings() *Timings {
+	return &Timings{
+		durations: make(map[Phase]time.Duration),
+	}
+}
+
+// Add adds the given duration to the wall time spent in the given phase.
+func (t *Timings) Add(phase Phase, duration time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.durations[phase] += duration
+}
+
+// Get returns the wall time spent in the given phase.
+func (t *Timings) Get(phase Phase) time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.durations[phase]
+}
+
+// Total returns the wall time spent in all phases.
+func (t *Timings) Total() time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var total time.Duration
+	for _, duration := range t.durations {
+		total += duration
+	}
+
+	return total
+}
+
+// timingsKey is the context key of the timings recording the phases.
+type timingsKey struct{}
+
+// WithTimings returns a copy of the context carrying the given timings, which
+// record the wall time of the phases tracked with the context.
+func WithTimings(ctx context.Context, timings *Timings) context.Context {
+	return context.WithValue(ctx, timingsKey{}, timings)
+}
+
+// AddPhaseTime adds the given duration to the given phase of the timings
+// carried by the context, if any.
+func AddPhaseTime(ctx context.Context, phase Phase, duration time.Duration) {
+	if timings, ok := ctx.Value(timingsKey{}).(*Timings); ok {
+		timings.Add(phase, duration)
+	}
+}
+
+// TrackPhase starts tracking the wall time of the given phase. It returns a
+// function stopping the tracking and adding the elapsed time to the timings
+// carried by the context, if any.
+func TrackPhase(ctx context.Context, phase Phase) func() {
+	start := time.Now()
+	return func() {
+		AddPhaseTime(ctx, phase, time.Since(start))
+	}
+}
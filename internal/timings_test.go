@@ -0,0 +1,70 @@
+package internal_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal"
+)
+
+func TestAddPhaseTime(t *testing.T) {
+	cases := map[string]struct {
+		withTimings    bool
+		phaseTimes     map[internal.Phase][]time.Duration
+		expectedPhases map[internal.Phase]time.Duration
+		expectedTotal  time.Duration
+	}{
+		"single-phase": {
+			withTimings: true,
+			phaseTimes: map[internal.Phase][]time.Duration{
+				internal.PhaseCompile: {2 * time.Second},
+			},
+			expectedPhases: map[internal.Phase]time.Duration{
+				internal.PhaseCompile: 2 * time.Second,
+			},
+			expectedTotal: 2 * time.Second,
+		},
+		"multiple-phases": {
+			withTimings: true,
+			phaseTimes: map[internal.Phase][]time.Duration{
+				internal.PhaseResolve:  {time.Second, 500 * time.Millisecond},
+				internal.PhaseDownload: {3 * time.Second},
+			},
+			expectedPhases: map[internal.Phase]time.Duration{
+				internal.PhaseResolve:  1500 * time.Millisecond,
+				internal.PhaseDownload: 3 * time.Second,
+			},
+			expectedTotal: 4500 * time.Millisecond,
+		},
+		"without-timings": {
+			phaseTimes: map[internal.Phase][]time.Duration{
+				internal.PhaseCompile: {2 * time.Second},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			timings := internal.NewTimings()
+
+			ctx := context.Background()
+			if tc.withTimings {
+				ctx = internal.WithTimings(ctx, timings)
+			}
+
+			for phase, durations := range tc.phaseTimes {
+				for _, duration := range durations {
+					internal.AddPhaseTime(ctx, phase, duration)
+				}
+			}
+
+			for _, phase := range internal.GetPhases() {
+				assert.Equal(t, tc.expectedPhases[phase], timings.Get(phase))
+			}
+			assert.Equal(t, tc.expectedTotal, timings.Total())
+		})
+	}
+}
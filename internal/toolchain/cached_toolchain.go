@@ -0,0 +1,245 @@
+package toolchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+	"github.com/brunoribeiro127/gobin/internal/system"
+)
+
+const (
+	// moduleCacheFileName is the name of the file caching the module lookups in
+	// the cache directory.
+	moduleCacheFileName = "modules.json"
+	// moduleCacheTTL is the default time a cached module lookup is used for,
+	// after which the module is looked up again.
+	moduleCacheTTL = time.Hour
+)
+
+// noCacheContextKey is the context key marking the module lookups to bypass
+// the module cache.
+type noCacheContextKey struct{}
+
+// moduleCacheEntry is a cached module lookup: the latest module path and
+// version, the go.mod file or the origin of a module, or whether the module was
+// not found.
+type moduleCacheEntry struct {
+	CheckedAt time.Time           `json:"checked_at"`
+	NotFound  bool                `json:"not_found,omitempty"`
+	Path      string              `json:"path,omitempty"`
+	Version   string              `json:"version,omitempty"`
+	GoMod     string              `json:"go_mod,omitempty"`
+	Origin    *model.ModuleOrigin `json:"origin,omitempty"`
+}
+
+// WithNoCache returns a copy of the context marking the module lookups run with
+// it to bypass the module cache, requesting the module proxies. The results
+// still refresh the module cache.
+func WithNoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheContextKey{}, true)
+}
+
+// IsNoCache returns whether the context is marked with WithNoCache, for the
+// other caches of module metadata to be bypassed too.
+func IsNoCache(ctx context.Context) bool {
+	noCache, _ := ctx.Value(noCacheContextKey{}).(bool)
+	return noCache
+}
+
+// CachedToolchain is a toolchain caching the module lookups of another
+// toolchain on disk: the latest module versions, the go.mod files and the
+// module origins. A cached lookup, including a module not found, is used for an
+// hour by default, so repeated commands skip the network calls. The other calls
+// are forwarded to the toolchain.
+type CachedToolchain struct {
+	Toolchain
+
+	fs    system.FileSystem
+	dir   string
+	ttl   time.Duration
+	mutex sync.Mutex
+	cache map[string]moduleCacheEntry
+}
+
+// NewCachedToolchain creates a new CachedToolchain caching the module lookups
+// of the given toolchain in the given cache directory, created with the first
+// cached lookup. The cached lookups are used for the given time to live, or an
+// hour if zero.
+func NewCachedToolchain(
+	toolchain Toolchain,
+	fs system.FileSystem,
+	dir string,
+	ttl time.Duration,
+) *CachedToolchain {
+	if ttl <= 0 {
+		ttl = moduleCacheTTL
+	}
+
+	return &CachedToolchain{
+		Toolchain: toolchain,
+		fs:        fs,
+		dir:       dir,
+		ttl:       ttl,
+	}
+}
+
+// GetLatestModuleVersion returns the latest module path and version of a module
+// from the module cache, or leveraging the toolchain if not cached or expired.
+func (t *CachedToolchain) GetLatestModuleVersion(
+	ctx context.Context,
+	module model.Module,
+) (model.Module, error) {
+	entry, err := t.lookup(ctx, "latest:"+module.String(), func() (moduleCacheEntry, error) {
+		mod, err := t.Toolchain.GetLatestModuleVersion(ctx, module)
+		return moduleCacheEntry{Path: mod.Path, Version: mod.Version.String()}, err
+	})
+	if err != nil {
+		return model.Module{}, err
+	}
+
+	return model.Module{Path: entry.Path, Version: model.Version(entry.Version)}, nil
+}
+
+// GetModuleFile returns the go.mod file for a module from the module cache, or
+// leveraging the toolchain if not cached or expired.
+func (t *CachedToolchain) GetModuleFile(
+	ctx context.Context,
+	module model.Module,
+) (*modfile.File, error) {
+	entry, err := t.lookup(ctx, "mod:"+module.String(), func() (moduleCacheEntry, error) {
+		modFile, err := t.Toolchain.GetModuleFile(ctx, module)
+		if err != nil {
+			return moduleCacheEntry{}, err
+		}
+
+		return moduleCacheEntry{GoMod: string(modfile.Format(modFile.Syntax))}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return modfile.Parse("go.mod", []byte(entry.GoMod), nil)
+}
+
+// GetModuleOrigin returns the origin of a module from the module cache, or
+// leveraging the toolchain if not cached or expired. A module origin not
+// available is cached as well.
+func (t *CachedToolchain) GetModuleOrigin(
+	ctx context.Context,
+	module model.Module,
+) (*model.ModuleOrigin, error) {
+	entry, err := t.lookup(ctx, "origin:"+module.String(), func() (moduleCacheEntry, error) {
+		origin, err := t.Toolchain.GetModuleOrigin(ctx, module)
+		if errors.Is(err, ErrModuleOriginNotAvailable) {
+			return moduleCacheEntry{}, nil
+		}
+
+		return moduleCacheEntry{Origin: origin}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Origin == nil {
+		return nil, ErrModuleOriginNotAvailable
+	}
+
+	return entry.Origin, nil
+}
+
+// lookup returns the module cache entry with the given key if cached within the
+// time to live, or looks it up with the given function otherwise, caching the
+// result. A module not found is cached and returned as ErrModuleNotFound, while
+// the other errors are not cached. The module cache is bypassed when the
+// context is marked with WithNoCache, only caching the result, or with
+// WithDirect. A cache that cannot be read or written is logged and ignored.
+func (t *CachedToolchain) lookup(
+	ctx context.Context,
+	key string,
+	fn func() (moduleCacheEntry, error),
+) (moduleCacheEntry, error) {
+	if direct, _ := ctx.Value(directContextKey{}).(bool); direct {
+		return fn()
+	}
+
+	if !IsNoCache(ctx) {
+		t.mutex.Lock()
+		entry, ok := t.load()[key]
+		t.mutex.Unlock()
+
+		if ok && time.Since(entry.CheckedAt) < t.ttl {
+			if entry.NotFound {
+				return moduleCacheEntry{}, ErrModuleNotFound
+			}
+
+			return entry, nil
+		}
+	}
+
+	entry, err := fn()
+	if errors.Is(err, ErrModuleNotFound) {
+		entry = moduleCacheEntry{NotFound: true}
+	} else if err != nil {
+		return moduleCacheEntry{}, err
+	}
+
+	entry.CheckedAt = time.Now()
+	t.store(ctx, key, entry)
+
+	if entry.NotFound {
+		return moduleCacheEntry{}, ErrModuleNotFound
+	}
+
+	return entry, nil
+}
+
+// load returns the module cache, read from the cache file on the first call.
+// It returns an empty cache if the file does not exist or cannot be parsed. The
+// mutex must be held.
+func (t *CachedToolchain) load() map[string]moduleCacheEntry {
+	if t.cache != nil {
+		return t.cache
+	}
+
+	t.cache = map[string]moduleCacheEntry{}
+	if data, err := t.fs.ReadFile(filepath.Join(t.dir, moduleCacheFileName)); err == nil {
+		if json.Unmarshal(data, &t.cache) != nil {
+			t.cache = map[string]moduleCacheEntry{}
+		}
+	}
+
+	return t.cache
+}
+
+// store caches the module cache entry with the given key, writing the module
+// cache to the cache file. An error writing the file is logged and ignored.
+func (t *CachedToolchain) store(ctx context.Context, key string, entry moduleCacheEntry) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	cache := t.load()
+	cache[key] = entry
+
+	data, err := json.Marshal(cache)
+	if err == nil {
+		//nolint:mnd // owner only permissions
+		err = t.fs.CreateDir(t.dir, 0700)
+	}
+
+	if err == nil {
+		//nolint:mnd // owner only permissions
+		err = t.fs.WriteFile(filepath.Join(t.dir, moduleCacheFileName), data, 0600)
+	}
+
+	if err != nil {
+		slog.Default().WarnContext(ctx, "error caching module lookup", "key", key, "err", err)
+	}
+}
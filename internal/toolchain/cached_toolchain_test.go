@@ -0,0 +1,254 @@
+package toolchain_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+
+	"github.com/brunoribeiro127/gobin/internal/model"
+	systemmocks "github.com/brunoribeiro127/gobin/internal/system/mocks"
+	"github.com/brunoribeiro127/gobin/internal/toolchain"
+	toolchainmocks "github.com/brunoribeiro127/gobin/internal/toolchain/mocks"
+)
+
+func TestCachedToolchain_GetLatestModuleVersion(t *testing.T) {
+	cacheDir := filepath.Join("home", "user", ".gobin", "cache")
+	cachePath := filepath.Join(cacheDir, "modules.json")
+	module := model.NewLatestModule("example.com/mockorg/mockproj")
+	latest := model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.2.0"))
+
+	makeCache := func(t *testing.T, checkedAt time.Time, notFound bool) []byte {
+		entry := map[string]any{"checked_at": checkedAt, "not_found": notFound}
+		if !notFound {
+			entry["path"] = "example.com/mockorg/mockproj"
+			entry["version"] = "v0.1.0"
+		}
+
+		data, err := json.Marshal(map[string]any{"latest:" + module.String(): entry})
+		require.NoError(t, err)
+		return data
+	}
+
+	cases := map[string]struct {
+		ttl              time.Duration
+		noCache          bool
+		direct           bool
+		mockReadFile     []byte
+		mockReadFileErr  error
+		callToolchain    bool
+		mockLatest       model.Module
+		mockLatestErr    error
+		callWriteFile    bool
+		mockWriteFileErr error
+		expectedModule   model.Module
+		expectedErr      error
+	}{
+		"success-not-cached": {
+			mockReadFileErr: os.ErrNotExist,
+			callToolchain:   true,
+			mockLatest:      latest,
+			callWriteFile:   true,
+			expectedModule:  latest,
+		},
+		"success-cached": {
+			mockReadFile:   makeCache(t, time.Now(), false),
+			expectedModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+		},
+		"success-cache-expired": {
+			mockReadFile:   makeCache(t, time.Now().Add(-2*time.Hour), false),
+			callToolchain:  true,
+			mockLatest:     latest,
+			callWriteFile:  true,
+			expectedModule: latest,
+		},
+		"success-cached-custom-ttl": {
+			ttl:            24 * time.Hour,
+			mockReadFile:   makeCache(t, time.Now().Add(-2*time.Hour), false),
+			expectedModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+		},
+		"success-invalid-cache": {
+			mockReadFile:   []byte("invalid"),
+			callToolchain:  true,
+			mockLatest:     latest,
+			callWriteFile:  true,
+			expectedModule: latest,
+		},
+		"success-no-cache": {
+			noCache:        true,
+			callToolchain:  true,
+			mockLatest:     latest,
+			callWriteFile:  true,
+			expectedModule: latest,
+		},
+		"success-direct": {
+			direct:         true,
+			callToolchain:  true,
+			mockLatest:     latest,
+			expectedModule: latest,
+		},
+		"success-write-cache-error": {
+			mockReadFileErr:  os.ErrNotExist,
+			callToolchain:    true,
+			mockLatest:       latest,
+			callWriteFile:    true,
+			mockWriteFileErr: errors.New("unexpected error"),
+			expectedModule:   latest,
+		},
+		"error-module-not-found": {
+			mockReadFileErr: os.ErrNotExist,
+			callToolchain:   true,
+			mockLatestErr:   toolchain.ErrModuleNotFound,
+			callWriteFile:   true,
+			expectedErr:     toolchain.ErrModuleNotFound,
+		},
+		"error-module-not-found-cached": {
+			mockReadFile: makeCache(t, time.Now(), true),
+			expectedErr:  toolchain.ErrModuleNotFound,
+		},
+		"error-get-latest-module-version": {
+			mockReadFileErr: os.ErrNotExist,
+			callToolchain:   true,
+			mockLatestErr:   errors.New("unexpected error"),
+			expectedErr:     errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			goToolchain := toolchainmocks.NewToolchain(t)
+
+			ctx := context.Background()
+			if tc.noCache {
+				ctx = toolchain.WithNoCache(ctx)
+			}
+			if tc.direct {
+				ctx = toolchain.WithDirect(ctx)
+			}
+
+			if !tc.noCache && !tc.direct {
+				fs.EXPECT().ReadFile(cachePath).Return(tc.mockReadFile, tc.mockReadFileErr).Once()
+			} else if !tc.direct {
+				fs.EXPECT().ReadFile(cachePath).Return(nil, os.ErrNotExist).Once()
+			}
+
+			if tc.callToolchain {
+				goToolchain.EXPECT().GetLatestModuleVersion(ctx, module).
+					Return(tc.mockLatest, tc.mockLatestErr).
+					Once()
+			}
+
+			if tc.callWriteFile {
+				fs.EXPECT().CreateDir(cacheDir, os.FileMode(0700)).Return(nil).Once()
+				fs.EXPECT().WriteFile(cachePath, mock.Anything, os.FileMode(0600)).
+					Return(tc.mockWriteFileErr).
+					Once()
+			}
+
+			cached := toolchain.NewCachedToolchain(goToolchain, fs, cacheDir, tc.ttl)
+			mod, err := cached.GetLatestModuleVersion(ctx, module)
+			assert.Equal(t, tc.expectedModule, mod)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestCachedToolchain_GetModuleFile(t *testing.T) {
+	cacheDir := filepath.Join("home", "user", ".gobin", "cache")
+	module := model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0"))
+
+	modFile, err := modfile.Parse("go.mod", []byte("module example.com/mockorg/mockproj\n\ngo 1.24\n"), nil)
+	require.NoError(t, err)
+
+	fs := systemmocks.NewFileSystem(t)
+	goToolchain := toolchainmocks.NewToolchain(t)
+
+	var cache []byte
+	fs.EXPECT().ReadFile(filepath.Join(cacheDir, "modules.json")).Return(nil, os.ErrNotExist).Once()
+	fs.EXPECT().CreateDir(cacheDir, os.FileMode(0700)).Return(nil).Once()
+	fs.EXPECT().WriteFile(filepath.Join(cacheDir, "modules.json"), mock.Anything, os.FileMode(0600)).
+		RunAndReturn(func(_ string, data []byte, _ os.FileMode) error {
+			cache = data
+			return nil
+		}).
+		Once()
+	goToolchain.EXPECT().GetModuleFile(context.Background(), module).Return(modFile, nil).Once()
+
+	cached := toolchain.NewCachedToolchain(goToolchain, fs, cacheDir, 0)
+
+	for range 2 {
+		result, getErr := cached.GetModuleFile(context.Background(), module)
+		require.NoError(t, getErr)
+		assert.Equal(t, "example.com/mockorg/mockproj", result.Module.Mod.Path)
+		assert.Equal(t, "1.24", result.Go.Version)
+	}
+
+	assert.Contains(t, string(cache), `"mod:example.com/mockorg/mockproj@v0.1.0"`)
+}
+
+func TestCachedToolchain_GetModuleOrigin(t *testing.T) {
+	cacheDir := filepath.Join("home", "user", ".gobin", "cache")
+	module := model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0"))
+	origin := &model.ModuleOrigin{VCS: "git", URL: "https://github.com/mockorg/mockproj"}
+
+	cases := map[string]struct {
+		mockOrigin     *model.ModuleOrigin
+		mockOriginErr  error
+		callWriteFile  bool
+		expectedOrigin *model.ModuleOrigin
+		expectedErr    error
+	}{
+		"success": {
+			mockOrigin:     origin,
+			callWriteFile:  true,
+			expectedOrigin: origin,
+		},
+		"error-module-origin-not-available": {
+			mockOriginErr: toolchain.ErrModuleOriginNotAvailable,
+			callWriteFile: true,
+			expectedErr:   toolchain.ErrModuleOriginNotAvailable,
+		},
+		"error-get-module-origin": {
+			mockOriginErr: errors.New("unexpected error"),
+			expectedErr:   errors.New("unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := systemmocks.NewFileSystem(t)
+			goToolchain := toolchainmocks.NewToolchain(t)
+
+			fs.EXPECT().ReadFile(filepath.Join(cacheDir, "modules.json")).Return(nil, os.ErrNotExist).Once()
+			goToolchain.EXPECT().GetModuleOrigin(context.Background(), module).
+				Return(tc.mockOrigin, tc.mockOriginErr).
+				Once()
+
+			if tc.callWriteFile {
+				fs.EXPECT().CreateDir(cacheDir, os.FileMode(0700)).Return(nil).Once()
+				fs.EXPECT().WriteFile(filepath.Join(cacheDir, "modules.json"), mock.Anything, os.FileMode(0600)).
+					Return(nil).
+					Once()
+			}
+
+			cached := toolchain.NewCachedToolchain(goToolchain, fs, cacheDir, 0)
+			result, err := cached.GetModuleOrigin(context.Background(), module)
+			assert.Equal(t, tc.expectedOrigin, result)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestIsNoCache(t *testing.T) {
+	assert.False(t, toolchain.IsNoCache(context.Background()))
+	assert.True(t, toolchain.IsNoCache(toolchain.WithNoCache(context.Background())))
+}
@@ -54,3 +54,8 @@ func (s *scanExecCombinedOutput) CombinedOutput() ([]byte, error) {
 
 	return s.output.Bytes(), nil
 }
+
+// InjectEnv injects environment variables into the govulncheck command.
+func (s *scanExecCombinedOutput) InjectEnv(env ...string) {
+	s.cmd.Env = append(s.cmd.Env, env...)
+}
@@ -40,183 +40,1364 @@ func (_m *Toolchain) EXPECT() *Toolchain_Expecter {
 	return &Toolchain_Expecter{mock: &_m.Mock}
 }
 
+// Compress provides a mock function for the type Toolchain
+func (_mock *Toolchain) Compress(ctx context.Context, path string) error {
+	ret := _mock.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Compress")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Toolchain_Compress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Compress'
+type Toolchain_Compress_Call struct {
+	*mock.Call
+}
+
+// Compress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *Toolchain_Expecter) Compress(ctx interface{}, path interface{}) *Toolchain_Compress_Call {
+	return &Toolchain_Compress_Call{Call: _e.mock.On("Compress", ctx, path)}
+}
+
+func (_c *Toolchain_Compress_Call) Run(run func(ctx context.Context, path string)) *Toolchain_Compress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_Compress_Call) Return(err error) *Toolchain_Compress_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Toolchain_Compress_Call) RunAndReturn(run func(ctx context.Context, path string) error) *Toolchain_Compress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DownloadPackage provides a mock function for the type Toolchain
+func (_mock *Toolchain) DownloadPackage(ctx context.Context, modCache string, pkg model.Package) error {
+	ret := _mock.Called(ctx, modCache, pkg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadPackage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Package) error); ok {
+		r0 = returnFunc(ctx, modCache, pkg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Toolchain_DownloadPackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DownloadPackage'
+type Toolchain_DownloadPackage_Call struct {
+	*mock.Call
+}
+
+// DownloadPackage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - modCache string
+//   - pkg model.Package
+func (_e *Toolchain_Expecter) DownloadPackage(ctx interface{}, modCache interface{}, pkg interface{}) *Toolchain_DownloadPackage_Call {
+	return &Toolchain_DownloadPackage_Call{Call: _e.mock.On("DownloadPackage", ctx, modCache, pkg)}
+}
+
+func (_c *Toolchain_DownloadPackage_Call) Run(run func(ctx context.Context, modCache string, pkg model.Package)) *Toolchain_DownloadPackage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.Package
+		if args[2] != nil {
+			arg2 = args[2].(model.Package)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_DownloadPackage_Call) Return(err error) *Toolchain_DownloadPackage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Toolchain_DownloadPackage_Call) RunAndReturn(run func(ctx context.Context, modCache string, pkg model.Package) error) *Toolchain_DownloadPackage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBuildID provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetBuildID(ctx context.Context, path string) (string, error) {
+	ret := _mock.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBuildID")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetBuildID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBuildID'
+type Toolchain_GetBuildID_Call struct {
+	*mock.Call
+}
+
+// GetBuildID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *Toolchain_Expecter) GetBuildID(ctx interface{}, path interface{}) *Toolchain_GetBuildID_Call {
+	return &Toolchain_GetBuildID_Call{Call: _e.mock.On("GetBuildID", ctx, path)}
+}
+
+func (_c *Toolchain_GetBuildID_Call) Run(run func(ctx context.Context, path string)) *Toolchain_GetBuildID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetBuildID_Call) Return(s string, err error) *Toolchain_GetBuildID_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *Toolchain_GetBuildID_Call) RunAndReturn(run func(ctx context.Context, path string) (string, error)) *Toolchain_GetBuildID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBuildInfo provides a mock function for the type Toolchain
 func (_mock *Toolchain) GetBuildInfo(path string) (*buildinfo.BuildInfo, error) {
 	ret := _mock.Called(path)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBuildInfo")
+		panic("no return value specified for GetBuildInfo")
+	}
+
+	var r0 *buildinfo.BuildInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*buildinfo.BuildInfo, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *buildinfo.BuildInfo); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*buildinfo.BuildInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetBuildInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBuildInfo'
+type Toolchain_GetBuildInfo_Call struct {
+	*mock.Call
+}
+
+// GetBuildInfo is a helper method to define mock.On call
+//   - path string
+func (_e *Toolchain_Expecter) GetBuildInfo(path interface{}) *Toolchain_GetBuildInfo_Call {
+	return &Toolchain_GetBuildInfo_Call{Call: _e.mock.On("GetBuildInfo", path)}
+}
+
+func (_c *Toolchain_GetBuildInfo_Call) Run(run func(path string)) *Toolchain_GetBuildInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetBuildInfo_Call) Return(v *buildinfo.BuildInfo, err error) *Toolchain_GetBuildInfo_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *Toolchain_GetBuildInfo_Call) RunAndReturn(run func(path string) (*buildinfo.BuildInfo, error)) *Toolchain_GetBuildInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGoEnv provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetGoEnv(ctx context.Context) (model.GoEnv, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGoEnv")
+	}
+
+	var r0 model.GoEnv
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.GoEnv, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.GoEnv); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.GoEnv)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetGoEnv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGoEnv'
+type Toolchain_GetGoEnv_Call struct {
+	*mock.Call
+}
+
+// GetGoEnv is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Toolchain_Expecter) GetGoEnv(ctx interface{}) *Toolchain_GetGoEnv_Call {
+	return &Toolchain_GetGoEnv_Call{Call: _e.mock.On("GetGoEnv", ctx)}
+}
+
+func (_c *Toolchain_GetGoEnv_Call) Run(run func(ctx context.Context)) *Toolchain_GetGoEnv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetGoEnv_Call) Return(goEnv model.GoEnv, err error) *Toolchain_GetGoEnv_Call {
+	_c.Call.Return(goEnv, err)
+	return _c
+}
+
+func (_c *Toolchain_GetGoEnv_Call) RunAndReturn(run func(ctx context.Context) (model.GoEnv, error)) *Toolchain_GetGoEnv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGoReleases provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetGoReleases(ctx context.Context, url string) (model.GoReleases, error) {
+	ret := _mock.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGoReleases")
+	}
+
+	var r0 model.GoReleases
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.GoReleases, error)); ok {
+		return returnFunc(ctx, url)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.GoReleases); ok {
+		r0 = returnFunc(ctx, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.GoReleases)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetGoReleases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGoReleases'
+type Toolchain_GetGoReleases_Call struct {
+	*mock.Call
+}
+
+// GetGoReleases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+func (_e *Toolchain_Expecter) GetGoReleases(ctx interface{}, url interface{}) *Toolchain_GetGoReleases_Call {
+	return &Toolchain_GetGoReleases_Call{Call: _e.mock.On("GetGoReleases", ctx, url)}
+}
+
+func (_c *Toolchain_GetGoReleases_Call) Run(run func(ctx context.Context, url string)) *Toolchain_GetGoReleases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetGoReleases_Call) Return(goReleases model.GoReleases, err error) *Toolchain_GetGoReleases_Call {
+	_c.Call.Return(goReleases, err)
+	return _c
+}
+
+func (_c *Toolchain_GetGoReleases_Call) RunAndReturn(run func(ctx context.Context, url string) (model.GoReleases, error)) *Toolchain_GetGoReleases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHTTPProxy provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetHTTPProxy(url string) (string, error) {
+	ret := _mock.Called(url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHTTPProxy")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(url)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(url)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(url)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetHTTPProxy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHTTPProxy'
+type Toolchain_GetHTTPProxy_Call struct {
+	*mock.Call
+}
+
+// GetHTTPProxy is a helper method to define mock.On call
+//   - url string
+func (_e *Toolchain_Expecter) GetHTTPProxy(url interface{}) *Toolchain_GetHTTPProxy_Call {
+	return &Toolchain_GetHTTPProxy_Call{Call: _e.mock.On("GetHTTPProxy", url)}
+}
+
+func (_c *Toolchain_GetHTTPProxy_Call) Run(run func(url string)) *Toolchain_GetHTTPProxy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetHTTPProxy_Call) Return(s string, err error) *Toolchain_GetHTTPProxy_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *Toolchain_GetHTTPProxy_Call) RunAndReturn(run func(url string) (string, error)) *Toolchain_GetHTTPProxy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestModuleVersion provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetLatestModuleVersion(ctx context.Context, module model.Module) (model.Module, error) {
+	ret := _mock.Called(ctx, module)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestModuleVersion")
+	}
+
+	var r0 model.Module
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) (model.Module, error)); ok {
+		return returnFunc(ctx, module)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) model.Module); ok {
+		r0 = returnFunc(ctx, module)
+	} else {
+		r0 = ret.Get(0).(model.Module)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Module) error); ok {
+		r1 = returnFunc(ctx, module)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetLatestModuleVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestModuleVersion'
+type Toolchain_GetLatestModuleVersion_Call struct {
+	*mock.Call
+}
+
+// GetLatestModuleVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - module model.Module
+func (_e *Toolchain_Expecter) GetLatestModuleVersion(ctx interface{}, module interface{}) *Toolchain_GetLatestModuleVersion_Call {
+	return &Toolchain_GetLatestModuleVersion_Call{Call: _e.mock.On("GetLatestModuleVersion", ctx, module)}
+}
+
+func (_c *Toolchain_GetLatestModuleVersion_Call) Run(run func(ctx context.Context, module model.Module)) *Toolchain_GetLatestModuleVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Module
+		if args[1] != nil {
+			arg1 = args[1].(model.Module)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetLatestModuleVersion_Call) Return(module1 model.Module, err error) *Toolchain_GetLatestModuleVersion_Call {
+	_c.Call.Return(module1, err)
+	return _c
+}
+
+func (_c *Toolchain_GetLatestModuleVersion_Call) RunAndReturn(run func(ctx context.Context, module model.Module) (model.Module, error)) *Toolchain_GetLatestModuleVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetModuleFile provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetModuleFile(ctx context.Context, module model.Module) (*modfile.File, error) {
+	ret := _mock.Called(ctx, module)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetModuleFile")
+	}
+
+	var r0 *modfile.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) (*modfile.File, error)); ok {
+		return returnFunc(ctx, module)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) *modfile.File); ok {
+		r0 = returnFunc(ctx, module)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*modfile.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Module) error); ok {
+		r1 = returnFunc(ctx, module)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetModuleFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetModuleFile'
+type Toolchain_GetModuleFile_Call struct {
+	*mock.Call
+}
+
+// GetModuleFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - module model.Module
+func (_e *Toolchain_Expecter) GetModuleFile(ctx interface{}, module interface{}) *Toolchain_GetModuleFile_Call {
+	return &Toolchain_GetModuleFile_Call{Call: _e.mock.On("GetModuleFile", ctx, module)}
+}
+
+func (_c *Toolchain_GetModuleFile_Call) Run(run func(ctx context.Context, module model.Module)) *Toolchain_GetModuleFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Module
+		if args[1] != nil {
+			arg1 = args[1].(model.Module)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetModuleFile_Call) Return(file *modfile.File, err error) *Toolchain_GetModuleFile_Call {
+	_c.Call.Return(file, err)
+	return _c
+}
+
+func (_c *Toolchain_GetModuleFile_Call) RunAndReturn(run func(ctx context.Context, module model.Module) (*modfile.File, error)) *Toolchain_GetModuleFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetModuleOrigin provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetModuleOrigin(ctx context.Context, module model.Module) (*model.ModuleOrigin, error) {
+	ret := _mock.Called(ctx, module)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetModuleOrigin")
+	}
+
+	var r0 *model.ModuleOrigin
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) (*model.ModuleOrigin, error)); ok {
+		return returnFunc(ctx, module)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) *model.ModuleOrigin); ok {
+		r0 = returnFunc(ctx, module)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ModuleOrigin)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Module) error); ok {
+		r1 = returnFunc(ctx, module)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetModuleOrigin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetModuleOrigin'
+type Toolchain_GetModuleOrigin_Call struct {
+	*mock.Call
+}
+
+// GetModuleOrigin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - module model.Module
+func (_e *Toolchain_Expecter) GetModuleOrigin(ctx interface{}, module interface{}) *Toolchain_GetModuleOrigin_Call {
+	return &Toolchain_GetModuleOrigin_Call{Call: _e.mock.On("GetModuleOrigin", ctx, module)}
+}
+
+func (_c *Toolchain_GetModuleOrigin_Call) Run(run func(ctx context.Context, module model.Module)) *Toolchain_GetModuleOrigin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Module
+		if args[1] != nil {
+			arg1 = args[1].(model.Module)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetModuleOrigin_Call) Return(moduleOrigin *model.ModuleOrigin, err error) *Toolchain_GetModuleOrigin_Call {
+	_c.Call.Return(moduleOrigin, err)
+	return _c
+}
+
+func (_c *Toolchain_GetModuleOrigin_Call) RunAndReturn(run func(ctx context.Context, module model.Module) (*model.ModuleOrigin, error)) *Toolchain_GetModuleOrigin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetModuleVersions provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetModuleVersions(ctx context.Context, path string) ([]model.Version, error) {
+	ret := _mock.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetModuleVersions")
+	}
+
+	var r0 []model.Version
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]model.Version, error)); ok {
+		return returnFunc(ctx, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []model.Version); ok {
+		r0 = returnFunc(ctx, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Version)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetModuleVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetModuleVersions'
+type Toolchain_GetModuleVersions_Call struct {
+	*mock.Call
+}
+
+// GetModuleVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *Toolchain_Expecter) GetModuleVersions(ctx interface{}, path interface{}) *Toolchain_GetModuleVersions_Call {
+	return &Toolchain_GetModuleVersions_Call{Call: _e.mock.On("GetModuleVersions", ctx, path)}
+}
+
+func (_c *Toolchain_GetModuleVersions_Call) Run(run func(ctx context.Context, path string)) *Toolchain_GetModuleVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetModuleVersions_Call) Return(versions []model.Version, err error) *Toolchain_GetModuleVersions_Call {
+	_c.Call.Return(versions, err)
+	return _c
+}
+
+func (_c *Toolchain_GetModuleVersions_Call) RunAndReturn(run func(ctx context.Context, path string) ([]model.Version, error)) *Toolchain_GetModuleVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPackageDownloadSize provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetPackageDownloadSize(ctx context.Context, pkg model.Package) (model.Module, model.ByteSize, error) {
+	ret := _mock.Called(ctx, pkg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPackageDownloadSize")
+	}
+
+	var r0 model.Module
+	var r1 model.ByteSize
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package) (model.Module, model.ByteSize, error)); ok {
+		return returnFunc(ctx, pkg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package) model.Module); ok {
+		r0 = returnFunc(ctx, pkg)
+	} else {
+		r0 = ret.Get(0).(model.Module)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Package) model.ByteSize); ok {
+		r1 = returnFunc(ctx, pkg)
+	} else {
+		r1 = ret.Get(1).(model.ByteSize)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, model.Package) error); ok {
+		r2 = returnFunc(ctx, pkg)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// Toolchain_GetPackageDownloadSize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPackageDownloadSize'
+type Toolchain_GetPackageDownloadSize_Call struct {
+	*mock.Call
+}
+
+// GetPackageDownloadSize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pkg model.Package
+func (_e *Toolchain_Expecter) GetPackageDownloadSize(ctx interface{}, pkg interface{}) *Toolchain_GetPackageDownloadSize_Call {
+	return &Toolchain_GetPackageDownloadSize_Call{Call: _e.mock.On("GetPackageDownloadSize", ctx, pkg)}
+}
+
+func (_c *Toolchain_GetPackageDownloadSize_Call) Run(run func(ctx context.Context, pkg model.Package)) *Toolchain_GetPackageDownloadSize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Package
+		if args[1] != nil {
+			arg1 = args[1].(model.Package)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetPackageDownloadSize_Call) Return(module model.Module, byteSize model.ByteSize, err error) *Toolchain_GetPackageDownloadSize_Call {
+	_c.Call.Return(module, byteSize, err)
+	return _c
+}
+
+func (_c *Toolchain_GetPackageDownloadSize_Call) RunAndReturn(run func(ctx context.Context, pkg model.Package) (model.Module, model.ByteSize, error)) *Toolchain_GetPackageDownloadSize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPackageInfo provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetPackageInfo(ctx context.Context, pkg model.Package) (model.PackageInfo, error) {
+	ret := _mock.Called(ctx, pkg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPackageInfo")
+	}
+
+	var r0 model.PackageInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package) (model.PackageInfo, error)); ok {
+		return returnFunc(ctx, pkg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Package) model.PackageInfo); ok {
+		r0 = returnFunc(ctx, pkg)
+	} else {
+		r0 = ret.Get(0).(model.PackageInfo)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Package) error); ok {
+		r1 = returnFunc(ctx, pkg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetPackageInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPackageInfo'
+type Toolchain_GetPackageInfo_Call struct {
+	*mock.Call
+}
+
+// GetPackageInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pkg model.Package
+func (_e *Toolchain_Expecter) GetPackageInfo(ctx interface{}, pkg interface{}) *Toolchain_GetPackageInfo_Call {
+	return &Toolchain_GetPackageInfo_Call{Call: _e.mock.On("GetPackageInfo", ctx, pkg)}
+}
+
+func (_c *Toolchain_GetPackageInfo_Call) Run(run func(ctx context.Context, pkg model.Package)) *Toolchain_GetPackageInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Package
+		if args[1] != nil {
+			arg1 = args[1].(model.Package)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetPackageInfo_Call) Return(packageInfo model.PackageInfo, err error) *Toolchain_GetPackageInfo_Call {
+	_c.Call.Return(packageInfo, err)
+	return _c
+}
+
+func (_c *Toolchain_GetPackageInfo_Call) RunAndReturn(run func(ctx context.Context, pkg model.Package) (model.PackageInfo, error)) *Toolchain_GetPackageInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProxies provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetProxies(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProxies")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetProxies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProxies'
+type Toolchain_GetProxies_Call struct {
+	*mock.Call
+}
+
+// GetProxies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Toolchain_Expecter) GetProxies(ctx interface{}) *Toolchain_GetProxies_Call {
+	return &Toolchain_GetProxies_Call{Call: _e.mock.On("GetProxies", ctx)}
+}
+
+func (_c *Toolchain_GetProxies_Call) Run(run func(ctx context.Context)) *Toolchain_GetProxies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetProxies_Call) Return(strings []string, err error) *Toolchain_GetProxies_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *Toolchain_GetProxies_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *Toolchain_GetProxies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSumDBConfig provides a mock function for the type Toolchain
+func (_mock *Toolchain) GetSumDBConfig(ctx context.Context) (model.SumDBConfig, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSumDBConfig")
+	}
+
+	var r0 model.SumDBConfig
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.SumDBConfig, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.SumDBConfig); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.SumDBConfig)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Toolchain_GetSumDBConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSumDBConfig'
+type Toolchain_GetSumDBConfig_Call struct {
+	*mock.Call
+}
+
+// GetSumDBConfig is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Toolchain_Expecter) GetSumDBConfig(ctx interface{}) *Toolchain_GetSumDBConfig_Call {
+	return &Toolchain_GetSumDBConfig_Call{Call: _e.mock.On("GetSumDBConfig", ctx)}
+}
+
+func (_c *Toolchain_GetSumDBConfig_Call) Run(run func(ctx context.Context)) *Toolchain_GetSumDBConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_GetSumDBConfig_Call) Return(sumDBConfig model.SumDBConfig, err error) *Toolchain_GetSumDBConfig_Call {
+	_c.Call.Return(sumDBConfig, err)
+	return _c
+}
+
+func (_c *Toolchain_GetSumDBConfig_Call) RunAndReturn(run func(ctx context.Context) (model.SumDBConfig, error)) *Toolchain_GetSumDBConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Install provides a mock function for the type Toolchain
+func (_mock *Toolchain) Install(ctx context.Context, path string, pkg model.Package, flags model.BuildFlags, rebuild bool) error {
+	ret := _mock.Called(ctx, path, pkg, flags, rebuild)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Install")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Package, model.BuildFlags, bool) error); ok {
+		r0 = returnFunc(ctx, path, pkg, flags, rebuild)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Toolchain_Install_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Install'
+type Toolchain_Install_Call struct {
+	*mock.Call
+}
+
+// Install is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+//   - pkg model.Package
+//   - flags model.BuildFlags
+//   - rebuild bool
+func (_e *Toolchain_Expecter) Install(ctx interface{}, path interface{}, pkg interface{}, flags interface{}, rebuild interface{}) *Toolchain_Install_Call {
+	return &Toolchain_Install_Call{Call: _e.mock.On("Install", ctx, path, pkg, flags, rebuild)}
+}
+
+func (_c *Toolchain_Install_Call) Run(run func(ctx context.Context, path string, pkg model.Package, flags model.BuildFlags, rebuild bool)) *Toolchain_Install_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.Package
+		if args[2] != nil {
+			arg2 = args[2].(model.Package)
+		}
+		var arg3 model.BuildFlags
+		if args[3] != nil {
+			arg3 = args[3].(model.BuildFlags)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_Install_Call) Return(err error) *Toolchain_Install_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Toolchain_Install_Call) RunAndReturn(run func(ctx context.Context, path string, pkg model.Package, flags model.BuildFlags, rebuild bool) error) *Toolchain_Install_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InstallLocal provides a mock function for the type Toolchain
+func (_mock *Toolchain) InstallLocal(ctx context.Context, path string, dir string, flags model.BuildFlags) error {
+	ret := _mock.Called(ctx, path, dir, flags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InstallLocal")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, model.BuildFlags) error); ok {
+		r0 = returnFunc(ctx, path, dir, flags)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Toolchain_InstallLocal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallLocal'
+type Toolchain_InstallLocal_Call struct {
+	*mock.Call
+}
+
+// InstallLocal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+//   - dir string
+//   - flags model.BuildFlags
+func (_e *Toolchain_Expecter) InstallLocal(ctx interface{}, path interface{}, dir interface{}, flags interface{}) *Toolchain_InstallLocal_Call {
+	return &Toolchain_InstallLocal_Call{Call: _e.mock.On("InstallLocal", ctx, path, dir, flags)}
+}
+
+func (_c *Toolchain_InstallLocal_Call) Run(run func(ctx context.Context, path string, dir string, flags model.BuildFlags)) *Toolchain_InstallLocal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 model.BuildFlags
+		if args[3] != nil {
+			arg3 = args[3].(model.BuildFlags)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_InstallLocal_Call) Return(err error) *Toolchain_InstallLocal_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Toolchain_InstallLocal_Call) RunAndReturn(run func(ctx context.Context, path string, dir string, flags model.BuildFlags) error) *Toolchain_InstallLocal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InstallPlatform provides a mock function for the type Toolchain
+func (_mock *Toolchain) InstallPlatform(ctx context.Context, path string, pkg model.Package, platform string, flags model.BuildFlags, rebuild bool) error {
+	ret := _mock.Called(ctx, path, pkg, platform, flags, rebuild)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InstallPlatform")
 	}
 
-	var r0 *buildinfo.BuildInfo
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*buildinfo.BuildInfo, error)); ok {
-		return returnFunc(path)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string) *buildinfo.BuildInfo); ok {
-		r0 = returnFunc(path)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*buildinfo.BuildInfo)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(path)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Package, string, model.BuildFlags, bool) error); ok {
+		r0 = returnFunc(ctx, path, pkg, platform, flags, rebuild)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// Toolchain_GetBuildInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBuildInfo'
-type Toolchain_GetBuildInfo_Call struct {
+// Toolchain_InstallPlatform_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InstallPlatform'
+type Toolchain_InstallPlatform_Call struct {
 	*mock.Call
 }
 
-// GetBuildInfo is a helper method to define mock.On call
+// InstallPlatform is a helper method to define mock.On call
+//   - ctx context.Context
 //   - path string
-func (_e *Toolchain_Expecter) GetBuildInfo(path interface{}) *Toolchain_GetBuildInfo_Call {
-	return &Toolchain_GetBuildInfo_Call{Call: _e.mock.On("GetBuildInfo", path)}
+//   - pkg model.Package
+//   - platform string
+//   - flags model.BuildFlags
+//   - rebuild bool
+func (_e *Toolchain_Expecter) InstallPlatform(ctx interface{}, path interface{}, pkg interface{}, platform interface{}, flags interface{}, rebuild interface{}) *Toolchain_InstallPlatform_Call {
+	return &Toolchain_InstallPlatform_Call{Call: _e.mock.On("InstallPlatform", ctx, path, pkg, platform, flags, rebuild)}
 }
 
-func (_c *Toolchain_GetBuildInfo_Call) Run(run func(path string)) *Toolchain_GetBuildInfo_Call {
+func (_c *Toolchain_InstallPlatform_Call) Run(run func(ctx context.Context, path string, pkg model.Package, platform string, flags model.BuildFlags, rebuild bool)) *Toolchain_InstallPlatform_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.Package
+		if args[2] != nil {
+			arg2 = args[2].(model.Package)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 model.BuildFlags
+		if args[4] != nil {
+			arg4 = args[4].(model.BuildFlags)
+		}
+		var arg5 bool
+		if args[5] != nil {
+			arg5 = args[5].(bool)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *Toolchain_GetBuildInfo_Call) Return(v *buildinfo.BuildInfo, err error) *Toolchain_GetBuildInfo_Call {
-	_c.Call.Return(v, err)
+func (_c *Toolchain_InstallPlatform_Call) Return(err error) *Toolchain_InstallPlatform_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Toolchain_GetBuildInfo_Call) RunAndReturn(run func(path string) (*buildinfo.BuildInfo, error)) *Toolchain_GetBuildInfo_Call {
+func (_c *Toolchain_InstallPlatform_Call) RunAndReturn(run func(ctx context.Context, path string, pkg model.Package, platform string, flags model.BuildFlags, rebuild bool) error) *Toolchain_InstallPlatform_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLatestModuleVersion provides a mock function for the type Toolchain
-func (_mock *Toolchain) GetLatestModuleVersion(ctx context.Context, module model.Module) (model.Module, error) {
-	ret := _mock.Called(ctx, module)
+// LookupModuleSum provides a mock function for the type Toolchain
+func (_mock *Toolchain) LookupModuleSum(ctx context.Context, sumDB model.SumDBConfig, module model.Module) (string, error) {
+	ret := _mock.Called(ctx, sumDB, module)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLatestModuleVersion")
+		panic("no return value specified for LookupModuleSum")
 	}
 
-	var r0 model.Module
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) (model.Module, error)); ok {
-		return returnFunc(ctx, module)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SumDBConfig, model.Module) (string, error)); ok {
+		return returnFunc(ctx, sumDB, module)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) model.Module); ok {
-		r0 = returnFunc(ctx, module)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SumDBConfig, model.Module) string); ok {
+		r0 = returnFunc(ctx, sumDB, module)
 	} else {
-		r0 = ret.Get(0).(model.Module)
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Module) error); ok {
-		r1 = returnFunc(ctx, module)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.SumDBConfig, model.Module) error); ok {
+		r1 = returnFunc(ctx, sumDB, module)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Toolchain_GetLatestModuleVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestModuleVersion'
-type Toolchain_GetLatestModuleVersion_Call struct {
+// Toolchain_LookupModuleSum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupModuleSum'
+type Toolchain_LookupModuleSum_Call struct {
 	*mock.Call
 }
 
-// GetLatestModuleVersion is a helper method to define mock.On call
+// LookupModuleSum is a helper method to define mock.On call
 //   - ctx context.Context
+//   - sumDB model.SumDBConfig
 //   - module model.Module
-func (_e *Toolchain_Expecter) GetLatestModuleVersion(ctx interface{}, module interface{}) *Toolchain_GetLatestModuleVersion_Call {
-	return &Toolchain_GetLatestModuleVersion_Call{Call: _e.mock.On("GetLatestModuleVersion", ctx, module)}
+func (_e *Toolchain_Expecter) LookupModuleSum(ctx interface{}, sumDB interface{}, module interface{}) *Toolchain_LookupModuleSum_Call {
+	return &Toolchain_LookupModuleSum_Call{Call: _e.mock.On("LookupModuleSum", ctx, sumDB, module)}
 }
 
-func (_c *Toolchain_GetLatestModuleVersion_Call) Run(run func(ctx context.Context, module model.Module)) *Toolchain_GetLatestModuleVersion_Call {
+func (_c *Toolchain_LookupModuleSum_Call) Run(run func(ctx context.Context, sumDB model.SumDBConfig, module model.Module)) *Toolchain_LookupModuleSum_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.Module
+		var arg1 model.SumDBConfig
 		if args[1] != nil {
-			arg1 = args[1].(model.Module)
+			arg1 = args[1].(model.SumDBConfig)
+		}
+		var arg2 model.Module
+		if args[2] != nil {
+			arg2 = args[2].(model.Module)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Toolchain_GetLatestModuleVersion_Call) Return(module1 model.Module, err error) *Toolchain_GetLatestModuleVersion_Call {
-	_c.Call.Return(module1, err)
+func (_c *Toolchain_LookupModuleSum_Call) Return(s string, err error) *Toolchain_LookupModuleSum_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *Toolchain_GetLatestModuleVersion_Call) RunAndReturn(run func(ctx context.Context, module model.Module) (model.Module, error)) *Toolchain_GetLatestModuleVersion_Call {
+func (_c *Toolchain_LookupModuleSum_Call) RunAndReturn(run func(ctx context.Context, sumDB model.SumDBConfig, module model.Module) (string, error)) *Toolchain_LookupModuleSum_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetModuleFile provides a mock function for the type Toolchain
-func (_mock *Toolchain) GetModuleFile(ctx context.Context, module model.Module) (*modfile.File, error) {
-	ret := _mock.Called(ctx, module)
+// ProbeBinary provides a mock function for the type Toolchain
+func (_mock *Toolchain) ProbeBinary(ctx context.Context, path string) error {
+	ret := _mock.Called(ctx, path)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetModuleFile")
+		panic("no return value specified for ProbeBinary")
 	}
 
-	var r0 *modfile.File
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) (*modfile.File, error)); ok {
-		return returnFunc(ctx, module)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) *modfile.File); ok {
-		r0 = returnFunc(ctx, module)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, path)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*modfile.File)
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Toolchain_ProbeBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProbeBinary'
+type Toolchain_ProbeBinary_Call struct {
+	*mock.Call
+}
+
+// ProbeBinary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *Toolchain_Expecter) ProbeBinary(ctx interface{}, path interface{}) *Toolchain_ProbeBinary_Call {
+	return &Toolchain_ProbeBinary_Call{Call: _e.mock.On("ProbeBinary", ctx, path)}
+}
+
+func (_c *Toolchain_ProbeBinary_Call) Run(run func(ctx context.Context, path string)) *Toolchain_ProbeBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_ProbeBinary_Call) Return(err error) *Toolchain_ProbeBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Toolchain_ProbeBinary_Call) RunAndReturn(run func(ctx context.Context, path string) error) *Toolchain_ProbeBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProbeProxy provides a mock function for the type Toolchain
+func (_mock *Toolchain) ProbeProxy(ctx context.Context, proxy string) error {
+	ret := _mock.Called(ctx, proxy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProbeProxy")
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Module) error); ok {
-		r1 = returnFunc(ctx, module)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, proxy)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// Toolchain_GetModuleFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetModuleFile'
-type Toolchain_GetModuleFile_Call struct {
+// Toolchain_ProbeProxy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProbeProxy'
+type Toolchain_ProbeProxy_Call struct {
 	*mock.Call
 }
 
-// GetModuleFile is a helper method to define mock.On call
+// ProbeProxy is a helper method to define mock.On call
 //   - ctx context.Context
-//   - module model.Module
-func (_e *Toolchain_Expecter) GetModuleFile(ctx interface{}, module interface{}) *Toolchain_GetModuleFile_Call {
-	return &Toolchain_GetModuleFile_Call{Call: _e.mock.On("GetModuleFile", ctx, module)}
+//   - proxy string
+func (_e *Toolchain_Expecter) ProbeProxy(ctx interface{}, proxy interface{}) *Toolchain_ProbeProxy_Call {
+	return &Toolchain_ProbeProxy_Call{Call: _e.mock.On("ProbeProxy", ctx, proxy)}
 }
 
-func (_c *Toolchain_GetModuleFile_Call) Run(run func(ctx context.Context, module model.Module)) *Toolchain_GetModuleFile_Call {
+func (_c *Toolchain_ProbeProxy_Call) Run(run func(ctx context.Context, proxy string)) *Toolchain_ProbeProxy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.Module
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(model.Module)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -226,116 +1407,203 @@ func (_c *Toolchain_GetModuleFile_Call) Run(run func(ctx context.Context, module
 	return _c
 }
 
-func (_c *Toolchain_GetModuleFile_Call) Return(file *modfile.File, err error) *Toolchain_GetModuleFile_Call {
-	_c.Call.Return(file, err)
+func (_c *Toolchain_ProbeProxy_Call) Return(err error) *Toolchain_ProbeProxy_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Toolchain_GetModuleFile_Call) RunAndReturn(run func(ctx context.Context, module model.Module) (*modfile.File, error)) *Toolchain_GetModuleFile_Call {
+func (_c *Toolchain_ProbeProxy_Call) RunAndReturn(run func(ctx context.Context, proxy string) error) *Toolchain_ProbeProxy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetModuleOrigin provides a mock function for the type Toolchain
-func (_mock *Toolchain) GetModuleOrigin(ctx context.Context, module model.Module) (*model.ModuleOrigin, error) {
-	ret := _mock.Called(ctx, module)
+// SearchPackages provides a mock function for the type Toolchain
+func (_mock *Toolchain) SearchPackages(ctx context.Context, url string, query string, limit int) ([]model.PackageSearchResult, error) {
+	ret := _mock.Called(ctx, url, query, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetModuleOrigin")
+		panic("no return value specified for SearchPackages")
 	}
 
-	var r0 *model.ModuleOrigin
+	var r0 []model.PackageSearchResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) (*model.ModuleOrigin, error)); ok {
-		return returnFunc(ctx, module)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) ([]model.PackageSearchResult, error)); ok {
+		return returnFunc(ctx, url, query, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Module) *model.ModuleOrigin); ok {
-		r0 = returnFunc(ctx, module)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) []model.PackageSearchResult); ok {
+		r0 = returnFunc(ctx, url, query, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.ModuleOrigin)
+			r0 = ret.Get(0).([]model.PackageSearchResult)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Module) error); ok {
-		r1 = returnFunc(ctx, module)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = returnFunc(ctx, url, query, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Toolchain_GetModuleOrigin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetModuleOrigin'
-type Toolchain_GetModuleOrigin_Call struct {
+// Toolchain_SearchPackages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchPackages'
+type Toolchain_SearchPackages_Call struct {
 	*mock.Call
 }
 
-// GetModuleOrigin is a helper method to define mock.On call
+// SearchPackages is a helper method to define mock.On call
 //   - ctx context.Context
-//   - module model.Module
-func (_e *Toolchain_Expecter) GetModuleOrigin(ctx interface{}, module interface{}) *Toolchain_GetModuleOrigin_Call {
-	return &Toolchain_GetModuleOrigin_Call{Call: _e.mock.On("GetModuleOrigin", ctx, module)}
+//   - url string
+//   - query string
+//   - limit int
+func (_e *Toolchain_Expecter) SearchPackages(ctx interface{}, url interface{}, query interface{}, limit interface{}) *Toolchain_SearchPackages_Call {
+	return &Toolchain_SearchPackages_Call{Call: _e.mock.On("SearchPackages", ctx, url, query, limit)}
 }
 
-func (_c *Toolchain_GetModuleOrigin_Call) Run(run func(ctx context.Context, module model.Module)) *Toolchain_GetModuleOrigin_Call {
+func (_c *Toolchain_SearchPackages_Call) Run(run func(ctx context.Context, url string, query string, limit int)) *Toolchain_SearchPackages_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.Module
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(model.Module)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Toolchain_SearchPackages_Call) Return(packageSearchResults []model.PackageSearchResult, err error) *Toolchain_SearchPackages_Call {
+	_c.Call.Return(packageSearchResults, err)
+	return _c
+}
+
+func (_c *Toolchain_SearchPackages_Call) RunAndReturn(run func(ctx context.Context, url string, query string, limit int) ([]model.PackageSearchResult, error)) *Toolchain_SearchPackages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sign provides a mock function for the type Toolchain
+func (_mock *Toolchain) Sign(ctx context.Context, goos string, identity string, path string, signaturePath string) error {
+	ret := _mock.Called(ctx, goos, identity, path, signaturePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = returnFunc(ctx, goos, identity, path, signaturePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Toolchain_Sign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sign'
+type Toolchain_Sign_Call struct {
+	*mock.Call
+}
+
+// Sign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - goos string
+//   - identity string
+//   - path string
+//   - signaturePath string
+func (_e *Toolchain_Expecter) Sign(ctx interface{}, goos interface{}, identity interface{}, path interface{}, signaturePath interface{}) *Toolchain_Sign_Call {
+	return &Toolchain_Sign_Call{Call: _e.mock.On("Sign", ctx, goos, identity, path, signaturePath)}
+}
+
+func (_c *Toolchain_Sign_Call) Run(run func(ctx context.Context, goos string, identity string, path string, signaturePath string)) *Toolchain_Sign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *Toolchain_GetModuleOrigin_Call) Return(moduleOrigin *model.ModuleOrigin, err error) *Toolchain_GetModuleOrigin_Call {
-	_c.Call.Return(moduleOrigin, err)
+func (_c *Toolchain_Sign_Call) Return(err error) *Toolchain_Sign_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Toolchain_GetModuleOrigin_Call) RunAndReturn(run func(ctx context.Context, module model.Module) (*model.ModuleOrigin, error)) *Toolchain_GetModuleOrigin_Call {
+func (_c *Toolchain_Sign_Call) RunAndReturn(run func(ctx context.Context, goos string, identity string, path string, signaturePath string) error) *Toolchain_Sign_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Install provides a mock function for the type Toolchain
-func (_mock *Toolchain) Install(ctx context.Context, path string, pkg model.Package, rebuild bool) error {
-	ret := _mock.Called(ctx, path, pkg, rebuild)
+// VerifySignature provides a mock function for the type Toolchain
+func (_mock *Toolchain) VerifySignature(ctx context.Context, goos string, path string, signaturePath string) error {
+	ret := _mock.Called(ctx, goos, path, signaturePath)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Install")
+		panic("no return value specified for VerifySignature")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Package, bool) error); ok {
-		r0 = returnFunc(ctx, path, pkg, rebuild)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, goos, path, signaturePath)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// Toolchain_Install_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Install'
-type Toolchain_Install_Call struct {
+// Toolchain_VerifySignature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifySignature'
+type Toolchain_VerifySignature_Call struct {
 	*mock.Call
 }
 
-// Install is a helper method to define mock.On call
+// VerifySignature is a helper method to define mock.On call
 //   - ctx context.Context
+//   - goos string
 //   - path string
-//   - pkg model.Package
-//   - rebuild bool
-func (_e *Toolchain_Expecter) Install(ctx interface{}, path interface{}, pkg interface{}, rebuild interface{}) *Toolchain_Install_Call {
-	return &Toolchain_Install_Call{Call: _e.mock.On("Install", ctx, path, pkg, rebuild)}
+//   - signaturePath string
+func (_e *Toolchain_Expecter) VerifySignature(ctx interface{}, goos interface{}, path interface{}, signaturePath interface{}) *Toolchain_VerifySignature_Call {
+	return &Toolchain_VerifySignature_Call{Call: _e.mock.On("VerifySignature", ctx, goos, path, signaturePath)}
 }
 
-func (_c *Toolchain_Install_Call) Run(run func(ctx context.Context, path string, pkg model.Package, rebuild bool)) *Toolchain_Install_Call {
+func (_c *Toolchain_VerifySignature_Call) Run(run func(ctx context.Context, goos string, path string, signaturePath string)) *Toolchain_VerifySignature_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -345,13 +1613,13 @@ func (_c *Toolchain_Install_Call) Run(run func(ctx context.Context, path string,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 model.Package
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(model.Package)
+			arg2 = args[2].(string)
 		}
-		var arg3 bool
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(bool)
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
@@ -363,12 +1631,12 @@ func (_c *Toolchain_Install_Call) Run(run func(ctx context.Context, path string,
 	return _c
 }
 
-func (_c *Toolchain_Install_Call) Return(err error) *Toolchain_Install_Call {
+func (_c *Toolchain_VerifySignature_Call) Return(err error) *Toolchain_VerifySignature_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Toolchain_Install_Call) RunAndReturn(run func(ctx context.Context, path string, pkg model.Package, rebuild bool) error) *Toolchain_Install_Call {
+func (_c *Toolchain_VerifySignature_Call) RunAndReturn(run func(ctx context.Context, goos string, path string, signaturePath string) error) *Toolchain_VerifySignature_Call {
 	_c.Call.Return(run)
 	return _c
 }
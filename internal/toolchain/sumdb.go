@@ -0,0 +1,117 @@
+package toolchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/mod/sumdb"
+)
+
+// sumDBOps is the implementation of the sumdb.ClientOps interface for a lookup
+// in a checksum database. The remote files are requested with the toolchain,
+// and the latest signed tree head and the tiles are kept in the toolchain, so
+// the tree heads of the lookups of a run are checked to be consistent.
+type sumDBOps struct {
+	ctx       context.Context
+	toolchain *GoToolchain
+	key       string
+	url       string
+	notFound  atomic.Bool
+}
+
+// newSumDBOps creates a new sumDBOps for a lookup in the checksum database at
+// the given URL, verified with the given verifier key.
+func newSumDBOps(ctx context.Context, toolchain *GoToolchain, key, url string) *sumDBOps {
+	return &sumDBOps{
+		ctx:       ctx,
+		toolchain: toolchain,
+		key:       key,
+		url:       strings.TrimSuffix(url, "/"),
+	}
+}
+
+// ReadRemote requests the file at the given path of the checksum database. It
+// records whether the module version looked up is not found.
+func (o *sumDBOps) ReadRemote(path string) ([]byte, error) {
+	data, err := o.toolchain.get(o.ctx, o.url+path)
+	if errors.Is(err, ErrModuleNotFound) && strings.HasPrefix(path, "/lookup/") {
+		o.notFound.Store(true)
+	}
+
+	return data, err
+}
+
+// ReadConfig returns the verifier key of the checksum database for the "key"
+// file, and the latest signed tree head seen by the toolchain, if any, for the
+// "<name>/latest" file.
+func (o *sumDBOps) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(o.key), nil
+	}
+
+	o.toolchain.sumDBMutex.Lock()
+	defer o.toolchain.sumDBMutex.Unlock()
+
+	return o.toolchain.sumDBConfig[file], nil
+}
+
+// WriteConfig replaces the latest signed tree head seen by the toolchain. It
+// returns sumdb.ErrWriteConflict if it was replaced since it was read.
+func (o *sumDBOps) WriteConfig(file string, oldData, newData []byte) error {
+	o.toolchain.sumDBMutex.Lock()
+	defer o.toolchain.sumDBMutex.Unlock()
+
+	if !bytes.Equal(o.toolchain.sumDBConfig[file], oldData) {
+		return sumdb.ErrWriteConflict
+	}
+
+	if o.toolchain.sumDBConfig == nil {
+		o.toolchain.sumDBConfig = make(map[string][]byte)
+	}
+
+	o.toolchain.sumDBConfig[file] = newData
+
+	return nil
+}
+
+// ReadCache returns the given file cached by the toolchain, ex. a tile. It
+// returns os.ErrNotExist if the file is not cached.
+func (o *sumDBOps) ReadCache(file string) ([]byte, error) {
+	o.toolchain.sumDBMutex.Lock()
+	defer o.toolchain.sumDBMutex.Unlock()
+
+	data, ok := o.toolchain.sumDBCache[file]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return data, nil
+}
+
+// WriteCache caches the given file in the toolchain.
+func (o *sumDBOps) WriteCache(file string, data []byte) {
+	o.toolchain.sumDBMutex.Lock()
+	defer o.toolchain.sumDBMutex.Unlock()
+
+	if o.toolchain.sumDBCache == nil {
+		o.toolchain.sumDBCache = make(map[string][]byte)
+	}
+
+	o.toolchain.sumDBCache[file] = data
+}
+
+// Log logs the given message of the checksum database client.
+func (o *sumDBOps) Log(msg string) {
+	slog.Default().DebugContext(o.ctx, msg, "url", o.url)
+}
+
+// SecurityError logs the given security error of the checksum database client,
+// ex. an inconsistent tree head. The lookup fails with sumdb.ErrSecurity.
+func (o *sumDBOps) SecurityError(msg string) {
+	slog.Default().ErrorContext(o.ctx, "checksum database misbehaving", "url", o.url, "err", msg)
+}
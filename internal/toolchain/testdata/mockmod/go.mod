@@ -0,0 +1,3 @@
+module example.com/mockorg/mockproj
+
+go 1.24
@@ -0,0 +1,3 @@
+module example.com/mockorg/mockproj/tools
+
+go 1.24
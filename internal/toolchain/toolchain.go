@@ -6,16 +6,75 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"go/parser"
+	"go/token"
+	"html"
+	"io"
+	"io/fs"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
 
+	"github.com/brunoribeiro127/gobin/internal"
 	"github.com/brunoribeiro127/gobin/internal/model"
 	"github.com/brunoribeiro127/gobin/internal/system"
 )
 
+const (
+	// probeModule is the well known module requested to probe a module proxy.
+	probeModule = "golang.org/x/mod"
+	// probeBinaryTimeout is the time a binary is given to run when probed,
+	// after which it is considered to have started successfully.
+	probeBinaryTimeout = 5 * time.Second
+)
+
+// directContextKey is the context key marking go commands to resolve modules
+// directly from their version control repositories.
+type directContextKey struct{}
+
+// goVersionContextKey is the context key defining the Go toolchain version the
+// go install commands build with.
+type goVersionContextKey struct{}
+
+// provenanceContextKey is the context key defining the provenance recorded in
+// the binaries built by the go install commands.
+type provenanceContextKey struct{}
+
+// proxyURLRegexp matches the URLs requested by go commands in their output.
+var proxyURLRegexp = regexp.MustCompile(`https?://[^\s"]+`)
+
+var (
+	// searchTitleRegexp matches the import path linked by the title of a
+	// package search result.
+	searchTitleRegexp = regexp.MustCompile(`<a[^>]*href="/([^"?#]+)"[^>]*data-test-id="snippet-title"`)
+	// searchSynopsisRegexp matches the synopsis of a package search result.
+	searchSynopsisRegexp = regexp.MustCompile(`(?s)data-test-id="snippet-synopsis"[^>]*>(.*?)</p>`)
+	// searchCommandRegexp matches the chip marking a package search result as
+	// a command.
+	searchCommandRegexp = regexp.MustCompile(`class="go-Chip[^"]*"[^>]*>\s*command\s*<`)
+	// htmlTagRegexp matches an HTML tag.
+	htmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+)
+
+// errProxyFallback indicates a module is to be resolved with the go command
+// rather than requested to the module proxies, ex. a private module or a
+// module proxy list falling back to direct.
+var errProxyFallback = errors.New("module proxy fallback to go command")
+
 var (
 	// ErrBinaryBuiltWithoutGoModules indicates the binary was built without
 	// module support.
@@ -24,9 +83,17 @@ var (
 	// ErrBinaryNotFound indicates the binary was not found.
 	ErrBinaryNotFound = errors.New("binary not found")
 
+	// ErrBuildFailed indicates the go install command failed to build the
+	// package.
+	ErrBuildFailed = errors.New("build failed")
+
 	// ErrGoModFileNotAvailable indicates the go mod file is not available.
 	ErrGoModFileNotAvailable = errors.New("go mod file not available")
 
+	// ErrDownloadSizeNotAvailable indicates the download size of the module
+	// is not available from the module proxies.
+	ErrDownloadSizeNotAvailable = errors.New("download size not available")
+
 	// ErrModuleNotFound indicates the module was not found.
 	ErrModuleNotFound = errors.New("module not found")
 
@@ -35,14 +102,61 @@ var (
 
 	// ErrModuleOriginNotAvailable indicates the module origin is not available.
 	ErrModuleOriginNotAvailable = errors.New("module origin not available")
+
+	// ErrModuleSumNotFound indicates the module version is not recorded in the
+	// checksum database.
+	ErrModuleSumNotFound = errors.New("module sum not found")
+
+	// ErrSumDBKeyNotFound indicates the verifier key of the checksum database is
+	// unknown, as GOSUMDB names a checksum database other than sum.golang.org
+	// without giving its key.
+	ErrSumDBKeyNotFound = errors.New("checksum database key not found")
+
+	// ErrNetworkUnavailable indicates the module proxies failed to respond,
+	// due to a network error or a server error.
+	ErrNetworkUnavailable = errors.New("network unavailable")
+
+	// ErrProxyAuthentication indicates a module proxy rejected the credentials
+	// of the .netrc file or GOAUTH.
+	ErrProxyAuthentication = errors.New("module proxy authentication failed")
 )
 
 // Toolchain is an interface for a toolchain.
 type Toolchain interface {
+	// Compress compresses a binary in place with UPX.
+	Compress(
+		ctx context.Context,
+		path string,
+	) error
+	// DownloadPackage downloads the modules required to install a package into
+	// a module cache.
+	DownloadPackage(
+		ctx context.Context,
+		modCache string,
+		pkg model.Package,
+	) error
 	// GetBuildInfo gets the build info for a binary.
 	GetBuildInfo(
 		path string,
 	) (*buildinfo.BuildInfo, error)
+	// GetBuildID gets the Go build ID of a binary.
+	GetBuildID(
+		ctx context.Context,
+		path string,
+	) (string, error)
+	// GetGoEnv gets the environment settings of the Go toolchain.
+	GetGoEnv(
+		ctx context.Context,
+	) (model.GoEnv, error)
+	// GetGoReleases gets the supported Go releases from a Go release feed.
+	GetGoReleases(
+		ctx context.Context,
+		url string,
+	) (model.GoReleases, error)
+	// GetHTTPProxy gets the HTTP proxy traversed to reach a URL.
+	GetHTTPProxy(
+		url string,
+	) (string, error)
 	// GetLatestModuleVersion gets the latest module version for a given module.
 	GetLatestModuleVersion(
 		ctx context.Context,
@@ -58,13 +172,95 @@ type Toolchain interface {
 		ctx context.Context,
 		module model.Module,
 	) (*model.ModuleOrigin, error)
+	// GetModuleVersions gets the versions of the module providing a package.
+	GetModuleVersions(
+		ctx context.Context,
+		path string,
+	) ([]model.Version, error)
+	// GetPackageDownloadSize gets the download size of the module providing a
+	// package.
+	GetPackageDownloadSize(
+		ctx context.Context,
+		pkg model.Package,
+	) (model.Module, model.ByteSize, error)
+	// GetPackageInfo gets the package info for a given package and version.
+	GetPackageInfo(
+		ctx context.Context,
+		pkg model.Package,
+	) (model.PackageInfo, error)
+	// GetProxies gets the module proxies.
+	GetProxies(
+		ctx context.Context,
+	) ([]string, error)
+	// GetSumDBConfig gets the checksum database configuration.
+	GetSumDBConfig(
+		ctx context.Context,
+	) (model.SumDBConfig, error)
 	// Install installs a package in the target path.
 	Install(
 		ctx context.Context,
 		path string,
 		pkg model.Package,
+		flags model.BuildFlags,
+		rebuild bool,
+	) error
+	// InstallLocal installs the main package of a local directory in the target
+	// path.
+	InstallLocal(
+		ctx context.Context,
+		path string,
+		dir string,
+		flags model.BuildFlags,
+	) error
+	// InstallPlatform installs a package for a platform in the target path.
+	InstallPlatform(
+		ctx context.Context,
+		path string,
+		pkg model.Package,
+		platform string,
+		flags model.BuildFlags,
 		rebuild bool,
 	) error
+	// LookupModuleSum looks up the sum of a module version in a checksum
+	// database.
+	LookupModuleSum(
+		ctx context.Context,
+		sumDB model.SumDBConfig,
+		module model.Module,
+	) (string, error)
+	// ProbeBinary probes a binary by running it.
+	ProbeBinary(
+		ctx context.Context,
+		path string,
+	) error
+	// ProbeProxy probes a module proxy.
+	ProbeProxy(
+		ctx context.Context,
+		proxy string,
+	) error
+	// SearchPackages searches the package index for packages matching a query.
+	SearchPackages(
+		ctx context.Context,
+		url string,
+		query string,
+		limit int,
+	) ([]model.PackageSearchResult, error)
+	// Sign signs a binary with the signing tool of an operating system.
+	Sign(
+		ctx context.Context,
+		goos string,
+		identity string,
+		path string,
+		signaturePath string,
+	) error
+	// VerifySignature verifies the signature of a binary with the signing tool
+	// of an operating system.
+	VerifySignature(
+		ctx context.Context,
+		goos string,
+		path string,
+		signaturePath string,
+	) error
 	// VulnCheck checks for vulnerabilities in a binary.
 	VulnCheck(
 		ctx context.Context,
@@ -72,26 +268,124 @@ type Toolchain interface {
 	) ([]model.Vulnerability, error)
 }
 
+// WithDirect returns a copy of the context marking the go commands run with it
+// to resolve modules directly from their version control repositories,
+// bypassing the module proxies. It is meant as a fallback for modules missing
+// from the module proxies, such as private modules or brand new tags.
+func WithDirect(ctx context.Context) context.Context {
+	return context.WithValue(ctx, directContextKey{}, true)
+}
+
+// WithGoVersion returns a copy of the context defining the Go toolchain version,
+// ex. go1.24.5, the go install commands run with it build with. The version is
+// set in GOTOOLCHAIN, so the go command downloads it if not the local one. It
+// is meant to rebuild a binary with the toolchain it was built with.
+func WithGoVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, goVersionContextKey{}, version)
+}
+
+// WithProvenance returns a copy of the context defining the provenance, ex. the
+// gobin version and command, recorded in the binaries built by the go install
+// commands run with it, with the package spec of each build. Only the binaries
+// built with the provenance build flag record it.
+func WithProvenance(ctx context.Context, provenance model.Provenance) context.Context {
+	return context.WithValue(ctx, provenanceContextKey{}, provenance)
+}
+
 // GoToolchain is a toolchain to interact with the Go toolchain.
 type GoToolchain struct {
 	buildInfo system.BuildInfo
 	exec      system.Exec
 	scanExec  ScanExecCombinedOutputFunc
+	network   model.NetworkConfig
+	slots     chan struct{}
+
+	proxyMutex    sync.Mutex
+	proxyLoaded   bool
+	proxyList     model.ProxyList
+	noProxy       string
+	netrc         model.Netrc
+	failedProxies []string
+
+	sumDBMutex  sync.Mutex
+	sumDBConfig map[string][]byte
+	sumDBCache  map[string][]byte
 }
 
 // NewGoToolchain creates a new GoToolchain to interact with the Go toolchain.
+// The network configuration defines the retries, backoff, timeout and
+// concurrency of the module proxy requests.
 func NewGoToolchain(
 	buildInfo system.BuildInfo,
 	exec system.Exec,
 	scanExec ScanExecCombinedOutputFunc,
+	network model.NetworkConfig,
 ) *GoToolchain {
+	var slots chan struct{}
+	if network.Concurrency > 0 {
+		slots = make(chan struct{}, network.Concurrency)
+	}
+
 	return &GoToolchain{
 		buildInfo: buildInfo,
 		exec:      exec,
 		scanExec:  scanExec,
+		network:   network,
+		slots:     slots,
 	}
 }
 
+// Compress compresses the binary at the given path in place with the upx
+// command, using its best compression level. It fails if the upx command fails,
+// ex. for an unsupported binary format.
+func (t *GoToolchain) Compress(ctx context.Context, path string) error {
+	logger := slog.Default().With("path", path)
+	logger.InfoContext(ctx, "compressing binary")
+
+	cmd := t.exec.CombinedOutput(ctx, "upx", "--best", "-q", path)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		logger.ErrorContext(ctx, "error compressing binary", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// DownloadPackage downloads the modules required to install a package, with
+// the go.sum data verifying them, into the module cache at the given path. It
+// uses the go install command with the option -n, resolving and downloading
+// the modules without building the package, and leaves the downloaded modules
+// writable (-modcacherw) so the module cache can be removed. It fails if the
+// go install command fails.
+func (t *GoToolchain) DownloadPackage(ctx context.Context, modCache string, pkg model.Package) error {
+	defer internal.TrackPhase(ctx, internal.PhaseDownload)()
+
+	logger := slog.Default().With("package", pkg.String(), "modCache", modCache)
+	logger.InfoContext(ctx, "downloading package modules")
+
+	env := append([]string{"GOMODCACHE=" + modCache, "GOFLAGS=-modcacherw"}, t.getProxyEnv(ctx)...)
+
+	output, err := t.runCombinedOutput(ctx, env, "install", "-n", pkg.String())
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		logger.ErrorContext(ctx, "error downloading package modules", "err", err)
+		return err
+	}
+
+	return nil
+}
+
 // GetBuildInfo returns the build info for a binary. It fails if the binary does
 // not exist or was not built with Go modules.
 func (t *GoToolchain) GetBuildInfo(path string) (*buildinfo.BuildInfo, error) {
@@ -117,22 +411,176 @@ func (t *GoToolchain) GetBuildInfo(path string) (*buildinfo.BuildInfo, error) {
 	return info, nil
 }
 
+// GetBuildID returns the Go build ID of the binary at the given path, kept in
+// stripped binaries. It uses the go tool buildid command. It fails if the
+// binary does not exist or the go tool buildid command fails.
+func (t *GoToolchain) GetBuildID(ctx context.Context, path string) (string, error) {
+	logger := slog.Default().With("path", path)
+	logger.InfoContext(ctx, "getting build id")
+
+	cmd := t.exec.CombinedOutput(ctx, "go", "tool", "buildid", path)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if strings.Contains(outputStr, "no such file or directory") ||
+			strings.Contains(outputStr, "cannot find the file") {
+			return "", ErrBinaryNotFound
+		}
+
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		logger.ErrorContext(ctx, "error getting build id", "err", err)
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetGoEnv returns the environment settings of the Go toolchain. It uses the
+// go env command with the option -json to get the effective GOCACHE,
+// GOMODCACHE and GOPROXY settings. It fails if the go env command fails.
+func (t *GoToolchain) GetGoEnv(ctx context.Context) (model.GoEnv, error) {
+	logger := slog.Default()
+	logger.InfoContext(ctx, "getting go env")
+
+	cmd := t.exec.CombinedOutput(ctx, "go", "env", "-json", "GOCACHE", "GOMODCACHE", "GOPROXY")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		logger.ErrorContext(ctx, "error getting go env", "err", err)
+		return model.GoEnv{}, err
+	}
+
+	var env model.GoEnv
+	if err = json.Unmarshal(output, &env); err != nil {
+		logger.ErrorContext(ctx, "error parsing go env response", "err", err)
+		return model.GoEnv{}, err
+	}
+
+	return env, nil
+}
+
+// GetGoReleases returns the supported Go releases from the Go release feed at
+// the given URL, ex. https://go.dev/dl/?mode=json, bounded by the network
+// timeout, if any. It fails if the request fails, the response status is not
+// OK or the response cannot be parsed.
+func (t *GoToolchain) GetGoReleases(ctx context.Context, url string) (model.GoReleases, error) {
+	logger := slog.Default().With("url", url)
+	logger.InfoContext(ctx, "getting go releases")
+
+	ctx, span := internal.StartSpan(ctx, "GET", attribute.String("url.full", url))
+	defer span.End()
+
+	release, err := t.acquireSlot(ctx)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+	defer release()
+
+	if t.network.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.network.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		logger.ErrorContext(ctx, "error getting go releases", "err", err)
+		return nil, internal.RecordSpanError(span, err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("unexpected status: %s", resp.Status)
+		logger.ErrorContext(ctx, "error getting go releases", "err", err)
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	var releases model.GoReleases
+	if err = json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		logger.ErrorContext(ctx, "error parsing go releases", "err", err)
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	return releases, nil
+}
+
+// GetHTTPProxy returns the HTTP proxy traversed by the go commands to reach the
+// given URL, according to the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+// variables, with any password redacted. It returns an empty string if the URL
+// is reached directly. It fails if the URL or the HTTP proxy is invalid.
+func (t *GoToolchain) GetHTTPProxy(url string) (string, error) {
+	logger := slog.Default().With("url", url)
+	logger.Info("getting http proxy")
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		logger.Error("error parsing url", "err", err)
+		return "", err
+	}
+
+	proxyURL, err := http.ProxyFromEnvironment(req)
+	if err != nil {
+		logger.Error("error getting http proxy", "err", err)
+		return "", err
+	}
+
+	if proxyURL == nil {
+		return "", nil
+	}
+
+	return proxyURL.Redacted(), nil
+}
+
 // GetLatestModuleVersion returns the latest module path and version of a module
 // based on the module path and version received, which can be latest or a
-// specific major or minor version. It uses the go list command with the option
-// -m -json to get a json response with the path to the go.mod file and the
-// version. It fails if the module is not found, the go list command fails or
-// the go.mod file does not contain the module information.
+// specific major or minor version. It requests the version list and the go.mod
+// file of the module to the module proxies in GOPROXY, skipping the retracted
+// versions. Private modules, modules matching GONOPROXY and proxy lists
+// falling back to direct are resolved with the go list command with the option
+// -m -json, to get a json response with the path to the go.mod file and the
+// version. It fails if the module is not found, the request fails or the
+// go.mod file does not contain the module information.
 func (t *GoToolchain) GetLatestModuleVersion(
 	ctx context.Context,
 	module model.Module,
 ) (model.Module, error) {
+	defer internal.TrackPhase(ctx, internal.PhaseResolve)()
+
 	logger := slog.Default().With("module", module.Path)
 	logger.InfoContext(ctx, "getting latest module version")
 
-	cmd := t.exec.CombinedOutput(ctx, "go", "list", "-m", "-json", module.String())
+	version, modFile, err := t.resolveProxyVersion(ctx, module)
+	if !errors.Is(err, errProxyFallback) {
+		if err != nil {
+			return model.Module{}, err
+		}
 
-	output, err := cmd.CombinedOutput()
+		if modFile.Module == nil {
+			err = ErrModuleInfoNotAvailable
+			logger.WarnContext(ctx, err.Error())
+			return model.Module{}, err
+		}
+
+		return model.NewModule(modFile.Module.Mod.Path, version), nil
+	}
+
+	output, err := t.proxyCombinedOutput(ctx, "list", "-m", "-json", module.String())
 	if err != nil {
 		outputStr := strings.TrimSpace(string(output))
 		if outputStr != "" {
@@ -172,7 +620,7 @@ func (t *GoToolchain) GetLatestModuleVersion(
 		return model.Module{}, err
 	}
 
-	modFile, err := modfile.Parse("go.mod", bytes, nil)
+	modFile, err = modfile.Parse("go.mod", bytes, nil)
 	if err != nil {
 		logger.ErrorContext(ctx, "error parsing go mod file", "err", err)
 		return model.Module{}, err
@@ -187,20 +635,27 @@ func (t *GoToolchain) GetLatestModuleVersion(
 	return model.NewModule(modFile.Module.Mod.Path, model.NewVersion(res.Version)), nil
 }
 
-// GetModuleFile returns the go.mod file for a module. It uses the go mod download
-// command with the module path and version to download the go.mod file and retrive
-// the location of it. It then parses the go.mod file and returns it. It fails if
-// the module is not found or the go mod download command fails.
+// GetModuleFile returns the go.mod file for a module. It requests the go.mod
+// file to the module proxies in GOPROXY, resolving the version first if it is
+// not exact, or uses the go mod download command with the module path and
+// version to download the go.mod file and retrive the location of it when the
+// module is resolved with the go command, as GetLatestModuleVersion. It then
+// parses the go.mod file and returns it. It fails if the module is not found,
+// the request fails or the go mod download command fails.
 func (t *GoToolchain) GetModuleFile(
 	ctx context.Context,
 	module model.Module,
 ) (*modfile.File, error) {
+	defer internal.TrackPhase(ctx, internal.PhaseResolve)()
+
 	logger := slog.Default().With("module", module.String())
 	logger.InfoContext(ctx, "getting module file")
 
-	cmd := t.exec.CombinedOutput(ctx, "go", "mod", "download", "-json", module.String())
+	if _, modFile, err := t.resolveProxyVersion(ctx, module); !errors.Is(err, errProxyFallback) {
+		return modFile, err
+	}
 
-	output, err := cmd.CombinedOutput()
+	output, err := t.proxyCombinedOutput(ctx, "mod", "download", "-json", module.String())
 	if err != nil {
 		var res struct {
 			Error string `json:"Error"`
@@ -240,20 +695,34 @@ func (t *GoToolchain) GetModuleFile(
 	return modFile, nil
 }
 
-// GetModuleOrigin returns the origin of a module. It uses the go mod download
-// command with the module path and version to download the go.mod file and retrive
-// the origin of the module. It fails if the module is not found or the go mod
+// GetModuleOrigin returns the origin of a module. It requests the version info
+// of the module to the module proxies in GOPROXY, resolving the version first
+// if it is not exact, or uses the go mod download command with the module path
+// and version to download the go.mod file and retrive the origin of the module
+// when the module is resolved with the go command, as GetLatestModuleVersion.
+// It fails if the module is not found, the request fails or the go mod
 // download command fails.
 func (t *GoToolchain) GetModuleOrigin(
 	ctx context.Context,
 	module model.Module,
 ) (*model.ModuleOrigin, error) {
+	defer internal.TrackPhase(ctx, internal.PhaseResolve)()
+
 	logger := slog.Default().With("module", module.String())
 	logger.InfoContext(ctx, "getting module origin")
 
-	cmd := t.exec.CombinedOutput(ctx, "go", "mod", "download", "-json", module.String())
+	origin, err := t.getProxyModuleOrigin(ctx, module)
+	if !errors.Is(err, errProxyFallback) {
+		if err == nil && (origin == nil || origin.URL == "") {
+			err = ErrModuleOriginNotAvailable
+			logger.WarnContext(ctx, err.Error())
+			return nil, err
+		}
 
-	output, err := cmd.CombinedOutput()
+		return origin, err
+	}
+
+	output, err := t.proxyCombinedOutput(ctx, "mod", "download", "-json", module.String())
 	if err != nil {
 		var res struct {
 			Error string `json:"Error"`
@@ -289,49 +758,201 @@ func (t *GoToolchain) GetModuleOrigin(
 	return res.Origin, nil
 }
 
-// Install installs a package and its dependencies for the specified version in
-// the target path. It uses the go install command to install the package and
-// its dependencies. If the rebuild flag is true, it uses the -a option to force
-// the rebuild of the package and its dependencies. It fails if the go install
-// command fails.
-func (t *GoToolchain) Install(
+// GetModuleVersions returns the known versions of the module providing the
+// given package path, sorted in ascending semantic version order. It uses the
+// go list command with the options -m -versions -json, trying the package path
+// and its parent paths until a module is found. It fails if no module is found
+// or the go list command fails.
+func (t *GoToolchain) GetModuleVersions(
 	ctx context.Context,
 	path string,
+) ([]model.Version, error) {
+	defer internal.TrackPhase(ctx, internal.PhaseResolve)()
+
+	logger := slog.Default().With("path", path)
+	logger.InfoContext(ctx, "getting module versions")
+
+	for modPath := path; modPath != ""; {
+		output, err := t.proxyCombinedOutput(ctx, "list", "-m", "-versions", "-json", modPath)
+		if err != nil {
+			outputStr := strings.TrimSpace(string(output))
+			if outputStr != "" {
+				err = fmt.Errorf("%w: %s", err, outputStr)
+			}
+
+			if !isModuleNotFound(err.Error()) {
+				logger.ErrorContext(ctx, "error getting module versions", "err", err, "module", modPath)
+				return nil, err
+			}
+
+			idx := strings.LastIndex(modPath, "/")
+			if idx < 0 {
+				break
+			}
+
+			modPath = modPath[:idx]
+			continue
+		}
+
+		var res struct {
+			Versions []string `json:"Versions"`
+		}
+
+		if err = json.Unmarshal(output, &res); err != nil {
+			logger.ErrorContext(ctx, "error parsing module versions response", "err", err)
+			return nil, err
+		}
+
+		versions := make([]model.Version, 0, len(res.Versions))
+		for _, v := range res.Versions {
+			versions = append(versions, model.NewVersion(v))
+		}
+
+		slices.SortFunc(versions, model.Version.Compare)
+
+		return versions, nil
+	}
+
+	logger.WarnContext(ctx, "module not found")
+	return nil, ErrModuleNotFound
+}
+
+// GetPackageDownloadSize returns the module providing a package at the specified
+// version and the size of its zip file, without downloading it. It uses the go
+// list command with the options -m -json, trying the package path and its
+// parent paths until a module is found, then requests the size of the module
+// zip file to each module proxy in GOPROXY until one serves it. Dependencies
+// are not included. It returns ErrDownloadSizeNotAvailable if no module proxy
+// serves the module zip file, or fails if no module is found or the go list
+// command fails.
+func (t *GoToolchain) GetPackageDownloadSize(
+	ctx context.Context,
 	pkg model.Package,
-	rebuild bool,
-) error {
-	logger := slog.Default().With("path", path, "package", pkg.String())
-	logger.InfoContext(ctx, "installing package")
+) (model.Module, model.ByteSize, error) {
+	logger := slog.Default().With("package", pkg.String())
+	logger.InfoContext(ctx, "getting package download size")
 
-	args := []string{"install"}
-	if rebuild {
-		args = append(args, "-a")
+	mod, err := t.getPackageModule(ctx, pkg)
+	if err != nil {
+		return model.Module{}, 0, err
 	}
-	args = append(args, pkg.String())
 
-	cmd := t.exec.Run(ctx, "go", args...)
-	cmd.InjectEnv("GOBIN=" + path)
+	escPath, err := module.EscapePath(mod.Path)
+	if err != nil {
+		logger.ErrorContext(ctx, "error escaping module path", "err", err)
+		return model.Module{}, 0, err
+	}
 
-	if err := cmd.Run(); err != nil {
-		logger.ErrorContext(ctx, "error installing binary", "err", err)
-		return err
+	escVersion, err := module.EscapeVersion(mod.Version.String())
+	if err != nil {
+		logger.ErrorContext(ctx, "error escaping module version", "err", err)
+		return model.Module{}, 0, err
 	}
 
-	return nil
+	proxies, err := t.GetProxies(ctx)
+	if err != nil {
+		return model.Module{}, 0, err
+	}
+
+	for _, proxy := range proxies {
+		if !strings.HasPrefix(proxy, "http://") && !strings.HasPrefix(proxy, "https://") {
+			continue
+		}
+
+		size, sizeErr := t.getContentLength(ctx, proxy+"/"+escPath+"/@v/"+escVersion+".zip")
+		if sizeErr != nil {
+			logger.WarnContext(ctx, "error getting module zip size", "proxy", proxy, "err", sizeErr)
+			continue
+		}
+
+		return mod, size, nil
+	}
+
+	logger.WarnContext(ctx, "download size not available", "module", mod.String())
+	return mod, 0, ErrDownloadSizeNotAvailable
 }
 
-// VulnCheck runs the govulncheck command to check for vulnerabilities in the
-// target binary. It returns a list of vulnerabilities found in the binary. It
-// uses the OpenVEX format and filters for affected vulnerabilities. It fails if
-// the govulncheck command fails.
-func (t *GoToolchain) VulnCheck(
+// GetPackageInfo returns the info of a package for the specified version. It
+// uses the go mod download command with the options -json, trying the package
+// path and its parent paths until the module providing the package is found.
+// It then inspects the module sources to check whether the package exists and
+// is a main package, and lists the main packages available in the module. It
+// fails if no module is found or the go mod download command fails.
+func (t *GoToolchain) GetPackageInfo(
 	ctx context.Context,
-	path string,
-) ([]model.Vulnerability, error) {
-	logger := slog.Default().With("path", path)
-	logger.InfoContext(ctx, "running govulncheck")
+	pkg model.Package,
+) (model.PackageInfo, error) {
+	defer internal.TrackPhase(ctx, internal.PhaseDownload)()
+
+	logger := slog.Default().With("package", pkg.String())
+	logger.InfoContext(ctx, "getting package info")
+
+	for modPath := pkg.Path; modPath != ""; {
+		output, err := t.proxyCombinedOutput(ctx, "mod", "download", "-json", modPath+"@"+pkg.Version.String())
+		if err != nil {
+			var res struct {
+				Error string `json:"Error"`
+			}
+
+			if jsonErr := json.Unmarshal(output, &res); jsonErr == nil {
+				err = errors.New(res.Error)
+			}
+
+			if !isModuleNotFound(err.Error()) {
+				logger.ErrorContext(ctx, "error downloading module", "err", err, "module", modPath)
+				return model.PackageInfo{}, err
+			}
+
+			idx := strings.LastIndex(modPath, "/")
+			if idx < 0 {
+				break
+			}
+
+			modPath = modPath[:idx]
+			continue
+		}
 
-	cmd := t.scanExec(ctx, "-mode", "binary", "-format", "openvex", path)
+		var res struct {
+			Path    string `json:"Path"`
+			Version string `json:"Version"`
+			Dir     string `json:"Dir"`
+		}
+
+		if err = json.Unmarshal(output, &res); err != nil {
+			logger.ErrorContext(ctx, "error parsing module download response", "err", err)
+			return model.PackageInfo{}, err
+		}
+
+		pkgDir := filepath.Join(res.Dir, filepath.FromSlash(strings.TrimPrefix(pkg.Path, res.Path)))
+		exists, isMain := inspectPackage(pkgDir)
+
+		mainPkgs, err := getMainPackages(res.Path, res.Dir)
+		if err != nil {
+			logger.ErrorContext(ctx, "error listing module main packages", "err", err)
+			return model.PackageInfo{}, err
+		}
+
+		return model.PackageInfo{
+			Module:       model.NewModule(res.Path, model.NewVersion(res.Version)),
+			Exists:       exists,
+			IsMain:       isMain,
+			MainPackages: mainPkgs,
+		}, nil
+	}
+
+	logger.WarnContext(ctx, "module not found")
+	return model.PackageInfo{}, ErrModuleNotFound
+}
+
+// GetProxies returns the module proxies of the Go toolchain, in the order they
+// are tried. It uses the go env command to get the effective GOPROXY setting,
+// splitting its comma or pipe separated entries and skipping "off". It fails
+// if the go env command fails.
+func (t *GoToolchain) GetProxies(ctx context.Context) ([]string, error) {
+	logger := slog.Default()
+	logger.InfoContext(ctx, "getting module proxies")
+
+	cmd := t.exec.CombinedOutput(ctx, "go", "env", "GOPROXY")
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -340,18 +961,462 @@ func (t *GoToolchain) VulnCheck(
 			err = fmt.Errorf("%w: %s", err, outputStr)
 		}
 
-		logger.ErrorContext(ctx, "error running govulncheck command", "err", err)
+		logger.ErrorContext(ctx, "error getting go env", "err", err)
 		return nil, err
 	}
 
-	var res struct {
-		Statements []struct {
-			Vulnerability struct {
-				ID   string `json:"@id"`
-				Name string `json:"name"`
-			} `json:"vulnerability"`
-			Status string `json:"status"`
-		} `json:"statements"`
+	return model.ProxyList(strings.TrimSpace(string(output))).GetProxies(), nil
+}
+
+// GetSumDBConfig returns the checksum database configuration of the Go
+// toolchain. It uses the go env command with the option -json to get the
+// effective GOSUMDB and GONOSUMDB settings, the latter defaulting to GOPRIVATE.
+// It fails if the go env command fails.
+func (t *GoToolchain) GetSumDBConfig(ctx context.Context) (model.SumDBConfig, error) {
+	logger := slog.Default()
+	logger.InfoContext(ctx, "getting checksum database config")
+
+	cmd := t.exec.CombinedOutput(ctx, "go", "env", "-json", "GOSUMDB", "GONOSUMDB")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		logger.ErrorContext(ctx, "error getting go env", "err", err)
+		return model.SumDBConfig{}, err
+	}
+
+	var config model.SumDBConfig
+	if err = json.Unmarshal(output, &config); err != nil {
+		logger.ErrorContext(ctx, "error parsing go env response", "err", err)
+		return model.SumDBConfig{}, err
+	}
+
+	return config, nil
+}
+
+// Install installs a package and its dependencies for the specified version in
+// the target path. It uses the go install command to install the package and
+// its dependencies. If the rebuild flag is true, it uses the -a option to force
+// the rebuild of the package and its dependencies. The given build flags are
+// passed as go install options and environment variables. The command waits
+// for a free network slot when the network concurrency is limited. It fails if
+// the go install command fails.
+func (t *GoToolchain) Install(
+	ctx context.Context,
+	path string,
+	pkg model.Package,
+	flags model.BuildFlags,
+	rebuild bool,
+) error {
+	defer internal.TrackPhase(ctx, internal.PhaseCompile)()
+
+	logger := slog.Default().With("path", path, "package", pkg.String())
+	logger.InfoContext(ctx, "installing package")
+
+	if err := t.install(ctx, pkg, flags, rebuild, "GOBIN="+path); err != nil {
+		logger.ErrorContext(ctx, "error installing binary", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// InstallLocal uses the go install command to install the main package of the
+// given local directory in the target path, ex. a tool under development. The
+// command runs in the directory (go -C), so the go.work file of an enclosing
+// workspace and the replace directives of the module are honored, unlike when
+// installing a package at a version. The binary is stamped with the version of
+// the local checkout, or (devel), and no module sum. It fails if the go install
+// command fails.
+func (t *GoToolchain) InstallLocal(
+	ctx context.Context,
+	path string,
+	dir string,
+	flags model.BuildFlags,
+) error {
+	defer internal.TrackPhase(ctx, internal.PhaseCompile)()
+
+	logger := slog.Default().With("path", path, "dir", dir)
+	logger.InfoContext(ctx, "installing local package")
+
+	if provenance, ok := ctx.Value(provenanceContextKey{}).(model.Provenance); ok {
+		// the linker splits the -X flags on spaces
+		if !strings.ContainsAny(dir, " \t") {
+			provenance.Spec = dir
+		}
+		flags = flags.WithProvenance(provenance)
+	}
+
+	args := append([]string{"-C", dir, "install"}, flags.Args()...)
+	args = append(args, ".")
+
+	env := append([]string{"GOBIN=" + path}, flags.Env()...)
+
+	cmd := t.exec.Run(ctx, "go", args...)
+	cmd.InjectEnv(append(env, t.getProxyEnv(ctx)...)...)
+
+	if err := cmd.Run(); err != nil {
+		err = fmt.Errorf("%w: %w", ErrBuildFailed, err)
+		logger.ErrorContext(ctx, "error installing local package", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// InstallPlatform uses the go install command to install a package for the
+// given platform, ex. darwin/amd64. As go install refuses to install cross
+// compiled binaries with GOBIN set, the package is installed in the bin
+// directory of a GOPATH at the target path, or in the bin/<goos>_<goarch>
+// subdirectory when cross compiled, keeping the module cache in use. It fails
+// if the module cache cannot be determined or the go install command fails.
+func (t *GoToolchain) InstallPlatform(
+	ctx context.Context,
+	path string,
+	pkg model.Package,
+	platform string,
+	flags model.BuildFlags,
+	rebuild bool,
+) error {
+	defer internal.TrackPhase(ctx, internal.PhaseCompile)()
+
+	logger := slog.Default().With("path", path, "package", pkg.String(), "platform", platform)
+	logger.InfoContext(ctx, "installing package for platform")
+
+	cmd := t.exec.CombinedOutput(ctx, "go", "env", "GOMODCACHE")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		logger.ErrorContext(ctx, "error getting go env", "err", err)
+		return err
+	}
+
+	goos, goarch, _ := strings.Cut(platform, "/")
+	if err = t.install(
+		ctx, pkg, flags, rebuild,
+		"GOBIN=",
+		"GOPATH="+path,
+		"GOMODCACHE="+strings.TrimSpace(string(output)),
+		"GOOS="+goos,
+		"GOARCH="+goarch,
+	); err != nil {
+		logger.ErrorContext(ctx, "error installing binary", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// LookupModuleSum returns the sum of the given module version, ex.
+// "h1:abc=", recorded in the checksum database of the given configuration, ex.
+// sum.golang.org, bounded by the network timeout, if any. The record is
+// verified like the go command does: the signed tree head must be signed with
+// the verifier key of the checksum database and consistent with the tree heads
+// seen before, and the record must be included in the tree. It returns
+// ErrModuleSumNotFound if the module version is not recorded in the checksum
+// database, ErrSumDBKeyNotFound if the verifier key of the checksum database is
+// unknown, and fails if a request fails or the record cannot be verified.
+func (t *GoToolchain) LookupModuleSum(ctx context.Context, sumDB model.SumDBConfig, mod model.Module) (string, error) {
+	url, _ := sumDB.GetURL()
+
+	logger := slog.Default().With("module", mod.String(), "url", url)
+	logger.InfoContext(ctx, "looking up module sum")
+
+	key, ok := sumDB.GetKey()
+	if !ok {
+		logger.ErrorContext(ctx, "error looking up module sum", "err", ErrSumDBKeyNotFound)
+		return "", fmt.Errorf("%w: GOSUMDB=%s", ErrSumDBKeyNotFound, sumDB.SumDB)
+	}
+
+	ops := newSumDBOps(ctx, t, key, url)
+	lines, err := sumdb.NewClient(ops).Lookup(mod.Path, mod.Version.String())
+	if ops.notFound.Load() {
+		return "", ErrModuleSumNotFound
+	} else if err != nil {
+		logger.ErrorContext(ctx, "error looking up module sum", "err", err)
+		return "", err
+	}
+
+	for _, line := range lines {
+		//nolint:mnd // expected go.sum line format: path version hash
+		if fields := strings.Fields(line); len(fields) == 3 && fields[1] == mod.Version.String() {
+			return fields[2], nil
+		}
+	}
+
+	return "", ErrModuleSumNotFound
+}
+
+// ProbeBinary probes the binary at the given path by running it with the -h
+// option. The exit code is ignored, as binaries may reject the option, and the
+// binary is stopped after a short timeout, ex. if waiting for input. It fails
+// if the binary cannot be started or is killed by a signal, ex. when its
+// compressed code is rejected by the operating system.
+func (t *GoToolchain) ProbeBinary(ctx context.Context, path string) error {
+	logger := slog.Default().With("path", path)
+	logger.InfoContext(ctx, "probing binary")
+
+	probeCtx, cancel := context.WithTimeout(ctx, probeBinaryTimeout)
+	defer cancel()
+
+	_, err := t.exec.CombinedOutput(probeCtx, path, "-h").CombinedOutput()
+	if err == nil || probeCtx.Err() != nil {
+		return nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() != -1 {
+		return nil
+	}
+
+	logger.WarnContext(ctx, "error probing binary", "err", err)
+	return err
+}
+
+// ProbeProxy probes a module proxy by requesting the versions of a well known
+// module. An HTTP module proxy is requested with the credentials of the .netrc
+// file, if any, and probed with the go command, with GOPROXY set to the given
+// proxy, if it requires other credentials. The request is limited by the
+// network timeout and not retried. It wraps ErrProxyAuthentication in the error
+// if the module proxy responds with an unauthorized or forbidden status.
+func (t *GoToolchain) ProbeProxy(ctx context.Context, proxy string) error {
+	logger := slog.Default().With("proxy", proxy)
+	logger.InfoContext(ctx, "probing module proxy")
+
+	if !strings.HasPrefix(proxy, "http://") && !strings.HasPrefix(proxy, "https://") {
+		return t.probeProxyWithGo(ctx, proxy)
+	}
+
+	if _, _, _, err := t.getProxyConfig(ctx); err != nil {
+		return err
+	}
+
+	_, err := t.get(ctx, strings.TrimSuffix(proxy, "/")+"/"+probeModule+"/@v/list")
+	if errors.Is(err, errProxyFallback) {
+		return t.probeProxyWithGo(ctx, proxy)
+	}
+
+	if err != nil {
+		logger.WarnContext(ctx, "error probing module proxy", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// probeProxyWithGo probes a module proxy by requesting the latest version of a
+// well known module with the go list command, with GOPROXY set to the given
+// proxy. It fails if the go list command fails, wrapping ErrProxyAuthentication
+// in the error if the go command reports an unauthorized or forbidden status.
+func (t *GoToolchain) probeProxyWithGo(ctx context.Context, proxy string) error {
+	logger := slog.Default().With("proxy", proxy)
+
+	output, err := t.runCombinedOutput(
+		ctx, []string{"GOPROXY=" + proxy}, "list", "-m", "-json", probeModule+"@latest",
+	)
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		if strings.Contains(outputStr, "401 Unauthorized") || strings.Contains(outputStr, "403 Forbidden") {
+			err = fmt.Errorf("%w: %w", ErrProxyAuthentication, err)
+		}
+
+		logger.WarnContext(ctx, "error probing module proxy", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// SearchPackages searches the package index at the given URL for packages
+// matching the given query, returning up to the given number of results with
+// their import path, synopsis and whether they are commands. The results are
+// parsed from the search page of pkg.go.dev, which does not provide a search
+// API.
+func (t *GoToolchain) SearchPackages(
+	ctx context.Context,
+	url string,
+	query string,
+	limit int,
+) ([]model.PackageSearchResult, error) {
+	logger := slog.Default().With("url", url, "query", query)
+	logger.InfoContext(ctx, "searching packages")
+
+	ctx, span := internal.StartSpan(ctx, "GET", attribute.String("url.full", url))
+	defer span.End()
+
+	release, err := t.acquireSlot(ctx)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+	defer release()
+
+	if t.network.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.network.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	params := req.URL.Query()
+	params.Set("q", query)
+	params.Set("m", "package")
+	params.Set("limit", strconv.Itoa(limit))
+	req.URL.RawQuery = params.Encode()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		logger.ErrorContext(ctx, "error searching packages", "err", err)
+		return nil, internal.RecordSpanError(span, err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("unexpected status: %s", resp.Status)
+		logger.ErrorContext(ctx, "error searching packages", "err", err)
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.ErrorContext(ctx, "error reading package search results", "err", err)
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	results := parsePackageSearchResults(string(body))
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// Sign signs the binary at the given path with the given identity using the
+// signing tool of the given operating system: codesign on macOS, signtool on
+// Windows and a GPG detached signature, written to the given signature path, on
+// Linux. The identity is the codesign identity, the signtool certificate
+// subject name or the GPG key, respectively.
+func (t *GoToolchain) Sign(ctx context.Context, goos, identity, path, signaturePath string) error {
+	logger := slog.Default().With("path", path, "identity", identity)
+	logger.InfoContext(ctx, "signing binary")
+
+	var cmd system.ExecCombinedOutput
+	switch goos {
+	case "darwin":
+		cmd = t.exec.CombinedOutput(ctx, "codesign", "--force", "--sign", identity, path)
+	case "windows":
+		cmd = t.exec.CombinedOutput(ctx, "signtool", "sign", "/fd", "SHA256", "/n", identity, path)
+	default:
+		cmd = t.exec.CombinedOutput(
+			ctx, "gpg", "--batch", "--yes", "--local-user", identity,
+			"--detach-sign", "--output", signaturePath, path,
+		)
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		logger.ErrorContext(ctx, "error signing binary", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// VerifySignature verifies the signature of the binary at the given path using
+// the signing tool of the given operating system: codesign on macOS, signtool
+// on Windows and GPG, against the detached signature at the given signature
+// path, on Linux. It fails if the signature is missing or no longer valid.
+func (t *GoToolchain) VerifySignature(ctx context.Context, goos, path, signaturePath string) error {
+	logger := slog.Default().With("path", path)
+	logger.InfoContext(ctx, "verifying binary signature")
+
+	var cmd system.ExecCombinedOutput
+	switch goos {
+	case "darwin":
+		cmd = t.exec.CombinedOutput(ctx, "codesign", "--verify", "--strict", path)
+	case "windows":
+		cmd = t.exec.CombinedOutput(ctx, "signtool", "verify", "/pa", path)
+	default:
+		cmd = t.exec.CombinedOutput(ctx, "gpg", "--batch", "--verify", signaturePath, path)
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		logger.ErrorContext(ctx, "error verifying binary signature", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// VulnCheck runs the govulncheck command to check for vulnerabilities in the
+// target binary. It returns a list of vulnerabilities found in the binary. It
+// uses the OpenVEX format and filters for affected vulnerabilities. It fails if
+// the govulncheck command fails.
+func (t *GoToolchain) VulnCheck(
+	ctx context.Context,
+	path string,
+) ([]model.Vulnerability, error) {
+	defer internal.TrackPhase(ctx, internal.PhaseVulnCheck)()
+
+	logger := slog.Default().With("path", path)
+	logger.InfoContext(ctx, "running govulncheck")
+
+	release, err := t.acquireSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := t.scanExec(ctx, "-mode", "binary", "-format", "openvex", path)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		outputStr := strings.TrimSpace(string(output))
+		if outputStr != "" {
+			err = fmt.Errorf("%w: %s", err, outputStr)
+		}
+
+		logger.ErrorContext(ctx, "error running govulncheck command", "err", err)
+		return nil, err
+	}
+
+	var res struct {
+		Statements []struct {
+			Vulnerability struct {
+				ID   string `json:"@id"`
+				Name string `json:"name"`
+			} `json:"vulnerability"`
+			Status string `json:"status"`
+		} `json:"statements"`
 	}
 
 	if err = json.Unmarshal(output, &res); err != nil {
@@ -372,11 +1437,862 @@ func (t *GoToolchain) VulnCheck(
 	return vulns, nil
 }
 
+// proxyCombinedOutput runs a go command requesting the module proxy and returns
+// its combined output. A failed attempt is retried up to the network retries,
+// waiting the network backoff doubled on each retry, unless the module is not
+// found or the context is done. Module proxies failing to respond are skipped
+// for the rest of the run, and ErrNetworkUnavailable is wrapped in the error if
+// they still fail after the last retry.
+func (t *GoToolchain) proxyCombinedOutput(ctx context.Context, args ...string) ([]byte, error) {
+	backoff := t.network.Backoff
+
+	for attempt := 1; ; attempt++ {
+		output, err := t.runCombinedOutput(ctx, t.getProxyEnv(ctx), args...)
+		if err == nil || isModuleNotFound(string(output)) {
+			return output, err
+		}
+
+		t.skipFailedProxy(ctx, string(output))
+
+		if attempt > t.network.Retries {
+			if isProxyUnavailable(string(output)) {
+				err = fmt.Errorf("%w: %w", ErrNetworkUnavailable, err)
+			}
+
+			return output, err
+		}
+
+		slog.Default().WarnContext(ctx, "retrying module proxy request", "args", args, "attempt", attempt, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return output, err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// install runs the go install command for a package with the given build
+// flags and environment variables, forcing the rebuild of all packages if
+// rebuild is set. It wraps ErrBuildFailed in the error if the command fails.
+func (t *GoToolchain) install(
+	ctx context.Context,
+	pkg model.Package,
+	flags model.BuildFlags,
+	rebuild bool,
+	env ...string,
+) error {
+	if provenance, ok := ctx.Value(provenanceContextKey{}).(model.Provenance); ok {
+		provenance.Spec = pkg.String()
+		flags = flags.WithProvenance(provenance)
+	}
+
+	args := []string{"install"}
+	if rebuild {
+		args = append(args, "-a")
+	}
+	args = append(args, flags.Args()...)
+	args = append(args, pkg.String())
+
+	ctx, span := internal.StartSpan(ctx, "go install", attribute.String("gobin.package", pkg.String()))
+	defer span.End()
+
+	release, err := t.acquireSlot(ctx)
+	if err != nil {
+		return internal.RecordSpanError(span, err)
+	}
+	defer release()
+
+	cmd := t.exec.Run(ctx, "go", args...)
+	env = append(env, flags.Env()...)
+	if version, _ := ctx.Value(goVersionContextKey{}).(string); version != "" {
+		env = append(env, "GOTOOLCHAIN="+version)
+	}
+	cmd.InjectEnv(append(env, t.getProxyEnv(ctx)...)...)
+
+	if err = cmd.Run(); err != nil {
+		return internal.RecordSpanError(span, fmt.Errorf("%w: %w", ErrBuildFailed, err))
+	}
+
+	return nil
+}
+
+// getProxyEnv returns the environment variables overriding GOPROXY without the
+// module proxies that failed to respond, or with direct if the context is
+// marked with WithDirect. It returns no environment variables if no module
+// proxy failed, or all of them did.
+func (t *GoToolchain) getProxyEnv(ctx context.Context) []string {
+	if direct, _ := ctx.Value(directContextKey{}).(bool); direct {
+		return []string{"GOPROXY=direct"}
+	}
+
+	t.proxyMutex.Lock()
+	defer t.proxyMutex.Unlock()
+
+	if len(t.failedProxies) == 0 {
+		return nil
+	}
+
+	list, ok := t.proxyList.Without(t.failedProxies...)
+	if !ok {
+		return nil
+	}
+
+	return []string{"GOPROXY=" + string(list)}
+}
+
+// skipFailedProxy records the module proxy that failed to respond according to
+// the output of a go command, to be skipped by the following go commands, and
+// raises a warning for the fallback. The GOPROXY setting is resolved with the
+// go env command on the first failure.
+func (t *GoToolchain) skipFailedProxy(ctx context.Context, output string) {
+	if !isProxyUnavailable(output) {
+		return
+	}
+
+	url := proxyURLRegexp.FindString(output)
+	if url == "" {
+		return
+	}
+
+	t.proxyMutex.Lock()
+	defer t.proxyMutex.Unlock()
+
+	if t.proxyList == "" {
+		cmd := t.exec.CombinedOutput(ctx, "go", "env", "GOPROXY")
+
+		envOutput, err := cmd.CombinedOutput()
+		if err != nil {
+			slog.Default().WarnContext(ctx, "error getting go env", "err", err)
+			return
+		}
+
+		t.proxyList = model.ProxyList(strings.TrimSpace(string(envOutput)))
+	}
+
+	if proxy, ok := t.proxyList.GetProxyForURL(url); ok {
+		t.addFailedProxy(ctx, proxy)
+	}
+}
+
+// addFailedProxy records a module proxy that failed to respond, to be skipped
+// for the rest of the run, and raises a warning for the fallback. The proxy
+// mutex must be held.
+func (t *GoToolchain) addFailedProxy(ctx context.Context, proxy string) {
+	if slices.Contains(t.failedProxies, proxy) {
+		return
+	}
+
+	slog.Default().WarnContext(ctx, "module proxy failed, skipping it for the rest of the run", "proxy", proxy)
+	internal.AddWarning(ctx, fmt.Sprintf("module proxy %s failed to respond, fell back to the next proxy", proxy))
+	t.failedProxies = append(t.failedProxies, proxy)
+}
+
+// runCombinedOutput runs a go command with the given environment variables and
+// returns its combined output. The command waits for a free network slot and is
+// limited by the network timeout, if any.
+func (t *GoToolchain) runCombinedOutput(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	ctx, span := internal.StartSpan(ctx, "go "+args[0], attribute.StringSlice("gobin.args", args))
+	defer span.End()
+
+	release, err := t.acquireSlot(ctx)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+	defer release()
+
+	if t.network.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.network.Timeout)
+		defer cancel()
+	}
+
+	cmd := t.exec.CombinedOutput(ctx, "go", args...)
+	if len(env) > 0 {
+		cmd.InjectEnv(env...)
+	}
+
+	output, err := cmd.CombinedOutput()
+	return output, internal.RecordSpanError(span, err)
+}
+
+// acquireSlot waits for a free network slot when the network concurrency is
+// limited. It returns a function releasing the slot, or an error if the context
+// is done while waiting.
+func (t *GoToolchain) acquireSlot(ctx context.Context) (func(), error) {
+	if t.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+		return func() { <-t.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// getContentLength requests the headers of the given URL and returns the size of
+// its content. The request waits for a free network slot and is limited by the
+// network timeout, if any. It fails if the request fails, the response status
+// is not OK or the size is unknown.
+func (t *GoToolchain) getContentLength(ctx context.Context, url string) (model.ByteSize, error) {
+	ctx, span := internal.StartSpan(ctx, "HEAD", attribute.String("url.full", url))
+	defer span.End()
+
+	release, err := t.acquireSlot(ctx)
+	if err != nil {
+		return 0, internal.RecordSpanError(span, err)
+	}
+	defer release()
+
+	if t.network.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.network.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, internal.RecordSpanError(span, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, internal.RecordSpanError(span, err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, internal.RecordSpanError(span, fmt.Errorf("unexpected status: %s", resp.Status))
+	}
+
+	if resp.ContentLength < 0 {
+		return 0, internal.RecordSpanError(span, errors.New("unknown content length"))
+	}
+
+	return model.ByteSize(resp.ContentLength), nil
+}
+
+// resolveProxyVersion resolves the version of a module requesting the module
+// proxies, and returns it with the go.mod file of the module at that version.
+// An exact version is only checked to exist, while the latest version or a
+// major or minor version is selected from the version list of the module,
+// preferring releases to pre-releases and skipping the versions retracted in
+// the go.mod file of the selected one. The latest version query of the module
+// proxies is used when the module has no tagged version. It returns
+// errProxyFallback if the version is a branch or tag ref, or the module is
+// resolved with the go command.
+func (t *GoToolchain) resolveProxyVersion(
+	ctx context.Context,
+	mod model.Module,
+) (model.Version, *modfile.File, error) {
+	version := mod.Version
+	if version.IsExact() {
+		modFile, err := t.getProxyModFile(ctx, mod.Path, version)
+		return version, modFile, err
+	}
+
+	if !version.IsLatest() && version.Major() == "" {
+		return "", nil, errProxyFallback
+	}
+
+	body, err := t.getProxyFile(ctx, mod.Path, "@v/list")
+	if err != nil {
+		return "", nil, err
+	}
+
+	var versions []model.Version
+	for line := range strings.Lines(string(body)) {
+		if v := model.NewVersion(line); v.IsExact() {
+			versions = append(versions, v)
+		}
+	}
+
+	selected, ok := selectVersion(versions, version, nil)
+	if !ok {
+		if len(versions) > 0 || !version.IsLatest() {
+			return "", nil, ErrModuleNotFound
+		}
+
+		if selected, err = t.getProxyVersion(ctx, mod.Path, "@latest"); err != nil {
+			return "", nil, err
+		}
+	}
+
+	modFile, err := t.getProxyModFile(ctx, mod.Path, selected)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if isRetracted(selected, modFile.Retract) {
+		if unretracted, found := selectVersion(versions, version, modFile.Retract); found {
+			selected = unretracted
+			if modFile, err = t.getProxyModFile(ctx, mod.Path, selected); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	return selected, modFile, nil
+}
+
+// getProxyModuleOrigin returns the origin of a module recorded in its version
+// info, requesting the module proxies. It returns errProxyFallback if the
+// module is resolved with the go command.
+func (t *GoToolchain) getProxyModuleOrigin(ctx context.Context, mod model.Module) (*model.ModuleOrigin, error) {
+	version := mod.Version
+	if !version.IsExact() {
+		var err error
+		if version, _, err = t.resolveProxyVersion(ctx, mod); err != nil {
+			return nil, err
+		}
+	}
+
+	info, err := t.getProxyInfo(ctx, mod.Path, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return info.Origin, nil
+}
+
+// proxyInfo is the version info of a module served by a module proxy.
+type proxyInfo struct {
+	Version string              `json:"Version"`
+	Origin  *model.ModuleOrigin `json:"Origin"`
+}
+
+// getProxyInfo returns the version info of a module at the given version,
+// requesting the module proxies.
+func (t *GoToolchain) getProxyInfo(ctx context.Context, modPath string, version model.Version) (proxyInfo, error) {
+	escVersion, err := module.EscapeVersion(version.String())
+	if err != nil {
+		return proxyInfo{}, err
+	}
+
+	return t.getProxyInfoFile(ctx, modPath, "@v/"+escVersion+".info")
+}
+
+// getProxyVersion returns the version of the version info served for the given
+// file of a module, ex. "@latest", requesting the module proxies.
+func (t *GoToolchain) getProxyVersion(ctx context.Context, modPath, file string) (model.Version, error) {
+	info, err := t.getProxyInfoFile(ctx, modPath, file)
+	if err != nil {
+		return "", err
+	}
+
+	version := model.NewVersion(info.Version)
+	if !version.IsExact() {
+		return "", fmt.Errorf("invalid version %q in module proxy response", info.Version)
+	}
+
+	return version, nil
+}
+
+// getProxyInfoFile parses the version info served for the given file of a
+// module, requesting the module proxies.
+func (t *GoToolchain) getProxyInfoFile(ctx context.Context, modPath, file string) (proxyInfo, error) {
+	body, err := t.getProxyFile(ctx, modPath, file)
+	if err != nil {
+		return proxyInfo{}, err
+	}
+
+	var info proxyInfo
+	if err = json.Unmarshal(body, &info); err != nil {
+		slog.Default().ErrorContext(ctx, "error parsing module info", "module", modPath, "err", err)
+		return proxyInfo{}, err
+	}
+
+	return info, nil
+}
+
+// getProxyModFile returns the parsed go.mod file of a module at the given
+// version, requesting the module proxies.
+func (t *GoToolchain) getProxyModFile(
+	ctx context.Context,
+	modPath string,
+	version model.Version,
+) (*modfile.File, error) {
+	escVersion, err := module.EscapeVersion(version.String())
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := t.getProxyFile(ctx, modPath, "@v/"+escVersion+".mod")
+	if err != nil {
+		return nil, err
+	}
+
+	modFile, err := modfile.Parse("go.mod", body, nil)
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "error parsing go mod file", "module", modPath, "err", err)
+		return nil, err
+	}
+
+	return modFile, nil
+}
+
+// getProxyFile requests a file of a module, ex. "@v/list", to the module
+// proxies in GOPROXY, skipping the ones that failed to respond. As the go
+// command, the next module proxy is tried when the module is not found, or on
+// any error after a pipe, and a module proxy failing to respond is skipped for
+// the rest of the run. It returns errProxyFallback if the module is resolved
+// with the go command: when the context is marked with WithDirect, the module
+// matches GONOPROXY, the next module proxy is not an HTTP proxy, ex. direct, or
+// a module proxy requires authentication not satisfied by the .netrc file.
+// It returns ErrModuleNotFound if no module proxy serves the module.
+func (t *GoToolchain) getProxyFile(ctx context.Context, modPath, file string) ([]byte, error) {
+	if direct, _ := ctx.Value(directContextKey{}).(bool); direct {
+		return nil, errProxyFallback
+	}
+
+	list, noProxy, failedProxies, err := t.getProxyConfig(ctx)
+	if err != nil || module.MatchPrefixPatterns(noProxy, modPath) {
+		return nil, errProxyFallback
+	}
+
+	escPath, err := module.EscapePath(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var notFound bool
+	for _, proxy := range list.GetProxies() {
+		if slices.Contains(failedProxies, proxy) {
+			continue
+		}
+
+		if !strings.HasPrefix(proxy, "http://") && !strings.HasPrefix(proxy, "https://") {
+			return nil, errProxyFallback
+		}
+
+		body, getErr := t.getWithRetries(ctx, strings.TrimSuffix(proxy, "/")+"/"+escPath+"/"+file)
+		switch {
+		case getErr == nil:
+			return body, nil
+		case errors.Is(getErr, ErrModuleNotFound):
+			notFound = true
+			continue
+		case errors.Is(getErr, errProxyFallback), errors.Is(getErr, ErrProxyAuthentication):
+			return nil, errProxyFallback
+		case errors.Is(getErr, ErrNetworkUnavailable):
+			t.proxyMutex.Lock()
+			t.addFailedProxy(ctx, proxy)
+			t.proxyMutex.Unlock()
+		}
+
+		if !list.FallsBackOnError(proxy) {
+			slog.Default().ErrorContext(ctx, "error requesting module proxy", "proxy", proxy, "err", getErr)
+			return nil, getErr
+		}
+	}
+
+	if !notFound {
+		return nil, errProxyFallback
+	}
+
+	return nil, ErrModuleNotFound
+}
+
+// getProxyConfig returns the GOPROXY list of module proxies, the GONOPROXY
+// patterns of the modules not requested to them and the module proxies that
+// failed to respond. The settings are resolved with the go env command on the
+// first call, GONOPROXY defaulting to GOPRIVATE, and the .netrc file is read
+// unless GOAUTH disables it.
+func (t *GoToolchain) getProxyConfig(ctx context.Context) (model.ProxyList, string, []string, error) {
+	t.proxyMutex.Lock()
+	defer t.proxyMutex.Unlock()
+
+	if !t.proxyLoaded {
+		output, err := t.exec.CombinedOutput(
+			ctx, "go", "env", "-json", "GOPROXY", "GONOPROXY", "GOAUTH",
+		).CombinedOutput()
+		if err != nil {
+			slog.Default().WarnContext(ctx, "error getting go env", "err", err)
+			return "", "", nil, err
+		}
+
+		var env struct {
+			GOPROXY   string `json:"GOPROXY"`
+			GONOPROXY string `json:"GONOPROXY"`
+			GOAUTH    string `json:"GOAUTH"`
+		}
+
+		if err = json.Unmarshal(output, &env); err != nil {
+			slog.Default().WarnContext(ctx, "error parsing go env", "err", err)
+			return "", "", nil, err
+		}
+
+		t.proxyList = model.ProxyList(env.GOPROXY)
+		t.noProxy = env.GONOPROXY
+		t.netrc = readNetrc(ctx, env.GOAUTH)
+		t.proxyLoaded = true
+	}
+
+	return t.proxyList, t.noProxy, slices.Clone(t.failedProxies), nil
+}
+
+// getCredentials returns the .netrc credentials of the given host, and whether
+// the host has credentials.
+func (t *GoToolchain) getCredentials(host string) (model.NetrcCredentials, bool) {
+	t.proxyMutex.Lock()
+	defer t.proxyMutex.Unlock()
+
+	return t.netrc.Get(host)
+}
+
+// getWithRetries requests the given URL and returns its content. A request
+// failing to respond is retried up to the network retries, waiting the network
+// backoff doubled on each retry, unless the context is done.
+func (t *GoToolchain) getWithRetries(ctx context.Context, url string) ([]byte, error) {
+	backoff := t.network.Backoff
+
+	for attempt := 1; ; attempt++ {
+		body, err := t.get(ctx, url)
+		if err == nil || !errors.Is(err, ErrNetworkUnavailable) || attempt > t.network.Retries {
+			return body, err
+		}
+
+		slog.Default().WarnContext(ctx, "retrying module proxy request", "url", url, "attempt", attempt, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// get requests the given URL and returns its content, with the .netrc
+// credentials of its host, if any. The request waits for a free network slot
+// and is limited by the network timeout, if any. It returns ErrModuleNotFound if
+// the response status is not found or gone, errProxyFallback if the module
+// proxy requires authentication, left to the go command and the other GOAUTH
+// methods, wraps ErrProxyAuthentication in the error if the module proxy
+// rejects the credentials, and wraps ErrNetworkUnavailable in the error if
+// the request fails to respond or the response status is a server error.
+func (t *GoToolchain) get(ctx context.Context, url string) ([]byte, error) {
+	ctx, span := internal.StartSpan(ctx, "GET", attribute.String("url.full", url))
+	defer span.End()
+
+	release, err := t.acquireSlot(ctx)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+	defer release()
+
+	reqCtx := ctx
+	if t.network.Timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, t.network.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, err)
+	}
+
+	creds, hasCreds := t.getCredentials(req.URL.Hostname())
+	if hasCreds {
+		req.SetBasicAuth(creds.Login, creds.Password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() == nil {
+			err = fmt.Errorf("%w: %w", ErrNetworkUnavailable, err)
+		}
+
+		return nil, internal.RecordSpanError(span, err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, internal.RecordSpanError(span, ErrModuleNotFound)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		if hasCreds {
+			err = fmt.Errorf("%w: %s", ErrProxyAuthentication, resp.Status)
+			return nil, internal.RecordSpanError(span, err)
+		}
+
+		return nil, internal.RecordSpanError(span, errProxyFallback)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		err = fmt.Errorf("%w: unexpected status: %s", ErrNetworkUnavailable, resp.Status)
+		return nil, internal.RecordSpanError(span, err)
+	default:
+		return nil, internal.RecordSpanError(span, fmt.Errorf("unexpected status: %s", resp.Status))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, internal.RecordSpanError(span, fmt.Errorf("%w: %w", ErrNetworkUnavailable, err))
+	}
+
+	return body, nil
+}
+
+// getPackageModule returns the module providing a package at the specified
+// version. It uses the go list command with the options -m -json, trying the
+// package path and its parent paths until a module is found. It fails if no
+// module is found or the go list command fails.
+func (t *GoToolchain) getPackageModule(ctx context.Context, pkg model.Package) (model.Module, error) {
+	logger := slog.Default().With("package", pkg.String())
+
+	for modPath := pkg.Path; modPath != ""; {
+		output, err := t.proxyCombinedOutput(ctx, "list", "-m", "-json", modPath+"@"+pkg.Version.String())
+		if err != nil {
+			outputStr := strings.TrimSpace(string(output))
+			if outputStr != "" {
+				err = fmt.Errorf("%w: %s", err, outputStr)
+			}
+
+			if !isModuleNotFound(err.Error()) {
+				logger.ErrorContext(ctx, "error getting package module", "err", err, "module", modPath)
+				return model.Module{}, err
+			}
+
+			idx := strings.LastIndex(modPath, "/")
+			if idx < 0 {
+				break
+			}
+
+			modPath = modPath[:idx]
+			continue
+		}
+
+		var res struct {
+			Path    string `json:"Path"`
+			Version string `json:"Version"`
+		}
+
+		if err = json.Unmarshal(output, &res); err != nil {
+			logger.ErrorContext(ctx, "error parsing package module response", "err", err)
+			return model.Module{}, err
+		}
+
+		return model.NewModule(res.Path, model.NewVersion(res.Version)), nil
+	}
+
+	logger.WarnContext(ctx, "module not found")
+	return model.Module{}, ErrModuleNotFound
+}
+
+// getMainPackages returns the import paths of the main packages in the module
+// with the given path and source directory. It skips testdata, vendor, hidden
+// and nested module directories.
+func getMainPackages(modPath, modDir string) ([]string, error) {
+	var mainPkgs []string
+	err := filepath.WalkDir(modDir, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		if dir != modDir {
+			name := entry.Name()
+			if name == "testdata" || name == "vendor" ||
+				strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+
+			if _, statErr := os.Stat(filepath.Join(dir, "go.mod")); statErr == nil {
+				return filepath.SkipDir
+			}
+		}
+
+		if _, isMain := inspectPackage(dir); isMain {
+			rel, relErr := filepath.Rel(modDir, dir)
+			if relErr != nil {
+				return relErr
+			}
+
+			mainPkgs = append(mainPkgs, path.Join(modPath, filepath.ToSlash(rel)))
+		}
+
+		return nil
+	})
+
+	return mainPkgs, err
+}
+
+// inspectPackage checks whether the given directory contains a Go package and
+// whether it is a main package, based on the package clause of its non-test Go
+// files.
+func inspectPackage(dir string) (bool, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, false
+	}
+
+	var exists bool
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, parseErr := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+		if parseErr != nil {
+			continue
+		}
+
+		exists = true
+		if file.Name.Name == "main" {
+			return true, true
+		}
+	}
+
+	return exists, false
+}
+
+// parsePackageSearchResults parses the package search results of a pkg.go.dev
+// search page, each in a search snippet linking to the package import path.
+// Snippets without an import path are skipped.
+func parsePackageSearchResults(page string) []model.PackageSearchResult {
+	snippets := strings.Split(page, `class="SearchSnippet"`)
+
+	results := make([]model.PackageSearchResult, 0, len(snippets))
+	for _, snippet := range snippets[1:] {
+		title := searchTitleRegexp.FindStringSubmatch(snippet)
+		if title == nil {
+			continue
+		}
+
+		result := model.PackageSearchResult{
+			Path:      html.UnescapeString(title[1]),
+			IsCommand: searchCommandRegexp.MatchString(snippet),
+		}
+
+		if synopsis := searchSynopsisRegexp.FindStringSubmatch(snippet); synopsis != nil {
+			text := html.UnescapeString(htmlTagRegexp.ReplaceAllString(synopsis[1], ""))
+			result.Synopsis = strings.Join(strings.Fields(text), " ")
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// readNetrc reads the credentials of the .netrc file, from NETRC or the home
+// directory, ex. ~/.netrc or ~/_netrc, unless GOAUTH is off or does not list
+// the netrc method. An empty GOAUTH defaults to netrc, as the go command does.
+func readNetrc(ctx context.Context, goAuth string) model.Netrc {
+	methods := []string{"netrc"}
+	if goAuth != "" {
+		methods = nil
+		for method := range strings.SplitSeq(goAuth, ";") {
+			methods = append(methods, strings.TrimSpace(method))
+		}
+	}
+
+	if !slices.Contains(methods, "netrc") {
+		return model.Netrc{}
+	}
+
+	paths := []string{os.Getenv("NETRC")}
+	if paths[0] == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return model.Netrc{}
+		}
+
+		paths = []string{filepath.Join(home, ".netrc"), filepath.Join(home, "_netrc")}
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return model.ParseNetrc(string(data))
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Default().WarnContext(ctx, "error reading netrc file", "path", path, "err", err)
+		}
+	}
+
+	return model.Netrc{}
+}
+
+// selectVersion selects the version of a module matching the given query, the
+// latest version or a major or minor version, among the given versions,
+// skipping the retracted ones. The highest release is preferred to the highest
+// pre-release. It returns false if no version matches.
+func selectVersion(versions []model.Version, query model.Version, retract []*modfile.Retract) (model.Version, bool) {
+	var release, prerelease model.Version
+	for _, v := range versions {
+		if (!query.IsLatest() && !v.IsPartOf(query)) || isRetracted(v, retract) {
+			continue
+		}
+
+		selected := &release
+		if v.IsPrerelease() {
+			selected = &prerelease
+		}
+
+		if *selected == "" || v.Compare(*selected) > 0 {
+			*selected = v
+		}
+	}
+
+	if release != "" {
+		return release, true
+	}
+
+	return prerelease, prerelease != ""
+}
+
+// isRetracted checks if the version is in one of the given retracted version
+// intervals.
+func isRetracted(version model.Version, retract []*modfile.Retract) bool {
+	for _, r := range retract {
+		if version.Compare(model.NewVersion(r.Low)) >= 0 && version.Compare(model.NewVersion(r.High)) <= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isProxyUnavailable checks if the output contains a message indicating that a
+// module proxy failed to respond to a go command, due to a network error or a
+// server error.
+func isProxyUnavailable(output string) bool {
+	output = strings.ToLower(output)
+	return strings.Contains(output, "dial tcp") ||
+		strings.Contains(output, "i/o timeout") ||
+		strings.Contains(output, "connection refused") ||
+		strings.Contains(output, "connection reset") ||
+		strings.Contains(output, "no such host") ||
+		strings.Contains(output, "tls handshake timeout") ||
+		strings.Contains(output, "context deadline exceeded") ||
+		strings.Contains(output, "502 bad gateway") ||
+		strings.Contains(output, "503 service unavailable") ||
+		strings.Contains(output, "504 gateway timeout")
+}
+
 // isModuleNotFound checks if the output contains a message indicating that a
-// module was not found by a go command.
+// module was not found by a go command. File proxies report missing modules
+// as missing files.
 func isModuleNotFound(output string) bool {
 	output = strings.ToLower(output)
 	return strings.Contains(output, "no matching versions for query") ||
 		strings.Contains(output, "not found") ||
+		strings.Contains(output, "no such file or directory") ||
 		strings.Contains(output, "unknown revision")
 }
@@ -2,26 +2,215 @@ package toolchain_test
 
 import (
 	"context"
+	"crypto/rand"
 	"debug/buildinfo"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	osexec "os/exec"
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/note"
 
+	"github.com/brunoribeiro127/gobin/internal"
 	"github.com/brunoribeiro127/gobin/internal/model"
 	"github.com/brunoribeiro127/gobin/internal/system"
 	systemmocks "github.com/brunoribeiro127/gobin/internal/system/mocks"
 	"github.com/brunoribeiro127/gobin/internal/toolchain"
 )
 
+type mockExecCombinedOutputCall struct {
+	args   []string
+	env    []string
+	output []byte
+	err    error
+}
+
+// mockGoEnvProxy mocks the go env command resolving the module proxy settings
+// to the given GOPROXY list, GONOPROXY patterns and GOAUTH methods.
+func mockGoEnvProxy(t *testing.T, exec *systemmocks.Exec, proxy, noProxy, goAuth string) {
+	t.Helper()
+
+	output, err := json.Marshal(map[string]string{"GOPROXY": proxy, "GONOPROXY": noProxy, "GOAUTH": goAuth})
+	require.NoError(t, err)
+
+	execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+	exec.EXPECT().CombinedOutput(mock.Anything, "go", []string{"env", "-json", "GOPROXY", "GONOPROXY", "GOAUTH"}).
+		Return(execCombinedOutput).
+		Once()
+	execCombinedOutput.EXPECT().CombinedOutput().Return(output, nil).Once()
+}
+
+// writeNetrc writes the given content to a .netrc file read through NETRC.
+func writeNetrc(t *testing.T, content string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), ".netrc")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	t.Setenv("NETRC", path)
+}
+
+func TestGoToolchain_Compress(t *testing.T) {
+	cases := map[string]struct {
+		mockOutput  []byte
+		mockErr     error
+		expectedErr error
+	}{
+		"success": {
+			mockOutput: []byte("Packed 1 file."),
+		},
+		"error-upx": {
+			mockOutput:  []byte("upx: mockproj: NotCompressibleException"),
+			mockErr:     errors.New("exit status 2"),
+			expectedErr: errors.New("exit status 2: upx: mockproj: NotCompressibleException"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+			exec.EXPECT().
+				CombinedOutput(context.Background(), "upx", []string{"--best", "-q", "/tmp/mockproj"}).
+				Return(execCombinedOutput).
+				Once()
+
+			execCombinedOutput.EXPECT().CombinedOutput().
+				Return(tc.mockOutput, tc.mockErr).
+				Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			err := toolchain.Compress(context.Background(), "/tmp/mockproj")
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_DownloadPackage(t *testing.T) {
+	cases := map[string]struct {
+		mockOutput  []byte
+		mockErr     error
+		expectedErr error
+	}{
+		"success": {
+			mockOutput: []byte("go: downloading example.com/mockorg/mockproj v1.0.0\nmkdir -p $WORK/b001/\n"),
+		},
+		"error-go-install": {
+			mockOutput: []byte("go: example.com/mockorg/mockproj/cmd/mockproj@v1.0.0: " +
+				"module example.com/mockorg/mockproj@v1.0.0 found, but does not contain package\n"),
+			mockErr: errors.New("exit status 1"),
+			expectedErr: errors.New("exit status 1: go: example.com/mockorg/mockproj/cmd/mockproj@v1.0.0: " +
+				"module example.com/mockorg/mockproj@v1.0.0 found, but does not contain package"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+			exec.EXPECT().
+				CombinedOutput(
+					mock.Anything,
+					"go",
+					[]string{"install", "-n", "example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"},
+				).
+				Return(execCombinedOutput).
+				Once()
+
+			execCombinedOutput.EXPECT().
+				InjectEnv([]string{"GOMODCACHE=/tmp/bundle", "GOFLAGS=-modcacherw"}).
+				Once()
+
+			execCombinedOutput.EXPECT().CombinedOutput().
+				Return(tc.mockOutput, tc.mockErr).
+				Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			err := toolchain.DownloadPackage(
+				context.Background(),
+				"/tmp/bundle",
+				model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_GetBuildID(t *testing.T) {
+	cases := map[string]struct {
+		mockOutput      []byte
+		mockErr         error
+		expectedBuildID string
+		expectedErr     error
+	}{
+		"success": {
+			mockOutput:      []byte("Qk3nRlcmYqYlM0e3Lw1J/9kJzPLpC5fHwQ9y/3uYxK1rB7pQ0wVZ2nH/aZ8mLq4tS6dXc1vN9oEr\n"),
+			expectedBuildID: "Qk3nRlcmYqYlM0e3Lw1J/9kJzPLpC5fHwQ9y/3uYxK1rB7pQ0wVZ2nH/aZ8mLq4tS6dXc1vN9oEr",
+		},
+		"error-binary-not-found": {
+			mockOutput:  []byte("open /tmp/mockproj: no such file or directory"),
+			mockErr:     errors.New("exit status 1"),
+			expectedErr: toolchain.ErrBinaryNotFound,
+		},
+		"error-go-tool-buildid": {
+			mockOutput:  []byte("/tmp/mockproj: missing build ID"),
+			mockErr:     errors.New("exit status 1"),
+			expectedErr: errors.New("exit status 1: /tmp/mockproj: missing build ID"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+			exec.EXPECT().CombinedOutput(context.Background(), "go", []string{"tool", "buildid", "/tmp/mockproj"}).
+				Return(execCombinedOutput).
+				Once()
+
+			execCombinedOutput.EXPECT().CombinedOutput().
+				Return(tc.mockOutput, tc.mockErr).
+				Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			buildID, err := toolchain.GetBuildID(context.Background(), "/tmp/mockproj")
+			assert.Equal(t, tc.expectedBuildID, buildID)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestGoToolchain_GetBuildInfo(t *testing.T) {
 	cases := map[string]struct {
 		path              string
@@ -68,7 +257,7 @@ func TestGoToolchain_GetBuildInfo(t *testing.T) {
 				Return(tc.mockReadFile, tc.mockReadFileErr).
 				Once()
 
-			toolchain := toolchain.NewGoToolchain(info, nil, nil)
+			toolchain := toolchain.NewGoToolchain(info, nil, nil, model.NetworkConfig{})
 			buildInfo, err := toolchain.GetBuildInfo(tc.path)
 			assert.Equal(t, tc.expectedBuildInfo, buildInfo)
 			assert.Equal(t, tc.expectedErr, err)
@@ -76,6 +265,140 @@ func TestGoToolchain_GetBuildInfo(t *testing.T) {
 	}
 }
 
+func TestGoToolchain_GetGoEnv(t *testing.T) {
+	cases := map[string]struct {
+		mockOutput  []byte
+		mockErr     error
+		expectedEnv model.GoEnv
+		expectedErr error
+	}{
+		"success": {
+			mockOutput: []byte(
+				`{"GOCACHE":"/home/user/.cache/go-build","GOMODCACHE":"/home/user/go/pkg/mod",` +
+					`"GOPROXY":"https://proxy.golang.org,direct"}`,
+			),
+			expectedEnv: model.GoEnv{
+				GoCache:    "/home/user/.cache/go-build",
+				GoModCache: "/home/user/go/pkg/mod",
+				GoProxy:    "https://proxy.golang.org,direct",
+			},
+		},
+		"error-go-env": {
+			mockOutput:  []byte("unexpected error"),
+			mockErr:     errors.New("exit status 1"),
+			expectedErr: errors.New("exit status 1: unexpected error"),
+		},
+		"error-parsing-env-response": {
+			expectedErr: errors.New("unexpected end of JSON input"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+			exec.EXPECT().CombinedOutput(
+				context.Background(), "go", []string{"env", "-json", "GOCACHE", "GOMODCACHE", "GOPROXY"},
+			).
+				Return(execCombinedOutput).
+				Once()
+
+			execCombinedOutput.EXPECT().CombinedOutput().
+				Return(tc.mockOutput, tc.mockErr).
+				Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			env, err := toolchain.GetGoEnv(context.Background())
+			assert.Equal(t, tc.expectedEnv, env)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_GetGoReleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dl":
+			_, _ = w.Write([]byte(`[{"version":"go1.24.9","stable":true,"files":[]},` +
+				`{"version":"go1.23.12","stable":true,"files":[]}]`))
+		case "/invalid":
+			_, _ = w.Write([]byte(`{`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cases := map[string]struct {
+		url              string
+		expectedReleases model.GoReleases
+		expectedErr      error
+	}{
+		"success": {
+			url: server.URL + "/dl",
+			expectedReleases: model.GoReleases{
+				{Version: "go1.24.9", Stable: true},
+				{Version: "go1.23.12", Stable: true},
+			},
+		},
+		"error-not-found": {
+			url:         server.URL + "/missing",
+			expectedErr: errors.New("unexpected status: 404 Not Found"),
+		},
+		"error-invalid-response": {
+			url:         server.URL + "/invalid",
+			expectedErr: errors.New("unexpected EOF"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchain.NewGoToolchain(nil, nil, nil, model.NetworkConfig{})
+			releases, err := toolchain.GetGoReleases(context.Background(), tc.url)
+			assert.Equal(t, tc.expectedReleases, releases)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_GetHTTPProxy(t *testing.T) {
+	cases := map[string]struct {
+		url           string
+		expectedProxy string
+		expectedErr   error
+	}{
+		"success-direct-localhost": {
+			url: "http://localhost:3000",
+		},
+		"error-invalid-url": {
+			url:         "://proxy.golang.org",
+			expectedErr: errors.New(`parse "://proxy.golang.org": missing protocol scheme`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchain.NewGoToolchain(nil, nil, nil, model.NetworkConfig{})
+			proxy, err := toolchain.GetHTTPProxy(tc.url)
+			assert.Equal(t, tc.expectedProxy, proxy)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestGoToolchain_GetLatestModuleVersion(t *testing.T) {
 	makeExecCmdOutput := func(t *testing.T, modFile string, version string) []byte {
 		wd, err := os.Getwd()
@@ -88,6 +411,7 @@ func TestGoToolchain_GetLatestModuleVersion(t *testing.T) {
 
 	cases := map[string]struct {
 		module            model.Module
+		direct            bool
 		mockExecCmdOutput []byte
 		mockExecCmdErr    error
 		expectedModule    model.Module
@@ -113,6 +437,12 @@ func TestGoToolchain_GetLatestModuleVersion(t *testing.T) {
 			mockExecCmdOutput: makeExecCmdOutput(t, "go.mod", "v1.1.0"),
 			expectedModule:    model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1.0")),
 		},
+		"success-direct": {
+			module:            model.NewLatestModule("example.com/mockorg/mockproj"),
+			direct:            true,
+			mockExecCmdOutput: makeExecCmdOutput(t, "go.mod", "v0.1.0"),
+			expectedModule:    model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+		},
 		"error-module-not-found": {
 			module: model.NewLatestModule("example.com/mockorg/mockproj"),
 			mockExecCmdOutput: func() []byte {
@@ -173,8 +503,16 @@ func TestGoToolchain_GetLatestModuleVersion(t *testing.T) {
 			exec := systemmocks.NewExec(t)
 			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
 
+			ctx := context.Background()
+			if tc.direct {
+				ctx = toolchain.WithDirect(ctx)
+				execCombinedOutput.EXPECT().InjectEnv([]string{"GOPROXY=direct"}).Once()
+			} else {
+				mockGoEnvProxy(t, exec, "direct", "", "off")
+			}
+
 			exec.EXPECT().CombinedOutput(
-				context.Background(),
+				ctx,
 				"go",
 				[]string{"list", "-m", "-json", tc.module.String()},
 			).Return(execCombinedOutput).Once()
@@ -183,8 +521,8 @@ func TestGoToolchain_GetLatestModuleVersion(t *testing.T) {
 				Return(tc.mockExecCmdOutput, tc.mockExecCmdErr).
 				Once()
 
-			toolchain := toolchain.NewGoToolchain(nil, exec, nil)
-			module, err := toolchain.GetLatestModuleVersion(context.Background(), tc.module)
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			module, err := toolchain.GetLatestModuleVersion(ctx, tc.module)
 			assert.Equal(t, tc.expectedModule, module)
 			if tc.expectedErr != nil {
 				assert.EqualError(t, err, tc.expectedErr.Error())
@@ -297,6 +635,7 @@ func TestGoToolchain_GetModuleFile(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			exec := systemmocks.NewExec(t)
 			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+			mockGoEnvProxy(t, exec, "direct", "", "off")
 
 			exec.EXPECT().CombinedOutput(
 				context.Background(),
@@ -308,7 +647,7 @@ func TestGoToolchain_GetModuleFile(t *testing.T) {
 				Return(tc.mockExecCmdOutput, tc.mockExecCmdErr).
 				Once()
 
-			toolchain := toolchain.NewGoToolchain(nil, exec, nil)
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
 			modFile, err := toolchain.GetModuleFile(context.Background(), tc.module)
 			assert.Equal(t, tc.expectedModFile, modFile)
 			if tc.expectedErr != nil {
@@ -387,6 +726,7 @@ func TestGoToolchain_GetModuleOrigin(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			exec := systemmocks.NewExec(t)
 			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+			mockGoEnvProxy(t, exec, "direct", "", "off")
 
 			exec.EXPECT().CombinedOutput(
 				context.Background(),
@@ -398,7 +738,7 @@ func TestGoToolchain_GetModuleOrigin(t *testing.T) {
 				Return(tc.mockExecCmdOutput, tc.mockExecCmdErr).
 				Once()
 
-			toolchain := toolchain.NewGoToolchain(nil, exec, nil)
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
 			modOrigin, err := toolchain.GetModuleOrigin(context.Background(), tc.module)
 			assert.Equal(t, tc.expectedModOrigin, modOrigin)
 			if tc.expectedErr != nil {
@@ -410,79 +750,1451 @@ func TestGoToolchain_GetModuleOrigin(t *testing.T) {
 	}
 }
 
-func TestGoToolchain_Install(t *testing.T) {
+func TestGoToolchain_GetModuleVersions(t *testing.T) {
+	pkgPath := "example.com/mockorg/mockproj/cmd/mockproj"
+
 	cases := map[string]struct {
-		path            string
-		pkg             model.Package
-		rebuild         bool
-		mockExecCmdArgs []string
-		mockExecCmdErr  error
-		expectedErr     error
+		path             string
+		network          model.NetworkConfig
+		mockExecCalls    []mockExecCombinedOutputCall
+		expectedVersions []model.Version
+		expectedWarnings []string
+		expectedErr      error
 	}{
-		"success": {
-			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
-			pkg: model.NewPackageWithVersion(
-				"example.com/mockorg/mockproj/cmd/mockproj",
+		"success-module-path": {
+			path: "example.com/mockorg/mockproj",
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+					output: []byte(`{"Path":"example.com/mockorg/mockproj","Versions":["v0.1.0","v0.10.0","v0.2.0"]}`),
+				},
+			},
+			expectedVersions: []model.Version{
 				model.NewVersion("v0.1.0"),
-			),
-			rebuild: false,
-			mockExecCmdArgs: []string{
-				"install",
-				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+				model.NewVersion("v0.2.0"),
+				model.NewVersion("v0.10.0"),
 			},
 		},
-		"success-rebuild": {
-			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
-			pkg: model.NewPackageWithVersion(
-				"example.com/mockorg/mockproj/cmd/mockproj",
+		"success-package-path": {
+			path: pkgPath,
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-versions", "-json", pkgPath},
+					output: []byte("go: module " + pkgPath + ": not found"),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj/cmd"},
+					output: []byte("go: module example.com/mockorg/mockproj/cmd: not found"),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+					output: []byte(`{"Path":"example.com/mockorg/mockproj","Versions":["v0.1.0"]}`),
+				},
+			},
+			expectedVersions: []model.Version{
 				model.NewVersion("v0.1.0"),
-			),
-			rebuild: true,
-			mockExecCmdArgs: []string{
-				"install",
-				"-a",
-				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
 			},
 		},
-		"error-installing-binary": {
-			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
-			pkg: model.NewPackageWithVersion(
-				"example.com/mockorg/mockproj/cmd/mockproj",
+		"error-module-not-found": {
+			path: "example.com/mockproj",
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockproj"},
+					output: []byte("go: module example.com/mockproj: not found"),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com"},
+					output: []byte("go: module example.com: not found"),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: toolchain.ErrModuleNotFound,
+		},
+		"error-listing-versions": {
+			path: "example.com/mockorg/mockproj",
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+					output: []byte("unexpected error"),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: errors.New("exit status 1: unexpected error"),
+		},
+		"success-retry": {
+			path:    "example.com/mockorg/mockproj",
+			network: model.NetworkConfig{Retries: 2},
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+					output: []byte("dial tcp: i/o timeout"),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+					output: []byte(`{"Path":"example.com/mockorg/mockproj","Versions":["v0.1.0"]}`),
+				},
+			},
+			expectedVersions: []model.Version{
 				model.NewVersion("v0.1.0"),
-			),
-			rebuild: false,
-			mockExecCmdArgs: []string{
-				"install",
-				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
 			},
-			mockExecCmdErr: errors.New("unexpected error"),
-			expectedErr:    errors.New("unexpected error"),
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			exec := systemmocks.NewExec(t)
-			execRun := systemmocks.NewExecRun(t)
-
-			exec.EXPECT().Run(
-				context.Background(),
-				"go",
-				tc.mockExecCmdArgs,
-			).Return(execRun).Once()
-
-			execRun.EXPECT().Run().Return(tc.mockExecCmdErr).Once()
-			execRun.EXPECT().InjectEnv([]string{"GOBIN=" + tc.path}).Once()
-
-			toolchain := toolchain.NewGoToolchain(nil, exec, nil)
-			err := toolchain.Install(context.Background(), tc.path, tc.pkg, tc.rebuild)
-			if tc.expectedErr != nil {
-				assert.EqualError(t, err, tc.expectedErr.Error())
-			} else {
-				assert.NoError(t, err)
-			}
-		})
-	}
+		"success-skip-failed-proxy": {
+			path:    "example.com/mockorg/mockproj",
+			network: model.NetworkConfig{Retries: 1},
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args: []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+					output: []byte(`go: example.com/mockorg/mockproj: Get "https://proxy.example.com/` +
+						`example.com/mockorg/mockproj/@v/list": dial tcp: i/o timeout`),
+					err: errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"env", "GOPROXY"},
+					output: []byte("https://proxy.example.com,https://proxy.golang.org|direct\n"),
+				},
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+					env:    []string{"GOPROXY=https://proxy.golang.org|direct"},
+					output: []byte(`{"Path":"example.com/mockorg/mockproj","Versions":["v0.1.0"]}`),
+				},
+			},
+			expectedVersions: []model.Version{
+				model.NewVersion("v0.1.0"),
+			},
+			expectedWarnings: []string{
+				"module proxy https://proxy.example.com failed to respond, fell back to the next proxy",
+			},
+		},
+		"error-retries-exhausted": {
+			path:    "example.com/mockorg/mockproj",
+			network: model.NetworkConfig{Retries: 1},
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+					output: []byte("dial tcp: i/o timeout"),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+					output: []byte("dial tcp: i/o timeout"),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: errors.New("network unavailable: exit status 1: dial tcp: i/o timeout"),
+		},
+		"error-module-not-found-not-retried": {
+			path:    "example.com",
+			network: model.NetworkConfig{Retries: 2},
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-versions", "-json", "example.com"},
+					output: []byte("go: module example.com: not found"),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: toolchain.ErrModuleNotFound,
+		},
+		"error-parsing-versions-response": {
+			path: "example.com/mockorg/mockproj",
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args: []string{"list", "-m", "-versions", "-json", "example.com/mockorg/mockproj"},
+				},
+			},
+			expectedErr: errors.New("unexpected end of JSON input"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			warnings := internal.NewWarnings()
+			ctx := internal.WithWarnings(context.Background(), warnings)
+			exec := systemmocks.NewExec(t)
+
+			for _, call := range tc.mockExecCalls {
+				execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+				exec.EXPECT().CombinedOutput(ctx, "go", call.args).
+					Return(execCombinedOutput).
+					Once()
+
+				if call.env != nil {
+					execCombinedOutput.EXPECT().InjectEnv(call.env).Once()
+				}
+
+				execCombinedOutput.EXPECT().CombinedOutput().
+					Return(call.output, call.err).
+					Once()
+			}
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, tc.network)
+			versions, err := toolchain.GetModuleVersions(ctx, tc.path)
+			assert.Equal(t, tc.expectedVersions, versions)
+			assert.Equal(t, tc.expectedWarnings, warnings.Get())
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_GetPackageDownloadSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || r.URL.Path != "/example.com/mockorg/mockproj/@v/v0.1.0.zip" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Length", "1234")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pkgPath := "example.com/mockorg/mockproj/cmd/mockproj"
+
+	cases := map[string]struct {
+		pkg            model.Package
+		mockExecCalls  []mockExecCombinedOutputCall
+		expectedModule model.Module
+		expectedSize   model.ByteSize
+		expectedErr    error
+	}{
+		"success": {
+			pkg: model.NewPackage(pkgPath + "@latest"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-json", pkgPath + "@latest"},
+					output: []byte("go: module " + pkgPath + ": not found"),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"list", "-m", "-json", "example.com/mockorg/mockproj/cmd@latest"},
+					output: []byte("go: module example.com/mockorg/mockproj/cmd: not found"),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"list", "-m", "-json", "example.com/mockorg/mockproj@latest"},
+					output: []byte(`{"Path":"example.com/mockorg/mockproj","Version":"v0.1.0"}`),
+				},
+				{
+					args:   []string{"env", "GOPROXY"},
+					output: []byte(server.URL + "/missing," + server.URL + ",direct"),
+				},
+			},
+			expectedModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.1.0")),
+			expectedSize:   1234,
+		},
+		"error-download-size-not-available": {
+			pkg: model.NewPackage("example.com/mockorg/mockproj@v0.2.0"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-json", "example.com/mockorg/mockproj@v0.2.0"},
+					output: []byte(`{"Path":"example.com/mockorg/mockproj","Version":"v0.2.0"}`),
+				},
+				{
+					args:   []string{"env", "GOPROXY"},
+					output: []byte(server.URL + ",direct"),
+				},
+			},
+			expectedModule: model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v0.2.0")),
+			expectedErr:    toolchain.ErrDownloadSizeNotAvailable,
+		},
+		"error-module-not-found": {
+			pkg: model.NewPackage("mockproj@latest"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-json", "mockproj@latest"},
+					output: []byte("go: module mockproj: not found"),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: toolchain.ErrModuleNotFound,
+		},
+		"error-get-package-module": {
+			pkg: model.NewPackage("example.com/mockorg/mockproj@latest"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-json", "example.com/mockorg/mockproj@latest"},
+					output: []byte("unexpected error"),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: errors.New("exit status 1: unexpected error"),
+		},
+		"error-get-proxies": {
+			pkg: model.NewPackage("example.com/mockorg/mockproj@latest"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-json", "example.com/mockorg/mockproj@latest"},
+					output: []byte(`{"Path":"example.com/mockorg/mockproj","Version":"v0.1.0"}`),
+				},
+				{
+					args:   []string{"env", "GOPROXY"},
+					output: []byte("unexpected error"),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: errors.New("exit status 1: unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+
+			for _, call := range tc.mockExecCalls {
+				execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+				exec.EXPECT().CombinedOutput(context.Background(), "go", call.args).
+					Return(execCombinedOutput).
+					Once()
+
+				execCombinedOutput.EXPECT().CombinedOutput().
+					Return(call.output, call.err).
+					Once()
+			}
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			mod, size, err := toolchain.GetPackageDownloadSize(context.Background(), tc.pkg)
+			assert.Equal(t, tc.expectedModule, mod)
+			assert.Equal(t, tc.expectedSize, size)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_GetPackageInfo(t *testing.T) {
+	makeDownloadOutput := func(t *testing.T, version string) []byte {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		bytes, err := json.Marshal(map[string]string{
+			"Path":    "example.com/mockorg/mockproj",
+			"Version": version,
+			"Dir":     filepath.Join(wd, "testdata", "mockmod"),
+		})
+		require.NoError(t, err)
+		return bytes
+	}
+
+	mainPkgs := []string{
+		"example.com/mockorg/mockproj/cmd/mockproj",
+		"example.com/mockorg/mockproj/cmd/mocktool",
+	}
+
+	cases := map[string]struct {
+		pkg           model.Package
+		mockExecCalls []mockExecCombinedOutputCall
+		expectedInfo  model.PackageInfo
+		expectedErr   error
+	}{
+		"success-main-package": {
+			pkg: model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"mod", "download", "-json", "example.com/mockorg/mockproj/cmd/mockproj@v1.0.0"},
+					output: []byte(`{"Error":"module example.com/mockorg/mockproj/cmd/mockproj: not found"}`),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"mod", "download", "-json", "example.com/mockorg/mockproj/cmd@v1.0.0"},
+					output: []byte(`{"Error":"module example.com/mockorg/mockproj/cmd: not found"}`),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"mod", "download", "-json", "example.com/mockorg/mockproj@v1.0.0"},
+					output: makeDownloadOutput(t, "v1.0.0"),
+				},
+			},
+			expectedInfo: model.PackageInfo{
+				Module:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				Exists:       true,
+				IsMain:       true,
+				MainPackages: mainPkgs,
+			},
+		},
+		"success-non-main-package": {
+			pkg: model.NewPackage("example.com/mockorg/mockproj/internal/lib@latest"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"mod", "download", "-json", "example.com/mockorg/mockproj/internal/lib@latest"},
+					output: []byte(`{"Error":"module example.com/mockorg/mockproj/internal/lib: not found"}`),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"mod", "download", "-json", "example.com/mockorg/mockproj/internal@latest"},
+					output: []byte(`{"Error":"module example.com/mockorg/mockproj/internal: not found"}`),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"mod", "download", "-json", "example.com/mockorg/mockproj@latest"},
+					output: makeDownloadOutput(t, "v1.1.0"),
+				},
+			},
+			expectedInfo: model.PackageInfo{
+				Module:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.1.0")),
+				Exists:       true,
+				IsMain:       false,
+				MainPackages: mainPkgs,
+			},
+		},
+		"success-missing-package": {
+			pkg: model.NewPackage("example.com/mockorg/mockproj@v1.0.0"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"mod", "download", "-json", "example.com/mockorg/mockproj@v1.0.0"},
+					output: makeDownloadOutput(t, "v1.0.0"),
+				},
+			},
+			expectedInfo: model.PackageInfo{
+				Module:       model.NewModule("example.com/mockorg/mockproj", model.NewVersion("v1.0.0")),
+				Exists:       false,
+				IsMain:       false,
+				MainPackages: mainPkgs,
+			},
+		},
+		"error-module-not-found": {
+			pkg: model.NewPackage("example.com/mockproj@v1.0.0"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"mod", "download", "-json", "example.com/mockproj@v1.0.0"},
+					output: []byte(`{"Error":"example.com/mockproj@v1.0.0: invalid version: unknown revision v1.0.0"}`),
+					err:    errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"mod", "download", "-json", "example.com@v1.0.0"},
+					output: []byte(`{"Error":"module example.com: not found"}`),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: toolchain.ErrModuleNotFound,
+		},
+		"error-module-not-found-file-proxy": {
+			pkg: model.NewPackage("example.com/mockproj@v1.0.0"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args: []string{"mod", "download", "-json", "example.com/mockproj@v1.0.0"},
+					output: []byte(`{"Error":"example.com/mockproj@v1.0.0: reading ` +
+						`file:///tmp/bundle/cache/download/example.com/mockproj/@v/v1.0.0.info: ` +
+						`no such file or directory"}`),
+					err: errors.New("exit status 1"),
+				},
+				{
+					args:   []string{"mod", "download", "-json", "example.com@v1.0.0"},
+					output: []byte(`{"Error":"module example.com: not found"}`),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: toolchain.ErrModuleNotFound,
+		},
+		"error-downloading-module": {
+			pkg: model.NewPackage("example.com/mockorg/mockproj@v1.0.0"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"mod", "download", "-json", "example.com/mockorg/mockproj@v1.0.0"},
+					output: []byte(`{"Error":"unexpected error"}`),
+					err:    errors.New("exit status 1"),
+				},
+			},
+			expectedErr: errors.New("unexpected error"),
+		},
+		"error-parsing-download-response": {
+			pkg: model.NewPackage("example.com/mockorg/mockproj@v1.0.0"),
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args: []string{"mod", "download", "-json", "example.com/mockorg/mockproj@v1.0.0"},
+				},
+			},
+			expectedErr: errors.New("unexpected end of JSON input"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+
+			for _, call := range tc.mockExecCalls {
+				execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+				exec.EXPECT().CombinedOutput(context.Background(), "go", call.args).
+					Return(execCombinedOutput).
+					Once()
+
+				execCombinedOutput.EXPECT().CombinedOutput().
+					Return(call.output, call.err).
+					Once()
+			}
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			info, err := toolchain.GetPackageInfo(context.Background(), tc.pkg)
+			assert.Equal(t, tc.expectedInfo, info)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_GetProxies(t *testing.T) {
+	cases := map[string]struct {
+		mockOutput      []byte
+		mockErr         error
+		expectedProxies []string
+		expectedErr     error
+	}{
+		"success": {
+			mockOutput:      []byte("https://proxy.example.com,https://proxy.golang.org|direct\n"),
+			expectedProxies: []string{"https://proxy.example.com", "https://proxy.golang.org", "direct"},
+		},
+		"success-off": {
+			mockOutput: []byte("off\n"),
+		},
+		"error-go-env": {
+			mockOutput:  []byte("unexpected error"),
+			mockErr:     errors.New("exit status 1"),
+			expectedErr: errors.New("exit status 1: unexpected error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+			exec.EXPECT().CombinedOutput(context.Background(), "go", []string{"env", "GOPROXY"}).
+				Return(execCombinedOutput).
+				Once()
+
+			execCombinedOutput.EXPECT().CombinedOutput().
+				Return(tc.mockOutput, tc.mockErr).
+				Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			proxies, err := toolchain.GetProxies(context.Background())
+			assert.Equal(t, tc.expectedProxies, proxies)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_GetSumDBConfig(t *testing.T) {
+	cases := map[string]struct {
+		mockOutput     []byte
+		mockErr        error
+		expectedConfig model.SumDBConfig
+		expectedErr    error
+	}{
+		"success": {
+			mockOutput: []byte(`{"GONOSUMDB":"example.com/*","GOSUMDB":"sum.golang.org"}`),
+			expectedConfig: model.SumDBConfig{
+				SumDB:   "sum.golang.org",
+				NoSumDB: "example.com/*",
+			},
+		},
+		"error-go-env": {
+			mockOutput:  []byte("unexpected error"),
+			mockErr:     errors.New("exit status 1"),
+			expectedErr: errors.New("exit status 1: unexpected error"),
+		},
+		"error-parsing-env-response": {
+			expectedErr: errors.New("unexpected end of JSON input"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+			exec.EXPECT().CombinedOutput(context.Background(), "go", []string{"env", "-json", "GOSUMDB", "GONOSUMDB"}).
+				Return(execCombinedOutput).
+				Once()
+
+			execCombinedOutput.EXPECT().CombinedOutput().
+				Return(tc.mockOutput, tc.mockErr).
+				Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			config, err := toolchain.GetSumDBConfig(context.Background())
+			assert.Equal(t, tc.expectedConfig, config)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_Install(t *testing.T) {
+	cases := map[string]struct {
+		path            string
+		pkg             model.Package
+		flags           model.BuildFlags
+		rebuild         bool
+		direct          bool
+		goVersion       string
+		provenance      model.Provenance
+		mockExecCmdArgs []string
+		mockExecCmdEnv  []string
+		mockExecCmdErr  error
+		expectedErr     error
+	}{
+		"success": {
+			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg: model.NewPackageWithVersion(
+				"example.com/mockorg/mockproj/cmd/mockproj",
+				model.NewVersion("v0.1.0"),
+			),
+			rebuild: false,
+			mockExecCmdArgs: []string{
+				"install",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+		},
+		"success-rebuild": {
+			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg: model.NewPackageWithVersion(
+				"example.com/mockorg/mockproj/cmd/mockproj",
+				model.NewVersion("v0.1.0"),
+			),
+			rebuild: true,
+			mockExecCmdArgs: []string{
+				"install",
+				"-a",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+		},
+		"success-build-flags": {
+			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg: model.NewPackageWithVersion(
+				"example.com/mockorg/mockproj/cmd/mockproj",
+				model.NewVersion("v0.1.0"),
+			),
+			flags: model.BuildFlags{
+				Tags:       "netgo",
+				LDFlags:    "-s -w",
+				CGOEnabled: "0",
+			},
+			rebuild: false,
+			mockExecCmdArgs: []string{
+				"install",
+				"-tags=netgo",
+				"-ldflags=-s -w",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+			mockExecCmdEnv: []string{"CGO_ENABLED=0"},
+		},
+		"success-direct": {
+			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg: model.NewPackageWithVersion(
+				"example.com/mockorg/mockproj/cmd/mockproj",
+				model.NewVersion("v0.1.0"),
+			),
+			rebuild: false,
+			direct:  true,
+			mockExecCmdArgs: []string{
+				"install",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+			mockExecCmdEnv: []string{"GOPROXY=direct"},
+		},
+		"success-go-version": {
+			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg: model.NewPackageWithVersion(
+				"example.com/mockorg/mockproj/cmd/mockproj",
+				model.NewVersion("v0.1.0"),
+			),
+			rebuild:   false,
+			goVersion: "go1.24.5",
+			mockExecCmdArgs: []string{
+				"install",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+			mockExecCmdEnv: []string{"GOTOOLCHAIN=go1.24.5"},
+		},
+		"success-provenance": {
+			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg: model.NewPackageWithVersion(
+				"example.com/mockorg/mockproj/cmd/mockproj",
+				model.NewVersion("v0.1.0"),
+			),
+			flags: model.BuildFlags{
+				Strip:      true,
+				Provenance: true,
+			},
+			provenance: model.Provenance{Builder: "gobin@v1.0.0", Source: "install"},
+			mockExecCmdArgs: []string{
+				"install",
+				"-ldflags=-s -w -X=dev.gobin.provenance.builder=gobin@v1.0.0 " +
+					"-X=dev.gobin.provenance.spec=example.com/mockorg/mockproj/cmd/mockproj@v0.1.0 " +
+					"-X=dev.gobin.provenance.source=install",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+		},
+		"success-provenance-disabled": {
+			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg: model.NewPackageWithVersion(
+				"example.com/mockorg/mockproj/cmd/mockproj",
+				model.NewVersion("v0.1.0"),
+			),
+			provenance: model.Provenance{Builder: "gobin@v1.0.0", Source: "install"},
+			mockExecCmdArgs: []string{
+				"install",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+		},
+		"error-installing-binary": {
+			path: "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg: model.NewPackageWithVersion(
+				"example.com/mockorg/mockproj/cmd/mockproj",
+				model.NewVersion("v0.1.0"),
+			),
+			rebuild: false,
+			mockExecCmdArgs: []string{
+				"install",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+			mockExecCmdErr: errors.New("unexpected error"),
+			expectedErr:    fmt.Errorf("%w: %w", toolchain.ErrBuildFailed, errors.New("unexpected error")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execRun := systemmocks.NewExecRun(t)
+
+			ctx := context.Background()
+			if tc.direct {
+				ctx = toolchain.WithDirect(ctx)
+			}
+			if tc.goVersion != "" {
+				ctx = toolchain.WithGoVersion(ctx, tc.goVersion)
+			}
+			if tc.provenance != (model.Provenance{}) {
+				ctx = toolchain.WithProvenance(ctx, tc.provenance)
+			}
+
+			exec.EXPECT().Run(
+				ctx,
+				"go",
+				tc.mockExecCmdArgs,
+			).Return(execRun).Once()
+
+			execRun.EXPECT().Run().Return(tc.mockExecCmdErr).Once()
+			execRun.EXPECT().InjectEnv(append([]string{"GOBIN=" + tc.path}, tc.mockExecCmdEnv...)).Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			err := toolchain.Install(ctx, tc.path, tc.pkg, tc.flags, tc.rebuild)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_InstallLocal(t *testing.T) {
+	path := "/home/user/.gobin/.tmp/local-1234567890"
+
+	cases := map[string]struct {
+		dir             string
+		flags           model.BuildFlags
+		direct          bool
+		provenance      model.Provenance
+		mockExecCmdArgs []string
+		mockExecCmdEnv  []string
+		mockExecCmdErr  error
+		expectedErr     error
+	}{
+		"success": {
+			dir:             "/home/user/src/mockproj/cmd/mockproj",
+			mockExecCmdArgs: []string{"-C", "/home/user/src/mockproj/cmd/mockproj", "install", "."},
+		},
+		"success-build-flags": {
+			dir: "/home/user/src/mockproj/cmd/mockproj",
+			flags: model.BuildFlags{
+				Tags:       "netgo",
+				CGOEnabled: "0",
+			},
+			mockExecCmdArgs: []string{"-C", "/home/user/src/mockproj/cmd/mockproj", "install", "-tags=netgo", "."},
+			mockExecCmdEnv:  []string{"CGO_ENABLED=0"},
+		},
+		"success-direct": {
+			dir:             "/home/user/src/mockproj/cmd/mockproj",
+			direct:          true,
+			mockExecCmdArgs: []string{"-C", "/home/user/src/mockproj/cmd/mockproj", "install", "."},
+			mockExecCmdEnv:  []string{"GOPROXY=direct"},
+		},
+		"success-provenance": {
+			dir:        "/home/user/src/mockproj/cmd/mockproj",
+			flags:      model.BuildFlags{Provenance: true},
+			provenance: model.Provenance{Builder: "gobin@v1.0.0", Source: "install"},
+			mockExecCmdArgs: []string{
+				"-C",
+				"/home/user/src/mockproj/cmd/mockproj",
+				"install",
+				"-ldflags=-X=dev.gobin.provenance.builder=gobin@v1.0.0 " +
+					"-X=dev.gobin.provenance.spec=/home/user/src/mockproj/cmd/mockproj " +
+					"-X=dev.gobin.provenance.source=install",
+				".",
+			},
+		},
+		"success-provenance-dir-with-spaces": {
+			dir:        "/home/user/my src/mockproj",
+			flags:      model.BuildFlags{Provenance: true},
+			provenance: model.Provenance{Builder: "gobin@v1.0.0", Source: "install"},
+			mockExecCmdArgs: []string{
+				"-C",
+				"/home/user/my src/mockproj",
+				"install",
+				"-ldflags=-X=dev.gobin.provenance.builder=gobin@v1.0.0 -X=dev.gobin.provenance.source=install",
+				".",
+			},
+		},
+		"error-installing-binary": {
+			dir:             "/home/user/src/mockproj/cmd/mockproj",
+			mockExecCmdArgs: []string{"-C", "/home/user/src/mockproj/cmd/mockproj", "install", "."},
+			mockExecCmdErr:  errors.New("unexpected error"),
+			expectedErr:     fmt.Errorf("%w: %w", toolchain.ErrBuildFailed, errors.New("unexpected error")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execRun := systemmocks.NewExecRun(t)
+
+			ctx := context.Background()
+			if tc.direct {
+				ctx = toolchain.WithDirect(ctx)
+			}
+			if tc.provenance != (model.Provenance{}) {
+				ctx = toolchain.WithProvenance(ctx, tc.provenance)
+			}
+
+			exec.EXPECT().Run(ctx, "go", tc.mockExecCmdArgs).Return(execRun).Once()
+
+			execRun.EXPECT().Run().Return(tc.mockExecCmdErr).Once()
+			execRun.EXPECT().InjectEnv(append([]string{"GOBIN=" + path}, tc.mockExecCmdEnv...)).Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			err := toolchain.InstallLocal(ctx, path, tc.dir, tc.flags)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_InstallPlatform(t *testing.T) {
+	cases := map[string]struct {
+		path              string
+		pkg               model.Package
+		platform          string
+		flags             model.BuildFlags
+		rebuild           bool
+		mockEnvOutput     []byte
+		mockEnvErr        error
+		callInstall       bool
+		mockExecCmdArgs   []string
+		mockExecCmdEnv    []string
+		mockExecCmdRunErr error
+		expectedErr       error
+	}{
+		"success": {
+			path:          "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg:           model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v0.1.0"),
+			platform:      "darwin/amd64",
+			mockEnvOutput: []byte("/home/user/go/pkg/mod\n"),
+			callInstall:   true,
+			mockExecCmdArgs: []string{
+				"install",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+			mockExecCmdEnv: []string{
+				"GOBIN=",
+				"GOPATH=/home/user/.gobin/.tmp/mockproj-1234567890",
+				"GOMODCACHE=/home/user/go/pkg/mod",
+				"GOOS=darwin",
+				"GOARCH=amd64",
+			},
+		},
+		"success-build-flags-rebuild": {
+			path:          "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg:           model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v0.1.0"),
+			platform:      "darwin/arm64",
+			flags:         model.BuildFlags{Tags: "netgo", CGOEnabled: "0"},
+			rebuild:       true,
+			mockEnvOutput: []byte("/home/user/go/pkg/mod\n"),
+			callInstall:   true,
+			mockExecCmdArgs: []string{
+				"install",
+				"-a",
+				"-tags=netgo",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+			mockExecCmdEnv: []string{
+				"GOBIN=",
+				"GOPATH=/home/user/.gobin/.tmp/mockproj-1234567890",
+				"GOMODCACHE=/home/user/go/pkg/mod",
+				"GOOS=darwin",
+				"GOARCH=arm64",
+				"CGO_ENABLED=0",
+			},
+		},
+		"error-go-env": {
+			path:          "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg:           model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v0.1.0"),
+			platform:      "darwin/amd64",
+			mockEnvOutput: []byte("unexpected error"),
+			mockEnvErr:    errors.New("exit status 1"),
+			expectedErr:   errors.New("exit status 1: unexpected error"),
+		},
+		"error-installing-binary": {
+			path:          "/home/user/.gobin/.tmp/mockproj-1234567890",
+			pkg:           model.NewPackage("example.com/mockorg/mockproj/cmd/mockproj@v0.1.0"),
+			platform:      "darwin/amd64",
+			mockEnvOutput: []byte("/home/user/go/pkg/mod\n"),
+			callInstall:   true,
+			mockExecCmdArgs: []string{
+				"install",
+				"example.com/mockorg/mockproj/cmd/mockproj@v0.1.0",
+			},
+			mockExecCmdEnv: []string{
+				"GOBIN=",
+				"GOPATH=/home/user/.gobin/.tmp/mockproj-1234567890",
+				"GOMODCACHE=/home/user/go/pkg/mod",
+				"GOOS=darwin",
+				"GOARCH=amd64",
+			},
+			mockExecCmdRunErr: errors.New("unexpected error"),
+			expectedErr:       fmt.Errorf("%w: %w", toolchain.ErrBuildFailed, errors.New("unexpected error")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+			execRun := systemmocks.NewExecRun(t)
+
+			exec.EXPECT().CombinedOutput(context.Background(), "go", []string{"env", "GOMODCACHE"}).
+				Return(execCombinedOutput).Once()
+			execCombinedOutput.EXPECT().CombinedOutput().Return(tc.mockEnvOutput, tc.mockEnvErr).Once()
+
+			if tc.callInstall {
+				exec.EXPECT().Run(context.Background(), "go", tc.mockExecCmdArgs).Return(execRun).Once()
+				execRun.EXPECT().InjectEnv(tc.mockExecCmdEnv).Once()
+				execRun.EXPECT().Run().Return(tc.mockExecCmdRunErr).Once()
+			}
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			err := toolchain.InstallPlatform(
+				context.Background(), tc.path, tc.pkg, tc.platform, tc.flags, tc.rebuild,
+			)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_LookupModuleSum(t *testing.T) {
+	skey, vkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+
+	_, otherVkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(sumdb.NewServer(sumdb.NewTestServer(skey, func(path, vers string) ([]byte, error) {
+		switch path + "@" + vers {
+		case "github.com/BurntSushi/toml@v1.5.0":
+			return []byte("github.com/BurntSushi/toml v1.5.0 h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=\n" +
+				"github.com/BurntSushi/toml v1.5.0/go.mod h1:ukJfTF/6rtPPRCnwkur4qwRxa8vTRFBF0uk2lLoLwho=\n"), nil
+		case "example.com/mockproj@v1.1.0":
+			return nil, errors.New("unexpected error")
+		default:
+			return nil, os.ErrNotExist
+		}
+	})))
+	defer server.Close()
+
+	cases := map[string]struct {
+		sumDB               model.SumDBConfig
+		module              model.Module
+		expectedSum         string
+		expectedErr         error
+		expectedErrContains string
+	}{
+		"success": {
+			sumDB:       model.SumDBConfig{SumDB: vkey + " " + server.URL},
+			module:      model.NewModule("github.com/BurntSushi/toml", model.NewVersion("v1.5.0")),
+			expectedSum: "h1:Zn6y0QZqqixH1kGqbYWR/Ce4eG9FD4xZ8buAi7rStQc=",
+		},
+		"error-not-found": {
+			sumDB:       model.SumDBConfig{SumDB: vkey + " " + server.URL},
+			module:      model.NewModule("github.com/BurntSushi/toml", model.NewVersion("v1.6.0")),
+			expectedErr: toolchain.ErrModuleSumNotFound,
+		},
+		"error-unexpected-status": {
+			sumDB:  model.SumDBConfig{SumDB: vkey + " " + server.URL},
+			module: model.NewModule("example.com/mockproj", model.NewVersion("v1.1.0")),
+			expectedErr: errors.New("example.com/mockproj@v1.1.0: network unavailable: " +
+				"unexpected status: 500 Internal Server Error"),
+		},
+		"error-invalid-signature": {
+			sumDB:  model.SumDBConfig{SumDB: otherVkey + " " + server.URL},
+			module: model.NewModule("github.com/BurntSushi/toml", model.NewVersion("v1.5.0")),
+			expectedErrContains: "github.com/BurntSushi/toml@v1.5.0: reading tree note: " +
+				"note has no verifiable signatures",
+		},
+		"error-key-not-found": {
+			sumDB:       model.SumDBConfig{SumDB: "sum.example.com " + server.URL},
+			module:      model.NewModule("github.com/BurntSushi/toml", model.NewVersion("v1.5.0")),
+			expectedErr: fmt.Errorf("%w: GOSUMDB=sum.example.com %s", toolchain.ErrSumDBKeyNotFound, server.URL),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchain.NewGoToolchain(nil, nil, nil, model.NetworkConfig{})
+			sum, err := toolchain.LookupModuleSum(context.Background(), tc.sumDB, tc.module)
+			assert.Equal(t, tc.expectedSum, sum)
+			switch {
+			case tc.expectedErr != nil:
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			case tc.expectedErrContains != "":
+				assert.ErrorContains(t, err, tc.expectedErrContains)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_ProbeBinary(t *testing.T) {
+	cases := map[string]struct {
+		mockErr     error
+		expectedErr error
+	}{
+		"success": {},
+		"success-exit-code": {
+			mockErr: osexec.Command("go", "-unknown").Run(),
+		},
+		"error-start": {
+			mockErr:     errors.New("fork/exec /tmp/mockproj: exec format error"),
+			expectedErr: errors.New("fork/exec /tmp/mockproj: exec format error"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+			exec.EXPECT().CombinedOutput(mock.Anything, "/tmp/mockproj", []string{"-h"}).
+				Return(execCombinedOutput).
+				Once()
+
+			execCombinedOutput.EXPECT().CombinedOutput().
+				Return(nil, tc.mockErr).
+				Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			err := toolchain.ProbeBinary(context.Background(), "/tmp/mockproj")
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGoToolchain_ProbeProxy(t *testing.T) {
+	cases := map[string]struct {
+		proxy        string
+		status       int
+		unauthorized bool
+		netrc        string
+		mockGoList   bool
+		mockOutput   []byte
+		mockErr      error
+		mockGoEnvErr error
+		expectedErr  error
+	}{
+		"success": {
+			proxy:  "%s",
+			status: http.StatusOK,
+		},
+		"success-authentication": {
+			proxy:        "%s",
+			status:       http.StatusOK,
+			unauthorized: true,
+			netrc:        "machine 127.0.0.1 login user password secret\n",
+		},
+		"success-fallback-authentication": {
+			proxy:        "%s",
+			status:       http.StatusOK,
+			unauthorized: true,
+			mockGoList:   true,
+			mockOutput:   []byte(`{"Path":"golang.org/x/mod","Version":"v0.27.0"}`),
+		},
+		"success-not-http": {
+			proxy:      "file:///var/cache/goproxy",
+			mockGoList: true,
+			mockOutput: []byte(`{"Path":"golang.org/x/mod","Version":"v0.27.0"}`),
+		},
+		"error-probe": {
+			proxy:       "%s",
+			status:      http.StatusBadGateway,
+			expectedErr: errors.New("network unavailable: unexpected status: 502 Bad Gateway"),
+		},
+		"error-rejected-credentials": {
+			proxy:        "%s",
+			status:       http.StatusOK,
+			unauthorized: true,
+			netrc:        "machine 127.0.0.1 login user password wrong\n",
+			expectedErr:  errors.New("module proxy authentication failed: 401 Unauthorized"),
+		},
+		"error-fallback-authentication": {
+			proxy:        "%s",
+			status:       http.StatusOK,
+			unauthorized: true,
+			mockGoList:   true,
+			mockOutput:   []byte("reading golang.org/x/mod/@v/list: 401 Unauthorized"),
+			mockErr:      errors.New("exit status 1"),
+			expectedErr: errors.New(
+				"module proxy authentication failed: exit status 1: reading golang.org/x/mod/@v/list: 401 Unauthorized",
+			),
+		},
+		"error-fallback-not-authentication": {
+			proxy:        "%s",
+			status:       http.StatusOK,
+			unauthorized: true,
+			mockGoList:   true,
+			mockOutput:   []byte("dial tcp: lookup proxy.example.com: no such host"),
+			mockErr:      errors.New("exit status 1"),
+			expectedErr:  errors.New("exit status 1: dial tcp: lookup proxy.example.com: no such host"),
+		},
+		"error-go-env": {
+			proxy:        "%s",
+			mockGoEnvErr: errors.New("exit status 1"),
+			expectedErr:  errors.New("exit status 1"),
+		},
+		"error-not-http": {
+			proxy:       "file:///var/cache/goproxy",
+			mockGoList:  true,
+			mockOutput:  []byte("open /var/cache/goproxy: no such file or directory"),
+			mockErr:     errors.New("exit status 1"),
+			expectedErr: errors.New("exit status 1: open /var/cache/goproxy: no such file or directory"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if login, password, _ := r.BasicAuth(); tc.unauthorized && (login != "user" || password != "secret") {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				if r.URL.Path != "/golang.org/x/mod/@v/list" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			proxy := tc.proxy
+			if strings.Contains(proxy, "%s") {
+				proxy = fmt.Sprintf(proxy, server.URL)
+			}
+
+			exec := systemmocks.NewExec(t)
+			writeNetrc(t, tc.netrc)
+
+			switch {
+			case tc.mockGoEnvErr != nil:
+				execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+				exec.EXPECT().
+					CombinedOutput(mock.Anything, "go", []string{"env", "-json", "GOPROXY", "GONOPROXY", "GOAUTH"}).
+					Return(execCombinedOutput).
+					Once()
+				execCombinedOutput.EXPECT().CombinedOutput().Return(nil, tc.mockGoEnvErr).Once()
+			case strings.HasPrefix(proxy, "http"):
+				mockGoEnvProxy(t, exec, proxy, "", "netrc")
+			}
+
+			if tc.mockGoList {
+				execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+				exec.EXPECT().
+					CombinedOutput(context.Background(), "go", []string{"list", "-m", "-json", "golang.org/x/mod@latest"}).
+					Return(execCombinedOutput).
+					Once()
+
+				execCombinedOutput.EXPECT().InjectEnv([]string{"GOPROXY=" + proxy}).Once()
+
+				execCombinedOutput.EXPECT().CombinedOutput().
+					Return(tc.mockOutput, tc.mockErr).
+					Once()
+			}
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			err := toolchain.ProbeProxy(context.Background(), proxy)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_SearchPackages(t *testing.T) {
+	page := `<div class="SearchResults">
+<div class="SearchSnippet">
+  <h2><a href="/golang.org/x/tools/cmd/stringer" data-gtmc="search result" data-test-id="snippet-title">
+    stringer <span class="SearchSnippet-header-path">(golang.org/x/tools/cmd/stringer)</span>
+  </a></h2>
+  <p class="SearchSnippet-synopsis" data-test-id="snippet-synopsis">
+    Stringer is a tool to automate the creation of methods that satisfy the fmt.Stringer interface.
+  </p>
+  <span class="go-Chip go-Chip--inverted">command</span>
+</div>
+<div class="SearchSnippet">
+  <h2><a href="/golang.org/x/tools/go/packages" data-test-id="snippet-title">packages</a></h2>
+  <p class="SearchSnippet-synopsis" data-test-id="snippet-synopsis">Package packages loads Go packages &amp; more.</p>
+</div>
+<div class="SearchSnippet">
+  <h2><a href="/github.com/mockorg/mockproj/cmd/mockproj" data-test-id="snippet-title">mockproj</a></h2>
+  <span class="go-Chip">command</span>
+</div>
+</div>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" || r.URL.Query().Get("m") != "package" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Query().Get("q") == "stringer" {
+			_, _ = w.Write([]byte(page))
+		}
+	}))
+	defer server.Close()
+
+	cases := map[string]struct {
+		url             string
+		query           string
+		limit           int
+		expectedResults []model.PackageSearchResult
+		expectedErr     error
+	}{
+		"success": {
+			url:   server.URL + "/search",
+			query: "stringer",
+			limit: 10,
+			expectedResults: []model.PackageSearchResult{
+				{
+					Path:      "golang.org/x/tools/cmd/stringer",
+					Synopsis:  "Stringer is a tool to automate the creation of methods that satisfy the fmt.Stringer interface.",
+					IsCommand: true,
+				},
+				{
+					Path:     "golang.org/x/tools/go/packages",
+					Synopsis: "Package packages loads Go packages & more.",
+				},
+				{
+					Path:      "github.com/mockorg/mockproj/cmd/mockproj",
+					IsCommand: true,
+				},
+			},
+		},
+		"success-limit": {
+			url:   server.URL + "/search",
+			query: "stringer",
+			limit: 1,
+			expectedResults: []model.PackageSearchResult{
+				{
+					Path:      "golang.org/x/tools/cmd/stringer",
+					Synopsis:  "Stringer is a tool to automate the creation of methods that satisfy the fmt.Stringer interface.",
+					IsCommand: true,
+				},
+			},
+		},
+		"success-no-results": {
+			url:             server.URL + "/search",
+			query:           "unknown",
+			limit:           10,
+			expectedResults: []model.PackageSearchResult{},
+		},
+		"error-not-found": {
+			url:         server.URL + "/missing",
+			query:       "stringer",
+			limit:       10,
+			expectedErr: errors.New("unexpected status: 404 Not Found"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toolchain := toolchain.NewGoToolchain(nil, nil, nil, model.NetworkConfig{})
+			results, err := toolchain.SearchPackages(context.Background(), tc.url, tc.query, tc.limit)
+			assert.Equal(t, tc.expectedResults, results)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_Sign(t *testing.T) {
+	cases := map[string]struct {
+		goos         string
+		expectedName string
+		expectedArgs []string
+		mockOutput   []byte
+		mockErr      error
+		expectedErr  error
+	}{
+		"success-darwin": {
+			goos:         "darwin",
+			expectedName: "codesign",
+			expectedArgs: []string{"--force", "--sign", "mockidentity", "/tmp/mockproj"},
+			mockOutput:   []byte("/tmp/mockproj: replacing existing signature"),
+		},
+		"success-windows": {
+			goos:         "windows",
+			expectedName: "signtool",
+			expectedArgs: []string{"sign", "/fd", "SHA256", "/n", "mockidentity", "/tmp/mockproj"},
+			mockOutput:   []byte("Successfully signed: /tmp/mockproj"),
+		},
+		"success-linux": {
+			goos:         "linux",
+			expectedName: "gpg",
+			expectedArgs: []string{
+				"--batch", "--yes", "--local-user", "mockidentity",
+				"--detach-sign", "--output", "/tmp/mockproj.sig", "/tmp/mockproj",
+			},
+		},
+		"error-codesign": {
+			goos:         "darwin",
+			expectedName: "codesign",
+			expectedArgs: []string{"--force", "--sign", "mockidentity", "/tmp/mockproj"},
+			mockOutput:   []byte("error: The specified item could not be found in the keychain."),
+			mockErr:      errors.New("exit status 1"),
+			expectedErr:  errors.New("exit status 1: error: The specified item could not be found in the keychain."),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+			exec.EXPECT().CombinedOutput(context.Background(), tc.expectedName, tc.expectedArgs).
+				Return(execCombinedOutput).
+				Once()
+
+			execCombinedOutput.EXPECT().CombinedOutput().
+				Return(tc.mockOutput, tc.mockErr).
+				Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			err := toolchain.Sign(context.Background(), tc.goos, "mockidentity", "/tmp/mockproj", "/tmp/mockproj.sig")
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_VerifySignature(t *testing.T) {
+	cases := map[string]struct {
+		goos         string
+		expectedName string
+		expectedArgs []string
+		mockOutput   []byte
+		mockErr      error
+		expectedErr  error
+	}{
+		"success-darwin": {
+			goos:         "darwin",
+			expectedName: "codesign",
+			expectedArgs: []string{"--verify", "--strict", "/tmp/mockproj"},
+		},
+		"success-windows": {
+			goos:         "windows",
+			expectedName: "signtool",
+			expectedArgs: []string{"verify", "/pa", "/tmp/mockproj"},
+			mockOutput:   []byte("Successfully verified: /tmp/mockproj"),
+		},
+		"success-linux": {
+			goos:         "linux",
+			expectedName: "gpg",
+			expectedArgs: []string{"--batch", "--verify", "/tmp/mockproj.sig", "/tmp/mockproj"},
+			mockOutput:   []byte("gpg: Good signature from \"Mock <mock@example.com>\""),
+		},
+		"error-invalid-signature": {
+			goos:         "darwin",
+			expectedName: "codesign",
+			expectedArgs: []string{"--verify", "--strict", "/tmp/mockproj"},
+			mockOutput:   []byte("/tmp/mockproj: invalid signature (code or signature have been modified)"),
+			mockErr:      errors.New("exit status 1"),
+			expectedErr: errors.New(
+				"exit status 1: /tmp/mockproj: invalid signature (code or signature have been modified)",
+			),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := systemmocks.NewExec(t)
+			execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+			exec.EXPECT().CombinedOutput(context.Background(), tc.expectedName, tc.expectedArgs).
+				Return(execCombinedOutput).
+				Once()
+
+			execCombinedOutput.EXPECT().CombinedOutput().
+				Return(tc.mockOutput, tc.mockErr).
+				Once()
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{})
+			err := toolchain.VerifySignature(context.Background(), tc.goos, "/tmp/mockproj", "/tmp/mockproj.sig")
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
 }
 
 func TestGoToolchain_VulnCheck(t *testing.T) {
@@ -547,7 +2259,7 @@ func TestGoToolchain_VulnCheck(t *testing.T) {
 				return execCmd
 			}
 
-			toolchain := toolchain.NewGoToolchain(nil, nil, execCmdFunc)
+			toolchain := toolchain.NewGoToolchain(nil, nil, execCmdFunc, model.NetworkConfig{})
 			vulns, err := toolchain.VulnCheck(context.Background(), tc.path)
 			assert.Equal(t, tc.expectedVulns, vulns)
 			if tc.expectedErr != nil {
@@ -558,3 +2270,374 @@ func TestGoToolchain_VulnCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestGoToolchain_ModuleProxy(t *testing.T) {
+	const modPath = "example.com/mockorg/mockproj"
+
+	goMod := "module " + modPath + "\n"
+	modInfo := `{"Version":"v0.2.0","Origin":{"VCS":"git","URL":"https://github.com/mockorg/mockproj"}}`
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	goListOutput, err := json.Marshal(map[string]string{
+		"GoMod":   filepath.Join(wd, "testdata", "go.mod"),
+		"Version": "v0.1.0",
+	})
+	require.NoError(t, err)
+
+	getLatest := func(ctx context.Context, tc *toolchain.GoToolchain, mod model.Module) (any, error) {
+		return tc.GetLatestModuleVersion(ctx, mod)
+	}
+
+	cases := map[string]struct {
+		module           model.Module
+		proxy            string
+		noProxy          string
+		direct           bool
+		network          model.NetworkConfig
+		responses        []map[string]string
+		failures         []int
+		unauthorized     bool
+		netrc            string
+		mockExecCalls    []mockExecCombinedOutputCall
+		call             func(context.Context, *toolchain.GoToolchain, model.Module) (any, error)
+		expected         any
+		expectedWarnings []string
+		expectedErr      error
+	}{
+		"success-latest": {
+			module: model.NewLatestModule(modPath),
+			proxy:  "%[1]s",
+			responses: []map[string]string{{
+				"/@v/list":       "v0.1.0\nv0.2.0\nv0.3.0-rc.1\n",
+				"/@v/v0.2.0.mod": "module example.com/newmockorg/newmockproj\n",
+			}},
+			call:     getLatest,
+			expected: model.NewModule("example.com/newmockorg/newmockproj", model.NewVersion("v0.2.0")),
+		},
+		"success-latest-prerelease": {
+			module: model.NewLatestModule(modPath),
+			proxy:  "%[1]s",
+			responses: []map[string]string{{
+				"/@v/list":            "v0.1.0-rc.1\nv0.1.0-rc.2\n",
+				"/@v/v0.1.0-rc.2.mod": goMod,
+			}},
+			call:     getLatest,
+			expected: model.NewModule(modPath, model.NewVersion("v0.1.0-rc.2")),
+		},
+		"success-latest-retracted": {
+			module: model.NewLatestModule(modPath),
+			proxy:  "%[1]s",
+			responses: []map[string]string{{
+				"/@v/list":       "v0.1.0\nv0.2.0\n",
+				"/@v/v0.2.0.mod": goMod + "\nretract v0.2.0\n",
+				"/@v/v0.1.0.mod": goMod,
+			}},
+			call:     getLatest,
+			expected: model.NewModule(modPath, model.NewVersion("v0.1.0")),
+		},
+		"success-latest-no-tags": {
+			module: model.NewLatestModule(modPath),
+			proxy:  "%[1]s",
+			responses: []map[string]string{{
+				"/@v/list": "",
+				"/@latest": `{"Version":"v0.0.0-20250101000000-abcdefabcdef"}`,
+				"/@v/v0.0.0-20250101000000-abcdefabcdef.mod": goMod,
+			}},
+			call:     getLatest,
+			expected: model.NewModule(modPath, model.NewVersion("v0.0.0-20250101000000-abcdefabcdef")),
+		},
+		"success-specific-minor-version": {
+			module: model.NewModule(modPath, model.NewVersion("v1.1")),
+			proxy:  "%[1]s",
+			responses: []map[string]string{{
+				"/@v/list":       "v1.0.0\nv1.1.0\nv1.1.1\nv1.2.0\n",
+				"/@v/v1.1.1.mod": goMod,
+			}},
+			call:     getLatest,
+			expected: model.NewModule(modPath, model.NewVersion("v1.1.1")),
+		},
+		"success-next-proxy-not-found": {
+			module:    model.NewLatestModule(modPath),
+			proxy:     "%[1]s,%[2]s",
+			responses: []map[string]string{{}, {"/@v/list": "v0.1.0\n", "/@v/v0.1.0.mod": goMod}},
+			call:      getLatest,
+			expected:  model.NewModule(modPath, model.NewVersion("v0.1.0")),
+		},
+		"success-next-proxy-error": {
+			module:    model.NewLatestModule(modPath),
+			proxy:     "%[1]s|%[2]s",
+			responses: []map[string]string{{}, {"/@v/list": "v0.1.0\n", "/@v/v0.1.0.mod": goMod}},
+			failures:  []int{0},
+			call:      getLatest,
+			expected:  model.NewModule(modPath, model.NewVersion("v0.1.0")),
+			expectedWarnings: []string{
+				"module proxy %[1]s failed to respond, fell back to the next proxy",
+			},
+		},
+		"success-retry": {
+			module:    model.NewLatestModule(modPath),
+			proxy:     "%[1]s",
+			network:   model.NetworkConfig{Retries: 1},
+			responses: []map[string]string{{"/@v/list": "v0.1.0\n", "/@v/v0.1.0.mod": goMod}},
+			failures:  []int{0},
+			call:      getLatest,
+			expected:  model.NewModule(modPath, model.NewVersion("v0.1.0")),
+		},
+		"success-fallback-direct": {
+			module:    model.NewLatestModule(modPath),
+			proxy:     "%[1]s,direct",
+			responses: []map[string]string{{}},
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-json", modPath + "@latest"},
+					output: goListOutput,
+				},
+			},
+			call:     getLatest,
+			expected: model.NewModule(modPath, model.NewVersion("v0.1.0")),
+		},
+		"success-fallback-no-proxy": {
+			module:  model.NewLatestModule(modPath),
+			proxy:   "%[1]s",
+			noProxy: "example.com/mockorg",
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-json", modPath + "@latest"},
+					output: goListOutput,
+				},
+			},
+			call:     getLatest,
+			expected: model.NewModule(modPath, model.NewVersion("v0.1.0")),
+		},
+		"success-fallback-authentication": {
+			module:       model.NewLatestModule(modPath),
+			proxy:        "%[1]s",
+			responses:    []map[string]string{{}},
+			unauthorized: true,
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-json", modPath + "@latest"},
+					output: goListOutput,
+				},
+			},
+			call:     getLatest,
+			expected: model.NewModule(modPath, model.NewVersion("v0.1.0")),
+		},
+		"success-authentication": {
+			module:       model.NewLatestModule(modPath),
+			proxy:        "%[1]s",
+			responses:    []map[string]string{{"/@v/list": "v0.1.0\n", "/@v/v0.1.0.mod": goMod}},
+			unauthorized: true,
+			netrc:        "machine 127.0.0.1 login user password secret\n",
+			call:         getLatest,
+			expected:     model.NewModule(modPath, model.NewVersion("v0.1.0")),
+		},
+		"success-fallback-rejected-credentials": {
+			module:       model.NewLatestModule(modPath),
+			proxy:        "%[1]s",
+			responses:    []map[string]string{{}},
+			unauthorized: true,
+			netrc:        "machine 127.0.0.1 login user password wrong\n",
+			mockExecCalls: []mockExecCombinedOutputCall{
+				{
+					args:   []string{"list", "-m", "-json", modPath + "@latest"},
+					output: goListOutput,
+				},
+			},
+			call:     getLatest,
+			expected: model.NewModule(modPath, model.NewVersion("v0.1.0")),
+		},
+		"success-module-file": {
+			module:    model.NewModule(modPath, model.NewVersion("v0.2.0")),
+			proxy:     "%[1]s",
+			responses: []map[string]string{{"/@v/v0.2.0.mod": goMod + "\ngo 1.24\n"}},
+			call: func(ctx context.Context, tc *toolchain.GoToolchain, mod model.Module) (any, error) {
+				modFile, err := tc.GetModuleFile(ctx, mod)
+				if err != nil {
+					return nil, err
+				}
+				return modFile.Go.Version, nil
+			},
+			expected: "1.24",
+		},
+		"success-module-origin": {
+			module:    model.NewLatestModule(modPath),
+			proxy:     "%[1]s",
+			responses: []map[string]string{{"/@v/list": "v0.2.0\n", "/@v/v0.2.0.mod": goMod, "/@v/v0.2.0.info": modInfo}},
+			call: func(ctx context.Context, tc *toolchain.GoToolchain, mod model.Module) (any, error) {
+				return tc.GetModuleOrigin(ctx, mod)
+			},
+			expected: &model.ModuleOrigin{VCS: "git", URL: "https://github.com/mockorg/mockproj"},
+		},
+		"error-module-origin-not-available": {
+			module:    model.NewModule(modPath, model.NewVersion("v0.2.0")),
+			proxy:     "%[1]s",
+			responses: []map[string]string{{"/@v/v0.2.0.info": `{"Version":"v0.2.0"}`}},
+			call: func(ctx context.Context, tc *toolchain.GoToolchain, mod model.Module) (any, error) {
+				return tc.GetModuleOrigin(ctx, mod)
+			},
+			expected:    (*model.ModuleOrigin)(nil),
+			expectedErr: toolchain.ErrModuleOriginNotAvailable,
+		},
+		"error-module-not-found": {
+			module:      model.NewLatestModule(modPath),
+			proxy:       "%[1]s,%[2]s",
+			responses:   []map[string]string{{}, {}},
+			call:        getLatest,
+			expected:    model.Module{},
+			expectedErr: toolchain.ErrModuleNotFound,
+		},
+		"error-version-not-found": {
+			module:      model.NewModule(modPath, model.NewVersion("v2")),
+			proxy:       "%[1]s",
+			responses:   []map[string]string{{"/@v/list": "v0.1.0\n"}},
+			call:        getLatest,
+			expected:    model.Module{},
+			expectedErr: toolchain.ErrModuleNotFound,
+		},
+		"error-proxy-unavailable": {
+			module:    model.NewLatestModule(modPath),
+			proxy:     "%[1]s,%[2]s",
+			responses: []map[string]string{{}, {"/@v/list": "v0.1.0\n"}},
+			failures:  []int{0},
+			call:      getLatest,
+			expected:  model.Module{},
+			expectedWarnings: []string{
+				"module proxy %[1]s failed to respond, fell back to the next proxy",
+			},
+			expectedErr: errors.New("network unavailable: unexpected status: 502 Bad Gateway"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			urls := make([]any, 0, len(tc.responses))
+			for i, responses := range tc.responses {
+				var requests atomic.Int32
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if login, password, _ := r.BasicAuth(); tc.unauthorized &&
+						(login != "user" || password != "secret") {
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+
+					if slices.Contains(tc.failures, i) && requests.Add(1) == 1 {
+						w.WriteHeader(http.StatusBadGateway)
+						return
+					}
+
+					body, ok := responses[strings.TrimPrefix(r.URL.Path, "/"+modPath)]
+					if !ok {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+
+					_, _ = w.Write([]byte(body))
+				}))
+				defer server.Close()
+
+				urls = append(urls, server.URL)
+			}
+
+			warnings := internal.NewWarnings()
+			ctx := internal.WithWarnings(context.Background(), warnings)
+			exec := systemmocks.NewExec(t)
+			mockGoEnvProxy(t, exec, fmt.Sprintf(tc.proxy, urls...), tc.noProxy, "netrc")
+			writeNetrc(t, tc.netrc)
+
+			for _, call := range tc.mockExecCalls {
+				execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+
+				exec.EXPECT().CombinedOutput(ctx, "go", call.args).
+					Return(execCombinedOutput).
+					Once()
+
+				execCombinedOutput.EXPECT().CombinedOutput().
+					Return(call.output, call.err).
+					Once()
+			}
+
+			var expectedWarnings []string
+			for _, warning := range tc.expectedWarnings {
+				expectedWarnings = append(expectedWarnings, fmt.Sprintf(warning, urls...))
+			}
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, tc.network)
+			result, err := tc.call(ctx, toolchain, tc.module)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, expectedWarnings, warnings.Get())
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGoToolchain_NetworkConcurrency(t *testing.T) {
+	cases := map[string]struct {
+		concurrency int
+		calls       int
+		expectedMax int32
+	}{
+		"limited": {
+			concurrency: 2,
+			calls:       6,
+			expectedMax: 2,
+		},
+		"single": {
+			concurrency: 1,
+			calls:       3,
+			expectedMax: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var active, maxActive atomic.Int32
+
+			exec := systemmocks.NewExec(t)
+			args := []string{"list", "-m", "-versions", "-json", "mockproj"}
+			exec.EXPECT().CombinedOutput(context.Background(), "go", args).
+				RunAndReturn(func(context.Context, string, ...string) system.ExecCombinedOutput {
+					execCombinedOutput := systemmocks.NewExecCombinedOutput(t)
+					execCombinedOutput.EXPECT().CombinedOutput().
+						RunAndReturn(func() ([]byte, error) {
+							current := active.Add(1)
+							defer active.Add(-1)
+
+							for {
+								prev := maxActive.Load()
+								if current <= prev || maxActive.CompareAndSwap(prev, current) {
+									break
+								}
+							}
+
+							time.Sleep(10 * time.Millisecond)
+							return []byte(`{"Path":"mockproj","Versions":["v0.1.0"]}`), nil
+						}).
+						Once()
+
+					return execCombinedOutput
+				}).
+				Times(tc.calls)
+
+			toolchain := toolchain.NewGoToolchain(nil, exec, nil, model.NetworkConfig{Concurrency: tc.concurrency})
+
+			var wg sync.WaitGroup
+			for range tc.calls {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := toolchain.GetModuleVersions(context.Background(), "mockproj")
+					assert.NoError(t, err)
+				}()
+			}
+			wg.Wait()
+
+			assert.LessOrEqual(t, maxActive.Load(), tc.expectedMax)
+		})
+	}
+}
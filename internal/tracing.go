@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"context"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TracingEndpointEnvVar is the environment variable to define the OTLP
+	// endpoint the spans are exported to. Tracing is disabled if it is not set.
+	TracingEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// tracerName is the name of the tracer creating the spans.
+	tracerName = "github.com/brunoribeiro127/gobin"
+	// serviceName is the name of the service in the exported spans.
+	serviceName = "gobin"
+)
+
+// SetupTracing sets up a global tracer provider exporting the spans in batches
+// over OTLP/HTTP, configured by the standard OTEL_EXPORTER_OTLP_* environment
+// variables. It returns a function flushing the pending spans and shutting down
+// the tracer provider, or an error if the exporter cannot be created.
+func SetupTracing(ctx context.Context) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceName(serviceName)}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		attrs = append(attrs, semconv.ServiceVersion(info.Main.Version))
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span with the given name and attributes as a child of the
+// span in the context, if any. It returns a copy of the context carrying the
+// span, to be ended by the caller. Spans are not recorded unless tracing is set
+// up with SetupTracing, in which case the context is returned unchanged.
+func StartSpan(
+	ctx context.Context,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	spanCtx, span := otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+	if !span.IsRecording() {
+		return ctx, span
+	}
+
+	return spanCtx, span
+}
+
+// RecordSpanError records the given error in the span and sets its status to
+// error, if the error is not nil. It returns the given error to be used in
+// return statements.
+func RecordSpanError(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
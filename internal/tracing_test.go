@@ -0,0 +1,74 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/brunoribeiro127/gobin/internal"
+)
+
+func TestStartSpan(t *testing.T) {
+	cases := map[string]struct {
+		tracing          bool
+		err              error
+		expectedSpans    int
+		expectedStatus   codes.Code
+		expectedCtxEqual bool
+	}{
+		"not-recording": {
+			expectedCtxEqual: true,
+		},
+		"success": {
+			tracing:        true,
+			expectedSpans:  1,
+			expectedStatus: codes.Unset,
+		},
+		"error": {
+			tracing:        true,
+			err:            errors.New("unexpected error"),
+			expectedSpans:  1,
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			if tc.tracing {
+				otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+				t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+			}
+
+			ctx := context.Background()
+			spanCtx, span := internal.StartSpan(ctx, "op", attribute.String("gobin.binary", "mockproj"))
+			err := internal.RecordSpanError(span, tc.err)
+			span.End()
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expectedCtxEqual, spanCtx == ctx)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, tc.expectedSpans)
+
+			if tc.expectedSpans > 0 {
+				assert.Equal(t, "op", spans[0].Name())
+				assert.Equal(t, tc.expectedStatus, spans[0].Status().Code)
+				assert.Equal(
+					t, []attribute.KeyValue{attribute.String("gobin.binary", "mockproj")}, spans[0].Attributes(),
+				)
+				assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(spanCtx))
+			}
+		})
+	}
+}
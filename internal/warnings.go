@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Warnings collects the warnings raised during an operation, ex. a module
+// proxy fallback, which do not fail the operation. It is safe for concurrent
+// use.
+type Warnings struct {
+	mutex    sync.Mutex
+	warnings []string
+}
+
+// NewWarnings creates a new empty Warnings.
+func NewWarnings() *Warnings {
+	return &Warnings{}
+}
+
+// Add adds the given warning, unless it was already added.
+func (w *Warnings) Add(warning string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if !slices.Contains(w.warnings, warning) {
+		w.warnings = append(w.warnings, warning)
+	}
+}
+
+// Get returns the warnings sorted alphabetically.
+func (w *Warnings) Get() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return slices.Sorted(slices.Values(w.warnings))
+}
+
+// warningsKey is the context key of the warnings raised during an operation.
+type warningsKey struct{}
+
+// WithWarnings returns a copy of the context carrying the given warnings, which
+// collect the warnings raised with the context.
+func WithWarnings(ctx context.Context, warnings *Warnings) context.Context {
+	return context.WithValue(ctx, warningsKey{}, warnings)
+}
+
+// AddWarning adds the given warning to the warnings carried by the context, if
+// any.
+func AddWarning(ctx context.Context, warning string) {
+	if warnings, ok := ctx.Value(warningsKey{}).(*Warnings); ok {
+		warnings.Add(warning)
+	}
+}
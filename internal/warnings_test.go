@@ -0,0 +1,49 @@
+package internal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brunoribeiro127/gobin/internal"
+)
+
+func TestAddWarning(t *testing.T) {
+	cases := map[string]struct {
+		withWarnings     bool
+		warnings         []string
+		expectedWarnings []string
+	}{
+		"sorted-warnings": {
+			withWarnings:     true,
+			warnings:         []string{"mock warning 2", "mock warning 1"},
+			expectedWarnings: []string{"mock warning 1", "mock warning 2"},
+		},
+		"duplicated-warnings": {
+			withWarnings:     true,
+			warnings:         []string{"mock warning", "mock warning"},
+			expectedWarnings: []string{"mock warning"},
+		},
+		"without-warnings": {
+			warnings: []string{"mock warning"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			warnings := internal.NewWarnings()
+
+			ctx := context.Background()
+			if tc.withWarnings {
+				ctx = internal.WithWarnings(ctx, warnings)
+			}
+
+			for _, warning := range tc.warnings {
+				internal.AddWarning(ctx, warning)
+			}
+
+			assert.Equal(t, tc.expectedWarnings, warnings.Get())
+		})
+	}
+}